@@ -0,0 +1,4 @@
+ALTER TABLE accounts
+    DROP CONSTRAINT accounts_balance_non_negative;
+
+DROP TABLE ledger_entries;
@@ -0,0 +1,34 @@
+CREATE TABLE ledger_entries
+(
+    id          VARCHAR(36)              NOT NULL PRIMARY KEY DEFAULT uuid(),
+    account_id  VARCHAR(36)              NOT NULL REFERENCES accounts (id),
+    transfer_id VARCHAR(36)              NULL REFERENCES transfers (id),
+    type        VARCHAR(6)               NOT NULL,
+    amount      BIGINT                   NOT NULL,
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL             DEFAULT CURRENT_TIMESTAMP,
+
+    CHECK ( type IN ('debit', 'credit') ),
+    CHECK ( amount > 0 )
+);
+
+CREATE INDEX ledger_entries_account_id_idx ON ledger_entries (account_id);
+CREATE INDEX ledger_entries_transfer_id_idx ON ledger_entries (transfer_id);
+
+INSERT INTO ledger_entries(account_id, transfer_id, type, amount, created_at)
+SELECT origin_account_id, id, 'debit', amount, created_at
+FROM transfers;
+
+INSERT INTO ledger_entries(account_id, transfer_id, type, amount, created_at)
+SELECT target_account_id, id, 'credit', amount, created_at
+FROM transfers;
+
+-- opening balances: whatever the account holds that is not explained by its transfers
+INSERT INTO ledger_entries(account_id, type, amount, created_at)
+SELECT a.id, 'credit', a.balance - COALESCE(SUM(CASE WHEN l.type = 'credit' THEN l.amount ELSE -l.amount END), 0), a.created_at
+FROM accounts a
+         LEFT JOIN ledger_entries l ON l.account_id = a.id
+GROUP BY a.id, a.balance, a.created_at
+HAVING a.balance - COALESCE(SUM(CASE WHEN l.type = 'credit' THEN l.amount ELSE -l.amount END), 0) > 0;
+
+ALTER TABLE accounts
+    ADD CONSTRAINT accounts_balance_non_negative CHECK ( balance >= 0 );
@@ -0,0 +1,17 @@
+CREATE TABLE scheduled_transfers
+(
+    id                VARCHAR(36)              NOT NULL PRIMARY KEY DEFAULT uuid(),
+    origin_account_id VARCHAR(36)              NOT NULL REFERENCES accounts (id),
+    target_account_id VARCHAR(36)              NOT NULL REFERENCES accounts (id),
+    amount            BIGINT                   NOT NULL,
+    scheduled_for     TIMESTAMP WITH TIME ZONE NOT NULL,
+    status            VARCHAR(30)              NOT NULL             DEFAULT 'pending',
+    transfer_id       VARCHAR(36)              NULL REFERENCES transfers (id),
+    created_at        TIMESTAMP WITH TIME ZONE NOT NULL             DEFAULT CURRENT_TIMESTAMP,
+
+    CHECK ( amount > 0 ),
+    CHECK ( status IN ('pending', 'processing', 'executed', 'failed-insufficient-funds', 'cancelled') )
+);
+
+CREATE INDEX scheduled_transfers_origin_account_id_idx ON scheduled_transfers (origin_account_id);
+CREATE INDEX scheduled_transfers_due_idx ON scheduled_transfers (scheduled_for) WHERE status = 'pending';
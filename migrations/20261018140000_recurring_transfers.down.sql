@@ -0,0 +1,2 @@
+DROP TABLE recurring_transfer_runs;
+DROP TABLE recurring_transfers;
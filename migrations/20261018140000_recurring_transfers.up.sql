@@ -0,0 +1,44 @@
+CREATE TABLE recurring_transfers
+(
+    id                        VARCHAR(36)              NOT NULL PRIMARY KEY DEFAULT uuid(),
+    origin_account_id         VARCHAR(36)              NOT NULL REFERENCES accounts (id),
+    target_account_id         VARCHAR(36)              NOT NULL REFERENCES accounts (id),
+    amount                    BIGINT                   NOT NULL,
+    frequency                 VARCHAR(10)              NOT NULL,
+    day_of_month              SMALLINT                 NULL,
+    start_at                  TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_at                    TIMESTAMP WITH TIME ZONE NULL,
+    max_occurrences           INTEGER                  NULL,
+    insufficient_funds_policy VARCHAR(10)              NOT NULL,
+    max_retries               INTEGER                  NOT NULL             DEFAULT 0,
+    status                    VARCHAR(20)              NOT NULL             DEFAULT 'active',
+    occurrences               INTEGER                  NOT NULL             DEFAULT 0,
+    retries                   INTEGER                  NOT NULL             DEFAULT 0,
+    next_occurrence_at        TIMESTAMP WITH TIME ZONE NOT NULL,
+    next_attempt_at           TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at                TIMESTAMP WITH TIME ZONE NOT NULL             DEFAULT CURRENT_TIMESTAMP,
+
+    CHECK ( amount > 0 ),
+    CHECK ( frequency IN ('daily', 'weekly', 'monthly') ),
+    CHECK ( day_of_month BETWEEN 1 AND 31 ),
+    CHECK ( insufficient_funds_policy IN ('skip', 'retry') ),
+    CHECK ( max_retries >= 0 ),
+    CHECK ( status IN ('active', 'processing', 'finished', 'cancelled') )
+);
+
+CREATE INDEX recurring_transfers_origin_account_id_idx ON recurring_transfers (origin_account_id);
+CREATE INDEX recurring_transfers_due_idx ON recurring_transfers (next_attempt_at) WHERE status = 'active';
+
+CREATE TABLE recurring_transfer_runs
+(
+    id                    VARCHAR(36)              NOT NULL PRIMARY KEY DEFAULT uuid(),
+    recurring_transfer_id VARCHAR(36)              NOT NULL REFERENCES recurring_transfers (id),
+    occurrence_at         TIMESTAMP WITH TIME ZONE NOT NULL,
+    status                VARCHAR(30)              NOT NULL,
+    transfer_id           VARCHAR(36)              NULL REFERENCES transfers (id),
+    created_at            TIMESTAMP WITH TIME ZONE NOT NULL             DEFAULT CURRENT_TIMESTAMP,
+
+    CHECK ( status IN ('executed', 'failed-insufficient-funds', 'skipped', 'failed') )
+);
+
+CREATE INDEX recurring_transfer_runs_recurring_transfer_id_idx ON recurring_transfer_runs (recurring_transfer_id);
@@ -0,0 +1,4 @@
+ALTER TABLE transfers
+    ADD COLUMN reversed_transfer_id VARCHAR(36) NULL REFERENCES transfers (id);
+
+CREATE INDEX transfers_reversed_transfer_id_idx ON transfers (reversed_transfer_id);
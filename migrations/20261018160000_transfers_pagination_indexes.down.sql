@@ -0,0 +1,2 @@
+DROP INDEX transfers_origin_account_id_created_at_idx;
+DROP INDEX transfers_target_account_id_created_at_idx;
@@ -0,0 +1,2 @@
+CREATE INDEX transfers_origin_account_id_created_at_idx ON transfers (origin_account_id, created_at DESC, id DESC);
+CREATE INDEX transfers_target_account_id_created_at_idx ON transfers (target_account_id, created_at DESC, id DESC);
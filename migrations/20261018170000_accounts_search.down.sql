@@ -0,0 +1,3 @@
+DROP INDEX accounts_lower_name_idx;
+DROP INDEX accounts_name_idx;
+DROP INDEX accounts_created_at_idx;
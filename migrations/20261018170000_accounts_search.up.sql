@@ -0,0 +1,3 @@
+CREATE INDEX accounts_created_at_idx ON accounts (created_at DESC, id DESC);
+CREATE INDEX accounts_name_idx ON accounts (name, id);
+CREATE INDEX accounts_lower_name_idx ON accounts (lower(name) text_pattern_ops);
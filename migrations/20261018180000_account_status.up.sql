@@ -0,0 +1,3 @@
+ALTER TABLE accounts
+    ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'active',
+    ADD CONSTRAINT accounts_status_check CHECK ( status IN ('active', 'frozen', 'closed') );
@@ -0,0 +1,14 @@
+CREATE TABLE audit_logs
+(
+    id         VARCHAR(36)              NOT NULL PRIMARY KEY DEFAULT uuid(),
+    action     VARCHAR(64)              NOT NULL,
+    account_id VARCHAR(36)              NULL REFERENCES accounts (id),
+    document   TEXT                     NOT NULL DEFAULT '',
+    ip         TEXT                     NOT NULL DEFAULT '',
+    reason     VARCHAR(64)              NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL             DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX audit_logs_account_id_created_at_idx ON audit_logs (account_id, created_at);
+CREATE INDEX audit_logs_document_created_at_idx ON audit_logs (document, created_at);
+CREATE INDEX audit_logs_ip_created_at_idx ON audit_logs (ip, created_at);
@@ -0,0 +1,2 @@
+DROP TABLE two_factor_recovery_codes;
+DROP TABLE two_factors;
@@ -0,0 +1,18 @@
+CREATE TABLE two_factors
+(
+    account_id   VARCHAR(36)              NOT NULL PRIMARY KEY REFERENCES accounts (id),
+    secret       TEXT                     NOT NULL,
+    confirmed_at TIMESTAMP WITH TIME ZONE NULL,
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE two_factor_recovery_codes
+(
+    id         VARCHAR(36)              NOT NULL PRIMARY KEY DEFAULT uuid(),
+    account_id VARCHAR(36)              NOT NULL REFERENCES two_factors (account_id) ON DELETE CASCADE,
+    code_hash  TEXT                     NOT NULL,
+    used_at    TIMESTAMP WITH TIME ZONE NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX two_factor_recovery_codes_account_id_code_hash_idx ON two_factor_recovery_codes (account_id, code_hash);
@@ -0,0 +1,3 @@
+ALTER TABLE accounts
+    ADD COLUMN role VARCHAR(16) NOT NULL DEFAULT 'customer',
+    ADD CONSTRAINT accounts_role_check CHECK ( role IN ('customer', 'support', 'admin') );
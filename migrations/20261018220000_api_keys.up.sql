@@ -0,0 +1,16 @@
+CREATE TABLE api_keys
+(
+    id           VARCHAR(36)              NOT NULL PRIMARY KEY DEFAULT uuid(),
+    account_id   VARCHAR(36)              NOT NULL REFERENCES accounts (id),
+    name         VARCHAR(100)             NOT NULL,
+    prefix       VARCHAR(32)              NOT NULL,
+    key_hash     TEXT                     NOT NULL,
+    scopes       TEXT[]                   NOT NULL,
+    expires_at   TIMESTAMP WITH TIME ZONE NULL,
+    last_used_at TIMESTAMP WITH TIME ZONE NULL,
+    revoked_at   TIMESTAMP WITH TIME ZONE NULL,
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX api_keys_prefix_idx ON api_keys (prefix);
+CREATE INDEX api_keys_account_id_idx ON api_keys (account_id, created_at DESC);
@@ -0,0 +1,8 @@
+ALTER TABLE transfers
+    DROP COLUMN source_currency,
+    DROP COLUMN target_currency,
+    DROP COLUMN target_amount,
+    DROP COLUMN exchange_rate;
+
+ALTER TABLE accounts
+    DROP COLUMN currency;
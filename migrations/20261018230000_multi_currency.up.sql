@@ -0,0 +1,19 @@
+ALTER TABLE accounts
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'BRL';
+
+ALTER TABLE transfers
+    ADD COLUMN source_currency CHAR(3)        NOT NULL DEFAULT 'BRL',
+    ADD COLUMN target_currency CHAR(3)        NOT NULL DEFAULT 'BRL',
+    ADD COLUMN target_amount   BIGINT         NULL,
+    ADD COLUMN exchange_rate   NUMERIC(24, 12) NOT NULL DEFAULT 1;
+
+UPDATE transfers
+SET target_amount = amount;
+
+ALTER TABLE transfers
+    ALTER COLUMN source_currency DROP DEFAULT,
+    ALTER COLUMN target_currency DROP DEFAULT,
+    ALTER COLUMN target_amount SET NOT NULL,
+    ALTER COLUMN exchange_rate DROP DEFAULT,
+    ADD CONSTRAINT transfers_target_amount_check CHECK ( target_amount > 0 ),
+    ADD CONSTRAINT transfers_exchange_rate_check CHECK ( exchange_rate > 0 );
@@ -0,0 +1,4 @@
+DROP TABLE transfer_fees;
+
+ALTER TABLE transfers
+    DROP COLUMN fee;
@@ -0,0 +1,16 @@
+ALTER TABLE transfers
+    ADD COLUMN fee BIGINT NOT NULL DEFAULT 0,
+    ADD CONSTRAINT transfers_fee_check CHECK ( fee >= 0 );
+
+CREATE TABLE transfer_fees
+(
+    id          VARCHAR(36)              NOT NULL PRIMARY KEY DEFAULT uuid(),
+    transfer_id VARCHAR(36)              NOT NULL REFERENCES transfers (id),
+    rule        VARCHAR(100)             NOT NULL,
+    amount      BIGINT                   NOT NULL,
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CHECK ( amount > 0 )
+);
+
+CREATE INDEX transfer_fees_transfer_id_idx ON transfer_fees (transfer_id);
@@ -0,0 +1,13 @@
+CREATE TABLE account_limits
+(
+    account_id   VARCHAR(36)              NOT NULL PRIMARY KEY REFERENCES accounts (id),
+    tier         VARCHAR(20)              NOT NULL DEFAULT 'standard',
+    per_transfer BIGINT                   NULL,
+    daily        BIGINT                   NULL,
+    monthly      BIGINT                   NULL,
+    daily_count  BIGINT                   NULL,
+    updated_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CHECK ( tier IN ('standard', 'premium', 'business') ),
+    CHECK ( per_transfer >= 0 AND daily >= 0 AND monthly >= 0 AND daily_count >= 0 )
+);
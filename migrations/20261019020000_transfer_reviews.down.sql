@@ -0,0 +1,4 @@
+DROP TABLE transfer_reviews;
+
+ALTER TABLE accounts
+    DROP COLUMN reserved;
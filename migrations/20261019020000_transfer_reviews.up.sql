@@ -0,0 +1,28 @@
+ALTER TABLE accounts
+    ADD COLUMN reserved BIGINT NOT NULL DEFAULT 0,
+    ADD CONSTRAINT accounts_reserved_check CHECK ( reserved >= 0 );
+
+CREATE TABLE transfer_reviews
+(
+    id                VARCHAR(36)              NOT NULL PRIMARY KEY DEFAULT uuid(),
+    origin_account_id VARCHAR(36)              NOT NULL REFERENCES accounts (id),
+    target_account_id VARCHAR(36)              NOT NULL REFERENCES accounts (id),
+    amount            BIGINT                   NOT NULL,
+    source_currency   CHAR(3)                  NOT NULL,
+    target_amount     BIGINT                   NOT NULL,
+    target_currency   CHAR(3)                  NOT NULL,
+    exchange_rate     NUMERIC(24, 12)          NOT NULL,
+    reserved          BIGINT                   NOT NULL,
+    score             INT                      NOT NULL,
+    reasons           TEXT[]                   NOT NULL,
+    status            VARCHAR(20)              NOT NULL DEFAULT 'pending',
+    transfer_id       VARCHAR(36)              NULL REFERENCES transfers (id),
+    decided_by        VARCHAR(36)              NULL REFERENCES accounts (id),
+    decided_at        TIMESTAMP WITH TIME ZONE NULL,
+    created_at        TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CHECK ( amount > 0 AND target_amount > 0 AND reserved >= 0 ),
+    CHECK ( status IN ('pending', 'approved', 'rejected') )
+);
+
+CREATE INDEX transfer_reviews_pending_idx ON transfer_reviews (created_at) WHERE status = 'pending';
@@ -0,0 +1,23 @@
+CREATE TABLE holds
+(
+    id                VARCHAR(36)              NOT NULL PRIMARY KEY DEFAULT uuid(),
+    origin_account_id VARCHAR(36)              NOT NULL REFERENCES accounts (id),
+    target_account_id VARCHAR(36)              NOT NULL REFERENCES accounts (id),
+    amount            BIGINT                   NOT NULL,
+    currency          CHAR(3)                  NOT NULL,
+    reserved          BIGINT                   NOT NULL,
+    captured_amount   BIGINT                   NOT NULL DEFAULT 0,
+    status            VARCHAR(20)              NOT NULL DEFAULT 'active',
+    transfer_id       VARCHAR(36)              NULL REFERENCES transfers (id),
+    expires_at        TIMESTAMP WITH TIME ZONE NOT NULL,
+    closed_at         TIMESTAMP WITH TIME ZONE NULL,
+    created_at        TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CHECK ( amount > 0 AND reserved >= amount ),
+    CHECK ( captured_amount >= 0 AND captured_amount <= amount ),
+    CHECK ( status IN ('active', 'captured', 'released', 'expired') )
+);
+
+CREATE INDEX holds_origin_account_id_idx ON holds (origin_account_id);
+CREATE INDEX holds_target_account_id_idx ON holds (target_account_id);
+CREATE INDEX holds_expiry_idx ON holds (expires_at) WHERE status = 'active';
@@ -0,0 +1,10 @@
+UPDATE scheduled_transfers
+SET status = 'cancelled'
+WHERE status = 'failed';
+
+ALTER TABLE scheduled_transfers
+    DROP CONSTRAINT scheduled_transfers_status_check,
+    ADD CONSTRAINT scheduled_transfers_status_check
+        CHECK ( status IN ('pending', 'processing', 'executed', 'failed-insufficient-funds', 'cancelled') ),
+    DROP COLUMN failure_reason,
+    DROP COLUMN attempts;
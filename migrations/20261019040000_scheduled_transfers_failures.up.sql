@@ -0,0 +1,6 @@
+ALTER TABLE scheduled_transfers
+    ADD COLUMN attempts       INTEGER     NOT NULL DEFAULT 0,
+    ADD COLUMN failure_reason TEXT        NULL,
+    DROP CONSTRAINT scheduled_transfers_status_check,
+    ADD CONSTRAINT scheduled_transfers_status_check
+        CHECK ( status IN ('pending', 'processing', 'executed', 'failed-insufficient-funds', 'failed', 'cancelled') );
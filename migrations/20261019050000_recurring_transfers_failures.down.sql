@@ -0,0 +1,13 @@
+ALTER TABLE recurring_transfer_runs
+    DROP COLUMN failure_reason;
+
+UPDATE recurring_transfers
+SET status = 'cancelled'
+WHERE status = 'failed';
+
+ALTER TABLE recurring_transfers
+    DROP CONSTRAINT recurring_transfers_status_check,
+    ADD CONSTRAINT recurring_transfers_status_check
+        CHECK ( status IN ('active', 'processing', 'finished', 'cancelled') ),
+    DROP COLUMN failure_reason,
+    DROP COLUMN attempts;
@@ -0,0 +1,9 @@
+ALTER TABLE recurring_transfers
+    ADD COLUMN attempts       INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN failure_reason TEXT    NULL,
+    DROP CONSTRAINT recurring_transfers_status_check,
+    ADD CONSTRAINT recurring_transfers_status_check
+        CHECK ( status IN ('active', 'processing', 'finished', 'failed', 'cancelled') );
+
+ALTER TABLE recurring_transfer_runs
+    ADD COLUMN failure_reason TEXT NULL;
@@ -0,0 +1,4 @@
+ALTER TABLE scheduled_transfers
+    ADD COLUMN claimed_at TIMESTAMP WITH TIME ZONE NULL;
+
+CREATE INDEX scheduled_transfers_claimed_at_idx ON scheduled_transfers (claimed_at) WHERE status = 'processing';
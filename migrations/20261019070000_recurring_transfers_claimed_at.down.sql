@@ -0,0 +1,2 @@
+ALTER TABLE recurring_transfers
+    DROP COLUMN claimed_at;
@@ -0,0 +1,4 @@
+ALTER TABLE recurring_transfers
+    ADD COLUMN claimed_at TIMESTAMP WITH TIME ZONE NULL;
+
+CREATE INDEX recurring_transfers_claimed_at_idx ON recurring_transfers (claimed_at) WHERE status = 'processing';
@@ -0,0 +1,12 @@
+DELETE
+FROM ledger_entries
+WHERE ledger_account IS NOT NULL;
+
+DROP INDEX ledger_entries_ledger_account_idx;
+
+ALTER TABLE ledger_entries
+    DROP CONSTRAINT ledger_entries_ledger_account_currency,
+    DROP CONSTRAINT ledger_entries_single_owner,
+    DROP COLUMN currency,
+    DROP COLUMN ledger_account,
+    ALTER COLUMN account_id SET NOT NULL;
@@ -0,0 +1,16 @@
+ALTER TABLE ledger_entries
+    ALTER COLUMN account_id DROP NOT NULL,
+    ADD COLUMN ledger_account VARCHAR(32) NULL,
+    ADD COLUMN currency       CHAR(3)     NULL,
+    ADD CONSTRAINT ledger_entries_single_owner CHECK ( (account_id IS NULL) <> (ledger_account IS NULL) ),
+    ADD CONSTRAINT ledger_entries_ledger_account_currency CHECK ( ledger_account IS NULL OR currency IS NOT NULL );
+
+CREATE INDEX ledger_entries_ledger_account_idx ON ledger_entries (ledger_account) WHERE ledger_account IS NOT NULL;
+
+-- opening balances: every credit not tied to a transfer is funded by the opening-balances internal account
+INSERT INTO ledger_entries(ledger_account, currency, type, amount, created_at)
+SELECT 'opening-balances', a.currency, 'debit', l.amount, l.created_at
+FROM ledger_entries l
+         JOIN accounts a ON a.id = l.account_id
+WHERE l.transfer_id IS NULL
+  AND l.type = 'credit';
@@ -0,0 +1,4 @@
+ALTER TABLE holds
+    DROP COLUMN exchange_rate,
+    DROP COLUMN target_currency,
+    DROP COLUMN target_amount;
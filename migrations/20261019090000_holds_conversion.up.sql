@@ -0,0 +1,16 @@
+ALTER TABLE holds
+    ADD COLUMN target_amount   BIGINT          NULL,
+    ADD COLUMN target_currency CHAR(3)         NULL,
+    ADD COLUMN exchange_rate   NUMERIC(24, 12) NULL,
+    ADD CONSTRAINT holds_target_amount_check CHECK ( target_amount > 0 ),
+    ADD CONSTRAINT holds_exchange_rate_check CHECK ( exchange_rate > 0 );
+
+-- holds between accounts of the same currency keep their amount; the ones across currencies were authorized without a
+-- quote and are converted at the rate of the capture
+UPDATE holds h
+SET target_amount   = h.amount,
+    target_currency = h.currency,
+    exchange_rate   = 1
+FROM accounts a
+WHERE a.id = h.target_account_id
+  AND a.currency = h.currency;
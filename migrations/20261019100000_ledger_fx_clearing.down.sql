@@ -0,0 +1,3 @@
+DELETE
+FROM ledger_entries
+WHERE ledger_account = 'fx-clearing';
@@ -0,0 +1,10 @@
+-- cross-currency transfers: the fx-clearing internal account receives the amount in the origin currency and pays the
+-- converted amount in the target currency, so the entries of each currency net to zero
+INSERT INTO ledger_entries(ledger_account, currency, transfer_id, type, amount, created_at)
+SELECT 'fx-clearing', t.source_currency, t.id, 'credit', t.amount, t.created_at
+FROM transfers t
+WHERE t.source_currency <> t.target_currency
+UNION ALL
+SELECT 'fx-clearing', t.target_currency, t.id, 'debit', t.target_amount, t.created_at
+FROM transfers t
+WHERE t.source_currency <> t.target_currency;
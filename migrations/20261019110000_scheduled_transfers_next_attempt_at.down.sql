@@ -0,0 +1,2 @@
+ALTER TABLE scheduled_transfers
+    DROP COLUMN next_attempt_at;
@@ -0,0 +1,2 @@
+ALTER TABLE scheduled_transfers
+    ADD COLUMN next_attempt_at TIMESTAMP WITH TIME ZONE NULL;
@@ -1,6 +1,7 @@
 package error
 
 import (
+	"errors"
 	"fmt"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/validator"
 	"net/http"
@@ -11,8 +12,16 @@ var (
 	ErrAccessDenied   = NewApiError(http.StatusForbidden, "api.access-denied", nil)
 	ErrInternal       = NewApiError(http.StatusInternalServerError, "api.unknown", nil)
 	ErrInvalidPayload = NewApiError(http.StatusBadRequest, "api.invalid_payload", nil)
+	ErrInvalidCursor  = NewApiError(http.StatusBadRequest, "api.invalid-cursor", nil)
 )
 
+// detailedError is an error of the app carrying data for the client. It is mapped by the error it wraps.
+type detailedError interface {
+	error
+	Unwrap() error
+	Detail() interface{}
+}
+
 type ApiError struct {
 	Code    int         `json:"code"`
 	Message string      `json:"message"`
@@ -35,6 +44,12 @@ func Get(err error, errorMap map[error]*ApiError) error {
 	if err, ok := err.(*validator.ValidationError); ok {
 		return NewApiError(http.StatusBadRequest, "invalid_payload", err.Violations)
 	}
+	var detailed detailedError
+	if errors.As(err, &detailed) {
+		if apiErr := errorMap[detailed.Unwrap()]; apiErr != nil {
+			return NewApiError(apiErr.Code, apiErr.Message, detailed.Detail())
+		}
+	}
 	if err := errorMap[err]; err != nil {
 		return err
 	}
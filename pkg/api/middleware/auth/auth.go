@@ -6,6 +6,7 @@ import (
 	"github.com/carlosrodriguesf/bank-api/pkg/model"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
 	"github.com/labstack/echo/v4"
+	"strings"
 )
 
 type (
@@ -15,6 +16,8 @@ type (
 	}
 	Middleware interface {
 		Private(next echo.HandlerFunc) echo.HandlerFunc
+		RequireRole(roles ...string) echo.MiddlewareFunc
+		RequirePermission(permission string) echo.MiddlewareFunc
 	}
 	middlewareImpl struct {
 		logger logger.Logger
@@ -29,6 +32,9 @@ func NewMiddleware(opts Options) Middleware {
 	}
 }
 
+// Private lets only authenticated requests through, putting their session on the context. Bearer tokens are opaque
+// session tokens or, when the auth app runs in JWT mode, access tokens verified without reaching the cache. Tokens
+// starting with model.APIKeyPrefix are API keys, whose session is limited to the scopes of the key.
 func (a *middlewareImpl) Private(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		token := getTokenFromRequest(c.Request())
@@ -36,8 +42,16 @@ func (a *middlewareImpl) Private(next echo.HandlerFunc) echo.HandlerFunc {
 			return apierror.ErrUnauthorized
 		}
 
-		ctx := c.Request().Context()
-		session, err := a.apps.Auth().GetSessionByToken(ctx, token)
+		var (
+			ctx     = c.Request().Context()
+			session *model.Session
+			err     error
+		)
+		if strings.HasPrefix(token, model.APIKeyPrefix) {
+			session, err = a.apps.APIKey().Authenticate(ctx, token)
+		} else {
+			session, err = a.apps.Auth().GetSessionByToken(ctx, token)
+		}
 		if err != nil {
 			if err := apierror.Get(err, errorMap); err != nil {
 				return err
@@ -51,3 +65,33 @@ func (a *middlewareImpl) Private(next echo.HandlerFunc) echo.HandlerFunc {
 		return next(c)
 	}
 }
+
+// RequireRole lets only sessions of accounts with one of roles through. It must run after Private.
+func (a *middlewareImpl) RequireRole(roles ...string) echo.MiddlewareFunc {
+	return a.authorize(func(session model.Session) bool {
+		return session.Account.HasRole(roles...)
+	})
+}
+
+// RequirePermission lets only sessions allowed to use permission through, see model.Session.HasPermission. It must run
+// after Private.
+func (a *middlewareImpl) RequirePermission(permission string) echo.MiddlewareFunc {
+	return a.authorize(func(session model.Session) bool {
+		return session.HasPermission(permission)
+	})
+}
+
+func (a *middlewareImpl) authorize(allowed func(session model.Session) bool) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			session := model.GetSessionFromContext(c.Request().Context())
+			if session == nil {
+				return apierror.ErrUnauthorized
+			}
+			if !allowed(*session) {
+				return apierror.ErrAccessDenied
+			}
+			return next(c)
+		}
+	}
+}
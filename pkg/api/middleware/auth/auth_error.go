@@ -6,6 +6,8 @@ import (
 )
 
 var errorMap = map[error]*apierror.ApiError{
-	pkgerror.ErrSessionNotFound: apierror.ErrUnauthorized,
-	pkgerror.ErrCantGetSession:  apierror.ErrInternal,
+	pkgerror.ErrSessionNotFound:        apierror.ErrUnauthorized,
+	pkgerror.ErrCantGetSession:         apierror.ErrInternal,
+	pkgerror.ErrInvalidAPIKey:          apierror.ErrUnauthorized,
+	pkgerror.ErrCantAuthenticateAPIKey: apierror.ErrInternal,
 }
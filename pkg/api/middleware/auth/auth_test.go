@@ -0,0 +1,119 @@
+package auth
+
+import (
+	"context"
+	apierror "github.com/carlosrodriguesf/bank-api/pkg/api/error"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequireRole(t *testing.T) {
+	cases := map[string]struct {
+		InputSession *model.Session
+		ExpectedErr  error
+	}{
+		"should call handler": {
+			InputSession: &model.Session{Account: model.Account{ID: "account_id", Role: model.RoleSupport}},
+			ExpectedErr:  nil,
+		},
+		"should return error: other role": {
+			InputSession: &model.Session{Account: model.Account{ID: "account_id", Role: model.RoleCustomer}},
+			ExpectedErr:  apierror.ErrAccessDenied,
+		},
+		"should return error: without session": {
+			InputSession: nil,
+			ExpectedErr:  apierror.ErrUnauthorized,
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			if cs.InputSession != nil {
+				ctx = model.SetSessionOnContext(ctx, cs.InputSession)
+			}
+
+			m := NewMiddleware(Options{
+				Logger: logger.New(""),
+			})
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/accounts", nil).WithContext(ctx)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			called := false
+			err := m.RequireRole(model.RoleAdmin, model.RoleSupport)(func(c echo.Context) error {
+				called = true
+				return nil
+			})(c)
+
+			assert.Equal(t, cs.ExpectedErr, err)
+			assert.Equal(t, cs.ExpectedErr == nil, called)
+		})
+	}
+}
+
+func TestRequirePermission(t *testing.T) {
+	cases := map[string]struct {
+		InputSession *model.Session
+		ExpectedErr  error
+	}{
+		"should call handler": {
+			InputSession: &model.Session{Account: model.Account{ID: "account_id", Role: model.RoleAdmin}},
+			ExpectedErr:  nil,
+		},
+		"should return error: role without permission": {
+			InputSession: &model.Session{Account: model.Account{ID: "account_id", Role: model.RoleSupport}},
+			ExpectedErr:  apierror.ErrAccessDenied,
+		},
+		"should return error: customer": {
+			InputSession: &model.Session{Account: model.Account{ID: "account_id", Role: model.RoleCustomer}},
+			ExpectedErr:  apierror.ErrAccessDenied,
+		},
+		"should return error: api key without scope": {
+			InputSession: &model.Session{
+				Account:  model.Account{ID: "account_id", Role: model.RoleAdmin},
+				APIKeyID: "api_key_id",
+				Scopes:   []string{model.PermissionReadAccounts},
+			},
+			ExpectedErr: apierror.ErrAccessDenied,
+		},
+		"should return error: without session": {
+			InputSession: nil,
+			ExpectedErr:  apierror.ErrUnauthorized,
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			if cs.InputSession != nil {
+				ctx = model.SetSessionOnContext(ctx, cs.InputSession)
+			}
+
+			m := NewMiddleware(Options{
+				Logger: logger.New(""),
+			})
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/accounts/account_id/close", nil).WithContext(ctx)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			called := false
+			err := m.RequirePermission(model.PermissionCloseAccounts)(func(c echo.Context) error {
+				called = true
+				return nil
+			})(c)
+
+			assert.Equal(t, cs.ExpectedErr, err)
+			assert.Equal(t, cs.ExpectedErr == nil, called)
+		})
+	}
+}
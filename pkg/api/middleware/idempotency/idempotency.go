@@ -0,0 +1,146 @@
+package idempotency
+
+import (
+	"bytes"
+	apierror "github.com/carlosrodriguesf/bank-api/pkg/api/error"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/cache"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/labstack/echo/v4"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	HeaderIdempotencyKey      = "Idempotency-Key"
+	HeaderIdempotentReplayed  = "Idempotent-Replayed"
+	cacheKeyIdempotency       = "idempotency:%s:%s"
+	cacheExpiration           = 24 * time.Hour
+	maxIdempotencyKeyLength   = 255
+	anonymousIdempotencyScope = "anonymous"
+
+	// inProgressExpiration bounds how long a key stays reserved without a stored response, so a request lost with its
+	// instance does not lock the key for the whole cacheExpiration.
+	inProgressExpiration = time.Minute
+)
+
+type (
+	Options struct {
+		Logger logger.Logger
+		Cache  cache.Cache
+	}
+	Middleware interface {
+		Handle(next echo.HandlerFunc) echo.HandlerFunc
+	}
+	middlewareImpl struct {
+		logger logger.Logger
+		cache  cache.Cache
+	}
+)
+
+func NewMiddleware(opts Options) Middleware {
+	return &middlewareImpl{
+		logger: opts.Logger.WithLocation().WithPreffix("api.middleware.idempotency"),
+		cache:  opts.Cache,
+	}
+}
+
+// Handle makes the request safe to retry when the client sends an Idempotency-Key header: the first response for a
+// key is stored and replayed to every retry with the same body, while a retry with a different body is refused.
+// When used together with the auth middleware it must come after it, so keys are scoped by the session account.
+// Anonymous requests are scoped by the client ip instead, so clients behind different addresses never share keys.
+func (m *middlewareImpl) Handle(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		key := c.Request().Header.Get(HeaderIdempotencyKey)
+		if key == "" {
+			return next(c)
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			return ErrInvalidKey
+		}
+
+		ctx := c.Request().Context()
+		log := m.logger.WithContext(ctx)
+
+		body, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			log.Error(err)
+			return apierror.ErrInvalidPayload
+		}
+		c.Request().Body = io.NopCloser(bytes.NewReader(body))
+
+		var (
+			current  = storedResponse{Fingerprint: getFingerprint(c.Request(), body)}
+			cacheKey = getCacheKey(getScope(model.GetSessionFromContext(ctx), c.RealIP()), key)
+		)
+
+		reserved, err := m.cache.SetIfNotExists(ctx, cacheKey, current, inProgressExpiration)
+		if err != nil {
+			log.Error(err)
+			return apierror.ErrInternal
+		}
+		if !reserved {
+			return m.replay(c, cacheKey, current.Fingerprint)
+		}
+
+		recorder := newResponseRecorder(c.Response().Writer)
+		c.Response().Writer = recorder
+		defer func() {
+			c.Response().Writer = recorder.ResponseWriter
+		}()
+
+		if err := next(c); err != nil {
+			c.Error(err)
+		}
+
+		m.store(c, cacheKey, storedResponse{
+			Fingerprint: current.Fingerprint,
+			Completed:   true,
+			Status:      c.Response().Status,
+			ContentType: c.Response().Header().Get(echo.HeaderContentType),
+			Body:        recorder.body.Bytes(),
+		})
+		return nil
+	}
+}
+
+func (m *middlewareImpl) replay(c echo.Context, cacheKey, fingerprint string) error {
+	ctx := c.Request().Context()
+	log := m.logger.WithContext(ctx)
+
+	var stored storedResponse
+	err := m.cache.Get(ctx, cacheKey, &stored)
+	if err != nil {
+		if m.cache.IsErrCacheMissing(err) {
+			return ErrRequestInProgress
+		}
+		log.Error(err)
+		return apierror.ErrInternal
+	}
+	if stored.Fingerprint != fingerprint {
+		return ErrKeyReused
+	}
+	if !stored.Completed {
+		return ErrRequestInProgress
+	}
+
+	c.Response().Header().Set(HeaderIdempotentReplayed, "true")
+	return c.Blob(stored.Status, stored.ContentType, stored.Body)
+}
+
+// store keeps the response to be replayed. Server errors release the key instead, so the client can retry them.
+func (m *middlewareImpl) store(c echo.Context, cacheKey string, response storedResponse) {
+	ctx := c.Request().Context()
+	log := m.logger.WithContext(ctx)
+
+	if response.Status >= http.StatusInternalServerError {
+		if err := m.cache.Delete(ctx, cacheKey); err != nil {
+			log.Error(err)
+		}
+		return
+	}
+	if err := m.cache.Set(ctx, cacheKey, response, cacheExpiration); err != nil {
+		log.Error(err)
+	}
+}
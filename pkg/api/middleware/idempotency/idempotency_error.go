@@ -0,0 +1,12 @@
+package idempotency
+
+import (
+	apierror "github.com/carlosrodriguesf/bank-api/pkg/api/error"
+	"net/http"
+)
+
+var (
+	ErrInvalidKey        = apierror.NewApiError(http.StatusBadRequest, "api.idempotency-key-invalid", nil)
+	ErrKeyReused         = apierror.NewApiError(http.StatusUnprocessableEntity, "api.idempotency-key-reused", nil)
+	ErrRequestInProgress = apierror.NewApiError(http.StatusConflict, "api.idempotency-request-in-progress", nil)
+)
@@ -0,0 +1,27 @@
+package idempotency
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"net/http"
+)
+
+func getCacheKey(scope, key string) string {
+	return fmt.Sprintf(cacheKeyIdempotency, scope, key)
+}
+
+func getScope(session *model.Session, ip string) string {
+	if session == nil {
+		return fmt.Sprintf("%s:%s", anonymousIdempotencyScope, ip)
+	}
+	return session.Account.ID
+}
+
+func getFingerprint(req *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(req.Method))
+	h.Write([]byte(req.URL.Path))
+	h.Write(body)
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
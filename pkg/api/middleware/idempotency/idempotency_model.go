@@ -0,0 +1,32 @@
+package idempotency
+
+import (
+	"bytes"
+	"net/http"
+)
+
+type (
+	storedResponse struct {
+		Fingerprint string `json:"fingerprint"`
+		Completed   bool   `json:"completed"`
+		Status      int    `json:"status,omitempty"`
+		ContentType string `json:"content_type,omitempty"`
+		Body        []byte `json:"body,omitempty"`
+	}
+	responseRecorder struct {
+		http.ResponseWriter
+		body *bytes.Buffer
+	}
+)
+
+func newResponseRecorder(w http.ResponseWriter) *responseRecorder {
+	return &responseRecorder{
+		ResponseWriter: w,
+		body:           new(bytes.Buffer),
+	}
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
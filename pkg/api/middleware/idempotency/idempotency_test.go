@@ -0,0 +1,243 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	apierror "github.com/carlosrodriguesf/bank-api/pkg/api/error"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/cache"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandle(t *testing.T) {
+	var (
+		endpoint     = "/api/v1/transfers"
+		body         = `{"account_destination_id":"target_account_id","amount":500}`
+		key          = "idempotency_key"
+		cacheKey     = getCacheKey("account_id", key)
+		fingerprint  = getFingerprint(httptest.NewRequest(http.MethodPost, endpoint, nil), []byte(body))
+		anonymousKey = getCacheKey(getScope(nil, "192.0.2.1"), key)
+		response     = `{"data":{"id":"transfer_id"}}`
+		stored       = storedResponse{
+			Fingerprint: fingerprint,
+			Completed:   true,
+			Status:      http.StatusOK,
+			ContentType: echo.MIMEApplicationJSON,
+			Body:        []byte(response),
+		}
+		okHandler = func(c echo.Context) error {
+			return c.Blob(http.StatusOK, echo.MIMEApplicationJSON, []byte(response))
+		}
+	)
+
+	cases := map[string]struct {
+		InputKey         string
+		InputAnonymous   bool
+		Handler          echo.HandlerFunc
+		ExpectedErr      error
+		ExpectedStatus   int
+		ExpectedBody     string
+		ExpectedReplayed bool
+		PrepareMockCache func(mock *cache.MockCache)
+	}{
+		"should call handler without key": {
+			InputKey:         "",
+			Handler:          okHandler,
+			ExpectedErr:      nil,
+			ExpectedStatus:   http.StatusOK,
+			ExpectedBody:     response,
+			PrepareMockCache: func(mock *cache.MockCache) {},
+		},
+		"should return error: key too long": {
+			InputKey:         strings.Repeat("k", maxIdempotencyKeyLength+1),
+			Handler:          okHandler,
+			ExpectedErr:      ErrInvalidKey,
+			PrepareMockCache: func(mock *cache.MockCache) {},
+		},
+		"should store first response": {
+			InputKey:       key,
+			Handler:        okHandler,
+			ExpectedErr:    nil,
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   response,
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().
+					SetIfNotExists(gomock.Any(), cacheKey, storedResponse{Fingerprint: fingerprint}, inProgressExpiration).
+					Return(true, nil)
+				mock.EXPECT().
+					Set(gomock.Any(), cacheKey, stored, cacheExpiration).
+					Return(nil)
+			},
+		},
+		"should store first response of anonymous client": {
+			InputKey:       key,
+			InputAnonymous: true,
+			Handler:        okHandler,
+			ExpectedErr:    nil,
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   response,
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().
+					SetIfNotExists(gomock.Any(), anonymousKey, storedResponse{Fingerprint: fingerprint}, inProgressExpiration).
+					Return(true, nil)
+				mock.EXPECT().
+					Set(gomock.Any(), anonymousKey, stored, cacheExpiration).
+					Return(nil)
+			},
+		},
+		"should release key on server error": {
+			InputKey: key,
+			Handler: func(c echo.Context) error {
+				return apierror.ErrInternal
+			},
+			ExpectedErr:    nil,
+			ExpectedStatus: http.StatusInternalServerError,
+			ExpectedBody:   `{"code":500,"message":"api.unknown"}`,
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().
+					SetIfNotExists(gomock.Any(), cacheKey, storedResponse{Fingerprint: fingerprint}, inProgressExpiration).
+					Return(true, nil)
+				mock.EXPECT().Delete(gomock.Any(), cacheKey).Return(nil)
+			},
+		},
+		"should replay stored response": {
+			InputKey: key,
+			Handler: func(c echo.Context) error {
+				return errors.New("handler must not be called")
+			},
+			ExpectedErr:      nil,
+			ExpectedStatus:   http.StatusOK,
+			ExpectedBody:     response,
+			ExpectedReplayed: true,
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().
+					SetIfNotExists(gomock.Any(), cacheKey, storedResponse{Fingerprint: fingerprint}, inProgressExpiration).
+					Return(false, nil)
+				mock.EXPECT().
+					Get(gomock.Any(), cacheKey, gomock.Any()).
+					Do(func(_ context.Context, _ string, value *storedResponse) {
+						*value = stored
+					})
+			},
+		},
+		"should return error: key reused with another body": {
+			InputKey:    key,
+			Handler:     okHandler,
+			ExpectedErr: ErrKeyReused,
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().
+					SetIfNotExists(gomock.Any(), cacheKey, storedResponse{Fingerprint: fingerprint}, inProgressExpiration).
+					Return(false, nil)
+				mock.EXPECT().
+					Get(gomock.Any(), cacheKey, gomock.Any()).
+					Do(func(_ context.Context, _ string, value *storedResponse) {
+						*value = stored
+						value.Fingerprint = "another_fingerprint"
+					})
+			},
+		},
+		"should return error: anonymous key reused with another body": {
+			InputKey:       key,
+			InputAnonymous: true,
+			Handler:        okHandler,
+			ExpectedErr:    ErrKeyReused,
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().
+					SetIfNotExists(gomock.Any(), anonymousKey, storedResponse{Fingerprint: fingerprint}, inProgressExpiration).
+					Return(false, nil)
+				mock.EXPECT().
+					Get(gomock.Any(), anonymousKey, gomock.Any()).
+					Do(func(_ context.Context, _ string, value *storedResponse) {
+						*value = stored
+						value.Fingerprint = "another_fingerprint"
+					})
+			},
+		},
+		"should return error: request in progress": {
+			InputKey:    key,
+			Handler:     okHandler,
+			ExpectedErr: ErrRequestInProgress,
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().
+					SetIfNotExists(gomock.Any(), cacheKey, storedResponse{Fingerprint: fingerprint}, inProgressExpiration).
+					Return(false, nil)
+				mock.EXPECT().
+					Get(gomock.Any(), cacheKey, gomock.Any()).
+					Do(func(_ context.Context, _ string, value *storedResponse) {
+						*value = storedResponse{Fingerprint: fingerprint}
+					})
+			},
+		},
+		"should return error on reserve key": {
+			InputKey:    key,
+			Handler:     okHandler,
+			ExpectedErr: apierror.ErrInternal,
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().
+					SetIfNotExists(gomock.Any(), cacheKey, storedResponse{Fingerprint: fingerprint}, inProgressExpiration).
+					Return(false, errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			if !cs.InputAnonymous {
+				ctx = model.SetSessionOnContext(ctx, &model.Session{
+					Token:   "session_token",
+					Account: model.Account{ID: "account_id"},
+				})
+			}
+
+			mockCache := cache.NewMockCache(ctrl)
+			cs.PrepareMockCache(mockCache)
+
+			m := NewMiddleware(Options{
+				Logger: logger.New(""),
+				Cache:  mockCache,
+			})
+
+			e := echo.New()
+			e.HTTPErrorHandler = func(err error, c echo.Context) {
+				apiErr, ok := err.(*apierror.ApiError)
+				if !ok {
+					apiErr = apierror.ErrInternal
+				}
+				_ = c.JSON(apiErr.Code, apiErr)
+			}
+			req := httptest.NewRequest(http.MethodPost, endpoint, strings.NewReader(body)).WithContext(ctx)
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			if cs.InputKey != "" {
+				req.Header.Set(HeaderIdempotencyKey, cs.InputKey)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath(endpoint)
+
+			err := m.Handle(func(c echo.Context) error {
+				received, err := io.ReadAll(c.Request().Body)
+				assert.NoError(t, err)
+				assert.Equal(t, body, string(received))
+				return cs.Handler(c)
+			})(c)
+
+			assert.Equal(t, cs.ExpectedErr, err)
+			if cs.ExpectedErr != nil {
+				return
+			}
+			assert.Equal(t, cs.ExpectedStatus, rec.Code)
+			assert.Equal(t, cs.ExpectedBody, strings.TrimSpace(rec.Body.String()))
+			assert.Equal(t, cs.ExpectedReplayed, rec.Header().Get(HeaderIdempotentReplayed) == "true")
+		})
+	}
+}
@@ -2,7 +2,11 @@ package middleware
 
 import (
 	"github.com/carlosrodriguesf/bank-api/pkg/api/middleware/auth"
+	"github.com/carlosrodriguesf/bank-api/pkg/api/middleware/idempotency"
+	"github.com/carlosrodriguesf/bank-api/pkg/api/middleware/signature"
 	"github.com/carlosrodriguesf/bank-api/pkg/app"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/cache"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/generate"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
 )
 
@@ -10,12 +14,19 @@ type (
 	Options struct {
 		Logger logger.Logger
 		App    app.Container
+		Cache  cache.Cache
+		// Signature configures the clients of the request signing middleware.
+		Signature signature.Config
 	}
 	Container interface {
 		Auth() auth.Middleware
+		Idempotency() idempotency.Middleware
+		Signature() signature.Middleware
 	}
 	container struct {
-		auth auth.Middleware
+		auth        auth.Middleware
+		idempotency idempotency.Middleware
+		signature   signature.Middleware
 	}
 )
 
@@ -25,9 +36,27 @@ func NewContainer(opts Options) Container {
 			Logger: opts.Logger,
 			Apps:   opts.App,
 		}),
+		idempotency: idempotency.NewMiddleware(idempotency.Options{
+			Logger: opts.Logger,
+			Cache:  opts.Cache,
+		}),
+		signature: signature.NewMiddleware(signature.Options{
+			Logger:   opts.Logger,
+			Cache:    opts.Cache,
+			Generate: generate.New(),
+			Config:   opts.Signature,
+		}),
 	}
 }
 
 func (c *container) Auth() auth.Middleware {
 	return c.auth
 }
+
+func (c *container) Idempotency() idempotency.Middleware {
+	return c.idempotency
+}
+
+func (c *container) Signature() signature.Middleware {
+	return c.signature
+}
@@ -0,0 +1,124 @@
+package signature
+
+import (
+	"bytes"
+	"crypto/hmac"
+	apierror "github.com/carlosrodriguesf/bank-api/pkg/api/error"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/cache"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/generate"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/labstack/echo/v4"
+	"io"
+	"strconv"
+	"time"
+)
+
+const (
+	HeaderClientID        = "X-Signature-Client"
+	HeaderTimestamp       = "X-Signature-Timestamp"
+	HeaderNonce           = "X-Signature-Nonce"
+	HeaderSignature       = "X-Signature"
+	cacheKeyNonce         = "signature:nonce:%s:%s"
+	defaultMaxSkew        = 5 * time.Minute
+	maxSignatureNonceSize = 128
+)
+
+type (
+	// Config holds the secrets of the clients allowed to sign requests, by client id. When Required is false unsigned
+	// requests go through, but signed ones are still verified.
+	Config struct {
+		Clients  map[string][]byte
+		MaxSkew  time.Duration
+		Required bool
+	}
+	Options struct {
+		Logger   logger.Logger
+		Cache    cache.Cache
+		Generate generate.Generate
+		Config   Config
+	}
+	Middleware interface {
+		Verify(next echo.HandlerFunc) echo.HandlerFunc
+	}
+	middlewareImpl struct {
+		logger   logger.Logger
+		cache    cache.Cache
+		generate generate.Generate
+		config   Config
+	}
+)
+
+func NewMiddleware(opts Options) Middleware {
+	if opts.Config.MaxSkew == 0 {
+		opts.Config.MaxSkew = defaultMaxSkew
+	}
+	return &middlewareImpl{
+		logger:   opts.Logger.WithLocation().WithPreffix("api.middleware.signature"),
+		cache:    opts.Cache,
+		generate: opts.Generate,
+		config:   opts.Config,
+	}
+}
+
+// Verify checks the HMAC-SHA256 signature of the request, made with the secret of the client over the method, path,
+// timestamp, nonce and body, see getSignature. Timestamps further than MaxSkew from now are refused, and so is a nonce
+// already used by the client within that time. It may be combined with the auth middleware in any order.
+func (m *middlewareImpl) Verify(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := c.Request()
+		clientID := req.Header.Get(HeaderClientID)
+		if clientID == "" {
+			if m.config.Required {
+				return ErrMissingSignature
+			}
+			return next(c)
+		}
+
+		var (
+			timestamp = req.Header.Get(HeaderTimestamp)
+			nonce     = req.Header.Get(HeaderNonce)
+			signature = req.Header.Get(HeaderSignature)
+		)
+		if timestamp == "" || nonce == "" || signature == "" || len(nonce) > maxSignatureNonceSize {
+			return ErrMissingSignature
+		}
+		secret, ok := m.config.Clients[clientID]
+		if !ok {
+			return ErrInvalidSignature
+		}
+
+		unix, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil {
+			return ErrInvalidSignature
+		}
+		if skew := m.generate.CurrentTime().Sub(time.Unix(unix, 0)); skew > m.config.MaxSkew || skew < -m.config.MaxSkew {
+			return ErrStaleTimestamp
+		}
+
+		ctx := req.Context()
+		log := m.logger.WithContext(ctx)
+
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			log.Error(err)
+			return apierror.ErrInvalidPayload
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+
+		expected := getSignature(secret, req, timestamp, nonce, body)
+		if !hmac.Equal([]byte(expected), []byte(signature)) {
+			return ErrInvalidSignature
+		}
+
+		// the nonce is kept while its timestamp is accepted, afterwards the timestamp alone refuses the replay
+		firstUse, err := m.cache.SetIfNotExists(ctx, getNonceCacheKey(clientID, nonce), true, 2*m.config.MaxSkew)
+		if err != nil {
+			log.Error(err)
+			return apierror.ErrInternal
+		}
+		if !firstUse {
+			return ErrReplayedNonce
+		}
+		return next(c)
+	}
+}
@@ -0,0 +1,13 @@
+package signature
+
+import (
+	apierror "github.com/carlosrodriguesf/bank-api/pkg/api/error"
+	"net/http"
+)
+
+var (
+	ErrMissingSignature = apierror.NewApiError(http.StatusUnauthorized, "api.signature-missing", nil)
+	ErrInvalidSignature = apierror.NewApiError(http.StatusUnauthorized, "api.signature-invalid", nil)
+	ErrStaleTimestamp   = apierror.NewApiError(http.StatusUnauthorized, "api.signature-stale-timestamp", nil)
+	ErrReplayedNonce    = apierror.NewApiError(http.StatusUnauthorized, "api.signature-replayed-nonce", nil)
+)
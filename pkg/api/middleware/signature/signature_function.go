@@ -0,0 +1,53 @@
+package signature
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var ErrInvalidClient = errors.New("signature: invalid client")
+
+// ParseClients reads client secrets written as a comma separated list of id:base64 pairs.
+func ParseClients(value string) (map[string][]byte, error) {
+	clients := make(map[string][]byte)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		parts := strings.SplitN(pair, ":", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidClient, pair)
+		}
+		secret, err := base64.StdEncoding.DecodeString(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %s", ErrInvalidClient, parts[0], err)
+		}
+		clients[parts[0]] = secret
+	}
+	return clients, nil
+}
+
+func getNonceCacheKey(clientID, nonce string) string {
+	return fmt.Sprintf(cacheKeyNonce, clientID, nonce)
+}
+
+// getSignature returns the hex encoded HMAC-SHA256 of the lines method, path with query, timestamp, nonce and the
+// hex encoded SHA-256 of the body.
+func getSignature(secret []byte, req *http.Request, timestamp, nonce string, body []byte) string {
+	bodyHash := sha256.Sum256(body)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(strings.Join([]string{
+		req.Method,
+		req.URL.RequestURI(),
+		timestamp,
+		nonce,
+		fmt.Sprintf("%x", bodyHash),
+	}, "\n")))
+	return fmt.Sprintf("%x", mac.Sum(nil))
+}
@@ -0,0 +1,209 @@
+package signature
+
+import (
+	"context"
+	"errors"
+	apierror "github.com/carlosrodriguesf/bank-api/pkg/api/error"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/cache"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/generate"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVerify(t *testing.T) {
+	var (
+		endpoint    = "/api/v1/transfers"
+		body        = `{"account_destination_id":"target_account_id","amount":500}`
+		secret      = []byte("partner_secret")
+		currentTime = time.Unix(1900000000, 0)
+		timestamp   = strconv.FormatInt(currentTime.Unix(), 10)
+		nonce       = "nonce"
+		signature   = getSignature(secret, httptest.NewRequest(http.MethodPost, endpoint, nil), timestamp, nonce, []byte(body))
+		signed      = map[string]string{
+			HeaderClientID:  "partner",
+			HeaderTimestamp: timestamp,
+			HeaderNonce:     nonce,
+			HeaderSignature: signature,
+		}
+		withHeader = func(name, value string) map[string]string {
+			headers := make(map[string]string)
+			for k, v := range signed {
+				headers[k] = v
+			}
+			headers[name] = value
+			return headers
+		}
+	)
+
+	cases := map[string]struct {
+		InputHeaders        map[string]string
+		InputRequired       bool
+		ExpectedErr         error
+		PrepareMockCache    func(mock *cache.MockCache)
+		PrepareMockGenerate func(mock *generate.MockGenerate)
+	}{
+		"should call handler": {
+			InputHeaders: signed,
+			ExpectedErr:  nil,
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().SetIfNotExists(gomock.Any(), getNonceCacheKey("partner", nonce), true, 2*defaultMaxSkew).Return(true, nil)
+			},
+			PrepareMockGenerate: func(mock *generate.MockGenerate) {
+				mock.EXPECT().CurrentTime().Return(currentTime.Add(defaultMaxSkew))
+			},
+		},
+		"should call handler without signature": {
+			InputHeaders:        nil,
+			ExpectedErr:         nil,
+			PrepareMockCache:    func(mock *cache.MockCache) {},
+			PrepareMockGenerate: func(mock *generate.MockGenerate) {},
+		},
+		"should return error: signature required": {
+			InputHeaders:        nil,
+			InputRequired:       true,
+			ExpectedErr:         ErrMissingSignature,
+			PrepareMockCache:    func(mock *cache.MockCache) {},
+			PrepareMockGenerate: func(mock *generate.MockGenerate) {},
+		},
+		"should return error: missing nonce": {
+			InputHeaders:        withHeader(HeaderNonce, ""),
+			ExpectedErr:         ErrMissingSignature,
+			PrepareMockCache:    func(mock *cache.MockCache) {},
+			PrepareMockGenerate: func(mock *generate.MockGenerate) {},
+		},
+		"should return error: unknown client": {
+			InputHeaders:        withHeader(HeaderClientID, "other"),
+			ExpectedErr:         ErrInvalidSignature,
+			PrepareMockCache:    func(mock *cache.MockCache) {},
+			PrepareMockGenerate: func(mock *generate.MockGenerate) {},
+		},
+		"should return error: stale timestamp": {
+			InputHeaders:     signed,
+			ExpectedErr:      ErrStaleTimestamp,
+			PrepareMockCache: func(mock *cache.MockCache) {},
+			PrepareMockGenerate: func(mock *generate.MockGenerate) {
+				mock.EXPECT().CurrentTime().Return(currentTime.Add(defaultMaxSkew + time.Second))
+			},
+		},
+		"should return error: timestamp in future": {
+			InputHeaders:     signed,
+			ExpectedErr:      ErrStaleTimestamp,
+			PrepareMockCache: func(mock *cache.MockCache) {},
+			PrepareMockGenerate: func(mock *generate.MockGenerate) {
+				mock.EXPECT().CurrentTime().Return(currentTime.Add(-defaultMaxSkew - time.Second))
+			},
+		},
+		"should return error: invalid signature": {
+			InputHeaders:     withHeader(HeaderNonce, "other_nonce"),
+			ExpectedErr:      ErrInvalidSignature,
+			PrepareMockCache: func(mock *cache.MockCache) {},
+			PrepareMockGenerate: func(mock *generate.MockGenerate) {
+				mock.EXPECT().CurrentTime().Return(currentTime)
+			},
+		},
+		"should return error: replayed nonce": {
+			InputHeaders: signed,
+			ExpectedErr:  ErrReplayedNonce,
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().SetIfNotExists(gomock.Any(), getNonceCacheKey("partner", nonce), true, 2*defaultMaxSkew).Return(false, nil)
+			},
+			PrepareMockGenerate: func(mock *generate.MockGenerate) {
+				mock.EXPECT().CurrentTime().Return(currentTime)
+			},
+		},
+		"should return error on cache": {
+			InputHeaders: signed,
+			ExpectedErr:  apierror.ErrInternal,
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().SetIfNotExists(gomock.Any(), getNonceCacheKey("partner", nonce), true, 2*defaultMaxSkew).Return(false, errors.New("fail"))
+			},
+			PrepareMockGenerate: func(mock *generate.MockGenerate) {
+				mock.EXPECT().CurrentTime().Return(currentTime)
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			mockCache := cache.NewMockCache(ctrl)
+			cs.PrepareMockCache(mockCache)
+			mockGenerate := generate.NewMockGenerate(ctrl)
+			cs.PrepareMockGenerate(mockGenerate)
+
+			m := NewMiddleware(Options{
+				Logger:   logger.New(""),
+				Cache:    mockCache,
+				Generate: mockGenerate,
+				Config: Config{
+					Clients:  map[string][]byte{"partner": secret},
+					Required: cs.InputRequired,
+				},
+			})
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, endpoint, strings.NewReader(body)).WithContext(ctx)
+			for name, value := range cs.InputHeaders {
+				req.Header.Set(name, value)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			var receivedBody string
+			err := m.Verify(func(c echo.Context) error {
+				b, err := io.ReadAll(c.Request().Body)
+				receivedBody = string(b)
+				return err
+			})(c)
+
+			assert.Equal(t, cs.ExpectedErr, err)
+			if cs.ExpectedErr == nil {
+				assert.Equal(t, body, receivedBody)
+			}
+		})
+	}
+}
+
+func TestParseClients(t *testing.T) {
+	cases := map[string]struct {
+		InputValue    string
+		ExpectedData  map[string][]byte
+		ExpectedError bool
+	}{
+		"should return success": {
+			InputValue:   "partner:c2VjcmV0, other:b3RoZXI=",
+			ExpectedData: map[string][]byte{"partner": []byte("secret"), "other": []byte("other")},
+		},
+		"should return success: empty": {
+			InputValue:   "",
+			ExpectedData: map[string][]byte{},
+		},
+		"should return error: without secret": {
+			InputValue:    "partner",
+			ExpectedError: true,
+		},
+		"should return error: invalid base64": {
+			InputValue:    "partner:***",
+			ExpectedError: true,
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			clients, err := ParseClients(cs.InputValue)
+
+			assert.Equal(t, cs.ExpectedData, clients)
+			assert.Equal(t, cs.ExpectedError, errors.Is(err, ErrInvalidClient))
+		})
+	}
+}
@@ -15,7 +15,8 @@ type (
 	}
 
 	Response struct {
-		Data  interface{}        `json:"data,omitempty" swaggerignore:"true"`
-		Error *apierror.ApiError `json:"error,omitempty" swaggerignore:"true"`
+		Data       interface{}        `json:"data,omitempty" swaggerignore:"true"`
+		NextCursor string             `json:"next_cursor,omitempty"`
+		Error      *apierror.ApiError `json:"error,omitempty" swaggerignore:"true"`
 	}
 )
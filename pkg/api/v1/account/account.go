@@ -1,9 +1,12 @@
 package account
 
 import (
+	"errors"
 	apierror "github.com/carlosrodriguesf/bank-api/pkg/api/error"
 	apimodel "github.com/carlosrodriguesf/bank-api/pkg/api/model"
 	"github.com/carlosrodriguesf/bank-api/pkg/app/account"
+	"github.com/carlosrodriguesf/bank-api/pkg/app/auth"
+	pkgerror "github.com/carlosrodriguesf/bank-api/pkg/error"
 	"github.com/carlosrodriguesf/bank-api/pkg/model"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
 	"github.com/labstack/echo/v4"
@@ -13,6 +16,7 @@ import (
 type handler struct {
 	logger     logger.Logger
 	accountApp account.App
+	authApp    auth.App
 }
 
 func Register(g *echo.Group, opts apimodel.Options) {
@@ -20,11 +24,20 @@ func Register(g *echo.Group, opts apimodel.Options) {
 	h := handler{
 		logger:     log.WithLocation(),
 		accountApp: opts.App.Account(),
+		authApp:    opts.App.Auth(),
 	}
 
-	g.POST("/accounts", h.postAccount)
-	g.GET("/accounts", h.getAccounts)
-	g.GET("/accounts/:id/balance", h.getAccountBalance)
+	g.POST("/accounts", h.postAccount, opts.Middleware.Idempotency().Handle)
+	g.GET("/accounts", h.getAccounts, opts.Middleware.Auth().Private, opts.Middleware.Auth().RequirePermission(model.PermissionListAccounts))
+	g.GET("/accounts/directory", h.getAccountDirectory, opts.Middleware.Auth().Private, opts.Middleware.Auth().RequirePermission(model.PermissionReadAccounts))
+	g.GET("/accounts/ledger-divergences", h.getLedgerDivergences, opts.Middleware.Auth().Private, opts.Middleware.Auth().RequirePermission(model.PermissionAuditLedger))
+	g.GET("/accounts/:id/balance", h.getAccountBalance, opts.Middleware.Auth().Private, opts.Middleware.Auth().RequirePermission(model.PermissionReadAccounts))
+	g.GET("/me", h.getMe, opts.Middleware.Auth().Private, opts.Middleware.Auth().RequirePermission(model.PermissionReadAccounts))
+	g.POST("/accounts/:id/freeze", h.freezeAccount, opts.Middleware.Auth().Private, opts.Middleware.Auth().RequirePermission(model.PermissionChangeAccountStatus))
+	g.POST("/accounts/:id/unfreeze", h.unfreezeAccount, opts.Middleware.Auth().Private, opts.Middleware.Auth().RequirePermission(model.PermissionChangeAccountStatus))
+	g.POST("/accounts/:id/close", h.closeAccount, opts.Middleware.Auth().Private, opts.Middleware.Auth().RequirePermission(model.PermissionCloseAccounts))
+	g.GET("/accounts/:id/limits", h.getAccountLimits, opts.Middleware.Auth().Private, opts.Middleware.Auth().RequirePermission(model.PermissionReadAccounts))
+	g.PUT("/accounts/:id/limits", h.putAccountLimits, opts.Middleware.Auth().Private, opts.Middleware.Auth().RequirePermission(model.PermissionManageLimits))
 
 	log.Info("registered")
 }
@@ -33,6 +46,7 @@ func Register(g *echo.Group, opts apimodel.Options) {
 // @Description Create account
 // @Tags account
 // @Produce json
+// @Param Idempotency-Key header string false "key to safely retry the request"
 // @Param account body postAccountBody true "expected structure"
 // @Success 200 {object} model.Response{data=model.Account}
 // @Success 400 {object} model.Response{error=error.ApiError}
@@ -53,6 +67,7 @@ func (h *handler) postAccount(c echo.Context) error {
 		Document: body.Document,
 		Secret:   body.Secret,
 		Balance:  body.Balance,
+		Currency: body.Currency,
 	})
 	if err != nil {
 		if err := apierror.Get(err, errorMap); err != nil {
@@ -67,17 +82,87 @@ func (h *handler) postAccount(c echo.Context) error {
 }
 
 // getAccounts swagger document
-// @Description List accounts
+// @Description List accounts, restricted to support and admin operators
 // @Tags account
 // @Produce json
+// @Security UserToken
+// @Param limit query int false "page size, up to 100 (default 50)"
+// @Param cursor query string false "next_cursor returned by the previous page"
+// @Param sort_by query string false "created_at (default) or name"
+// @Param sort query string false "asc or desc, newest first when sorted by created_at and alphabetical when sorted by name"
+// @Param name query string false "prefix of the account name, case insensitive"
+// @Param document query string false "document of the account"
 // @Success 200 {object} model.Response{data=[]model.Account}
+// @Success 400 {object} model.Response{error=error.ApiError}
+// @Failure 403 {object} model.Response{error=error.ApiError}
 // @Failure 500 {object} model.Response{error=error.ApiError}
 // @Router /api/v1/accounts [get]
 func (h *handler) getAccounts(c echo.Context) error {
 	ctx := c.Request().Context()
 	log := h.logger.WithContext(ctx)
 
-	data, err := h.accountApp.List(ctx)
+	filter, err := getAccountFilter(c)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	page, err := h.accountApp.List(ctx, *filter)
+	if err != nil {
+		if err := apierror.Get(err, errorMap); err != nil {
+			return err
+		}
+		log.Error(err)
+		return apierror.ErrInternal
+	}
+	return c.JSON(http.StatusOK, apimodel.Response{
+		Data:       page.Accounts,
+		NextCursor: page.NextCursor,
+	})
+}
+
+// getAccountDirectory swagger document
+// @Description Find the account of a document, returning only its id and masked name
+// @Tags account
+// @Produce json
+// @Security UserToken
+// @Param document query string true "document of the account"
+// @Success 200 {object} model.Response{data=model.AccountDirectoryEntry}
+// @Success 400 {object} model.Response{error=error.ApiError}
+// @Failure 500 {object} model.Response{error=error.ApiError}
+// @Router /api/v1/accounts/directory [get]
+func (h *handler) getAccountDirectory(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := h.logger.WithContext(ctx)
+
+	data, err := h.accountApp.Lookup(ctx, c.QueryParam("document"))
+	if err != nil {
+		if err := apierror.Get(err, errorMap); err != nil {
+			return err
+		}
+		log.Error(err)
+		return apierror.ErrInternal
+	}
+	return c.JSON(http.StatusOK, apimodel.Response{
+		Data: data,
+	})
+}
+
+// getLedgerDivergences swagger document
+// @Description List the accounts whose balance differs from the sum of their ledger entries, restricted to admins. An
+// @Description empty list means the balances and the ledger agree
+// @Tags account
+// @Produce json
+// @Security UserToken
+// @Success 200 {object} model.Response{data=[]model.LedgerDivergence}
+// @Failure 403 {object} model.Response{error=error.ApiError}
+// @Failure 500 {object} model.Response{error=error.ApiError}
+// @Router /api/v1/accounts/ledger-divergences [get]
+func (h *handler) getLedgerDivergences(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := h.logger.WithContext(ctx)
+
+	data, err := h.accountApp.ListLedgerDivergences(ctx)
 	if err != nil {
 		if err := apierror.Get(err, errorMap); err != nil {
 			return err
@@ -91,17 +176,25 @@ func (h *handler) getAccounts(c echo.Context) error {
 }
 
 // getAccountBalance swagger document
-// @Description Get balance of an account
+// @Description Get balance of an account of current auth user, along with the available balance, without the money
+// @Description reserved for holds and reviews. Support and admin operators can read any account, by id or document
 // @Tags account
 // @Produce json
-// @Param id path string true "id of an account"
+// @Security UserToken
+// @Param id path string true "id or document of an account"
 // @Success 200 {object} model.Response{data=model.AccountBalance}
+// @Failure 403 {object} model.Response{error=error.ApiError}
 // @Failure 500 {object} model.Response{error=error.ApiError}
 // @Router /api/v1/accounts/{id}/balance [get]
 func (h *handler) getAccountBalance(c echo.Context) error {
 	ctx := c.Request().Context()
 	log := h.logger.WithContext(ctx)
-	accountID := c.Param("id")
+
+	accountID, err := h.getReadableAccountID(c)
+	if err != nil {
+		return err
+	}
+
 	data, err := h.accountApp.GetBalance(ctx, accountID)
 	if err != nil {
 		if err := apierror.Get(err, errorMap); err != nil {
@@ -114,3 +207,255 @@ func (h *handler) getAccountBalance(c echo.Context) error {
 		Data: data,
 	})
 }
+
+// freezeAccount swagger document
+// @Description Stop an active account from sending and receiving money, restricted to support and admin operators
+// @Tags account
+// @Produce json
+// @Security UserToken
+// @Param id path string true "id of an account"
+// @Success 200 {object} model.Response{data=model.Account}
+// @Success 400 {object} model.Response{error=error.ApiError}
+// @Failure 403 {object} model.Response{error=error.ApiError}
+// @Failure 500 {object} model.Response{error=error.ApiError}
+// @Router /api/v1/accounts/{id}/freeze [post]
+func (h *handler) freezeAccount(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := h.logger.WithContext(ctx)
+
+	data, err := h.accountApp.Freeze(ctx, c.Param("id"))
+	if err != nil {
+		if err := apierror.Get(err, errorMap); err != nil {
+			return err
+		}
+		log.Error(err)
+		return apierror.ErrInternal
+	}
+	return c.JSON(http.StatusOK, apimodel.Response{
+		Data: data,
+	})
+}
+
+// unfreezeAccount swagger document
+// @Description Make a frozen account active again, restricted to support and admin operators
+// @Tags account
+// @Produce json
+// @Security UserToken
+// @Param id path string true "id of an account"
+// @Success 200 {object} model.Response{data=model.Account}
+// @Success 400 {object} model.Response{error=error.ApiError}
+// @Failure 403 {object} model.Response{error=error.ApiError}
+// @Failure 500 {object} model.Response{error=error.ApiError}
+// @Router /api/v1/accounts/{id}/unfreeze [post]
+func (h *handler) unfreezeAccount(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := h.logger.WithContext(ctx)
+
+	data, err := h.accountApp.Unfreeze(ctx, c.Param("id"))
+	if err != nil {
+		if err := apierror.Get(err, errorMap); err != nil {
+			return err
+		}
+		log.Error(err)
+		return apierror.ErrInternal
+	}
+	return c.JSON(http.StatusOK, apimodel.Response{
+		Data: data,
+	})
+}
+
+// closeAccount swagger document
+// @Description Close an account for good, moving its balance to the sweep account and ending its sessions, restricted
+// @Description to admins
+// @Tags account
+// @Produce json
+// @Security UserToken
+// @Param id path string true "id of an account"
+// @Param close body closeAccountBody false "account receiving the remaining balance, required when there is one"
+// @Success 200 {object} model.Response{data=model.Account}
+// @Success 400 {object} model.Response{error=error.ApiError}
+// @Failure 403 {object} model.Response{error=error.ApiError}
+// @Failure 500 {object} model.Response{error=error.ApiError}
+// @Router /api/v1/accounts/{id}/close [post]
+func (h *handler) closeAccount(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := h.logger.WithContext(ctx)
+
+	body := new(closeAccountBody)
+	if err := c.Bind(body); err != nil {
+		log.Error(err)
+		return apierror.ErrInvalidPayload
+	}
+
+	data, err := h.accountApp.Close(ctx, c.Param("id"), body.SweepAccountID)
+	if err != nil {
+		if err := apierror.Get(err, errorMap); err != nil {
+			return err
+		}
+		log.Error(err)
+		return apierror.ErrInternal
+	}
+	// the account is closed already, a failure here only leaves its sessions to expire
+	if err = h.authApp.RevokeSessions(ctx, data.ID, ""); err != nil {
+		log.Error(err)
+	}
+	return c.JSON(http.StatusOK, apimodel.Response{
+		Data: data,
+	})
+}
+
+// getAccountLimits swagger document
+// @Description Get the transfer limits of an account of current auth user and how much of them was used today and
+// @Description this month. Support and admin operators can read any account, by id or document
+// @Tags account
+// @Produce json
+// @Security UserToken
+// @Param id path string true "id or document of an account"
+// @Success 200 {object} model.Response{data=model.AccountLimitsStatus}
+// @Success 400 {object} model.Response{error=error.ApiError}
+// @Failure 403 {object} model.Response{error=error.ApiError}
+// @Failure 500 {object} model.Response{error=error.ApiError}
+// @Router /api/v1/accounts/{id}/limits [get]
+func (h *handler) getAccountLimits(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := h.logger.WithContext(ctx)
+
+	accountID, err := h.getReadableAccountID(c)
+	if err != nil {
+		return err
+	}
+
+	data, err := h.accountApp.GetLimits(ctx, accountID)
+	if err != nil {
+		if err := apierror.Get(err, errorMap); err != nil {
+			return err
+		}
+		log.Error(err)
+		return apierror.ErrInternal
+	}
+	return c.JSON(http.StatusOK, apimodel.Response{
+		Data: data,
+	})
+}
+
+// putAccountLimits swagger document
+// @Description Set the tier and the transfer limits of an account, restricted to admins. Limits left null follow the
+// @Description tier and zero means no limit
+// @Tags account
+// @Produce json
+// @Security UserToken
+// @Param id path string true "id of an account"
+// @Param limits body putAccountLimitsBody true "expected structure"
+// @Success 200 {object} model.Response{data=model.AccountLimitsStatus}
+// @Success 400 {object} model.Response{error=error.ApiError}
+// @Failure 403 {object} model.Response{error=error.ApiError}
+// @Failure 500 {object} model.Response{error=error.ApiError}
+// @Router /api/v1/accounts/{id}/limits [put]
+func (h *handler) putAccountLimits(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := h.logger.WithContext(ctx)
+
+	body := new(putAccountLimitsBody)
+	if err := c.Bind(body); err != nil {
+		log.Error(err)
+		return apierror.ErrInvalidPayload
+	}
+
+	data, err := h.accountApp.UpdateLimits(ctx, model.AccountLimits{
+		AccountID:   c.Param("id"),
+		Tier:        body.Tier,
+		PerTransfer: body.PerTransfer,
+		Daily:       body.Daily,
+		Monthly:     body.Monthly,
+		DailyCount:  body.DailyCount,
+	})
+	if err != nil {
+		if err := apierror.Get(err, errorMap); err != nil {
+			return err
+		}
+		log.Error(err)
+		return apierror.ErrInternal
+	}
+	return c.JSON(http.StatusOK, apimodel.Response{
+		Data: data,
+	})
+}
+
+func getAccountFilter(c echo.Context) (*model.AccountFilter, error) {
+	var (
+		filter model.AccountFilter
+		cursor string
+	)
+	err := echo.QueryParamsBinder(c).
+		Int("limit", &filter.Limit).
+		String("cursor", &cursor).
+		String("sort_by", &filter.SortBy).
+		String("sort", &filter.Sort).
+		String("name", &filter.Name).
+		String("document", &filter.Document).
+		BindError()
+	if err != nil {
+		return nil, apierror.ErrInvalidPayload
+	}
+
+	if cursor != "" {
+		if filter.Cursor, err = model.DecodeCursor(cursor); err != nil {
+			return nil, apierror.ErrInvalidCursor
+		}
+	}
+	return &filter, nil
+}
+
+// getReadableAccountID resolves the account informed by id or document in the path to its id, as long as the user of
+// the session can read it. Support and admin operators read any account, and other users only their own, which they
+// may inform by document as well. An account they can't read is denied whether it exists or not.
+func (h *handler) getReadableAccountID(c echo.Context) (string, error) {
+	ctx := c.Request().Context()
+	sess := model.GetSessionFromContext(ctx)
+	accountID := c.Param("id")
+	if accountID == sess.Account.ID || sess.HasPermission(model.PermissionReadAnyAccount) {
+		return accountID, nil
+	}
+
+	acc, err := h.accountApp.Get(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, pkgerror.ErrAccountNotFound) {
+			return "", apierror.ErrAccessDenied
+		}
+		if err := apierror.Get(err, errorMap); err != nil {
+			return "", err
+		}
+		h.logger.WithContext(ctx).Error(err)
+		return "", apierror.ErrInternal
+	}
+	if acc.ID != sess.Account.ID {
+		return "", apierror.ErrAccessDenied
+	}
+	return acc.ID, nil
+}
+
+// getMe swagger document
+// @Description Get the profile and the current balance of the account of current auth user
+// @Tags account
+// @Produce json
+// @Security UserToken
+// @Success 200 {object} model.Response{data=model.Account}
+// @Failure 500 {object} model.Response{error=error.ApiError}
+// @Router /api/v1/me [get]
+func (h *handler) getMe(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := h.logger.WithContext(ctx)
+
+	sess := model.GetSessionFromContext(ctx)
+	data, err := h.accountApp.Get(ctx, sess.Account.ID)
+	if err != nil {
+		if err := apierror.Get(err, errorMap); err != nil {
+			return err
+		}
+		log.Error(err)
+		return apierror.ErrInternal
+	}
+	return c.JSON(http.StatusOK, apimodel.Response{
+		Data: data,
+	})
+}
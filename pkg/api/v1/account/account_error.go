@@ -13,5 +13,16 @@ var (
 		pkgerror.ErrCantCreateAccount:     apierror.NewApiError(http.StatusInternalServerError, pkgerror.ErrCantCreateAccount.Error(), nil),
 		pkgerror.ErrCantListAccounts:      apierror.NewApiError(http.StatusInternalServerError, pkgerror.ErrCantListAccounts.Error(), nil),
 		pkgerror.ErrCantGetAccountBalance: apierror.NewApiError(http.StatusInternalServerError, pkgerror.ErrCantGetAccountBalance.Error(), nil),
+		pkgerror.ErrCantGetAccount:        apierror.NewApiError(http.StatusInternalServerError, pkgerror.ErrCantGetAccount.Error(), nil),
+		pkgerror.ErrCantLookupAccount:     apierror.NewApiError(http.StatusInternalServerError, pkgerror.ErrCantLookupAccount.Error(), nil),
+		pkgerror.ErrCantChangeStatus:      apierror.NewApiError(http.StatusInternalServerError, pkgerror.ErrCantChangeStatus.Error(), nil),
+		pkgerror.ErrInvalidStatusChange:   apierror.NewApiError(http.StatusConflict, pkgerror.ErrInvalidStatusChange.Error(), nil),
+		pkgerror.ErrAccountHasBalance:     apierror.NewApiError(http.StatusBadRequest, pkgerror.ErrAccountHasBalance.Error(), nil),
+		pkgerror.ErrAccountHasReserved:    apierror.NewApiError(http.StatusBadRequest, pkgerror.ErrAccountHasReserved.Error(), nil),
+		pkgerror.ErrInvalidSweepTarget:    apierror.NewApiError(http.StatusBadRequest, pkgerror.ErrInvalidSweepTarget.Error(), nil),
+		pkgerror.ErrCurrencyNotSupported:  apierror.NewApiError(http.StatusBadRequest, pkgerror.ErrCurrencyNotSupported.Error(), nil),
+		pkgerror.ErrCantGetLimits:         apierror.NewApiError(http.StatusInternalServerError, pkgerror.ErrCantGetLimits.Error(), nil),
+		pkgerror.ErrCantUpdateLimits:      apierror.NewApiError(http.StatusInternalServerError, pkgerror.ErrCantUpdateLimits.Error(), nil),
+		pkgerror.ErrCantListDivergences:   apierror.NewApiError(http.StatusInternalServerError, pkgerror.ErrCantListDivergences.Error(), nil),
 	}
 )
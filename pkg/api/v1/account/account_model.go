@@ -1,8 +1,21 @@
 package account
 
-type postAccountBody struct {
-	Name     string `json:"name"`
-	Document string `json:"document"`
-	Secret   string `json:"secret"`
-	Balance  int64  `json:"balance"`
-}
+type (
+	postAccountBody struct {
+		Name     string `json:"name"`
+		Document string `json:"document"`
+		Secret   string `json:"secret"`
+		Balance  int64  `json:"balance"`
+		Currency string `json:"currency"`
+	}
+	closeAccountBody struct {
+		SweepAccountID string `json:"sweep_account_id"`
+	}
+	putAccountLimitsBody struct {
+		Tier        string `json:"tier"`
+		PerTransfer *int64 `json:"per_transfer"`
+		Daily       *int64 `json:"daily"`
+		Monthly     *int64 `json:"monthly"`
+		DailyCount  *int64 `json:"daily_count"`
+	}
+)
@@ -8,6 +8,7 @@ import (
 	apierror "github.com/carlosrodriguesf/bank-api/pkg/api/error"
 	apimodel "github.com/carlosrodriguesf/bank-api/pkg/api/model"
 	"github.com/carlosrodriguesf/bank-api/pkg/app/account"
+	"github.com/carlosrodriguesf/bank-api/pkg/app/auth"
 	pkgerror "github.com/carlosrodriguesf/bank-api/pkg/error"
 	"github.com/carlosrodriguesf/bank-api/pkg/model"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
@@ -20,6 +21,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestHandler_CreateAccount(t *testing.T) {
@@ -171,6 +173,7 @@ func TestHandler_CreateAccount(t *testing.T) {
 func TestHandler_getAccounts(t *testing.T) {
 	var (
 		endpoint        = "/api/v1/accounts"
+		cursor          = model.Cursor{CreatedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), ID: "last_id", Name: "Account"}
 		accountsExample = []model.Account{
 			{
 				ID:       "account_id_1",
@@ -183,32 +186,228 @@ func TestHandler_getAccounts(t *testing.T) {
 				Document: "12312312312",
 			},
 		}
+		pageExample = model.AccountPage{
+			Accounts:   accountsExample,
+			NextCursor: "next_cursor",
+		}
 	)
 
 	cases := map[string]struct {
+		InputQuery     string
 		ExpectedData   []model.Account
+		ExpectedCursor string
 		ExpectedErr    error
 		PrepareMockApp func(mock *account.MockApp)
 	}{
 		"should return success": {
-			ExpectedData: accountsExample,
-			ExpectedErr:  nil,
+			InputQuery:     "",
+			ExpectedData:   accountsExample,
+			ExpectedCursor: "next_cursor",
+			ExpectedErr:    nil,
+			PrepareMockApp: func(mock *account.MockApp) {
+				mock.EXPECT().List(gomock.Any(), model.AccountFilter{}).Return(&pageExample, nil)
+			},
+		},
+		"should return success with filters": {
+			InputQuery:     "?limit=10&sort_by=name&sort=desc&name=Acc&document=12312312312&cursor=" + cursor.Encode(),
+			ExpectedData:   accountsExample,
+			ExpectedCursor: "next_cursor",
+			ExpectedErr:    nil,
 			PrepareMockApp: func(mock *account.MockApp) {
-				mock.EXPECT().List(gomock.Any()).Return(accountsExample, nil)
+				mock.EXPECT().
+					List(gomock.Any(), model.AccountFilter{
+						Name:     "Acc",
+						Document: "12312312312",
+						SortBy:   model.AccountSortByName,
+						Sort:     model.SortDesc,
+						Cursor:   &cursor,
+						Limit:    10,
+					}).
+					Return(&pageExample, nil)
 			},
 		},
+		"should return error: invalid query": {
+			InputQuery:     "?limit=ten",
+			ExpectedErr:    apierror.ErrInvalidPayload,
+			PrepareMockApp: func(mock *account.MockApp) {},
+		},
+		"should return error: invalid cursor": {
+			InputQuery:     "?cursor=invalid",
+			ExpectedErr:    apierror.ErrInvalidCursor,
+			PrepareMockApp: func(mock *account.MockApp) {},
+		},
 		"should return error": {
+			InputQuery:  "",
+			ExpectedErr: errorMap[pkgerror.ErrCantListAccounts],
+			PrepareMockApp: func(mock *account.MockApp) {
+				mock.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, pkgerror.ErrCantListAccounts)
+			},
+		},
+		"should return internal error": {
+			InputQuery:  "",
+			ExpectedErr: apierror.ErrInternal,
+			PrepareMockApp: func(mock *account.MockApp) {
+				mock.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			mockApp := account.NewMockApp(ctrl)
+
+			cs.PrepareMockApp(mockApp)
+
+			h := handler{
+				logger:     logger.New(""),
+				accountApp: mockApp,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, endpoint+cs.InputQuery, nil).WithContext(ctx)
+			rec := httptest.NewRecorder()
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			c := e.NewContext(req, rec)
+			c.SetPath(endpoint)
+
+			err := h.getAccounts(c)
+
+			assert.Equal(t, cs.ExpectedErr, err)
+
+			expectedResponseJSON, err := json.Marshal(apimodel.Response{Data: cs.ExpectedData, NextCursor: cs.ExpectedCursor})
+			assert.NoError(t, err)
+
+			var expectedResponse apimodel.Response
+			err = json.Unmarshal(expectedResponseJSON, &expectedResponse)
+			assert.NoError(t, err)
+
+			var currentResponse apimodel.Response
+			json.NewDecoder(rec.Body).Decode(&currentResponse)
+
+			assert.Equal(t, expectedResponse, currentResponse)
+		})
+	}
+}
+
+func TestHandler_getAccountDirectory(t *testing.T) {
+	var (
+		endpoint     = "/api/v1/accounts/directory"
+		document     = "12312312312"
+		entryExample = model.AccountDirectoryEntry{
+			ID:   "account_id",
+			Name: "J*** D**",
+		}
+	)
+
+	cases := map[string]struct {
+		ExpectedData   *model.AccountDirectoryEntry
+		ExpectedErr    error
+		PrepareMockApp func(mock *account.MockApp)
+	}{
+		"should return success": {
+			ExpectedData: &entryExample,
+			ExpectedErr:  nil,
+			PrepareMockApp: func(mock *account.MockApp) {
+				mock.EXPECT().Lookup(gomock.Any(), document).Return(&entryExample, nil)
+			},
+		},
+		"should return error: account not found": {
+			ExpectedData: nil,
+			ExpectedErr:  errorMap[pkgerror.ErrAccountNotFound],
+			PrepareMockApp: func(mock *account.MockApp) {
+				mock.EXPECT().Lookup(gomock.Any(), document).Return(nil, pkgerror.ErrAccountNotFound)
+			},
+		},
+		"should return internal error": {
+			ExpectedData: nil,
+			ExpectedErr:  apierror.ErrInternal,
+			PrepareMockApp: func(mock *account.MockApp) {
+				mock.EXPECT().Lookup(gomock.Any(), document).Return(nil, errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			mockApp := account.NewMockApp(ctrl)
+
+			cs.PrepareMockApp(mockApp)
+
+			h := handler{
+				logger:     logger.New(""),
+				accountApp: mockApp,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, endpoint+"?document="+document, nil).WithContext(ctx)
+			rec := httptest.NewRecorder()
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			c := e.NewContext(req, rec)
+			c.SetPath(endpoint)
+
+			err := h.getAccountDirectory(c)
+
+			assert.Equal(t, cs.ExpectedErr, err)
+
+			expectedResponseJSON, err := json.Marshal(apimodel.Response{Data: cs.ExpectedData})
+			assert.NoError(t, err)
+
+			var expectedResponse apimodel.Response
+			err = json.Unmarshal(expectedResponseJSON, &expectedResponse)
+			assert.NoError(t, err)
+
+			var currentResponse apimodel.Response
+			json.NewDecoder(rec.Body).Decode(&currentResponse)
+
+			assert.Equal(t, expectedResponse, currentResponse)
+		})
+	}
+}
+
+func TestHandler_getLedgerDivergences(t *testing.T) {
+	var (
+		endpoint           = "/api/v1/accounts/ledger-divergences"
+		divergencesExample = []model.LedgerDivergence{{
+			AccountID:     "account_id",
+			Balance:       1000,
+			LedgerBalance: 500,
+		}}
+	)
+
+	cases := map[string]struct {
+		ExpectedData   []model.LedgerDivergence
+		ExpectedErr    error
+		PrepareMockApp func(mock *account.MockApp)
+	}{
+		"should return success": {
+			ExpectedData: divergencesExample,
+			ExpectedErr:  nil,
+			PrepareMockApp: func(mock *account.MockApp) {
+				mock.EXPECT().
+					ListLedgerDivergences(gomock.Any()).
+					Return(divergencesExample, nil)
+			},
+		},
+		"should return error: cant list divergences": {
 			ExpectedData: nil,
-			ExpectedErr:  errorMap[pkgerror.ErrCantListAccounts],
+			ExpectedErr:  errorMap[pkgerror.ErrCantListDivergences],
 			PrepareMockApp: func(mock *account.MockApp) {
-				mock.EXPECT().List(gomock.Any()).Return(nil, pkgerror.ErrCantListAccounts)
+				mock.EXPECT().
+					ListLedgerDivergences(gomock.Any()).
+					Return(nil, pkgerror.ErrCantListDivergences)
 			},
 		},
 		"should return internal error": {
 			ExpectedData: nil,
 			ExpectedErr:  apierror.ErrInternal,
 			PrepareMockApp: func(mock *account.MockApp) {
-				mock.EXPECT().List(gomock.Any()).Return(nil, errors.New("fail"))
+				mock.EXPECT().
+					ListLedgerDivergences(gomock.Any()).
+					Return(nil, errors.New("fail"))
 			},
 		},
 	}
@@ -233,7 +432,7 @@ func TestHandler_getAccounts(t *testing.T) {
 			c := e.NewContext(req, rec)
 			c.SetPath(endpoint)
 
-			err := h.getAccounts(c)
+			err := h.getLedgerDivergences(c)
 
 			assert.Equal(t, cs.ExpectedErr, err)
 
@@ -262,11 +461,23 @@ func TestHandler_getAccountBalance(t *testing.T) {
 	)
 
 	cases := map[string]struct {
+		InputSession   *model.Session
 		ExpectedData   *model.AccountBalance
 		ExpectedErr    error
 		PrepareMockApp func(mock *account.MockApp)
 	}{
 		"should return success": {
+			InputSession: &model.Session{Account: model.Account{ID: accountID, Role: model.RoleCustomer}},
+			ExpectedData: &balanceExample,
+			ExpectedErr:  nil,
+			PrepareMockApp: func(mock *account.MockApp) {
+				mock.EXPECT().
+					GetBalance(gomock.Any(), accountID).
+					Return(&balanceExample, nil)
+			},
+		},
+		"should return success for support operators": {
+			InputSession: &model.Session{Account: model.Account{ID: "support_account_id", Role: model.RoleSupport}},
 			ExpectedData: &balanceExample,
 			ExpectedErr:  nil,
 			PrepareMockApp: func(mock *account.MockApp) {
@@ -275,7 +486,35 @@ func TestHandler_getAccountBalance(t *testing.T) {
 					Return(&balanceExample, nil)
 			},
 		},
+		"should return success: own account by document": {
+			InputSession: &model.Session{Account: model.Account{ID: "owner_account_id", Role: model.RoleCustomer}},
+			ExpectedData: &balanceExample,
+			ExpectedErr:  nil,
+			PrepareMockApp: func(mock *account.MockApp) {
+				mock.EXPECT().Get(gomock.Any(), accountID).Return(&model.Account{ID: "owner_account_id"}, nil)
+				mock.EXPECT().
+					GetBalance(gomock.Any(), "owner_account_id").
+					Return(&balanceExample, nil)
+			},
+		},
+		"should return error: access denied": {
+			InputSession: &model.Session{Account: model.Account{ID: "another_account_id", Role: model.RoleCustomer}},
+			ExpectedData: nil,
+			ExpectedErr:  apierror.ErrAccessDenied,
+			PrepareMockApp: func(mock *account.MockApp) {
+				mock.EXPECT().Get(gomock.Any(), accountID).Return(&model.Account{ID: accountID}, nil)
+			},
+		},
+		"should return error: access denied to unknown account": {
+			InputSession: &model.Session{Account: model.Account{ID: "another_account_id", Role: model.RoleCustomer}},
+			ExpectedData: nil,
+			ExpectedErr:  apierror.ErrAccessDenied,
+			PrepareMockApp: func(mock *account.MockApp) {
+				mock.EXPECT().Get(gomock.Any(), accountID).Return(nil, pkgerror.ErrAccountNotFound)
+			},
+		},
 		"should return error: account not found": {
+			InputSession: &model.Session{Account: model.Account{ID: "support_account_id", Role: model.RoleSupport}},
 			ExpectedData: nil,
 			ExpectedErr:  errorMap[pkgerror.ErrAccountNotFound],
 			PrepareMockApp: func(mock *account.MockApp) {
@@ -285,6 +524,7 @@ func TestHandler_getAccountBalance(t *testing.T) {
 			},
 		},
 		"should return error: cant get account balance": {
+			InputSession: &model.Session{Account: model.Account{ID: accountID}},
 			ExpectedData: nil,
 			ExpectedErr:  errorMap[pkgerror.ErrCantGetAccountBalance],
 			PrepareMockApp: func(mock *account.MockApp) {
@@ -294,6 +534,7 @@ func TestHandler_getAccountBalance(t *testing.T) {
 			},
 		},
 		"should return internal error": {
+			InputSession: &model.Session{Account: model.Account{ID: accountID}},
 			ExpectedData: nil,
 			ExpectedErr:  apierror.ErrInternal,
 			PrepareMockApp: func(mock *account.MockApp) {
@@ -307,6 +548,7 @@ func TestHandler_getAccountBalance(t *testing.T) {
 	for name, cs := range cases {
 		t.Run(name, func(t *testing.T) {
 			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			ctx = model.SetSessionOnContext(ctx, cs.InputSession)
 
 			mockApp := account.NewMockApp(ctrl)
 
@@ -344,3 +586,537 @@ func TestHandler_getAccountBalance(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_freezeAccount(t *testing.T) {
+	var (
+		endpoint      = "/api/v1/accounts/account_id/freeze"
+		accountID     = "account_id"
+		frozenExample = model.Account{
+			ID:     accountID,
+			Name:   "John Doe",
+			Status: model.AccountStatusFrozen,
+		}
+	)
+
+	cases := map[string]struct {
+		ExpectedData   *model.Account
+		ExpectedErr    error
+		PrepareMockApp func(mock *account.MockApp)
+	}{
+		"should return success": {
+			ExpectedData: &frozenExample,
+			ExpectedErr:  nil,
+			PrepareMockApp: func(mock *account.MockApp) {
+				mock.EXPECT().
+					Freeze(gomock.Any(), accountID).
+					Return(&frozenExample, nil)
+			},
+		},
+		"should return error: invalid status change": {
+			ExpectedData: nil,
+			ExpectedErr:  errorMap[pkgerror.ErrInvalidStatusChange],
+			PrepareMockApp: func(mock *account.MockApp) {
+				mock.EXPECT().
+					Freeze(gomock.Any(), accountID).
+					Return(nil, pkgerror.ErrInvalidStatusChange)
+			},
+		},
+		"should return internal error": {
+			ExpectedData: nil,
+			ExpectedErr:  apierror.ErrInternal,
+			PrepareMockApp: func(mock *account.MockApp) {
+				mock.EXPECT().
+					Freeze(gomock.Any(), accountID).
+					Return(nil, errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			mockApp := account.NewMockApp(ctrl)
+
+			cs.PrepareMockApp(mockApp)
+
+			h := handler{
+				logger:     logger.New(""),
+				accountApp: mockApp,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, endpoint, nil).WithContext(ctx)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath(endpoint)
+			c.SetParamNames("id")
+			c.SetParamValues(accountID)
+
+			err := h.freezeAccount(c)
+
+			assert.Equal(t, cs.ExpectedErr, err)
+
+			expectedResponseJSON, err := json.Marshal(apimodel.Response{Data: cs.ExpectedData})
+			assert.NoError(t, err)
+
+			var expectedResponse apimodel.Response
+			err = json.Unmarshal(expectedResponseJSON, &expectedResponse)
+			assert.NoError(t, err)
+
+			var currentResponse apimodel.Response
+			json.NewDecoder(rec.Body).Decode(&currentResponse)
+
+			assert.Equal(t, expectedResponse, currentResponse)
+		})
+	}
+}
+
+func TestHandler_closeAccount(t *testing.T) {
+	var (
+		endpoint      = "/api/v1/accounts/account_id/close"
+		accountID     = "account_id"
+		closedExample = model.Account{
+			ID:     accountID,
+			Name:   "John Doe",
+			Status: model.AccountStatusClosed,
+		}
+	)
+
+	cases := map[string]struct {
+		InputBody          string
+		ExpectedData       *model.Account
+		ExpectedErr        error
+		PrepareMockApp     func(mock *account.MockApp)
+		PrepareMockAuthApp func(mock *auth.MockApp)
+	}{
+		"should return success": {
+			InputBody:    `{"sweep_account_id":"sweep_account_id"}`,
+			ExpectedData: &closedExample,
+			ExpectedErr:  nil,
+			PrepareMockApp: func(mock *account.MockApp) {
+				mock.EXPECT().
+					Close(gomock.Any(), accountID, "sweep_account_id").
+					Return(&closedExample, nil)
+			},
+			PrepareMockAuthApp: func(mock *auth.MockApp) {
+				mock.EXPECT().RevokeSessions(gomock.Any(), accountID, "").Return(nil)
+			},
+		},
+		"should return success without sweep account": {
+			InputBody:    `{}`,
+			ExpectedData: &closedExample,
+			ExpectedErr:  nil,
+			PrepareMockApp: func(mock *account.MockApp) {
+				mock.EXPECT().
+					Close(gomock.Any(), accountID, "").
+					Return(&closedExample, nil)
+			},
+			PrepareMockAuthApp: func(mock *auth.MockApp) {
+				mock.EXPECT().RevokeSessions(gomock.Any(), accountID, "").Return(nil)
+			},
+		},
+		"should return success when revoking sessions fails": {
+			InputBody:    `{}`,
+			ExpectedData: &closedExample,
+			ExpectedErr:  nil,
+			PrepareMockApp: func(mock *account.MockApp) {
+				mock.EXPECT().
+					Close(gomock.Any(), accountID, "").
+					Return(&closedExample, nil)
+			},
+			PrepareMockAuthApp: func(mock *auth.MockApp) {
+				mock.EXPECT().RevokeSessions(gomock.Any(), accountID, "").Return(errors.New("fail"))
+			},
+		},
+		"should return error: invalid payload": {
+			InputBody:          `{"sweep_account_id":1}`,
+			ExpectedData:       nil,
+			ExpectedErr:        apierror.ErrInvalidPayload,
+			PrepareMockApp:     func(mock *account.MockApp) {},
+			PrepareMockAuthApp: func(mock *auth.MockApp) {},
+		},
+		"should return error: account has balance": {
+			InputBody:    `{}`,
+			ExpectedData: nil,
+			ExpectedErr:  errorMap[pkgerror.ErrAccountHasBalance],
+			PrepareMockApp: func(mock *account.MockApp) {
+				mock.EXPECT().
+					Close(gomock.Any(), accountID, "").
+					Return(nil, pkgerror.ErrAccountHasBalance)
+			},
+			PrepareMockAuthApp: func(mock *auth.MockApp) {},
+		},
+		"should return error: invalid sweep target": {
+			InputBody:    `{"sweep_account_id":"sweep_account_id"}`,
+			ExpectedData: nil,
+			ExpectedErr:  errorMap[pkgerror.ErrInvalidSweepTarget],
+			PrepareMockApp: func(mock *account.MockApp) {
+				mock.EXPECT().
+					Close(gomock.Any(), accountID, "sweep_account_id").
+					Return(nil, pkgerror.ErrInvalidSweepTarget)
+			},
+			PrepareMockAuthApp: func(mock *auth.MockApp) {},
+		},
+		"should return internal error": {
+			InputBody:    `{}`,
+			ExpectedData: nil,
+			ExpectedErr:  apierror.ErrInternal,
+			PrepareMockApp: func(mock *account.MockApp) {
+				mock.EXPECT().
+					Close(gomock.Any(), accountID, "").
+					Return(nil, errors.New("fail"))
+			},
+			PrepareMockAuthApp: func(mock *auth.MockApp) {},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			mockApp := account.NewMockApp(ctrl)
+			mockAuthApp := auth.NewMockApp(ctrl)
+
+			cs.PrepareMockApp(mockApp)
+			cs.PrepareMockAuthApp(mockAuthApp)
+
+			h := handler{
+				logger:     logger.New(""),
+				accountApp: mockApp,
+				authApp:    mockAuthApp,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, endpoint, strings.NewReader(cs.InputBody)).WithContext(ctx)
+			rec := httptest.NewRecorder()
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			c := e.NewContext(req, rec)
+			c.SetPath(endpoint)
+			c.SetParamNames("id")
+			c.SetParamValues(accountID)
+
+			err := h.closeAccount(c)
+
+			assert.Equal(t, cs.ExpectedErr, err)
+
+			expectedResponseJSON, err := json.Marshal(apimodel.Response{Data: cs.ExpectedData})
+			assert.NoError(t, err)
+
+			var expectedResponse apimodel.Response
+			err = json.Unmarshal(expectedResponseJSON, &expectedResponse)
+			assert.NoError(t, err)
+
+			var currentResponse apimodel.Response
+			json.NewDecoder(rec.Body).Decode(&currentResponse)
+
+			assert.Equal(t, expectedResponse, currentResponse)
+		})
+	}
+}
+
+func TestHandler_getMe(t *testing.T) {
+	var (
+		endpoint       = "/api/v1/me"
+		sessionExample = model.Session{
+			ID:      "session_id",
+			Account: model.Account{ID: "account_id"},
+		}
+		accountExample = model.Account{
+			ID:       "account_id",
+			Name:     "John Doe",
+			Document: "12312312312",
+			Balance:  1000,
+			Role:     model.RoleCustomer,
+			Status:   model.AccountStatusActive,
+		}
+	)
+
+	cases := map[string]struct {
+		ExpectedData   *model.Account
+		ExpectedErr    error
+		PrepareMockApp func(mock *account.MockApp)
+	}{
+		"should return success": {
+			ExpectedData: &accountExample,
+			ExpectedErr:  nil,
+			PrepareMockApp: func(mock *account.MockApp) {
+				mock.EXPECT().
+					Get(gomock.Any(), "account_id").
+					Return(&accountExample, nil)
+			},
+		},
+		"should return error: cant get account": {
+			ExpectedData: nil,
+			ExpectedErr:  errorMap[pkgerror.ErrCantGetAccount],
+			PrepareMockApp: func(mock *account.MockApp) {
+				mock.EXPECT().
+					Get(gomock.Any(), "account_id").
+					Return(nil, pkgerror.ErrCantGetAccount)
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			ctx = model.SetSessionOnContext(ctx, &sessionExample)
+
+			mockApp := account.NewMockApp(ctrl)
+
+			cs.PrepareMockApp(mockApp)
+
+			h := handler{
+				logger:     logger.New(""),
+				accountApp: mockApp,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, endpoint, nil).WithContext(ctx)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath(endpoint)
+
+			err := h.getMe(c)
+
+			assert.Equal(t, cs.ExpectedErr, err)
+
+			expectedResponseJSON, err := json.Marshal(apimodel.Response{Data: cs.ExpectedData})
+			assert.NoError(t, err)
+
+			var expectedResponse apimodel.Response
+			err = json.Unmarshal(expectedResponseJSON, &expectedResponse)
+			assert.NoError(t, err)
+
+			var currentResponse apimodel.Response
+			json.NewDecoder(rec.Body).Decode(&currentResponse)
+
+			assert.Equal(t, expectedResponse, currentResponse)
+		})
+	}
+}
+
+func TestHandler_getAccountLimits(t *testing.T) {
+	var (
+		endpoint      = "/api/v1/accounts/account_id/limits"
+		accountID     = "account_id"
+		limitsExample = model.AccountLimitsStatus{
+			Settings: model.DefaultAccountLimits(accountID),
+			Limits:   model.DefaultAccountLimits(accountID).Effective(),
+			Usage:    model.TransferUsage{Daily: 300, Monthly: 1200, DailyCount: 2},
+		}
+	)
+
+	cases := map[string]struct {
+		InputSession   *model.Session
+		ExpectedData   *model.AccountLimitsStatus
+		ExpectedErr    error
+		PrepareMockApp func(mock *account.MockApp)
+	}{
+		"should return success": {
+			InputSession: &model.Session{Account: model.Account{ID: accountID, Role: model.RoleCustomer}},
+			ExpectedData: &limitsExample,
+			ExpectedErr:  nil,
+			PrepareMockApp: func(mock *account.MockApp) {
+				mock.EXPECT().
+					GetLimits(gomock.Any(), accountID).
+					Return(&limitsExample, nil)
+			},
+		},
+		"should return success for support operators": {
+			InputSession: &model.Session{Account: model.Account{ID: "support_account_id", Role: model.RoleSupport}},
+			ExpectedData: &limitsExample,
+			ExpectedErr:  nil,
+			PrepareMockApp: func(mock *account.MockApp) {
+				mock.EXPECT().
+					GetLimits(gomock.Any(), accountID).
+					Return(&limitsExample, nil)
+			},
+		},
+		"should return success: own account by document": {
+			InputSession: &model.Session{Account: model.Account{ID: "owner_account_id", Role: model.RoleCustomer}},
+			ExpectedData: &limitsExample,
+			ExpectedErr:  nil,
+			PrepareMockApp: func(mock *account.MockApp) {
+				mock.EXPECT().Get(gomock.Any(), accountID).Return(&model.Account{ID: "owner_account_id"}, nil)
+				mock.EXPECT().
+					GetLimits(gomock.Any(), "owner_account_id").
+					Return(&limitsExample, nil)
+			},
+		},
+		"should return error: access denied": {
+			InputSession: &model.Session{Account: model.Account{ID: "another_account_id", Role: model.RoleCustomer}},
+			ExpectedData: nil,
+			ExpectedErr:  apierror.ErrAccessDenied,
+			PrepareMockApp: func(mock *account.MockApp) {
+				mock.EXPECT().Get(gomock.Any(), accountID).Return(&model.Account{ID: accountID}, nil)
+			},
+		},
+		"should return error: access denied to unknown account": {
+			InputSession: &model.Session{Account: model.Account{ID: "another_account_id", Role: model.RoleCustomer}},
+			ExpectedData: nil,
+			ExpectedErr:  apierror.ErrAccessDenied,
+			PrepareMockApp: func(mock *account.MockApp) {
+				mock.EXPECT().Get(gomock.Any(), accountID).Return(nil, pkgerror.ErrAccountNotFound)
+			},
+		},
+		"should return error: cant get limits": {
+			InputSession: &model.Session{Account: model.Account{ID: accountID, Role: model.RoleCustomer}},
+			ExpectedData: nil,
+			ExpectedErr:  errorMap[pkgerror.ErrCantGetLimits],
+			PrepareMockApp: func(mock *account.MockApp) {
+				mock.EXPECT().
+					GetLimits(gomock.Any(), accountID).
+					Return(nil, pkgerror.ErrCantGetLimits)
+			},
+		},
+		"should return internal error": {
+			InputSession: &model.Session{Account: model.Account{ID: accountID, Role: model.RoleCustomer}},
+			ExpectedData: nil,
+			ExpectedErr:  apierror.ErrInternal,
+			PrepareMockApp: func(mock *account.MockApp) {
+				mock.EXPECT().
+					GetLimits(gomock.Any(), accountID).
+					Return(nil, errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			ctx = model.SetSessionOnContext(ctx, cs.InputSession)
+
+			mockApp := account.NewMockApp(ctrl)
+
+			cs.PrepareMockApp(mockApp)
+
+			h := handler{
+				logger:     logger.New(""),
+				accountApp: mockApp,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, endpoint, nil).WithContext(ctx)
+			rec := httptest.NewRecorder()
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			c := e.NewContext(req, rec)
+			c.SetPath(endpoint)
+			c.SetParamNames("id")
+			c.SetParamValues(accountID)
+
+			err := h.getAccountLimits(c)
+
+			assert.Equal(t, cs.ExpectedErr, err)
+
+			expectedResponseJSON, err := json.Marshal(apimodel.Response{Data: cs.ExpectedData})
+			assert.NoError(t, err)
+
+			var expectedResponse apimodel.Response
+			err = json.Unmarshal(expectedResponseJSON, &expectedResponse)
+			assert.NoError(t, err)
+
+			var currentResponse apimodel.Response
+			json.NewDecoder(rec.Body).Decode(&currentResponse)
+
+			assert.Equal(t, expectedResponse, currentResponse)
+		})
+	}
+}
+
+func TestHandler_putAccountLimits(t *testing.T) {
+	var (
+		endpoint      = "/api/v1/accounts/account_id/limits"
+		accountID     = "account_id"
+		daily         = int64(2000)
+		inputExample  = model.AccountLimits{AccountID: accountID, Tier: model.AccountTierPremium, Daily: &daily}
+		limitsExample = model.AccountLimitsStatus{
+			Settings: inputExample,
+			Limits:   inputExample.Effective(),
+		}
+	)
+
+	cases := map[string]struct {
+		InputBody      string
+		ExpectedData   *model.AccountLimitsStatus
+		ExpectedErr    error
+		PrepareMockApp func(mock *account.MockApp)
+	}{
+		"should return success": {
+			InputBody:    `{"tier":"premium","daily":2000}`,
+			ExpectedData: &limitsExample,
+			ExpectedErr:  nil,
+			PrepareMockApp: func(mock *account.MockApp) {
+				mock.EXPECT().
+					UpdateLimits(gomock.Any(), inputExample).
+					Return(&limitsExample, nil)
+			},
+		},
+		"should return error: invalid payload": {
+			InputBody:      `{"tier":1}`,
+			ExpectedData:   nil,
+			ExpectedErr:    apierror.ErrInvalidPayload,
+			PrepareMockApp: func(mock *account.MockApp) {},
+		},
+		"should return error: account not found": {
+			InputBody:    `{"tier":"premium","daily":2000}`,
+			ExpectedData: nil,
+			ExpectedErr:  errorMap[pkgerror.ErrAccountNotFound],
+			PrepareMockApp: func(mock *account.MockApp) {
+				mock.EXPECT().
+					UpdateLimits(gomock.Any(), inputExample).
+					Return(nil, pkgerror.ErrAccountNotFound)
+			},
+		},
+		"should return internal error": {
+			InputBody:    `{"tier":"premium","daily":2000}`,
+			ExpectedData: nil,
+			ExpectedErr:  apierror.ErrInternal,
+			PrepareMockApp: func(mock *account.MockApp) {
+				mock.EXPECT().
+					UpdateLimits(gomock.Any(), inputExample).
+					Return(nil, errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			mockApp := account.NewMockApp(ctrl)
+
+			cs.PrepareMockApp(mockApp)
+
+			h := handler{
+				logger:     logger.New(""),
+				accountApp: mockApp,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPut, endpoint, strings.NewReader(cs.InputBody)).WithContext(ctx)
+			rec := httptest.NewRecorder()
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			c := e.NewContext(req, rec)
+			c.SetPath(endpoint)
+			c.SetParamNames("id")
+			c.SetParamValues(accountID)
+
+			err := h.putAccountLimits(c)
+
+			assert.Equal(t, cs.ExpectedErr, err)
+
+			expectedResponseJSON, err := json.Marshal(apimodel.Response{Data: cs.ExpectedData})
+			assert.NoError(t, err)
+
+			var expectedResponse apimodel.Response
+			err = json.Unmarshal(expectedResponseJSON, &expectedResponse)
+			assert.NoError(t, err)
+
+			var currentResponse apimodel.Response
+			json.NewDecoder(rec.Body).Decode(&currentResponse)
+
+			assert.Equal(t, expectedResponse, currentResponse)
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package apikey
+
+import (
+	apierror "github.com/carlosrodriguesf/bank-api/pkg/api/error"
+	apimodel "github.com/carlosrodriguesf/bank-api/pkg/api/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/app/apikey"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type handler struct {
+	logger    logger.Logger
+	apiKeyApp apikey.App
+}
+
+func Register(g *echo.Group, opts apimodel.Options) {
+	log := opts.Logger.WithPreffix("api.v1.apikey")
+	h := handler{
+		logger:    log.WithLocation(),
+		apiKeyApp: opts.App.APIKey(),
+	}
+
+	g.POST("/api-keys", h.postAPIKey, opts.Middleware.Auth().Private, opts.Middleware.Auth().RequirePermission(model.PermissionManageCredentials))
+	g.GET("/api-keys", h.getAPIKeys, opts.Middleware.Auth().Private, opts.Middleware.Auth().RequirePermission(model.PermissionManageCredentials))
+	g.DELETE("/api-keys/:id", h.deleteAPIKey, opts.Middleware.Auth().Private, opts.Middleware.Auth().RequirePermission(model.PermissionManageCredentials))
+
+	log.Info("registered")
+}
+
+// postAPIKey swagger document
+// @Description Create an API key for current auth user. The key is only returned here
+// @Tags apikey
+// @Produce json
+// @Security UserToken
+// @Param apiKey body postAPIKeyBody true "expected structure"
+// @Success 200 {object} model.Response{data=model.APIKeyCreated}
+// @Success 400 {object} model.Response{error=error.ApiError}
+// @Failure 500 {object} model.Response{error=error.ApiError}
+// @Router /api/v1/api-keys [post]
+func (h *handler) postAPIKey(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := h.logger.WithContext(ctx)
+
+	body := new(postAPIKeyBody)
+	if err := c.Bind(body); err != nil {
+		log.Error(err)
+		return apierror.ErrInvalidPayload
+	}
+
+	sess := model.GetSessionFromContext(ctx)
+	data, err := h.apiKeyApp.Create(ctx, model.APIKeyCreation{
+		AccountID: sess.Account.ID,
+		Name:      body.Name,
+		Scopes:    body.Scopes,
+		ExpiresAt: body.ExpiresAt,
+	})
+	if err != nil {
+		if err := apierror.Get(err, errorMap); err != nil {
+			return err
+		}
+		log.Error(err)
+		return apierror.ErrInternal
+	}
+	return c.JSON(http.StatusOK, apimodel.Response{
+		Data: data,
+	})
+}
+
+// getAPIKeys swagger document
+// @Description List the API keys of current auth user that were not revoked
+// @Tags apikey
+// @Produce json
+// @Security UserToken
+// @Success 200 {object} model.Response{data=[]model.APIKey}
+// @Failure 500 {object} model.Response{error=error.ApiError}
+// @Router /api/v1/api-keys [get]
+func (h *handler) getAPIKeys(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := h.logger.WithContext(ctx)
+
+	sess := model.GetSessionFromContext(ctx)
+	data, err := h.apiKeyApp.List(ctx, sess.Account.ID)
+	if err != nil {
+		if err := apierror.Get(err, errorMap); err != nil {
+			return err
+		}
+		log.Error(err)
+		return apierror.ErrInternal
+	}
+	return c.JSON(http.StatusOK, apimodel.Response{
+		Data: data,
+	})
+}
+
+// deleteAPIKey swagger document
+// @Description Revoke an API key of current auth user
+// @Tags apikey
+// @Produce json
+// @Security UserToken
+// @Param id path string true "id of an API key"
+// @Success 204
+// @Failure 404 {object} model.Response{error=error.ApiError}
+// @Failure 500 {object} model.Response{error=error.ApiError}
+// @Router /api/v1/api-keys/{id} [delete]
+func (h *handler) deleteAPIKey(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := h.logger.WithContext(ctx)
+
+	sess := model.GetSessionFromContext(ctx)
+	if err := h.apiKeyApp.Revoke(ctx, sess.Account.ID, c.Param("id")); err != nil {
+		if err := apierror.Get(err, errorMap); err != nil {
+			return err
+		}
+		log.Error(err)
+		return apierror.ErrInternal
+	}
+	return c.NoContent(http.StatusNoContent)
+}
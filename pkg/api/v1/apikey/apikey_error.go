@@ -0,0 +1,17 @@
+package apikey
+
+import (
+	apierror "github.com/carlosrodriguesf/bank-api/pkg/api/error"
+	pkgerror "github.com/carlosrodriguesf/bank-api/pkg/error"
+	"net/http"
+)
+
+var errorMap = map[error]*apierror.ApiError{
+	pkgerror.ErrCantCreateAPIKey:        apierror.NewApiError(http.StatusInternalServerError, pkgerror.ErrCantCreateAPIKey.Error(), nil),
+	pkgerror.ErrCantListAPIKeys:         apierror.NewApiError(http.StatusInternalServerError, pkgerror.ErrCantListAPIKeys.Error(), nil),
+	pkgerror.ErrCantRevokeAPIKey:        apierror.NewApiError(http.StatusInternalServerError, pkgerror.ErrCantRevokeAPIKey.Error(), nil),
+	pkgerror.ErrAPIKeyNotFound:          apierror.NewApiError(http.StatusNotFound, pkgerror.ErrAPIKeyNotFound.Error(), nil),
+	pkgerror.ErrInvalidAPIKeyScopes:     apierror.NewApiError(http.StatusBadRequest, pkgerror.ErrInvalidAPIKeyScopes.Error(), nil),
+	pkgerror.ErrInvalidAPIKeyExpiration: apierror.NewApiError(http.StatusBadRequest, pkgerror.ErrInvalidAPIKeyExpiration.Error(), nil),
+	pkgerror.ErrAccountNotFound:         apierror.NewApiError(http.StatusNotFound, pkgerror.ErrAccountNotFound.Error(), nil),
+}
@@ -0,0 +1,9 @@
+package apikey
+
+import "time"
+
+type postAPIKeyBody struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
@@ -0,0 +1,280 @@
+package apikey
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	apierror "github.com/carlosrodriguesf/bank-api/pkg/api/error"
+	apimodel "github.com/carlosrodriguesf/bank-api/pkg/api/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/app/apikey"
+	pkgerror "github.com/carlosrodriguesf/bank-api/pkg/error"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandler_postAPIKey(t *testing.T) {
+	var (
+		endpoint    = "/api/v1/api-keys"
+		expiresAt   = time.Date(2030, 1, 1, 10, 0, 0, 0, time.UTC)
+		postExample = postAPIKeyBody{
+			Name:      "merchant backend",
+			Scopes:    []string{model.PermissionReadAccounts},
+			ExpiresAt: &expiresAt,
+		}
+		createExample = model.APIKeyCreation{
+			AccountID: "account_id",
+			Name:      postExample.Name,
+			Scopes:    postExample.Scopes,
+			ExpiresAt: postExample.ExpiresAt,
+		}
+		createdExample = model.APIKeyCreated{
+			APIKey: model.APIKey{
+				ID:        "api_key_id",
+				AccountID: "account_id",
+				Name:      postExample.Name,
+				Prefix:    "0a1b2c3d4e5f",
+				Scopes:    postExample.Scopes,
+				ExpiresAt: postExample.ExpiresAt,
+				CreatedAt: time.Date(2029, 1, 1, 10, 0, 0, 0, time.UTC),
+			},
+			Key: "bk_0a1b2c3d4e5f_secret",
+		}
+		bodyExample = func(t *testing.T) io.Reader {
+			body, err := json.Marshal(postExample)
+			assert.NoError(t, err)
+			return bytes.NewReader(body)
+		}
+	)
+
+	cases := map[string]struct {
+		InputData      func(t *testing.T) io.Reader
+		ExpectedData   *model.APIKeyCreated
+		ExpectedErr    error
+		PrepareMockApp func(mock *apikey.MockApp)
+	}{
+		"should return success": {
+			InputData:    bodyExample,
+			ExpectedData: &createdExample,
+			ExpectedErr:  nil,
+			PrepareMockApp: func(mock *apikey.MockApp) {
+				mock.EXPECT().Create(gomock.Any(), createExample).Return(&createdExample, nil)
+			},
+		},
+		"should return error on bind": {
+			InputData: func(t *testing.T) io.Reader {
+				return strings.NewReader("invalid body")
+			},
+			ExpectedData:   nil,
+			ExpectedErr:    apierror.ErrInvalidPayload,
+			PrepareMockApp: func(mock *apikey.MockApp) {},
+		},
+		"should return error: invalid scopes": {
+			InputData:    bodyExample,
+			ExpectedData: nil,
+			ExpectedErr:  errorMap[pkgerror.ErrInvalidAPIKeyScopes],
+			PrepareMockApp: func(mock *apikey.MockApp) {
+				mock.EXPECT().Create(gomock.Any(), createExample).Return(nil, pkgerror.ErrInvalidAPIKeyScopes)
+			},
+		},
+		"should return error": {
+			InputData:    bodyExample,
+			ExpectedData: nil,
+			ExpectedErr:  apierror.ErrInternal,
+			PrepareMockApp: func(mock *apikey.MockApp) {
+				mock.EXPECT().Create(gomock.Any(), createExample).Return(nil, errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			ctx = model.SetSessionOnContext(ctx, &model.Session{
+				Token:   "session_token",
+				Account: model.Account{ID: "account_id"},
+			})
+
+			mockApp := apikey.NewMockApp(ctrl)
+			cs.PrepareMockApp(mockApp)
+
+			h := handler{
+				logger:    logger.New(""),
+				apiKeyApp: mockApp,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, endpoint, cs.InputData(t)).WithContext(ctx)
+			rec := httptest.NewRecorder()
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			c := e.NewContext(req, rec)
+			c.SetPath(endpoint)
+
+			err := h.postAPIKey(c)
+
+			assert.Equal(t, cs.ExpectedErr, err)
+
+			expectedResponseJSON, err := json.Marshal(apimodel.Response{Data: cs.ExpectedData})
+			assert.NoError(t, err)
+
+			var expectedResponse apimodel.Response
+			err = json.Unmarshal(expectedResponseJSON, &expectedResponse)
+			assert.NoError(t, err)
+
+			var currentResponse apimodel.Response
+			json.NewDecoder(rec.Body).Decode(&currentResponse)
+
+			assert.Equal(t, expectedResponse, currentResponse)
+		})
+	}
+}
+
+func TestHandler_getAPIKeys(t *testing.T) {
+	var (
+		endpoint       = "/api/v1/api-keys"
+		apiKeysExample = []model.APIKey{{
+			ID:        "api_key_id",
+			AccountID: "account_id",
+			Name:      "merchant backend",
+			Prefix:    "0a1b2c3d4e5f",
+			Scopes:    []string{model.PermissionReadAccounts},
+			CreatedAt: time.Date(2029, 1, 1, 10, 0, 0, 0, time.UTC),
+		}}
+	)
+
+	cases := map[string]struct {
+		ExpectedData   []model.APIKey
+		ExpectedErr    error
+		PrepareMockApp func(mock *apikey.MockApp)
+	}{
+		"should return success": {
+			ExpectedData: apiKeysExample,
+			ExpectedErr:  nil,
+			PrepareMockApp: func(mock *apikey.MockApp) {
+				mock.EXPECT().List(gomock.Any(), "account_id").Return(apiKeysExample, nil)
+			},
+		},
+		"should return error": {
+			ExpectedData: nil,
+			ExpectedErr:  errorMap[pkgerror.ErrCantListAPIKeys],
+			PrepareMockApp: func(mock *apikey.MockApp) {
+				mock.EXPECT().List(gomock.Any(), "account_id").Return(nil, pkgerror.ErrCantListAPIKeys)
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			ctx = model.SetSessionOnContext(ctx, &model.Session{
+				Token:   "session_token",
+				Account: model.Account{ID: "account_id"},
+			})
+
+			mockApp := apikey.NewMockApp(ctrl)
+			cs.PrepareMockApp(mockApp)
+
+			h := handler{
+				logger:    logger.New(""),
+				apiKeyApp: mockApp,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, endpoint, nil).WithContext(ctx)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath(endpoint)
+
+			err := h.getAPIKeys(c)
+
+			assert.Equal(t, cs.ExpectedErr, err)
+
+			expectedResponseJSON, err := json.Marshal(apimodel.Response{Data: cs.ExpectedData})
+			assert.NoError(t, err)
+
+			var expectedResponse apimodel.Response
+			err = json.Unmarshal(expectedResponseJSON, &expectedResponse)
+			assert.NoError(t, err)
+
+			var currentResponse apimodel.Response
+			json.NewDecoder(rec.Body).Decode(&currentResponse)
+
+			assert.Equal(t, expectedResponse, currentResponse)
+		})
+	}
+}
+
+func TestHandler_deleteAPIKey(t *testing.T) {
+	endpoint := "/api/v1/api-keys/:id"
+
+	cases := map[string]struct {
+		ExpectedStatus int
+		ExpectedErr    error
+		PrepareMockApp func(mock *apikey.MockApp)
+	}{
+		"should return success": {
+			ExpectedStatus: http.StatusNoContent,
+			ExpectedErr:    nil,
+			PrepareMockApp: func(mock *apikey.MockApp) {
+				mock.EXPECT().Revoke(gomock.Any(), "account_id", "api_key_id").Return(nil)
+			},
+		},
+		"should return error: not found": {
+			ExpectedStatus: http.StatusOK,
+			ExpectedErr:    errorMap[pkgerror.ErrAPIKeyNotFound],
+			PrepareMockApp: func(mock *apikey.MockApp) {
+				mock.EXPECT().Revoke(gomock.Any(), "account_id", "api_key_id").Return(pkgerror.ErrAPIKeyNotFound)
+			},
+		},
+		"should return error": {
+			ExpectedStatus: http.StatusOK,
+			ExpectedErr:    apierror.ErrInternal,
+			PrepareMockApp: func(mock *apikey.MockApp) {
+				mock.EXPECT().Revoke(gomock.Any(), "account_id", "api_key_id").Return(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			ctx = model.SetSessionOnContext(ctx, &model.Session{
+				Token:   "session_token",
+				Account: model.Account{ID: "account_id"},
+			})
+
+			mockApp := apikey.NewMockApp(ctrl)
+			cs.PrepareMockApp(mockApp)
+
+			h := handler{
+				logger:    logger.New(""),
+				apiKeyApp: mockApp,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodDelete, endpoint, nil).WithContext(ctx)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath(endpoint)
+			c.SetParamNames("id")
+			c.SetParamValues("api_key_id")
+
+			err := h.deleteAPIKey(c)
+
+			assert.Equal(t, cs.ExpectedErr, err)
+			assert.Equal(t, cs.ExpectedStatus, rec.Code)
+		})
+	}
+}
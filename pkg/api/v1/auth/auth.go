@@ -24,6 +24,14 @@ func Register(g *echo.Group, opts apimodel.Options) {
 	}
 
 	g.POST("/login", h.login)
+	g.POST("/refresh", h.refresh)
+	g.POST("/logout", h.logout, opts.Middleware.Auth().Private, opts.Middleware.Auth().RequirePermission(model.PermissionManageCredentials))
+	g.GET("/sessions", h.getSessions, opts.Middleware.Auth().Private, opts.Middleware.Auth().RequirePermission(model.PermissionManageCredentials))
+	g.DELETE("/sessions/:id", h.deleteSession, opts.Middleware.Auth().Private, opts.Middleware.Auth().RequirePermission(model.PermissionManageCredentials))
+	g.POST("/sessions/revoke-others", h.revokeOtherSessions, opts.Middleware.Auth().Private, opts.Middleware.Auth().RequirePermission(model.PermissionManageCredentials))
+	g.POST("/secret", h.changeSecret, opts.Middleware.Auth().Private, opts.Middleware.Auth().RequirePermission(model.PermissionManageCredentials))
+	g.POST("/secret/reset-request", h.requestSecretReset)
+	g.POST("/secret/reset", h.resetSecret)
 
 	log.Info("registered")
 }
@@ -35,6 +43,7 @@ func Register(g *echo.Group, opts apimodel.Options) {
 // @Param credentials body model.Credentials true "expected structure"
 // @Success 200 {object} model.Response{data=model.Account}
 // @Success 400 {object} model.Response{error=error.ApiError}
+// @Failure 429 {object} model.Response{error=error.ApiError}
 // @Failure 500 {object} model.Response{error=error.ApiError}
 // @Router /api/v1/login [post]
 func (h *handler) login(c echo.Context) error {
@@ -46,6 +55,8 @@ func (h *handler) login(c echo.Context) error {
 		log.Error(err)
 		return apierror.ErrInvalidPayload
 	}
+	body.IP = c.RealIP()
+	body.Device = c.Request().UserAgent()
 
 	data, err := h.authApp.Auth(ctx, body)
 	if err != nil {
@@ -59,3 +70,223 @@ func (h *handler) login(c echo.Context) error {
 		Data: data,
 	})
 }
+
+// refresh swagger document
+// @Description Renew the access token of a session, replacing its refresh token
+// @Tags auth
+// @Produce json
+// @Param body body refreshBody true "expected structure"
+// @Success 200 {object} model.Response{data=model.Session}
+// @Failure 400 {object} model.Response{error=error.ApiError}
+// @Failure 401 {object} model.Response{error=error.ApiError}
+// @Failure 500 {object} model.Response{error=error.ApiError}
+// @Router /api/v1/refresh [post]
+func (h *handler) refresh(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := h.logger.WithContext(ctx)
+
+	var body refreshBody
+	if err := c.Bind(&body); err != nil {
+		log.Error(err)
+		return apierror.ErrInvalidPayload
+	}
+
+	data, err := h.authApp.Refresh(ctx, body.RefreshToken)
+	if err != nil {
+		if err := apierror.Get(err, errorMap); err != nil {
+			return err
+		}
+		log.Error(err)
+		return apierror.ErrInternal
+	}
+	return c.JSON(http.StatusOK, apimodel.Response{
+		Data: data,
+	})
+}
+
+// logout swagger document
+// @Description End the session of current auth user
+// @Tags auth
+// @Produce json
+// @Security UserToken
+// @Success 204
+// @Failure 500 {object} model.Response{error=error.ApiError}
+// @Router /api/v1/logout [post]
+func (h *handler) logout(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := h.logger.WithContext(ctx)
+
+	sess := model.GetSessionFromContext(ctx)
+	if err := h.authApp.Logout(ctx, *sess); err != nil {
+		if err := apierror.Get(err, errorMap); err != nil {
+			return err
+		}
+		log.Error(err)
+		return apierror.ErrInternal
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// getSessions swagger document
+// @Description List the active sessions of current auth user
+// @Tags auth
+// @Produce json
+// @Security UserToken
+// @Success 200 {object} model.Response{data=[]model.SessionInfo}
+// @Failure 500 {object} model.Response{error=error.ApiError}
+// @Router /api/v1/sessions [get]
+func (h *handler) getSessions(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := h.logger.WithContext(ctx)
+
+	sess := model.GetSessionFromContext(ctx)
+	data, err := h.authApp.ListSessions(ctx, sess.Account.ID, sess.ID)
+	if err != nil {
+		if err := apierror.Get(err, errorMap); err != nil {
+			return err
+		}
+		log.Error(err)
+		return apierror.ErrInternal
+	}
+	return c.JSON(http.StatusOK, apimodel.Response{
+		Data: data,
+	})
+}
+
+// deleteSession swagger document
+// @Description End a session of current auth user
+// @Tags auth
+// @Produce json
+// @Security UserToken
+// @Param id path string true "id of a session"
+// @Success 204
+// @Failure 404 {object} model.Response{error=error.ApiError}
+// @Failure 500 {object} model.Response{error=error.ApiError}
+// @Router /api/v1/sessions/{id} [delete]
+func (h *handler) deleteSession(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := h.logger.WithContext(ctx)
+
+	sess := model.GetSessionFromContext(ctx)
+	if err := h.authApp.RevokeSession(ctx, sess.Account.ID, c.Param("id")); err != nil {
+		if err := apierror.Get(err, errorMap); err != nil {
+			return err
+		}
+		log.Error(err)
+		return apierror.ErrInternal
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// revokeOtherSessions swagger document
+// @Description End every session of current auth user but the one making the request
+// @Tags auth
+// @Produce json
+// @Security UserToken
+// @Success 204
+// @Failure 500 {object} model.Response{error=error.ApiError}
+// @Router /api/v1/sessions/revoke-others [post]
+func (h *handler) revokeOtherSessions(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := h.logger.WithContext(ctx)
+
+	sess := model.GetSessionFromContext(ctx)
+	if err := h.authApp.RevokeSessions(ctx, sess.Account.ID, sess.ID); err != nil {
+		if err := apierror.Get(err, errorMap); err != nil {
+			return err
+		}
+		log.Error(err)
+		return apierror.ErrInternal
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// changeSecret swagger document
+// @Description Replace the secret of current auth user, ending all of its sessions
+// @Tags auth
+// @Produce json
+// @Security UserToken
+// @Param body body model.SecretChange true "expected structure"
+// @Success 204
+// @Failure 400 {object} model.Response{error=error.ApiError}
+// @Failure 403 {object} model.Response{error=error.ApiError}
+// @Failure 500 {object} model.Response{error=error.ApiError}
+// @Router /api/v1/secret [post]
+func (h *handler) changeSecret(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := h.logger.WithContext(ctx)
+
+	var body model.SecretChange
+	if err := c.Bind(&body); err != nil {
+		log.Error(err)
+		return apierror.ErrInvalidPayload
+	}
+	body.AccountID = model.GetSessionFromContext(ctx).Account.ID
+
+	if err := h.authApp.ChangeSecret(ctx, body); err != nil {
+		if err := apierror.Get(err, errorMap); err != nil {
+			return err
+		}
+		log.Error(err)
+		return apierror.ErrInternal
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// requestSecretReset swagger document
+// @Description Send a secret reset token to the holder of an account. The response is the same whether the account exists or not
+// @Tags auth
+// @Produce json
+// @Param body body secretResetRequestBody true "expected structure"
+// @Success 202
+// @Failure 400 {object} model.Response{error=error.ApiError}
+// @Failure 500 {object} model.Response{error=error.ApiError}
+// @Router /api/v1/secret/reset-request [post]
+func (h *handler) requestSecretReset(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := h.logger.WithContext(ctx)
+
+	var body secretResetRequestBody
+	if err := c.Bind(&body); err != nil {
+		log.Error(err)
+		return apierror.ErrInvalidPayload
+	}
+
+	if err := h.authApp.RequestSecretReset(ctx, body.Document); err != nil {
+		if err := apierror.Get(err, errorMap); err != nil {
+			return err
+		}
+		log.Error(err)
+		return apierror.ErrInternal
+	}
+	return c.NoContent(http.StatusAccepted)
+}
+
+// resetSecret swagger document
+// @Description Replace the secret of an account with a secret reset token, ending all of its sessions
+// @Tags auth
+// @Produce json
+// @Param body body model.SecretReset true "expected structure"
+// @Success 204
+// @Failure 400 {object} model.Response{error=error.ApiError}
+// @Failure 500 {object} model.Response{error=error.ApiError}
+// @Router /api/v1/secret/reset [post]
+func (h *handler) resetSecret(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := h.logger.WithContext(ctx)
+
+	var body model.SecretReset
+	if err := c.Bind(&body); err != nil {
+		log.Error(err)
+		return apierror.ErrInvalidPayload
+	}
+
+	if err := h.authApp.ResetSecret(ctx, body); err != nil {
+		if err := apierror.Get(err, errorMap); err != nil {
+			return err
+		}
+		log.Error(err)
+		return apierror.ErrInternal
+	}
+	return c.NoContent(http.StatusNoContent)
+}
@@ -7,6 +7,22 @@ import (
 )
 
 var errorMap = map[error]*apierror.ApiError{
-	pkgerror.ErrCantAuth:           apierror.NewApiError(http.StatusInternalServerError, pkgerror.ErrCantAuth.Error(), nil),
-	pkgerror.ErrInvalidCredentials: apierror.NewApiError(http.StatusUnauthorized, pkgerror.ErrInvalidCredentials.Error(), nil),
+	pkgerror.ErrCantAuth:             apierror.NewApiError(http.StatusInternalServerError, pkgerror.ErrCantAuth.Error(), nil),
+	pkgerror.ErrInvalidCredentials:   apierror.NewApiError(http.StatusUnauthorized, pkgerror.ErrInvalidCredentials.Error(), nil),
+	pkgerror.ErrAccountClosed:        apierror.NewApiError(http.StatusForbidden, pkgerror.ErrAccountClosed.Error(), nil),
+	pkgerror.ErrAccountLocked:        apierror.NewApiError(http.StatusTooManyRequests, pkgerror.ErrAccountLocked.Error(), nil),
+	pkgerror.ErrTooManyAttempts:      apierror.NewApiError(http.StatusTooManyRequests, pkgerror.ErrTooManyAttempts.Error(), nil),
+	pkgerror.ErrSessionNotFound:      apierror.NewApiError(http.StatusNotFound, pkgerror.ErrSessionNotFound.Error(), nil),
+	pkgerror.ErrCantListSessions:     apierror.NewApiError(http.StatusInternalServerError, pkgerror.ErrCantListSessions.Error(), nil),
+	pkgerror.ErrCantRevokeSession:    apierror.NewApiError(http.StatusInternalServerError, pkgerror.ErrCantRevokeSession.Error(), nil),
+	pkgerror.ErrCantRefresh:          apierror.NewApiError(http.StatusInternalServerError, pkgerror.ErrCantRefresh.Error(), nil),
+	pkgerror.ErrInvalidRefresh:       apierror.NewApiError(http.StatusUnauthorized, pkgerror.ErrInvalidRefresh.Error(), nil),
+	pkgerror.ErrRefreshReused:        apierror.NewApiError(http.StatusUnauthorized, pkgerror.ErrRefreshReused.Error(), nil),
+	pkgerror.ErrTwoFactorRequired:    apierror.NewApiError(http.StatusUnauthorized, pkgerror.ErrTwoFactorRequired.Error(), nil),
+	pkgerror.ErrInvalidTwoFactorCode: apierror.NewApiError(http.StatusUnauthorized, pkgerror.ErrInvalidTwoFactorCode.Error(), nil),
+	pkgerror.ErrCantChangeSecret:     apierror.NewApiError(http.StatusInternalServerError, pkgerror.ErrCantChangeSecret.Error(), nil),
+	pkgerror.ErrInvalidSecret:        apierror.NewApiError(http.StatusForbidden, pkgerror.ErrInvalidSecret.Error(), nil),
+	pkgerror.ErrCantResetSecret:      apierror.NewApiError(http.StatusInternalServerError, pkgerror.ErrCantResetSecret.Error(), nil),
+	pkgerror.ErrInvalidResetToken:    apierror.NewApiError(http.StatusBadRequest, pkgerror.ErrInvalidResetToken.Error(), nil),
+	pkgerror.ErrAccountNotFound:      apierror.NewApiError(http.StatusNotFound, pkgerror.ErrAccountNotFound.Error(), nil),
 }
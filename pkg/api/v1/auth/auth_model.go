@@ -0,0 +1,10 @@
+package auth
+
+type (
+	refreshBody struct {
+		RefreshToken string `json:"refreshToken"`
+	}
+	secretResetRequestBody struct {
+		Document string `json:"document"`
+	}
+)
@@ -29,6 +29,7 @@ func TestHandler_Login(t *testing.T) {
 		credentialsExample = model.Credentials{
 			Document: "123.123.123-18",
 			Secret:   "1234",
+			IP:       "192.0.2.1",
 		}
 		accountExample = model.Account{
 			ID:       "account_id",
@@ -111,6 +112,20 @@ func TestHandler_Login(t *testing.T) {
 					Return(nil, pkgerror.ErrInvalidCredentials)
 			},
 		},
+		"should return error: account locked": {
+			InputData: func(t *testing.T) io.Reader {
+				body, err := json.Marshal(credentialsExample)
+				assert.NoError(t, err)
+				return bytes.NewReader(body)
+			},
+			ExpectedData: nil,
+			ExpectedErr:  errorMap[pkgerror.ErrAccountLocked],
+			PrepareMockApp: func(mock *auth.MockApp) {
+				mock.EXPECT().
+					Auth(gomock.Any(), credentialsExample).
+					Return(nil, pkgerror.ErrAccountLocked)
+			},
+		},
 		"should return error": {
 			InputData: func(t *testing.T) io.Reader {
 				body, err := json.Marshal(credentialsExample)
@@ -165,3 +180,582 @@ func TestHandler_Login(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_refresh(t *testing.T) {
+	var (
+		endpoint       = "/api/v1/refresh"
+		expiresAt      = time.Now().Add(5 * time.Minute)
+		sessionExample = model.Session{
+			ID:           "session_id",
+			Token:        "access_token",
+			RefreshToken: "new_refresh_token",
+			ExpiresAt:    &expiresAt,
+			Account:      model.Account{ID: "account_id"},
+		}
+	)
+
+	cases := map[string]struct {
+		InputBody      string
+		ExpectedData   *model.Session
+		ExpectedErr    error
+		PrepareMockApp func(mock *auth.MockApp)
+	}{
+		"should return success": {
+			InputBody:    `{"refreshToken":"refresh_token"}`,
+			ExpectedData: &sessionExample,
+			ExpectedErr:  nil,
+			PrepareMockApp: func(mock *auth.MockApp) {
+				mock.EXPECT().
+					Refresh(gomock.Any(), "refresh_token").
+					Return(&sessionExample, nil)
+			},
+		},
+		"should return error on bind": {
+			InputBody:      "invalid body",
+			ExpectedData:   nil,
+			ExpectedErr:    apierror.ErrInvalidPayload,
+			PrepareMockApp: func(mock *auth.MockApp) {},
+		},
+		"should return error: refresh token reused": {
+			InputBody:    `{"refreshToken":"refresh_token"}`,
+			ExpectedData: nil,
+			ExpectedErr:  errorMap[pkgerror.ErrRefreshReused],
+			PrepareMockApp: func(mock *auth.MockApp) {
+				mock.EXPECT().
+					Refresh(gomock.Any(), "refresh_token").
+					Return(nil, pkgerror.ErrRefreshReused)
+			},
+		},
+		"should return error": {
+			InputBody:    `{"refreshToken":"refresh_token"}`,
+			ExpectedData: nil,
+			ExpectedErr:  apierror.ErrInternal,
+			PrepareMockApp: func(mock *auth.MockApp) {
+				mock.EXPECT().
+					Refresh(gomock.Any(), "refresh_token").
+					Return(nil, errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			mockApp := auth.NewMockApp(ctrl)
+
+			cs.PrepareMockApp(mockApp)
+
+			h := handler{
+				logger:  logger.New(""),
+				authApp: mockApp,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, endpoint, strings.NewReader(cs.InputBody)).WithContext(ctx)
+			rec := httptest.NewRecorder()
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			c := e.NewContext(req, rec)
+			c.SetPath(endpoint)
+
+			err := h.refresh(c)
+
+			assert.Equal(t, cs.ExpectedErr, err)
+
+			expectedResponseJSON, err := json.Marshal(apimodel.Response{Data: cs.ExpectedData})
+			assert.NoError(t, err)
+
+			var expectedResponse apimodel.Response
+			err = json.Unmarshal(expectedResponseJSON, &expectedResponse)
+			assert.NoError(t, err)
+
+			var currentResponse apimodel.Response
+			json.NewDecoder(rec.Body).Decode(&currentResponse)
+
+			assert.Equal(t, expectedResponse, currentResponse)
+		})
+	}
+}
+
+func TestHandler_logout(t *testing.T) {
+	var (
+		endpoint       = "/api/v1/logout"
+		sessionExample = model.Session{
+			ID:      "session_id",
+			Token:   "session_token",
+			Account: model.Account{ID: "account_id"},
+		}
+	)
+
+	cases := map[string]struct {
+		ExpectedStatus int
+		ExpectedErr    error
+		PrepareMockApp func(mock *auth.MockApp)
+	}{
+		"should return success": {
+			ExpectedStatus: http.StatusNoContent,
+			ExpectedErr:    nil,
+			PrepareMockApp: func(mock *auth.MockApp) {
+				mock.EXPECT().Logout(gomock.Any(), sessionExample).Return(nil)
+			},
+		},
+		"should return error: cant revoke session": {
+			ExpectedErr: errorMap[pkgerror.ErrCantRevokeSession],
+			PrepareMockApp: func(mock *auth.MockApp) {
+				mock.EXPECT().Logout(gomock.Any(), sessionExample).Return(pkgerror.ErrCantRevokeSession)
+			},
+		},
+		"should return internal error": {
+			ExpectedErr: apierror.ErrInternal,
+			PrepareMockApp: func(mock *auth.MockApp) {
+				mock.EXPECT().Logout(gomock.Any(), sessionExample).Return(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			ctx = model.SetSessionOnContext(ctx, &sessionExample)
+
+			mockApp := auth.NewMockApp(ctrl)
+
+			cs.PrepareMockApp(mockApp)
+
+			h := handler{
+				logger:  logger.New(""),
+				authApp: mockApp,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, endpoint, nil).WithContext(ctx)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath(endpoint)
+
+			err := h.logout(c)
+
+			assert.Equal(t, cs.ExpectedErr, err)
+			if cs.ExpectedErr == nil {
+				assert.Equal(t, cs.ExpectedStatus, rec.Code)
+			}
+		})
+	}
+}
+
+func TestHandler_getSessions(t *testing.T) {
+	var (
+		endpoint       = "/api/v1/sessions"
+		sessionExample = model.Session{
+			ID:      "session_id",
+			Token:   "session_token",
+			Account: model.Account{ID: "account_id"},
+		}
+		sessionsExample = []model.SessionInfo{{
+			ID:         "session_id",
+			Device:     "browser",
+			IP:         "192.0.2.1",
+			Current:    true,
+			CreatedAt:  time.Now(),
+			LastSeenAt: time.Now(),
+		}}
+	)
+
+	cases := map[string]struct {
+		ExpectedData   []model.SessionInfo
+		ExpectedErr    error
+		PrepareMockApp func(mock *auth.MockApp)
+	}{
+		"should return success": {
+			ExpectedData: sessionsExample,
+			ExpectedErr:  nil,
+			PrepareMockApp: func(mock *auth.MockApp) {
+				mock.EXPECT().
+					ListSessions(gomock.Any(), "account_id", "session_id").
+					Return(sessionsExample, nil)
+			},
+		},
+		"should return error: cant list sessions": {
+			ExpectedData: nil,
+			ExpectedErr:  errorMap[pkgerror.ErrCantListSessions],
+			PrepareMockApp: func(mock *auth.MockApp) {
+				mock.EXPECT().
+					ListSessions(gomock.Any(), "account_id", "session_id").
+					Return(nil, pkgerror.ErrCantListSessions)
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			ctx = model.SetSessionOnContext(ctx, &sessionExample)
+
+			mockApp := auth.NewMockApp(ctrl)
+
+			cs.PrepareMockApp(mockApp)
+
+			h := handler{
+				logger:  logger.New(""),
+				authApp: mockApp,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, endpoint, nil).WithContext(ctx)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath(endpoint)
+
+			err := h.getSessions(c)
+
+			assert.Equal(t, cs.ExpectedErr, err)
+
+			expectedResponseJSON, err := json.Marshal(apimodel.Response{Data: cs.ExpectedData})
+			assert.NoError(t, err)
+
+			var expectedResponse apimodel.Response
+			err = json.Unmarshal(expectedResponseJSON, &expectedResponse)
+			assert.NoError(t, err)
+
+			var currentResponse apimodel.Response
+			json.NewDecoder(rec.Body).Decode(&currentResponse)
+
+			assert.Equal(t, expectedResponse, currentResponse)
+		})
+	}
+}
+
+func TestHandler_deleteSession(t *testing.T) {
+	var (
+		endpoint       = "/api/v1/sessions/other_session_id"
+		sessionExample = model.Session{
+			ID:      "session_id",
+			Token:   "session_token",
+			Account: model.Account{ID: "account_id"},
+		}
+	)
+
+	cases := map[string]struct {
+		ExpectedStatus int
+		ExpectedErr    error
+		PrepareMockApp func(mock *auth.MockApp)
+	}{
+		"should return success": {
+			ExpectedStatus: http.StatusNoContent,
+			ExpectedErr:    nil,
+			PrepareMockApp: func(mock *auth.MockApp) {
+				mock.EXPECT().RevokeSession(gomock.Any(), "account_id", "other_session_id").Return(nil)
+			},
+		},
+		"should return error: session not found": {
+			ExpectedErr: errorMap[pkgerror.ErrSessionNotFound],
+			PrepareMockApp: func(mock *auth.MockApp) {
+				mock.EXPECT().
+					RevokeSession(gomock.Any(), "account_id", "other_session_id").
+					Return(pkgerror.ErrSessionNotFound)
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			ctx = model.SetSessionOnContext(ctx, &sessionExample)
+
+			mockApp := auth.NewMockApp(ctrl)
+
+			cs.PrepareMockApp(mockApp)
+
+			h := handler{
+				logger:  logger.New(""),
+				authApp: mockApp,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodDelete, endpoint, nil).WithContext(ctx)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath(endpoint)
+			c.SetParamNames("id")
+			c.SetParamValues("other_session_id")
+
+			err := h.deleteSession(c)
+
+			assert.Equal(t, cs.ExpectedErr, err)
+			if cs.ExpectedErr == nil {
+				assert.Equal(t, cs.ExpectedStatus, rec.Code)
+			}
+		})
+	}
+}
+
+func TestHandler_revokeOtherSessions(t *testing.T) {
+	var (
+		endpoint       = "/api/v1/sessions/revoke-others"
+		sessionExample = model.Session{
+			ID:      "session_id",
+			Token:   "session_token",
+			Account: model.Account{ID: "account_id"},
+		}
+	)
+
+	cases := map[string]struct {
+		ExpectedStatus int
+		ExpectedErr    error
+		PrepareMockApp func(mock *auth.MockApp)
+	}{
+		"should return success": {
+			ExpectedStatus: http.StatusNoContent,
+			ExpectedErr:    nil,
+			PrepareMockApp: func(mock *auth.MockApp) {
+				mock.EXPECT().RevokeSessions(gomock.Any(), "account_id", "session_id").Return(nil)
+			},
+		},
+		"should return error: cant revoke session": {
+			ExpectedErr: errorMap[pkgerror.ErrCantRevokeSession],
+			PrepareMockApp: func(mock *auth.MockApp) {
+				mock.EXPECT().
+					RevokeSessions(gomock.Any(), "account_id", "session_id").
+					Return(pkgerror.ErrCantRevokeSession)
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			ctx = model.SetSessionOnContext(ctx, &sessionExample)
+
+			mockApp := auth.NewMockApp(ctrl)
+
+			cs.PrepareMockApp(mockApp)
+
+			h := handler{
+				logger:  logger.New(""),
+				authApp: mockApp,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, endpoint, nil).WithContext(ctx)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath(endpoint)
+
+			err := h.revokeOtherSessions(c)
+
+			assert.Equal(t, cs.ExpectedErr, err)
+			if cs.ExpectedErr == nil {
+				assert.Equal(t, cs.ExpectedStatus, rec.Code)
+			}
+		})
+	}
+}
+
+func TestHandler_changeSecret(t *testing.T) {
+	var (
+		endpoint       = "/api/v1/secret"
+		sessionExample = model.Session{
+			ID:      "session_id",
+			Token:   "session_token",
+			Account: model.Account{ID: "account_id"},
+		}
+		changeExample = model.SecretChange{
+			AccountID:     "account_id",
+			CurrentSecret: "current",
+			NewSecret:     "new",
+		}
+	)
+
+	cases := map[string]struct {
+		InputBody      string
+		ExpectedStatus int
+		ExpectedErr    error
+		PrepareMockApp func(mock *auth.MockApp)
+	}{
+		"should return success": {
+			InputBody:      `{"current_secret":"current","new_secret":"new"}`,
+			ExpectedStatus: http.StatusNoContent,
+			ExpectedErr:    nil,
+			PrepareMockApp: func(mock *auth.MockApp) {
+				mock.EXPECT().ChangeSecret(gomock.Any(), changeExample).Return(nil)
+			},
+		},
+		"should return error on bind": {
+			InputBody:      "invalid body",
+			ExpectedErr:    apierror.ErrInvalidPayload,
+			PrepareMockApp: func(mock *auth.MockApp) {},
+		},
+		"should return error: invalid current secret": {
+			InputBody:   `{"current_secret":"current","new_secret":"new"}`,
+			ExpectedErr: errorMap[pkgerror.ErrInvalidSecret],
+			PrepareMockApp: func(mock *auth.MockApp) {
+				mock.EXPECT().ChangeSecret(gomock.Any(), changeExample).Return(pkgerror.ErrInvalidSecret)
+			},
+		},
+		"should return error": {
+			InputBody:   `{"current_secret":"current","new_secret":"new"}`,
+			ExpectedErr: apierror.ErrInternal,
+			PrepareMockApp: func(mock *auth.MockApp) {
+				mock.EXPECT().ChangeSecret(gomock.Any(), changeExample).Return(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			ctx = model.SetSessionOnContext(ctx, &sessionExample)
+
+			mockApp := auth.NewMockApp(ctrl)
+
+			cs.PrepareMockApp(mockApp)
+
+			h := handler{
+				logger:  logger.New(""),
+				authApp: mockApp,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, endpoint, strings.NewReader(cs.InputBody)).WithContext(ctx)
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath(endpoint)
+
+			err := h.changeSecret(c)
+
+			assert.Equal(t, cs.ExpectedErr, err)
+			if cs.ExpectedErr == nil {
+				assert.Equal(t, cs.ExpectedStatus, rec.Code)
+			}
+		})
+	}
+}
+
+func TestHandler_requestSecretReset(t *testing.T) {
+	endpoint := "/api/v1/secret/reset-request"
+
+	cases := map[string]struct {
+		InputBody      string
+		ExpectedStatus int
+		ExpectedErr    error
+		PrepareMockApp func(mock *auth.MockApp)
+	}{
+		"should return success": {
+			InputBody:      `{"document":"123.123.123-12"}`,
+			ExpectedStatus: http.StatusAccepted,
+			ExpectedErr:    nil,
+			PrepareMockApp: func(mock *auth.MockApp) {
+				mock.EXPECT().RequestSecretReset(gomock.Any(), "123.123.123-12").Return(nil)
+			},
+		},
+		"should return error on bind": {
+			InputBody:      "invalid body",
+			ExpectedErr:    apierror.ErrInvalidPayload,
+			PrepareMockApp: func(mock *auth.MockApp) {},
+		},
+		"should return error: cant reset secret": {
+			InputBody:   `{"document":"123.123.123-12"}`,
+			ExpectedErr: errorMap[pkgerror.ErrCantResetSecret],
+			PrepareMockApp: func(mock *auth.MockApp) {
+				mock.EXPECT().RequestSecretReset(gomock.Any(), "123.123.123-12").Return(pkgerror.ErrCantResetSecret)
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			mockApp := auth.NewMockApp(ctrl)
+
+			cs.PrepareMockApp(mockApp)
+
+			h := handler{
+				logger:  logger.New(""),
+				authApp: mockApp,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, endpoint, strings.NewReader(cs.InputBody)).WithContext(ctx)
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath(endpoint)
+
+			err := h.requestSecretReset(c)
+
+			assert.Equal(t, cs.ExpectedErr, err)
+			if cs.ExpectedErr == nil {
+				assert.Equal(t, cs.ExpectedStatus, rec.Code)
+			}
+		})
+	}
+}
+
+func TestHandler_resetSecret(t *testing.T) {
+	var (
+		endpoint     = "/api/v1/secret/reset"
+		resetExample = model.SecretReset{
+			Token:     "reset_token",
+			NewSecret: "new",
+		}
+	)
+
+	cases := map[string]struct {
+		InputBody      string
+		ExpectedStatus int
+		ExpectedErr    error
+		PrepareMockApp func(mock *auth.MockApp)
+	}{
+		"should return success": {
+			InputBody:      `{"token":"reset_token","new_secret":"new"}`,
+			ExpectedStatus: http.StatusNoContent,
+			ExpectedErr:    nil,
+			PrepareMockApp: func(mock *auth.MockApp) {
+				mock.EXPECT().ResetSecret(gomock.Any(), resetExample).Return(nil)
+			},
+		},
+		"should return error on bind": {
+			InputBody:      "invalid body",
+			ExpectedErr:    apierror.ErrInvalidPayload,
+			PrepareMockApp: func(mock *auth.MockApp) {},
+		},
+		"should return error: invalid reset token": {
+			InputBody:   `{"token":"reset_token","new_secret":"new"}`,
+			ExpectedErr: errorMap[pkgerror.ErrInvalidResetToken],
+			PrepareMockApp: func(mock *auth.MockApp) {
+				mock.EXPECT().ResetSecret(gomock.Any(), resetExample).Return(pkgerror.ErrInvalidResetToken)
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			mockApp := auth.NewMockApp(ctrl)
+
+			cs.PrepareMockApp(mockApp)
+
+			h := handler{
+				logger:  logger.New(""),
+				authApp: mockApp,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, endpoint, strings.NewReader(cs.InputBody)).WithContext(ctx)
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath(endpoint)
+
+			err := h.resetSecret(c)
+
+			assert.Equal(t, cs.ExpectedErr, err)
+			if cs.ExpectedErr == nil {
+				assert.Equal(t, cs.ExpectedStatus, rec.Code)
+			}
+		})
+	}
+}
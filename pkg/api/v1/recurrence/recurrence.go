@@ -0,0 +1,162 @@
+package recurrence
+
+import (
+	apierror "github.com/carlosrodriguesf/bank-api/pkg/api/error"
+	apimodel "github.com/carlosrodriguesf/bank-api/pkg/api/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/app/recurrence"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type handler struct {
+	logger        logger.Logger
+	recurrenceApp recurrence.App
+}
+
+func Register(g *echo.Group, opts apimodel.Options) {
+	log := opts.Logger.WithPreffix("api.v1.recurrence")
+	h := handler{
+		logger:        log.WithLocation(),
+		recurrenceApp: opts.App.Recurrence(),
+	}
+
+	g.POST("/recurring-transfers", h.postRecurringTransfer, opts.Middleware.Auth().Private, opts.Middleware.Auth().RequirePermission(model.PermissionCreateTransfers), opts.Middleware.Idempotency().Handle)
+	g.GET("/recurring-transfers", h.getRecurringTransfers, opts.Middleware.Auth().Private, opts.Middleware.Auth().RequirePermission(model.PermissionReadTransfers))
+	g.GET("/recurring-transfers/:id/runs", h.getRecurringTransferRuns, opts.Middleware.Auth().Private, opts.Middleware.Auth().RequirePermission(model.PermissionReadTransfers))
+	g.POST("/recurring-transfers/:id/cancel", h.cancelRecurringTransfer, opts.Middleware.Auth().Private, opts.Middleware.Auth().RequirePermission(model.PermissionCreateTransfers))
+
+	log.Info("registered")
+}
+
+// postRecurringTransfer swagger document
+// @Description Create a standing order that transfers money on a daily, weekly or monthly recurrence
+// @Tags recurrence
+// @Produce json
+// @Security UserToken
+// @Param Idempotency-Key header string false "key to safely retry the request"
+// @Param recurringTransfer body postRecurringTransferBody true "expected structure"
+// @Success 200 {object} model.Response{data=model.RecurringTransfer}
+// @Success 400 {object} model.Response{error=error.ApiError}
+// @Failure 500 {object} model.Response{error=error.ApiError}
+// @Router /api/v1/recurring-transfers [post]
+func (h *handler) postRecurringTransfer(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := h.logger.WithContext(ctx)
+
+	body := new(postRecurringTransferBody)
+	if err := c.Bind(body); err != nil {
+		log.Error(err)
+		return apierror.ErrInvalidPayload
+	}
+
+	sess := model.GetSessionFromContext(ctx)
+	data, err := h.recurrenceApp.Create(ctx, model.RecurringTransfer{
+		OriginAccountID: sess.Account.ID,
+		TargetAccountID: body.TargetAccountID,
+		Amount:          body.Amount,
+		RecurrenceRule: model.RecurrenceRule{
+			Frequency:      body.Frequency,
+			DayOfMonth:     body.DayOfMonth,
+			StartAt:        body.StartAt,
+			EndAt:          body.EndAt,
+			MaxOccurrences: body.MaxOccurrences,
+		},
+		InsufficientFundsPolicy: body.InsufficientFundsPolicy,
+		MaxRetries:              body.MaxRetries,
+		TwoFactorCode:           body.TwoFactorCode,
+	})
+	if err != nil {
+		if err := apierror.Get(err, errorMap); err != nil {
+			return err
+		}
+		log.Error(err)
+		return apierror.ErrInternal
+	}
+	return c.JSON(http.StatusOK, apimodel.Response{
+		Data: data,
+	})
+}
+
+// getRecurringTransfers swagger document
+// @Description List standing orders of current auth user
+// @Tags recurrence
+// @Produce json
+// @Security UserToken
+// @Success 200 {object} model.Response{data=[]model.RecurringTransfer}
+// @Failure 500 {object} model.Response{error=error.ApiError}
+// @Router /api/v1/recurring-transfers [get]
+func (h *handler) getRecurringTransfers(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := h.logger.WithContext(ctx)
+
+	sess := model.GetSessionFromContext(ctx)
+	data, err := h.recurrenceApp.List(ctx, sess.Account.ID)
+	if err != nil {
+		if err := apierror.Get(err, errorMap); err != nil {
+			return err
+		}
+		log.Error(err)
+		return apierror.ErrInternal
+	}
+	return c.JSON(http.StatusOK, apimodel.Response{
+		Data: data,
+	})
+}
+
+// getRecurringTransferRuns swagger document
+// @Description List the outcome of each run of a standing order of current auth user
+// @Tags recurrence
+// @Produce json
+// @Security UserToken
+// @Param id path string true "id of a recurring transfer"
+// @Success 200 {object} model.Response{data=[]model.RecurringTransferRun}
+// @Success 400 {object} model.Response{error=error.ApiError}
+// @Failure 500 {object} model.Response{error=error.ApiError}
+// @Router /api/v1/recurring-transfers/{id}/runs [get]
+func (h *handler) getRecurringTransferRuns(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := h.logger.WithContext(ctx)
+
+	sess := model.GetSessionFromContext(ctx)
+	data, err := h.recurrenceApp.ListRuns(ctx, sess.Account.ID, c.Param("id"))
+	if err != nil {
+		if err := apierror.Get(err, errorMap); err != nil {
+			return err
+		}
+		log.Error(err)
+		return apierror.ErrInternal
+	}
+	return c.JSON(http.StatusOK, apimodel.Response{
+		Data: data,
+	})
+}
+
+// cancelRecurringTransfer swagger document
+// @Description Cancel an active standing order of current auth user
+// @Tags recurrence
+// @Produce json
+// @Security UserToken
+// @Param id path string true "id of a recurring transfer"
+// @Success 200 {object} model.Response{data=model.RecurringTransfer}
+// @Success 400 {object} model.Response{error=error.ApiError}
+// @Failure 500 {object} model.Response{error=error.ApiError}
+// @Router /api/v1/recurring-transfers/{id}/cancel [post]
+func (h *handler) cancelRecurringTransfer(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := h.logger.WithContext(ctx)
+
+	sess := model.GetSessionFromContext(ctx)
+	data, err := h.recurrenceApp.Cancel(ctx, sess.Account.ID, c.Param("id"))
+	if err != nil {
+		if err := apierror.Get(err, errorMap); err != nil {
+			return err
+		}
+		log.Error(err)
+		return apierror.ErrInternal
+	}
+	return c.JSON(http.StatusOK, apimodel.Response{
+		Data: data,
+	})
+}
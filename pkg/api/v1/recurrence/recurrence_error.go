@@ -0,0 +1,25 @@
+package recurrence
+
+import (
+	apierror "github.com/carlosrodriguesf/bank-api/pkg/api/error"
+	pkgerror "github.com/carlosrodriguesf/bank-api/pkg/error"
+	"net/http"
+)
+
+var errorMap = map[error]*apierror.ApiError{
+	pkgerror.ErrCantCreateRecurringTransfer:   apierror.NewApiError(http.StatusInternalServerError, pkgerror.ErrCantCreateRecurringTransfer.Error(), nil),
+	pkgerror.ErrCantListRecurringTransfers:    apierror.NewApiError(http.StatusInternalServerError, pkgerror.ErrCantListRecurringTransfers.Error(), nil),
+	pkgerror.ErrCantListRecurringTransferRuns: apierror.NewApiError(http.StatusInternalServerError, pkgerror.ErrCantListRecurringTransferRuns.Error(), nil),
+	pkgerror.ErrCantCancelRecurringTransfer:   apierror.NewApiError(http.StatusInternalServerError, pkgerror.ErrCantCancelRecurringTransfer.Error(), nil),
+	pkgerror.ErrRecurringTransferNotFound:     apierror.NewApiError(http.StatusNotFound, pkgerror.ErrRecurringTransferNotFound.Error(), nil),
+	pkgerror.ErrRecurringTransferNotActive:    apierror.NewApiError(http.StatusBadRequest, pkgerror.ErrRecurringTransferNotActive.Error(), nil),
+	pkgerror.ErrRecurrenceStartAtInPast:       apierror.NewApiError(http.StatusBadRequest, pkgerror.ErrRecurrenceStartAtInPast.Error(), nil),
+	pkgerror.ErrRecurrenceEndAtBeforeStart:    apierror.NewApiError(http.StatusBadRequest, pkgerror.ErrRecurrenceEndAtBeforeStart.Error(), nil),
+	pkgerror.ErrTargetAccountTransferNotFound: apierror.NewApiError(http.StatusBadRequest, pkgerror.ErrTargetAccountTransferNotFound.Error(), nil),
+	pkgerror.ErrOriginAccountTransferNotFound: apierror.NewApiError(http.StatusBadRequest, pkgerror.ErrOriginAccountTransferNotFound.Error(), nil),
+	pkgerror.ErrExchangeRateNotFound:          apierror.NewApiError(http.StatusBadRequest, pkgerror.ErrExchangeRateNotFound.Error(), nil),
+	pkgerror.ErrTransferAmountTooSmall:        apierror.NewApiError(http.StatusBadRequest, pkgerror.ErrTransferAmountTooSmall.Error(), nil),
+	pkgerror.ErrTwoFactorRequired:             apierror.NewApiError(http.StatusUnauthorized, pkgerror.ErrTwoFactorRequired.Error(), nil),
+	pkgerror.ErrInvalidTwoFactorCode:          apierror.NewApiError(http.StatusUnauthorized, pkgerror.ErrInvalidTwoFactorCode.Error(), nil),
+	pkgerror.ErrTransferDenied:                apierror.NewApiError(http.StatusForbidden, pkgerror.ErrTransferDenied.Error(), nil),
+}
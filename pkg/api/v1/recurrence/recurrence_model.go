@@ -0,0 +1,16 @@
+package recurrence
+
+import "time"
+
+type postRecurringTransferBody struct {
+	TargetAccountID         string     `json:"account_destination_id"`
+	Amount                  int64      `json:"amount"`
+	Frequency               string     `json:"frequency"`
+	DayOfMonth              *int       `json:"day_of_month"`
+	StartAt                 time.Time  `json:"start_at"`
+	EndAt                   *time.Time `json:"end_at"`
+	MaxOccurrences          *int       `json:"max_occurrences"`
+	InsufficientFundsPolicy string     `json:"insufficient_funds_policy"`
+	MaxRetries              int        `json:"max_retries"`
+	TwoFactorCode           string     `json:"two_factor_code"`
+}
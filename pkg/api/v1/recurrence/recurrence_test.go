@@ -0,0 +1,229 @@
+package recurrence
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	apierror "github.com/carlosrodriguesf/bank-api/pkg/api/error"
+	apimodel "github.com/carlosrodriguesf/bank-api/pkg/api/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/app/recurrence"
+	pkgerror "github.com/carlosrodriguesf/bank-api/pkg/error"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandler_postRecurringTransfer(t *testing.T) {
+	var (
+		endpoint    = "/api/v1/recurring-transfers"
+		dayOfMonth  = 5
+		startAt     = time.Date(2030, 1, 1, 10, 0, 0, 0, time.UTC)
+		postExample = postRecurringTransferBody{
+			TargetAccountID:         "target_account_id",
+			Amount:                  500,
+			Frequency:               model.RecurrenceFrequencyMonthly,
+			DayOfMonth:              &dayOfMonth,
+			StartAt:                 startAt,
+			InsufficientFundsPolicy: model.RecurrencePolicyRetry,
+			MaxRetries:              3,
+		}
+		createExample = model.RecurringTransfer{
+			OriginAccountID: "origin_account_id",
+			TargetAccountID: postExample.TargetAccountID,
+			Amount:          postExample.Amount,
+			RecurrenceRule: model.RecurrenceRule{
+				Frequency:  postExample.Frequency,
+				DayOfMonth: &dayOfMonth,
+				StartAt:    startAt,
+			},
+			InsufficientFundsPolicy: postExample.InsufficientFundsPolicy,
+			MaxRetries:              postExample.MaxRetries,
+		}
+		createdExample = func() model.RecurringTransfer {
+			data := createExample
+			data.ID = "recurring_transfer_id"
+			data.Status = model.RecurringTransferStatusActive
+			data.NextOccurrenceAt = time.Date(2030, 1, 5, 10, 0, 0, 0, time.UTC)
+			data.NextAttemptAt = data.NextOccurrenceAt
+			return data
+		}()
+		bodyExample = func(t *testing.T) io.Reader {
+			body, err := json.Marshal(postExample)
+			assert.NoError(t, err)
+			return bytes.NewReader(body)
+		}
+	)
+
+	cases := map[string]struct {
+		InputData      func(t *testing.T) io.Reader
+		ExpectedData   *model.RecurringTransfer
+		ExpectedErr    error
+		PrepareMockApp func(mock *recurrence.MockApp)
+	}{
+		"should return success": {
+			InputData:    bodyExample,
+			ExpectedData: &createdExample,
+			ExpectedErr:  nil,
+			PrepareMockApp: func(mock *recurrence.MockApp) {
+				mock.EXPECT().Create(gomock.Any(), createExample).Return(&createdExample, nil)
+			},
+		},
+		"should return error on bind": {
+			InputData: func(t *testing.T) io.Reader {
+				return strings.NewReader("invalid body")
+			},
+			ExpectedData:   nil,
+			ExpectedErr:    apierror.ErrInvalidPayload,
+			PrepareMockApp: func(mock *recurrence.MockApp) {},
+		},
+		"should return error: start at in past": {
+			InputData:    bodyExample,
+			ExpectedData: nil,
+			ExpectedErr:  errorMap[pkgerror.ErrRecurrenceStartAtInPast],
+			PrepareMockApp: func(mock *recurrence.MockApp) {
+				mock.EXPECT().Create(gomock.Any(), createExample).Return(nil, pkgerror.ErrRecurrenceStartAtInPast)
+			},
+		},
+		"should return error": {
+			InputData:    bodyExample,
+			ExpectedData: nil,
+			ExpectedErr:  apierror.ErrInternal,
+			PrepareMockApp: func(mock *recurrence.MockApp) {
+				mock.EXPECT().Create(gomock.Any(), createExample).Return(nil, errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			ctx = model.SetSessionOnContext(ctx, &model.Session{
+				Token:   "session_token",
+				Account: model.Account{ID: createExample.OriginAccountID},
+			})
+
+			mockApp := recurrence.NewMockApp(ctrl)
+			cs.PrepareMockApp(mockApp)
+
+			h := handler{
+				logger:        logger.New(""),
+				recurrenceApp: mockApp,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, endpoint, cs.InputData(t)).WithContext(ctx)
+			rec := httptest.NewRecorder()
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			c := e.NewContext(req, rec)
+			c.SetPath(endpoint)
+
+			err := h.postRecurringTransfer(c)
+
+			assert.Equal(t, cs.ExpectedErr, err)
+
+			expectedResponseJSON, err := json.Marshal(apimodel.Response{Data: cs.ExpectedData})
+			assert.NoError(t, err)
+
+			var expectedResponse apimodel.Response
+			err = json.Unmarshal(expectedResponseJSON, &expectedResponse)
+			assert.NoError(t, err)
+
+			var currentResponse apimodel.Response
+			json.NewDecoder(rec.Body).Decode(&currentResponse)
+
+			assert.Equal(t, expectedResponse, currentResponse)
+		})
+	}
+}
+
+func TestHandler_getRecurringTransferRuns(t *testing.T) {
+	var (
+		endpoint    = "/api/v1/recurring-transfers/:id/runs"
+		transferID  = "transfer_id"
+		runsExample = []model.RecurringTransferRun{{
+			ID:                  "run_id",
+			RecurringTransferID: "recurring_transfer_id",
+			OccurrenceAt:        time.Date(2030, 1, 5, 10, 0, 0, 0, time.UTC),
+			Status:              model.RecurringTransferRunStatusExecuted,
+			TransferID:          &transferID,
+		}}
+	)
+
+	cases := map[string]struct {
+		ExpectedData   []model.RecurringTransferRun
+		ExpectedErr    error
+		PrepareMockApp func(mock *recurrence.MockApp)
+	}{
+		"should return success": {
+			ExpectedData: runsExample,
+			ExpectedErr:  nil,
+			PrepareMockApp: func(mock *recurrence.MockApp) {
+				mock.EXPECT().
+					ListRuns(gomock.Any(), "origin_account_id", "recurring_transfer_id").
+					Return(runsExample, nil)
+			},
+		},
+		"should return error: not found": {
+			ExpectedData: nil,
+			ExpectedErr:  errorMap[pkgerror.ErrRecurringTransferNotFound],
+			PrepareMockApp: func(mock *recurrence.MockApp) {
+				mock.EXPECT().
+					ListRuns(gomock.Any(), "origin_account_id", "recurring_transfer_id").
+					Return(nil, pkgerror.ErrRecurringTransferNotFound)
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			ctx = model.SetSessionOnContext(ctx, &model.Session{
+				Token:   "session_token",
+				Account: model.Account{ID: "origin_account_id"},
+			})
+
+			mockApp := recurrence.NewMockApp(ctrl)
+			cs.PrepareMockApp(mockApp)
+
+			h := handler{
+				logger:        logger.New(""),
+				recurrenceApp: mockApp,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, endpoint, nil).WithContext(ctx)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath(endpoint)
+			c.SetParamNames("id")
+			c.SetParamValues("recurring_transfer_id")
+
+			err := h.getRecurringTransferRuns(c)
+
+			assert.Equal(t, cs.ExpectedErr, err)
+
+			expectedResponseJSON, err := json.Marshal(apimodel.Response{Data: cs.ExpectedData})
+			assert.NoError(t, err)
+
+			var expectedResponse apimodel.Response
+			err = json.Unmarshal(expectedResponseJSON, &expectedResponse)
+			assert.NoError(t, err)
+
+			var currentResponse apimodel.Response
+			json.NewDecoder(rec.Body).Decode(&currentResponse)
+
+			assert.Equal(t, expectedResponse, currentResponse)
+		})
+	}
+}
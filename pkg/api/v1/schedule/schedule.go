@@ -0,0 +1,126 @@
+package schedule
+
+import (
+	apierror "github.com/carlosrodriguesf/bank-api/pkg/api/error"
+	apimodel "github.com/carlosrodriguesf/bank-api/pkg/api/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/app/schedule"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type handler struct {
+	logger      logger.Logger
+	scheduleApp schedule.App
+}
+
+func Register(g *echo.Group, opts apimodel.Options) {
+	log := opts.Logger.WithPreffix("api.v1.schedule")
+	h := handler{
+		logger:      log.WithLocation(),
+		scheduleApp: opts.App.Schedule(),
+	}
+
+	g.POST("/scheduled-transfers", h.postScheduledTransfer, opts.Middleware.Auth().Private, opts.Middleware.Auth().RequirePermission(model.PermissionCreateTransfers), opts.Middleware.Idempotency().Handle)
+	g.GET("/scheduled-transfers", h.getScheduledTransfers, opts.Middleware.Auth().Private, opts.Middleware.Auth().RequirePermission(model.PermissionReadTransfers))
+	g.POST("/scheduled-transfers/:id/cancel", h.cancelScheduledTransfer, opts.Middleware.Auth().Private, opts.Middleware.Auth().RequirePermission(model.PermissionCreateTransfers))
+
+	log.Info("registered")
+}
+
+// postScheduledTransfer swagger document
+// @Description Schedule a transfer to be made at a future date
+// @Tags schedule
+// @Produce json
+// @Security UserToken
+// @Param Idempotency-Key header string false "key to safely retry the request"
+// @Param scheduledTransfer body postScheduledTransferBody true "expected structure"
+// @Success 200 {object} model.Response{data=model.ScheduledTransfer}
+// @Success 400 {object} model.Response{error=error.ApiError}
+// @Failure 500 {object} model.Response{error=error.ApiError}
+// @Router /api/v1/scheduled-transfers [post]
+func (h *handler) postScheduledTransfer(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := h.logger.WithContext(ctx)
+
+	body := new(postScheduledTransferBody)
+	if err := c.Bind(body); err != nil {
+		log.Error(err)
+		return apierror.ErrInvalidPayload
+	}
+
+	sess := model.GetSessionFromContext(ctx)
+	data, err := h.scheduleApp.Create(ctx, model.ScheduledTransfer{
+		OriginAccountID: sess.Account.ID,
+		TargetAccountID: body.TargetAccountID,
+		Amount:          body.Amount,
+		ScheduledFor:    body.ScheduledFor,
+		TwoFactorCode:   body.TwoFactorCode,
+	})
+	if err != nil {
+		if err := apierror.Get(err, errorMap); err != nil {
+			return err
+		}
+		log.Error(err)
+		return apierror.ErrInternal
+	}
+	return c.JSON(http.StatusOK, apimodel.Response{
+		Data: data,
+	})
+}
+
+// getScheduledTransfers swagger document
+// @Description List transfers scheduled by current auth user
+// @Tags schedule
+// @Produce json
+// @Security UserToken
+// @Param status query string false "filter by status: pending, processing, executed, failed-insufficient-funds, failed or cancelled"
+// @Success 200 {object} model.Response{data=[]model.ScheduledTransfer}
+// @Failure 500 {object} model.Response{error=error.ApiError}
+// @Router /api/v1/scheduled-transfers [get]
+func (h *handler) getScheduledTransfers(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := h.logger.WithContext(ctx)
+
+	sess := model.GetSessionFromContext(ctx)
+	data, err := h.scheduleApp.List(ctx, sess.Account.ID, c.QueryParam("status"))
+	if err != nil {
+		if err := apierror.Get(err, errorMap); err != nil {
+			return err
+		}
+		log.Error(err)
+		return apierror.ErrInternal
+	}
+	return c.JSON(http.StatusOK, apimodel.Response{
+		Data: data,
+	})
+}
+
+// cancelScheduledTransfer swagger document
+// @Description Cancel a pending scheduled transfer of current auth user
+// @Tags schedule
+// @Produce json
+// @Security UserToken
+// @Param id path string true "id of a scheduled transfer"
+// @Success 200 {object} model.Response{data=model.ScheduledTransfer}
+// @Success 400 {object} model.Response{error=error.ApiError}
+// @Failure 500 {object} model.Response{error=error.ApiError}
+// @Router /api/v1/scheduled-transfers/{id}/cancel [post]
+func (h *handler) cancelScheduledTransfer(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := h.logger.WithContext(ctx)
+
+	sess := model.GetSessionFromContext(ctx)
+	data, err := h.scheduleApp.Cancel(ctx, sess.Account.ID, c.Param("id"))
+	if err != nil {
+		if err := apierror.Get(err, errorMap); err != nil {
+			return err
+		}
+		log.Error(err)
+		return apierror.ErrInternal
+	}
+	return c.JSON(http.StatusOK, apimodel.Response{
+		Data: data,
+	})
+}
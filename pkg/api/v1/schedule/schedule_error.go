@@ -0,0 +1,23 @@
+package schedule
+
+import (
+	apierror "github.com/carlosrodriguesf/bank-api/pkg/api/error"
+	pkgerror "github.com/carlosrodriguesf/bank-api/pkg/error"
+	"net/http"
+)
+
+var errorMap = map[error]*apierror.ApiError{
+	pkgerror.ErrCantCreateScheduledTransfer:   apierror.NewApiError(http.StatusInternalServerError, pkgerror.ErrCantCreateScheduledTransfer.Error(), nil),
+	pkgerror.ErrCantListScheduledTransfers:    apierror.NewApiError(http.StatusInternalServerError, pkgerror.ErrCantListScheduledTransfers.Error(), nil),
+	pkgerror.ErrCantCancelScheduledTransfer:   apierror.NewApiError(http.StatusInternalServerError, pkgerror.ErrCantCancelScheduledTransfer.Error(), nil),
+	pkgerror.ErrScheduledTransferNotFound:     apierror.NewApiError(http.StatusNotFound, pkgerror.ErrScheduledTransferNotFound.Error(), nil),
+	pkgerror.ErrScheduledTransferNotPending:   apierror.NewApiError(http.StatusBadRequest, pkgerror.ErrScheduledTransferNotPending.Error(), nil),
+	pkgerror.ErrScheduledForInPast:            apierror.NewApiError(http.StatusBadRequest, pkgerror.ErrScheduledForInPast.Error(), nil),
+	pkgerror.ErrTargetAccountTransferNotFound: apierror.NewApiError(http.StatusBadRequest, pkgerror.ErrTargetAccountTransferNotFound.Error(), nil),
+	pkgerror.ErrOriginAccountTransferNotFound: apierror.NewApiError(http.StatusBadRequest, pkgerror.ErrOriginAccountTransferNotFound.Error(), nil),
+	pkgerror.ErrExchangeRateNotFound:          apierror.NewApiError(http.StatusBadRequest, pkgerror.ErrExchangeRateNotFound.Error(), nil),
+	pkgerror.ErrTransferAmountTooSmall:        apierror.NewApiError(http.StatusBadRequest, pkgerror.ErrTransferAmountTooSmall.Error(), nil),
+	pkgerror.ErrTwoFactorRequired:             apierror.NewApiError(http.StatusUnauthorized, pkgerror.ErrTwoFactorRequired.Error(), nil),
+	pkgerror.ErrInvalidTwoFactorCode:          apierror.NewApiError(http.StatusUnauthorized, pkgerror.ErrInvalidTwoFactorCode.Error(), nil),
+	pkgerror.ErrTransferDenied:                apierror.NewApiError(http.StatusForbidden, pkgerror.ErrTransferDenied.Error(), nil),
+}
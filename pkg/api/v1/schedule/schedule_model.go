@@ -0,0 +1,10 @@
+package schedule
+
+import "time"
+
+type postScheduledTransferBody struct {
+	TargetAccountID string    `json:"account_destination_id"`
+	Amount          int64     `json:"amount"`
+	ScheduledFor    time.Time `json:"scheduled_for"`
+	TwoFactorCode   string    `json:"two_factor_code"`
+}
@@ -0,0 +1,306 @@
+package schedule
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	apierror "github.com/carlosrodriguesf/bank-api/pkg/api/error"
+	apimodel "github.com/carlosrodriguesf/bank-api/pkg/api/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/app/schedule"
+	pkgerror "github.com/carlosrodriguesf/bank-api/pkg/error"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandler_postScheduledTransfer(t *testing.T) {
+	var (
+		endpoint     = "/api/v1/scheduled-transfers"
+		scheduledFor = time.Date(2030, 1, 1, 10, 0, 0, 0, time.UTC)
+		postExample  = postScheduledTransferBody{
+			TargetAccountID: "target_account_id",
+			Amount:          500,
+			ScheduledFor:    scheduledFor,
+		}
+		createExample = model.ScheduledTransfer{
+			OriginAccountID: "origin_account_id",
+			TargetAccountID: postExample.TargetAccountID,
+			Amount:          postExample.Amount,
+			ScheduledFor:    scheduledFor,
+		}
+		createdExample = model.ScheduledTransfer{
+			ID:              "scheduled_transfer_id",
+			OriginAccountID: createExample.OriginAccountID,
+			TargetAccountID: createExample.TargetAccountID,
+			Amount:          createExample.Amount,
+			ScheduledFor:    scheduledFor,
+			Status:          model.ScheduledTransferStatusPending,
+		}
+		bodyExample = func(t *testing.T) io.Reader {
+			body, err := json.Marshal(postExample)
+			assert.NoError(t, err)
+			return bytes.NewReader(body)
+		}
+	)
+
+	cases := map[string]struct {
+		InputData      func(t *testing.T) io.Reader
+		ExpectedData   *model.ScheduledTransfer
+		ExpectedErr    error
+		PrepareMockApp func(mock *schedule.MockApp)
+	}{
+		"should return success": {
+			InputData:    bodyExample,
+			ExpectedData: &createdExample,
+			ExpectedErr:  nil,
+			PrepareMockApp: func(mock *schedule.MockApp) {
+				mock.EXPECT().Create(gomock.Any(), createExample).Return(&createdExample, nil)
+			},
+		},
+		"should return error on bind": {
+			InputData: func(t *testing.T) io.Reader {
+				return strings.NewReader("invalid body")
+			},
+			ExpectedData:   nil,
+			ExpectedErr:    apierror.ErrInvalidPayload,
+			PrepareMockApp: func(mock *schedule.MockApp) {},
+		},
+		"should return error: scheduled for in past": {
+			InputData:    bodyExample,
+			ExpectedData: nil,
+			ExpectedErr:  errorMap[pkgerror.ErrScheduledForInPast],
+			PrepareMockApp: func(mock *schedule.MockApp) {
+				mock.EXPECT().Create(gomock.Any(), createExample).Return(nil, pkgerror.ErrScheduledForInPast)
+			},
+		},
+		"should return error": {
+			InputData:    bodyExample,
+			ExpectedData: nil,
+			ExpectedErr:  apierror.ErrInternal,
+			PrepareMockApp: func(mock *schedule.MockApp) {
+				mock.EXPECT().Create(gomock.Any(), createExample).Return(nil, errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			ctx = model.SetSessionOnContext(ctx, &model.Session{
+				Token:   "session_token",
+				Account: model.Account{ID: createExample.OriginAccountID},
+			})
+
+			mockApp := schedule.NewMockApp(ctrl)
+			cs.PrepareMockApp(mockApp)
+
+			h := handler{
+				logger:      logger.New(""),
+				scheduleApp: mockApp,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, endpoint, cs.InputData(t)).WithContext(ctx)
+			rec := httptest.NewRecorder()
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			c := e.NewContext(req, rec)
+			c.SetPath(endpoint)
+
+			err := h.postScheduledTransfer(c)
+
+			assert.Equal(t, cs.ExpectedErr, err)
+
+			expectedResponseJSON, err := json.Marshal(apimodel.Response{Data: cs.ExpectedData})
+			assert.NoError(t, err)
+
+			var expectedResponse apimodel.Response
+			err = json.Unmarshal(expectedResponseJSON, &expectedResponse)
+			assert.NoError(t, err)
+
+			var currentResponse apimodel.Response
+			json.NewDecoder(rec.Body).Decode(&currentResponse)
+
+			assert.Equal(t, expectedResponse, currentResponse)
+		})
+	}
+}
+
+func TestHandler_getScheduledTransfers(t *testing.T) {
+	var (
+		endpoint                  = "/api/v1/scheduled-transfers"
+		scheduledTransfersExample = []model.ScheduledTransfer{{
+			ID:              "scheduled_transfer_id",
+			OriginAccountID: "origin_account_id",
+			TargetAccountID: "target_account_id",
+			Amount:          500,
+			ScheduledFor:    time.Date(2030, 1, 1, 10, 0, 0, 0, time.UTC),
+			Status:          model.ScheduledTransferStatusPending,
+		}}
+	)
+
+	cases := map[string]struct {
+		ExpectedData   []model.ScheduledTransfer
+		ExpectedErr    error
+		PrepareMockApp func(mock *schedule.MockApp)
+	}{
+		"should return success": {
+			ExpectedData: scheduledTransfersExample,
+			ExpectedErr:  nil,
+			PrepareMockApp: func(mock *schedule.MockApp) {
+				mock.EXPECT().
+					List(gomock.Any(), "origin_account_id", model.ScheduledTransferStatusPending).
+					Return(scheduledTransfersExample, nil)
+			},
+		},
+		"should return error": {
+			ExpectedData: nil,
+			ExpectedErr:  errorMap[pkgerror.ErrCantListScheduledTransfers],
+			PrepareMockApp: func(mock *schedule.MockApp) {
+				mock.EXPECT().
+					List(gomock.Any(), "origin_account_id", model.ScheduledTransferStatusPending).
+					Return(nil, pkgerror.ErrCantListScheduledTransfers)
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			ctx = model.SetSessionOnContext(ctx, &model.Session{
+				Token:   "session_token",
+				Account: model.Account{ID: "origin_account_id"},
+			})
+
+			mockApp := schedule.NewMockApp(ctrl)
+			cs.PrepareMockApp(mockApp)
+
+			h := handler{
+				logger:      logger.New(""),
+				scheduleApp: mockApp,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, endpoint+"?status=pending", nil).WithContext(ctx)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath(endpoint)
+
+			err := h.getScheduledTransfers(c)
+
+			assert.Equal(t, cs.ExpectedErr, err)
+
+			expectedResponseJSON, err := json.Marshal(apimodel.Response{Data: cs.ExpectedData})
+			assert.NoError(t, err)
+
+			var expectedResponse apimodel.Response
+			err = json.Unmarshal(expectedResponseJSON, &expectedResponse)
+			assert.NoError(t, err)
+
+			var currentResponse apimodel.Response
+			json.NewDecoder(rec.Body).Decode(&currentResponse)
+
+			assert.Equal(t, expectedResponse, currentResponse)
+		})
+	}
+}
+
+func TestHandler_cancelScheduledTransfer(t *testing.T) {
+	var (
+		endpoint         = "/api/v1/scheduled-transfers/:id/cancel"
+		cancelledExample = model.ScheduledTransfer{
+			ID:              "scheduled_transfer_id",
+			OriginAccountID: "origin_account_id",
+			TargetAccountID: "target_account_id",
+			Amount:          500,
+			Status:          model.ScheduledTransferStatusCancelled,
+		}
+	)
+
+	cases := map[string]struct {
+		ExpectedData   *model.ScheduledTransfer
+		ExpectedErr    error
+		PrepareMockApp func(mock *schedule.MockApp)
+	}{
+		"should return success": {
+			ExpectedData: &cancelledExample,
+			ExpectedErr:  nil,
+			PrepareMockApp: func(mock *schedule.MockApp) {
+				mock.EXPECT().
+					Cancel(gomock.Any(), "origin_account_id", "scheduled_transfer_id").
+					Return(&cancelledExample, nil)
+			},
+		},
+		"should return error: not found": {
+			ExpectedData: nil,
+			ExpectedErr:  errorMap[pkgerror.ErrScheduledTransferNotFound],
+			PrepareMockApp: func(mock *schedule.MockApp) {
+				mock.EXPECT().
+					Cancel(gomock.Any(), "origin_account_id", "scheduled_transfer_id").
+					Return(nil, pkgerror.ErrScheduledTransferNotFound)
+			},
+		},
+		"should return error: not pending": {
+			ExpectedData: nil,
+			ExpectedErr:  errorMap[pkgerror.ErrScheduledTransferNotPending],
+			PrepareMockApp: func(mock *schedule.MockApp) {
+				mock.EXPECT().
+					Cancel(gomock.Any(), "origin_account_id", "scheduled_transfer_id").
+					Return(nil, pkgerror.ErrScheduledTransferNotPending)
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			ctx = model.SetSessionOnContext(ctx, &model.Session{
+				Token:   "session_token",
+				Account: model.Account{ID: "origin_account_id"},
+			})
+
+			mockApp := schedule.NewMockApp(ctrl)
+			cs.PrepareMockApp(mockApp)
+
+			h := handler{
+				logger:      logger.New(""),
+				scheduleApp: mockApp,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, endpoint, nil).WithContext(ctx)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath(endpoint)
+			c.SetParamNames("id")
+			c.SetParamValues("scheduled_transfer_id")
+
+			err := h.cancelScheduledTransfer(c)
+
+			assert.Equal(t, cs.ExpectedErr, err)
+
+			expectedResponseJSON, err := json.Marshal(apimodel.Response{Data: cs.ExpectedData})
+			assert.NoError(t, err)
+
+			var expectedResponse apimodel.Response
+			err = json.Unmarshal(expectedResponseJSON, &expectedResponse)
+			assert.NoError(t, err)
+
+			var currentResponse apimodel.Response
+			json.NewDecoder(rec.Body).Decode(&currentResponse)
+
+			assert.Equal(t, expectedResponse, currentResponse)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package statement
+
+import (
+	apierror "github.com/carlosrodriguesf/bank-api/pkg/api/error"
+	apimodel "github.com/carlosrodriguesf/bank-api/pkg/api/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/app/statement"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/labstack/echo/v4"
+	"time"
+)
+
+type handler struct {
+	logger       logger.Logger
+	statementApp statement.App
+}
+
+func Register(g *echo.Group, opts apimodel.Options) {
+	log := opts.Logger.WithPreffix("api.v1.statement")
+	h := handler{
+		logger:       log.WithLocation(),
+		statementApp: opts.App.Statement(),
+	}
+
+	g.GET("/accounts/:id/statement", h.getStatement, opts.Middleware.Auth().Private, opts.Middleware.Auth().RequirePermission(model.PermissionReadAccounts))
+
+	log.Info("registered")
+}
+
+// getStatement swagger document
+// @Description Export the transfers of an account with opening, running and closing balances
+// @Tags statement
+// @Produce json,text/csv,application/x-ofx
+// @Security UserToken
+// @Param id path string true "id of an account"
+// @Param from query string false "created at or after this RFC3339 date (default account creation)"
+// @Param to query string false "created before this RFC3339 date (default now)"
+// @Param format query string false "csv, ofx or json (default)"
+// @Success 200 {object} model.Response{data=model.Statement}
+// @Success 400 {object} model.Response{error=error.ApiError}
+// @Failure 403 {object} model.Response{error=error.ApiError}
+// @Failure 500 {object} model.Response{error=error.ApiError}
+// @Router /api/v1/accounts/{id}/statement [get]
+func (h *handler) getStatement(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := h.logger.WithContext(ctx)
+
+	sess := model.GetSessionFromContext(ctx)
+	filter := model.StatementFilter{AccountID: c.Param("id")}
+	if filter.AccountID != sess.Account.ID && !sess.HasPermission(model.PermissionReadAnyStatement) {
+		return apierror.ErrAccessDenied
+	}
+
+	format := model.StatementFormatJSON
+	err := echo.QueryParamsBinder(c).
+		Time("from", &filter.From, time.RFC3339).
+		Time("to", &filter.To, time.RFC3339).
+		String("format", &format).
+		BindError()
+	if err != nil {
+		log.Error(err)
+		return apierror.ErrInvalidPayload
+	}
+
+	w := newWriter(format, c.Response())
+	if w == nil {
+		return ErrInvalidFormat
+	}
+
+	err = h.statementApp.Export(ctx, filter, w)
+	if err != nil {
+		// once the statement started streaming the status was sent, all we can do is to cut it short
+		if c.Response().Committed {
+			log.Error(err)
+			return nil
+		}
+		if err := apierror.Get(err, errorMap); err != nil {
+			return err
+		}
+		log.Error(err)
+		return apierror.ErrInternal
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+package statement
+
+import (
+	apierror "github.com/carlosrodriguesf/bank-api/pkg/api/error"
+	pkgerror "github.com/carlosrodriguesf/bank-api/pkg/error"
+	"net/http"
+)
+
+var ErrInvalidFormat = apierror.NewApiError(http.StatusBadRequest, "statement.invalid-format", nil)
+
+var errorMap = map[error]*apierror.ApiError{
+	pkgerror.ErrCantExportStatement:    apierror.NewApiError(http.StatusInternalServerError, pkgerror.ErrCantExportStatement.Error(), nil),
+	pkgerror.ErrInvalidStatementPeriod: apierror.NewApiError(http.StatusBadRequest, pkgerror.ErrInvalidStatementPeriod.Error(), nil),
+	pkgerror.ErrAccountNotFound:        apierror.NewApiError(http.StatusNotFound, pkgerror.ErrAccountNotFound.Error(), nil),
+}
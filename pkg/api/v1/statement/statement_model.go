@@ -0,0 +1,42 @@
+package statement
+
+import "time"
+
+type (
+	// jsonStatementHeader is what the json statement knows before its entries. The closing balance goes after them.
+	jsonStatementHeader struct {
+		AccountID       string    `json:"account_id"`
+		AccountName     string    `json:"account_name"`
+		AccountDocument string    `json:"account_document"`
+		From            time.Time `json:"from"`
+		To              time.Time `json:"to"`
+		OpeningBalance  int64     `json:"opening_balance"`
+	}
+
+	ofxStatus struct {
+		Code     int    `xml:"CODE"`
+		Severity string `xml:"SEVERITY"`
+	}
+	ofxSignOnResponse struct {
+		Status   ofxStatus `xml:"STATUS"`
+		DTServer string    `xml:"DTSERVER"`
+		Language string    `xml:"LANGUAGE"`
+	}
+	ofxBankAccount struct {
+		BankID   string `xml:"BANKID"`
+		AcctID   string `xml:"ACCTID"`
+		AcctType string `xml:"ACCTTYPE"`
+	}
+	ofxTransaction struct {
+		TrnType  string `xml:"TRNTYPE"`
+		DTPosted string `xml:"DTPOSTED"`
+		TrnAmt   string `xml:"TRNAMT"`
+		FITID    string `xml:"FITID"`
+		Name     string `xml:"NAME,omitempty"`
+		Memo     string `xml:"MEMO,omitempty"`
+	}
+	ofxBalance struct {
+		BalAmt string `xml:"BALAMT"`
+		DTAsOf string `xml:"DTASOF"`
+	}
+)
@@ -0,0 +1,242 @@
+package statement
+
+import (
+	"context"
+	"errors"
+	apierror "github.com/carlosrodriguesf/bank-api/pkg/api/error"
+	"github.com/carlosrodriguesf/bank-api/pkg/app/statement"
+	pkgerror "github.com/carlosrodriguesf/bank-api/pkg/error"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandler_getStatement(t *testing.T) {
+	var (
+		endpoint         = "/api/v1/accounts/:id/statement"
+		from             = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		to               = time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+		reversedID       = "transfer_id_1"
+		statementExample = model.Statement{
+			AccountID:       "account_id",
+			AccountName:     "John Doe",
+			AccountDocument: "12312312312",
+			Currency:        "BRL",
+			From:            from,
+			To:              to,
+			OpeningBalance:  1000,
+			ClosingBalance:  820,
+		}
+		entriesExample = []model.StatementEntry{
+			{
+				TransferID:            "transfer_id_1",
+				CounterpartyAccountID: "target_account_id",
+				CounterpartyName:      "Jane Doe",
+				Amount:                -300,
+				Fee:                   -50,
+				Balance:               650,
+				CreatedAt:             from.Add(time.Hour),
+			},
+			{
+				TransferID:            "transfer_id_2",
+				ReversedTransferID:    &reversedID,
+				CounterpartyAccountID: "target_account_id",
+				CounterpartyName:      "Jane Doe",
+				Amount:                150,
+				Balance:               800,
+				CreatedAt:             from.Add(2 * time.Hour),
+			},
+			{
+				TransferID:            "transfer_id_3",
+				CounterpartyAccountID: "origin_account_id",
+				CounterpartyName:      "Mary Doe",
+				Fee:                   20,
+				Balance:               820,
+				CreatedAt:             from.Add(3 * time.Hour),
+			},
+		}
+		export = func(_ context.Context, _ model.StatementFilter, w statement.Writer) error {
+			if err := w.WriteHeader(statementExample); err != nil {
+				return err
+			}
+			for _, entry := range entriesExample {
+				if err := w.WriteEntry(entry); err != nil {
+					return err
+				}
+			}
+			return w.WriteFooter(statementExample)
+		}
+		filterExample = model.StatementFilter{AccountID: "account_id", From: from, To: to}
+		session       = &model.Session{Account: model.Account{ID: "account_id"}}
+	)
+
+	cases := map[string]struct {
+		InputQuery          string
+		InputSession        *model.Session
+		ExpectedErr         error
+		ExpectedStatus      int
+		ExpectedContentType string
+		ExpectedBody        string
+		PrepareMockApp      func(mock *statement.MockApp)
+	}{
+		"should return json statement": {
+			InputQuery:          "?from=2026-01-01T00:00:00Z&to=2026-02-01T00:00:00Z",
+			InputSession:        session,
+			ExpectedErr:         nil,
+			ExpectedStatus:      http.StatusOK,
+			ExpectedContentType: echo.MIMEApplicationJSONCharsetUTF8,
+			ExpectedBody: `{"data":{"account_id":"account_id","account_name":"John Doe","account_document":"12312312312",` +
+				`"from":"2026-01-01T00:00:00Z","to":"2026-02-01T00:00:00Z","opening_balance":1000,"entries":[` +
+				`{"transfer_id":"transfer_id_1","counterparty_account_id":"target_account_id","counterparty_name":"Jane Doe",` +
+				`"amount":-300,"fee":-50,"balance":650,"created_at":"2026-01-01T01:00:00Z"},` +
+				`{"transfer_id":"transfer_id_2","reversed_transfer_id":"transfer_id_1","counterparty_account_id":"target_account_id",` +
+				`"counterparty_name":"Jane Doe","amount":150,"fee":0,"balance":800,"created_at":"2026-01-01T02:00:00Z"},` +
+				`{"transfer_id":"transfer_id_3","counterparty_account_id":"origin_account_id","counterparty_name":"Mary Doe",` +
+				`"amount":0,"fee":20,"balance":820,"created_at":"2026-01-01T03:00:00Z"}` +
+				`],"closing_balance":820}}`,
+			PrepareMockApp: func(mock *statement.MockApp) {
+				mock.EXPECT().Export(gomock.Any(), filterExample, gomock.Any()).DoAndReturn(export)
+			},
+		},
+		"should return csv statement": {
+			InputQuery:          "?from=2026-01-01T00:00:00Z&to=2026-02-01T00:00:00Z&format=csv",
+			InputSession:        session,
+			ExpectedErr:         nil,
+			ExpectedStatus:      http.StatusOK,
+			ExpectedContentType: mimeTextCSV,
+			ExpectedBody: "date,transfer_id,description,counterparty_account_id,counterparty_name,amount,fee,balance\n" +
+				"2026-01-01T00:00:00Z,,opening balance,,,,,1000\n" +
+				"2026-01-01T01:00:00Z,transfer_id_1,transfer sent,target_account_id,Jane Doe,-300,-50,650\n" +
+				"2026-01-01T02:00:00Z,transfer_id_2,reversal received,target_account_id,Jane Doe,150,0,800\n" +
+				"2026-01-01T03:00:00Z,transfer_id_3,fee collected,origin_account_id,Mary Doe,0,20,820\n" +
+				"2026-02-01T00:00:00Z,,closing balance,,,,,820",
+			PrepareMockApp: func(mock *statement.MockApp) {
+				mock.EXPECT().Export(gomock.Any(), filterExample, gomock.Any()).DoAndReturn(export)
+			},
+		},
+		"should return ofx statement": {
+			InputQuery:          "?from=2026-01-01T00:00:00Z&to=2026-02-01T00:00:00Z&format=ofx",
+			InputSession:        &model.Session{Account: model.Account{ID: "admin_account_id", Role: model.RoleSupport}},
+			ExpectedErr:         nil,
+			ExpectedStatus:      http.StatusOK,
+			ExpectedContentType: mimeOFX,
+			ExpectedBody: ofxHeader +
+				"<OFX><SIGNONMSGSRSV1><SONRS><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>" +
+				"<DTSERVER>20260201000000.000[0:GMT]</DTSERVER><LANGUAGE>POR</LANGUAGE></SONRS></SIGNONMSGSRSV1>" +
+				"<BANKMSGSRSV1><STMTTRNRS><TRNUID>0</TRNUID><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>" +
+				"<STMTRS><CURDEF>BRL</CURDEF>" +
+				"<BANKACCTFROM><BANKID>0001</BANKID><ACCTID>12312312312</ACCTID><ACCTTYPE>CHECKING</ACCTTYPE></BANKACCTFROM>" +
+				"<BANKTRANLIST><DTSTART>20260101000000.000[0:GMT]</DTSTART><DTEND>20260201000000.000[0:GMT]</DTEND>" +
+				"<STMTTRN><TRNTYPE>DEBIT</TRNTYPE><DTPOSTED>20260101010000.000[0:GMT]</DTPOSTED><TRNAMT>-3.00</TRNAMT>" +
+				"<FITID>transfer_id_1</FITID><NAME>Jane Doe</NAME><MEMO>transfer sent</MEMO></STMTTRN>" +
+				"<STMTTRN><TRNTYPE>FEE</TRNTYPE><DTPOSTED>20260101010000.000[0:GMT]</DTPOSTED><TRNAMT>-0.50</TRNAMT>" +
+				"<FITID>transfer_id_1-fee</FITID><NAME>Jane Doe</NAME><MEMO>transfer fee</MEMO></STMTTRN>" +
+				"<STMTTRN><TRNTYPE>CREDIT</TRNTYPE><DTPOSTED>20260101020000.000[0:GMT]</DTPOSTED><TRNAMT>1.50</TRNAMT>" +
+				"<FITID>transfer_id_2</FITID><NAME>Jane Doe</NAME><MEMO>reversal received</MEMO></STMTTRN>" +
+				"<STMTTRN><TRNTYPE>CREDIT</TRNTYPE><DTPOSTED>20260101030000.000[0:GMT]</DTPOSTED><TRNAMT>0.20</TRNAMT>" +
+				"<FITID>transfer_id_3-fee</FITID><NAME>Mary Doe</NAME><MEMO>fee collected</MEMO></STMTTRN>" +
+				"</BANKTRANLIST>" +
+				"<LEDGERBAL><BALAMT>8.20</BALAMT><DTASOF>20260201000000.000[0:GMT]</DTASOF></LEDGERBAL>" +
+				"</STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>",
+			PrepareMockApp: func(mock *statement.MockApp) {
+				mock.EXPECT().Export(gomock.Any(), filterExample, gomock.Any()).DoAndReturn(export)
+			},
+		},
+		"should return error: access denied": {
+			InputQuery:     "",
+			InputSession:   &model.Session{Account: model.Account{ID: "another_account_id"}},
+			ExpectedErr:    apierror.ErrAccessDenied,
+			PrepareMockApp: func(mock *statement.MockApp) {},
+		},
+		"should return error: invalid format": {
+			InputQuery:     "?format=pdf",
+			InputSession:   session,
+			ExpectedErr:    ErrInvalidFormat,
+			PrepareMockApp: func(mock *statement.MockApp) {},
+		},
+		"should return error: invalid query": {
+			InputQuery:     "?from=yesterday",
+			InputSession:   session,
+			ExpectedErr:    apierror.ErrInvalidPayload,
+			PrepareMockApp: func(mock *statement.MockApp) {},
+		},
+		"should return error: account not found": {
+			InputQuery:   "",
+			InputSession: session,
+			ExpectedErr:  errorMap[pkgerror.ErrAccountNotFound],
+			PrepareMockApp: func(mock *statement.MockApp) {
+				mock.EXPECT().
+					Export(gomock.Any(), model.StatementFilter{AccountID: "account_id"}, gomock.Any()).
+					Return(pkgerror.ErrAccountNotFound)
+			},
+		},
+		"should return internal error": {
+			InputQuery:   "",
+			InputSession: session,
+			ExpectedErr:  apierror.ErrInternal,
+			PrepareMockApp: func(mock *statement.MockApp) {
+				mock.EXPECT().Export(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("fail"))
+			},
+		},
+		"should cut statement short on error while streaming": {
+			InputQuery:          "?format=csv",
+			InputSession:        session,
+			ExpectedErr:         nil,
+			ExpectedStatus:      http.StatusOK,
+			ExpectedContentType: mimeTextCSV,
+			ExpectedBody:        "",
+			PrepareMockApp: func(mock *statement.MockApp) {
+				mock.EXPECT().
+					Export(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ model.StatementFilter, w statement.Writer) error {
+						if err := w.WriteHeader(statementExample); err != nil {
+							return err
+						}
+						return pkgerror.ErrCantExportStatement
+					})
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			ctx = model.SetSessionOnContext(ctx, cs.InputSession)
+
+			mockApp := statement.NewMockApp(ctrl)
+
+			cs.PrepareMockApp(mockApp)
+
+			h := handler{
+				logger:       logger.New(""),
+				statementApp: mockApp,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/accounts/account_id/statement"+cs.InputQuery, nil).WithContext(ctx)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath(endpoint)
+			c.SetParamNames("id")
+			c.SetParamValues("account_id")
+
+			err := h.getStatement(c)
+
+			assert.Equal(t, cs.ExpectedErr, err)
+			if cs.ExpectedErr != nil {
+				return
+			}
+			assert.Equal(t, cs.ExpectedStatus, rec.Code)
+			assert.Equal(t, cs.ExpectedContentType, rec.Header().Get(echo.HeaderContentType))
+			assert.Equal(t, cs.ExpectedBody, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,285 @@
+package statement
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"github.com/carlosrodriguesf/bank-api/pkg/app/statement"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/labstack/echo/v4"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	mimeTextCSV = "text/csv; charset=UTF-8"
+	mimeOFX     = "application/x-ofx"
+
+	ofxHeader = `<?xml version="1.0" encoding="UTF-8" standalone="no"?>` + "\n" +
+		`<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>` + "\n"
+	ofxBankID        = "0001"
+	ofxDateLayout    = "20060102150405.000[0:GMT]"
+	ofxNameMaxLength = 32
+)
+
+var csvHeader = []string{"date", "transfer_id", "description", "counterparty_account_id", "counterparty_name", "amount", "fee", "balance"}
+
+type (
+	csvWriter struct {
+		res *echo.Response
+		csv *csv.Writer
+	}
+	jsonWriter struct {
+		res     *echo.Response
+		entries int
+	}
+	// ofxWriter writes an OFX 2.2 bank statement. Encoding errors are kept and returned by the next Write call.
+	ofxWriter struct {
+		res *echo.Response
+		xml *xml.Encoder
+		err error
+		// minorUnits is the number of decimals of the statement currency.
+		minorUnits int
+	}
+)
+
+func newWriter(format string, res *echo.Response) statement.Writer {
+	switch format {
+	case model.StatementFormatCSV:
+		return &csvWriter{res: res, csv: csv.NewWriter(res)}
+	case model.StatementFormatJSON:
+		return &jsonWriter{res: res}
+	case model.StatementFormatOFX:
+		return &ofxWriter{res: res, xml: xml.NewEncoder(res)}
+	}
+	return nil
+}
+
+// startResponse sends the headers of the statement download. From here on the response status can't change.
+func startResponse(res *echo.Response, contentType string, format string) {
+	res.Header().Set(echo.HeaderContentType, contentType)
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="statement.%s"`, format))
+	res.WriteHeader(http.StatusOK)
+}
+
+func (w *csvWriter) WriteHeader(statement model.Statement) error {
+	startResponse(w.res, mimeTextCSV, model.StatementFormatCSV)
+	if err := w.csv.Write(csvHeader); err != nil {
+		return err
+	}
+	return w.csv.Write([]string{
+		statement.From.Format(time.RFC3339), "", "opening balance", "", "", "", "", strconv.FormatInt(statement.OpeningBalance, 10),
+	})
+}
+
+func (w *csvWriter) WriteEntry(entry model.StatementEntry) error {
+	return w.csv.Write([]string{
+		entry.CreatedAt.Format(time.RFC3339),
+		entry.TransferID,
+		getDescription(entry),
+		entry.CounterpartyAccountID,
+		entry.CounterpartyName,
+		strconv.FormatInt(entry.Amount, 10),
+		strconv.FormatInt(entry.Fee, 10),
+		strconv.FormatInt(entry.Balance, 10),
+	})
+}
+
+func (w *csvWriter) WriteFooter(statement model.Statement) error {
+	err := w.csv.Write([]string{
+		statement.To.Format(time.RFC3339), "", "closing balance", "", "", "", "", strconv.FormatInt(statement.ClosingBalance, 10),
+	})
+	if err != nil {
+		return err
+	}
+	w.csv.Flush()
+	return w.csv.Error()
+}
+
+func (w *jsonWriter) WriteHeader(statement model.Statement) error {
+	startResponse(w.res, echo.MIMEApplicationJSONCharsetUTF8, model.StatementFormatJSON)
+	header, err := json.Marshal(jsonStatementHeader{
+		AccountID:       statement.AccountID,
+		AccountName:     statement.AccountName,
+		AccountDocument: statement.AccountDocument,
+		From:            statement.From,
+		To:              statement.To,
+		OpeningBalance:  statement.OpeningBalance,
+	})
+	if err != nil {
+		return err
+	}
+	// the header object is left open so the entries and the closing balance can be appended to it
+	_, err = fmt.Fprintf(w.res, `{"data":%s,"entries":[`, header[:len(header)-1])
+	return err
+}
+
+func (w *jsonWriter) WriteEntry(entry model.StatementEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if w.entries > 0 {
+		if _, err = io.WriteString(w.res, ","); err != nil {
+			return err
+		}
+	}
+	w.entries++
+	_, err = w.res.Write(data)
+	return err
+}
+
+func (w *jsonWriter) WriteFooter(statement model.Statement) error {
+	_, err := fmt.Fprintf(w.res, "],\"closing_balance\":%d}}\n", statement.ClosingBalance)
+	return err
+}
+
+func (w *ofxWriter) WriteHeader(statement model.Statement) error {
+	startResponse(w.res, mimeOFX, model.StatementFormatOFX)
+	w.minorUnits, _ = model.GetCurrencyMinorUnits(statement.Currency)
+	if _, err := io.WriteString(w.res, ofxHeader); err != nil {
+		return err
+	}
+
+	w.start("OFX")
+	w.start("SIGNONMSGSRSV1")
+	w.element("SONRS", ofxSignOnResponse{
+		Status:   ofxStatus{Code: 0, Severity: "INFO"},
+		DTServer: formatOFXDate(statement.To),
+		Language: "POR",
+	})
+	w.end("SIGNONMSGSRSV1")
+	w.start("BANKMSGSRSV1")
+	w.start("STMTTRNRS")
+	w.element("TRNUID", "0")
+	w.element("STATUS", ofxStatus{Code: 0, Severity: "INFO"})
+	w.start("STMTRS")
+	w.element("CURDEF", statement.Currency)
+	w.element("BANKACCTFROM", ofxBankAccount{
+		BankID:   ofxBankID,
+		AcctID:   statement.AccountDocument,
+		AcctType: "CHECKING",
+	})
+	w.start("BANKTRANLIST")
+	w.element("DTSTART", formatOFXDate(statement.From))
+	w.element("DTEND", formatOFXDate(statement.To))
+	return w.flush()
+}
+
+// WriteEntry writes the amount and the fee of the entry as separate transactions. Entries of the fee account that
+// only collected a fee have no amount, so only the fee is written.
+func (w *ofxWriter) WriteEntry(entry model.StatementEntry) error {
+	trnType := "CREDIT"
+	if entry.Amount < 0 {
+		trnType = "DEBIT"
+	}
+	if entry.Amount != 0 || entry.Fee == 0 {
+		w.element("STMTTRN", ofxTransaction{
+			TrnType:  trnType,
+			DTPosted: formatOFXDate(entry.CreatedAt),
+			TrnAmt:   formatOFXAmount(entry.Amount, w.minorUnits),
+			FITID:    entry.TransferID,
+			Name:     truncate(entry.CounterpartyName, ofxNameMaxLength),
+			Memo:     getDescription(entry),
+		})
+	}
+	if entry.Fee != 0 {
+		feeType, memo := "FEE", "transfer fee"
+		if entry.Fee > 0 {
+			feeType, memo = "CREDIT", "fee collected"
+		}
+		w.element("STMTTRN", ofxTransaction{
+			TrnType:  feeType,
+			DTPosted: formatOFXDate(entry.CreatedAt),
+			TrnAmt:   formatOFXAmount(entry.Fee, w.minorUnits),
+			FITID:    entry.TransferID + "-fee",
+			Name:     truncate(entry.CounterpartyName, ofxNameMaxLength),
+			Memo:     memo,
+		})
+	}
+	return w.err
+}
+
+func (w *ofxWriter) WriteFooter(statement model.Statement) error {
+	w.end("BANKTRANLIST")
+	w.element("LEDGERBAL", ofxBalance{
+		BalAmt: formatOFXAmount(statement.ClosingBalance, w.minorUnits),
+		DTAsOf: formatOFXDate(statement.To),
+	})
+	w.end("STMTRS")
+	w.end("STMTTRNRS")
+	w.end("BANKMSGSRSV1")
+	w.end("OFX")
+	return w.flush()
+}
+
+func (w *ofxWriter) start(name string) {
+	if w.err == nil {
+		w.err = w.xml.EncodeToken(xml.StartElement{Name: xml.Name{Local: name}})
+	}
+}
+
+func (w *ofxWriter) end(name string) {
+	if w.err == nil {
+		w.err = w.xml.EncodeToken(xml.EndElement{Name: xml.Name{Local: name}})
+	}
+}
+
+func (w *ofxWriter) element(name string, v interface{}) {
+	if w.err == nil {
+		w.err = w.xml.EncodeElement(v, xml.StartElement{Name: xml.Name{Local: name}})
+	}
+}
+
+func (w *ofxWriter) flush() error {
+	if w.err == nil {
+		w.err = w.xml.Flush()
+	}
+	return w.err
+}
+
+func getDescription(entry model.StatementEntry) string {
+	if entry.Amount == 0 && entry.Fee > 0 {
+		return "fee collected"
+	}
+	description := "transfer"
+	if entry.ReversedTransferID != nil {
+		description = "reversal"
+	}
+	if entry.Amount < 0 {
+		return description + " sent"
+	}
+	return description + " received"
+}
+
+func formatOFXDate(t time.Time) string {
+	return t.UTC().Format(ofxDateLayout)
+}
+
+// formatOFXAmount writes an amount in minor units as a decimal with minorUnits digits without going through floats.
+func formatOFXAmount(amount int64, minorUnits int) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	if minorUnits == 0 {
+		return fmt.Sprintf("%s%d", sign, amount)
+	}
+	scale := int64(1)
+	for i := 0; i < minorUnits; i++ {
+		scale *= 10
+	}
+	return fmt.Sprintf("%s%d.%0*d", sign, amount/scale, minorUnits, amount%scale)
+}
+
+func truncate(value string, length int) string {
+	runes := []rune(value)
+	if len(runes) <= length {
+		return value
+	}
+	return string(runes[:length])
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
 	"github.com/labstack/echo/v4"
 	"net/http"
+	"time"
 )
 
 type handler struct {
@@ -22,19 +23,31 @@ func Register(g *echo.Group, opts apimodel.Options) {
 		transferApp: opts.App.Transfer(),
 	}
 
-	g.POST("/transfers", h.postTransfer, opts.Middleware.Auth().Private)
-	g.GET("/transfers", h.getTransfers, opts.Middleware.Auth().Private)
+	g.POST("/transfers", h.postTransfer, opts.Middleware.Auth().Private, opts.Middleware.Auth().RequirePermission(model.PermissionCreateTransfers), opts.Middleware.Signature().Verify, opts.Middleware.Idempotency().Handle)
+	g.POST("/transfers/quote", h.postQuote, opts.Middleware.Auth().Private, opts.Middleware.Auth().RequirePermission(model.PermissionCreateTransfers), opts.Middleware.Signature().Verify)
+	g.GET("/transfers", h.getTransfers, opts.Middleware.Auth().Private, opts.Middleware.Auth().RequirePermission(model.PermissionReadTransfers), opts.Middleware.Signature().Verify)
+	g.POST("/transfers/:id/reversal", h.postReversal, opts.Middleware.Auth().Private, opts.Middleware.Auth().RequirePermission(model.PermissionCreateTransfers), opts.Middleware.Signature().Verify, opts.Middleware.Idempotency().Handle)
+	g.POST("/transfers/holds", h.postHold, opts.Middleware.Auth().Private, opts.Middleware.Auth().RequirePermission(model.PermissionCreateTransfers), opts.Middleware.Signature().Verify, opts.Middleware.Idempotency().Handle)
+	g.GET("/transfers/holds", h.getHolds, opts.Middleware.Auth().Private, opts.Middleware.Auth().RequirePermission(model.PermissionReadTransfers), opts.Middleware.Signature().Verify)
+	g.POST("/transfers/holds/:id/capture", h.captureHold, opts.Middleware.Auth().Private, opts.Middleware.Auth().RequirePermission(model.PermissionCreateTransfers), opts.Middleware.Signature().Verify, opts.Middleware.Idempotency().Handle)
+	g.POST("/transfers/holds/:id/release", h.releaseHold, opts.Middleware.Auth().Private, opts.Middleware.Auth().RequirePermission(model.PermissionCreateTransfers), opts.Middleware.Signature().Verify)
+	g.GET("/transfers/reviews", h.getReviews, opts.Middleware.Auth().Private, opts.Middleware.Auth().RequirePermission(model.PermissionReviewTransfers))
+	g.POST("/transfers/reviews/:id/approve", h.approveReview, opts.Middleware.Auth().Private, opts.Middleware.Auth().RequirePermission(model.PermissionReviewTransfers))
+	g.POST("/transfers/reviews/:id/reject", h.rejectReview, opts.Middleware.Auth().Private, opts.Middleware.Auth().RequirePermission(model.PermissionReviewTransfers))
 
 	log.Info("registered")
 }
 
 // postTransfer swagger document
-// @Description Make a transfer between accounts
+// @Description Make a transfer between accounts. Transfers held by the risk check for a review are answered with 202
+// @Description and their review_id, their money stays reserved until an admin decides on them
 // @Tags transfer
 // @Produce json
 // @Security UserToken
+// @Param Idempotency-Key header string false "key to safely retry the request"
 // @Param transfer body postTransferBody true "expected structure"
 // @Success 200 {object} model.Response{data=model.Transfer}
+// @Success 202 {object} model.Response{data=model.Transfer}
 // @Success 400 {object} model.Response{error=error.ApiError}
 // @Failure 500 {object} model.Response{error=error.ApiError}
 // @Router /api/v1/transfers [post]
@@ -53,6 +66,50 @@ func (h *handler) postTransfer(c echo.Context) error {
 		OriginAccountID: sess.Account.ID,
 		TargetAccountID: body.TargetAccountID,
 		Amount:          body.Amount,
+		TwoFactorCode:   body.TwoFactorCode,
+	})
+	if err != nil {
+		if err := apierror.Get(err, errorMap); err != nil {
+			return err
+		}
+		log.Error(err)
+		return apierror.ErrInternal
+	}
+
+	status := http.StatusOK
+	if data.ReviewID != nil {
+		status = http.StatusAccepted
+	}
+	return c.JSON(status, apimodel.Response{
+		Data: data,
+	})
+}
+
+// postQuote swagger document
+// @Description Quote a transfer from current auth user, returning the converted amount and the fees without making it
+// @Tags transfer
+// @Produce json
+// @Security UserToken
+// @Param quote body postQuoteBody true "expected structure"
+// @Success 200 {object} model.Response{data=model.Transfer}
+// @Success 400 {object} model.Response{error=error.ApiError}
+// @Failure 500 {object} model.Response{error=error.ApiError}
+// @Router /api/v1/transfers/quote [post]
+func (h *handler) postQuote(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := h.logger.WithContext(ctx)
+
+	body := new(postQuoteBody)
+	if err := c.Bind(body); err != nil {
+		log.Error(err)
+		return apierror.ErrInvalidPayload
+	}
+
+	sess := model.GetSessionFromContext(ctx)
+	data, err := h.transferApp.Quote(ctx, model.Transfer{
+		OriginAccountID: sess.Account.ID,
+		TargetAccountID: body.TargetAccountID,
+		Amount:          body.Amount,
 	})
 	if err != nil {
 		if err := apierror.Get(err, errorMap); err != nil {
@@ -67,11 +124,21 @@ func (h *handler) postTransfer(c echo.Context) error {
 }
 
 // getTransfers swagger document
-// @Description List of transfer received or sent by current auth user
+// @Description List of transfer received or sent by current auth user, newest first unless sorted otherwise
 // @Tags transfer
 // @Produce json
 // @Security UserToken
-// @Success 200 {object} model.Response{data=[]model.Account}
+// @Param limit query int false "page size, up to 100 (default 50)"
+// @Param cursor query string false "next_cursor returned by the previous page"
+// @Param sort query string false "asc or desc (default) by creation date"
+// @Param sent query bool false "true for sent transfers only, false for received transfers only"
+// @Param counterparty_account_id query string false "id of the account on the other side of the transfer"
+// @Param from query string false "created at or after this RFC3339 date"
+// @Param to query string false "created before this RFC3339 date"
+// @Param min_amount query int false "minimum amount sent or received, in the currency of the account"
+// @Param max_amount query int false "maximum amount sent or received, in the currency of the account"
+// @Success 200 {object} model.Response{data=[]model.TransferDetailed}
+// @Success 400 {object} model.Response{error=error.ApiError}
 // @Failure 500 {object} model.Response{error=error.ApiError}
 // @Router /api/v1/transfers [get]
 func (h *handler) getTransfers(c echo.Context) error {
@@ -79,7 +146,55 @@ func (h *handler) getTransfers(c echo.Context) error {
 	log := h.logger.WithContext(ctx)
 
 	sess := model.GetSessionFromContext(ctx)
-	data, err := h.transferApp.List(ctx, sess.Account.ID)
+	filter, err := getTransferFilter(c, sess.Account.ID)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	page, err := h.transferApp.List(ctx, *filter)
+	if err != nil {
+		if err := apierror.Get(err, errorMap); err != nil {
+			return err
+		}
+		log.Error(err)
+		return apierror.ErrInternal
+	}
+	return c.JSON(http.StatusOK, apimodel.Response{
+		Data:       page.Transfers,
+		NextCursor: page.NextCursor,
+	})
+}
+
+// postReversal swagger document
+// @Description Send back all or part of a transfer received by current auth user, or of any transfer for admin operators
+// @Tags transfer
+// @Produce json
+// @Security UserToken
+// @Param Idempotency-Key header string false "key to safely retry the request"
+// @Param id path string true "id of the transfer to reverse"
+// @Param reversal body postReversalBody false "amount to reverse, everything not reversed yet when omitted"
+// @Success 200 {object} model.Response{data=model.Transfer}
+// @Success 400 {object} model.Response{error=error.ApiError}
+// @Failure 500 {object} model.Response{error=error.ApiError}
+// @Router /api/v1/transfers/{id}/reversal [post]
+func (h *handler) postReversal(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := h.logger.WithContext(ctx)
+
+	body := new(postReversalBody)
+	if err := c.Bind(body); err != nil {
+		log.Error(err)
+		return apierror.ErrInvalidPayload
+	}
+
+	sess := model.GetSessionFromContext(ctx)
+	accountID := sess.Account.ID
+	if sess.HasPermission(model.PermissionReverseAnyTransfer) {
+		accountID = ""
+	}
+
+	data, err := h.transferApp.Reverse(ctx, accountID, c.Param("id"), body.Amount)
 	if err != nil {
 		if err := apierror.Get(err, errorMap); err != nil {
 			return err
@@ -91,3 +206,255 @@ func (h *handler) getTransfers(c echo.Context) error {
 		Data: data,
 	})
 }
+
+// postHold swagger document
+// @Description Reserve money of current auth user for the target account to capture later into a transfer. The hold
+// @Description lasts 7 days unless expires_at, up to 30 days from now, is set
+// @Tags transfer
+// @Produce json
+// @Security UserToken
+// @Param Idempotency-Key header string false "key to safely retry the request"
+// @Param hold body postHoldBody true "expected structure"
+// @Success 200 {object} model.Response{data=model.Hold}
+// @Success 400 {object} model.Response{error=error.ApiError}
+// @Failure 500 {object} model.Response{error=error.ApiError}
+// @Router /api/v1/transfers/holds [post]
+func (h *handler) postHold(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := h.logger.WithContext(ctx)
+
+	body := new(postHoldBody)
+	if err := c.Bind(body); err != nil {
+		log.Error(err)
+		return apierror.ErrInvalidPayload
+	}
+
+	sess := model.GetSessionFromContext(ctx)
+	data, err := h.transferApp.CreateHold(ctx, model.Hold{
+		OriginAccountID: sess.Account.ID,
+		TargetAccountID: body.TargetAccountID,
+		Amount:          body.Amount,
+		ExpiresAt:       body.ExpiresAt,
+		TwoFactorCode:   body.TwoFactorCode,
+	})
+	if err != nil {
+		if err := apierror.Get(err, errorMap); err != nil {
+			return err
+		}
+		log.Error(err)
+		return apierror.ErrInternal
+	}
+	return c.JSON(http.StatusOK, apimodel.Response{
+		Data: data,
+	})
+}
+
+// getHolds swagger document
+// @Description List the holds made or received by current auth user, newest first
+// @Tags transfer
+// @Produce json
+// @Security UserToken
+// @Param status query string false "filter by status: active, captured, released or expired"
+// @Success 200 {object} model.Response{data=[]model.Hold}
+// @Failure 500 {object} model.Response{error=error.ApiError}
+// @Router /api/v1/transfers/holds [get]
+func (h *handler) getHolds(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := h.logger.WithContext(ctx)
+
+	sess := model.GetSessionFromContext(ctx)
+	data, err := h.transferApp.ListHolds(ctx, sess.Account.ID, c.QueryParam("status"))
+	if err != nil {
+		if err := apierror.Get(err, errorMap); err != nil {
+			return err
+		}
+		log.Error(err)
+		return apierror.ErrInternal
+	}
+	return c.JSON(http.StatusOK, apimodel.Response{
+		Data: data,
+	})
+}
+
+// captureHold swagger document
+// @Description Transfer all or part of a hold received by current auth user, releasing the rest of it
+// @Tags transfer
+// @Produce json
+// @Security UserToken
+// @Param Idempotency-Key header string false "key to safely retry the request"
+// @Param id path string true "id of the hold"
+// @Param capture body captureHoldBody false "amount to capture, the whole hold when omitted"
+// @Success 200 {object} model.Response{data=model.Transfer}
+// @Success 400 {object} model.Response{error=error.ApiError}
+// @Failure 404 {object} model.Response{error=error.ApiError}
+// @Failure 409 {object} model.Response{error=error.ApiError}
+// @Failure 500 {object} model.Response{error=error.ApiError}
+// @Router /api/v1/transfers/holds/{id}/capture [post]
+func (h *handler) captureHold(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := h.logger.WithContext(ctx)
+
+	body := new(captureHoldBody)
+	if err := c.Bind(body); err != nil {
+		log.Error(err)
+		return apierror.ErrInvalidPayload
+	}
+
+	sess := model.GetSessionFromContext(ctx)
+	data, err := h.transferApp.CaptureHold(ctx, sess.Account.ID, c.Param("id"), body.Amount)
+	if err != nil {
+		if err := apierror.Get(err, errorMap); err != nil {
+			return err
+		}
+		log.Error(err)
+		return apierror.ErrInternal
+	}
+	return c.JSON(http.StatusOK, apimodel.Response{
+		Data: data,
+	})
+}
+
+// releaseHold swagger document
+// @Description Give the money of a hold received by current auth user back to the account that made it
+// @Tags transfer
+// @Produce json
+// @Security UserToken
+// @Param id path string true "id of the hold"
+// @Success 200 {object} model.Response{data=model.Hold}
+// @Failure 404 {object} model.Response{error=error.ApiError}
+// @Failure 409 {object} model.Response{error=error.ApiError}
+// @Failure 500 {object} model.Response{error=error.ApiError}
+// @Router /api/v1/transfers/holds/{id}/release [post]
+func (h *handler) releaseHold(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := h.logger.WithContext(ctx)
+
+	sess := model.GetSessionFromContext(ctx)
+	data, err := h.transferApp.ReleaseHold(ctx, sess.Account.ID, c.Param("id"))
+	if err != nil {
+		if err := apierror.Get(err, errorMap); err != nil {
+			return err
+		}
+		log.Error(err)
+		return apierror.ErrInternal
+	}
+	return c.JSON(http.StatusOK, apimodel.Response{
+		Data: data,
+	})
+}
+
+// getReviews swagger document
+// @Description List the oldest transfers held by the risk check waiting for a review, restricted to admins
+// @Tags transfer
+// @Produce json
+// @Security UserToken
+// @Success 200 {object} model.Response{data=[]model.TransferReview}
+// @Failure 403 {object} model.Response{error=error.ApiError}
+// @Failure 500 {object} model.Response{error=error.ApiError}
+// @Router /api/v1/transfers/reviews [get]
+func (h *handler) getReviews(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := h.logger.WithContext(ctx)
+
+	data, err := h.transferApp.ListReviews(ctx)
+	if err != nil {
+		if err := apierror.Get(err, errorMap); err != nil {
+			return err
+		}
+		log.Error(err)
+		return apierror.ErrInternal
+	}
+	return c.JSON(http.StatusOK, apimodel.Response{
+		Data: data,
+	})
+}
+
+// approveReview swagger document
+// @Description Make a transfer held by the risk check, using its reserved money, restricted to admins
+// @Tags transfer
+// @Produce json
+// @Security UserToken
+// @Param id path string true "id of the review"
+// @Success 200 {object} model.Response{data=model.Transfer}
+// @Success 400 {object} model.Response{error=error.ApiError}
+// @Failure 403 {object} model.Response{error=error.ApiError}
+// @Failure 404 {object} model.Response{error=error.ApiError}
+// @Failure 409 {object} model.Response{error=error.ApiError}
+// @Failure 500 {object} model.Response{error=error.ApiError}
+// @Router /api/v1/transfers/reviews/{id}/approve [post]
+func (h *handler) approveReview(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := h.logger.WithContext(ctx)
+
+	sess := model.GetSessionFromContext(ctx)
+	data, err := h.transferApp.ApproveReview(ctx, c.Param("id"), sess.Account.ID)
+	if err != nil {
+		if err := apierror.Get(err, errorMap); err != nil {
+			return err
+		}
+		log.Error(err)
+		return apierror.ErrInternal
+	}
+	return c.JSON(http.StatusOK, apimodel.Response{
+		Data: data,
+	})
+}
+
+// rejectReview swagger document
+// @Description Drop a transfer held by the risk check, releasing its reserved money, restricted to admins
+// @Tags transfer
+// @Produce json
+// @Security UserToken
+// @Param id path string true "id of the review"
+// @Success 204
+// @Failure 403 {object} model.Response{error=error.ApiError}
+// @Failure 404 {object} model.Response{error=error.ApiError}
+// @Failure 409 {object} model.Response{error=error.ApiError}
+// @Failure 500 {object} model.Response{error=error.ApiError}
+// @Router /api/v1/transfers/reviews/{id}/reject [post]
+func (h *handler) rejectReview(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := h.logger.WithContext(ctx)
+
+	sess := model.GetSessionFromContext(ctx)
+	if err := h.transferApp.RejectReview(ctx, c.Param("id"), sess.Account.ID); err != nil {
+		if err := apierror.Get(err, errorMap); err != nil {
+			return err
+		}
+		log.Error(err)
+		return apierror.ErrInternal
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func getTransferFilter(c echo.Context, accountID string) (*model.TransferFilter, error) {
+	var (
+		filter = model.TransferFilter{AccountID: accountID}
+		sent   bool
+		cursor string
+	)
+	err := echo.QueryParamsBinder(c).
+		Int("limit", &filter.Limit).
+		String("cursor", &cursor).
+		String("sort", &filter.Sort).
+		Bool("sent", &sent).
+		String("counterparty_account_id", &filter.CounterpartyAccountID).
+		Time("from", &filter.From, time.RFC3339).
+		Time("to", &filter.To, time.RFC3339).
+		Int64("min_amount", &filter.MinAmount).
+		Int64("max_amount", &filter.MaxAmount).
+		BindError()
+	if err != nil {
+		return nil, apierror.ErrInvalidPayload
+	}
+
+	if c.QueryParam("sent") != "" {
+		filter.Sent = &sent
+	}
+	if cursor != "" {
+		if filter.Cursor, err = model.DecodeCursor(cursor); err != nil {
+			return nil, apierror.ErrInvalidCursor
+		}
+	}
+	return &filter, nil
+}
@@ -12,4 +12,29 @@ var errorMap = map[error]*apierror.ApiError{
 	pkgerror.ErrOriginAccountTransferNotFound: apierror.NewApiError(http.StatusBadRequest, pkgerror.ErrOriginAccountTransferNotFound.Error(), nil),
 	pkgerror.ErrTargetAccountTransferNotFound: apierror.NewApiError(http.StatusBadRequest, pkgerror.ErrTargetAccountTransferNotFound.Error(), nil),
 	pkgerror.ErrInsufficientFunds:             apierror.NewApiError(http.StatusBadRequest, pkgerror.ErrInsufficientFunds.Error(), nil),
+	pkgerror.ErrOriginAccountNotActive:        apierror.NewApiError(http.StatusForbidden, pkgerror.ErrOriginAccountNotActive.Error(), nil),
+	pkgerror.ErrTargetAccountNotActive:        apierror.NewApiError(http.StatusBadRequest, pkgerror.ErrTargetAccountNotActive.Error(), nil),
+	pkgerror.ErrCantReverseTransfer:           apierror.NewApiError(http.StatusInternalServerError, pkgerror.ErrCantReverseTransfer.Error(), nil),
+	pkgerror.ErrTransferNotFound:              apierror.NewApiError(http.StatusNotFound, pkgerror.ErrTransferNotFound.Error(), nil),
+	pkgerror.ErrTransferIsReversal:            apierror.NewApiError(http.StatusBadRequest, pkgerror.ErrTransferIsReversal.Error(), nil),
+	pkgerror.ErrTransferAlreadyReversed:       apierror.NewApiError(http.StatusConflict, pkgerror.ErrTransferAlreadyReversed.Error(), nil),
+	pkgerror.ErrInvalidReversalAmount:         apierror.NewApiError(http.StatusBadRequest, pkgerror.ErrInvalidReversalAmount.Error(), nil),
+	pkgerror.ErrTwoFactorRequired:             apierror.NewApiError(http.StatusUnauthorized, pkgerror.ErrTwoFactorRequired.Error(), nil),
+	pkgerror.ErrInvalidTwoFactorCode:          apierror.NewApiError(http.StatusUnauthorized, pkgerror.ErrInvalidTwoFactorCode.Error(), nil),
+	pkgerror.ErrExchangeRateNotFound:          apierror.NewApiError(http.StatusBadRequest, pkgerror.ErrExchangeRateNotFound.Error(), nil),
+	pkgerror.ErrTransferAmountTooSmall:        apierror.NewApiError(http.StatusBadRequest, pkgerror.ErrTransferAmountTooSmall.Error(), nil),
+	pkgerror.ErrTransferLimitExceeded:         apierror.NewApiError(http.StatusBadRequest, pkgerror.ErrTransferLimitExceeded.Error(), nil),
+	pkgerror.ErrTransferDenied:                apierror.NewApiError(http.StatusForbidden, pkgerror.ErrTransferDenied.Error(), nil),
+	pkgerror.ErrCantListTransferReviews:       apierror.NewApiError(http.StatusInternalServerError, pkgerror.ErrCantListTransferReviews.Error(), nil),
+	pkgerror.ErrCantDecideTransferReview:      apierror.NewApiError(http.StatusInternalServerError, pkgerror.ErrCantDecideTransferReview.Error(), nil),
+	pkgerror.ErrTransferReviewNotFound:        apierror.NewApiError(http.StatusNotFound, pkgerror.ErrTransferReviewNotFound.Error(), nil),
+	pkgerror.ErrTransferReviewAlreadyDecided:  apierror.NewApiError(http.StatusConflict, pkgerror.ErrTransferReviewAlreadyDecided.Error(), nil),
+	pkgerror.ErrCantCreateHold:                apierror.NewApiError(http.StatusInternalServerError, pkgerror.ErrCantCreateHold.Error(), nil),
+	pkgerror.ErrCantListHolds:                 apierror.NewApiError(http.StatusInternalServerError, pkgerror.ErrCantListHolds.Error(), nil),
+	pkgerror.ErrCantCaptureHold:               apierror.NewApiError(http.StatusInternalServerError, pkgerror.ErrCantCaptureHold.Error(), nil),
+	pkgerror.ErrCantReleaseHold:               apierror.NewApiError(http.StatusInternalServerError, pkgerror.ErrCantReleaseHold.Error(), nil),
+	pkgerror.ErrHoldNotFound:                  apierror.NewApiError(http.StatusNotFound, pkgerror.ErrHoldNotFound.Error(), nil),
+	pkgerror.ErrHoldNotActive:                 apierror.NewApiError(http.StatusConflict, pkgerror.ErrHoldNotActive.Error(), nil),
+	pkgerror.ErrInvalidHoldExpiry:             apierror.NewApiError(http.StatusBadRequest, pkgerror.ErrInvalidHoldExpiry.Error(), nil),
+	pkgerror.ErrInvalidCaptureAmount:          apierror.NewApiError(http.StatusBadRequest, pkgerror.ErrInvalidCaptureAmount.Error(), nil),
 }
@@ -1,6 +1,27 @@
 package transfer
 
-type postTransferBody struct {
-	TargetAccountID string `json:"account_destination_id"`
-	Amount          int64  `json:"amount"`
-}
+import "time"
+
+type (
+	postTransferBody struct {
+		TargetAccountID string `json:"account_destination_id"`
+		Amount          int64  `json:"amount"`
+		TwoFactorCode   string `json:"two_factor_code"`
+	}
+	postQuoteBody struct {
+		TargetAccountID string `json:"account_destination_id"`
+		Amount          int64  `json:"amount"`
+	}
+	postReversalBody struct {
+		Amount int64 `json:"amount"`
+	}
+	postHoldBody struct {
+		TargetAccountID string    `json:"account_destination_id"`
+		Amount          int64     `json:"amount"`
+		ExpiresAt       time.Time `json:"expires_at"`
+		TwoFactorCode   string    `json:"two_factor_code"`
+	}
+	captureHoldBody struct {
+		Amount int64 `json:"amount"`
+	}
+)
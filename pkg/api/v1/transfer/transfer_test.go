@@ -40,6 +40,12 @@ func TestHandler_CreateTransfer(t *testing.T) {
 			OriginAccountID: createTransferExample.OriginAccountID,
 			TargetAccountID: createTransferExample.TargetAccountID,
 		}
+		reviewID            = "review_id"
+		heldTransferExample = model.Transfer{
+			OriginAccountID: createTransferExample.OriginAccountID,
+			TargetAccountID: createTransferExample.TargetAccountID,
+			ReviewID:        &reviewID,
+		}
 		validationErrorExample = &validator.ValidationError{
 			OriginalMessage: "invalid data",
 			Message:         "invalid data",
@@ -52,6 +58,7 @@ func TestHandler_CreateTransfer(t *testing.T) {
 
 	cases := map[string]struct {
 		InputData      func(t *testing.T) io.Reader
+		ExpectedStatus int
 		ExpectedData   *model.Transfer
 		ExpectedErr    error
 		PrepareMockApp func(mock *transfer.MockApp)
@@ -62,14 +69,44 @@ func TestHandler_CreateTransfer(t *testing.T) {
 				assert.NoError(t, err)
 				return bytes.NewReader(body)
 			},
-			ExpectedData: &createdTransferExample,
-			ExpectedErr:  nil,
+			ExpectedStatus: http.StatusOK,
+			ExpectedData:   &createdTransferExample,
+			ExpectedErr:    nil,
 			PrepareMockApp: func(mock *transfer.MockApp) {
 				mock.EXPECT().
 					Create(gomock.Any(), createTransferExample).
 					Return(&createdTransferExample, nil)
 			},
 		},
+		"should return success: held for review": {
+			InputData: func(t *testing.T) io.Reader {
+				body, err := json.Marshal(postTransferExample)
+				assert.NoError(t, err)
+				return bytes.NewReader(body)
+			},
+			ExpectedStatus: http.StatusAccepted,
+			ExpectedData:   &heldTransferExample,
+			ExpectedErr:    nil,
+			PrepareMockApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().
+					Create(gomock.Any(), createTransferExample).
+					Return(&heldTransferExample, nil)
+			},
+		},
+		"should return error: denied by the risk check": {
+			InputData: func(t *testing.T) io.Reader {
+				body, err := json.Marshal(postTransferExample)
+				assert.NoError(t, err)
+				return bytes.NewReader(body)
+			},
+			ExpectedData: nil,
+			ExpectedErr:  errorMap[pkgerror.ErrTransferDenied],
+			PrepareMockApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().
+					Create(gomock.Any(), createTransferExample).
+					Return(nil, pkgerror.ErrTransferDenied)
+			},
+		},
 		"should return error on bind": {
 			InputData: func(t *testing.T) io.Reader {
 				return strings.NewReader("invalid body")
@@ -139,6 +176,24 @@ func TestHandler_CreateTransfer(t *testing.T) {
 					Return(nil, pkgerror.ErrInsufficientFunds)
 			},
 		},
+		"should return error: transfer limit exceeded": {
+			InputData: func(t *testing.T) io.Reader {
+				body, err := json.Marshal(postTransferExample)
+				assert.NoError(t, err)
+				return bytes.NewReader(body)
+			},
+			ExpectedData: nil,
+			ExpectedErr: apierror.NewApiError(
+				http.StatusBadRequest,
+				pkgerror.ErrTransferLimitExceeded.Error(),
+				&pkgerror.TransferLimitError{Limit: model.LimitDaily, Remaining: 200},
+			),
+			PrepareMockApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().
+					Create(gomock.Any(), createTransferExample).
+					Return(nil, &pkgerror.TransferLimitError{Limit: model.LimitDaily, Remaining: 200})
+			},
+		},
 		"should return error": {
 			InputData: func(t *testing.T) io.Reader {
 				body, err := json.Marshal(postTransferExample)
@@ -184,6 +239,139 @@ func TestHandler_CreateTransfer(t *testing.T) {
 
 			err := h.postTransfer(c)
 
+			assert.Equal(t, cs.ExpectedErr, err)
+			if cs.ExpectedErr == nil {
+				assert.Equal(t, cs.ExpectedStatus, rec.Code)
+			}
+
+			expectedResponseJSON, err := json.Marshal(apimodel.Response{Data: cs.ExpectedData})
+			assert.NoError(t, err)
+
+			var expectedResponse apimodel.Response
+			err = json.Unmarshal(expectedResponseJSON, &expectedResponse)
+			assert.NoError(t, err)
+
+			var currentResponse apimodel.Response
+			json.NewDecoder(rec.Body).Decode(&currentResponse)
+
+			assert.Equal(t, expectedResponse, currentResponse)
+		})
+	}
+}
+
+func TestHandler_postQuote(t *testing.T) {
+	var (
+		endpoint         = "/api/v1/transfers/quote"
+		postQuoteExample = postQuoteBody{
+			TargetAccountID: "target_account_id",
+			Amount:          500,
+		}
+		quoteTransferExample = model.Transfer{
+			OriginAccountID: "origin_account_id",
+			TargetAccountID: postQuoteExample.TargetAccountID,
+			Amount:          postQuoteExample.Amount,
+		}
+		quotedTransferExample = model.Transfer{
+			OriginAccountID: quoteTransferExample.OriginAccountID,
+			TargetAccountID: quoteTransferExample.TargetAccountID,
+			Amount:          quoteTransferExample.Amount,
+			SourceCurrency:  "BRL",
+			TargetAmount:    quoteTransferExample.Amount,
+			TargetCurrency:  "BRL",
+			ExchangeRate:    "1",
+			Fee:             65,
+			Fees:            []model.TransferFee{{Rule: "pix", Amount: 50}, {Rule: "service", Amount: 15}},
+		}
+	)
+
+	cases := map[string]struct {
+		InputData      func(t *testing.T) io.Reader
+		ExpectedData   *model.Transfer
+		ExpectedErr    error
+		PrepareMockApp func(mock *transfer.MockApp)
+	}{
+		"should return success": {
+			InputData: func(t *testing.T) io.Reader {
+				body, err := json.Marshal(postQuoteExample)
+				assert.NoError(t, err)
+				return bytes.NewReader(body)
+			},
+			ExpectedData: &quotedTransferExample,
+			ExpectedErr:  nil,
+			PrepareMockApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().
+					Quote(gomock.Any(), quoteTransferExample).
+					Return(&quotedTransferExample, nil)
+			},
+		},
+		"should return error on bind": {
+			InputData: func(t *testing.T) io.Reader {
+				return strings.NewReader("invalid body")
+			},
+			ExpectedData: nil,
+			ExpectedErr:  apierror.ErrInvalidPayload,
+			PrepareMockApp: func(mock *transfer.MockApp) {
+			},
+		},
+		"should return error: exchange rate not found": {
+			InputData: func(t *testing.T) io.Reader {
+				body, err := json.Marshal(postQuoteExample)
+				assert.NoError(t, err)
+				return bytes.NewReader(body)
+			},
+			ExpectedData: nil,
+			ExpectedErr:  errorMap[pkgerror.ErrExchangeRateNotFound],
+			PrepareMockApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().
+					Quote(gomock.Any(), quoteTransferExample).
+					Return(nil, pkgerror.ErrExchangeRateNotFound)
+			},
+		},
+		"should return error": {
+			InputData: func(t *testing.T) io.Reader {
+				body, err := json.Marshal(postQuoteExample)
+				assert.NoError(t, err)
+				return bytes.NewReader(body)
+			},
+			ExpectedData: nil,
+			ExpectedErr:  apierror.ErrInternal,
+			PrepareMockApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().
+					Quote(gomock.Any(), quoteTransferExample).
+					Return(nil, errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			ctx = model.SetSessionOnContext(ctx, &model.Session{
+				Token: "session_token",
+				Account: model.Account{
+					ID: quoteTransferExample.OriginAccountID,
+				},
+			})
+
+			mockApp := transfer.NewMockApp(ctrl)
+
+			cs.PrepareMockApp(mockApp)
+
+			h := handler{
+				logger:      logger.New(""),
+				transferApp: mockApp,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, endpoint, cs.InputData(t)).WithContext(ctx)
+			rec := httptest.NewRecorder()
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			c := e.NewContext(req, rec)
+			c.SetPath(endpoint)
+
+			err := h.postQuote(c)
+
 			assert.Equal(t, cs.ExpectedErr, err)
 
 			expectedResponseJSON, err := json.Marshal(apimodel.Response{Data: cs.ExpectedData})
@@ -204,6 +392,9 @@ func TestHandler_CreateTransfer(t *testing.T) {
 func TestHandler_getTransfers(t *testing.T) {
 	var (
 		endpoint         = "/api/v1/transfers"
+		sent             = false
+		from             = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		cursor           = model.Cursor{CreatedAt: from, ID: "last_id"}
 		transfersExample = []model.TransferDetailed{{
 			Transfer: model.Transfer{
 				ID:              "transfer_id",
@@ -215,32 +406,71 @@ func TestHandler_getTransfers(t *testing.T) {
 			OriginAccountName: "Origin Account",
 			TargetAccountName: "Target account",
 		}}
+		pageExample = model.TransferPage{
+			Transfers:  transfersExample,
+			NextCursor: "next_cursor",
+		}
 	)
 
 	cases := map[string]struct {
+		InputQuery     string
 		ExpectedData   []model.TransferDetailed
+		ExpectedCursor string
 		ExpectedErr    error
 		PrepareMockApp func(mock *transfer.MockApp)
 	}{
 		"should return success": {
-			ExpectedData: transfersExample,
-			ExpectedErr:  nil,
+			InputQuery:     "",
+			ExpectedData:   transfersExample,
+			ExpectedCursor: "next_cursor",
+			ExpectedErr:    nil,
+			PrepareMockApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().
+					List(gomock.Any(), model.TransferFilter{AccountID: "origin_account_id"}).
+					Return(&pageExample, nil)
+			},
+		},
+		"should return success with filters": {
+			InputQuery:     "?limit=10&sort=asc&sent=false&from=2026-01-01T00:00:00Z&min_amount=100&cursor=" + cursor.Encode(),
+			ExpectedData:   transfersExample,
+			ExpectedCursor: "next_cursor",
+			ExpectedErr:    nil,
 			PrepareMockApp: func(mock *transfer.MockApp) {
-				mock.EXPECT().List(gomock.Any(), "origin_account_id").Return(transfersExample, nil)
+				mock.EXPECT().
+					List(gomock.Any(), model.TransferFilter{
+						AccountID: "origin_account_id",
+						Sent:      &sent,
+						From:      from,
+						MinAmount: 100,
+						Sort:      model.SortAsc,
+						Cursor:    &cursor,
+						Limit:     10,
+					}).
+					Return(&pageExample, nil)
 			},
 		},
+		"should return error: invalid query": {
+			InputQuery:     "?limit=ten",
+			ExpectedErr:    apierror.ErrInvalidPayload,
+			PrepareMockApp: func(mock *transfer.MockApp) {},
+		},
+		"should return error: invalid cursor": {
+			InputQuery:     "?cursor=invalid",
+			ExpectedErr:    apierror.ErrInvalidCursor,
+			PrepareMockApp: func(mock *transfer.MockApp) {},
+		},
 		"should return error": {
-			ExpectedData: nil,
-			ExpectedErr:  errorMap[pkgerror.ErrCantListTransfers],
+			InputQuery:  "",
+			ExpectedErr: errorMap[pkgerror.ErrCantListTransfers],
 			PrepareMockApp: func(mock *transfer.MockApp) {
-				mock.EXPECT().List(gomock.Any(), "origin_account_id").Return(nil, pkgerror.ErrCantListTransfers)
+				mock.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, pkgerror.ErrCantListTransfers)
 			},
 		},
 		"should return internal error": {
-			ExpectedData: nil,
-			ExpectedErr:  apierror.ErrInternal,
+			InputQuery:  "",
+			ExpectedErr: apierror.ErrInternal,
 			PrepareMockApp: func(mock *transfer.MockApp) {
-				mock.EXPECT().List(gomock.Any(), "origin_account_id").Return(nil, errors.New("fail"))
+				mock.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, errors.New("fail"))
 			},
 		},
 	}
@@ -266,7 +496,7 @@ func TestHandler_getTransfers(t *testing.T) {
 			}
 
 			e := echo.New()
-			req := httptest.NewRequest(http.MethodGet, endpoint, nil).WithContext(ctx)
+			req := httptest.NewRequest(http.MethodGet, endpoint+cs.InputQuery, nil).WithContext(ctx)
 			rec := httptest.NewRecorder()
 			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 			c := e.NewContext(req, rec)
@@ -276,6 +506,134 @@ func TestHandler_getTransfers(t *testing.T) {
 
 			assert.Equal(t, cs.ExpectedErr, err)
 
+			expectedResponseJSON, err := json.Marshal(apimodel.Response{Data: cs.ExpectedData, NextCursor: cs.ExpectedCursor})
+			assert.NoError(t, err)
+
+			var expectedResponse apimodel.Response
+			err = json.Unmarshal(expectedResponseJSON, &expectedResponse)
+			assert.NoError(t, err)
+
+			var currentResponse apimodel.Response
+			json.NewDecoder(rec.Body).Decode(&currentResponse)
+
+			assert.Equal(t, expectedResponse, currentResponse)
+		})
+	}
+}
+
+func TestHandler_postReversal(t *testing.T) {
+	var (
+		endpoint           = "/api/v1/transfers/:id/reversal"
+		originalTransferID = "original_transfer_id"
+		reversalExample    = model.Transfer{
+			ID:                 "reversal_transfer_id",
+			OriginAccountID:    "target_account_id",
+			TargetAccountID:    "origin_account_id",
+			Amount:             200,
+			ReversedTransferID: &originalTransferID,
+		}
+	)
+
+	cases := map[string]struct {
+		InputRole      string
+		InputData      io.Reader
+		ExpectedData   *model.Transfer
+		ExpectedErr    error
+		PrepareMockApp func(mock *transfer.MockApp)
+	}{
+		"should return success": {
+			InputData:    strings.NewReader(`{"amount":200}`),
+			ExpectedData: &reversalExample,
+			ExpectedErr:  nil,
+			PrepareMockApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().
+					Reverse(gomock.Any(), "target_account_id", originalTransferID, int64(200)).
+					Return(&reversalExample, nil)
+			},
+		},
+		"should return success without body": {
+			InputData:    nil,
+			ExpectedData: &reversalExample,
+			ExpectedErr:  nil,
+			PrepareMockApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().
+					Reverse(gomock.Any(), "target_account_id", originalTransferID, int64(0)).
+					Return(&reversalExample, nil)
+			},
+		},
+		"should return success for admin operators": {
+			InputRole:    model.RoleAdmin,
+			InputData:    strings.NewReader(`{"amount":200}`),
+			ExpectedData: &reversalExample,
+			ExpectedErr:  nil,
+			PrepareMockApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().
+					Reverse(gomock.Any(), "", originalTransferID, int64(200)).
+					Return(&reversalExample, nil)
+			},
+		},
+		"should return error on bind": {
+			InputData:      strings.NewReader("invalid body"),
+			ExpectedData:   nil,
+			ExpectedErr:    apierror.ErrInvalidPayload,
+			PrepareMockApp: func(mock *transfer.MockApp) {},
+		},
+		"should return error: already reversed": {
+			InputData:    strings.NewReader(`{"amount":200}`),
+			ExpectedData: nil,
+			ExpectedErr:  errorMap[pkgerror.ErrTransferAlreadyReversed],
+			PrepareMockApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().
+					Reverse(gomock.Any(), "target_account_id", originalTransferID, int64(200)).
+					Return(nil, pkgerror.ErrTransferAlreadyReversed)
+			},
+		},
+		"should return error": {
+			InputData:    strings.NewReader(`{"amount":200}`),
+			ExpectedData: nil,
+			ExpectedErr:  apierror.ErrInternal,
+			PrepareMockApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().
+					Reverse(gomock.Any(), "target_account_id", originalTransferID, int64(200)).
+					Return(nil, errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			ctx = model.SetSessionOnContext(ctx, &model.Session{
+				Token: "session_token",
+				Account: model.Account{
+					ID:   "target_account_id",
+					Role: cs.InputRole,
+				},
+			})
+
+			mockApp := transfer.NewMockApp(ctrl)
+
+			cs.PrepareMockApp(mockApp)
+
+			h := handler{
+				logger:      logger.New(""),
+				transferApp: mockApp,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, endpoint, cs.InputData).WithContext(ctx)
+			rec := httptest.NewRecorder()
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			c := e.NewContext(req, rec)
+			c.SetPath(endpoint)
+			c.SetParamNames("id")
+			c.SetParamValues(originalTransferID)
+
+			err := h.postReversal(c)
+
+			assert.Equal(t, cs.ExpectedErr, err)
+
 			expectedResponseJSON, err := json.Marshal(apimodel.Response{Data: cs.ExpectedData})
 			assert.NoError(t, err)
 
@@ -290,3 +648,619 @@ func TestHandler_getTransfers(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_postHold(t *testing.T) {
+	var (
+		endpoint    = "/api/v1/transfers/holds"
+		expiresAt   = time.Date(2026, time.October, 20, 12, 0, 0, 0, time.UTC)
+		holdExample = model.Hold{
+			OriginAccountID: "origin_account_id",
+			TargetAccountID: "target_account_id",
+			Amount:          10000,
+			ExpiresAt:       expiresAt,
+		}
+		createdHold = model.Hold{
+			ID:              "hold_id",
+			OriginAccountID: "origin_account_id",
+			TargetAccountID: "target_account_id",
+			Amount:          10000,
+			Currency:        "BRL",
+			Reserved:        10000,
+			Status:          model.HoldStatusActive,
+			ExpiresAt:       expiresAt,
+		}
+		body = `{"account_destination_id":"target_account_id","amount":10000,"expires_at":"2026-10-20T12:00:00Z"}`
+	)
+
+	cases := map[string]struct {
+		InputData      io.Reader
+		ExpectedData   *model.Hold
+		ExpectedErr    error
+		PrepareMockApp func(mock *transfer.MockApp)
+	}{
+		"should return success": {
+			InputData:    strings.NewReader(body),
+			ExpectedData: &createdHold,
+			ExpectedErr:  nil,
+			PrepareMockApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().CreateHold(gomock.Any(), holdExample).Return(&createdHold, nil)
+			},
+		},
+		"should return error on bind": {
+			InputData:      strings.NewReader("invalid body"),
+			ExpectedData:   nil,
+			ExpectedErr:    apierror.ErrInvalidPayload,
+			PrepareMockApp: func(mock *transfer.MockApp) {},
+		},
+		"should return error: invalid expiry": {
+			InputData:    strings.NewReader(body),
+			ExpectedData: nil,
+			ExpectedErr:  errorMap[pkgerror.ErrInvalidHoldExpiry],
+			PrepareMockApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().CreateHold(gomock.Any(), holdExample).Return(nil, pkgerror.ErrInvalidHoldExpiry)
+			},
+		},
+		"should return error": {
+			InputData:    strings.NewReader(body),
+			ExpectedData: nil,
+			ExpectedErr:  apierror.ErrInternal,
+			PrepareMockApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().CreateHold(gomock.Any(), holdExample).Return(nil, errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			ctx = model.SetSessionOnContext(ctx, &model.Session{
+				Token:   "session_token",
+				Account: model.Account{ID: "origin_account_id"},
+			})
+
+			mockApp := transfer.NewMockApp(ctrl)
+
+			cs.PrepareMockApp(mockApp)
+
+			h := handler{
+				logger:      logger.New(""),
+				transferApp: mockApp,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, endpoint, cs.InputData).WithContext(ctx)
+			rec := httptest.NewRecorder()
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			c := e.NewContext(req, rec)
+			c.SetPath(endpoint)
+
+			err := h.postHold(c)
+
+			assert.Equal(t, cs.ExpectedErr, err)
+
+			expectedResponseJSON, err := json.Marshal(apimodel.Response{Data: cs.ExpectedData})
+			assert.NoError(t, err)
+
+			var expectedResponse apimodel.Response
+			err = json.Unmarshal(expectedResponseJSON, &expectedResponse)
+			assert.NoError(t, err)
+
+			var currentResponse apimodel.Response
+			json.NewDecoder(rec.Body).Decode(&currentResponse)
+
+			assert.Equal(t, expectedResponse, currentResponse)
+		})
+	}
+}
+
+func TestHandler_getHolds(t *testing.T) {
+	var (
+		endpoint     = "/api/v1/transfers/holds"
+		holdsExample = []model.Hold{{
+			ID:              "hold_id",
+			OriginAccountID: "origin_account_id",
+			TargetAccountID: "target_account_id",
+			Amount:          10000,
+			Status:          model.HoldStatusActive,
+		}}
+	)
+
+	cases := map[string]struct {
+		ExpectedData   []model.Hold
+		ExpectedErr    error
+		PrepareMockApp func(mock *transfer.MockApp)
+	}{
+		"should return success": {
+			ExpectedData: holdsExample,
+			ExpectedErr:  nil,
+			PrepareMockApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().ListHolds(gomock.Any(), "origin_account_id", model.HoldStatusActive).Return(holdsExample, nil)
+			},
+		},
+		"should return error": {
+			ExpectedData: nil,
+			ExpectedErr:  apierror.ErrInternal,
+			PrepareMockApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().
+					ListHolds(gomock.Any(), "origin_account_id", model.HoldStatusActive).
+					Return(nil, errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			ctx = model.SetSessionOnContext(ctx, &model.Session{
+				Token:   "session_token",
+				Account: model.Account{ID: "origin_account_id"},
+			})
+
+			mockApp := transfer.NewMockApp(ctrl)
+
+			cs.PrepareMockApp(mockApp)
+
+			h := handler{
+				logger:      logger.New(""),
+				transferApp: mockApp,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, endpoint+"?status=active", nil).WithContext(ctx)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath(endpoint)
+
+			err := h.getHolds(c)
+
+			assert.Equal(t, cs.ExpectedErr, err)
+
+			expectedResponseJSON, err := json.Marshal(apimodel.Response{Data: cs.ExpectedData})
+			assert.NoError(t, err)
+
+			var expectedResponse apimodel.Response
+			err = json.Unmarshal(expectedResponseJSON, &expectedResponse)
+			assert.NoError(t, err)
+
+			var currentResponse apimodel.Response
+			json.NewDecoder(rec.Body).Decode(&currentResponse)
+
+			assert.Equal(t, expectedResponse, currentResponse)
+		})
+	}
+}
+
+func TestHandler_captureHold(t *testing.T) {
+	var (
+		endpoint        = "/api/v1/transfers/holds/:id/capture"
+		holdID          = "hold_id"
+		transferExample = model.Transfer{
+			ID:              "transfer_id",
+			OriginAccountID: "origin_account_id",
+			TargetAccountID: "target_account_id",
+			Amount:          6000,
+		}
+	)
+
+	cases := map[string]struct {
+		InputData      io.Reader
+		ExpectedData   *model.Transfer
+		ExpectedErr    error
+		PrepareMockApp func(mock *transfer.MockApp)
+	}{
+		"should return success": {
+			InputData:    strings.NewReader(`{"amount":6000}`),
+			ExpectedData: &transferExample,
+			ExpectedErr:  nil,
+			PrepareMockApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().
+					CaptureHold(gomock.Any(), "target_account_id", holdID, int64(6000)).
+					Return(&transferExample, nil)
+			},
+		},
+		"should return success without body": {
+			InputData:    nil,
+			ExpectedData: &transferExample,
+			ExpectedErr:  nil,
+			PrepareMockApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().
+					CaptureHold(gomock.Any(), "target_account_id", holdID, int64(0)).
+					Return(&transferExample, nil)
+			},
+		},
+		"should return error on bind": {
+			InputData:      strings.NewReader("invalid body"),
+			ExpectedData:   nil,
+			ExpectedErr:    apierror.ErrInvalidPayload,
+			PrepareMockApp: func(mock *transfer.MockApp) {},
+		},
+		"should return error: hold not active": {
+			InputData:    strings.NewReader(`{"amount":6000}`),
+			ExpectedData: nil,
+			ExpectedErr:  errorMap[pkgerror.ErrHoldNotActive],
+			PrepareMockApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().
+					CaptureHold(gomock.Any(), "target_account_id", holdID, int64(6000)).
+					Return(nil, pkgerror.ErrHoldNotActive)
+			},
+		},
+		"should return error": {
+			InputData:    strings.NewReader(`{"amount":6000}`),
+			ExpectedData: nil,
+			ExpectedErr:  apierror.ErrInternal,
+			PrepareMockApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().
+					CaptureHold(gomock.Any(), "target_account_id", holdID, int64(6000)).
+					Return(nil, errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			ctx = model.SetSessionOnContext(ctx, &model.Session{
+				Token:   "session_token",
+				Account: model.Account{ID: "target_account_id"},
+			})
+
+			mockApp := transfer.NewMockApp(ctrl)
+
+			cs.PrepareMockApp(mockApp)
+
+			h := handler{
+				logger:      logger.New(""),
+				transferApp: mockApp,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, endpoint, cs.InputData).WithContext(ctx)
+			rec := httptest.NewRecorder()
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			c := e.NewContext(req, rec)
+			c.SetPath(endpoint)
+			c.SetParamNames("id")
+			c.SetParamValues(holdID)
+
+			err := h.captureHold(c)
+
+			assert.Equal(t, cs.ExpectedErr, err)
+
+			expectedResponseJSON, err := json.Marshal(apimodel.Response{Data: cs.ExpectedData})
+			assert.NoError(t, err)
+
+			var expectedResponse apimodel.Response
+			err = json.Unmarshal(expectedResponseJSON, &expectedResponse)
+			assert.NoError(t, err)
+
+			var currentResponse apimodel.Response
+			json.NewDecoder(rec.Body).Decode(&currentResponse)
+
+			assert.Equal(t, expectedResponse, currentResponse)
+		})
+	}
+}
+
+func TestHandler_releaseHold(t *testing.T) {
+	var (
+		endpoint    = "/api/v1/transfers/holds/:id/release"
+		holdID      = "hold_id"
+		holdExample = model.Hold{
+			ID:              holdID,
+			OriginAccountID: "origin_account_id",
+			TargetAccountID: "target_account_id",
+			Amount:          10000,
+			Status:          model.HoldStatusReleased,
+		}
+	)
+
+	cases := map[string]struct {
+		ExpectedData   *model.Hold
+		ExpectedErr    error
+		PrepareMockApp func(mock *transfer.MockApp)
+	}{
+		"should return success": {
+			ExpectedData: &holdExample,
+			ExpectedErr:  nil,
+			PrepareMockApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().ReleaseHold(gomock.Any(), "target_account_id", holdID).Return(&holdExample, nil)
+			},
+		},
+		"should return error: hold not found": {
+			ExpectedData: nil,
+			ExpectedErr:  errorMap[pkgerror.ErrHoldNotFound],
+			PrepareMockApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().ReleaseHold(gomock.Any(), "target_account_id", holdID).Return(nil, pkgerror.ErrHoldNotFound)
+			},
+		},
+		"should return error": {
+			ExpectedData: nil,
+			ExpectedErr:  apierror.ErrInternal,
+			PrepareMockApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().ReleaseHold(gomock.Any(), "target_account_id", holdID).Return(nil, errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			ctx = model.SetSessionOnContext(ctx, &model.Session{
+				Token:   "session_token",
+				Account: model.Account{ID: "target_account_id"},
+			})
+
+			mockApp := transfer.NewMockApp(ctrl)
+
+			cs.PrepareMockApp(mockApp)
+
+			h := handler{
+				logger:      logger.New(""),
+				transferApp: mockApp,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, endpoint, nil).WithContext(ctx)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath(endpoint)
+			c.SetParamNames("id")
+			c.SetParamValues(holdID)
+
+			err := h.releaseHold(c)
+
+			assert.Equal(t, cs.ExpectedErr, err)
+
+			expectedResponseJSON, err := json.Marshal(apimodel.Response{Data: cs.ExpectedData})
+			assert.NoError(t, err)
+
+			var expectedResponse apimodel.Response
+			err = json.Unmarshal(expectedResponseJSON, &expectedResponse)
+			assert.NoError(t, err)
+
+			var currentResponse apimodel.Response
+			json.NewDecoder(rec.Body).Decode(&currentResponse)
+
+			assert.Equal(t, expectedResponse, currentResponse)
+		})
+	}
+}
+
+func TestHandler_getReviews(t *testing.T) {
+	var (
+		endpoint       = "/api/v1/transfers/reviews"
+		reviewsExample = []model.TransferReview{{
+			ID:              "review_id",
+			OriginAccountID: "origin_account_id",
+			TargetAccountID: "target_account_id",
+			Amount:          150000,
+			Reasons:         []string{"new-counterparty-high-amount"},
+			Status:          model.TransferReviewStatusPending,
+		}}
+	)
+
+	cases := map[string]struct {
+		ExpectedData   []model.TransferReview
+		ExpectedErr    error
+		PrepareMockApp func(mock *transfer.MockApp)
+	}{
+		"should return success": {
+			ExpectedData: reviewsExample,
+			ExpectedErr:  nil,
+			PrepareMockApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().ListReviews(gomock.Any()).Return(reviewsExample, nil)
+			},
+		},
+		"should return error: can't list reviews": {
+			ExpectedData: nil,
+			ExpectedErr:  errorMap[pkgerror.ErrCantListTransferReviews],
+			PrepareMockApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().ListReviews(gomock.Any()).Return(nil, pkgerror.ErrCantListTransferReviews)
+			},
+		},
+		"should return error": {
+			ExpectedData: nil,
+			ExpectedErr:  apierror.ErrInternal,
+			PrepareMockApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().ListReviews(gomock.Any()).Return(nil, errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			mockApp := transfer.NewMockApp(ctrl)
+
+			cs.PrepareMockApp(mockApp)
+
+			h := handler{
+				logger:      logger.New(""),
+				transferApp: mockApp,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, endpoint, nil).WithContext(ctx)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath(endpoint)
+
+			err := h.getReviews(c)
+
+			assert.Equal(t, cs.ExpectedErr, err)
+
+			expectedResponseJSON, err := json.Marshal(apimodel.Response{Data: cs.ExpectedData})
+			assert.NoError(t, err)
+
+			var expectedResponse apimodel.Response
+			err = json.Unmarshal(expectedResponseJSON, &expectedResponse)
+			assert.NoError(t, err)
+
+			var currentResponse apimodel.Response
+			json.NewDecoder(rec.Body).Decode(&currentResponse)
+
+			assert.Equal(t, expectedResponse, currentResponse)
+		})
+	}
+}
+
+func TestHandler_approveReview(t *testing.T) {
+	var (
+		endpoint        = "/api/v1/transfers/reviews/:id/approve"
+		reviewID        = "review_id"
+		transferExample = model.Transfer{
+			ID:              "transfer_id",
+			OriginAccountID: "origin_account_id",
+			TargetAccountID: "target_account_id",
+			Amount:          150000,
+			ReviewID:        &reviewID,
+		}
+	)
+
+	cases := map[string]struct {
+		ExpectedData   *model.Transfer
+		ExpectedErr    error
+		PrepareMockApp func(mock *transfer.MockApp)
+	}{
+		"should return success": {
+			ExpectedData: &transferExample,
+			ExpectedErr:  nil,
+			PrepareMockApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().ApproveReview(gomock.Any(), reviewID, "admin_id").Return(&transferExample, nil)
+			},
+		},
+		"should return error: already decided": {
+			ExpectedData: nil,
+			ExpectedErr:  errorMap[pkgerror.ErrTransferReviewAlreadyDecided],
+			PrepareMockApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().
+					ApproveReview(gomock.Any(), reviewID, "admin_id").
+					Return(nil, pkgerror.ErrTransferReviewAlreadyDecided)
+			},
+		},
+		"should return error": {
+			ExpectedData: nil,
+			ExpectedErr:  apierror.ErrInternal,
+			PrepareMockApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().ApproveReview(gomock.Any(), reviewID, "admin_id").Return(nil, errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			ctx = model.SetSessionOnContext(ctx, &model.Session{
+				Token:   "session_token",
+				Account: model.Account{ID: "admin_id", Role: model.RoleAdmin},
+			})
+
+			mockApp := transfer.NewMockApp(ctrl)
+
+			cs.PrepareMockApp(mockApp)
+
+			h := handler{
+				logger:      logger.New(""),
+				transferApp: mockApp,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, endpoint, nil).WithContext(ctx)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath(endpoint)
+			c.SetParamNames("id")
+			c.SetParamValues(reviewID)
+
+			err := h.approveReview(c)
+
+			assert.Equal(t, cs.ExpectedErr, err)
+
+			expectedResponseJSON, err := json.Marshal(apimodel.Response{Data: cs.ExpectedData})
+			assert.NoError(t, err)
+
+			var expectedResponse apimodel.Response
+			err = json.Unmarshal(expectedResponseJSON, &expectedResponse)
+			assert.NoError(t, err)
+
+			var currentResponse apimodel.Response
+			json.NewDecoder(rec.Body).Decode(&currentResponse)
+
+			assert.Equal(t, expectedResponse, currentResponse)
+		})
+	}
+}
+
+func TestHandler_rejectReview(t *testing.T) {
+	var (
+		endpoint = "/api/v1/transfers/reviews/:id/reject"
+		reviewID = "review_id"
+	)
+
+	cases := map[string]struct {
+		ExpectedStatus int
+		ExpectedErr    error
+		PrepareMockApp func(mock *transfer.MockApp)
+	}{
+		"should return success": {
+			ExpectedStatus: http.StatusNoContent,
+			ExpectedErr:    nil,
+			PrepareMockApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().RejectReview(gomock.Any(), reviewID, "admin_id").Return(nil)
+			},
+		},
+		"should return error: review not found": {
+			ExpectedErr: errorMap[pkgerror.ErrTransferReviewNotFound],
+			PrepareMockApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().RejectReview(gomock.Any(), reviewID, "admin_id").Return(pkgerror.ErrTransferReviewNotFound)
+			},
+		},
+		"should return error": {
+			ExpectedErr: apierror.ErrInternal,
+			PrepareMockApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().RejectReview(gomock.Any(), reviewID, "admin_id").Return(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			ctx = model.SetSessionOnContext(ctx, &model.Session{
+				Token:   "session_token",
+				Account: model.Account{ID: "admin_id", Role: model.RoleAdmin},
+			})
+
+			mockApp := transfer.NewMockApp(ctrl)
+
+			cs.PrepareMockApp(mockApp)
+
+			h := handler{
+				logger:      logger.New(""),
+				transferApp: mockApp,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, endpoint, nil).WithContext(ctx)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath(endpoint)
+			c.SetParamNames("id")
+			c.SetParamValues(reviewID)
+
+			err := h.rejectReview(c)
+
+			assert.Equal(t, cs.ExpectedErr, err)
+			if cs.ExpectedErr == nil {
+				assert.Equal(t, cs.ExpectedStatus, rec.Code)
+			}
+		})
+	}
+}
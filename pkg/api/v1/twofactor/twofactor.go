@@ -0,0 +1,92 @@
+package twofactor
+
+import (
+	apierror "github.com/carlosrodriguesf/bank-api/pkg/api/error"
+	apimodel "github.com/carlosrodriguesf/bank-api/pkg/api/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/app/twofactor"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type handler struct {
+	logger       logger.Logger
+	twoFactorApp twofactor.App
+}
+
+func Register(g *echo.Group, opts apimodel.Options) {
+	log := opts.Logger.WithPreffix("api.v1.twofactor")
+	h := handler{
+		logger:       log.WithLocation(),
+		twoFactorApp: opts.App.TwoFactor(),
+	}
+
+	g.POST("/two-factor/enroll", h.enroll, opts.Middleware.Auth().Private, opts.Middleware.Auth().RequirePermission(model.PermissionManageCredentials))
+	g.POST("/two-factor/confirm", h.confirm, opts.Middleware.Auth().Private, opts.Middleware.Auth().RequirePermission(model.PermissionManageCredentials))
+
+	log.Info("registered")
+}
+
+// enroll swagger document
+// @Description Generate a TOTP secret for current auth user, it is enforced only after being confirmed
+// @Tags two-factor
+// @Produce json
+// @Security UserToken
+// @Success 200 {object} model.Response{data=model.TwoFactorEnrollment}
+// @Failure 409 {object} model.Response{error=error.ApiError}
+// @Failure 500 {object} model.Response{error=error.ApiError}
+// @Router /api/v1/two-factor/enroll [post]
+func (h *handler) enroll(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := h.logger.WithContext(ctx)
+
+	sess := model.GetSessionFromContext(ctx)
+	data, err := h.twoFactorApp.Enroll(ctx, sess.Account.ID)
+	if err != nil {
+		if err := apierror.Get(err, errorMap); err != nil {
+			return err
+		}
+		log.Error(err)
+		return apierror.ErrInternal
+	}
+	return c.JSON(http.StatusOK, apimodel.Response{
+		Data: data,
+	})
+}
+
+// confirm swagger document
+// @Description Enable the enrolled TOTP secret of current auth user and return its recovery codes
+// @Tags two-factor
+// @Produce json
+// @Security UserToken
+// @Param body body confirmBody true "expected structure"
+// @Success 200 {object} model.Response{data=model.TwoFactorConfirmation}
+// @Failure 400 {object} model.Response{error=error.ApiError}
+// @Failure 401 {object} model.Response{error=error.ApiError}
+// @Failure 409 {object} model.Response{error=error.ApiError}
+// @Failure 500 {object} model.Response{error=error.ApiError}
+// @Router /api/v1/two-factor/confirm [post]
+func (h *handler) confirm(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := h.logger.WithContext(ctx)
+
+	var body confirmBody
+	if err := c.Bind(&body); err != nil {
+		log.Error(err)
+		return apierror.ErrInvalidPayload
+	}
+
+	sess := model.GetSessionFromContext(ctx)
+	data, err := h.twoFactorApp.Confirm(ctx, sess.Account.ID, body.Code)
+	if err != nil {
+		if err := apierror.Get(err, errorMap); err != nil {
+			return err
+		}
+		log.Error(err)
+		return apierror.ErrInternal
+	}
+	return c.JSON(http.StatusOK, apimodel.Response{
+		Data: data,
+	})
+}
@@ -0,0 +1,16 @@
+package twofactor
+
+import (
+	apierror "github.com/carlosrodriguesf/bank-api/pkg/api/error"
+	pkgerror "github.com/carlosrodriguesf/bank-api/pkg/error"
+	"net/http"
+)
+
+var errorMap = map[error]*apierror.ApiError{
+	pkgerror.ErrCantEnrollTwoFactor:     apierror.NewApiError(http.StatusInternalServerError, pkgerror.ErrCantEnrollTwoFactor.Error(), nil),
+	pkgerror.ErrCantConfirmTwoFactor:    apierror.NewApiError(http.StatusInternalServerError, pkgerror.ErrCantConfirmTwoFactor.Error(), nil),
+	pkgerror.ErrTwoFactorAlreadyEnabled: apierror.NewApiError(http.StatusConflict, pkgerror.ErrTwoFactorAlreadyEnabled.Error(), nil),
+	pkgerror.ErrTwoFactorNotEnrolled:    apierror.NewApiError(http.StatusBadRequest, pkgerror.ErrTwoFactorNotEnrolled.Error(), nil),
+	pkgerror.ErrInvalidTwoFactorCode:    apierror.NewApiError(http.StatusUnauthorized, pkgerror.ErrInvalidTwoFactorCode.Error(), nil),
+	pkgerror.ErrAccountNotFound:         apierror.NewApiError(http.StatusNotFound, pkgerror.ErrAccountNotFound.Error(), nil),
+}
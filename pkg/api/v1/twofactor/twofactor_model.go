@@ -0,0 +1,5 @@
+package twofactor
+
+type confirmBody struct {
+	Code string `json:"code"`
+}
@@ -0,0 +1,199 @@
+package twofactor
+
+import (
+	"context"
+	"encoding/json"
+	apierror "github.com/carlosrodriguesf/bank-api/pkg/api/error"
+	apimodel "github.com/carlosrodriguesf/bank-api/pkg/api/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/app/twofactor"
+	pkgerror "github.com/carlosrodriguesf/bank-api/pkg/error"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+var sessionExample = model.Session{
+	ID:      "session_id",
+	Token:   "session_token",
+	Account: model.Account{ID: "account_id"},
+}
+
+func TestHandler_enroll(t *testing.T) {
+	var (
+		endpoint          = "/api/v1/two-factor/enroll"
+		enrollmentExample = model.TwoFactorEnrollment{
+			Secret: "SECRET",
+			URI:    "otpauth://totp/Bank%20API:12312312312?secret=SECRET",
+		}
+	)
+
+	cases := map[string]struct {
+		ExpectedData   *model.TwoFactorEnrollment
+		ExpectedErr    error
+		PrepareMockApp func(mock *twofactor.MockApp)
+	}{
+		"should return success": {
+			ExpectedData: &enrollmentExample,
+			ExpectedErr:  nil,
+			PrepareMockApp: func(mock *twofactor.MockApp) {
+				mock.EXPECT().
+					Enroll(gomock.Any(), "account_id").
+					Return(&enrollmentExample, nil)
+			},
+		},
+		"should return error: already enabled": {
+			ExpectedData: nil,
+			ExpectedErr:  errorMap[pkgerror.ErrTwoFactorAlreadyEnabled],
+			PrepareMockApp: func(mock *twofactor.MockApp) {
+				mock.EXPECT().
+					Enroll(gomock.Any(), "account_id").
+					Return(nil, pkgerror.ErrTwoFactorAlreadyEnabled)
+			},
+		},
+		"should return error: cant enroll": {
+			ExpectedData: nil,
+			ExpectedErr:  errorMap[pkgerror.ErrCantEnrollTwoFactor],
+			PrepareMockApp: func(mock *twofactor.MockApp) {
+				mock.EXPECT().
+					Enroll(gomock.Any(), "account_id").
+					Return(nil, pkgerror.ErrCantEnrollTwoFactor)
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			ctx = model.SetSessionOnContext(ctx, &sessionExample)
+
+			mockApp := twofactor.NewMockApp(ctrl)
+
+			cs.PrepareMockApp(mockApp)
+
+			h := handler{
+				logger:       logger.New(""),
+				twoFactorApp: mockApp,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, endpoint, nil).WithContext(ctx)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath(endpoint)
+
+			err := h.enroll(c)
+
+			assert.Equal(t, cs.ExpectedErr, err)
+
+			expectedResponseJSON, err := json.Marshal(apimodel.Response{Data: cs.ExpectedData})
+			assert.NoError(t, err)
+
+			var expectedResponse apimodel.Response
+			err = json.Unmarshal(expectedResponseJSON, &expectedResponse)
+			assert.NoError(t, err)
+
+			var currentResponse apimodel.Response
+			json.NewDecoder(rec.Body).Decode(&currentResponse)
+
+			assert.Equal(t, expectedResponse, currentResponse)
+		})
+	}
+}
+
+func TestHandler_confirm(t *testing.T) {
+	var (
+		endpoint            = "/api/v1/two-factor/confirm"
+		confirmationExample = model.TwoFactorConfirmation{
+			RecoveryCodes: []string{"abcde-fghjk"},
+		}
+	)
+
+	cases := map[string]struct {
+		InputBody      string
+		ExpectedData   *model.TwoFactorConfirmation
+		ExpectedErr    error
+		PrepareMockApp func(mock *twofactor.MockApp)
+	}{
+		"should return success": {
+			InputBody:    `{"code":"123456"}`,
+			ExpectedData: &confirmationExample,
+			ExpectedErr:  nil,
+			PrepareMockApp: func(mock *twofactor.MockApp) {
+				mock.EXPECT().
+					Confirm(gomock.Any(), "account_id", "123456").
+					Return(&confirmationExample, nil)
+			},
+		},
+		"should return error on bind": {
+			InputBody:      "invalid body",
+			ExpectedData:   nil,
+			ExpectedErr:    apierror.ErrInvalidPayload,
+			PrepareMockApp: func(mock *twofactor.MockApp) {},
+		},
+		"should return error: not enrolled": {
+			InputBody:    `{"code":"123456"}`,
+			ExpectedData: nil,
+			ExpectedErr:  errorMap[pkgerror.ErrTwoFactorNotEnrolled],
+			PrepareMockApp: func(mock *twofactor.MockApp) {
+				mock.EXPECT().
+					Confirm(gomock.Any(), "account_id", "123456").
+					Return(nil, pkgerror.ErrTwoFactorNotEnrolled)
+			},
+		},
+		"should return error: invalid code": {
+			InputBody:    `{"code":"000000"}`,
+			ExpectedData: nil,
+			ExpectedErr:  errorMap[pkgerror.ErrInvalidTwoFactorCode],
+			PrepareMockApp: func(mock *twofactor.MockApp) {
+				mock.EXPECT().
+					Confirm(gomock.Any(), "account_id", "000000").
+					Return(nil, pkgerror.ErrInvalidTwoFactorCode)
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			ctx = model.SetSessionOnContext(ctx, &sessionExample)
+
+			mockApp := twofactor.NewMockApp(ctrl)
+
+			cs.PrepareMockApp(mockApp)
+
+			h := handler{
+				logger:       logger.New(""),
+				twoFactorApp: mockApp,
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, endpoint, strings.NewReader(cs.InputBody)).WithContext(ctx)
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath(endpoint)
+
+			err := h.confirm(c)
+
+			assert.Equal(t, cs.ExpectedErr, err)
+
+			expectedResponseJSON, err := json.Marshal(apimodel.Response{Data: cs.ExpectedData})
+			assert.NoError(t, err)
+
+			var expectedResponse apimodel.Response
+			err = json.Unmarshal(expectedResponseJSON, &expectedResponse)
+			assert.NoError(t, err)
+
+			var currentResponse apimodel.Response
+			json.NewDecoder(rec.Body).Decode(&currentResponse)
+
+			assert.Equal(t, expectedResponse, currentResponse)
+		})
+	}
+}
@@ -3,8 +3,13 @@ package v1
 import (
 	apimodel "github.com/carlosrodriguesf/bank-api/pkg/api/model"
 	"github.com/carlosrodriguesf/bank-api/pkg/api/v1/account"
+	"github.com/carlosrodriguesf/bank-api/pkg/api/v1/apikey"
 	"github.com/carlosrodriguesf/bank-api/pkg/api/v1/auth"
+	"github.com/carlosrodriguesf/bank-api/pkg/api/v1/recurrence"
+	"github.com/carlosrodriguesf/bank-api/pkg/api/v1/schedule"
+	"github.com/carlosrodriguesf/bank-api/pkg/api/v1/statement"
 	"github.com/carlosrodriguesf/bank-api/pkg/api/v1/transfer"
+	"github.com/carlosrodriguesf/bank-api/pkg/api/v1/twofactor"
 	"github.com/labstack/echo/v4"
 )
 
@@ -14,8 +19,13 @@ func Register(g *echo.Group, opts apimodel.Options) {
 	g = g.Group("/v1")
 
 	account.Register(g, opts)
+	apikey.Register(g, opts)
 	auth.Register(g, opts)
+	recurrence.Register(g, opts)
+	schedule.Register(g, opts)
+	statement.Register(g, opts)
 	transfer.Register(g, opts)
+	twofactor.Register(g, opts)
 
 	log.Info("registered")
 }
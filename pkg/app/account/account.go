@@ -4,50 +4,95 @@ package account
 
 import (
 	"context"
+	"errors"
 	pkgerror "github.com/carlosrodriguesf/bank-api/pkg/error"
 	"github.com/carlosrodriguesf/bank-api/pkg/model"
 	"github.com/carlosrodriguesf/bank-api/pkg/repository/account"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/ledger"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/limit"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/transfer"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/generate"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/secret"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/transaction"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/validator"
+	"strings"
 )
 
+const defaultPageSize = 50
+
 type (
 	Options struct {
-		Logger      logger.Logger
-		Secret      secret.Secret
-		Validator   validator.Validator
-		RepoAccount account.Repository
+		Logger       logger.Logger
+		Secret       secret.Secret
+		Validator    validator.Validator
+		Generate     generate.Generate
+		TxManager    transaction.Manager
+		RepoAccount  account.Repository
+		RepoLedger   ledger.Repository
+		RepoLimit    limit.Repository
+		RepoTransfer transfer.Repository
 	}
 	App interface {
 		Create(ctx context.Context, account model.Account) (*model.Account, error)
-		List(ctx context.Context) ([]model.Account, error)
+		List(ctx context.Context, filter model.AccountFilter) (*model.AccountPage, error)
+		Lookup(ctx context.Context, document string) (*model.AccountDirectoryEntry, error)
+		Get(ctx context.Context, accountID string) (*model.Account, error)
 		GetBalance(ctx context.Context, accountID string) (*model.AccountBalance, error)
+		Freeze(ctx context.Context, accountID string) (*model.Account, error)
+		Unfreeze(ctx context.Context, accountID string) (*model.Account, error)
+		Close(ctx context.Context, accountID string, sweepAccountID string) (*model.Account, error)
+		GetLimits(ctx context.Context, accountID string) (*model.AccountLimitsStatus, error)
+		UpdateLimits(ctx context.Context, limits model.AccountLimits) (*model.AccountLimitsStatus, error)
+		ListLedgerDivergences(ctx context.Context) ([]model.LedgerDivergence, error)
 	}
 	appImpl struct {
-		logger      logger.Logger
-		secret      secret.Secret
-		validator   validator.Validator
-		repoAccount account.Repository
+		logger       logger.Logger
+		secret       secret.Secret
+		validator    validator.Validator
+		generate     generate.Generate
+		txManager    transaction.Manager
+		repoAccount  account.Repository
+		repoLedger   ledger.Repository
+		repoLimit    limit.Repository
+		repoTransfer transfer.Repository
 	}
 )
 
 func NewApp(opts Options) App {
 	return &appImpl{
-		logger:      opts.Logger.WithLocation().WithPreffix("service.account"),
-		secret:      opts.Secret,
-		validator:   opts.Validator,
-		repoAccount: opts.RepoAccount,
+		logger:       opts.Logger.WithLocation().WithPreffix("service.account"),
+		secret:       opts.Secret,
+		validator:    opts.Validator,
+		generate:     opts.Generate,
+		txManager:    opts.TxManager,
+		repoAccount:  opts.RepoAccount,
+		repoLedger:   opts.RepoLedger,
+		repoLimit:    opts.RepoLimit,
+		repoTransfer: opts.RepoTransfer,
 	}
 }
 
+// Create opens an account holding money in creationData.Currency, which defaults to model.DefaultCurrency.
 func (s *appImpl) Create(ctx context.Context, creationData model.Account) (*model.Account, error) {
 	if err := s.validator.Validate(creationData); err != nil {
 		return nil, err
 	}
 
-	creationData.SecretSalt = s.secret.GenSalt()
-	creationData.Secret = s.secret.Encode(creationData.Secret, creationData.SecretSalt)
+	creationData.Currency = strings.ToUpper(creationData.Currency)
+	if creationData.Currency == "" {
+		creationData.Currency = model.DefaultCurrency
+	}
+	if _, ok := model.GetCurrencyMinorUnits(creationData.Currency); !ok {
+		return nil, pkgerror.ErrCurrencyNotSupported
+	}
+
+	encodedSecret, err := s.secret.Encode(creationData.Secret)
+	if err != nil {
+		s.logger.Error(err)
+		return nil, pkgerror.ErrCantCreateAccount
+	}
+	creationData.Secret = encodedSecret
 	creationData.Document = model.DocumentRegex.ReplaceAllString(creationData.Document, "")
 
 	documentExists, err := s.repoAccount.HasDocument(ctx, creationData.Document)
@@ -59,7 +104,7 @@ func (s *appImpl) Create(ctx context.Context, creationData model.Account) (*mode
 		return nil, pkgerror.ErrDocumentAlreadyExists
 	}
 
-	generatedData, err := s.repoAccount.Create(ctx, creationData)
+	generatedData, err := s.createWithOpeningBalance(ctx, creationData)
 	if err != nil {
 		s.logger.Error(err)
 		return nil, pkgerror.ErrCantCreateAccount
@@ -70,19 +115,79 @@ func (s *appImpl) Create(ctx context.Context, creationData model.Account) (*mode
 		Name:      creationData.Name,
 		Document:  creationData.Document,
 		Balance:   creationData.Balance,
+		Currency:  creationData.Currency,
+		Status:    model.AccountStatusActive,
 		CreatedAt: generatedData.CreatedAt,
 	}, nil
 }
 
-func (s *appImpl) List(ctx context.Context) ([]model.Account, error) {
-	acc, err := s.repoAccount.List(ctx)
+// List returns a page of the accounts selected by filter, with the cursor of the next page when there is one.
+func (s *appImpl) List(ctx context.Context, filter model.AccountFilter) (*model.AccountPage, error) {
+	if err := s.validator.Validate(filter); err != nil {
+		return nil, err
+	}
+
+	limit := filter.Limit
+	if limit == 0 {
+		limit = defaultPageSize
+	}
+	filter.Limit = limit + 1
+	filter.Document = model.DocumentRegex.ReplaceAllString(filter.Document, "")
+
+	accounts, err := s.repoAccount.List(ctx, filter)
 	if err != nil {
 		s.logger.Error(err)
 		return nil, pkgerror.ErrCantListAccounts
 	}
+
+	page := &model.AccountPage{Accounts: accounts}
+	if len(accounts) > limit {
+		last := accounts[limit-1]
+		cursor := model.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+		if filter.SortBy == model.AccountSortByName {
+			cursor.Name = last.Name
+		}
+		page.Accounts = accounts[:limit]
+		page.NextCursor = cursor.Encode()
+	}
+	return page, nil
+}
+
+// Lookup finds the account of document, exposing only its id and a masked name.
+func (s *appImpl) Lookup(ctx context.Context, document string) (*model.AccountDirectoryEntry, error) {
+	document = model.DocumentRegex.ReplaceAllString(document, "")
+	if document == "" {
+		return nil, pkgerror.ErrAccountNotFound
+	}
+
+	acc, err := s.repoAccount.GetByIDOrDocument(ctx, document)
+	if err != nil {
+		s.logger.Error(err)
+		return nil, pkgerror.ErrCantLookupAccount
+	}
+	if acc == nil {
+		return nil, pkgerror.ErrAccountNotFound
+	}
+	return &model.AccountDirectoryEntry{
+		ID:   acc.ID,
+		Name: maskName(acc.Name),
+	}, nil
+}
+
+// Get returns the current profile and balance of the account.
+func (s *appImpl) Get(ctx context.Context, accountID string) (*model.Account, error) {
+	acc, err := s.repoAccount.GetByIDOrDocument(ctx, accountID)
+	if err != nil {
+		s.logger.Error(err)
+		return nil, pkgerror.ErrCantGetAccount
+	}
+	if acc == nil {
+		return nil, pkgerror.ErrAccountNotFound
+	}
 	return acc, nil
 }
 
+// GetBalance returns the ledger balance of the account along with its available balance.
 func (s *appImpl) GetBalance(ctx context.Context, accountID string) (*model.AccountBalance, error) {
 	acc, err := s.repoAccount.GetByIDOrDocument(ctx, accountID)
 	if err != nil {
@@ -93,6 +198,275 @@ func (s *appImpl) GetBalance(ctx context.Context, accountID string) (*model.Acco
 		return nil, pkgerror.ErrAccountNotFound
 	}
 	return &model.AccountBalance{
-		Balance: acc.Balance,
+		Balance:          acc.Balance,
+		AvailableBalance: acc.AvailableBalance(),
+		Currency:         acc.Currency,
 	}, nil
 }
+
+// Freeze stops an active account from sending and receiving money until it is unfrozen.
+func (s *appImpl) Freeze(ctx context.Context, accountID string) (*model.Account, error) {
+	return s.changeStatus(ctx, accountID, model.AccountStatusActive, model.AccountStatusFrozen)
+}
+
+func (s *appImpl) Unfreeze(ctx context.Context, accountID string) (*model.Account, error) {
+	return s.changeStatus(ctx, accountID, model.AccountStatusFrozen, model.AccountStatusActive)
+}
+
+// Close closes the account for good. An account with money left can only be closed moving all of it to the active
+// account sweepAccountID, which must hold the same currency. Money reserved for holds and for transfers waiting for a
+// review must be released first.
+func (s *appImpl) Close(ctx context.Context, accountID string, sweepAccountID string) (*model.Account, error) {
+	if sweepAccountID == accountID {
+		return nil, pkgerror.ErrInvalidSweepTarget
+	}
+
+	var acc *model.Account
+	err := s.txManager.Execute(ctx, func(tx transaction.Transaction) error {
+		repoAccount := s.repoAccount.WithTransaction(tx)
+
+		accounts, err := lockAccounts(ctx, repoAccount, accountID, sweepAccountID)
+		if err != nil {
+			return err
+		}
+		acc = accounts[accountID]
+		if acc == nil {
+			return pkgerror.ErrAccountNotFound
+		}
+		if acc.Status == model.AccountStatusClosed {
+			return pkgerror.ErrInvalidStatusChange
+		}
+		if acc.Reserved > 0 {
+			return pkgerror.ErrAccountHasReserved
+		}
+
+		if acc.Balance > 0 {
+			if sweepAccountID == "" {
+				return pkgerror.ErrAccountHasBalance
+			}
+			sweepAccount := accounts[sweepAccountID]
+			if sweepAccount == nil || sweepAccount.Status != model.AccountStatusActive ||
+				sweepAccount.Currency != acc.Currency {
+				return pkgerror.ErrInvalidSweepTarget
+			}
+			if err = s.sweep(ctx, tx, *acc, *sweepAccount); err != nil {
+				return err
+			}
+			acc.Balance = 0
+		}
+
+		acc.Status = model.AccountStatusClosed
+		return repoAccount.UpdateStatus(ctx, acc.ID, acc.Status)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pkgerror.ErrAccountNotFound),
+			errors.Is(err, pkgerror.ErrInvalidStatusChange),
+			errors.Is(err, pkgerror.ErrAccountHasBalance),
+			errors.Is(err, pkgerror.ErrAccountHasReserved),
+			errors.Is(err, pkgerror.ErrInvalidSweepTarget):
+			return nil, err
+		}
+		s.logger.Error(err)
+		return nil, pkgerror.ErrCantChangeStatus
+	}
+	return acc, nil
+}
+
+// GetLimits returns the transfer limits of the account and what it already sent in the current day and month.
+func (s *appImpl) GetLimits(ctx context.Context, accountID string) (*model.AccountLimitsStatus, error) {
+	acc, err := s.repoAccount.GetByIDOrDocument(ctx, accountID)
+	if err != nil {
+		s.logger.Error(err)
+		return nil, pkgerror.ErrCantGetLimits
+	}
+	if acc == nil {
+		return nil, pkgerror.ErrAccountNotFound
+	}
+
+	limits, err := s.repoLimit.Get(ctx, acc.ID)
+	if err != nil {
+		s.logger.Error(err)
+		return nil, pkgerror.ErrCantGetLimits
+	}
+	if limits == nil {
+		defaults := model.DefaultAccountLimits(acc.ID)
+		limits = &defaults
+	}
+	return s.limitsStatus(ctx, *limits)
+}
+
+// UpdateLimits replaces the tier and the limits of the account. Limits left nil follow the tier.
+func (s *appImpl) UpdateLimits(ctx context.Context, limits model.AccountLimits) (*model.AccountLimitsStatus, error) {
+	if err := s.validator.Validate(limits); err != nil {
+		return nil, err
+	}
+
+	acc, err := s.repoAccount.GetByIDOrDocument(ctx, limits.AccountID)
+	if err != nil {
+		s.logger.Error(err)
+		return nil, pkgerror.ErrCantUpdateLimits
+	}
+	if acc == nil {
+		return nil, pkgerror.ErrAccountNotFound
+	}
+	limits.AccountID = acc.ID
+
+	saved, err := s.repoLimit.Save(ctx, limits)
+	if err != nil {
+		s.logger.Error(err)
+		return nil, pkgerror.ErrCantUpdateLimits
+	}
+	return s.limitsStatus(ctx, *saved)
+}
+
+// ListLedgerDivergences returns the accounts whose stored balance is not explained by their ledger entries. An empty
+// list means the balances and the ledger agree.
+func (s *appImpl) ListLedgerDivergences(ctx context.Context) ([]model.LedgerDivergence, error) {
+	divergences, err := s.repoLedger.ListDivergences(ctx)
+	if err != nil {
+		s.logger.Error(err)
+		return nil, pkgerror.ErrCantListDivergences
+	}
+	return divergences, nil
+}
+
+func (s *appImpl) limitsStatus(ctx context.Context, limits model.AccountLimits) (*model.AccountLimitsStatus, error) {
+	dayStart, monthStart := model.LimitPeriods(s.generate.CurrentTime())
+	usage, err := s.repoTransfer.GetUsage(ctx, limits.AccountID, dayStart, monthStart)
+	if err != nil {
+		s.logger.Error(err)
+		return nil, pkgerror.ErrCantGetLimits
+	}
+	return &model.AccountLimitsStatus{
+		Settings: limits,
+		Limits:   limits.Effective(),
+		Usage:    *usage,
+	}, nil
+}
+
+func (s *appImpl) changeStatus(ctx context.Context, accountID string, from string, to string) (*model.Account, error) {
+	var acc *model.Account
+	err := s.txManager.Execute(ctx, func(tx transaction.Transaction) (err error) {
+		repoAccount := s.repoAccount.WithTransaction(tx)
+
+		acc, err = repoAccount.GetByIDForUpdate(ctx, accountID)
+		if err != nil {
+			return err
+		}
+		if acc == nil {
+			return pkgerror.ErrAccountNotFound
+		}
+		if acc.Status != from {
+			return pkgerror.ErrInvalidStatusChange
+		}
+
+		acc.Status = to
+		return repoAccount.UpdateStatus(ctx, acc.ID, acc.Status)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pkgerror.ErrAccountNotFound),
+			errors.Is(err, pkgerror.ErrInvalidStatusChange):
+			return nil, err
+		}
+		s.logger.Error(err)
+		return nil, pkgerror.ErrCantChangeStatus
+	}
+	return acc, nil
+}
+
+// sweep moves the whole balance of acc to target as a regular transfer. It must run inside tx.
+func (s *appImpl) sweep(ctx context.Context, tx transaction.Transaction, acc model.Account, target model.Account) error {
+	repoAccount := s.repoAccount.WithTransaction(tx)
+
+	genData, err := s.repoTransfer.WithTransaction(tx).Create(ctx, model.Transfer{
+		OriginAccountID: acc.ID,
+		TargetAccountID: target.ID,
+		Amount:          acc.Balance,
+		SourceCurrency:  acc.Currency,
+		TargetAmount:    acc.Balance,
+		TargetCurrency:  target.Currency,
+		ExchangeRate:    "1",
+	})
+	if err != nil {
+		return err
+	}
+
+	debited, err := repoAccount.Debit(ctx, acc.ID, acc.Balance)
+	if err != nil {
+		return err
+	}
+	if !debited {
+		return pkgerror.ErrInsufficientFunds
+	}
+	if err = repoAccount.Credit(ctx, target.ID, acc.Balance); err != nil {
+		return err
+	}
+
+	return s.repoLedger.WithTransaction(tx).Create(ctx, []model.LedgerEntry{
+		{
+			AccountID:  acc.ID,
+			TransferID: &genData.ID,
+			Type:       model.LedgerEntryTypeDebit,
+			Amount:     acc.Balance,
+		},
+		{
+			AccountID:  target.ID,
+			TransferID: &genData.ID,
+			Type:       model.LedgerEntryTypeCredit,
+			Amount:     acc.Balance,
+		},
+	})
+}
+
+// createWithOpeningBalance inserts the account and the ledger credit that explains its initial balance atomically. The
+// credit is balanced by a debit on the opening-balances internal account, so the ledger always nets to zero.
+func (s *appImpl) createWithOpeningBalance(ctx context.Context, creationData model.Account) (*model.GeneratedData, error) {
+	tx, err := s.txManager.Create(ctx)
+	if err != nil {
+		s.logger.Error(err)
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			if err := s.txManager.Rollback(tx); err != nil {
+				s.logger.Error(err)
+			}
+		}
+	}()
+
+	generatedData, err := s.repoAccount.WithTransaction(tx).Create(ctx, creationData)
+	if err != nil {
+		s.logger.Error(err)
+		return nil, err
+	}
+
+	if creationData.Balance > 0 {
+		err = s.repoLedger.WithTransaction(tx).Create(ctx, []model.LedgerEntry{
+			{
+				AccountID: generatedData.ID,
+				Type:      model.LedgerEntryTypeCredit,
+				Amount:    creationData.Balance,
+			},
+			{
+				LedgerAccount: model.LedgerAccountOpeningBalances,
+				Currency:      creationData.Currency,
+				Type:          model.LedgerEntryTypeDebit,
+				Amount:        creationData.Balance,
+			},
+		})
+		if err != nil {
+			s.logger.Error(err)
+			return nil, err
+		}
+	}
+
+	err = s.txManager.Commit(tx)
+	if err != nil {
+		s.logger.Error(err)
+		return nil, err
+	}
+
+	return generatedData, nil
+}
@@ -0,0 +1,40 @@
+package account
+
+import (
+	"context"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/account"
+	"sort"
+	"strings"
+)
+
+// maskName keeps the first letter of each word of name, so "John Doe" becomes "J*** D**".
+func maskName(name string) string {
+	words := strings.Fields(name)
+	for i, word := range words {
+		runes := []rune(word)
+		words[i] = string(runes[0]) + strings.Repeat("*", len(runes)-1)
+	}
+	return strings.Join(words, " ")
+}
+
+// lockAccounts reads the non-empty ids with a row lock, in ascending order like transfers do to avoid deadlocks.
+func lockAccounts(ctx context.Context, repoAccount account.Repository, ids ...string) (map[string]*model.Account, error) {
+	sorted := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id != "" {
+			sorted = append(sorted, id)
+		}
+	}
+	sort.Strings(sorted)
+
+	accounts := make(map[string]*model.Account, len(sorted))
+	for _, id := range sorted {
+		acc, err := repoAccount.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		accounts[id] = acc
+	}
+	return accounts, nil
+}
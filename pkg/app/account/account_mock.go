@@ -35,6 +35,21 @@ func (m *MockApp) EXPECT() *MockAppMockRecorder {
 	return m.recorder
 }
 
+// Close mocks base method.
+func (m *MockApp) Close(ctx context.Context, accountID, sweepAccountID string) (*model.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close", ctx, accountID, sweepAccountID)
+	ret0, _ := ret[0].(*model.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockAppMockRecorder) Close(ctx, accountID, sweepAccountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockApp)(nil).Close), ctx, accountID, sweepAccountID)
+}
+
 // Create mocks base method.
 func (m *MockApp) Create(ctx context.Context, account model.Account) (*model.Account, error) {
 	m.ctrl.T.Helper()
@@ -50,6 +65,36 @@ func (mr *MockAppMockRecorder) Create(ctx, account interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockApp)(nil).Create), ctx, account)
 }
 
+// Freeze mocks base method.
+func (m *MockApp) Freeze(ctx context.Context, accountID string) (*model.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Freeze", ctx, accountID)
+	ret0, _ := ret[0].(*model.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Freeze indicates an expected call of Freeze.
+func (mr *MockAppMockRecorder) Freeze(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Freeze", reflect.TypeOf((*MockApp)(nil).Freeze), ctx, accountID)
+}
+
+// Get mocks base method.
+func (m *MockApp) Get(ctx context.Context, accountID string) (*model.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, accountID)
+	ret0, _ := ret[0].(*model.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockAppMockRecorder) Get(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockApp)(nil).Get), ctx, accountID)
+}
+
 // GetBalance mocks base method.
 func (m *MockApp) GetBalance(ctx context.Context, accountID string) (*model.AccountBalance, error) {
 	m.ctrl.T.Helper()
@@ -65,17 +110,92 @@ func (mr *MockAppMockRecorder) GetBalance(ctx, accountID interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalance", reflect.TypeOf((*MockApp)(nil).GetBalance), ctx, accountID)
 }
 
+// GetLimits mocks base method.
+func (m *MockApp) GetLimits(ctx context.Context, accountID string) (*model.AccountLimitsStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLimits", ctx, accountID)
+	ret0, _ := ret[0].(*model.AccountLimitsStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLimits indicates an expected call of GetLimits.
+func (mr *MockAppMockRecorder) GetLimits(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLimits", reflect.TypeOf((*MockApp)(nil).GetLimits), ctx, accountID)
+}
+
 // List mocks base method.
-func (m *MockApp) List(ctx context.Context) ([]model.Account, error) {
+func (m *MockApp) List(ctx context.Context, filter model.AccountFilter) (*model.AccountPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", ctx)
-	ret0, _ := ret[0].([]model.Account)
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].(*model.AccountPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // List indicates an expected call of List.
-func (mr *MockAppMockRecorder) List(ctx interface{}) *gomock.Call {
+func (mr *MockAppMockRecorder) List(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockApp)(nil).List), ctx, filter)
+}
+
+// ListLedgerDivergences mocks base method.
+func (m *MockApp) ListLedgerDivergences(ctx context.Context) ([]model.LedgerDivergence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLedgerDivergences", ctx)
+	ret0, _ := ret[0].([]model.LedgerDivergence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLedgerDivergences indicates an expected call of ListLedgerDivergences.
+func (mr *MockAppMockRecorder) ListLedgerDivergences(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLedgerDivergences", reflect.TypeOf((*MockApp)(nil).ListLedgerDivergences), ctx)
+}
+
+// Lookup mocks base method.
+func (m *MockApp) Lookup(ctx context.Context, document string) (*model.AccountDirectoryEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lookup", ctx, document)
+	ret0, _ := ret[0].(*model.AccountDirectoryEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Lookup indicates an expected call of Lookup.
+func (mr *MockAppMockRecorder) Lookup(ctx, document interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lookup", reflect.TypeOf((*MockApp)(nil).Lookup), ctx, document)
+}
+
+// Unfreeze mocks base method.
+func (m *MockApp) Unfreeze(ctx context.Context, accountID string) (*model.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unfreeze", ctx, accountID)
+	ret0, _ := ret[0].(*model.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Unfreeze indicates an expected call of Unfreeze.
+func (mr *MockAppMockRecorder) Unfreeze(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unfreeze", reflect.TypeOf((*MockApp)(nil).Unfreeze), ctx, accountID)
+}
+
+// UpdateLimits mocks base method.
+func (m *MockApp) UpdateLimits(ctx context.Context, limits model.AccountLimits) (*model.AccountLimitsStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLimits", ctx, limits)
+	ret0, _ := ret[0].(*model.AccountLimitsStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLimits indicates an expected call of UpdateLimits.
+func (mr *MockAppMockRecorder) UpdateLimits(ctx, limits interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockApp)(nil).List), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLimits", reflect.TypeOf((*MockApp)(nil).UpdateLimits), ctx, limits)
 }
@@ -6,8 +6,13 @@ import (
 	pkgerror "github.com/carlosrodriguesf/bank-api/pkg/error"
 	"github.com/carlosrodriguesf/bank-api/pkg/model"
 	"github.com/carlosrodriguesf/bank-api/pkg/repository/account"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/ledger"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/limit"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/transfer"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/generate"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/secret"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/transaction"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/validator"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -22,11 +27,15 @@ func TestCreate(t *testing.T) {
 			Name:     "John Doe",
 			Document: "123.123.123-12",
 			Secret:   "secret",
+			Balance:  1000,
 		}
 		accountExample = model.Account{
 			ID:        "account_id",
 			Name:      "John Doe",
 			Document:  "12312312312",
+			Balance:   1000,
+			Currency:  model.DefaultCurrency,
+			Status:    model.AccountStatusActive,
 			CreatedAt: currentTime,
 		}
 		validationErrorExample = &validator.ValidationError{
@@ -37,6 +46,26 @@ func TestCreate(t *testing.T) {
 				Tag:   "required",
 			}},
 		}
+		openingEntries = []model.LedgerEntry{
+			{
+				AccountID: accountExample.ID,
+				Type:      model.LedgerEntryTypeCredit,
+				Amount:    accountExample.Balance,
+			},
+			{
+				LedgerAccount: model.LedgerAccountOpeningBalances,
+				Currency:      accountExample.Currency,
+				Type:          model.LedgerEntryTypeDebit,
+				Amount:        accountExample.Balance,
+			},
+		}
+		creationExample = model.Account{
+			Name:     accountExample.Name,
+			Document: accountExample.Document,
+			Balance:  accountExample.Balance,
+			Currency: accountExample.Currency,
+			Secret:   accountExample.Secret,
+		}
 	)
 
 	cases := map[string]struct {
@@ -45,7 +74,9 @@ func TestCreate(t *testing.T) {
 		ExpectedError         error
 		PrepareMockValidator  func(mock *validator.MockValidator)
 		PrepareMockSecret     func(mock *secret.MockSecret)
-		PrepareMockRepository func(mock *account.MockRepository)
+		PrepareMockRepository func(mock *account.MockRepository, tx transaction.Transaction)
+		PrepareMockTxManager  func(mock *transaction.MockManager, tx transaction.Transaction)
+		PrepareMockRepoLedger func(mock *ledger.MockRepository, tx transaction.Transaction)
 	}{
 		"should return success": {
 			InputData:     creationDataExample,
@@ -55,27 +86,52 @@ func TestCreate(t *testing.T) {
 				mock.EXPECT().Validate(creationDataExample).Return(nil)
 			},
 			PrepareMockSecret: func(mock *secret.MockSecret) {
-				mock.EXPECT().GenSalt().Return(accountExample.SecretSalt)
 				mock.EXPECT().
-					Encode(creationDataExample.Secret, accountExample.SecretSalt).
-					Return(accountExample.Secret)
+					Encode(creationDataExample.Secret).
+					Return(accountExample.Secret, nil)
 			},
-			PrepareMockRepository: func(mock *account.MockRepository) {
+			PrepareMockRepository: func(mock *account.MockRepository, tx transaction.Transaction) {
 				mock.EXPECT().
 					HasDocument(gomock.Any(), model.DocumentRegex.ReplaceAllString(creationDataExample.Document, "")).
 					Return(false, nil)
+				mock.EXPECT().WithTransaction(tx).Return(mock)
 				mock.EXPECT().
-					Create(gomock.Any(), model.Account{
-						Name:       accountExample.Name,
-						Document:   accountExample.Document,
-						Secret:     accountExample.Secret,
-						SecretSalt: accountExample.SecretSalt,
-					}).
+					Create(gomock.Any(), creationExample).
 					Return(&model.GeneratedData{
 						ID:        accountExample.ID,
 						CreatedAt: accountExample.CreatedAt,
 					}, nil)
 			},
+			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {
+				mock.EXPECT().Create(gomock.Any()).Return(tx, nil)
+				mock.EXPECT().Commit(tx)
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().Create(gomock.Any(), openingEntries).Return(nil)
+			},
+		},
+		"should return error on unsupported currency": {
+			InputData: model.Account{
+				Name:     creationDataExample.Name,
+				Document: creationDataExample.Document,
+				Secret:   creationDataExample.Secret,
+				Balance:  creationDataExample.Balance,
+				Currency: "xyz",
+			},
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCurrencyNotSupported,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(gomock.Any()).Return(nil)
+			},
+			PrepareMockSecret: func(mock *secret.MockSecret) {
+			},
+			PrepareMockRepository: func(mock *account.MockRepository, tx transaction.Transaction) {
+			},
+			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+			},
 		},
 		"should return error on validate": {
 			InputData:     creationDataExample,
@@ -87,9 +143,32 @@ func TestCreate(t *testing.T) {
 			PrepareMockSecret: func(mock *secret.MockSecret) {
 
 			},
-			PrepareMockRepository: func(mock *account.MockRepository) {
+			PrepareMockRepository: func(mock *account.MockRepository, tx transaction.Transaction) {
 
 			},
+			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+			},
+		},
+		"should return error on encode secret": {
+			InputData:     creationDataExample,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantCreateAccount,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(creationDataExample).Return(nil)
+			},
+			PrepareMockSecret: func(mock *secret.MockSecret) {
+				mock.EXPECT().
+					Encode(creationDataExample.Secret).
+					Return("", errors.New("fail"))
+			},
+			PrepareMockRepository: func(mock *account.MockRepository, tx transaction.Transaction) {
+			},
+			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+			},
 		},
 		"should return error on save account": {
 			InputData:     creationDataExample,
@@ -99,24 +178,25 @@ func TestCreate(t *testing.T) {
 				mock.EXPECT().Validate(creationDataExample).Return(nil)
 			},
 			PrepareMockSecret: func(mock *secret.MockSecret) {
-				mock.EXPECT().GenSalt().Return(accountExample.SecretSalt)
 				mock.EXPECT().
-					Encode(creationDataExample.Secret, accountExample.SecretSalt).
-					Return(accountExample.Secret)
+					Encode(creationDataExample.Secret).
+					Return(accountExample.Secret, nil)
 			},
-			PrepareMockRepository: func(mock *account.MockRepository) {
+			PrepareMockRepository: func(mock *account.MockRepository, tx transaction.Transaction) {
 				mock.EXPECT().
 					HasDocument(gomock.Any(), model.DocumentRegex.ReplaceAllString(creationDataExample.Document, "")).
 					Return(false, nil)
+				mock.EXPECT().WithTransaction(tx).Return(mock)
 				mock.EXPECT().
-					Create(gomock.Any(), model.Account{
-						Name:       accountExample.Name,
-						Document:   accountExample.Document,
-						Secret:     accountExample.Secret,
-						SecretSalt: accountExample.SecretSalt,
-					}).
+					Create(gomock.Any(), creationExample).
 					Return(nil, errors.New("fail"))
 			},
+			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {
+				mock.EXPECT().Create(gomock.Any()).Return(tx, nil)
+				mock.EXPECT().Rollback(tx)
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+			},
 		},
 		"should return error on check document": {
 			InputData:     creationDataExample,
@@ -126,16 +206,19 @@ func TestCreate(t *testing.T) {
 				mock.EXPECT().Validate(creationDataExample).Return(nil)
 			},
 			PrepareMockSecret: func(mock *secret.MockSecret) {
-				mock.EXPECT().GenSalt().Return(accountExample.SecretSalt)
 				mock.EXPECT().
-					Encode(creationDataExample.Secret, accountExample.SecretSalt).
-					Return(accountExample.Secret)
+					Encode(creationDataExample.Secret).
+					Return(accountExample.Secret, nil)
 			},
-			PrepareMockRepository: func(mock *account.MockRepository) {
+			PrepareMockRepository: func(mock *account.MockRepository, tx transaction.Transaction) {
 				mock.EXPECT().
 					HasDocument(gomock.Any(), model.DocumentRegex.ReplaceAllString(creationDataExample.Document, "")).
 					Return(false, errors.New("fail"))
 			},
+			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+			},
 		},
 		"should return error: document exists": {
 			InputData:     creationDataExample,
@@ -145,16 +228,109 @@ func TestCreate(t *testing.T) {
 				mock.EXPECT().Validate(creationDataExample).Return(nil)
 			},
 			PrepareMockSecret: func(mock *secret.MockSecret) {
-				mock.EXPECT().GenSalt().Return(accountExample.SecretSalt)
 				mock.EXPECT().
-					Encode(creationDataExample.Secret, accountExample.SecretSalt).
-					Return(accountExample.Secret)
+					Encode(creationDataExample.Secret).
+					Return(accountExample.Secret, nil)
 			},
-			PrepareMockRepository: func(mock *account.MockRepository) {
+			PrepareMockRepository: func(mock *account.MockRepository, tx transaction.Transaction) {
 				mock.EXPECT().
 					HasDocument(gomock.Any(), model.DocumentRegex.ReplaceAllString(creationDataExample.Document, "")).
 					Return(true, nil)
 			},
+			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+			},
+		},
+		"should return error on create transaction": {
+			InputData:     creationDataExample,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantCreateAccount,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(creationDataExample).Return(nil)
+			},
+			PrepareMockSecret: func(mock *secret.MockSecret) {
+				mock.EXPECT().
+					Encode(creationDataExample.Secret).
+					Return(accountExample.Secret, nil)
+			},
+			PrepareMockRepository: func(mock *account.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().
+					HasDocument(gomock.Any(), model.DocumentRegex.ReplaceAllString(creationDataExample.Document, "")).
+					Return(false, nil)
+			},
+			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {
+				mock.EXPECT().Create(gomock.Any()).Return(nil, errors.New("fail"))
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+			},
+		},
+		"should return error on save opening balance": {
+			InputData:     creationDataExample,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantCreateAccount,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(creationDataExample).Return(nil)
+			},
+			PrepareMockSecret: func(mock *secret.MockSecret) {
+				mock.EXPECT().
+					Encode(creationDataExample.Secret).
+					Return(accountExample.Secret, nil)
+			},
+			PrepareMockRepository: func(mock *account.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().
+					HasDocument(gomock.Any(), model.DocumentRegex.ReplaceAllString(creationDataExample.Document, "")).
+					Return(false, nil)
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().
+					Create(gomock.Any(), creationExample).
+					Return(&model.GeneratedData{
+						ID:        accountExample.ID,
+						CreatedAt: accountExample.CreatedAt,
+					}, nil)
+			},
+			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {
+				mock.EXPECT().Create(gomock.Any()).Return(tx, nil)
+				mock.EXPECT().Rollback(tx)
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().Create(gomock.Any(), openingEntries).Return(errors.New("fail"))
+			},
+		},
+		"should return error on commit": {
+			InputData:     creationDataExample,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantCreateAccount,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(creationDataExample).Return(nil)
+			},
+			PrepareMockSecret: func(mock *secret.MockSecret) {
+				mock.EXPECT().
+					Encode(creationDataExample.Secret).
+					Return(accountExample.Secret, nil)
+			},
+			PrepareMockRepository: func(mock *account.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().
+					HasDocument(gomock.Any(), model.DocumentRegex.ReplaceAllString(creationDataExample.Document, "")).
+					Return(false, nil)
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().
+					Create(gomock.Any(), creationExample).
+					Return(&model.GeneratedData{
+						ID:        accountExample.ID,
+						CreatedAt: accountExample.CreatedAt,
+					}, nil)
+			},
+			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {
+				mock.EXPECT().Create(gomock.Any()).Return(tx, nil)
+				mock.EXPECT().Commit(tx).Return(errors.New("fail"))
+				mock.EXPECT().Rollback(tx)
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().Create(gomock.Any(), openingEntries).Return(nil)
+			},
 		},
 	}
 
@@ -165,17 +341,24 @@ func TestCreate(t *testing.T) {
 				mockSecret     = secret.NewMockSecret(ctrl)
 				mockValidator  = validator.NewMockValidator(ctrl)
 				mockRepository = account.NewMockRepository(ctrl)
+				mockTxManager  = transaction.NewMockManager(ctrl)
+				mockRepoLedger = ledger.NewMockRepository(ctrl)
+				txExample      = transaction.Transaction(nil)
 			)
 
 			cs.PrepareMockSecret(mockSecret)
 			cs.PrepareMockValidator(mockValidator)
-			cs.PrepareMockRepository(mockRepository)
+			cs.PrepareMockRepository(mockRepository, txExample)
+			cs.PrepareMockTxManager(mockTxManager, txExample)
+			cs.PrepareMockRepoLedger(mockRepoLedger, txExample)
 
 			service := NewApp(Options{
 				Logger:      logger.New(""),
 				Secret:      mockSecret,
 				Validator:   mockValidator,
+				TxManager:   mockTxManager,
 				RepoAccount: mockRepository,
+				RepoLedger:  mockRepoLedger,
 			})
 
 			data, err := service.Create(ctx, cs.InputData)
@@ -188,28 +371,97 @@ func TestCreate(t *testing.T) {
 
 func TestList(t *testing.T) {
 	var (
-		accountsExample = []model.Account{{
-			ID:   "account_id",
-			Name: "Account Test",
-		}}
+		currentTime     = time.Now()
+		accountsExample = []model.Account{
+			{
+				ID:        "account_id_1",
+				Name:      "Account Test 1",
+				CreatedAt: currentTime,
+			},
+			{
+				ID:        "account_id_2",
+				Name:      "Account Test 2",
+				CreatedAt: currentTime.Add(-time.Hour),
+			},
+		}
+		filterExample = model.AccountFilter{
+			Document: "123.123.123-12",
+			Limit:    1,
+		}
+		validationErrorExample = &validator.ValidationError{Message: "invalid data"}
 	)
 	cases := map[string]struct {
-		ExpectedData           []model.Account
+		InputData              model.AccountFilter
+		ExpectedData           *model.AccountPage
 		ExpectedError          error
+		PrepareMockValidator   func(mock *validator.MockValidator)
 		PrepareMockRepoAccount func(mock *account.MockRepository)
 	}{
-		"should return success": {
-			ExpectedData:  accountsExample,
+		"should return page with next cursor": {
+			InputData: filterExample,
+			ExpectedData: &model.AccountPage{
+				Accounts:   accountsExample[:1],
+				NextCursor: model.Cursor{CreatedAt: currentTime, ID: "account_id_1"}.Encode(),
+			},
 			ExpectedError: nil,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(filterExample).Return(nil)
+			},
 			PrepareMockRepoAccount: func(mock *account.MockRepository) {
-				mock.EXPECT().List(gomock.Any()).Return(accountsExample, nil)
+				mock.EXPECT().
+					List(gomock.Any(), model.AccountFilter{Document: "12312312312", Limit: 2}).
+					Return(accountsExample, nil)
 			},
 		},
+		"should return page with name cursor": {
+			InputData: model.AccountFilter{SortBy: model.AccountSortByName, Limit: 1},
+			ExpectedData: &model.AccountPage{
+				Accounts:   accountsExample[:1],
+				NextCursor: model.Cursor{CreatedAt: currentTime, ID: "account_id_1", Name: "Account Test 1"}.Encode(),
+			},
+			ExpectedError: nil,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(model.AccountFilter{SortBy: model.AccountSortByName, Limit: 1}).Return(nil)
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().
+					List(gomock.Any(), model.AccountFilter{SortBy: model.AccountSortByName, Limit: 2}).
+					Return(accountsExample, nil)
+			},
+		},
+		"should return last page with default limit": {
+			InputData: model.AccountFilter{},
+			ExpectedData: &model.AccountPage{
+				Accounts: accountsExample,
+			},
+			ExpectedError: nil,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(model.AccountFilter{}).Return(nil)
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().
+					List(gomock.Any(), model.AccountFilter{Limit: defaultPageSize + 1}).
+					Return(accountsExample, nil)
+			},
+		},
+		"should return validation error": {
+			InputData:     filterExample,
+			ExpectedData:  nil,
+			ExpectedError: validationErrorExample,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(filterExample).Return(validationErrorExample)
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {},
+		},
 		"should return error": {
+			InputData:     filterExample,
 			ExpectedData:  nil,
 			ExpectedError: pkgerror.ErrCantListAccounts,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(filterExample).Return(nil)
+			},
 			PrepareMockRepoAccount: func(mock *account.MockRepository) {
-				mock.EXPECT().List(gomock.Any()).Return(nil, errors.New("fail"))
+				mock.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, errors.New("fail"))
 			},
 		},
 	}
@@ -218,16 +470,19 @@ func TestList(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			var (
 				ctrl, ctx       = gomock.WithContext(context.Background(), t)
+				mockValidator   = validator.NewMockValidator(ctrl)
 				mockRepoAccount = account.NewMockRepository(ctrl)
 				app             = NewApp(Options{
 					Logger:      logger.New(""),
+					Validator:   mockValidator,
 					RepoAccount: mockRepoAccount,
 				})
 			)
 
+			cs.PrepareMockValidator(mockValidator)
 			cs.PrepareMockRepoAccount(mockRepoAccount)
 
-			data, err := app.List(ctx)
+			data, err := app.List(ctx, cs.InputData)
 
 			assert.Equal(t, cs.ExpectedData, data)
 			assert.Equal(t, cs.ExpectedError, err)
@@ -235,16 +490,156 @@ func TestList(t *testing.T) {
 	}
 }
 
-func TestGetBalance(t *testing.T) {
+func TestLookup(t *testing.T) {
 	var (
-		accountID      = "accountID"
+		document       = "123.123.123-12"
 		accountExample = model.Account{
-			ID:      "account_id",
+			ID:       "account_id",
+			Name:     "John  Doe",
+			Document: "12312312312",
+		}
+	)
+	cases := map[string]struct {
+		InputData              string
+		ExpectedData           *model.AccountDirectoryEntry
+		ExpectedError          error
+		PrepareMockRepoAccount func(mock *account.MockRepository)
+	}{
+		"should return success": {
+			InputData:     document,
+			ExpectedData:  &model.AccountDirectoryEntry{ID: "account_id", Name: "J*** D**"},
+			ExpectedError: nil,
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), "12312312312").Return(&accountExample, nil)
+			},
+		},
+		"should return error: empty document": {
+			InputData:              "account_id",
+			ExpectedData:           nil,
+			ExpectedError:          pkgerror.ErrAccountNotFound,
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {},
+		},
+		"should return error: account not found": {
+			InputData:     document,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrAccountNotFound,
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), "12312312312").Return(nil, nil)
+			},
+		},
+		"should return error": {
+			InputData:     document,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantLookupAccount,
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), "12312312312").Return(nil, errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx       = gomock.WithContext(context.Background(), t)
+				mockRepoAccount = account.NewMockRepository(ctrl)
+				app             = NewApp(Options{
+					Logger:      logger.New(""),
+					RepoAccount: mockRepoAccount,
+				})
+			)
+
+			cs.PrepareMockRepoAccount(mockRepoAccount)
+
+			data, err := app.Lookup(ctx, cs.InputData)
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	var (
+		accountID      = "account_id"
+		accountExample = model.Account{
+			ID:      accountID,
 			Name:    "Account Test",
 			Balance: 456,
+			Role:    model.RoleCustomer,
+			Status:  model.AccountStatusActive,
+		}
+	)
+	cases := map[string]struct {
+		InputData              string
+		ExpectedData           *model.Account
+		ExpectedError          error
+		PrepareMockRepoAccount func(mock *account.MockRepository)
+	}{
+		"should return success": {
+			InputData:     accountID,
+			ExpectedData:  &accountExample,
+			ExpectedError: nil,
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().
+					GetByIDOrDocument(gomock.Any(), accountID).
+					Return(&accountExample, nil)
+			},
+		},
+		"should return error: account not found": {
+			InputData:     accountID,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrAccountNotFound,
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().
+					GetByIDOrDocument(gomock.Any(), accountID).
+					Return(nil, nil)
+			},
+		},
+		"should return error": {
+			InputData:     accountID,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantGetAccount,
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().
+					GetByIDOrDocument(gomock.Any(), accountID).
+					Return(nil, errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx       = gomock.WithContext(context.Background(), t)
+				mockRepoAccount = account.NewMockRepository(ctrl)
+				app             = NewApp(Options{
+					Logger:      logger.New(""),
+					RepoAccount: mockRepoAccount,
+				})
+			)
+
+			cs.PrepareMockRepoAccount(mockRepoAccount)
+
+			data, err := app.Get(ctx, cs.InputData)
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestGetBalance(t *testing.T) {
+	var (
+		accountID      = "accountID"
+		accountExample = model.Account{
+			ID:       "account_id",
+			Name:     "Account Test",
+			Balance:  456,
+			Reserved: 100,
 		}
 		accountBalanceExample = model.AccountBalance{
-			Balance: accountExample.Balance,
+			Balance:          accountExample.Balance,
+			AvailableBalance: 356,
 		}
 	)
 	cases := map[string]struct {
@@ -305,3 +700,665 @@ func TestGetBalance(t *testing.T) {
 		})
 	}
 }
+
+func TestGetLimits(t *testing.T) {
+	var (
+		currentTime          = time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC)
+		dayStart, monthStart = model.LimitPeriods(currentTime)
+		daily                = int64(2000)
+		accountExample       = model.Account{ID: "account_id", Document: "12312312312"}
+		limitsExample        = model.AccountLimits{AccountID: accountExample.ID, Tier: model.AccountTierPremium, Daily: &daily}
+		usageExample         = model.TransferUsage{Daily: 300, Monthly: 1200, DailyCount: 2}
+	)
+	cases := map[string]struct {
+		InputData               string
+		ExpectedData            *model.AccountLimitsStatus
+		ExpectedError           error
+		PrepareMockRepoAccount  func(mock *account.MockRepository)
+		PrepareMockRepoLimit    func(mock *limit.MockRepository)
+		PrepareMockRepoTransfer func(mock *transfer.MockRepository)
+	}{
+		"should return success": {
+			InputData: accountExample.Document,
+			ExpectedData: &model.AccountLimitsStatus{
+				Settings: limitsExample,
+				Limits:   limitsExample.Effective(),
+				Usage:    usageExample,
+			},
+			ExpectedError: nil,
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), accountExample.Document).Return(&accountExample, nil)
+			},
+			PrepareMockRepoLimit: func(mock *limit.MockRepository) {
+				mock.EXPECT().Get(gomock.Any(), accountExample.ID).Return(&limitsExample, nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {
+				mock.EXPECT().GetUsage(gomock.Any(), accountExample.ID, dayStart, monthStart).Return(&usageExample, nil)
+			},
+		},
+		"should return success: default limits": {
+			InputData: accountExample.Document,
+			ExpectedData: &model.AccountLimitsStatus{
+				Settings: model.DefaultAccountLimits(accountExample.ID),
+				Limits:   model.DefaultAccountLimits(accountExample.ID).Effective(),
+				Usage:    usageExample,
+			},
+			ExpectedError: nil,
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), accountExample.Document).Return(&accountExample, nil)
+			},
+			PrepareMockRepoLimit: func(mock *limit.MockRepository) {
+				mock.EXPECT().Get(gomock.Any(), accountExample.ID).Return(nil, nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {
+				mock.EXPECT().GetUsage(gomock.Any(), accountExample.ID, dayStart, monthStart).Return(&usageExample, nil)
+			},
+		},
+		"should return error: account not found": {
+			InputData:     accountExample.Document,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrAccountNotFound,
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), accountExample.Document).Return(nil, nil)
+			},
+			PrepareMockRepoLimit: func(mock *limit.MockRepository) {
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {
+			},
+		},
+		"should return error on get limits": {
+			InputData:     accountExample.Document,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantGetLimits,
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), accountExample.Document).Return(&accountExample, nil)
+			},
+			PrepareMockRepoLimit: func(mock *limit.MockRepository) {
+				mock.EXPECT().Get(gomock.Any(), accountExample.ID).Return(nil, errors.New("fail"))
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {
+			},
+		},
+		"should return error on get usage": {
+			InputData:     accountExample.Document,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantGetLimits,
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), accountExample.Document).Return(&accountExample, nil)
+			},
+			PrepareMockRepoLimit: func(mock *limit.MockRepository) {
+				mock.EXPECT().Get(gomock.Any(), accountExample.ID).Return(&limitsExample, nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {
+				mock.EXPECT().GetUsage(gomock.Any(), accountExample.ID, dayStart, monthStart).Return(nil, errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx        = gomock.WithContext(context.Background(), t)
+				mockGenerate     = generate.NewMockGenerate(ctrl)
+				mockRepoAccount  = account.NewMockRepository(ctrl)
+				mockRepoLimit    = limit.NewMockRepository(ctrl)
+				mockRepoTransfer = transfer.NewMockRepository(ctrl)
+				app              = NewApp(Options{
+					Logger:       logger.New(""),
+					Generate:     mockGenerate,
+					RepoAccount:  mockRepoAccount,
+					RepoLimit:    mockRepoLimit,
+					RepoTransfer: mockRepoTransfer,
+				})
+			)
+
+			mockGenerate.EXPECT().CurrentTime().Return(currentTime).AnyTimes()
+			cs.PrepareMockRepoAccount(mockRepoAccount)
+			cs.PrepareMockRepoLimit(mockRepoLimit)
+			cs.PrepareMockRepoTransfer(mockRepoTransfer)
+
+			data, err := app.GetLimits(ctx, cs.InputData)
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestUpdateLimits(t *testing.T) {
+	var (
+		currentTime          = time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC)
+		dayStart, monthStart = model.LimitPeriods(currentTime)
+		perTransfer          = int64(100000)
+		accountExample       = model.Account{ID: "account_id", Document: "12312312312"}
+		inputExample         = model.AccountLimits{AccountID: accountExample.Document, Tier: model.AccountTierBusiness, PerTransfer: &perTransfer}
+		savedExample         = model.AccountLimits{
+			AccountID:   accountExample.ID,
+			Tier:        model.AccountTierBusiness,
+			PerTransfer: &perTransfer,
+			UpdatedAt:   currentTime,
+		}
+		usageExample    = model.TransferUsage{Daily: 300, Monthly: 1200, DailyCount: 2}
+		validationError = validator.ValidationError{}
+	)
+	cases := map[string]struct {
+		InputData               model.AccountLimits
+		ExpectedData            *model.AccountLimitsStatus
+		ExpectedError           error
+		PrepareMockValidator    func(mock *validator.MockValidator)
+		PrepareMockRepoAccount  func(mock *account.MockRepository)
+		PrepareMockRepoLimit    func(mock *limit.MockRepository)
+		PrepareMockRepoTransfer func(mock *transfer.MockRepository)
+	}{
+		"should return success": {
+			InputData: inputExample,
+			ExpectedData: &model.AccountLimitsStatus{
+				Settings: savedExample,
+				Limits:   savedExample.Effective(),
+				Usage:    usageExample,
+			},
+			ExpectedError: nil,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(inputExample).Return(nil)
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), accountExample.Document).Return(&accountExample, nil)
+			},
+			PrepareMockRepoLimit: func(mock *limit.MockRepository) {
+				data := inputExample
+				data.AccountID = accountExample.ID
+				mock.EXPECT().Save(gomock.Any(), data).Return(&savedExample, nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {
+				mock.EXPECT().GetUsage(gomock.Any(), accountExample.ID, dayStart, monthStart).Return(&usageExample, nil)
+			},
+		},
+		"should return error: validation": {
+			InputData:     inputExample,
+			ExpectedData:  nil,
+			ExpectedError: &validationError,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(inputExample).Return(&validationError)
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+			},
+			PrepareMockRepoLimit: func(mock *limit.MockRepository) {
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {
+			},
+		},
+		"should return error: account not found": {
+			InputData:     inputExample,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrAccountNotFound,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(inputExample).Return(nil)
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), accountExample.Document).Return(nil, nil)
+			},
+			PrepareMockRepoLimit: func(mock *limit.MockRepository) {
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {
+			},
+		},
+		"should return error on save": {
+			InputData:     inputExample,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantUpdateLimits,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(inputExample).Return(nil)
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), accountExample.Document).Return(&accountExample, nil)
+			},
+			PrepareMockRepoLimit: func(mock *limit.MockRepository) {
+				mock.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil, errors.New("fail"))
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx        = gomock.WithContext(context.Background(), t)
+				mockValidator    = validator.NewMockValidator(ctrl)
+				mockGenerate     = generate.NewMockGenerate(ctrl)
+				mockRepoAccount  = account.NewMockRepository(ctrl)
+				mockRepoLimit    = limit.NewMockRepository(ctrl)
+				mockRepoTransfer = transfer.NewMockRepository(ctrl)
+				app              = NewApp(Options{
+					Logger:       logger.New(""),
+					Validator:    mockValidator,
+					Generate:     mockGenerate,
+					RepoAccount:  mockRepoAccount,
+					RepoLimit:    mockRepoLimit,
+					RepoTransfer: mockRepoTransfer,
+				})
+			)
+
+			mockGenerate.EXPECT().CurrentTime().Return(currentTime).AnyTimes()
+			cs.PrepareMockValidator(mockValidator)
+			cs.PrepareMockRepoAccount(mockRepoAccount)
+			cs.PrepareMockRepoLimit(mockRepoLimit)
+			cs.PrepareMockRepoTransfer(mockRepoTransfer)
+
+			data, err := app.UpdateLimits(ctx, cs.InputData)
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestListLedgerDivergences(t *testing.T) {
+	var divergencesExample = []model.LedgerDivergence{{
+		AccountID:     "account_id",
+		Balance:       1000,
+		LedgerBalance: 500,
+	}}
+	cases := map[string]struct {
+		ExpectedData          []model.LedgerDivergence
+		ExpectedError         error
+		PrepareMockRepoLedger func(mock *ledger.MockRepository)
+	}{
+		"should return success": {
+			ExpectedData:  divergencesExample,
+			ExpectedError: nil,
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository) {
+				mock.EXPECT().ListDivergences(gomock.Any()).Return(divergencesExample, nil)
+			},
+		},
+		"should return error": {
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantListDivergences,
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository) {
+				mock.EXPECT().ListDivergences(gomock.Any()).Return(nil, errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx      = gomock.WithContext(context.Background(), t)
+				mockRepoLedger = ledger.NewMockRepository(ctrl)
+				app            = NewApp(Options{
+					Logger:     logger.New(""),
+					RepoLedger: mockRepoLedger,
+				})
+			)
+
+			cs.PrepareMockRepoLedger(mockRepoLedger)
+
+			data, err := app.ListLedgerDivergences(ctx)
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestChangeStatus(t *testing.T) {
+	var (
+		accountExample = model.Account{
+			ID:      "account_id",
+			Name:    "John Doe",
+			Balance: 1000,
+			Status:  model.AccountStatusActive,
+		}
+		executeWith = func(tx transaction.Transaction) func(context.Context, func(transaction.Transaction) error) error {
+			return func(_ context.Context, fn func(transaction.Transaction) error) error {
+				return fn(tx)
+			}
+		}
+	)
+	cases := map[string]struct {
+		InputUnfreeze          bool
+		ExpectedData           *model.Account
+		ExpectedError          error
+		PrepareMockRepoAccount func(mock *account.MockRepository, tx transaction.Transaction)
+	}{
+		"should freeze account": {
+			InputUnfreeze: false,
+			ExpectedData: &model.Account{
+				ID:      "account_id",
+				Name:    "John Doe",
+				Balance: 1000,
+				Status:  model.AccountStatusFrozen,
+			},
+			ExpectedError: nil,
+			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
+				acc := accountExample
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), "account_id").Return(&acc, nil)
+				mock.EXPECT().UpdateStatus(gomock.Any(), "account_id", model.AccountStatusFrozen).Return(nil)
+			},
+		},
+		"should unfreeze account": {
+			InputUnfreeze: true,
+			ExpectedData:  &accountExample,
+			ExpectedError: nil,
+			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
+				acc := accountExample
+				acc.Status = model.AccountStatusFrozen
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), "account_id").Return(&acc, nil)
+				mock.EXPECT().UpdateStatus(gomock.Any(), "account_id", model.AccountStatusActive).Return(nil)
+			},
+		},
+		"should return error: account not found": {
+			InputUnfreeze: false,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrAccountNotFound,
+			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), "account_id").Return(nil, nil)
+			},
+		},
+		"should return error: closed account": {
+			InputUnfreeze: false,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrInvalidStatusChange,
+			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
+				acc := accountExample
+				acc.Status = model.AccountStatusClosed
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), "account_id").Return(&acc, nil)
+			},
+		},
+		"should return error": {
+			InputUnfreeze: false,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantChangeStatus,
+			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
+				acc := accountExample
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), "account_id").Return(&acc, nil)
+				mock.EXPECT().UpdateStatus(gomock.Any(), "account_id", model.AccountStatusFrozen).Return(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx       = gomock.WithContext(context.Background(), t)
+				txExample       = transaction.Transaction(nil)
+				mockTxManager   = transaction.NewMockManager(ctrl)
+				mockRepoAccount = account.NewMockRepository(ctrl)
+				app             = NewApp(Options{
+					Logger:      logger.New(""),
+					TxManager:   mockTxManager,
+					RepoAccount: mockRepoAccount,
+				})
+			)
+
+			mockTxManager.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(executeWith(txExample))
+			cs.PrepareMockRepoAccount(mockRepoAccount, txExample)
+
+			var (
+				data *model.Account
+				err  error
+			)
+			if cs.InputUnfreeze {
+				data, err = app.Unfreeze(ctx, "account_id")
+			} else {
+				data, err = app.Freeze(ctx, "account_id")
+			}
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestClose(t *testing.T) {
+	var (
+		accountExample = model.Account{
+			ID:       "account_id",
+			Name:     "John Doe",
+			Balance:  1000,
+			Currency: "BRL",
+			Status:   model.AccountStatusFrozen,
+		}
+		sweepAccountExample = model.Account{
+			ID:       "sweep_account_id",
+			Name:     "Jane Doe",
+			Balance:  500,
+			Currency: "BRL",
+			Status:   model.AccountStatusActive,
+		}
+		closedAccountExample = model.Account{
+			ID:       "account_id",
+			Name:     "John Doe",
+			Currency: "BRL",
+			Status:   model.AccountStatusClosed,
+		}
+		genData     = model.GeneratedData{ID: "transfer_id"}
+		executeWith = func(tx transaction.Transaction) func(context.Context, func(transaction.Transaction) error) error {
+			return func(_ context.Context, fn func(transaction.Transaction) error) error {
+				return fn(tx)
+			}
+		}
+	)
+	cases := map[string]struct {
+		InputSweepAccountID     string
+		ExpectedData            *model.Account
+		ExpectedError           error
+		PrepareMockTxManager    func(mock *transaction.MockManager, tx transaction.Transaction)
+		PrepareMockRepoAccount  func(mock *account.MockRepository, tx transaction.Transaction)
+		PrepareMockRepoTransfer func(mock *transfer.MockRepository, tx transaction.Transaction)
+		PrepareMockRepoLedger   func(mock *ledger.MockRepository, tx transaction.Transaction)
+	}{
+		"should close account without balance": {
+			InputSweepAccountID: "",
+			ExpectedData:        &closedAccountExample,
+			ExpectedError:       nil,
+			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {
+				mock.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(executeWith(tx))
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
+				acc := accountExample
+				acc.Balance = 0
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), "account_id").Return(&acc, nil)
+				mock.EXPECT().UpdateStatus(gomock.Any(), "account_id", model.AccountStatusClosed).Return(nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository, tx transaction.Transaction) {},
+			PrepareMockRepoLedger:   func(mock *ledger.MockRepository, tx transaction.Transaction) {},
+		},
+		"should close account sweeping its balance": {
+			InputSweepAccountID: "sweep_account_id",
+			ExpectedData:        &closedAccountExample,
+			ExpectedError:       nil,
+			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {
+				mock.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(executeWith(tx))
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
+				acc := accountExample
+				sweepAcc := sweepAccountExample
+				mock.EXPECT().WithTransaction(tx).Return(mock).Times(2)
+				gomock.InOrder(
+					mock.EXPECT().GetByIDForUpdate(gomock.Any(), "account_id").Return(&acc, nil),
+					mock.EXPECT().GetByIDForUpdate(gomock.Any(), "sweep_account_id").Return(&sweepAcc, nil),
+				)
+				mock.EXPECT().Debit(gomock.Any(), "account_id", int64(1000)).Return(true, nil)
+				mock.EXPECT().Credit(gomock.Any(), "sweep_account_id", int64(1000)).Return(nil)
+				mock.EXPECT().UpdateStatus(gomock.Any(), "account_id", model.AccountStatusClosed).Return(nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().
+					Create(gomock.Any(), model.Transfer{
+						OriginAccountID: "account_id",
+						TargetAccountID: "sweep_account_id",
+						Amount:          1000,
+						SourceCurrency:  "BRL",
+						TargetAmount:    1000,
+						TargetCurrency:  "BRL",
+						ExchangeRate:    "1",
+					}).
+					Return(&genData, nil)
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().
+					Create(gomock.Any(), []model.LedgerEntry{
+						{
+							AccountID:  "account_id",
+							TransferID: &genData.ID,
+							Type:       model.LedgerEntryTypeDebit,
+							Amount:     1000,
+						},
+						{
+							AccountID:  "sweep_account_id",
+							TransferID: &genData.ID,
+							Type:       model.LedgerEntryTypeCredit,
+							Amount:     1000,
+						},
+					}).
+					Return(nil)
+			},
+		},
+		"should return error: account has balance": {
+			InputSweepAccountID: "",
+			ExpectedData:        nil,
+			ExpectedError:       pkgerror.ErrAccountHasBalance,
+			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {
+				mock.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(executeWith(tx))
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
+				acc := accountExample
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), "account_id").Return(&acc, nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository, tx transaction.Transaction) {},
+			PrepareMockRepoLedger:   func(mock *ledger.MockRepository, tx transaction.Transaction) {},
+		},
+		"should return error: account has reserved funds": {
+			InputSweepAccountID: "sweep_account_id",
+			ExpectedData:        nil,
+			ExpectedError:       pkgerror.ErrAccountHasReserved,
+			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {
+				mock.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(executeWith(tx))
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
+				acc := accountExample
+				acc.Reserved = 300
+				sweepAcc := sweepAccountExample
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), "account_id").Return(&acc, nil)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), "sweep_account_id").Return(&sweepAcc, nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository, tx transaction.Transaction) {},
+			PrepareMockRepoLedger:   func(mock *ledger.MockRepository, tx transaction.Transaction) {},
+		},
+		"should return error: sweep account not active": {
+			InputSweepAccountID: "sweep_account_id",
+			ExpectedData:        nil,
+			ExpectedError:       pkgerror.ErrInvalidSweepTarget,
+			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {
+				mock.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(executeWith(tx))
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
+				acc := accountExample
+				sweepAcc := sweepAccountExample
+				sweepAcc.Status = model.AccountStatusFrozen
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), "account_id").Return(&acc, nil)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), "sweep_account_id").Return(&sweepAcc, nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository, tx transaction.Transaction) {},
+			PrepareMockRepoLedger:   func(mock *ledger.MockRepository, tx transaction.Transaction) {},
+		},
+		"should return error: sweep account in another currency": {
+			InputSweepAccountID: "sweep_account_id",
+			ExpectedData:        nil,
+			ExpectedError:       pkgerror.ErrInvalidSweepTarget,
+			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {
+				mock.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(executeWith(tx))
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
+				acc := accountExample
+				sweepAcc := sweepAccountExample
+				sweepAcc.Currency = "USD"
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), "account_id").Return(&acc, nil)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), "sweep_account_id").Return(&sweepAcc, nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository, tx transaction.Transaction) {},
+			PrepareMockRepoLedger:   func(mock *ledger.MockRepository, tx transaction.Transaction) {},
+		},
+		"should return error: sweep to itself": {
+			InputSweepAccountID:     "account_id",
+			ExpectedData:            nil,
+			ExpectedError:           pkgerror.ErrInvalidSweepTarget,
+			PrepareMockTxManager:    func(mock *transaction.MockManager, tx transaction.Transaction) {},
+			PrepareMockRepoAccount:  func(mock *account.MockRepository, tx transaction.Transaction) {},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository, tx transaction.Transaction) {},
+			PrepareMockRepoLedger:   func(mock *ledger.MockRepository, tx transaction.Transaction) {},
+		},
+		"should return error: already closed": {
+			InputSweepAccountID: "",
+			ExpectedData:        nil,
+			ExpectedError:       pkgerror.ErrInvalidStatusChange,
+			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {
+				mock.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(executeWith(tx))
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
+				acc := closedAccountExample
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), "account_id").Return(&acc, nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository, tx transaction.Transaction) {},
+			PrepareMockRepoLedger:   func(mock *ledger.MockRepository, tx transaction.Transaction) {},
+		},
+		"should return error": {
+			InputSweepAccountID: "",
+			ExpectedData:        nil,
+			ExpectedError:       pkgerror.ErrCantChangeStatus,
+			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {
+				mock.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(executeWith(tx))
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), "account_id").Return(nil, errors.New("fail"))
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository, tx transaction.Transaction) {},
+			PrepareMockRepoLedger:   func(mock *ledger.MockRepository, tx transaction.Transaction) {},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx        = gomock.WithContext(context.Background(), t)
+				txExample        = transaction.Transaction(nil)
+				mockTxManager    = transaction.NewMockManager(ctrl)
+				mockRepoAccount  = account.NewMockRepository(ctrl)
+				mockRepoTransfer = transfer.NewMockRepository(ctrl)
+				mockRepoLedger   = ledger.NewMockRepository(ctrl)
+				app              = NewApp(Options{
+					Logger:       logger.New(""),
+					TxManager:    mockTxManager,
+					RepoAccount:  mockRepoAccount,
+					RepoLedger:   mockRepoLedger,
+					RepoTransfer: mockRepoTransfer,
+				})
+			)
+
+			cs.PrepareMockTxManager(mockTxManager, txExample)
+			cs.PrepareMockRepoAccount(mockRepoAccount, txExample)
+			cs.PrepareMockRepoTransfer(mockRepoTransfer, txExample)
+			cs.PrepareMockRepoLedger(mockRepoLedger, txExample)
+
+			data, err := app.Close(ctx, "account_id", cs.InputSweepAccountID)
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
@@ -0,0 +1,164 @@
+//go:generate mockgen -source=${GOFILE} -package=${GOPACKAGE} -destination=${GOPACKAGE}_mock.go
+
+package apikey
+
+import (
+	"context"
+	"crypto/subtle"
+	pkgerror "github.com/carlosrodriguesf/bank-api/pkg/error"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/account"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/apikey"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/generate"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/validator"
+)
+
+type (
+	Options struct {
+		Logger      logger.Logger
+		Validator   validator.Validator
+		Generate    generate.Generate
+		RepoAccount account.Repository
+		RepoAPIKey  apikey.Repository
+	}
+	App interface {
+		Create(ctx context.Context, creation model.APIKeyCreation) (*model.APIKeyCreated, error)
+		List(ctx context.Context, accountID string) ([]model.APIKey, error)
+		Revoke(ctx context.Context, accountID string, id string) error
+		Authenticate(ctx context.Context, key string) (*model.Session, error)
+	}
+	appImpl struct {
+		logger      logger.Logger
+		validator   validator.Validator
+		generate    generate.Generate
+		repoAccount account.Repository
+		repoAPIKey  apikey.Repository
+	}
+)
+
+func NewApp(opts Options) App {
+	return &appImpl{
+		logger:      opts.Logger.WithLocation().WithPreffix("app.apikey"),
+		validator:   opts.Validator,
+		generate:    opts.Generate,
+		repoAccount: opts.RepoAccount,
+		repoAPIKey:  opts.RepoAPIKey,
+	}
+}
+
+// Create issues a key for the account. The scopes must be granted by the role of the account and can't include
+// managing credentials, so a leaked key can't be used to create others.
+func (a *appImpl) Create(ctx context.Context, creation model.APIKeyCreation) (*model.APIKeyCreated, error) {
+	if err := a.validator.Validate(creation); err != nil {
+		return nil, err
+	}
+	if creation.ExpiresAt != nil && !creation.ExpiresAt.After(a.generate.CurrentTime()) {
+		return nil, pkgerror.ErrInvalidAPIKeyExpiration
+	}
+
+	acc, err := a.repoAccount.GetByIDOrDocument(ctx, creation.AccountID)
+	if err != nil {
+		a.logger.Error(err)
+		return nil, pkgerror.ErrCantCreateAPIKey
+	}
+	if acc == nil {
+		return nil, pkgerror.ErrAccountNotFound
+	}
+	for _, scope := range creation.Scopes {
+		if scope == model.PermissionManageCredentials || !acc.HasPermission(scope) {
+			return nil, pkgerror.ErrInvalidAPIKeyScopes
+		}
+	}
+
+	prefix, key, err := generateKey()
+	if err != nil {
+		a.logger.Error(err)
+		return nil, pkgerror.ErrCantCreateAPIKey
+	}
+
+	apiKey := model.APIKey{
+		AccountID: acc.ID,
+		Name:      creation.Name,
+		Prefix:    prefix,
+		KeyHash:   hashKey(key),
+		Scopes:    creation.Scopes,
+		ExpiresAt: creation.ExpiresAt,
+	}
+	genData, err := a.repoAPIKey.Create(ctx, apiKey)
+	if err != nil {
+		a.logger.Error(err)
+		return nil, pkgerror.ErrCantCreateAPIKey
+	}
+
+	apiKey.ID = genData.ID
+	apiKey.CreatedAt = genData.CreatedAt
+
+	return &model.APIKeyCreated{APIKey: apiKey, Key: key}, nil
+}
+
+func (a *appImpl) List(ctx context.Context, accountID string) ([]model.APIKey, error) {
+	apiKeys, err := a.repoAPIKey.ListByAccount(ctx, accountID)
+	if err != nil {
+		a.logger.Error(err)
+		return nil, pkgerror.ErrCantListAPIKeys
+	}
+	return apiKeys, nil
+}
+
+func (a *appImpl) Revoke(ctx context.Context, accountID string, id string) error {
+	revoked, err := a.repoAPIKey.Revoke(ctx, accountID, id)
+	if err != nil {
+		a.logger.Error(err)
+		return pkgerror.ErrCantRevokeAPIKey
+	}
+	if !revoked {
+		return pkgerror.ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+// Authenticate returns a session for the account of key, limited to its scopes. Revoked and expired keys, and keys of
+// closed accounts, are refused.
+func (a *appImpl) Authenticate(ctx context.Context, key string) (*model.Session, error) {
+	prefix, ok := parseKeyPrefix(key)
+	if !ok {
+		return nil, pkgerror.ErrInvalidAPIKey
+	}
+
+	apiKey, err := a.repoAPIKey.GetByPrefix(ctx, prefix)
+	if err != nil {
+		a.logger.Error(err)
+		return nil, pkgerror.ErrCantAuthenticateAPIKey
+	}
+	if apiKey == nil || subtle.ConstantTimeCompare([]byte(apiKey.KeyHash), []byte(hashKey(key))) != 1 {
+		return nil, pkgerror.ErrInvalidAPIKey
+	}
+
+	now := a.generate.CurrentTime()
+	if apiKey.RevokedAt != nil || (apiKey.ExpiresAt != nil && !apiKey.ExpiresAt.After(now)) {
+		return nil, pkgerror.ErrInvalidAPIKey
+	}
+
+	acc, err := a.repoAccount.GetByIDOrDocument(ctx, apiKey.AccountID)
+	if err != nil {
+		a.logger.Error(err)
+		return nil, pkgerror.ErrCantAuthenticateAPIKey
+	}
+	if acc == nil || acc.Status == model.AccountStatusClosed {
+		return nil, pkgerror.ErrInvalidAPIKey
+	}
+
+	if err = a.repoAPIKey.UpdateLastUsed(ctx, apiKey.ID, now); err != nil {
+		a.logger.Error(err)
+	}
+
+	return &model.Session{
+		ID:         apiKey.ID,
+		Account:    *acc,
+		CreatedAt:  apiKey.CreatedAt,
+		LastSeenAt: now,
+		APIKeyID:   apiKey.ID,
+		Scopes:     apiKey.Scopes,
+	}, nil
+}
@@ -0,0 +1,42 @@
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"strings"
+)
+
+const (
+	keyPrefixBytes = 6
+	keySecretBytes = 32
+)
+
+// generateKey returns a key formatted as bk_<prefix>_<secret> along with its prefix, which is stored in clear to find
+// the key again.
+func generateKey() (string, string, error) {
+	b := make([]byte, keyPrefixBytes+keySecretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	prefix := hex.EncodeToString(b[:keyPrefixBytes])
+	return prefix, model.APIKeyPrefix + prefix + "_" + base64.RawURLEncoding.EncodeToString(b[keyPrefixBytes:]), nil
+}
+
+func parseKeyPrefix(key string) (string, bool) {
+	if !strings.HasPrefix(key, model.APIKeyPrefix) {
+		return "", false
+	}
+	parts := strings.SplitN(strings.TrimPrefix(key, model.APIKeyPrefix), "_", 2)
+	if len(parts) != 2 || len(parts[0]) != keyPrefixBytes*2 || parts[1] == "" {
+		return "", false
+	}
+	return parts[0], true
+}
+
+func hashKey(key string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(key)))
+}
@@ -0,0 +1,466 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	pkgerror "github.com/carlosrodriguesf/bank-api/pkg/error"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/account"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/apikey"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/generate"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/validator"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestCreate(t *testing.T) {
+	var (
+		currentTime = time.Now()
+		expiresAt   = currentTime.Add(time.Hour)
+		createData  = model.APIKeyCreation{
+			AccountID: "account_id",
+			Name:      "merchant backend",
+			Scopes:    []string{model.PermissionReadAccounts, model.PermissionCreateTransfers},
+			ExpiresAt: &expiresAt,
+		}
+		accountExample         = model.Account{ID: "account_id", Role: model.RoleCustomer}
+		validationErrorExample = &validator.ValidationError{Message: "invalid data"}
+	)
+
+	cases := map[string]struct {
+		InputData              model.APIKeyCreation
+		ExpectedError          error
+		PrepareMockValidator   func(mock *validator.MockValidator)
+		PrepareMockGenerate    func(mock *generate.MockGenerate)
+		PrepareMockRepoAccount func(mock *account.MockRepository)
+		PrepareMockRepoAPIKey  func(mock *apikey.MockRepository)
+	}{
+		"should return success": {
+			InputData:     createData,
+			ExpectedError: nil,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(createData).Return(nil)
+			},
+			PrepareMockGenerate: func(mock *generate.MockGenerate) {
+				mock.EXPECT().CurrentTime().Return(currentTime)
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), "account_id").Return(&accountExample, nil)
+			},
+			PrepareMockRepoAPIKey: func(mock *apikey.MockRepository) {
+				mock.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(&model.GeneratedData{ID: "api_key_id", CreatedAt: currentTime}, nil)
+			},
+		},
+		"should return validation error": {
+			InputData:     createData,
+			ExpectedError: validationErrorExample,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(createData).Return(validationErrorExample)
+			},
+			PrepareMockGenerate:    func(mock *generate.MockGenerate) {},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {},
+			PrepareMockRepoAPIKey:  func(mock *apikey.MockRepository) {},
+		},
+		"should return error: expiration in past": {
+			InputData:     createData,
+			ExpectedError: pkgerror.ErrInvalidAPIKeyExpiration,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(createData).Return(nil)
+			},
+			PrepareMockGenerate: func(mock *generate.MockGenerate) {
+				mock.EXPECT().CurrentTime().Return(expiresAt)
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {},
+			PrepareMockRepoAPIKey:  func(mock *apikey.MockRepository) {},
+		},
+		"should return error: scope not granted by role": {
+			InputData: model.APIKeyCreation{
+				AccountID: "account_id",
+				Name:      "merchant backend",
+				Scopes:    []string{model.PermissionListAccounts},
+			},
+			ExpectedError: pkgerror.ErrInvalidAPIKeyScopes,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(gomock.Any()).Return(nil)
+			},
+			PrepareMockGenerate: func(mock *generate.MockGenerate) {},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), "account_id").Return(&accountExample, nil)
+			},
+			PrepareMockRepoAPIKey: func(mock *apikey.MockRepository) {},
+		},
+		"should return error: scope to manage credentials": {
+			InputData: model.APIKeyCreation{
+				AccountID: "account_id",
+				Name:      "merchant backend",
+				Scopes:    []string{model.PermissionManageCredentials},
+			},
+			ExpectedError: pkgerror.ErrInvalidAPIKeyScopes,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(gomock.Any()).Return(nil)
+			},
+			PrepareMockGenerate: func(mock *generate.MockGenerate) {},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), "account_id").Return(&accountExample, nil)
+			},
+			PrepareMockRepoAPIKey: func(mock *apikey.MockRepository) {},
+		},
+		"should return error: account not found": {
+			InputData:     createData,
+			ExpectedError: pkgerror.ErrAccountNotFound,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(createData).Return(nil)
+			},
+			PrepareMockGenerate: func(mock *generate.MockGenerate) {
+				mock.EXPECT().CurrentTime().Return(currentTime)
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), "account_id").Return(nil, nil)
+			},
+			PrepareMockRepoAPIKey: func(mock *apikey.MockRepository) {},
+		},
+		"should return error on get account": {
+			InputData:     createData,
+			ExpectedError: pkgerror.ErrCantCreateAPIKey,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(createData).Return(nil)
+			},
+			PrepareMockGenerate: func(mock *generate.MockGenerate) {
+				mock.EXPECT().CurrentTime().Return(currentTime)
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), "account_id").Return(nil, errors.New("fail"))
+			},
+			PrepareMockRepoAPIKey: func(mock *apikey.MockRepository) {},
+		},
+		"should return error on create": {
+			InputData:     createData,
+			ExpectedError: pkgerror.ErrCantCreateAPIKey,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(createData).Return(nil)
+			},
+			PrepareMockGenerate: func(mock *generate.MockGenerate) {
+				mock.EXPECT().CurrentTime().Return(currentTime)
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), "account_id").Return(&accountExample, nil)
+			},
+			PrepareMockRepoAPIKey: func(mock *apikey.MockRepository) {
+				mock.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx       = gomock.WithContext(context.Background(), t)
+				mockValidator   = validator.NewMockValidator(ctrl)
+				mockGenerate    = generate.NewMockGenerate(ctrl)
+				mockRepoAccount = account.NewMockRepository(ctrl)
+				mockRepoAPIKey  = apikey.NewMockRepository(ctrl)
+				app             = NewApp(Options{
+					Logger:      logger.New(""),
+					Validator:   mockValidator,
+					Generate:    mockGenerate,
+					RepoAccount: mockRepoAccount,
+					RepoAPIKey:  mockRepoAPIKey,
+				})
+			)
+
+			cs.PrepareMockValidator(mockValidator)
+			cs.PrepareMockGenerate(mockGenerate)
+			cs.PrepareMockRepoAccount(mockRepoAccount)
+			cs.PrepareMockRepoAPIKey(mockRepoAPIKey)
+
+			data, err := app.Create(ctx, cs.InputData)
+
+			assert.Equal(t, cs.ExpectedError, err)
+			if cs.ExpectedError != nil {
+				assert.Nil(t, data)
+				return
+			}
+			prefix, ok := parseKeyPrefix(data.Key)
+			assert.True(t, ok)
+			assert.Equal(t, prefix, data.Prefix)
+			assert.Equal(t, hashKey(data.Key), data.KeyHash)
+			assert.Equal(t, model.APIKey{
+				ID:        "api_key_id",
+				AccountID: "account_id",
+				Name:      createData.Name,
+				Prefix:    data.Prefix,
+				KeyHash:   data.KeyHash,
+				Scopes:    createData.Scopes,
+				ExpiresAt: createData.ExpiresAt,
+				CreatedAt: currentTime,
+			}, data.APIKey)
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	apiKeysExample := []model.APIKey{{ID: "api_key_id", AccountID: "account_id", Name: "merchant backend"}}
+
+	cases := map[string]struct {
+		ExpectedData          []model.APIKey
+		ExpectedError         error
+		PrepareMockRepoAPIKey func(mock *apikey.MockRepository)
+	}{
+		"should return success": {
+			ExpectedData:  apiKeysExample,
+			ExpectedError: nil,
+			PrepareMockRepoAPIKey: func(mock *apikey.MockRepository) {
+				mock.EXPECT().ListByAccount(gomock.Any(), "account_id").Return(apiKeysExample, nil)
+			},
+		},
+		"should return error": {
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantListAPIKeys,
+			PrepareMockRepoAPIKey: func(mock *apikey.MockRepository) {
+				mock.EXPECT().ListByAccount(gomock.Any(), "account_id").Return(nil, errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx      = gomock.WithContext(context.Background(), t)
+				mockRepoAPIKey = apikey.NewMockRepository(ctrl)
+				app            = NewApp(Options{
+					Logger:     logger.New(""),
+					RepoAPIKey: mockRepoAPIKey,
+				})
+			)
+
+			cs.PrepareMockRepoAPIKey(mockRepoAPIKey)
+
+			data, err := app.List(ctx, "account_id")
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestRevoke(t *testing.T) {
+	cases := map[string]struct {
+		ExpectedError         error
+		PrepareMockRepoAPIKey func(mock *apikey.MockRepository)
+	}{
+		"should return success": {
+			ExpectedError: nil,
+			PrepareMockRepoAPIKey: func(mock *apikey.MockRepository) {
+				mock.EXPECT().Revoke(gomock.Any(), "account_id", "api_key_id").Return(true, nil)
+			},
+		},
+		"should return error: not found": {
+			ExpectedError: pkgerror.ErrAPIKeyNotFound,
+			PrepareMockRepoAPIKey: func(mock *apikey.MockRepository) {
+				mock.EXPECT().Revoke(gomock.Any(), "account_id", "api_key_id").Return(false, nil)
+			},
+		},
+		"should return error": {
+			ExpectedError: pkgerror.ErrCantRevokeAPIKey,
+			PrepareMockRepoAPIKey: func(mock *apikey.MockRepository) {
+				mock.EXPECT().Revoke(gomock.Any(), "account_id", "api_key_id").Return(false, errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx      = gomock.WithContext(context.Background(), t)
+				mockRepoAPIKey = apikey.NewMockRepository(ctrl)
+				app            = NewApp(Options{
+					Logger:     logger.New(""),
+					RepoAPIKey: mockRepoAPIKey,
+				})
+			)
+
+			cs.PrepareMockRepoAPIKey(mockRepoAPIKey)
+
+			err := app.Revoke(ctx, "account_id", "api_key_id")
+
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	var (
+		currentTime   = time.Now()
+		expiredAt     = currentTime.Add(-time.Minute)
+		key           = "bk_0a1b2c3d4e5f_secret"
+		apiKeyExample = model.APIKey{
+			ID:        "api_key_id",
+			AccountID: "account_id",
+			Prefix:    "0a1b2c3d4e5f",
+			KeyHash:   hashKey(key),
+			Scopes:    pq.StringArray{model.PermissionReadAccounts},
+			CreatedAt: currentTime.Add(-time.Hour),
+		}
+		accountExample = model.Account{ID: "account_id", Role: model.RoleCustomer, Status: model.AccountStatusActive}
+	)
+
+	cases := map[string]struct {
+		InputKey               string
+		ExpectedData           *model.Session
+		ExpectedError          error
+		PrepareMockGenerate    func(mock *generate.MockGenerate)
+		PrepareMockRepoAccount func(mock *account.MockRepository)
+		PrepareMockRepoAPIKey  func(mock *apikey.MockRepository)
+	}{
+		"should return success": {
+			InputKey: key,
+			ExpectedData: &model.Session{
+				ID:         "api_key_id",
+				Account:    accountExample,
+				CreatedAt:  apiKeyExample.CreatedAt,
+				LastSeenAt: currentTime,
+				APIKeyID:   "api_key_id",
+				Scopes:     []string{model.PermissionReadAccounts},
+			},
+			ExpectedError: nil,
+			PrepareMockGenerate: func(mock *generate.MockGenerate) {
+				mock.EXPECT().CurrentTime().Return(currentTime)
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), "account_id").Return(&accountExample, nil)
+			},
+			PrepareMockRepoAPIKey: func(mock *apikey.MockRepository) {
+				mock.EXPECT().GetByPrefix(gomock.Any(), "0a1b2c3d4e5f").Return(&apiKeyExample, nil)
+				mock.EXPECT().UpdateLastUsed(gomock.Any(), "api_key_id", currentTime).Return(errors.New("fail"))
+			},
+		},
+		"should return error: malformed key": {
+			InputKey:               "bk_secret",
+			ExpectedData:           nil,
+			ExpectedError:          pkgerror.ErrInvalidAPIKey,
+			PrepareMockGenerate:    func(mock *generate.MockGenerate) {},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {},
+			PrepareMockRepoAPIKey:  func(mock *apikey.MockRepository) {},
+		},
+		"should return error: key not found": {
+			InputKey:               key,
+			ExpectedData:           nil,
+			ExpectedError:          pkgerror.ErrInvalidAPIKey,
+			PrepareMockGenerate:    func(mock *generate.MockGenerate) {},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {},
+			PrepareMockRepoAPIKey: func(mock *apikey.MockRepository) {
+				mock.EXPECT().GetByPrefix(gomock.Any(), "0a1b2c3d4e5f").Return(nil, nil)
+			},
+		},
+		"should return error: wrong secret": {
+			InputKey:               "bk_0a1b2c3d4e5f_other",
+			ExpectedData:           nil,
+			ExpectedError:          pkgerror.ErrInvalidAPIKey,
+			PrepareMockGenerate:    func(mock *generate.MockGenerate) {},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {},
+			PrepareMockRepoAPIKey: func(mock *apikey.MockRepository) {
+				mock.EXPECT().GetByPrefix(gomock.Any(), "0a1b2c3d4e5f").Return(&apiKeyExample, nil)
+			},
+		},
+		"should return error: revoked key": {
+			InputKey:      key,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrInvalidAPIKey,
+			PrepareMockGenerate: func(mock *generate.MockGenerate) {
+				mock.EXPECT().CurrentTime().Return(currentTime)
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {},
+			PrepareMockRepoAPIKey: func(mock *apikey.MockRepository) {
+				revoked := apiKeyExample
+				revoked.RevokedAt = &expiredAt
+				mock.EXPECT().GetByPrefix(gomock.Any(), "0a1b2c3d4e5f").Return(&revoked, nil)
+			},
+		},
+		"should return error: expired key": {
+			InputKey:      key,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrInvalidAPIKey,
+			PrepareMockGenerate: func(mock *generate.MockGenerate) {
+				mock.EXPECT().CurrentTime().Return(currentTime)
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {},
+			PrepareMockRepoAPIKey: func(mock *apikey.MockRepository) {
+				expired := apiKeyExample
+				expired.ExpiresAt = &expiredAt
+				mock.EXPECT().GetByPrefix(gomock.Any(), "0a1b2c3d4e5f").Return(&expired, nil)
+			},
+		},
+		"should return error: closed account": {
+			InputKey:      key,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrInvalidAPIKey,
+			PrepareMockGenerate: func(mock *generate.MockGenerate) {
+				mock.EXPECT().CurrentTime().Return(currentTime)
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().
+					GetByIDOrDocument(gomock.Any(), "account_id").
+					Return(&model.Account{ID: "account_id", Status: model.AccountStatusClosed}, nil)
+			},
+			PrepareMockRepoAPIKey: func(mock *apikey.MockRepository) {
+				mock.EXPECT().GetByPrefix(gomock.Any(), "0a1b2c3d4e5f").Return(&apiKeyExample, nil)
+			},
+		},
+		"should return error on get key": {
+			InputKey:               key,
+			ExpectedData:           nil,
+			ExpectedError:          pkgerror.ErrCantAuthenticateAPIKey,
+			PrepareMockGenerate:    func(mock *generate.MockGenerate) {},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {},
+			PrepareMockRepoAPIKey: func(mock *apikey.MockRepository) {
+				mock.EXPECT().GetByPrefix(gomock.Any(), "0a1b2c3d4e5f").Return(nil, errors.New("fail"))
+			},
+		},
+		"should return error on get account": {
+			InputKey:      key,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantAuthenticateAPIKey,
+			PrepareMockGenerate: func(mock *generate.MockGenerate) {
+				mock.EXPECT().CurrentTime().Return(currentTime)
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), "account_id").Return(nil, errors.New("fail"))
+			},
+			PrepareMockRepoAPIKey: func(mock *apikey.MockRepository) {
+				mock.EXPECT().GetByPrefix(gomock.Any(), "0a1b2c3d4e5f").Return(&apiKeyExample, nil)
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx       = gomock.WithContext(context.Background(), t)
+				mockGenerate    = generate.NewMockGenerate(ctrl)
+				mockRepoAccount = account.NewMockRepository(ctrl)
+				mockRepoAPIKey  = apikey.NewMockRepository(ctrl)
+				app             = NewApp(Options{
+					Logger:      logger.New(""),
+					Generate:    mockGenerate,
+					RepoAccount: mockRepoAccount,
+					RepoAPIKey:  mockRepoAPIKey,
+				})
+			)
+
+			cs.PrepareMockGenerate(mockGenerate)
+			cs.PrepareMockRepoAccount(mockRepoAccount)
+			cs.PrepareMockRepoAPIKey(mockRepoAPIKey)
+
+			data, err := app.Authenticate(ctx, cs.InputKey)
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
@@ -2,66 +2,165 @@ package app
 
 import (
 	"github.com/carlosrodriguesf/bank-api/pkg/app/account"
+	"github.com/carlosrodriguesf/bank-api/pkg/app/apikey"
 	"github.com/carlosrodriguesf/bank-api/pkg/app/auth"
+	"github.com/carlosrodriguesf/bank-api/pkg/app/recurrence"
+	"github.com/carlosrodriguesf/bank-api/pkg/app/schedule"
+	"github.com/carlosrodriguesf/bank-api/pkg/app/statement"
 	"github.com/carlosrodriguesf/bank-api/pkg/app/transfer"
+	"github.com/carlosrodriguesf/bank-api/pkg/app/twofactor"
 	"github.com/carlosrodriguesf/bank-api/pkg/repository"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/cache"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/db"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/exchange"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/fee"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/generate"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/notifier"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/risk"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/secret"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/totp"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/transaction"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/validator"
+	"time"
 )
 
 type (
 	Options struct {
-		DB         db.ExtendedDB
-		Repository repository.Container
-		Logger     logger.Logger
-		Cache      cache.Cache
+		DB          db.ExtendedDB
+		Repository  repository.Container
+		Logger      logger.Logger
+		Cache       cache.Cache
+		Secret      secret.Secret
+		AuthLockout auth.LockoutConfig
+		AuthTokens  auth.TokenConfig
+		TOTP        totp.TOTP
+		Notifier    notifier.Notifier
+		Rates       exchange.Rates
+		Fees        fee.Engine
+		// Risk checks transfers before they move money, holding the suspicious ones for a review.
+		Risk risk.Engine
+		// FeeAccountID is the account credited with the transfer fees.
+		FeeAccountID string
+		// SecretResetTTL is how long a secret reset token can be used.
+		SecretResetTTL time.Duration
+		// TransferStepUpThreshold is the amount above which transfers ask for the second factor.
+		TransferStepUpThreshold int64
 	}
 	Container interface {
 		Account() account.App
+		APIKey() apikey.App
 		Auth() auth.App
+		Recurrence() recurrence.App
+		Schedule() schedule.App
+		Statement() statement.App
 		Transfer() transfer.App
+		TwoFactor() twofactor.App
 	}
 	container struct {
-		account  account.App
-		auth     auth.App
-		transfer transfer.App
+		account    account.App
+		apiKey     apikey.App
+		auth       auth.App
+		recurrence recurrence.App
+		schedule   schedule.App
+		statement  statement.App
+		transfer   transfer.App
+		twoFactor  twofactor.App
 	}
 )
 
 func NewContainer(opts Options) Container {
 	var (
 		validatorInstance = validator.New()
-		secretInstance    = secret.New()
 		txManagerInstance = transaction.NewManager(opts.DB)
 		generateInstance  = generate.New()
+		twoFactorInstance = twofactor.NewApp(twofactor.Options{
+			Logger:        opts.Logger,
+			Cache:         opts.Cache,
+			Generate:      generateInstance,
+			TxManager:     txManagerInstance,
+			TOTP:          opts.TOTP,
+			RepoAccount:   opts.Repository.Account(),
+			RepoTwoFactor: opts.Repository.TwoFactor(),
+		})
+		transferInstance = transfer.NewApp(transfer.Options{
+			Logger:          opts.Logger,
+			Validator:       validatorInstance,
+			Generate:        generateInstance,
+			TxManager:       txManagerInstance,
+			RepoAccount:     opts.Repository.Account(),
+			RepoHold:        opts.Repository.Hold(),
+			RepoLedger:      opts.Repository.Ledger(),
+			RepoLimit:       opts.Repository.Limit(),
+			RepoReview:      opts.Repository.Review(),
+			RepoTransfer:    opts.Repository.Transfer(),
+			TwoFactorApp:    twoFactorInstance,
+			Rates:           opts.Rates,
+			Fees:            opts.Fees,
+			FeeAccountID:    opts.FeeAccountID,
+			StepUpThreshold: opts.TransferStepUpThreshold,
+			Risk:            opts.Risk,
+		})
 	)
 	return &container{
 		account: account.NewApp(account.Options{
-			RepoAccount: opts.Repository.Account(),
-			Logger:      opts.Logger,
-			Validator:   validatorInstance,
-			Secret:      secretInstance,
+			RepoAccount:  opts.Repository.Account(),
+			RepoLedger:   opts.Repository.Ledger(),
+			RepoLimit:    opts.Repository.Limit(),
+			RepoTransfer: opts.Repository.Transfer(),
+			TxManager:    txManagerInstance,
+			Logger:       opts.Logger,
+			Validator:    validatorInstance,
+			Generate:     generateInstance,
+			Secret:       opts.Secret,
 		}),
-		auth: auth.NewApp(auth.Options{
+		apiKey: apikey.NewApp(apikey.Options{
 			Logger:      opts.Logger,
-			Cache:       opts.Cache,
 			Validator:   validatorInstance,
-			Secret:      secretInstance,
-			RepoAccount: opts.Repository.Account(),
 			Generate:    generateInstance,
+			RepoAccount: opts.Repository.Account(),
+			RepoAPIKey:  opts.Repository.APIKey(),
 		}),
-		transfer: transfer.NewApp(transfer.Options{
+		auth: auth.NewApp(auth.Options{
 			Logger:       opts.Logger,
+			Cache:        opts.Cache,
 			Validator:    validatorInstance,
-			TxManager:    txManagerInstance,
+			Secret:       opts.Secret,
+			RepoAccount:  opts.Repository.Account(),
+			RepoAudit:    opts.Repository.Audit(),
+			Generate:     generateInstance,
+			TwoFactorApp: twoFactorInstance,
+			Notifier:     opts.Notifier,
+			Lockout:      opts.AuthLockout,
+			Tokens:       opts.AuthTokens,
+
+			SecretResetTTL: opts.SecretResetTTL,
+		}),
+		recurrence: recurrence.NewApp(recurrence.Options{
+			Logger:         opts.Logger,
+			Validator:      validatorInstance,
+			Generate:       generateInstance,
+			RepoRecurrence: opts.Repository.Recurrence(),
+			TransferApp:    transferInstance,
+		}),
+		schedule: schedule.NewApp(schedule.Options{
+			Logger:       opts.Logger,
+			Validator:    validatorInstance,
+			Generate:     generateInstance,
+			RepoSchedule: opts.Repository.Schedule(),
+			TransferApp:  transferInstance,
+		}),
+		statement: statement.NewApp(statement.Options{
+			Logger:       opts.Logger,
+			Validator:    validatorInstance,
+			Generate:     generateInstance,
 			RepoAccount:  opts.Repository.Account(),
+			RepoLedger:   opts.Repository.Ledger(),
 			RepoTransfer: opts.Repository.Transfer(),
+			FeeAccountID: opts.FeeAccountID,
 		}),
+		transfer:  transferInstance,
+		twoFactor: twoFactorInstance,
 	}
 }
 
@@ -69,10 +168,30 @@ func (c *container) Account() account.App {
 	return c.account
 }
 
+func (c *container) APIKey() apikey.App {
+	return c.apiKey
+}
+
 func (c *container) Auth() auth.App {
 	return c.auth
 }
 
+func (c *container) Recurrence() recurrence.App {
+	return c.recurrence
+}
+
+func (c *container) Schedule() schedule.App {
+	return c.schedule
+}
+
+func (c *container) Statement() statement.App {
+	return c.statement
+}
+
 func (c *container) Transfer() transfer.App {
 	return c.transfer
 }
+
+func (c *container) TwoFactor() twofactor.App {
+	return c.twoFactor
+}
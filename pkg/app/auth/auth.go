@@ -4,53 +4,161 @@ package auth
 
 import (
 	"context"
+	"fmt"
+	"github.com/carlosrodriguesf/bank-api/pkg/app/twofactor"
 	pkgerror "github.com/carlosrodriguesf/bank-api/pkg/error"
 	"github.com/carlosrodriguesf/bank-api/pkg/model"
 	"github.com/carlosrodriguesf/bank-api/pkg/repository/account"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/audit"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/cache"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/generate"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/jwt"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/notifier"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/secret"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/validator"
+	"sort"
 	"time"
 )
 
 const (
-	cacheKeySession = "auth:session:%s"
-	cacheExpiration = time.Hour
+	TokenModeSession = "session"
+	TokenModeJWT     = "jwt"
+
+	cacheKeySession          = "auth:session:%s"
+	cacheKeyAccountSessions  = "auth:account-sessions:%s"
+	cacheKeyDocumentFailures = "auth:failures:document:%s"
+	cacheKeyDocumentLock     = "auth:lock:document:%s"
+	cacheKeyIPFailures       = "auth:failures:ip:%s"
+	cacheKeyIPLock           = "auth:lock:ip:%s"
+	cacheKeyRefresh          = "auth:refresh:%s"
+	cacheKeyRefreshUsed      = "auth:refresh-used:%s"
+	cacheKeySecretReset      = "auth:secret-reset:%s"
+	cacheKeySecretResetUsed  = "auth:secret-reset-used:%s"
+	cacheExpiration          = time.Hour
+
+	defaultMaxAttempts        = 5
+	defaultMaxAttemptsPerIP   = 20
+	defaultLockoutDuration    = 15 * time.Minute
+	defaultMaxLockoutDuration = 24 * time.Hour
+	defaultAttemptsWindow     = 24 * time.Hour
+	defaultAccessTTL          = 5 * time.Minute
+	defaultRefreshTTL         = 30 * 24 * time.Hour
+	defaultSecretResetTTL     = 30 * time.Minute
 )
 
 type (
+	// LockoutConfig sets how many failed logins are tolerated before a document or an ip is locked. Every failure
+	// past the limit doubles the lockout, up to MaxDuration. Failures are forgotten after Window without new ones.
+	// Zero values fall back to the defaults.
+	LockoutConfig struct {
+		MaxAttempts      int64
+		MaxAttemptsPerIP int64
+		Duration         time.Duration
+		MaxDuration      time.Duration
+		Window           time.Duration
+	}
+	// TokenConfig selects what the client gets to authenticate. In TokenModeSession it is an opaque token that keys
+	// the session in the cache. In TokenModeJWT it is a JWT access token, checked without the cache and valid for
+	// AccessTTL, along with a refresh token that renews it and is replaced on every use. The session then lasts
+	// RefreshTTL since the last renewal. Zero values fall back to the defaults.
+	TokenConfig struct {
+		Mode       string
+		JWT        jwt.JWT
+		AccessTTL  time.Duration
+		RefreshTTL time.Duration
+	}
 	Options struct {
-		Logger      logger.Logger
-		Secret      secret.Secret
-		Cache       cache.Cache
-		Validator   validator.Validator
-		RepoAccount account.Repository
-		Generate    generate.Generate
+		Logger       logger.Logger
+		Secret       secret.Secret
+		Cache        cache.Cache
+		Validator    validator.Validator
+		RepoAccount  account.Repository
+		RepoAudit    audit.Repository
+		Generate     generate.Generate
+		TwoFactorApp twofactor.App
+		Notifier     notifier.Notifier
+		Lockout      LockoutConfig
+		Tokens       TokenConfig
+		// SecretResetTTL is how long a secret reset token can be used. Zero falls back to the default.
+		SecretResetTTL time.Duration
 	}
 	App interface {
 		Auth(ctx context.Context, credentials model.Credentials) (*model.Session, error)
+		Refresh(ctx context.Context, refreshToken string) (*model.Session, error)
 		GetSessionByToken(ctx context.Context, token string) (*model.Session, error)
+		Logout(ctx context.Context, session model.Session) error
+		ListSessions(ctx context.Context, accountID string, currentSessionID string) ([]model.SessionInfo, error)
+		RevokeSession(ctx context.Context, accountID string, sessionID string) error
+		RevokeSessions(ctx context.Context, accountID string, exceptSessionID string) error
+		ChangeSecret(ctx context.Context, change model.SecretChange) error
+		RequestSecretReset(ctx context.Context, document string) error
+		ResetSecret(ctx context.Context, reset model.SecretReset) error
 	}
 	appImpl struct {
-		logger      logger.Logger
-		secret      secret.Secret
-		cache       cache.Cache
-		validator   validator.Validator
-		repoAccount account.Repository
-		generate    generate.Generate
+		logger       logger.Logger
+		secret       secret.Secret
+		cache        cache.Cache
+		validator    validator.Validator
+		repoAccount  account.Repository
+		repoAudit    audit.Repository
+		generate     generate.Generate
+		twoFactorApp twofactor.App
+		notifier     notifier.Notifier
+		lockout      LockoutConfig
+		tokens       TokenConfig
+		resetTTL     time.Duration
+	}
+	// refreshRecord is what the cache keeps of a refresh token, under its hash. SessionToken is the internal key of
+	// the session, which is never handed to the client in TokenModeJWT.
+	refreshRecord struct {
+		AccountID    string `json:"accountId"`
+		SessionID    string `json:"sessionId"`
+		SessionToken string `json:"sessionToken"`
 	}
 )
 
 func NewApp(opts Options) App {
+	if opts.Lockout.MaxAttempts == 0 {
+		opts.Lockout.MaxAttempts = defaultMaxAttempts
+	}
+	if opts.Lockout.MaxAttemptsPerIP == 0 {
+		opts.Lockout.MaxAttemptsPerIP = defaultMaxAttemptsPerIP
+	}
+	if opts.Lockout.Duration == 0 {
+		opts.Lockout.Duration = defaultLockoutDuration
+	}
+	if opts.Lockout.MaxDuration == 0 {
+		opts.Lockout.MaxDuration = defaultMaxLockoutDuration
+	}
+	if opts.Lockout.Window == 0 {
+		opts.Lockout.Window = defaultAttemptsWindow
+	}
+	if opts.Tokens.Mode == "" {
+		opts.Tokens.Mode = TokenModeSession
+	}
+	if opts.Tokens.AccessTTL == 0 {
+		opts.Tokens.AccessTTL = defaultAccessTTL
+	}
+	if opts.Tokens.RefreshTTL == 0 {
+		opts.Tokens.RefreshTTL = defaultRefreshTTL
+	}
+	if opts.SecretResetTTL == 0 {
+		opts.SecretResetTTL = defaultSecretResetTTL
+	}
 	return &appImpl{
-		logger:      opts.Logger.WithLocation().WithPreffix("service.auth"),
-		secret:      opts.Secret,
-		cache:       opts.Cache,
-		validator:   opts.Validator,
-		repoAccount: opts.RepoAccount,
-		generate:    opts.Generate,
+		logger:       opts.Logger.WithLocation().WithPreffix("service.auth"),
+		secret:       opts.Secret,
+		cache:        opts.Cache,
+		validator:    opts.Validator,
+		repoAccount:  opts.RepoAccount,
+		repoAudit:    opts.RepoAudit,
+		generate:     opts.Generate,
+		twoFactorApp: opts.TwoFactorApp,
+		notifier:     opts.Notifier,
+		lockout:      opts.Lockout,
+		tokens:       opts.Tokens,
+		resetTTL:     opts.SecretResetTTL,
 	}
 }
 
@@ -61,44 +169,576 @@ func (a *appImpl) Auth(ctx context.Context, credentials model.Credentials) (*mod
 
 	credentials.Document = model.DocumentRegex.ReplaceAllString(credentials.Document, "")
 
+	if err := a.checkLockout(ctx, credentials); err != nil {
+		if err != pkgerror.ErrCantAuth {
+			a.audit(ctx, credentials, nil, err)
+		}
+		return nil, err
+	}
+
 	acc, err := a.repoAccount.GetByIDOrDocument(ctx, credentials.Document)
 	if err != nil {
 		a.logger.Error(err)
 		return nil, pkgerror.ErrCantAuth
 	}
 	if acc == nil {
+		a.registerFailure(ctx, credentials, nil, pkgerror.ErrInvalidCredentials)
 		return nil, pkgerror.ErrInvalidCredentials
 	}
 	if !a.secret.Verify(credentials.Secret, acc.Secret, acc.SecretSalt) {
+		a.registerFailure(ctx, credentials, &acc.ID, pkgerror.ErrInvalidCredentials)
 		return nil, pkgerror.ErrInvalidCredentials
 	}
+	if acc.Status == model.AccountStatusClosed {
+		a.audit(ctx, credentials, &acc.ID, pkgerror.ErrAccountClosed)
+		return nil, pkgerror.ErrAccountClosed
+	}
+	if err = a.twoFactorApp.Verify(ctx, acc.ID, credentials.Code); err != nil {
+		switch err {
+		case pkgerror.ErrTwoFactorRequired:
+			return nil, err
+		case pkgerror.ErrInvalidTwoFactorCode:
+			a.registerFailure(ctx, credentials, &acc.ID, err)
+			return nil, err
+		}
+		return nil, pkgerror.ErrCantAuth
+	}
+	if err = a.cache.Delete(ctx, fmt.Sprintf(cacheKeyDocumentFailures, credentials.Document)); err != nil {
+		a.logger.Error(err)
+	}
+	if a.secret.NeedsRehash(acc.Secret) {
+		a.rehashSecret(ctx, acc.ID, credentials.Secret)
+	}
 
+	now := a.generate.CurrentTime()
 	session := &model.Session{
-		Token:     a.generate.UUID(),
-		Account:   *acc,
-		CreatedAt: a.generate.CurrentTime(),
+		ID:         a.generate.UUID(),
+		Token:      a.generate.UUID(),
+		Account:    *acc,
+		Device:     credentials.Device,
+		IP:         credentials.IP,
+		CreatedAt:  now,
+		LastSeenAt: now,
 	}
 
-	err = a.cache.Set(ctx, getSessionCacheKey(session.Token), session, cacheExpiration)
+	expiration := a.getSessionExpiration()
+	err = a.cache.SetField(ctx, getAccountSessionsCacheKey(acc.ID), session.ID, session.Token, expiration)
+	if err != nil {
+		a.logger.Error(err)
+		return nil, pkgerror.ErrCantAuth
+	}
+	err = a.cache.Set(ctx, getSessionCacheKey(session.Token), session, expiration)
 	if err != nil {
 		a.logger.Error(err)
 		return nil, pkgerror.ErrCantAuth
 	}
 
+	if a.tokens.Mode == TokenModeJWT {
+		if session, err = a.issueTokens(ctx, *session); err != nil {
+			return nil, pkgerror.ErrCantAuth
+		}
+	}
 	return session, nil
 }
 
-func (a *appImpl) GetSessionByToken(ctx context.Context, token string) (*model.Session, error) {
-	var (
-		session = new(model.Session)
-		err     = a.cache.GetUpdating(ctx, getSessionCacheKey(token), session, cacheExpiration)
-	)
+// Refresh trades a refresh token for a new access token and a new refresh token. Each refresh token is accepted only
+// once: presenting one that was already used ends its session, as the token may have leaked to someone else.
+func (a *appImpl) Refresh(ctx context.Context, refreshToken string) (*model.Session, error) {
+	if a.tokens.Mode != TokenModeJWT || refreshToken == "" {
+		return nil, pkgerror.ErrInvalidRefresh
+	}
+
+	hash := hashToken(refreshToken)
+	var record refreshRecord
+	if err := a.cache.Get(ctx, getRefreshCacheKey(hash), &record); err != nil {
+		if a.cache.IsErrCacheMissing(err) {
+			return nil, pkgerror.ErrInvalidRefresh
+		}
+		a.logger.Error(err)
+		return nil, pkgerror.ErrCantRefresh
+	}
+
+	firstUse, err := a.cache.SetIfNotExists(ctx, getRefreshUsedCacheKey(hash), true, a.tokens.RefreshTTL)
+	if err != nil {
+		a.logger.Error(err)
+		return nil, pkgerror.ErrCantRefresh
+	}
+	if !firstUse {
+		_ = a.revoke(ctx, record.AccountID, map[string]string{record.SessionID: record.SessionToken})
+		return nil, pkgerror.ErrRefreshReused
+	}
+
+	session := new(model.Session)
+	if err = a.cache.Get(ctx, getSessionCacheKey(record.SessionToken), session); err != nil {
+		if a.cache.IsErrCacheMissing(err) {
+			return nil, pkgerror.ErrInvalidRefresh
+		}
+		a.logger.Error(err)
+		return nil, pkgerror.ErrCantRefresh
+	}
+
+	// the account is read again so the new access token does not carry stale data
+	acc, err := a.repoAccount.GetByIDOrDocument(ctx, record.AccountID)
+	if err != nil {
+		a.logger.Error(err)
+		return nil, pkgerror.ErrCantRefresh
+	}
+	if acc == nil || acc.Status == model.AccountStatusClosed {
+		_ = a.revoke(ctx, record.AccountID, map[string]string{record.SessionID: record.SessionToken})
+		return nil, pkgerror.ErrInvalidRefresh
+	}
+
+	session.Account = *acc
+	session.LastSeenAt = a.generate.CurrentTime()
+	exists, err := a.cache.SetIfExists(ctx, getSessionCacheKey(record.SessionToken), session, a.tokens.RefreshTTL)
+	if err != nil {
+		a.logger.Error(err)
+		return nil, pkgerror.ErrCantRefresh
+	}
+	if !exists {
+		return nil, pkgerror.ErrInvalidRefresh
+	}
+
+	err = a.cache.SetField(ctx, getAccountSessionsCacheKey(acc.ID), session.ID, record.SessionToken, a.tokens.RefreshTTL)
 	if err != nil {
+		a.logger.Error(err)
+	}
+
+	if session, err = a.issueTokens(ctx, *session); err != nil {
+		return nil, pkgerror.ErrCantRefresh
+	}
+	return session, nil
+}
+
+// GetSessionByToken returns the session and extends it, recording the access as its last activity.
+// In TokenModeJWT token is an access token, which is only verified: it is not renewed and its session is built from
+// its claims.
+func (a *appImpl) GetSessionByToken(ctx context.Context, token string) (*model.Session, error) {
+	if a.tokens.Mode == TokenModeJWT {
+		return a.getSessionByAccessToken(token)
+	}
+
+	session := new(model.Session)
+	if err := a.cache.Get(ctx, getSessionCacheKey(token), session); err != nil {
 		if a.cache.IsErrCacheMissing(err) {
 			return nil, pkgerror.ErrSessionNotFound
 		}
 		a.logger.Error(err)
 		return nil, pkgerror.ErrCantGetSession
 	}
+
+	// the session is only written back if it still exists, so a concurrent revocation is not undone
+	session.LastSeenAt = a.generate.CurrentTime()
+	exists, err := a.cache.SetIfExists(ctx, getSessionCacheKey(token), session, cacheExpiration)
+	if err != nil {
+		a.logger.Error(err)
+		return nil, pkgerror.ErrCantGetSession
+	}
+	if !exists {
+		return nil, pkgerror.ErrSessionNotFound
+	}
+
+	err = a.cache.SetField(ctx, getAccountSessionsCacheKey(session.Account.ID), session.ID, token, cacheExpiration)
+	if err != nil {
+		a.logger.Error(err)
+	}
 	return session, nil
 }
+
+// Logout ends the given session. In TokenModeJWT its access token stays valid until it expires.
+func (a *appImpl) Logout(ctx context.Context, session model.Session) error {
+	if a.tokens.Mode == TokenModeJWT {
+		// the access token does not key the stored session, which is found by its id instead
+		err := a.RevokeSession(ctx, session.Account.ID, session.ID)
+		if err == pkgerror.ErrSessionNotFound {
+			return nil
+		}
+		return err
+	}
+
+	err := a.revoke(ctx, session.Account.ID, map[string]string{session.ID: session.Token})
+	if err != nil {
+		return pkgerror.ErrCantRevokeSession
+	}
+	return nil
+}
+
+// ListSessions returns the active sessions of the account, newest first, flagging the one of currentSessionID.
+func (a *appImpl) ListSessions(ctx context.Context, accountID string, currentSessionID string) ([]model.SessionInfo, error) {
+	tokens, err := a.getSessionTokens(ctx, accountID)
+	if err != nil {
+		return nil, pkgerror.ErrCantListSessions
+	}
+
+	var (
+		sessions = make([]model.SessionInfo, 0, len(tokens))
+		expired  []string
+	)
+	for id, token := range tokens {
+		session := new(model.Session)
+		if err = a.cache.Get(ctx, getSessionCacheKey(token), session); err != nil {
+			if a.cache.IsErrCacheMissing(err) {
+				expired = append(expired, id)
+				continue
+			}
+			a.logger.Error(err)
+			return nil, pkgerror.ErrCantListSessions
+		}
+		sessions = append(sessions, model.SessionInfo{
+			ID:         session.ID,
+			Device:     session.Device,
+			IP:         session.IP,
+			Current:    session.ID == currentSessionID,
+			CreatedAt:  session.CreatedAt,
+			LastSeenAt: session.LastSeenAt,
+		})
+	}
+
+	if len(expired) > 0 {
+		sort.Strings(expired)
+		if err = a.cache.DeleteFields(ctx, getAccountSessionsCacheKey(accountID), expired...); err != nil {
+			a.logger.Error(err)
+		}
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		if sessions[i].CreatedAt.Equal(sessions[j].CreatedAt) {
+			return sessions[i].ID < sessions[j].ID
+		}
+		return sessions[i].CreatedAt.After(sessions[j].CreatedAt)
+	})
+	return sessions, nil
+}
+
+// RevokeSession ends one session of the account.
+func (a *appImpl) RevokeSession(ctx context.Context, accountID string, sessionID string) error {
+	tokens, err := a.getSessionTokens(ctx, accountID)
+	if err != nil {
+		return pkgerror.ErrCantRevokeSession
+	}
+	token, ok := tokens[sessionID]
+	if !ok {
+		return pkgerror.ErrSessionNotFound
+	}
+	if err = a.revoke(ctx, accountID, map[string]string{sessionID: token}); err != nil {
+		return pkgerror.ErrCantRevokeSession
+	}
+	return nil
+}
+
+// RevokeSessions ends every session of the account but exceptSessionID, which may be empty to end them all.
+func (a *appImpl) RevokeSessions(ctx context.Context, accountID string, exceptSessionID string) error {
+	tokens, err := a.getSessionTokens(ctx, accountID)
+	if err != nil {
+		return pkgerror.ErrCantRevokeSession
+	}
+	delete(tokens, exceptSessionID)
+	if err = a.revoke(ctx, accountID, tokens); err != nil {
+		return pkgerror.ErrCantRevokeSession
+	}
+	return nil
+}
+
+// ChangeSecret replaces the secret of the account once the current one is confirmed, ending all of its sessions.
+func (a *appImpl) ChangeSecret(ctx context.Context, change model.SecretChange) error {
+	if err := a.validator.Validate(change); err != nil {
+		return err
+	}
+
+	acc, err := a.repoAccount.GetByIDOrDocument(ctx, change.AccountID)
+	if err != nil {
+		a.logger.Error(err)
+		return pkgerror.ErrCantChangeSecret
+	}
+	if acc == nil {
+		return pkgerror.ErrAccountNotFound
+	}
+	if !a.secret.Verify(change.CurrentSecret, acc.Secret, acc.SecretSalt) {
+		return pkgerror.ErrInvalidSecret
+	}
+
+	if err = a.replaceSecret(ctx, acc.ID, change.NewSecret); err != nil {
+		return pkgerror.ErrCantChangeSecret
+	}
+	return a.RevokeSessions(ctx, acc.ID, "")
+}
+
+// RequestSecretReset sends a single use token to the holder of the account of document, which resets its secret
+// within the configured time. Unknown and closed accounts are ignored without an error, so the request does not
+// reveal which documents have an account.
+func (a *appImpl) RequestSecretReset(ctx context.Context, document string) error {
+	document = model.DocumentRegex.ReplaceAllString(document, "")
+	if document == "" {
+		return nil
+	}
+
+	acc, err := a.repoAccount.GetByIDOrDocument(ctx, document)
+	if err != nil {
+		a.logger.Error(err)
+		return pkgerror.ErrCantResetSecret
+	}
+	if acc == nil || acc.Status == model.AccountStatusClosed {
+		return nil
+	}
+
+	token := a.generate.UUID()
+	if err = a.cache.Set(ctx, getSecretResetCacheKey(hashToken(token)), acc.ID, a.resetTTL); err != nil {
+		a.logger.Error(err)
+		return pkgerror.ErrCantResetSecret
+	}
+
+	err = a.notifier.Notify(ctx, notifier.Message{
+		AccountID: acc.ID,
+		Recipient: acc.Document,
+		Subject:   "Secret reset",
+		Body:      fmt.Sprintf("Use the token %s to reset your secret. It expires in %s.", token, a.resetTTL),
+	})
+	if err != nil {
+		a.logger.Error(err)
+		return pkgerror.ErrCantResetSecret
+	}
+	return nil
+}
+
+// ResetSecret replaces the secret of the account the token was issued to, ending all of its sessions. The token is
+// accepted only once.
+func (a *appImpl) ResetSecret(ctx context.Context, reset model.SecretReset) error {
+	if err := a.validator.Validate(reset); err != nil {
+		return err
+	}
+
+	hash := hashToken(reset.Token)
+	var accountID string
+	if err := a.cache.Get(ctx, getSecretResetCacheKey(hash), &accountID); err != nil {
+		if a.cache.IsErrCacheMissing(err) {
+			return pkgerror.ErrInvalidResetToken
+		}
+		a.logger.Error(err)
+		return pkgerror.ErrCantResetSecret
+	}
+
+	firstUse, err := a.cache.SetIfNotExists(ctx, getSecretResetUsedCacheKey(hash), true, a.resetTTL)
+	if err != nil {
+		a.logger.Error(err)
+		return pkgerror.ErrCantResetSecret
+	}
+	if !firstUse {
+		return pkgerror.ErrInvalidResetToken
+	}
+	if err = a.cache.Delete(ctx, getSecretResetCacheKey(hash)); err != nil {
+		a.logger.Error(err)
+	}
+
+	acc, err := a.repoAccount.GetByIDOrDocument(ctx, accountID)
+	if err != nil {
+		a.logger.Error(err)
+		return pkgerror.ErrCantResetSecret
+	}
+	if acc == nil || acc.Status == model.AccountStatusClosed {
+		return pkgerror.ErrInvalidResetToken
+	}
+
+	if err = a.replaceSecret(ctx, acc.ID, reset.NewSecret); err != nil {
+		return pkgerror.ErrCantResetSecret
+	}
+	return a.RevokeSessions(ctx, acc.ID, "")
+}
+
+func (a *appImpl) replaceSecret(ctx context.Context, accountID string, newSecret string) error {
+	encoded, err := a.secret.Encode(newSecret)
+	if err != nil {
+		a.logger.Error(err)
+		return err
+	}
+	if err = a.repoAccount.UpdateSecret(ctx, accountID, encoded); err != nil {
+		a.logger.Error(err)
+		return err
+	}
+	return nil
+}
+
+// getSessionTokens returns the tokens of the sessions of the account, by session id. Sessions that expired may
+// still be listed.
+func (a *appImpl) getSessionTokens(ctx context.Context, accountID string) (map[string]string, error) {
+	tokens := make(map[string]string)
+	if err := a.cache.GetFields(ctx, getAccountSessionsCacheKey(accountID), &tokens); err != nil {
+		a.logger.Error(err)
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// revoke deletes the sessions, given as tokens by session id, and removes them from the account index.
+func (a *appImpl) revoke(ctx context.Context, accountID string, tokens map[string]string) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(tokens))
+	for id, token := range tokens {
+		if err := a.cache.Delete(ctx, getSessionCacheKey(token)); err != nil {
+			a.logger.Error(err)
+			return err
+		}
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	if err := a.cache.DeleteFields(ctx, getAccountSessionsCacheKey(accountID), ids...); err != nil {
+		a.logger.Error(err)
+		return err
+	}
+	return nil
+}
+
+// issueTokens returns the session with a new access token and a new refresh token.
+func (a *appImpl) issueTokens(ctx context.Context, session model.Session) (*model.Session, error) {
+	now := a.generate.CurrentTime()
+	expiresAt := now.Add(a.tokens.AccessTTL)
+	accessToken, err := a.tokens.JWT.Sign(jwt.Claims{
+		ID:        a.generate.UUID(),
+		AccountID: session.Account.ID,
+		SessionID: session.ID,
+		Name:      session.Account.Name,
+		Role:      session.Account.Role,
+		IssuedAt:  now,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		a.logger.Error(err)
+		return nil, err
+	}
+
+	refreshToken := a.generate.UUID()
+	record := refreshRecord{
+		AccountID:    session.Account.ID,
+		SessionID:    session.ID,
+		SessionToken: session.Token,
+	}
+	err = a.cache.Set(ctx, getRefreshCacheKey(hashToken(refreshToken)), record, a.tokens.RefreshTTL)
+	if err != nil {
+		a.logger.Error(err)
+		return nil, err
+	}
+
+	session.Token = accessToken
+	session.RefreshToken = refreshToken
+	session.ExpiresAt = &expiresAt
+	return &session, nil
+}
+
+func (a *appImpl) getSessionByAccessToken(token string) (*model.Session, error) {
+	claims, err := a.tokens.JWT.Parse(token)
+	if err != nil {
+		return nil, pkgerror.ErrSessionNotFound
+	}
+	return &model.Session{
+		ID:        claims.SessionID,
+		Token:     token,
+		ExpiresAt: &claims.ExpiresAt,
+		Account: model.Account{
+			ID:   claims.AccountID,
+			Name: claims.Name,
+			Role: claims.Role,
+		},
+	}, nil
+}
+
+func (a *appImpl) getSessionExpiration() time.Duration {
+	if a.tokens.Mode == TokenModeJWT {
+		return a.tokens.RefreshTTL
+	}
+	return cacheExpiration
+}
+
+// checkLockout refuses the login while the document or the ip is locked by previous failures.
+func (a *appImpl) checkLockout(ctx context.Context, credentials model.Credentials) error {
+	locked, err := a.isLocked(ctx, fmt.Sprintf(cacheKeyDocumentLock, credentials.Document))
+	if err != nil {
+		return pkgerror.ErrCantAuth
+	}
+	if locked {
+		return pkgerror.ErrAccountLocked
+	}
+	if credentials.IP == "" {
+		return nil
+	}
+
+	locked, err = a.isLocked(ctx, fmt.Sprintf(cacheKeyIPLock, credentials.IP))
+	if err != nil {
+		return pkgerror.ErrCantAuth
+	}
+	if locked {
+		return pkgerror.ErrTooManyAttempts
+	}
+	return nil
+}
+
+func (a *appImpl) isLocked(ctx context.Context, key string) (bool, error) {
+	var locked bool
+	if err := a.cache.Get(ctx, key, &locked); err != nil {
+		if a.cache.IsErrCacheMissing(err) {
+			return false, nil
+		}
+		a.logger.Error(err)
+		return false, err
+	}
+	return locked, nil
+}
+
+// registerFailure audits a login refused for invalid credentials or second factor and counts it against the document
+// and the ip, locking them once they go past the allowed attempts. Failures here are only logged, the login is
+// refused anyway.
+func (a *appImpl) registerFailure(ctx context.Context, credentials model.Credentials, accountID *string, reason error) {
+	a.audit(ctx, credentials, accountID, reason)
+
+	a.countFailure(
+		ctx,
+		fmt.Sprintf(cacheKeyDocumentFailures, credentials.Document),
+		fmt.Sprintf(cacheKeyDocumentLock, credentials.Document),
+		a.lockout.MaxAttempts,
+	)
+	if credentials.IP != "" {
+		a.countFailure(
+			ctx,
+			fmt.Sprintf(cacheKeyIPFailures, credentials.IP),
+			fmt.Sprintf(cacheKeyIPLock, credentials.IP),
+			a.lockout.MaxAttemptsPerIP,
+		)
+	}
+}
+
+func (a *appImpl) countFailure(ctx context.Context, failuresKey string, lockKey string, maxAttempts int64) {
+	failures, err := a.cache.Increment(ctx, failuresKey, a.lockout.Window)
+	if err != nil {
+		a.logger.Error(err)
+		return
+	}
+	if failures < maxAttempts {
+		return
+	}
+
+	d := getLockoutDuration(a.lockout.Duration, a.lockout.MaxDuration, failures-maxAttempts)
+	if err = a.cache.Set(ctx, lockKey, true, d); err != nil {
+		a.logger.Error(err)
+	}
+}
+
+func (a *appImpl) audit(ctx context.Context, credentials model.Credentials, accountID *string, reason error) {
+	err := a.repoAudit.Create(ctx, model.AuditEntry{
+		Action:    model.AuditActionLoginFailed,
+		AccountID: accountID,
+		Document:  credentials.Document,
+		IP:        credentials.IP,
+		Reason:    reason.Error(),
+	})
+	if err != nil {
+		a.logger.Error(err)
+	}
+}
+
+// rehashSecret stores the secret again with the current algorithm and parameters, so accounts migrate as they sign
+// in. A failure here must not prevent the login, the next one will try again.
+func (a *appImpl) rehashSecret(ctx context.Context, accountID string, decoded string) {
+	_ = a.replaceSecret(ctx, accountID, decoded)
+}
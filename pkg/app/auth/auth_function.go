@@ -1,7 +1,49 @@
 package auth
 
-import "fmt"
+import (
+	"crypto/sha256"
+	"fmt"
+	"time"
+)
 
 func getSessionCacheKey(token string) string {
 	return fmt.Sprintf(cacheKeySession, token)
 }
+
+func getAccountSessionsCacheKey(accountID string) string {
+	return fmt.Sprintf(cacheKeyAccountSessions, accountID)
+}
+
+func getRefreshCacheKey(hash string) string {
+	return fmt.Sprintf(cacheKeyRefresh, hash)
+}
+
+func getRefreshUsedCacheKey(hash string) string {
+	return fmt.Sprintf(cacheKeyRefreshUsed, hash)
+}
+
+func getSecretResetCacheKey(hash string) string {
+	return fmt.Sprintf(cacheKeySecretReset, hash)
+}
+
+func getSecretResetUsedCacheKey(hash string) string {
+	return fmt.Sprintf(cacheKeySecretResetUsed, hash)
+}
+
+// hashToken returns the key of a refresh or secret reset token in the cache, so the cache does not hold usable
+// tokens.
+func hashToken(token string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(token)))
+}
+
+// getLockoutDuration doubles the base duration for every failure beyond the allowed ones, up to max.
+func getLockoutDuration(base, max time.Duration, excessFailures int64) time.Duration {
+	d := base
+	for i := int64(0); i < excessFailures && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		return max
+	}
+	return d
+}
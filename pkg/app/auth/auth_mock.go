@@ -50,6 +50,20 @@ func (mr *MockAppMockRecorder) Auth(ctx, credentials interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Auth", reflect.TypeOf((*MockApp)(nil).Auth), ctx, credentials)
 }
 
+// ChangeSecret mocks base method.
+func (m *MockApp) ChangeSecret(ctx context.Context, change model.SecretChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeSecret", ctx, change)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeSecret indicates an expected call of ChangeSecret.
+func (mr *MockAppMockRecorder) ChangeSecret(ctx, change interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeSecret", reflect.TypeOf((*MockApp)(nil).ChangeSecret), ctx, change)
+}
+
 // GetSessionByToken mocks base method.
 func (m *MockApp) GetSessionByToken(ctx context.Context, token string) (*model.Session, error) {
 	m.ctrl.T.Helper()
@@ -64,3 +78,103 @@ func (mr *MockAppMockRecorder) GetSessionByToken(ctx, token interface{}) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionByToken", reflect.TypeOf((*MockApp)(nil).GetSessionByToken), ctx, token)
 }
+
+// ListSessions mocks base method.
+func (m *MockApp) ListSessions(ctx context.Context, accountID, currentSessionID string) ([]model.SessionInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions", ctx, accountID, currentSessionID)
+	ret0, _ := ret[0].([]model.SessionInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockAppMockRecorder) ListSessions(ctx, accountID, currentSessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockApp)(nil).ListSessions), ctx, accountID, currentSessionID)
+}
+
+// Logout mocks base method.
+func (m *MockApp) Logout(ctx context.Context, session model.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", ctx, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockAppMockRecorder) Logout(ctx, session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockApp)(nil).Logout), ctx, session)
+}
+
+// Refresh mocks base method.
+func (m *MockApp) Refresh(ctx context.Context, refreshToken string) (*model.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx, refreshToken)
+	ret0, _ := ret[0].(*model.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockAppMockRecorder) Refresh(ctx, refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockApp)(nil).Refresh), ctx, refreshToken)
+}
+
+// RequestSecretReset mocks base method.
+func (m *MockApp) RequestSecretReset(ctx context.Context, document string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestSecretReset", ctx, document)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestSecretReset indicates an expected call of RequestSecretReset.
+func (mr *MockAppMockRecorder) RequestSecretReset(ctx, document interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestSecretReset", reflect.TypeOf((*MockApp)(nil).RequestSecretReset), ctx, document)
+}
+
+// ResetSecret mocks base method.
+func (m *MockApp) ResetSecret(ctx context.Context, reset model.SecretReset) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetSecret", ctx, reset)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetSecret indicates an expected call of ResetSecret.
+func (mr *MockAppMockRecorder) ResetSecret(ctx, reset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetSecret", reflect.TypeOf((*MockApp)(nil).ResetSecret), ctx, reset)
+}
+
+// RevokeSession mocks base method.
+func (m *MockApp) RevokeSession(ctx context.Context, accountID, sessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, accountID, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockAppMockRecorder) RevokeSession(ctx, accountID, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockApp)(nil).RevokeSession), ctx, accountID, sessionID)
+}
+
+// RevokeSessions mocks base method.
+func (m *MockApp) RevokeSessions(ctx context.Context, accountID, exceptSessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSessions", ctx, accountID, exceptSessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSessions indicates an expected call of RevokeSessions.
+func (mr *MockAppMockRecorder) RevokeSessions(ctx, accountID, exceptSessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSessions", reflect.TypeOf((*MockApp)(nil).RevokeSessions), ctx, accountID, exceptSessionID)
+}
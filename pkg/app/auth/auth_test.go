@@ -4,12 +4,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/carlosrodriguesf/bank-api/pkg/app/twofactor"
 	pkgerror "github.com/carlosrodriguesf/bank-api/pkg/error"
 	"github.com/carlosrodriguesf/bank-api/pkg/model"
 	"github.com/carlosrodriguesf/bank-api/pkg/repository/account"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/audit"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/cache"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/generate"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/jwt"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/notifier"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/secret"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/validator"
 	"github.com/golang/mock/gomock"
@@ -23,9 +27,14 @@ func TestAuth(t *testing.T) {
 	var (
 		currentTime        = time.Now()
 		uuidExample        = uuid.NewString()
+		errCacheMissing    = errors.New("cache missing")
+		document           = "12312312312"
+		ip                 = "192.0.2.1"
 		credentialsExample = model.Credentials{
 			Document: "123.123.123-12",
 			Secret:   "secret",
+			IP:       ip,
+			Device:   "test-agent",
 		}
 		accountExample = model.Account{
 			ID:        "account_id",
@@ -34,6 +43,7 @@ func TestAuth(t *testing.T) {
 			CreatedAt: currentTime,
 		}
 		sessionExample = model.Session{
+			ID:    uuidExample,
 			Token: uuidExample,
 			Account: model.Account{
 				ID:        accountExample.ID,
@@ -41,7 +51,54 @@ func TestAuth(t *testing.T) {
 				Document:  accountExample.Document,
 				CreatedAt: accountExample.CreatedAt,
 			},
-			CreatedAt: currentTime,
+			Device:     credentialsExample.Device,
+			IP:         ip,
+			CreatedAt:  currentTime,
+			LastSeenAt: currentTime,
+		}
+		lockout = LockoutConfig{
+			MaxAttempts:      3,
+			MaxAttemptsPerIP: 10,
+			Duration:         time.Minute,
+			MaxDuration:      time.Hour,
+			Window:           24 * time.Hour,
+		}
+		auditEntry = func(accountID *string, reason error) model.AuditEntry {
+			return model.AuditEntry{
+				Action:    model.AuditActionLoginFailed,
+				AccountID: accountID,
+				Document:  document,
+				IP:        ip,
+				Reason:    reason.Error(),
+			}
+		}
+		expectNotLocked = func(mock *cache.MockCache) {
+			mock.EXPECT().
+				Get(gomock.Any(), fmt.Sprintf(cacheKeyDocumentLock, document), gomock.Any()).
+				Return(errCacheMissing)
+			mock.EXPECT().
+				Get(gomock.Any(), fmt.Sprintf(cacheKeyIPLock, ip), gomock.Any()).
+				Return(errCacheMissing)
+			mock.EXPECT().IsErrCacheMissing(errCacheMissing).Times(2).Return(true)
+		}
+		expectFailures = func(mock *cache.MockCache, documentFailures int64, ipFailures int64) {
+			mock.EXPECT().
+				Increment(gomock.Any(), fmt.Sprintf(cacheKeyDocumentFailures, document), lockout.Window).
+				Return(documentFailures, nil)
+			mock.EXPECT().
+				Increment(gomock.Any(), fmt.Sprintf(cacheKeyIPFailures, ip), lockout.Window).
+				Return(ipFailures, nil)
+		}
+		expectSession = func(mock *cache.MockCache) {
+			mock.EXPECT().
+				Delete(gomock.Any(), fmt.Sprintf(cacheKeyDocumentFailures, document)).
+				Return(nil)
+			mock.EXPECT().
+				SetField(gomock.Any(), getAccountSessionsCacheKey(accountExample.ID), uuidExample, uuidExample, cacheExpiration).
+				Return(nil)
+			mock.EXPECT().
+				Set(gomock.Any(), getSessionCacheKey(sessionExample.Token), &sessionExample, cacheExpiration).
+				Return(nil)
 		}
 	)
 
@@ -52,7 +109,9 @@ func TestAuth(t *testing.T) {
 		PrepareMockValidator  func(mock *validator.MockValidator)
 		PrepareMockSecret     func(mock *secret.MockSecret)
 		PrepareMockRepository func(mock *account.MockRepository)
+		PrepareMockRepoAudit  func(mock *audit.MockRepository)
 		PrepareMockCache      func(mock *cache.MockCache)
+		PrepareMockTwoFactor  func(mock *twofactor.MockApp)
 	}{
 		"should return success": {
 			InputData:     credentialsExample,
@@ -65,18 +124,77 @@ func TestAuth(t *testing.T) {
 				mock.EXPECT().
 					Verify(credentialsExample.Secret, accountExample.Secret, accountExample.SecretSalt).
 					Return(true)
+				mock.EXPECT().NeedsRehash(accountExample.Secret).Return(false)
 			},
 			PrepareMockRepository: func(mock *account.MockRepository) {
-				document := model.DocumentRegex.ReplaceAllString(accountExample.Document, "")
 				mock.EXPECT().
 					GetByIDOrDocument(gomock.Any(), document).
 					Return(&accountExample, nil)
 			},
+			PrepareMockRepoAudit: func(mock *audit.MockRepository) {
+			},
 			PrepareMockCache: func(mock *cache.MockCache) {
+				expectNotLocked(mock)
+				expectSession(mock)
+			},
+		},
+		"should return success rehashing secret": {
+			InputData:     credentialsExample,
+			ExpectedData:  &sessionExample,
+			ExpectedError: nil,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(credentialsExample).Return(nil)
+			},
+			PrepareMockSecret: func(mock *secret.MockSecret) {
 				mock.EXPECT().
-					Set(gomock.Any(), getSessionCacheKey(sessionExample.Token), &sessionExample, cacheExpiration).
+					Verify(credentialsExample.Secret, accountExample.Secret, accountExample.SecretSalt).
+					Return(true)
+				mock.EXPECT().NeedsRehash(accountExample.Secret).Return(true)
+				mock.EXPECT().Encode(credentialsExample.Secret).Return("rehashed_secret", nil)
+			},
+			PrepareMockRepository: func(mock *account.MockRepository) {
+				mock.EXPECT().
+					GetByIDOrDocument(gomock.Any(), document).
+					Return(&accountExample, nil)
+				mock.EXPECT().
+					UpdateSecret(gomock.Any(), accountExample.ID, "rehashed_secret").
 					Return(nil)
 			},
+			PrepareMockRepoAudit: func(mock *audit.MockRepository) {
+			},
+			PrepareMockCache: func(mock *cache.MockCache) {
+				expectNotLocked(mock)
+				expectSession(mock)
+			},
+		},
+		"should return success when rehash fails": {
+			InputData:     credentialsExample,
+			ExpectedData:  &sessionExample,
+			ExpectedError: nil,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(credentialsExample).Return(nil)
+			},
+			PrepareMockSecret: func(mock *secret.MockSecret) {
+				mock.EXPECT().
+					Verify(credentialsExample.Secret, accountExample.Secret, accountExample.SecretSalt).
+					Return(true)
+				mock.EXPECT().NeedsRehash(accountExample.Secret).Return(true)
+				mock.EXPECT().Encode(credentialsExample.Secret).Return("rehashed_secret", nil)
+			},
+			PrepareMockRepository: func(mock *account.MockRepository) {
+				mock.EXPECT().
+					GetByIDOrDocument(gomock.Any(), document).
+					Return(&accountExample, nil)
+				mock.EXPECT().
+					UpdateSecret(gomock.Any(), accountExample.ID, "rehashed_secret").
+					Return(errors.New("fail"))
+			},
+			PrepareMockRepoAudit: func(mock *audit.MockRepository) {
+			},
+			PrepareMockCache: func(mock *cache.MockCache) {
+				expectNotLocked(mock)
+				expectSession(mock)
+			},
 		},
 		"should return validation error": {
 			InputData:     credentialsExample,
@@ -89,7 +207,77 @@ func TestAuth(t *testing.T) {
 			},
 			PrepareMockRepository: func(mock *account.MockRepository) {
 			},
+			PrepareMockRepoAudit: func(mock *audit.MockRepository) {
+			},
+			PrepareMockCache: func(mock *cache.MockCache) {
+			},
+		},
+		"should return error: account locked": {
+			InputData:     credentialsExample,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrAccountLocked,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(credentialsExample).Return(nil)
+			},
+			PrepareMockSecret: func(mock *secret.MockSecret) {
+			},
+			PrepareMockRepository: func(mock *account.MockRepository) {
+			},
+			PrepareMockRepoAudit: func(mock *audit.MockRepository) {
+				mock.EXPECT().Create(gomock.Any(), auditEntry(nil, pkgerror.ErrAccountLocked)).Return(nil)
+			},
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().
+					Get(gomock.Any(), fmt.Sprintf(cacheKeyDocumentLock, document), gomock.Any()).
+					Do(func(_ context.Context, _ string, value *bool) {
+						*value = true
+					})
+			},
+		},
+		"should return error: too many attempts": {
+			InputData:     credentialsExample,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrTooManyAttempts,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(credentialsExample).Return(nil)
+			},
+			PrepareMockSecret: func(mock *secret.MockSecret) {
+			},
+			PrepareMockRepository: func(mock *account.MockRepository) {
+			},
+			PrepareMockRepoAudit: func(mock *audit.MockRepository) {
+				mock.EXPECT().Create(gomock.Any(), auditEntry(nil, pkgerror.ErrTooManyAttempts)).Return(nil)
+			},
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().
+					Get(gomock.Any(), fmt.Sprintf(cacheKeyDocumentLock, document), gomock.Any()).
+					Return(errCacheMissing)
+				mock.EXPECT().IsErrCacheMissing(errCacheMissing).Return(true)
+				mock.EXPECT().
+					Get(gomock.Any(), fmt.Sprintf(cacheKeyIPLock, ip), gomock.Any()).
+					Do(func(_ context.Context, _ string, value *bool) {
+						*value = true
+					})
+			},
+		},
+		"should return error on check lockout": {
+			InputData:     credentialsExample,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantAuth,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(credentialsExample).Return(nil)
+			},
+			PrepareMockSecret: func(mock *secret.MockSecret) {
+			},
+			PrepareMockRepository: func(mock *account.MockRepository) {
+			},
+			PrepareMockRepoAudit: func(mock *audit.MockRepository) {
+			},
 			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().
+					Get(gomock.Any(), fmt.Sprintf(cacheKeyDocumentLock, document), gomock.Any()).
+					Return(errors.New("fail"))
+				mock.EXPECT().IsErrCacheMissing(errors.New("fail")).Return(false)
 			},
 		},
 		"should return error on get account": {
@@ -102,12 +290,14 @@ func TestAuth(t *testing.T) {
 			PrepareMockSecret: func(mock *secret.MockSecret) {
 			},
 			PrepareMockRepository: func(mock *account.MockRepository) {
-				document := model.DocumentRegex.ReplaceAllString(accountExample.Document, "")
 				mock.EXPECT().
 					GetByIDOrDocument(gomock.Any(), document).
 					Return(nil, errors.New("fail"))
 			},
+			PrepareMockRepoAudit: func(mock *audit.MockRepository) {
+			},
 			PrepareMockCache: func(mock *cache.MockCache) {
+				expectNotLocked(mock)
 			},
 		},
 		"should return error: document invalid": {
@@ -120,12 +310,16 @@ func TestAuth(t *testing.T) {
 			PrepareMockSecret: func(mock *secret.MockSecret) {
 			},
 			PrepareMockRepository: func(mock *account.MockRepository) {
-				document := model.DocumentRegex.ReplaceAllString(accountExample.Document, "")
 				mock.EXPECT().
 					GetByIDOrDocument(gomock.Any(), document).
 					Return(nil, nil)
 			},
+			PrepareMockRepoAudit: func(mock *audit.MockRepository) {
+				mock.EXPECT().Create(gomock.Any(), auditEntry(nil, pkgerror.ErrInvalidCredentials)).Return(nil)
+			},
 			PrepareMockCache: func(mock *cache.MockCache) {
+				expectNotLocked(mock)
+				expectFailures(mock, 1, 1)
 			},
 		},
 		"should return error: password invalid": {
@@ -141,139 +335,1446 @@ func TestAuth(t *testing.T) {
 					Return(false)
 			},
 			PrepareMockRepository: func(mock *account.MockRepository) {
-				document := model.DocumentRegex.ReplaceAllString(accountExample.Document, "")
 				mock.EXPECT().
 					GetByIDOrDocument(gomock.Any(), document).
 					Return(&accountExample, nil)
 			},
+			PrepareMockRepoAudit: func(mock *audit.MockRepository) {
+				mock.EXPECT().
+					Create(gomock.Any(), auditEntry(&accountExample.ID, pkgerror.ErrInvalidCredentials)).
+					Return(nil)
+			},
 			PrepareMockCache: func(mock *cache.MockCache) {
+				expectNotLocked(mock)
+				expectFailures(mock, 2, 2)
 			},
 		},
-		"should return error on save session": {
+		"should return error: password invalid when audit fails": {
 			InputData:     credentialsExample,
 			ExpectedData:  nil,
-			ExpectedError: pkgerror.ErrCantAuth,
+			ExpectedError: pkgerror.ErrInvalidCredentials,
 			PrepareMockValidator: func(mock *validator.MockValidator) {
 				mock.EXPECT().Validate(credentialsExample).Return(nil)
 			},
 			PrepareMockSecret: func(mock *secret.MockSecret) {
 				mock.EXPECT().
 					Verify(credentialsExample.Secret, accountExample.Secret, accountExample.SecretSalt).
-					Return(true)
+					Return(false)
 			},
 			PrepareMockRepository: func(mock *account.MockRepository) {
-				document := model.DocumentRegex.ReplaceAllString(accountExample.Document, "")
 				mock.EXPECT().
 					GetByIDOrDocument(gomock.Any(), document).
 					Return(&accountExample, nil)
 			},
-			PrepareMockCache: func(mock *cache.MockCache) {
+			PrepareMockRepoAudit: func(mock *audit.MockRepository) {
 				mock.EXPECT().
-					Set(gomock.Any(), fmt.Sprintf(cacheKeySession, sessionExample.Token), &sessionExample, cacheExpiration).
+					Create(gomock.Any(), auditEntry(&accountExample.ID, pkgerror.ErrInvalidCredentials)).
 					Return(errors.New("fail"))
 			},
+			PrepareMockCache: func(mock *cache.MockCache) {
+				expectNotLocked(mock)
+				expectFailures(mock, 1, 1)
+			},
 		},
-	}
-
-	for name, cs := range cases {
-		t.Run(name, func(t *testing.T) {
-			var (
-				ctrl, ctx      = gomock.WithContext(context.Background(), t)
-				mockCache      = cache.NewMockCache(ctrl)
-				mockSecret     = secret.NewMockSecret(ctrl)
-				mockValidator  = validator.NewMockValidator(ctrl)
-				mockRepository = account.NewMockRepository(ctrl)
-				mockGenerate   = generate.NewMockGenerate(ctrl)
-			)
-
-			cs.PrepareMockSecret(mockSecret)
-			cs.PrepareMockValidator(mockValidator)
-			cs.PrepareMockRepository(mockRepository)
-			cs.PrepareMockCache(mockCache)
-
-			mockGenerate.EXPECT().UUID().AnyTimes().Return(uuidExample)
-			mockGenerate.EXPECT().CurrentTime().AnyTimes().Return(currentTime)
-
-			app := NewApp(Options{
-				Logger:      logger.New(""),
-				Secret:      mockSecret,
-				Cache:       mockCache,
-				Validator:   mockValidator,
-				RepoAccount: mockRepository,
-				Generate:    mockGenerate,
-			})
-
-			data, err := app.Auth(ctx, cs.InputData)
-
-			assert.Equal(t, cs.ExpectedData, data)
-			assert.Equal(t, cs.ExpectedError, err)
-		})
-	}
-}
-
-func TestGetSessionByToken(t *testing.T) {
-	var (
-		currentTime    = time.Now()
-		uuidExample    = uuid.NewString()
-		sessionExample = model.Session{
-			Token: uuidExample,
-			Account: model.Account{
-				ID:        "account_id",
-				Name:      "John Doe",
-				Document:  "123.123.123-12",
-				CreatedAt: currentTime,
+		"should lock document after too many failures": {
+			InputData:     credentialsExample,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrInvalidCredentials,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(credentialsExample).Return(nil)
+			},
+			PrepareMockSecret: func(mock *secret.MockSecret) {
+				mock.EXPECT().
+					Verify(credentialsExample.Secret, accountExample.Secret, accountExample.SecretSalt).
+					Return(false)
+			},
+			PrepareMockRepository: func(mock *account.MockRepository) {
+				mock.EXPECT().
+					GetByIDOrDocument(gomock.Any(), document).
+					Return(&accountExample, nil)
+			},
+			PrepareMockRepoAudit: func(mock *audit.MockRepository) {
+				mock.EXPECT().
+					Create(gomock.Any(), auditEntry(&accountExample.ID, pkgerror.ErrInvalidCredentials)).
+					Return(nil)
 			},
-			CreatedAt: currentTime,
-		}
-	)
-
-	cases := map[string]struct {
-		InputData        string
-		ExpectedData     *model.Session
-		ExpectedError    error
-		PrepareMockCache func(mock *cache.MockCache)
-	}{
-		"should return success": {
-			InputData:     uuidExample,
-			ExpectedData:  &sessionExample,
-			ExpectedError: nil,
 			PrepareMockCache: func(mock *cache.MockCache) {
+				expectNotLocked(mock)
+				expectFailures(mock, 5, 5)
 				mock.EXPECT().
-					GetUpdating(gomock.Any(), getSessionCacheKey(uuidExample), new(model.Session), cacheExpiration).
-					Do(func(_, _ interface{}, session *model.Session, _ time.Duration) {
-						*session = sessionExample
-					})
+					Set(gomock.Any(), fmt.Sprintf(cacheKeyDocumentLock, document), true, 4*time.Minute).
+					Return(nil)
 			},
 		},
-		"should return cache missing error": {
-			InputData:     uuidExample,
+		"should lock ip after too many failures": {
+			InputData:     credentialsExample,
 			ExpectedData:  nil,
-			ExpectedError: pkgerror.ErrSessionNotFound,
+			ExpectedError: pkgerror.ErrInvalidCredentials,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(credentialsExample).Return(nil)
+			},
+			PrepareMockSecret: func(mock *secret.MockSecret) {
+			},
+			PrepareMockRepository: func(mock *account.MockRepository) {
+				mock.EXPECT().
+					GetByIDOrDocument(gomock.Any(), document).
+					Return(nil, nil)
+			},
+			PrepareMockRepoAudit: func(mock *audit.MockRepository) {
+				mock.EXPECT().Create(gomock.Any(), auditEntry(nil, pkgerror.ErrInvalidCredentials)).Return(nil)
+			},
 			PrepareMockCache: func(mock *cache.MockCache) {
+				expectNotLocked(mock)
+				expectFailures(mock, 1, 10)
 				mock.EXPECT().
-					GetUpdating(gomock.Any(), getSessionCacheKey(uuidExample), new(model.Session), cacheExpiration).
-					Return(errors.New("cache missing"))
+					Set(gomock.Any(), fmt.Sprintf(cacheKeyIPLock, ip), true, time.Minute).
+					Return(nil)
+			},
+		},
+		"should return error: account closed": {
+			InputData:     credentialsExample,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrAccountClosed,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(credentialsExample).Return(nil)
+			},
+			PrepareMockSecret: func(mock *secret.MockSecret) {
 				mock.EXPECT().
-					IsErrCacheMissing(errors.New("cache missing")).
+					Verify(credentialsExample.Secret, accountExample.Secret, accountExample.SecretSalt).
 					Return(true)
 			},
+			PrepareMockRepository: func(mock *account.MockRepository) {
+				closedAccount := accountExample
+				closedAccount.Status = model.AccountStatusClosed
+				mock.EXPECT().
+					GetByIDOrDocument(gomock.Any(), document).
+					Return(&closedAccount, nil)
+			},
+			PrepareMockRepoAudit: func(mock *audit.MockRepository) {
+				mock.EXPECT().
+					Create(gomock.Any(), auditEntry(&accountExample.ID, pkgerror.ErrAccountClosed)).
+					Return(nil)
+			},
+			PrepareMockCache: func(mock *cache.MockCache) {
+				expectNotLocked(mock)
+			},
 		},
-		"should return cant get session error": {
-			InputData:     uuidExample,
+		"should return error on save session": {
+			InputData:     credentialsExample,
 			ExpectedData:  nil,
-			ExpectedError: pkgerror.ErrCantGetSession,
-			PrepareMockCache: func(mock *cache.MockCache) {
+			ExpectedError: pkgerror.ErrCantAuth,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(credentialsExample).Return(nil)
+			},
+			PrepareMockSecret: func(mock *secret.MockSecret) {
 				mock.EXPECT().
-					GetUpdating(gomock.Any(), getSessionCacheKey(uuidExample), new(model.Session), cacheExpiration).
-					Return(errors.New("cache missing"))
+					Verify(credentialsExample.Secret, accountExample.Secret, accountExample.SecretSalt).
+					Return(true)
+				mock.EXPECT().NeedsRehash(accountExample.Secret).Return(false)
+			},
+			PrepareMockRepository: func(mock *account.MockRepository) {
 				mock.EXPECT().
-					IsErrCacheMissing(errors.New("cache missing")).
-					Return(false)
+					GetByIDOrDocument(gomock.Any(), document).
+					Return(&accountExample, nil)
 			},
-		},
-	}
-
-	for name, cs := range cases {
+			PrepareMockRepoAudit: func(mock *audit.MockRepository) {
+			},
+			PrepareMockCache: func(mock *cache.MockCache) {
+				expectNotLocked(mock)
+				mock.EXPECT().
+					Delete(gomock.Any(), fmt.Sprintf(cacheKeyDocumentFailures, document)).
+					Return(nil)
+				mock.EXPECT().
+					SetField(gomock.Any(), getAccountSessionsCacheKey(accountExample.ID), uuidExample, uuidExample, cacheExpiration).
+					Return(nil)
+				mock.EXPECT().
+					Set(gomock.Any(), fmt.Sprintf(cacheKeySession, sessionExample.Token), &sessionExample, cacheExpiration).
+					Return(errors.New("fail"))
+			},
+		},
+		"should return error on index session": {
+			InputData:     credentialsExample,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantAuth,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(credentialsExample).Return(nil)
+			},
+			PrepareMockSecret: func(mock *secret.MockSecret) {
+				mock.EXPECT().
+					Verify(credentialsExample.Secret, accountExample.Secret, accountExample.SecretSalt).
+					Return(true)
+				mock.EXPECT().NeedsRehash(accountExample.Secret).Return(false)
+			},
+			PrepareMockRepository: func(mock *account.MockRepository) {
+				mock.EXPECT().
+					GetByIDOrDocument(gomock.Any(), document).
+					Return(&accountExample, nil)
+			},
+			PrepareMockRepoAudit: func(mock *audit.MockRepository) {
+			},
+			PrepareMockCache: func(mock *cache.MockCache) {
+				expectNotLocked(mock)
+				mock.EXPECT().
+					Delete(gomock.Any(), fmt.Sprintf(cacheKeyDocumentFailures, document)).
+					Return(nil)
+				mock.EXPECT().
+					SetField(gomock.Any(), getAccountSessionsCacheKey(accountExample.ID), uuidExample, uuidExample, cacheExpiration).
+					Return(errors.New("fail"))
+			},
+		},
+		"should return error: two factor required": {
+			InputData:     credentialsExample,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrTwoFactorRequired,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(credentialsExample).Return(nil)
+			},
+			PrepareMockSecret: func(mock *secret.MockSecret) {
+				mock.EXPECT().
+					Verify(credentialsExample.Secret, accountExample.Secret, accountExample.SecretSalt).
+					Return(true)
+			},
+			PrepareMockRepository: func(mock *account.MockRepository) {
+				mock.EXPECT().
+					GetByIDOrDocument(gomock.Any(), document).
+					Return(&accountExample, nil)
+			},
+			PrepareMockRepoAudit: func(mock *audit.MockRepository) {
+			},
+			PrepareMockCache: func(mock *cache.MockCache) {
+				expectNotLocked(mock)
+			},
+			PrepareMockTwoFactor: func(mock *twofactor.MockApp) {
+				mock.EXPECT().Verify(gomock.Any(), accountExample.ID, "").Return(pkgerror.ErrTwoFactorRequired)
+			},
+		},
+		"should return error and count failure: invalid two factor code": {
+			InputData:     credentialsExample,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrInvalidTwoFactorCode,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(credentialsExample).Return(nil)
+			},
+			PrepareMockSecret: func(mock *secret.MockSecret) {
+				mock.EXPECT().
+					Verify(credentialsExample.Secret, accountExample.Secret, accountExample.SecretSalt).
+					Return(true)
+			},
+			PrepareMockRepository: func(mock *account.MockRepository) {
+				mock.EXPECT().
+					GetByIDOrDocument(gomock.Any(), document).
+					Return(&accountExample, nil)
+			},
+			PrepareMockRepoAudit: func(mock *audit.MockRepository) {
+				mock.EXPECT().
+					Create(gomock.Any(), auditEntry(&accountExample.ID, pkgerror.ErrInvalidTwoFactorCode)).
+					Return(nil)
+			},
+			PrepareMockCache: func(mock *cache.MockCache) {
+				expectNotLocked(mock)
+				expectFailures(mock, 1, 1)
+			},
+			PrepareMockTwoFactor: func(mock *twofactor.MockApp) {
+				mock.EXPECT().Verify(gomock.Any(), accountExample.ID, "").Return(pkgerror.ErrInvalidTwoFactorCode)
+			},
+		},
+		"should return error on verify two factor": {
+			InputData:     credentialsExample,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantAuth,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(credentialsExample).Return(nil)
+			},
+			PrepareMockSecret: func(mock *secret.MockSecret) {
+				mock.EXPECT().
+					Verify(credentialsExample.Secret, accountExample.Secret, accountExample.SecretSalt).
+					Return(true)
+			},
+			PrepareMockRepository: func(mock *account.MockRepository) {
+				mock.EXPECT().
+					GetByIDOrDocument(gomock.Any(), document).
+					Return(&accountExample, nil)
+			},
+			PrepareMockRepoAudit: func(mock *audit.MockRepository) {
+			},
+			PrepareMockCache: func(mock *cache.MockCache) {
+				expectNotLocked(mock)
+			},
+			PrepareMockTwoFactor: func(mock *twofactor.MockApp) {
+				mock.EXPECT().Verify(gomock.Any(), accountExample.ID, "").Return(pkgerror.ErrCantVerifyTwoFactor)
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx      = gomock.WithContext(context.Background(), t)
+				mockCache      = cache.NewMockCache(ctrl)
+				mockSecret     = secret.NewMockSecret(ctrl)
+				mockValidator  = validator.NewMockValidator(ctrl)
+				mockRepository = account.NewMockRepository(ctrl)
+				mockRepoAudit  = audit.NewMockRepository(ctrl)
+				mockGenerate   = generate.NewMockGenerate(ctrl)
+				mockTwoFactor  = twofactor.NewMockApp(ctrl)
+			)
+
+			cs.PrepareMockSecret(mockSecret)
+			cs.PrepareMockValidator(mockValidator)
+			cs.PrepareMockRepository(mockRepository)
+			cs.PrepareMockRepoAudit(mockRepoAudit)
+			cs.PrepareMockCache(mockCache)
+			if cs.PrepareMockTwoFactor != nil {
+				cs.PrepareMockTwoFactor(mockTwoFactor)
+			} else {
+				mockTwoFactor.EXPECT().Verify(gomock.Any(), accountExample.ID, "").AnyTimes().Return(nil)
+			}
+
+			mockGenerate.EXPECT().UUID().AnyTimes().Return(uuidExample)
+			mockGenerate.EXPECT().CurrentTime().AnyTimes().Return(currentTime)
+
+			app := NewApp(Options{
+				Logger:       logger.New(""),
+				Secret:       mockSecret,
+				Cache:        mockCache,
+				Validator:    mockValidator,
+				RepoAccount:  mockRepository,
+				RepoAudit:    mockRepoAudit,
+				Generate:     mockGenerate,
+				TwoFactorApp: mockTwoFactor,
+				Lockout:      lockout,
+			})
+
+			data, err := app.Auth(ctx, cs.InputData)
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestGetLockoutDuration(t *testing.T) {
+	cases := map[string]struct {
+		InputExcess      int64
+		ExpectedDuration time.Duration
+	}{
+		"should return base duration": {
+			InputExcess:      0,
+			ExpectedDuration: time.Minute,
+		},
+		"should double for every excess failure": {
+			InputExcess:      3,
+			ExpectedDuration: 8 * time.Minute,
+		},
+		"should cap at max duration": {
+			InputExcess:      1000,
+			ExpectedDuration: time.Hour,
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, cs.ExpectedDuration, getLockoutDuration(time.Minute, time.Hour, cs.InputExcess))
+		})
+	}
+}
+
+func TestGetSessionByToken(t *testing.T) {
+	var (
+		currentTime    = time.Now()
+		uuidExample    = uuid.NewString()
+		sessionExample = model.Session{
+			ID:    "session_id",
+			Token: uuidExample,
+			Account: model.Account{
+				ID:        "account_id",
+				Name:      "John Doe",
+				Document:  "123.123.123-12",
+				CreatedAt: currentTime,
+			},
+			CreatedAt:  currentTime.Add(-time.Minute),
+			LastSeenAt: currentTime.Add(-time.Minute),
+		}
+		seenSessionExample = model.Session{
+			ID:         sessionExample.ID,
+			Token:      sessionExample.Token,
+			Account:    sessionExample.Account,
+			CreatedAt:  sessionExample.CreatedAt,
+			LastSeenAt: currentTime,
+		}
+	)
+
+	cases := map[string]struct {
+		InputData        string
+		ExpectedData     *model.Session
+		ExpectedError    error
+		PrepareMockCache func(mock *cache.MockCache)
+	}{
+		"should return success": {
+			InputData:     uuidExample,
+			ExpectedData:  &seenSessionExample,
+			ExpectedError: nil,
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().
+					Get(gomock.Any(), getSessionCacheKey(uuidExample), new(model.Session)).
+					Do(func(_, _ interface{}, session *model.Session) {
+						*session = sessionExample
+					})
+				mock.EXPECT().
+					SetIfExists(gomock.Any(), getSessionCacheKey(uuidExample), &seenSessionExample, cacheExpiration).
+					Return(true, nil)
+				mock.EXPECT().
+					SetField(gomock.Any(), getAccountSessionsCacheKey("account_id"), "session_id", uuidExample, cacheExpiration).
+					Return(nil)
+			},
+		},
+		"should return success when index refresh fails": {
+			InputData:     uuidExample,
+			ExpectedData:  &seenSessionExample,
+			ExpectedError: nil,
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().
+					Get(gomock.Any(), getSessionCacheKey(uuidExample), new(model.Session)).
+					Do(func(_, _ interface{}, session *model.Session) {
+						*session = sessionExample
+					})
+				mock.EXPECT().
+					SetIfExists(gomock.Any(), getSessionCacheKey(uuidExample), &seenSessionExample, cacheExpiration).
+					Return(true, nil)
+				mock.EXPECT().
+					SetField(gomock.Any(), getAccountSessionsCacheKey("account_id"), "session_id", uuidExample, cacheExpiration).
+					Return(errors.New("fail"))
+			},
+		},
+		"should return cache missing error": {
+			InputData:     uuidExample,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrSessionNotFound,
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().
+					Get(gomock.Any(), getSessionCacheKey(uuidExample), new(model.Session)).
+					Return(errors.New("cache missing"))
+				mock.EXPECT().
+					IsErrCacheMissing(errors.New("cache missing")).
+					Return(true)
+			},
+		},
+		"should return cant get session error": {
+			InputData:     uuidExample,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantGetSession,
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().
+					Get(gomock.Any(), getSessionCacheKey(uuidExample), new(model.Session)).
+					Return(errors.New("cache missing"))
+				mock.EXPECT().
+					IsErrCacheMissing(errors.New("cache missing")).
+					Return(false)
+			},
+		},
+		"should return error: session revoked meanwhile": {
+			InputData:     uuidExample,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrSessionNotFound,
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().
+					Get(gomock.Any(), getSessionCacheKey(uuidExample), new(model.Session)).
+					Do(func(_, _ interface{}, session *model.Session) {
+						*session = sessionExample
+					})
+				mock.EXPECT().
+					SetIfExists(gomock.Any(), getSessionCacheKey(uuidExample), &seenSessionExample, cacheExpiration).
+					Return(false, nil)
+			},
+		},
+		"should return error on extend session": {
+			InputData:     uuidExample,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantGetSession,
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().
+					Get(gomock.Any(), getSessionCacheKey(uuidExample), new(model.Session)).
+					Do(func(_, _ interface{}, session *model.Session) {
+						*session = sessionExample
+					})
+				mock.EXPECT().
+					SetIfExists(gomock.Any(), getSessionCacheKey(uuidExample), &seenSessionExample, cacheExpiration).
+					Return(false, errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx    = gomock.WithContext(context.Background(), t)
+				mockCache    = cache.NewMockCache(ctrl)
+				mockGenerate = generate.NewMockGenerate(ctrl)
+			)
+
+			cs.PrepareMockCache(mockCache)
+
+			mockGenerate.EXPECT().CurrentTime().AnyTimes().Return(currentTime)
+
+			app := NewApp(Options{
+				Logger:   logger.New(""),
+				Cache:    mockCache,
+				Generate: mockGenerate,
+			})
+
+			data, err := app.GetSessionByToken(ctx, cs.InputData)
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestLogout(t *testing.T) {
+	sessionExample := model.Session{
+		ID:      "session_id",
+		Token:   "session_token",
+		Account: model.Account{ID: "account_id"},
+	}
+
+	cases := map[string]struct {
+		InputMode        string
+		ExpectedError    error
+		PrepareMockCache func(mock *cache.MockCache)
+	}{
+		"should return success": {
+			ExpectedError: nil,
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().Delete(gomock.Any(), getSessionCacheKey("session_token")).Return(nil)
+				mock.EXPECT().
+					DeleteFields(gomock.Any(), getAccountSessionsCacheKey("account_id"), "session_id").
+					Return(nil)
+			},
+		},
+		"should return error on delete session": {
+			ExpectedError: pkgerror.ErrCantRevokeSession,
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().Delete(gomock.Any(), getSessionCacheKey("session_token")).Return(errors.New("fail"))
+			},
+		},
+		"should return error on delete index": {
+			ExpectedError: pkgerror.ErrCantRevokeSession,
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().Delete(gomock.Any(), getSessionCacheKey("session_token")).Return(nil)
+				mock.EXPECT().
+					DeleteFields(gomock.Any(), getAccountSessionsCacheKey("account_id"), "session_id").
+					Return(errors.New("fail"))
+			},
+		},
+		"should return success with jwt": {
+			InputMode:     TokenModeJWT,
+			ExpectedError: nil,
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().
+					GetFields(gomock.Any(), getAccountSessionsCacheKey("account_id"), gomock.Any()).
+					Do(func(_, _ interface{}, tokens *map[string]string) {
+						*tokens = map[string]string{"session_id": "internal_token"}
+					})
+				mock.EXPECT().Delete(gomock.Any(), getSessionCacheKey("internal_token")).Return(nil)
+				mock.EXPECT().
+					DeleteFields(gomock.Any(), getAccountSessionsCacheKey("account_id"), "session_id").
+					Return(nil)
+			},
+		},
+		"should return success with jwt when already revoked": {
+			InputMode:     TokenModeJWT,
+			ExpectedError: nil,
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().
+					GetFields(gomock.Any(), getAccountSessionsCacheKey("account_id"), gomock.Any()).
+					Return(nil)
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx = gomock.WithContext(context.Background(), t)
+				mockCache = cache.NewMockCache(ctrl)
+			)
+
+			cs.PrepareMockCache(mockCache)
+
+			app := NewApp(Options{
+				Logger: logger.New(""),
+				Cache:  mockCache,
+				Tokens: TokenConfig{Mode: cs.InputMode},
+			})
+
+			err := app.Logout(ctx, sessionExample)
+
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestListSessions(t *testing.T) {
+	var (
+		currentTime     = time.Now()
+		errCacheMissing = errors.New("cache missing")
+		indexKey        = getAccountSessionsCacheKey("account_id")
+		tokensExample   = map[string]string{
+			"current_id": "current_token",
+			"older_id":   "older_token",
+			"expired_id": "expired_token",
+		}
+		currentSession = model.Session{
+			ID:         "current_id",
+			Token:      "current_token",
+			Device:     "browser",
+			IP:         "192.0.2.1",
+			CreatedAt:  currentTime,
+			LastSeenAt: currentTime,
+		}
+		olderSession = model.Session{
+			ID:         "older_id",
+			Token:      "older_token",
+			Device:     "phone",
+			IP:         "192.0.2.2",
+			CreatedAt:  currentTime.Add(-time.Hour),
+			LastSeenAt: currentTime.Add(-time.Minute),
+		}
+		expectGetFields = func(mock *cache.MockCache) {
+			mock.EXPECT().
+				GetFields(gomock.Any(), indexKey, gomock.Any()).
+				Do(func(_ context.Context, _ string, value *map[string]string) {
+					for id, token := range tokensExample {
+						(*value)[id] = token
+					}
+				})
+		}
+		expectGetSession = func(mock *cache.MockCache, token string, session *model.Session, err error) {
+			call := mock.EXPECT().Get(gomock.Any(), getSessionCacheKey(token), new(model.Session))
+			if session != nil {
+				call.Do(func(_, _ interface{}, value *model.Session) {
+					*value = *session
+				})
+				return
+			}
+			call.Return(err)
+		}
+	)
+
+	cases := map[string]struct {
+		ExpectedData     []model.SessionInfo
+		ExpectedError    error
+		PrepareMockCache func(mock *cache.MockCache)
+	}{
+		"should return success": {
+			ExpectedData: []model.SessionInfo{
+				{
+					ID:         "current_id",
+					Device:     "browser",
+					IP:         "192.0.2.1",
+					Current:    true,
+					CreatedAt:  currentSession.CreatedAt,
+					LastSeenAt: currentSession.LastSeenAt,
+				},
+				{
+					ID:         "older_id",
+					Device:     "phone",
+					IP:         "192.0.2.2",
+					Current:    false,
+					CreatedAt:  olderSession.CreatedAt,
+					LastSeenAt: olderSession.LastSeenAt,
+				},
+			},
+			ExpectedError: nil,
+			PrepareMockCache: func(mock *cache.MockCache) {
+				expectGetFields(mock)
+				expectGetSession(mock, "current_token", &currentSession, nil)
+				expectGetSession(mock, "older_token", &olderSession, nil)
+				expectGetSession(mock, "expired_token", nil, errCacheMissing)
+				mock.EXPECT().IsErrCacheMissing(errCacheMissing).Return(true)
+				mock.EXPECT().DeleteFields(gomock.Any(), indexKey, "expired_id").Return(nil)
+			},
+		},
+		"should return error on get index": {
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantListSessions,
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().
+					GetFields(gomock.Any(), indexKey, gomock.Any()).
+					Return(errors.New("fail"))
+			},
+		},
+		"should return error on get session": {
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantListSessions,
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().
+					GetFields(gomock.Any(), indexKey, gomock.Any()).
+					Do(func(_ context.Context, _ string, value *map[string]string) {
+						(*value)["current_id"] = "current_token"
+					})
+				expectGetSession(mock, "current_token", nil, errors.New("fail"))
+				mock.EXPECT().IsErrCacheMissing(errors.New("fail")).Return(false)
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx = gomock.WithContext(context.Background(), t)
+				mockCache = cache.NewMockCache(ctrl)
+			)
+
+			cs.PrepareMockCache(mockCache)
+
+			app := NewApp(Options{
+				Logger: logger.New(""),
+				Cache:  mockCache,
+			})
+
+			data, err := app.ListSessions(ctx, "account_id", "current_id")
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestRevokeSession(t *testing.T) {
+	indexKey := getAccountSessionsCacheKey("account_id")
+
+	cases := map[string]struct {
+		InputSessionID   string
+		ExpectedError    error
+		PrepareMockCache func(mock *cache.MockCache)
+	}{
+		"should return success": {
+			InputSessionID: "session_id",
+			ExpectedError:  nil,
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().
+					GetFields(gomock.Any(), indexKey, gomock.Any()).
+					Do(func(_ context.Context, _ string, value *map[string]string) {
+						(*value)["session_id"] = "session_token"
+					})
+				mock.EXPECT().Delete(gomock.Any(), getSessionCacheKey("session_token")).Return(nil)
+				mock.EXPECT().DeleteFields(gomock.Any(), indexKey, "session_id").Return(nil)
+			},
+		},
+		"should return error: session not found": {
+			InputSessionID: "another_session_id",
+			ExpectedError:  pkgerror.ErrSessionNotFound,
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().
+					GetFields(gomock.Any(), indexKey, gomock.Any()).
+					Do(func(_ context.Context, _ string, value *map[string]string) {
+						(*value)["session_id"] = "session_token"
+					})
+			},
+		},
+		"should return error on get index": {
+			InputSessionID: "session_id",
+			ExpectedError:  pkgerror.ErrCantRevokeSession,
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().
+					GetFields(gomock.Any(), indexKey, gomock.Any()).
+					Return(errors.New("fail"))
+			},
+		},
+		"should return error on delete session": {
+			InputSessionID: "session_id",
+			ExpectedError:  pkgerror.ErrCantRevokeSession,
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().
+					GetFields(gomock.Any(), indexKey, gomock.Any()).
+					Do(func(_ context.Context, _ string, value *map[string]string) {
+						(*value)["session_id"] = "session_token"
+					})
+				mock.EXPECT().Delete(gomock.Any(), getSessionCacheKey("session_token")).Return(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx = gomock.WithContext(context.Background(), t)
+				mockCache = cache.NewMockCache(ctrl)
+			)
+
+			cs.PrepareMockCache(mockCache)
+
+			app := NewApp(Options{
+				Logger: logger.New(""),
+				Cache:  mockCache,
+			})
+
+			err := app.RevokeSession(ctx, "account_id", cs.InputSessionID)
+
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestRevokeSessions(t *testing.T) {
+	var (
+		indexKey        = getAccountSessionsCacheKey("account_id")
+		expectGetFields = func(mock *cache.MockCache) {
+			mock.EXPECT().
+				GetFields(gomock.Any(), indexKey, gomock.Any()).
+				Do(func(_ context.Context, _ string, value *map[string]string) {
+					(*value)["current_id"] = "current_token"
+					(*value)["other_id"] = "other_token"
+				})
+		}
+	)
+
+	cases := map[string]struct {
+		InputExceptID    string
+		ExpectedError    error
+		PrepareMockCache func(mock *cache.MockCache)
+	}{
+		"should revoke other sessions": {
+			InputExceptID: "current_id",
+			ExpectedError: nil,
+			PrepareMockCache: func(mock *cache.MockCache) {
+				expectGetFields(mock)
+				mock.EXPECT().Delete(gomock.Any(), getSessionCacheKey("other_token")).Return(nil)
+				mock.EXPECT().DeleteFields(gomock.Any(), indexKey, "other_id").Return(nil)
+			},
+		},
+		"should revoke every session": {
+			InputExceptID: "",
+			ExpectedError: nil,
+			PrepareMockCache: func(mock *cache.MockCache) {
+				expectGetFields(mock)
+				mock.EXPECT().Delete(gomock.Any(), getSessionCacheKey("current_token")).Return(nil)
+				mock.EXPECT().Delete(gomock.Any(), getSessionCacheKey("other_token")).Return(nil)
+				mock.EXPECT().DeleteFields(gomock.Any(), indexKey, "current_id", "other_id").Return(nil)
+			},
+		},
+		"should return success without sessions": {
+			InputExceptID: "",
+			ExpectedError: nil,
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().GetFields(gomock.Any(), indexKey, gomock.Any()).Return(nil)
+			},
+		},
+		"should return error on get index": {
+			InputExceptID: "current_id",
+			ExpectedError: pkgerror.ErrCantRevokeSession,
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().
+					GetFields(gomock.Any(), indexKey, gomock.Any()).
+					Return(errors.New("fail"))
+			},
+		},
+		"should return error on delete index": {
+			InputExceptID: "current_id",
+			ExpectedError: pkgerror.ErrCantRevokeSession,
+			PrepareMockCache: func(mock *cache.MockCache) {
+				expectGetFields(mock)
+				mock.EXPECT().Delete(gomock.Any(), getSessionCacheKey("other_token")).Return(nil)
+				mock.EXPECT().DeleteFields(gomock.Any(), indexKey, "other_id").Return(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx = gomock.WithContext(context.Background(), t)
+				mockCache = cache.NewMockCache(ctrl)
+			)
+
+			cs.PrepareMockCache(mockCache)
+
+			app := NewApp(Options{
+				Logger: logger.New(""),
+				Cache:  mockCache,
+			})
+
+			err := app.RevokeSessions(ctx, "account_id", cs.InputExceptID)
+
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestAuthWithJWT(t *testing.T) {
+	var (
+		currentTime        = time.Now()
+		expiresAt          = currentTime.Add(defaultAccessTTL)
+		document           = "12312312312"
+		credentialsExample = model.Credentials{
+			Document: document,
+			Secret:   "secret",
+		}
+		accountExample = model.Account{
+			ID:   "account_id",
+			Name: "John Doe",
+			Role: model.RoleAdmin,
+		}
+		storedSessionExample = model.Session{
+			ID:         "generated_id",
+			Token:      "generated_id",
+			Account:    accountExample,
+			CreatedAt:  currentTime,
+			LastSeenAt: currentTime,
+		}
+		claimsExample = jwt.Claims{
+			ID:        "generated_id",
+			AccountID: accountExample.ID,
+			SessionID: storedSessionExample.ID,
+			Name:      accountExample.Name,
+			Role:      model.RoleAdmin,
+			IssuedAt:  currentTime,
+			ExpiresAt: expiresAt,
+		}
+		sessionExample = model.Session{
+			ID:           storedSessionExample.ID,
+			Token:        "access_token",
+			RefreshToken: "generated_id",
+			ExpiresAt:    &expiresAt,
+			Account:      accountExample,
+			CreatedAt:    currentTime,
+			LastSeenAt:   currentTime,
+		}
+		refreshRecordExample = refreshRecord{
+			AccountID:    accountExample.ID,
+			SessionID:    storedSessionExample.ID,
+			SessionToken: storedSessionExample.Token,
+		}
+		errCacheMissing = errors.New("cache missing")
+	)
+
+	cases := map[string]struct {
+		ExpectedData     *model.Session
+		ExpectedError    error
+		PrepareMockJWT   func(mock *jwt.MockJWT)
+		PrepareMockCache func(mock *cache.MockCache)
+	}{
+		"should return success": {
+			ExpectedData:  &sessionExample,
+			ExpectedError: nil,
+			PrepareMockJWT: func(mock *jwt.MockJWT) {
+				mock.EXPECT().Sign(claimsExample).Return("access_token", nil)
+			},
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().
+					Set(gomock.Any(), getRefreshCacheKey(hashToken("generated_id")), refreshRecordExample, defaultRefreshTTL).
+					Return(nil)
+			},
+		},
+		"should return error on sign": {
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantAuth,
+			PrepareMockJWT: func(mock *jwt.MockJWT) {
+				mock.EXPECT().Sign(claimsExample).Return("", errors.New("fail"))
+			},
+			PrepareMockCache: func(mock *cache.MockCache) {},
+		},
+		"should return error on store refresh token": {
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantAuth,
+			PrepareMockJWT: func(mock *jwt.MockJWT) {
+				mock.EXPECT().Sign(claimsExample).Return("access_token", nil)
+			},
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().
+					Set(gomock.Any(), getRefreshCacheKey(hashToken("generated_id")), refreshRecordExample, defaultRefreshTTL).
+					Return(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx      = gomock.WithContext(context.Background(), t)
+				mockCache      = cache.NewMockCache(ctrl)
+				mockJWT        = jwt.NewMockJWT(ctrl)
+				mockSecret     = secret.NewMockSecret(ctrl)
+				mockValidator  = validator.NewMockValidator(ctrl)
+				mockRepository = account.NewMockRepository(ctrl)
+				mockGenerate   = generate.NewMockGenerate(ctrl)
+				mockTwoFactor  = twofactor.NewMockApp(ctrl)
+			)
+
+			mockValidator.EXPECT().Validate(credentialsExample).Return(nil)
+			mockSecret.EXPECT().Verify(gomock.Any(), gomock.Any(), gomock.Any()).Return(true)
+			mockTwoFactor.EXPECT().Verify(gomock.Any(), accountExample.ID, "").Return(nil)
+			mockSecret.EXPECT().NeedsRehash(gomock.Any()).Return(false)
+			mockRepository.EXPECT().GetByIDOrDocument(gomock.Any(), document).Return(&accountExample, nil)
+			mockCache.EXPECT().Get(gomock.Any(), fmt.Sprintf(cacheKeyDocumentLock, document), gomock.Any()).Return(errCacheMissing)
+			mockCache.EXPECT().IsErrCacheMissing(errCacheMissing).Return(true)
+			mockCache.EXPECT().Delete(gomock.Any(), fmt.Sprintf(cacheKeyDocumentFailures, document)).Return(nil)
+			mockCache.EXPECT().
+				SetField(gomock.Any(), getAccountSessionsCacheKey(accountExample.ID), "generated_id", "generated_id", defaultRefreshTTL).
+				Return(nil)
+			mockCache.EXPECT().
+				Set(gomock.Any(), getSessionCacheKey("generated_id"), &storedSessionExample, defaultRefreshTTL).
+				Return(nil)
+
+			cs.PrepareMockJWT(mockJWT)
+			cs.PrepareMockCache(mockCache)
+
+			mockGenerate.EXPECT().UUID().AnyTimes().Return("generated_id")
+			mockGenerate.EXPECT().CurrentTime().AnyTimes().Return(currentTime)
+
+			app := NewApp(Options{
+				Logger:       logger.New(""),
+				Secret:       mockSecret,
+				Cache:        mockCache,
+				Validator:    mockValidator,
+				RepoAccount:  mockRepository,
+				Generate:     mockGenerate,
+				TwoFactorApp: mockTwoFactor,
+				Tokens:       TokenConfig{Mode: TokenModeJWT, JWT: mockJWT},
+			})
+
+			data, err := app.Auth(ctx, credentialsExample)
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	var (
+		currentTime     = time.Now()
+		expiresAt       = currentTime.Add(defaultAccessTTL)
+		refreshHash     = hashToken("refresh_token")
+		errCacheMissing = errors.New("cache missing")
+		accountExample  = model.Account{
+			ID:     "account_id",
+			Name:   "John Doe",
+			Status: model.AccountStatusActive,
+		}
+		refreshRecordExample = refreshRecord{
+			AccountID:    accountExample.ID,
+			SessionID:    "session_id",
+			SessionToken: "session_token",
+		}
+		storedSessionExample = model.Session{
+			ID:         "session_id",
+			Token:      "session_token",
+			Account:    model.Account{ID: accountExample.ID, Name: "Old Name"},
+			CreatedAt:  currentTime.Add(-time.Hour),
+			LastSeenAt: currentTime.Add(-time.Hour),
+		}
+		seenSessionExample = model.Session{
+			ID:         "session_id",
+			Token:      "session_token",
+			Account:    accountExample,
+			CreatedAt:  storedSessionExample.CreatedAt,
+			LastSeenAt: currentTime,
+		}
+		sessionExample = model.Session{
+			ID:           "session_id",
+			Token:        "access_token",
+			RefreshToken: "generated_id",
+			ExpiresAt:    &expiresAt,
+			Account:      accountExample,
+			CreatedAt:    storedSessionExample.CreatedAt,
+			LastSeenAt:   currentTime,
+		}
+		expectRecord = func(mock *cache.MockCache) {
+			mock.EXPECT().
+				Get(gomock.Any(), getRefreshCacheKey(refreshHash), new(refreshRecord)).
+				Do(func(_, _ interface{}, record *refreshRecord) {
+					*record = refreshRecordExample
+				})
+		}
+		expectFirstUse = func(mock *cache.MockCache) {
+			expectRecord(mock)
+			mock.EXPECT().
+				SetIfNotExists(gomock.Any(), getRefreshUsedCacheKey(refreshHash), true, defaultRefreshTTL).
+				Return(true, nil)
+		}
+		expectStoredSession = func(mock *cache.MockCache) {
+			expectFirstUse(mock)
+			mock.EXPECT().
+				Get(gomock.Any(), getSessionCacheKey("session_token"), new(model.Session)).
+				Do(func(_, _ interface{}, session *model.Session) {
+					*session = storedSessionExample
+				})
+		}
+		expectRevoke = func(mock *cache.MockCache) {
+			mock.EXPECT().Delete(gomock.Any(), getSessionCacheKey("session_token")).Return(nil)
+			mock.EXPECT().
+				DeleteFields(gomock.Any(), getAccountSessionsCacheKey(accountExample.ID), "session_id").
+				Return(nil)
+		}
+	)
+
+	cases := map[string]struct {
+		InputMode             string
+		InputData             string
+		ExpectedData          *model.Session
+		ExpectedError         error
+		PrepareMockCache      func(mock *cache.MockCache)
+		PrepareMockRepository func(mock *account.MockRepository)
+		PrepareMockJWT        func(mock *jwt.MockJWT)
+	}{
+		"should return success": {
+			InputMode:     TokenModeJWT,
+			InputData:     "refresh_token",
+			ExpectedData:  &sessionExample,
+			ExpectedError: nil,
+			PrepareMockCache: func(mock *cache.MockCache) {
+				expectStoredSession(mock)
+				mock.EXPECT().
+					SetIfExists(gomock.Any(), getSessionCacheKey("session_token"), &seenSessionExample, defaultRefreshTTL).
+					Return(true, nil)
+				mock.EXPECT().
+					SetField(gomock.Any(), getAccountSessionsCacheKey(accountExample.ID), "session_id", "session_token", defaultRefreshTTL).
+					Return(nil)
+				mock.EXPECT().
+					Set(gomock.Any(), getRefreshCacheKey(hashToken("generated_id")), refreshRecordExample, defaultRefreshTTL).
+					Return(nil)
+			},
+			PrepareMockRepository: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), accountExample.ID).Return(&accountExample, nil)
+			},
+			PrepareMockJWT: func(mock *jwt.MockJWT) {
+				mock.EXPECT().
+					Sign(jwt.Claims{
+						ID:        "generated_id",
+						AccountID: accountExample.ID,
+						SessionID: "session_id",
+						Name:      accountExample.Name,
+						IssuedAt:  currentTime,
+						ExpiresAt: expiresAt,
+					}).
+					Return("access_token", nil)
+			},
+		},
+		"should return error: session mode": {
+			InputMode:             TokenModeSession,
+			InputData:             "refresh_token",
+			ExpectedData:          nil,
+			ExpectedError:         pkgerror.ErrInvalidRefresh,
+			PrepareMockCache:      func(mock *cache.MockCache) {},
+			PrepareMockRepository: func(mock *account.MockRepository) {},
+			PrepareMockJWT:        func(mock *jwt.MockJWT) {},
+		},
+		"should return error: unknown refresh token": {
+			InputMode:     TokenModeJWT,
+			InputData:     "refresh_token",
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrInvalidRefresh,
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().
+					Get(gomock.Any(), getRefreshCacheKey(refreshHash), new(refreshRecord)).
+					Return(errCacheMissing)
+				mock.EXPECT().IsErrCacheMissing(errCacheMissing).Return(true)
+			},
+			PrepareMockRepository: func(mock *account.MockRepository) {},
+			PrepareMockJWT:        func(mock *jwt.MockJWT) {},
+		},
+		"should return error on get refresh token": {
+			InputMode:     TokenModeJWT,
+			InputData:     "refresh_token",
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantRefresh,
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().
+					Get(gomock.Any(), getRefreshCacheKey(refreshHash), new(refreshRecord)).
+					Return(errors.New("fail"))
+				mock.EXPECT().IsErrCacheMissing(errors.New("fail")).Return(false)
+			},
+			PrepareMockRepository: func(mock *account.MockRepository) {},
+			PrepareMockJWT:        func(mock *jwt.MockJWT) {},
+		},
+		"should return error and revoke session: refresh token reused": {
+			InputMode:     TokenModeJWT,
+			InputData:     "refresh_token",
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrRefreshReused,
+			PrepareMockCache: func(mock *cache.MockCache) {
+				expectRecord(mock)
+				mock.EXPECT().
+					SetIfNotExists(gomock.Any(), getRefreshUsedCacheKey(refreshHash), true, defaultRefreshTTL).
+					Return(false, nil)
+				expectRevoke(mock)
+			},
+			PrepareMockRepository: func(mock *account.MockRepository) {},
+			PrepareMockJWT:        func(mock *jwt.MockJWT) {},
+		},
+		"should return error: session revoked": {
+			InputMode:     TokenModeJWT,
+			InputData:     "refresh_token",
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrInvalidRefresh,
+			PrepareMockCache: func(mock *cache.MockCache) {
+				expectFirstUse(mock)
+				mock.EXPECT().
+					Get(gomock.Any(), getSessionCacheKey("session_token"), new(model.Session)).
+					Return(errCacheMissing)
+				mock.EXPECT().IsErrCacheMissing(errCacheMissing).Return(true)
+			},
+			PrepareMockRepository: func(mock *account.MockRepository) {},
+			PrepareMockJWT:        func(mock *jwt.MockJWT) {},
+		},
+		"should return error and revoke session: account closed": {
+			InputMode:     TokenModeJWT,
+			InputData:     "refresh_token",
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrInvalidRefresh,
+			PrepareMockCache: func(mock *cache.MockCache) {
+				expectStoredSession(mock)
+				expectRevoke(mock)
+			},
+			PrepareMockRepository: func(mock *account.MockRepository) {
+				closed := accountExample
+				closed.Status = model.AccountStatusClosed
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), accountExample.ID).Return(&closed, nil)
+			},
+			PrepareMockJWT: func(mock *jwt.MockJWT) {},
+		},
+		"should return error: session revoked meanwhile": {
+			InputMode:     TokenModeJWT,
+			InputData:     "refresh_token",
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrInvalidRefresh,
+			PrepareMockCache: func(mock *cache.MockCache) {
+				expectStoredSession(mock)
+				mock.EXPECT().
+					SetIfExists(gomock.Any(), getSessionCacheKey("session_token"), &seenSessionExample, defaultRefreshTTL).
+					Return(false, nil)
+			},
+			PrepareMockRepository: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), accountExample.ID).Return(&accountExample, nil)
+			},
+			PrepareMockJWT: func(mock *jwt.MockJWT) {},
+		},
+		"should return error on get account": {
+			InputMode:     TokenModeJWT,
+			InputData:     "refresh_token",
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantRefresh,
+			PrepareMockCache: func(mock *cache.MockCache) {
+				expectStoredSession(mock)
+			},
+			PrepareMockRepository: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), accountExample.ID).Return(nil, errors.New("fail"))
+			},
+			PrepareMockJWT: func(mock *jwt.MockJWT) {},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx      = gomock.WithContext(context.Background(), t)
+				mockCache      = cache.NewMockCache(ctrl)
+				mockJWT        = jwt.NewMockJWT(ctrl)
+				mockRepository = account.NewMockRepository(ctrl)
+				mockGenerate   = generate.NewMockGenerate(ctrl)
+			)
+
+			cs.PrepareMockCache(mockCache)
+			cs.PrepareMockRepository(mockRepository)
+			cs.PrepareMockJWT(mockJWT)
+
+			mockGenerate.EXPECT().UUID().AnyTimes().Return("generated_id")
+			mockGenerate.EXPECT().CurrentTime().AnyTimes().Return(currentTime)
+
+			app := NewApp(Options{
+				Logger:      logger.New(""),
+				Cache:       mockCache,
+				RepoAccount: mockRepository,
+				Generate:    mockGenerate,
+				Tokens:      TokenConfig{Mode: cs.InputMode, JWT: mockJWT},
+			})
+
+			data, err := app.Refresh(ctx, cs.InputData)
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestGetSessionByAccessToken(t *testing.T) {
+	var (
+		expiresAt     = time.Unix(time.Now().Add(time.Minute).Unix(), 0)
+		claimsExample = jwt.Claims{
+			ID:        "token_id",
+			AccountID: "account_id",
+			SessionID: "session_id",
+			Name:      "John Doe",
+			Role:      model.RoleAdmin,
+			ExpiresAt: expiresAt,
+		}
+	)
+
+	cases := map[string]struct {
+		ExpectedData   *model.Session
+		ExpectedError  error
+		PrepareMockJWT func(mock *jwt.MockJWT)
+	}{
+		"should return success": {
+			ExpectedData: &model.Session{
+				ID:        "session_id",
+				Token:     "access_token",
+				ExpiresAt: &expiresAt,
+				Account:   model.Account{ID: "account_id", Name: "John Doe", Role: model.RoleAdmin},
+			},
+			ExpectedError: nil,
+			PrepareMockJWT: func(mock *jwt.MockJWT) {
+				mock.EXPECT().Parse("access_token").Return(&claimsExample, nil)
+			},
+		},
+		"should return error: invalid token": {
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrSessionNotFound,
+			PrepareMockJWT: func(mock *jwt.MockJWT) {
+				mock.EXPECT().Parse("access_token").Return(nil, jwt.ErrInvalidToken)
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx = gomock.WithContext(context.Background(), t)
+				mockJWT   = jwt.NewMockJWT(ctrl)
+			)
+
+			cs.PrepareMockJWT(mockJWT)
+
+			app := NewApp(Options{
+				Logger: logger.New(""),
+				Tokens: TokenConfig{Mode: TokenModeJWT, JWT: mockJWT},
+			})
+
+			data, err := app.GetSessionByToken(ctx, "access_token")
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestChangeSecret(t *testing.T) {
+	var (
+		changeExample = model.SecretChange{
+			AccountID:     "account_id",
+			CurrentSecret: "current",
+			NewSecret:     "new",
+		}
+		accountExample = model.Account{
+			ID:         "account_id",
+			Secret:     "encoded_current",
+			SecretSalt: "salt",
+		}
+		indexKey        = getAccountSessionsCacheKey("account_id")
+		expectRevokeAll = func(mock *cache.MockCache) {
+			mock.EXPECT().
+				GetFields(gomock.Any(), indexKey, gomock.Any()).
+				Do(func(_ context.Context, _ string, value *map[string]string) {
+					(*value)["session_id"] = "session_token"
+				})
+			mock.EXPECT().Delete(gomock.Any(), getSessionCacheKey("session_token")).Return(nil)
+			mock.EXPECT().DeleteFields(gomock.Any(), indexKey, "session_id").Return(nil)
+		}
+		validationErrorExample = &validator.ValidationError{Message: "invalid data"}
+	)
+
+	cases := map[string]struct {
+		ExpectedError         error
+		PrepareMockValidator  func(mock *validator.MockValidator)
+		PrepareMockSecret     func(mock *secret.MockSecret)
+		PrepareMockRepository func(mock *account.MockRepository)
+		PrepareMockCache      func(mock *cache.MockCache)
+	}{
+		"should return success": {
+			ExpectedError: nil,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(changeExample).Return(nil)
+			},
+			PrepareMockSecret: func(mock *secret.MockSecret) {
+				mock.EXPECT().Verify("current", "encoded_current", "salt").Return(true)
+				mock.EXPECT().Encode("new").Return("encoded_new", nil)
+			},
+			PrepareMockRepository: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), "account_id").Return(&accountExample, nil)
+				mock.EXPECT().UpdateSecret(gomock.Any(), "account_id", "encoded_new").Return(nil)
+			},
+			PrepareMockCache: expectRevokeAll,
+		},
+		"should return validation error": {
+			ExpectedError: validationErrorExample,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(changeExample).Return(validationErrorExample)
+			},
+			PrepareMockSecret:     func(mock *secret.MockSecret) {},
+			PrepareMockRepository: func(mock *account.MockRepository) {},
+			PrepareMockCache:      func(mock *cache.MockCache) {},
+		},
+		"should return error: account not found": {
+			ExpectedError: pkgerror.ErrAccountNotFound,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(changeExample).Return(nil)
+			},
+			PrepareMockSecret: func(mock *secret.MockSecret) {},
+			PrepareMockRepository: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), "account_id").Return(nil, nil)
+			},
+			PrepareMockCache: func(mock *cache.MockCache) {},
+		},
+		"should return error: invalid current secret": {
+			ExpectedError: pkgerror.ErrInvalidSecret,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(changeExample).Return(nil)
+			},
+			PrepareMockSecret: func(mock *secret.MockSecret) {
+				mock.EXPECT().Verify("current", "encoded_current", "salt").Return(false)
+			},
+			PrepareMockRepository: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), "account_id").Return(&accountExample, nil)
+			},
+			PrepareMockCache: func(mock *cache.MockCache) {},
+		},
+		"should return error on update secret": {
+			ExpectedError: pkgerror.ErrCantChangeSecret,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(changeExample).Return(nil)
+			},
+			PrepareMockSecret: func(mock *secret.MockSecret) {
+				mock.EXPECT().Verify("current", "encoded_current", "salt").Return(true)
+				mock.EXPECT().Encode("new").Return("encoded_new", nil)
+			},
+			PrepareMockRepository: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), "account_id").Return(&accountExample, nil)
+				mock.EXPECT().UpdateSecret(gomock.Any(), "account_id", "encoded_new").Return(errors.New("fail"))
+			},
+			PrepareMockCache: func(mock *cache.MockCache) {},
+		},
+		"should return error on revoke sessions": {
+			ExpectedError: pkgerror.ErrCantRevokeSession,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(changeExample).Return(nil)
+			},
+			PrepareMockSecret: func(mock *secret.MockSecret) {
+				mock.EXPECT().Verify("current", "encoded_current", "salt").Return(true)
+				mock.EXPECT().Encode("new").Return("encoded_new", nil)
+			},
+			PrepareMockRepository: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), "account_id").Return(&accountExample, nil)
+				mock.EXPECT().UpdateSecret(gomock.Any(), "account_id", "encoded_new").Return(nil)
+			},
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().GetFields(gomock.Any(), indexKey, gomock.Any()).Return(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
 		t.Run(name, func(t *testing.T) {
 			var (
 				ctrl, ctx      = gomock.WithContext(context.Background(), t)
@@ -281,13 +1782,285 @@ func TestGetSessionByToken(t *testing.T) {
 				mockSecret     = secret.NewMockSecret(ctrl)
 				mockValidator  = validator.NewMockValidator(ctrl)
 				mockRepository = account.NewMockRepository(ctrl)
+			)
+
+			cs.PrepareMockValidator(mockValidator)
+			cs.PrepareMockSecret(mockSecret)
+			cs.PrepareMockRepository(mockRepository)
+			cs.PrepareMockCache(mockCache)
+
+			app := NewApp(Options{
+				Logger:      logger.New(""),
+				Secret:      mockSecret,
+				Cache:       mockCache,
+				Validator:   mockValidator,
+				RepoAccount: mockRepository,
+			})
+
+			err := app.ChangeSecret(ctx, changeExample)
+
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestRequestSecretReset(t *testing.T) {
+	var (
+		token          = "reset_token"
+		resetKey       = getSecretResetCacheKey(hashToken(token))
+		accountExample = model.Account{
+			ID:       "account_id",
+			Document: "12312312312",
+			Status:   model.AccountStatusActive,
+		}
+		closedAccountExample = model.Account{
+			ID:       "account_id",
+			Document: "12312312312",
+			Status:   model.AccountStatusClosed,
+		}
+		messageExample = notifier.Message{
+			AccountID: "account_id",
+			Recipient: "12312312312",
+			Subject:   "Secret reset",
+			Body:      "Use the token reset_token to reset your secret. It expires in 30m0s.",
+		}
+	)
+
+	cases := map[string]struct {
+		InputDocument         string
+		ExpectedError         error
+		PrepareMockRepository func(mock *account.MockRepository)
+		PrepareMockCache      func(mock *cache.MockCache)
+		PrepareMockNotifier   func(mock *notifier.MockNotifier)
+	}{
+		"should return success": {
+			InputDocument: "123.123.123-12",
+			ExpectedError: nil,
+			PrepareMockRepository: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), "12312312312").Return(&accountExample, nil)
+			},
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().Set(gomock.Any(), resetKey, "account_id", defaultSecretResetTTL).Return(nil)
+			},
+			PrepareMockNotifier: func(mock *notifier.MockNotifier) {
+				mock.EXPECT().Notify(gomock.Any(), messageExample).Return(nil)
+			},
+		},
+		"should return success without sending: account not found": {
+			InputDocument: "123.123.123-12",
+			ExpectedError: nil,
+			PrepareMockRepository: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), "12312312312").Return(nil, nil)
+			},
+			PrepareMockCache:    func(mock *cache.MockCache) {},
+			PrepareMockNotifier: func(mock *notifier.MockNotifier) {},
+		},
+		"should return success without sending: account closed": {
+			InputDocument: "123.123.123-12",
+			ExpectedError: nil,
+			PrepareMockRepository: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), "12312312312").Return(&closedAccountExample, nil)
+			},
+			PrepareMockCache:    func(mock *cache.MockCache) {},
+			PrepareMockNotifier: func(mock *notifier.MockNotifier) {},
+		},
+		"should return success without sending: empty document": {
+			InputDocument:         "...-",
+			ExpectedError:         nil,
+			PrepareMockRepository: func(mock *account.MockRepository) {},
+			PrepareMockCache:      func(mock *cache.MockCache) {},
+			PrepareMockNotifier:   func(mock *notifier.MockNotifier) {},
+		},
+		"should return error on get account": {
+			InputDocument: "123.123.123-12",
+			ExpectedError: pkgerror.ErrCantResetSecret,
+			PrepareMockRepository: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), "12312312312").Return(nil, errors.New("fail"))
+			},
+			PrepareMockCache:    func(mock *cache.MockCache) {},
+			PrepareMockNotifier: func(mock *notifier.MockNotifier) {},
+		},
+		"should return error on store token": {
+			InputDocument: "123.123.123-12",
+			ExpectedError: pkgerror.ErrCantResetSecret,
+			PrepareMockRepository: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), "12312312312").Return(&accountExample, nil)
+			},
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().Set(gomock.Any(), resetKey, "account_id", defaultSecretResetTTL).Return(errors.New("fail"))
+			},
+			PrepareMockNotifier: func(mock *notifier.MockNotifier) {},
+		},
+		"should return error on notify": {
+			InputDocument: "123.123.123-12",
+			ExpectedError: pkgerror.ErrCantResetSecret,
+			PrepareMockRepository: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), "12312312312").Return(&accountExample, nil)
+			},
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().Set(gomock.Any(), resetKey, "account_id", defaultSecretResetTTL).Return(nil)
+			},
+			PrepareMockNotifier: func(mock *notifier.MockNotifier) {
+				mock.EXPECT().Notify(gomock.Any(), messageExample).Return(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx      = gomock.WithContext(context.Background(), t)
+				mockCache      = cache.NewMockCache(ctrl)
+				mockRepository = account.NewMockRepository(ctrl)
 				mockGenerate   = generate.NewMockGenerate(ctrl)
+				mockNotifier   = notifier.NewMockNotifier(ctrl)
 			)
 
+			cs.PrepareMockRepository(mockRepository)
 			cs.PrepareMockCache(mockCache)
+			cs.PrepareMockNotifier(mockNotifier)
 
-			mockGenerate.EXPECT().UUID().AnyTimes().Return(uuidExample)
-			mockGenerate.EXPECT().CurrentTime().AnyTimes().Return(currentTime)
+			mockGenerate.EXPECT().UUID().AnyTimes().Return(token)
+
+			app := NewApp(Options{
+				Logger:      logger.New(""),
+				Cache:       mockCache,
+				RepoAccount: mockRepository,
+				Generate:    mockGenerate,
+				Notifier:    mockNotifier,
+			})
+
+			err := app.RequestSecretReset(ctx, cs.InputDocument)
+
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestResetSecret(t *testing.T) {
+	var (
+		errCacheMissing = errors.New("cache missing")
+		resetExample    = model.SecretReset{
+			Token:     "reset_token",
+			NewSecret: "new",
+		}
+		hash           = hashToken("reset_token")
+		resetKey       = getSecretResetCacheKey(hash)
+		usedKey        = getSecretResetUsedCacheKey(hash)
+		indexKey       = getAccountSessionsCacheKey("account_id")
+		accountExample = model.Account{
+			ID:     "account_id",
+			Status: model.AccountStatusActive,
+		}
+		closedAccountExample = model.Account{
+			ID:     "account_id",
+			Status: model.AccountStatusClosed,
+		}
+		expectToken = func(mock *cache.MockCache) {
+			mock.EXPECT().IsErrCacheMissing(gomock.Any()).AnyTimes().DoAndReturn(func(err error) bool {
+				return err == errCacheMissing
+			})
+			mock.EXPECT().
+				Get(gomock.Any(), resetKey, gomock.Any()).
+				Do(func(_ context.Context, _ string, value *string) {
+					*value = "account_id"
+				})
+		}
+		expectFirstUse = func(mock *cache.MockCache) {
+			expectToken(mock)
+			mock.EXPECT().SetIfNotExists(gomock.Any(), usedKey, true, defaultSecretResetTTL).Return(true, nil)
+			mock.EXPECT().Delete(gomock.Any(), resetKey).Return(nil)
+		}
+	)
+
+	cases := map[string]struct {
+		ExpectedError         error
+		PrepareMockSecret     func(mock *secret.MockSecret)
+		PrepareMockRepository func(mock *account.MockRepository)
+		PrepareMockCache      func(mock *cache.MockCache)
+	}{
+		"should return success": {
+			ExpectedError: nil,
+			PrepareMockSecret: func(mock *secret.MockSecret) {
+				mock.EXPECT().Encode("new").Return("encoded_new", nil)
+			},
+			PrepareMockRepository: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), "account_id").Return(&accountExample, nil)
+				mock.EXPECT().UpdateSecret(gomock.Any(), "account_id", "encoded_new").Return(nil)
+			},
+			PrepareMockCache: func(mock *cache.MockCache) {
+				expectFirstUse(mock)
+				mock.EXPECT().
+					GetFields(gomock.Any(), indexKey, gomock.Any()).
+					Do(func(_ context.Context, _ string, value *map[string]string) {
+						(*value)["session_id"] = "session_token"
+					})
+				mock.EXPECT().Delete(gomock.Any(), getSessionCacheKey("session_token")).Return(nil)
+				mock.EXPECT().DeleteFields(gomock.Any(), indexKey, "session_id").Return(nil)
+			},
+		},
+		"should return error: unknown token": {
+			ExpectedError:         pkgerror.ErrInvalidResetToken,
+			PrepareMockSecret:     func(mock *secret.MockSecret) {},
+			PrepareMockRepository: func(mock *account.MockRepository) {},
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().IsErrCacheMissing(errCacheMissing).Return(true)
+				mock.EXPECT().Get(gomock.Any(), resetKey, gomock.Any()).Return(errCacheMissing)
+			},
+		},
+		"should return error: token already used": {
+			ExpectedError:         pkgerror.ErrInvalidResetToken,
+			PrepareMockSecret:     func(mock *secret.MockSecret) {},
+			PrepareMockRepository: func(mock *account.MockRepository) {},
+			PrepareMockCache: func(mock *cache.MockCache) {
+				expectToken(mock)
+				mock.EXPECT().SetIfNotExists(gomock.Any(), usedKey, true, defaultSecretResetTTL).Return(false, nil)
+			},
+		},
+		"should return error: account closed": {
+			ExpectedError:     pkgerror.ErrInvalidResetToken,
+			PrepareMockSecret: func(mock *secret.MockSecret) {},
+			PrepareMockRepository: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), "account_id").Return(&closedAccountExample, nil)
+			},
+			PrepareMockCache: expectFirstUse,
+		},
+		"should return error on get token": {
+			ExpectedError:         pkgerror.ErrCantResetSecret,
+			PrepareMockSecret:     func(mock *secret.MockSecret) {},
+			PrepareMockRepository: func(mock *account.MockRepository) {},
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().IsErrCacheMissing(gomock.Any()).Return(false)
+				mock.EXPECT().Get(gomock.Any(), resetKey, gomock.Any()).Return(errors.New("fail"))
+			},
+		},
+		"should return error on encode": {
+			ExpectedError: pkgerror.ErrCantResetSecret,
+			PrepareMockSecret: func(mock *secret.MockSecret) {
+				mock.EXPECT().Encode("new").Return("", errors.New("fail"))
+			},
+			PrepareMockRepository: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), "account_id").Return(&accountExample, nil)
+			},
+			PrepareMockCache: expectFirstUse,
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx      = gomock.WithContext(context.Background(), t)
+				mockCache      = cache.NewMockCache(ctrl)
+				mockSecret     = secret.NewMockSecret(ctrl)
+				mockValidator  = validator.NewMockValidator(ctrl)
+				mockRepository = account.NewMockRepository(ctrl)
+			)
+
+			cs.PrepareMockSecret(mockSecret)
+			cs.PrepareMockRepository(mockRepository)
+			cs.PrepareMockCache(mockCache)
+
+			mockValidator.EXPECT().Validate(resetExample).Return(nil)
 
 			app := NewApp(Options{
 				Logger:      logger.New(""),
@@ -295,12 +2068,10 @@ func TestGetSessionByToken(t *testing.T) {
 				Cache:       mockCache,
 				Validator:   mockValidator,
 				RepoAccount: mockRepository,
-				Generate:    mockGenerate,
 			})
 
-			data, err := app.GetSessionByToken(ctx, cs.InputData)
+			err := app.ResetSecret(ctx, resetExample)
 
-			assert.Equal(t, cs.ExpectedData, data)
 			assert.Equal(t, cs.ExpectedError, err)
 		})
 	}
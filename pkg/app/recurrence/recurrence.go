@@ -0,0 +1,324 @@
+//go:generate mockgen -source=${GOFILE} -package=${GOPACKAGE} -destination=${GOPACKAGE}_mock.go
+
+package recurrence
+
+import (
+	"context"
+	"github.com/carlosrodriguesf/bank-api/pkg/app/transfer"
+	pkgerror "github.com/carlosrodriguesf/bank-api/pkg/error"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/recurrence"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/generate"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/transaction"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/validator"
+	"time"
+)
+
+const (
+	executeDueBatchSize = 100
+	retryDelay          = time.Hour
+	// maxExecuteAttempts is how many times an occurrence is attempted while the infrastructure fails.
+	maxExecuteAttempts = 5
+	// claimTimeout is how long a claimed recurring transfer may stay processing before it is taken as abandoned by an
+	// instance that stopped and is claimed again. It is far longer than an attempt takes.
+	claimTimeout = 10 * time.Minute
+)
+
+type (
+	Options struct {
+		Logger         logger.Logger
+		Validator      validator.Validator
+		Generate       generate.Generate
+		RepoRecurrence recurrence.Repository
+		TransferApp    transfer.App
+	}
+	App interface {
+		Create(ctx context.Context, recurringTransfer model.RecurringTransfer) (*model.RecurringTransfer, error)
+		List(ctx context.Context, accountID string) ([]model.RecurringTransfer, error)
+		ListRuns(ctx context.Context, accountID string, id string) ([]model.RecurringTransferRun, error)
+		Cancel(ctx context.Context, accountID string, id string) (*model.RecurringTransfer, error)
+		ExecuteDue(ctx context.Context) error
+	}
+	appImpl struct {
+		logger         logger.Logger
+		validator      validator.Validator
+		generate       generate.Generate
+		repoRecurrence recurrence.Repository
+		transferApp    transfer.App
+	}
+)
+
+func NewApp(opts Options) App {
+	return &appImpl{
+		logger:         opts.Logger.WithLocation().WithPreffix("app.recurrence"),
+		validator:      opts.Validator,
+		generate:       opts.Generate,
+		repoRecurrence: opts.RepoRecurrence,
+		transferApp:    opts.TransferApp,
+	}
+}
+
+// Create sets up the recurring transfer after running the checks transfer.App.Authorize makes for a signed in user,
+// since its occurrences are made later by a worker with no session.
+func (a *appImpl) Create(ctx context.Context, recurringTransfer model.RecurringTransfer) (*model.RecurringTransfer, error) {
+	if err := a.validator.Validate(recurringTransfer); err != nil {
+		return nil, err
+	}
+	if recurringTransfer.Frequency != model.RecurrenceFrequencyMonthly {
+		recurringTransfer.DayOfMonth = nil
+	}
+	if !recurringTransfer.StartAt.After(a.generate.CurrentTime()) {
+		return nil, pkgerror.ErrRecurrenceStartAtInPast
+	}
+
+	firstOccurrence := getFirstOccurrence(recurringTransfer.RecurrenceRule)
+	if recurringTransfer.EndAt != nil && firstOccurrence.After(*recurringTransfer.EndAt) {
+		return nil, pkgerror.ErrRecurrenceEndAtBeforeStart
+	}
+
+	authorized, err := a.transferApp.Authorize(ctx, model.Transfer{
+		OriginAccountID: recurringTransfer.OriginAccountID,
+		TargetAccountID: recurringTransfer.TargetAccountID,
+		Amount:          recurringTransfer.Amount,
+		TwoFactorCode:   recurringTransfer.TwoFactorCode,
+	})
+	if err != nil {
+		if err == pkgerror.ErrCantCreateTransfer {
+			return nil, pkgerror.ErrCantCreateRecurringTransfer
+		}
+		return nil, err
+	}
+
+	recurringTransfer.TargetAccountID = authorized.TargetAccountID
+	recurringTransfer.Status = model.RecurringTransferStatusActive
+	recurringTransfer.NextOccurrenceAt = firstOccurrence
+	recurringTransfer.NextAttemptAt = firstOccurrence
+
+	genData, err := a.repoRecurrence.Create(ctx, recurringTransfer)
+	if err != nil {
+		a.logger.Error(err)
+		return nil, pkgerror.ErrCantCreateRecurringTransfer
+	}
+
+	recurringTransfer.ID = genData.ID
+	recurringTransfer.CreatedAt = genData.CreatedAt
+
+	return &recurringTransfer, nil
+}
+
+func (a *appImpl) List(ctx context.Context, accountID string) ([]model.RecurringTransfer, error) {
+	recurringTransfers, err := a.repoRecurrence.List(ctx, accountID)
+	if err != nil {
+		a.logger.Error(err)
+		return nil, pkgerror.ErrCantListRecurringTransfers
+	}
+	return recurringTransfers, nil
+}
+
+func (a *appImpl) ListRuns(ctx context.Context, accountID string, id string) ([]model.RecurringTransferRun, error) {
+	recurringTransfer, err := a.repoRecurrence.GetByID(ctx, id)
+	if err != nil {
+		a.logger.Error(err)
+		return nil, pkgerror.ErrCantListRecurringTransferRuns
+	}
+	if recurringTransfer == nil || recurringTransfer.OriginAccountID != accountID {
+		return nil, pkgerror.ErrRecurringTransferNotFound
+	}
+
+	runs, err := a.repoRecurrence.ListRuns(ctx, id)
+	if err != nil {
+		a.logger.Error(err)
+		return nil, pkgerror.ErrCantListRecurringTransferRuns
+	}
+	return runs, nil
+}
+
+func (a *appImpl) Cancel(ctx context.Context, accountID string, id string) (*model.RecurringTransfer, error) {
+	recurringTransfer, err := a.repoRecurrence.GetByID(ctx, id)
+	if err != nil {
+		a.logger.Error(err)
+		return nil, pkgerror.ErrCantCancelRecurringTransfer
+	}
+	if recurringTransfer == nil || recurringTransfer.OriginAccountID != accountID {
+		return nil, pkgerror.ErrRecurringTransferNotFound
+	}
+
+	cancelled, err := a.repoRecurrence.Cancel(ctx, id)
+	if err != nil {
+		a.logger.Error(err)
+		return nil, pkgerror.ErrCantCancelRecurringTransfer
+	}
+	if !cancelled {
+		return nil, pkgerror.ErrRecurringTransferNotActive
+	}
+
+	recurringTransfer.Status = model.RecurringTransferStatusCancelled
+	return recurringTransfer, nil
+}
+
+// ExecuteDue claims the recurring transfers whose next attempt is due, materializes the current occurrence of each
+// one through transfer.App.CreateWith and records the outcome as a run before moving to the next attempt.
+// Recurring transfers left processing for longer than claimTimeout are claimed again. Since an occurrence is recorded
+// and the recurring transfer advanced in the transaction that makes it, one claimed again was not made and no
+// occurrence is made twice.
+func (a *appImpl) ExecuteDue(ctx context.Context) error {
+	now := a.generate.CurrentTime()
+	recurringTransfers, err := a.repoRecurrence.ClaimDue(ctx, now, now.Add(-claimTimeout), executeDueBatchSize)
+	if err != nil {
+		a.logger.Error(err)
+		return pkgerror.ErrCantExecuteRecurringTransfers
+	}
+
+	for _, recurringTransfer := range recurringTransfers {
+		a.execute(ctx, now, recurringTransfer)
+	}
+	return nil
+}
+
+// execute attempts the current occurrence and stores the run and what must happen next. When funds are insufficient,
+// the occurrence is skipped or, under the retry policy, attempted again after retryDelay while there are retries left
+// and the next occurrence has not come. A failure of the infrastructure is attempted again after retryDelay, doubled
+// after each attempt, up to maxExecuteAttempts times before the occurrence is skipped. Accounts that are gone or no longer active fail the
+// recurring transfer for good, and any other error skips the occurrence. Only the instance that still has the
+// recurring transfer processing stores the outcome.
+func (a *appImpl) execute(ctx context.Context, now time.Time, recurringTransfer model.RecurringTransfer) {
+	run := model.RecurringTransferRun{
+		RecurringTransferID: recurringTransfer.ID,
+		OccurrenceAt:        recurringTransfer.NextOccurrenceAt,
+	}
+
+	_, err := a.transferApp.CreateWith(ctx, model.Transfer{
+		OriginAccountID: recurringTransfer.OriginAccountID,
+		TargetAccountID: recurringTransfer.TargetAccountID,
+		Amount:          recurringTransfer.Amount,
+	}, func(tx transaction.Transaction, transfer model.Transfer) error {
+		executed, executedRun := recurringTransfer, run
+		executedRun.Status = model.RecurringTransferRunStatusExecuted
+		executedRun.TransferID = &transfer.ID
+		advance(&executed, now)
+		return a.store(ctx, a.repoRecurrence.WithTransaction(tx), executed, executedRun)
+	})
+	if err == nil {
+		return
+	}
+	if err != pkgerror.ErrInsufficientFunds {
+		reason := err.Error()
+		run.FailureReason = &reason
+	}
+	switch {
+	case err == pkgerror.ErrInsufficientFunds:
+		nextAttemptAt := now.Add(retryDelay)
+		canRetry := recurringTransfer.InsufficientFundsPolicy == model.RecurrencePolicyRetry &&
+			recurringTransfer.Retries < recurringTransfer.MaxRetries &&
+			nextAttemptAt.Before(getNextOccurrence(recurringTransfer.RecurrenceRule, recurringTransfer.NextOccurrenceAt))
+		if !canRetry {
+			run.Status = model.RecurringTransferRunStatusSkipped
+			advance(&recurringTransfer, now)
+			break
+		}
+		run.Status = model.RecurringTransferRunStatusFailedInsufficientFunds
+		recurringTransfer.Status = model.RecurringTransferStatusActive
+		recurringTransfer.Retries++
+		recurringTransfer.NextAttemptAt = nextAttemptAt
+	case err == pkgerror.ErrCantCreateTransfer:
+		a.logger.Error(err)
+		run.Status = model.RecurringTransferRunStatusFailed
+		recurringTransfer.Attempts++
+		if recurringTransfer.Attempts >= maxExecuteAttempts {
+			advance(&recurringTransfer, now)
+			break
+		}
+		recurringTransfer.Status = model.RecurringTransferStatusActive
+		recurringTransfer.NextAttemptAt = now.Add(retryDelay << (recurringTransfer.Attempts - 1))
+	case err == pkgerror.ErrOriginAccountTransferNotFound,
+		err == pkgerror.ErrTargetAccountTransferNotFound,
+		err == pkgerror.ErrOriginAccountNotActive,
+		err == pkgerror.ErrTargetAccountNotActive:
+		run.Status = model.RecurringTransferRunStatusFailed
+		recurringTransfer.Status = model.RecurringTransferStatusFailed
+		recurringTransfer.FailureReason = run.FailureReason
+	default:
+		run.Status = model.RecurringTransferRunStatusFailed
+		advance(&recurringTransfer, now)
+	}
+
+	if err = a.store(ctx, a.repoRecurrence, recurringTransfer, run); err != nil {
+		a.logger.Error(err)
+	}
+}
+
+// store updates the recurring transfer and records the run of the occurrence, unless the recurring transfer is no
+// longer processing.
+func (a *appImpl) store(ctx context.Context, repoRecurrence recurrence.Repository, recurringTransfer model.RecurringTransfer, run model.RecurringTransferRun) error {
+	updated, err := repoRecurrence.UpdateProgress(ctx, recurringTransfer)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return pkgerror.ErrRecurringTransferNotProcessing
+	}
+	return repoRecurrence.CreateRun(ctx, run)
+}
+
+// advance moves the recurring transfer to its first occurrence after now, finishing it when the rule has no
+// occurrences left. Occurrences missed while the workers were down count as passed but are not made, so a late
+// attempt doesn't make all of them in a row.
+func advance(recurringTransfer *model.RecurringTransfer, now time.Time) {
+	rule := recurringTransfer.RecurrenceRule
+	next := getNextOccurrence(rule, recurringTransfer.NextOccurrenceAt)
+	recurringTransfer.Occurrences++
+	for !next.After(now) && !isFinished(rule, recurringTransfer.Occurrences, next) {
+		next = getNextOccurrence(rule, next)
+		recurringTransfer.Occurrences++
+	}
+
+	recurringTransfer.Retries = 0
+	recurringTransfer.Attempts = 0
+	recurringTransfer.NextOccurrenceAt = next
+	recurringTransfer.NextAttemptAt = next
+	recurringTransfer.Status = model.RecurringTransferStatusActive
+
+	if isFinished(rule, recurringTransfer.Occurrences, next) {
+		recurringTransfer.Status = model.RecurringTransferStatusFinished
+	}
+}
+
+// isFinished tells whether a rule that already had occurrences has no occurrence left from next on.
+func isFinished(rule model.RecurrenceRule, occurrences int, next time.Time) bool {
+	return (rule.MaxOccurrences != nil && occurrences >= *rule.MaxOccurrences) ||
+		(rule.EndAt != nil && next.After(*rule.EndAt))
+}
+
+func getFirstOccurrence(rule model.RecurrenceRule) time.Time {
+	if rule.Frequency != model.RecurrenceFrequencyMonthly {
+		return rule.StartAt
+	}
+	occurrence := getMonthlyOccurrence(rule.StartAt, 0, *rule.DayOfMonth)
+	if occurrence.Before(rule.StartAt) {
+		return getMonthlyOccurrence(rule.StartAt, 1, *rule.DayOfMonth)
+	}
+	return occurrence
+}
+
+func getNextOccurrence(rule model.RecurrenceRule, current time.Time) time.Time {
+	switch rule.Frequency {
+	case model.RecurrenceFrequencyWeekly:
+		return current.AddDate(0, 0, 7)
+	case model.RecurrenceFrequencyMonthly:
+		return getMonthlyOccurrence(current, 1, *rule.DayOfMonth)
+	default:
+		return current.AddDate(0, 0, 1)
+	}
+}
+
+// getMonthlyOccurrence returns the given day of the month that is months after the month of base, keeping the clock
+// of base. Days past the end of a short month fall on its last day.
+func getMonthlyOccurrence(base time.Time, months int, day int) time.Time {
+	firstDay := time.Date(base.Year(), base.Month()+time.Month(months), 1, 0, 0, 0, 0, base.Location())
+	lastDay := firstDay.AddDate(0, 1, -1).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(firstDay.Year(), firstDay.Month(), day, base.Hour(), base.Minute(), base.Second(), 0, base.Location())
+}
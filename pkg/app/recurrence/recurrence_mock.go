@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: recurrence.go
+
+// Package recurrence is a generated GoMock package.
+package recurrence
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/carlosrodriguesf/bank-api/pkg/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockApp is a mock of App interface.
+type MockApp struct {
+	ctrl     *gomock.Controller
+	recorder *MockAppMockRecorder
+}
+
+// MockAppMockRecorder is the mock recorder for MockApp.
+type MockAppMockRecorder struct {
+	mock *MockApp
+}
+
+// NewMockApp creates a new mock instance.
+func NewMockApp(ctrl *gomock.Controller) *MockApp {
+	mock := &MockApp{ctrl: ctrl}
+	mock.recorder = &MockAppMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApp) EXPECT() *MockAppMockRecorder {
+	return m.recorder
+}
+
+// Cancel mocks base method.
+func (m *MockApp) Cancel(ctx context.Context, accountID, id string) (*model.RecurringTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", ctx, accountID, id)
+	ret0, _ := ret[0].(*model.RecurringTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockAppMockRecorder) Cancel(ctx, accountID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockApp)(nil).Cancel), ctx, accountID, id)
+}
+
+// Create mocks base method.
+func (m *MockApp) Create(ctx context.Context, recurringTransfer model.RecurringTransfer) (*model.RecurringTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, recurringTransfer)
+	ret0, _ := ret[0].(*model.RecurringTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAppMockRecorder) Create(ctx, recurringTransfer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockApp)(nil).Create), ctx, recurringTransfer)
+}
+
+// ExecuteDue mocks base method.
+func (m *MockApp) ExecuteDue(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecuteDue", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExecuteDue indicates an expected call of ExecuteDue.
+func (mr *MockAppMockRecorder) ExecuteDue(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteDue", reflect.TypeOf((*MockApp)(nil).ExecuteDue), ctx)
+}
+
+// List mocks base method.
+func (m *MockApp) List(ctx context.Context, accountID string) ([]model.RecurringTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, accountID)
+	ret0, _ := ret[0].([]model.RecurringTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockAppMockRecorder) List(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockApp)(nil).List), ctx, accountID)
+}
+
+// ListRuns mocks base method.
+func (m *MockApp) ListRuns(ctx context.Context, accountID, id string) ([]model.RecurringTransferRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRuns", ctx, accountID, id)
+	ret0, _ := ret[0].([]model.RecurringTransferRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRuns indicates an expected call of ListRuns.
+func (mr *MockAppMockRecorder) ListRuns(ctx, accountID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRuns", reflect.TypeOf((*MockApp)(nil).ListRuns), ctx, accountID, id)
+}
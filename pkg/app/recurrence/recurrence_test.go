@@ -0,0 +1,653 @@
+package recurrence
+
+import (
+	"context"
+	"errors"
+	"github.com/carlosrodriguesf/bank-api/pkg/app/transfer"
+	pkgerror "github.com/carlosrodriguesf/bank-api/pkg/error"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/recurrence"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/generate"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/transaction"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/validator"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func TestCreate(t *testing.T) {
+	var (
+		currentTime = time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+		startAt     = time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC)
+		createData  = model.RecurringTransfer{
+			OriginAccountID: "origin_account_id",
+			TargetAccountID: "target_document",
+			Amount:          500,
+			RecurrenceRule: model.RecurrenceRule{
+				Frequency:  model.RecurrenceFrequencyMonthly,
+				DayOfMonth: intPtr(5),
+				StartAt:    startAt,
+			},
+			InsufficientFundsPolicy: model.RecurrencePolicySkip,
+		}
+		firstOccurrence = time.Date(2026, 2, 5, 9, 0, 0, 0, time.UTC)
+		repoCreateData  = func() model.RecurringTransfer {
+			data := createData
+			data.TargetAccountID = "target_account_id"
+			data.Status = model.RecurringTransferStatusActive
+			data.NextOccurrenceAt = firstOccurrence
+			data.NextAttemptAt = firstOccurrence
+			return data
+		}()
+		createdData = func() model.RecurringTransfer {
+			data := repoCreateData
+			data.ID = "recurring_transfer_id"
+			data.CreatedAt = currentTime
+			return data
+		}()
+		authorizeData = model.Transfer{
+			OriginAccountID: createData.OriginAccountID,
+			TargetAccountID: createData.TargetAccountID,
+			Amount:          createData.Amount,
+		}
+		authorizedData = model.Transfer{OriginAccountID: "origin_account_id", TargetAccountID: "target_account_id"}
+	)
+	cases := map[string]struct {
+		InputData                 model.RecurringTransfer
+		ExpectedData              *model.RecurringTransfer
+		ExpectedError             error
+		PrepareMockTransferApp    func(mock *transfer.MockApp)
+		PrepareMockRepoRecurrence func(mock *recurrence.MockRepository)
+	}{
+		"should return success": {
+			InputData:     createData,
+			ExpectedData:  &createdData,
+			ExpectedError: nil,
+			PrepareMockTransferApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().Authorize(gomock.Any(), authorizeData).Return(&authorizedData, nil)
+			},
+			PrepareMockRepoRecurrence: func(mock *recurrence.MockRepository) {
+				mock.EXPECT().
+					Create(gomock.Any(), repoCreateData).
+					Return(&model.GeneratedData{ID: createdData.ID, CreatedAt: currentTime}, nil)
+			},
+		},
+		"should return error: start at in past": {
+			InputData: func() model.RecurringTransfer {
+				data := createData
+				data.StartAt = currentTime
+				return data
+			}(),
+			ExpectedData:              nil,
+			ExpectedError:             pkgerror.ErrRecurrenceStartAtInPast,
+			PrepareMockTransferApp:    func(mock *transfer.MockApp) {},
+			PrepareMockRepoRecurrence: func(mock *recurrence.MockRepository) {},
+		},
+		"should return error: end at before first occurrence": {
+			InputData: func() model.RecurringTransfer {
+				endAt := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+				data := createData
+				data.EndAt = &endAt
+				return data
+			}(),
+			ExpectedData:              nil,
+			ExpectedError:             pkgerror.ErrRecurrenceEndAtBeforeStart,
+			PrepareMockTransferApp:    func(mock *transfer.MockApp) {},
+			PrepareMockRepoRecurrence: func(mock *recurrence.MockRepository) {},
+		},
+		"should return error: target account not found": {
+			InputData:     createData,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrTargetAccountTransferNotFound,
+			PrepareMockTransferApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().Authorize(gomock.Any(), authorizeData).Return(nil, pkgerror.ErrTargetAccountTransferNotFound)
+			},
+			PrepareMockRepoRecurrence: func(mock *recurrence.MockRepository) {},
+		},
+		"should return error: two factor required": {
+			InputData:     createData,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrTwoFactorRequired,
+			PrepareMockTransferApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().Authorize(gomock.Any(), authorizeData).Return(nil, pkgerror.ErrTwoFactorRequired)
+			},
+			PrepareMockRepoRecurrence: func(mock *recurrence.MockRepository) {},
+		},
+		"should return error on authorize": {
+			InputData:     createData,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantCreateRecurringTransfer,
+			PrepareMockTransferApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().Authorize(gomock.Any(), authorizeData).Return(nil, pkgerror.ErrCantCreateTransfer)
+			},
+			PrepareMockRepoRecurrence: func(mock *recurrence.MockRepository) {},
+		},
+		"should return error on create": {
+			InputData:     createData,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantCreateRecurringTransfer,
+			PrepareMockTransferApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().Authorize(gomock.Any(), authorizeData).Return(&authorizedData, nil)
+			},
+			PrepareMockRepoRecurrence: func(mock *recurrence.MockRepository) {
+				mock.EXPECT().Create(gomock.Any(), repoCreateData).Return(nil, errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx          = gomock.WithContext(context.Background(), t)
+				mockValidator      = validator.NewMockValidator(ctrl)
+				mockGenerate       = generate.NewMockGenerate(ctrl)
+				mockRepoRecurrence = recurrence.NewMockRepository(ctrl)
+				mockTransferApp    = transfer.NewMockApp(ctrl)
+				app                = NewApp(Options{
+					Logger:         logger.New(""),
+					Validator:      mockValidator,
+					Generate:       mockGenerate,
+					RepoRecurrence: mockRepoRecurrence,
+					TransferApp:    mockTransferApp,
+				})
+			)
+
+			mockValidator.EXPECT().Validate(cs.InputData).Return(nil)
+			mockGenerate.EXPECT().CurrentTime().Return(currentTime)
+			cs.PrepareMockRepoRecurrence(mockRepoRecurrence)
+			cs.PrepareMockTransferApp(mockTransferApp)
+
+			data, err := app.Create(ctx, cs.InputData)
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestListRuns(t *testing.T) {
+	var (
+		recurringTransferExample = model.RecurringTransfer{
+			ID:              "recurring_transfer_id",
+			OriginAccountID: "origin_account_id",
+		}
+		runsExample = []model.RecurringTransferRun{{
+			ID:                  "run_id",
+			RecurringTransferID: recurringTransferExample.ID,
+			Status:              model.RecurringTransferRunStatusExecuted,
+		}}
+	)
+	cases := map[string]struct {
+		InputAccountID            string
+		ExpectedData              []model.RecurringTransferRun
+		ExpectedError             error
+		PrepareMockRepoRecurrence func(mock *recurrence.MockRepository)
+	}{
+		"should return success": {
+			InputAccountID: "origin_account_id",
+			ExpectedData:   runsExample,
+			ExpectedError:  nil,
+			PrepareMockRepoRecurrence: func(mock *recurrence.MockRepository) {
+				mock.EXPECT().GetByID(gomock.Any(), recurringTransferExample.ID).Return(&recurringTransferExample, nil)
+				mock.EXPECT().ListRuns(gomock.Any(), recurringTransferExample.ID).Return(runsExample, nil)
+			},
+		},
+		"should return error: owned by another account": {
+			InputAccountID: "another_account_id",
+			ExpectedData:   nil,
+			ExpectedError:  pkgerror.ErrRecurringTransferNotFound,
+			PrepareMockRepoRecurrence: func(mock *recurrence.MockRepository) {
+				mock.EXPECT().GetByID(gomock.Any(), recurringTransferExample.ID).Return(&recurringTransferExample, nil)
+			},
+		},
+		"should return error on list runs": {
+			InputAccountID: "origin_account_id",
+			ExpectedData:   nil,
+			ExpectedError:  pkgerror.ErrCantListRecurringTransferRuns,
+			PrepareMockRepoRecurrence: func(mock *recurrence.MockRepository) {
+				mock.EXPECT().GetByID(gomock.Any(), recurringTransferExample.ID).Return(&recurringTransferExample, nil)
+				mock.EXPECT().ListRuns(gomock.Any(), recurringTransferExample.ID).Return(nil, errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx          = gomock.WithContext(context.Background(), t)
+				mockRepoRecurrence = recurrence.NewMockRepository(ctrl)
+				app                = NewApp(Options{
+					Logger:         logger.New(""),
+					RepoRecurrence: mockRepoRecurrence,
+				})
+			)
+
+			cs.PrepareMockRepoRecurrence(mockRepoRecurrence)
+
+			data, err := app.ListRuns(ctx, cs.InputAccountID, recurringTransferExample.ID)
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestCancel(t *testing.T) {
+	recurringTransferExample := model.RecurringTransfer{
+		ID:              "recurring_transfer_id",
+		OriginAccountID: "origin_account_id",
+		Status:          model.RecurringTransferStatusActive,
+	}
+	cases := map[string]struct {
+		ExpectedData              *model.RecurringTransfer
+		ExpectedError             error
+		PrepareMockRepoRecurrence func(mock *recurrence.MockRepository)
+	}{
+		"should return success": {
+			ExpectedData: &model.RecurringTransfer{
+				ID:              recurringTransferExample.ID,
+				OriginAccountID: recurringTransferExample.OriginAccountID,
+				Status:          model.RecurringTransferStatusCancelled,
+			},
+			ExpectedError: nil,
+			PrepareMockRepoRecurrence: func(mock *recurrence.MockRepository) {
+				recurringTransfer := recurringTransferExample
+				mock.EXPECT().GetByID(gomock.Any(), recurringTransferExample.ID).Return(&recurringTransfer, nil)
+				mock.EXPECT().Cancel(gomock.Any(), recurringTransferExample.ID).Return(true, nil)
+			},
+		},
+		"should return error: not found": {
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrRecurringTransferNotFound,
+			PrepareMockRepoRecurrence: func(mock *recurrence.MockRepository) {
+				mock.EXPECT().GetByID(gomock.Any(), recurringTransferExample.ID).Return(nil, nil)
+			},
+		},
+		"should return error: not active": {
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrRecurringTransferNotActive,
+			PrepareMockRepoRecurrence: func(mock *recurrence.MockRepository) {
+				recurringTransfer := recurringTransferExample
+				mock.EXPECT().GetByID(gomock.Any(), recurringTransferExample.ID).Return(&recurringTransfer, nil)
+				mock.EXPECT().Cancel(gomock.Any(), recurringTransferExample.ID).Return(false, nil)
+			},
+		},
+		"should return error on cancel": {
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantCancelRecurringTransfer,
+			PrepareMockRepoRecurrence: func(mock *recurrence.MockRepository) {
+				recurringTransfer := recurringTransferExample
+				mock.EXPECT().GetByID(gomock.Any(), recurringTransferExample.ID).Return(&recurringTransfer, nil)
+				mock.EXPECT().Cancel(gomock.Any(), recurringTransferExample.ID).Return(false, errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx          = gomock.WithContext(context.Background(), t)
+				mockRepoRecurrence = recurrence.NewMockRepository(ctrl)
+				app                = NewApp(Options{
+					Logger:         logger.New(""),
+					RepoRecurrence: mockRepoRecurrence,
+				})
+			)
+
+			cs.PrepareMockRepoRecurrence(mockRepoRecurrence)
+
+			data, err := app.Cancel(ctx, "origin_account_id", recurringTransferExample.ID)
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestExecuteDue(t *testing.T) {
+	var (
+		currentTime  = time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC)
+		occurrence   = time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC)
+		nextDay      = occurrence.AddDate(0, 0, 1)
+		transferID   = "transfer_id"
+		txExample    = transaction.Transaction(nil)
+		transferData = model.Transfer{
+			OriginAccountID: "origin_account_id",
+			TargetAccountID: "target_account_id",
+			Amount:          500,
+		}
+		claimed = func(policy string, maxRetries int, retries int, maxOccurrences *int) model.RecurringTransfer {
+			return model.RecurringTransfer{
+				ID:              "recurring_transfer_id",
+				OriginAccountID: transferData.OriginAccountID,
+				TargetAccountID: transferData.TargetAccountID,
+				Amount:          transferData.Amount,
+				RecurrenceRule: model.RecurrenceRule{
+					Frequency:      model.RecurrenceFrequencyDaily,
+					StartAt:        occurrence,
+					MaxOccurrences: maxOccurrences,
+				},
+				InsufficientFundsPolicy: policy,
+				MaxRetries:              maxRetries,
+				Status:                  model.RecurringTransferStatusProcessing,
+				Retries:                 retries,
+				NextOccurrenceAt:        occurrence,
+				NextAttemptAt:           occurrence,
+			}
+		}
+		updated = func(data model.RecurringTransfer, update func(data *model.RecurringTransfer)) model.RecurringTransfer {
+			update(&data)
+			return data
+		}
+		run = func(status string, transferID *string, reason string) model.RecurringTransferRun {
+			data := model.RecurringTransferRun{
+				RecurringTransferID: "recurring_transfer_id",
+				OccurrenceAt:        occurrence,
+				Status:              status,
+				TransferID:          transferID,
+			}
+			if reason != "" {
+				data.FailureReason = &reason
+			}
+			return data
+		}
+		// createWith makes the transfer as transfer.App.CreateWith does, failing it with transferErr or when record
+		// fails.
+		createWith = func(transferErr error) func(context.Context, model.Transfer, transfer.RecordFunc) (*model.Transfer, error) {
+			return func(_ context.Context, _ model.Transfer, record transfer.RecordFunc) (*model.Transfer, error) {
+				if transferErr != nil {
+					return nil, transferErr
+				}
+				if err := record(txExample, model.Transfer{ID: transferID}); err != nil {
+					return nil, pkgerror.ErrCantCreateTransfer
+				}
+				return &model.Transfer{ID: transferID}, nil
+			}
+		}
+	)
+	cases := map[string]struct {
+		InputData      model.RecurringTransfer
+		TransferErr    error
+		ExpectedRun    model.RecurringTransferRun
+		ExpectedUpdate model.RecurringTransfer
+	}{
+		"should execute and move to next occurrence": {
+			InputData:   claimed(model.RecurrencePolicySkip, 0, 0, nil),
+			TransferErr: nil,
+			ExpectedRun: run(model.RecurringTransferRunStatusExecuted, &transferID, ""),
+			ExpectedUpdate: updated(claimed(model.RecurrencePolicySkip, 0, 0, nil), func(data *model.RecurringTransfer) {
+				data.Status = model.RecurringTransferStatusActive
+				data.Occurrences = 1
+				data.NextOccurrenceAt = nextDay
+				data.NextAttemptAt = nextDay
+			}),
+		},
+		"should execute and finish on last occurrence": {
+			InputData:   claimed(model.RecurrencePolicySkip, 0, 0, intPtr(1)),
+			TransferErr: nil,
+			ExpectedRun: run(model.RecurringTransferRunStatusExecuted, &transferID, ""),
+			ExpectedUpdate: updated(claimed(model.RecurrencePolicySkip, 0, 0, intPtr(1)), func(data *model.RecurringTransfer) {
+				data.Status = model.RecurringTransferStatusFinished
+				data.Occurrences = 1
+				data.NextOccurrenceAt = nextDay
+				data.NextAttemptAt = nextDay
+			}),
+		},
+		"should skip occurrence on insufficient funds": {
+			InputData:   claimed(model.RecurrencePolicySkip, 0, 0, nil),
+			TransferErr: pkgerror.ErrInsufficientFunds,
+			ExpectedRun: run(model.RecurringTransferRunStatusSkipped, nil, ""),
+			ExpectedUpdate: updated(claimed(model.RecurrencePolicySkip, 0, 0, nil), func(data *model.RecurringTransfer) {
+				data.Status = model.RecurringTransferStatusActive
+				data.Occurrences = 1
+				data.NextOccurrenceAt = nextDay
+				data.NextAttemptAt = nextDay
+			}),
+		},
+		"should retry occurrence on insufficient funds": {
+			InputData:   claimed(model.RecurrencePolicyRetry, 2, 0, nil),
+			TransferErr: pkgerror.ErrInsufficientFunds,
+			ExpectedRun: run(model.RecurringTransferRunStatusFailedInsufficientFunds, nil, ""),
+			ExpectedUpdate: updated(claimed(model.RecurrencePolicyRetry, 2, 0, nil), func(data *model.RecurringTransfer) {
+				data.Status = model.RecurringTransferStatusActive
+				data.Retries = 1
+				data.NextAttemptAt = currentTime.Add(retryDelay)
+			}),
+		},
+		"should skip occurrence when retries are exhausted": {
+			InputData:   claimed(model.RecurrencePolicyRetry, 2, 2, nil),
+			TransferErr: pkgerror.ErrInsufficientFunds,
+			ExpectedRun: run(model.RecurringTransferRunStatusSkipped, nil, ""),
+			ExpectedUpdate: updated(claimed(model.RecurrencePolicyRetry, 2, 2, nil), func(data *model.RecurringTransfer) {
+				data.Status = model.RecurringTransferStatusActive
+				data.Occurrences = 1
+				data.Retries = 0
+				data.NextOccurrenceAt = nextDay
+				data.NextAttemptAt = nextDay
+			}),
+		},
+		"should try again later on unexpected error": {
+			InputData:   claimed(model.RecurrencePolicySkip, 0, 0, nil),
+			TransferErr: pkgerror.ErrCantCreateTransfer,
+			ExpectedRun: run(model.RecurringTransferRunStatusFailed, nil, pkgerror.ErrCantCreateTransfer.Error()),
+			ExpectedUpdate: updated(claimed(model.RecurrencePolicySkip, 0, 0, nil), func(data *model.RecurringTransfer) {
+				data.Status = model.RecurringTransferStatusActive
+				data.Attempts = 1
+				data.NextAttemptAt = currentTime.Add(retryDelay)
+			}),
+		},
+		"should wait longer after each unexpected error": {
+			InputData: updated(claimed(model.RecurrencePolicySkip, 0, 0, nil), func(data *model.RecurringTransfer) {
+				data.Attempts = 2
+			}),
+			TransferErr: pkgerror.ErrCantCreateTransfer,
+			ExpectedRun: run(model.RecurringTransferRunStatusFailed, nil, pkgerror.ErrCantCreateTransfer.Error()),
+			ExpectedUpdate: updated(claimed(model.RecurrencePolicySkip, 0, 0, nil), func(data *model.RecurringTransfer) {
+				data.Status = model.RecurringTransferStatusActive
+				data.Attempts = 3
+				data.NextAttemptAt = currentTime.Add(4 * retryDelay)
+			}),
+		},
+		"should skip occurrence when attempts are exhausted": {
+			InputData: updated(claimed(model.RecurrencePolicySkip, 0, 0, nil), func(data *model.RecurringTransfer) {
+				data.Attempts = maxExecuteAttempts - 1
+			}),
+			TransferErr: pkgerror.ErrCantCreateTransfer,
+			ExpectedRun: run(model.RecurringTransferRunStatusFailed, nil, pkgerror.ErrCantCreateTransfer.Error()),
+			ExpectedUpdate: updated(claimed(model.RecurrencePolicySkip, 0, 0, nil), func(data *model.RecurringTransfer) {
+				data.Status = model.RecurringTransferStatusActive
+				data.Occurrences = 1
+				data.NextOccurrenceAt = nextDay
+				data.NextAttemptAt = nextDay
+			}),
+		},
+		"should skip occurrence on transfer limit": {
+			InputData:   claimed(model.RecurrencePolicySkip, 0, 0, nil),
+			TransferErr: &pkgerror.TransferLimitError{Limit: model.LimitDaily},
+			ExpectedRun: run(model.RecurringTransferRunStatusFailed, nil, pkgerror.ErrTransferLimitExceeded.Error()),
+			ExpectedUpdate: updated(claimed(model.RecurrencePolicySkip, 0, 0, nil), func(data *model.RecurringTransfer) {
+				data.Status = model.RecurringTransferStatusActive
+				data.Occurrences = 1
+				data.NextOccurrenceAt = nextDay
+				data.NextAttemptAt = nextDay
+			}),
+		},
+		"should fail for good when an account is not active": {
+			InputData:   claimed(model.RecurrencePolicySkip, 0, 0, nil),
+			TransferErr: pkgerror.ErrTargetAccountNotActive,
+			ExpectedRun: run(model.RecurringTransferRunStatusFailed, nil, pkgerror.ErrTargetAccountNotActive.Error()),
+			ExpectedUpdate: updated(claimed(model.RecurrencePolicySkip, 0, 0, nil), func(data *model.RecurringTransfer) {
+				reason := pkgerror.ErrTargetAccountNotActive.Error()
+				data.Status = model.RecurringTransferStatusFailed
+				data.FailureReason = &reason
+			}),
+		},
+		"should skip occurrences missed while the workers were down": {
+			InputData: updated(claimed(model.RecurrencePolicySkip, 0, 0, nil), func(data *model.RecurringTransfer) {
+				data.NextOccurrenceAt = occurrence.AddDate(0, 0, -3)
+				data.NextAttemptAt = occurrence.AddDate(0, 0, -3)
+			}),
+			TransferErr: nil,
+			ExpectedRun: model.RecurringTransferRun{
+				RecurringTransferID: "recurring_transfer_id",
+				OccurrenceAt:        occurrence.AddDate(0, 0, -3),
+				Status:              model.RecurringTransferRunStatusExecuted,
+				TransferID:          &transferID,
+			},
+			ExpectedUpdate: updated(claimed(model.RecurrencePolicySkip, 0, 0, nil), func(data *model.RecurringTransfer) {
+				data.Status = model.RecurringTransferStatusActive
+				data.Occurrences = 4
+				data.NextOccurrenceAt = nextDay
+				data.NextAttemptAt = nextDay
+			}),
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx          = gomock.WithContext(context.Background(), t)
+				mockGenerate       = generate.NewMockGenerate(ctrl)
+				mockRepoRecurrence = recurrence.NewMockRepository(ctrl)
+				mockTransferApp    = transfer.NewMockApp(ctrl)
+				app                = NewApp(Options{
+					Logger:         logger.New(""),
+					Generate:       mockGenerate,
+					RepoRecurrence: mockRepoRecurrence,
+					TransferApp:    mockTransferApp,
+				})
+			)
+
+			mockGenerate.EXPECT().CurrentTime().Return(currentTime)
+			mockRepoRecurrence.EXPECT().
+				ClaimDue(gomock.Any(), currentTime, currentTime.Add(-claimTimeout), executeDueBatchSize).
+				Return([]model.RecurringTransfer{cs.InputData}, nil)
+			mockTransferApp.EXPECT().
+				CreateWith(gomock.Any(), transferData, gomock.Any()).
+				DoAndReturn(createWith(cs.TransferErr))
+			if cs.TransferErr == nil {
+				mockRepoRecurrence.EXPECT().WithTransaction(txExample).Return(mockRepoRecurrence)
+			}
+			gomock.InOrder(
+				mockRepoRecurrence.EXPECT().UpdateProgress(gomock.Any(), cs.ExpectedUpdate).Return(true, nil),
+				mockRepoRecurrence.EXPECT().CreateRun(gomock.Any(), cs.ExpectedRun).Return(nil),
+			)
+
+			err := app.ExecuteDue(ctx)
+
+			assert.NoError(t, err)
+		})
+	}
+
+	t.Run("should not make the occurrence when another instance stored the outcome", func(t *testing.T) {
+		var (
+			ctrl, ctx          = gomock.WithContext(context.Background(), t)
+			mockGenerate       = generate.NewMockGenerate(ctrl)
+			mockRepoRecurrence = recurrence.NewMockRepository(ctrl)
+			mockTransferApp    = transfer.NewMockApp(ctrl)
+			app                = NewApp(Options{
+				Logger:         logger.New(""),
+				Generate:       mockGenerate,
+				RepoRecurrence: mockRepoRecurrence,
+				TransferApp:    mockTransferApp,
+			})
+			data = claimed(model.RecurrencePolicySkip, 0, 0, nil)
+		)
+
+		mockGenerate.EXPECT().CurrentTime().Return(currentTime)
+		mockRepoRecurrence.EXPECT().
+			ClaimDue(gomock.Any(), currentTime, currentTime.Add(-claimTimeout), executeDueBatchSize).
+			Return([]model.RecurringTransfer{data}, nil)
+		mockTransferApp.EXPECT().
+			CreateWith(gomock.Any(), transferData, gomock.Any()).
+			DoAndReturn(createWith(nil))
+		mockRepoRecurrence.EXPECT().WithTransaction(txExample).Return(mockRepoRecurrence)
+		mockRepoRecurrence.EXPECT().
+			UpdateProgress(gomock.Any(), updated(data, func(data *model.RecurringTransfer) {
+				data.Status = model.RecurringTransferStatusActive
+				data.Occurrences = 1
+				data.NextOccurrenceAt = nextDay
+				data.NextAttemptAt = nextDay
+			})).
+			Return(false, nil)
+		mockRepoRecurrence.EXPECT().
+			UpdateProgress(gomock.Any(), updated(data, func(data *model.RecurringTransfer) {
+				data.Status = model.RecurringTransferStatusActive
+				data.Attempts = 1
+				data.NextAttemptAt = currentTime.Add(retryDelay)
+			})).
+			Return(false, nil)
+
+		err := app.ExecuteDue(ctx)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return error on claim", func(t *testing.T) {
+		var (
+			ctrl, ctx          = gomock.WithContext(context.Background(), t)
+			mockGenerate       = generate.NewMockGenerate(ctrl)
+			mockRepoRecurrence = recurrence.NewMockRepository(ctrl)
+			app                = NewApp(Options{
+				Logger:         logger.New(""),
+				Generate:       mockGenerate,
+				RepoRecurrence: mockRepoRecurrence,
+			})
+		)
+
+		mockGenerate.EXPECT().CurrentTime().Return(currentTime)
+		mockRepoRecurrence.EXPECT().
+			ClaimDue(gomock.Any(), currentTime, currentTime.Add(-claimTimeout), executeDueBatchSize).
+			Return(nil, errors.New("fail"))
+
+		err := app.ExecuteDue(ctx)
+
+		assert.Equal(t, pkgerror.ErrCantExecuteRecurringTransfers, err)
+	})
+}
+
+func TestGetNextOccurrence(t *testing.T) {
+	cases := map[string]struct {
+		InputRule    model.RecurrenceRule
+		InputCurrent time.Time
+		ExpectedData time.Time
+	}{
+		"should return next day": {
+			InputRule:    model.RecurrenceRule{Frequency: model.RecurrenceFrequencyDaily},
+			InputCurrent: time.Date(2026, 1, 31, 9, 0, 0, 0, time.UTC),
+			ExpectedData: time.Date(2026, 2, 1, 9, 0, 0, 0, time.UTC),
+		},
+		"should return next week": {
+			InputRule:    model.RecurrenceRule{Frequency: model.RecurrenceFrequencyWeekly},
+			InputCurrent: time.Date(2026, 1, 31, 9, 0, 0, 0, time.UTC),
+			ExpectedData: time.Date(2026, 2, 7, 9, 0, 0, 0, time.UTC),
+		},
+		"should return last day of a short month": {
+			InputRule:    model.RecurrenceRule{Frequency: model.RecurrenceFrequencyMonthly, DayOfMonth: intPtr(31)},
+			InputCurrent: time.Date(2026, 1, 31, 9, 0, 0, 0, time.UTC),
+			ExpectedData: time.Date(2026, 2, 28, 9, 0, 0, 0, time.UTC),
+		},
+		"should return day of month after a short month": {
+			InputRule:    model.RecurrenceRule{Frequency: model.RecurrenceFrequencyMonthly, DayOfMonth: intPtr(31)},
+			InputCurrent: time.Date(2026, 2, 28, 9, 0, 0, 0, time.UTC),
+			ExpectedData: time.Date(2026, 3, 31, 9, 0, 0, 0, time.UTC),
+		},
+		"should return day of month in next year": {
+			InputRule:    model.RecurrenceRule{Frequency: model.RecurrenceFrequencyMonthly, DayOfMonth: intPtr(5)},
+			InputCurrent: time.Date(2026, 12, 5, 9, 0, 0, 0, time.UTC),
+			ExpectedData: time.Date(2027, 1, 5, 9, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, cs.ExpectedData, getNextOccurrence(cs.InputRule, cs.InputCurrent))
+		})
+	}
+}
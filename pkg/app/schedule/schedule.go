@@ -0,0 +1,191 @@
+//go:generate mockgen -source=${GOFILE} -package=${GOPACKAGE} -destination=${GOPACKAGE}_mock.go
+
+package schedule
+
+import (
+	"context"
+	"github.com/carlosrodriguesf/bank-api/pkg/app/transfer"
+	pkgerror "github.com/carlosrodriguesf/bank-api/pkg/error"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/schedule"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/generate"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/transaction"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/validator"
+	"time"
+)
+
+const (
+	executeDueBatchSize = 100
+	// maxExecuteAttempts is how many times a scheduled transfer is attempted while the infrastructure fails. The
+	// attempts are apart by retryDelay, doubled after each one, so a failure that lasts a while is not hammered.
+	maxExecuteAttempts = 5
+	retryDelay         = time.Minute
+	// claimTimeout is how long a claimed transfer may stay processing before it is taken as abandoned by an instance
+	// that stopped and is claimed again. It is far longer than an execution takes.
+	claimTimeout = 10 * time.Minute
+)
+
+type (
+	Options struct {
+		Logger       logger.Logger
+		Validator    validator.Validator
+		Generate     generate.Generate
+		RepoSchedule schedule.Repository
+		TransferApp  transfer.App
+	}
+	App interface {
+		Create(ctx context.Context, scheduledTransfer model.ScheduledTransfer) (*model.ScheduledTransfer, error)
+		List(ctx context.Context, accountID string, status string) ([]model.ScheduledTransfer, error)
+		Cancel(ctx context.Context, accountID string, id string) (*model.ScheduledTransfer, error)
+		ExecuteDue(ctx context.Context) error
+	}
+	appImpl struct {
+		logger       logger.Logger
+		validator    validator.Validator
+		generate     generate.Generate
+		repoSchedule schedule.Repository
+		transferApp  transfer.App
+	}
+)
+
+func NewApp(opts Options) App {
+	return &appImpl{
+		logger:       opts.Logger.WithLocation().WithPreffix("app.schedule"),
+		validator:    opts.Validator,
+		generate:     opts.Generate,
+		repoSchedule: opts.RepoSchedule,
+		transferApp:  opts.TransferApp,
+	}
+}
+
+// Create schedules the transfer after running the checks transfer.App.Authorize makes for a signed in user, since it
+// is made later by a worker with no session.
+func (a *appImpl) Create(ctx context.Context, scheduledTransfer model.ScheduledTransfer) (*model.ScheduledTransfer, error) {
+	if err := a.validator.Validate(scheduledTransfer); err != nil {
+		return nil, err
+	}
+	if !scheduledTransfer.ScheduledFor.After(a.generate.CurrentTime()) {
+		return nil, pkgerror.ErrScheduledForInPast
+	}
+
+	authorized, err := a.transferApp.Authorize(ctx, model.Transfer{
+		OriginAccountID: scheduledTransfer.OriginAccountID,
+		TargetAccountID: scheduledTransfer.TargetAccountID,
+		Amount:          scheduledTransfer.Amount,
+		TwoFactorCode:   scheduledTransfer.TwoFactorCode,
+	})
+	if err != nil {
+		if err == pkgerror.ErrCantCreateTransfer {
+			return nil, pkgerror.ErrCantCreateScheduledTransfer
+		}
+		return nil, err
+	}
+
+	scheduledTransfer.TargetAccountID = authorized.TargetAccountID
+	scheduledTransfer.Status = model.ScheduledTransferStatusPending
+
+	genData, err := a.repoSchedule.Create(ctx, scheduledTransfer)
+	if err != nil {
+		a.logger.Error(err)
+		return nil, pkgerror.ErrCantCreateScheduledTransfer
+	}
+
+	scheduledTransfer.ID = genData.ID
+	scheduledTransfer.CreatedAt = genData.CreatedAt
+
+	return &scheduledTransfer, nil
+}
+
+func (a *appImpl) List(ctx context.Context, accountID string, status string) ([]model.ScheduledTransfer, error) {
+	scheduledTransfers, err := a.repoSchedule.List(ctx, accountID, status)
+	if err != nil {
+		a.logger.Error(err)
+		return nil, pkgerror.ErrCantListScheduledTransfers
+	}
+	return scheduledTransfers, nil
+}
+
+func (a *appImpl) Cancel(ctx context.Context, accountID string, id string) (*model.ScheduledTransfer, error) {
+	scheduledTransfer, err := a.repoSchedule.GetByID(ctx, id)
+	if err != nil {
+		a.logger.Error(err)
+		return nil, pkgerror.ErrCantCancelScheduledTransfer
+	}
+	if scheduledTransfer == nil || scheduledTransfer.OriginAccountID != accountID {
+		return nil, pkgerror.ErrScheduledTransferNotFound
+	}
+
+	cancelled, err := a.repoSchedule.Cancel(ctx, id)
+	if err != nil {
+		a.logger.Error(err)
+		return nil, pkgerror.ErrCantCancelScheduledTransfer
+	}
+	if !cancelled {
+		return nil, pkgerror.ErrScheduledTransferNotPending
+	}
+
+	scheduledTransfer.Status = model.ScheduledTransferStatusCancelled
+	return scheduledTransfer, nil
+}
+
+// ExecuteDue claims the transfers whose date has come and executes each one through transfer.App.CreateWith.
+// A transfer that fails because of the infrastructure goes back to pending to be attempted again after a growing
+// delay, up to maxExecuteAttempts times. Any other error can't go away by trying again, so it fails the transfer for good.
+// Transfers left processing for longer than claimTimeout are claimed again. Since a transfer is marked as executed in
+// the transaction that makes it, one claimed again was not made and is never made twice.
+func (a *appImpl) ExecuteDue(ctx context.Context) error {
+	now := a.generate.CurrentTime()
+	scheduledTransfers, err := a.repoSchedule.ClaimDue(ctx, now, now.Add(-claimTimeout), executeDueBatchSize)
+	if err != nil {
+		a.logger.Error(err)
+		return pkgerror.ErrCantExecuteScheduledTransfers
+	}
+
+	for _, scheduledTransfer := range scheduledTransfers {
+		a.execute(ctx, now, scheduledTransfer)
+	}
+	return nil
+}
+
+// execute attempts the scheduled transfer and stores the outcome. Only the instance that still has the transfer
+// processing stores it, so an instance that took too long can't make the transfer again or overwrite the outcome.
+func (a *appImpl) execute(ctx context.Context, now time.Time, scheduledTransfer model.ScheduledTransfer) {
+	scheduledTransfer.Attempts++
+	_, err := a.transferApp.CreateWith(ctx, model.Transfer{
+		OriginAccountID: scheduledTransfer.OriginAccountID,
+		TargetAccountID: scheduledTransfer.TargetAccountID,
+		Amount:          scheduledTransfer.Amount,
+	}, func(tx transaction.Transaction, transfer model.Transfer) error {
+		executed := scheduledTransfer
+		executed.Status = model.ScheduledTransferStatusExecuted
+		executed.TransferID = &transfer.ID
+		updated, err := a.repoSchedule.WithTransaction(tx).UpdateProgress(ctx, executed)
+		if err != nil {
+			return err
+		}
+		if !updated {
+			return pkgerror.ErrScheduledTransferNotProcessing
+		}
+		return nil
+	})
+	switch {
+	case err == nil:
+		return
+	case err == pkgerror.ErrInsufficientFunds:
+		scheduledTransfer.Status = model.ScheduledTransferStatusFailedInsufficientFunds
+	case err == pkgerror.ErrCantCreateTransfer && scheduledTransfer.Attempts < maxExecuteAttempts:
+		nextAttemptAt := now.Add(retryDelay << (scheduledTransfer.Attempts - 1))
+		scheduledTransfer.Status = model.ScheduledTransferStatusPending
+		scheduledTransfer.NextAttemptAt = &nextAttemptAt
+	default:
+		a.logger.Error(err)
+		reason := err.Error()
+		scheduledTransfer.Status = model.ScheduledTransferStatusFailed
+		scheduledTransfer.FailureReason = &reason
+	}
+
+	if _, err = a.repoSchedule.UpdateProgress(ctx, scheduledTransfer); err != nil {
+		a.logger.Error(err)
+	}
+}
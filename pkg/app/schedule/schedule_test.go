@@ -0,0 +1,550 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"github.com/carlosrodriguesf/bank-api/pkg/app/transfer"
+	pkgerror "github.com/carlosrodriguesf/bank-api/pkg/error"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/schedule"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/generate"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/transaction"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/validator"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestCreate(t *testing.T) {
+	var (
+		currentTime = time.Now()
+		createData  = model.ScheduledTransfer{
+			OriginAccountID: "origin_account_id",
+			TargetAccountID: "target_document",
+			Amount:          500,
+			ScheduledFor:    currentTime.Add(time.Hour),
+		}
+		repoCreateData = model.ScheduledTransfer{
+			OriginAccountID: createData.OriginAccountID,
+			TargetAccountID: "target_account_id",
+			Amount:          createData.Amount,
+			ScheduledFor:    createData.ScheduledFor,
+			Status:          model.ScheduledTransferStatusPending,
+		}
+		createdData = model.ScheduledTransfer{
+			ID:              "scheduled_transfer_id",
+			OriginAccountID: repoCreateData.OriginAccountID,
+			TargetAccountID: repoCreateData.TargetAccountID,
+			Amount:          repoCreateData.Amount,
+			ScheduledFor:    repoCreateData.ScheduledFor,
+			Status:          repoCreateData.Status,
+			CreatedAt:       currentTime,
+		}
+		authorizeData = model.Transfer{
+			OriginAccountID: createData.OriginAccountID,
+			TargetAccountID: createData.TargetAccountID,
+			Amount:          createData.Amount,
+		}
+		authorizedData         = model.Transfer{OriginAccountID: "origin_account_id", TargetAccountID: "target_account_id"}
+		validationErrorExample = &validator.ValidationError{Message: "invalid data"}
+	)
+	cases := map[string]struct {
+		InputData               model.ScheduledTransfer
+		ExpectedData            *model.ScheduledTransfer
+		ExpectedError           error
+		PrepareMockValidator    func(mock *validator.MockValidator)
+		PrepareMockGenerate     func(mock *generate.MockGenerate)
+		PrepareMockTransferApp  func(mock *transfer.MockApp)
+		PrepareMockRepoSchedule func(mock *schedule.MockRepository)
+	}{
+		"should return success": {
+			InputData:     createData,
+			ExpectedData:  &createdData,
+			ExpectedError: nil,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(createData).Return(nil)
+			},
+			PrepareMockGenerate: func(mock *generate.MockGenerate) {
+				mock.EXPECT().CurrentTime().Return(currentTime)
+			},
+			PrepareMockTransferApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().Authorize(gomock.Any(), authorizeData).Return(&authorizedData, nil)
+			},
+			PrepareMockRepoSchedule: func(mock *schedule.MockRepository) {
+				mock.EXPECT().
+					Create(gomock.Any(), repoCreateData).
+					Return(&model.GeneratedData{ID: createdData.ID, CreatedAt: currentTime}, nil)
+			},
+		},
+		"should return validation error": {
+			InputData:     createData,
+			ExpectedData:  nil,
+			ExpectedError: validationErrorExample,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(createData).Return(validationErrorExample)
+			},
+			PrepareMockGenerate:     func(mock *generate.MockGenerate) {},
+			PrepareMockTransferApp:  func(mock *transfer.MockApp) {},
+			PrepareMockRepoSchedule: func(mock *schedule.MockRepository) {},
+		},
+		"should return error: scheduled for in past": {
+			InputData:     createData,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrScheduledForInPast,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(createData).Return(nil)
+			},
+			PrepareMockGenerate: func(mock *generate.MockGenerate) {
+				mock.EXPECT().CurrentTime().Return(createData.ScheduledFor)
+			},
+			PrepareMockTransferApp:  func(mock *transfer.MockApp) {},
+			PrepareMockRepoSchedule: func(mock *schedule.MockRepository) {},
+		},
+		"should return error: denied by the risk check": {
+			InputData:     createData,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrTransferDenied,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(createData).Return(nil)
+			},
+			PrepareMockGenerate: func(mock *generate.MockGenerate) {
+				mock.EXPECT().CurrentTime().Return(currentTime)
+			},
+			PrepareMockTransferApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().Authorize(gomock.Any(), authorizeData).Return(nil, pkgerror.ErrTransferDenied)
+			},
+			PrepareMockRepoSchedule: func(mock *schedule.MockRepository) {},
+		},
+		"should return error on authorize": {
+			InputData:     createData,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantCreateScheduledTransfer,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(createData).Return(nil)
+			},
+			PrepareMockGenerate: func(mock *generate.MockGenerate) {
+				mock.EXPECT().CurrentTime().Return(currentTime)
+			},
+			PrepareMockTransferApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().Authorize(gomock.Any(), authorizeData).Return(nil, pkgerror.ErrCantCreateTransfer)
+			},
+			PrepareMockRepoSchedule: func(mock *schedule.MockRepository) {},
+		},
+		"should return error on create": {
+			InputData:     createData,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantCreateScheduledTransfer,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(createData).Return(nil)
+			},
+			PrepareMockGenerate: func(mock *generate.MockGenerate) {
+				mock.EXPECT().CurrentTime().Return(currentTime)
+			},
+			PrepareMockTransferApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().Authorize(gomock.Any(), authorizeData).Return(&authorizedData, nil)
+			},
+			PrepareMockRepoSchedule: func(mock *schedule.MockRepository) {
+				mock.EXPECT().Create(gomock.Any(), repoCreateData).Return(nil, errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx        = gomock.WithContext(context.Background(), t)
+				mockValidator    = validator.NewMockValidator(ctrl)
+				mockGenerate     = generate.NewMockGenerate(ctrl)
+				mockRepoSchedule = schedule.NewMockRepository(ctrl)
+				mockTransferApp  = transfer.NewMockApp(ctrl)
+				app              = NewApp(Options{
+					Logger:       logger.New(""),
+					Validator:    mockValidator,
+					Generate:     mockGenerate,
+					RepoSchedule: mockRepoSchedule,
+					TransferApp:  mockTransferApp,
+				})
+			)
+
+			cs.PrepareMockValidator(mockValidator)
+			cs.PrepareMockGenerate(mockGenerate)
+			cs.PrepareMockRepoSchedule(mockRepoSchedule)
+			cs.PrepareMockTransferApp(mockTransferApp)
+
+			data, err := app.Create(ctx, cs.InputData)
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	scheduledTransfersExample := []model.ScheduledTransfer{{
+		ID:              "scheduled_transfer_id",
+		OriginAccountID: "origin_account_id",
+		TargetAccountID: "target_account_id",
+		Amount:          500,
+		Status:          model.ScheduledTransferStatusPending,
+	}}
+	cases := map[string]struct {
+		ExpectedData            []model.ScheduledTransfer
+		ExpectedError           error
+		PrepareMockRepoSchedule func(mock *schedule.MockRepository)
+	}{
+		"should return success": {
+			ExpectedData:  scheduledTransfersExample,
+			ExpectedError: nil,
+			PrepareMockRepoSchedule: func(mock *schedule.MockRepository) {
+				mock.EXPECT().List(gomock.Any(), "origin_account_id", "").Return(scheduledTransfersExample, nil)
+			},
+		},
+		"should return error": {
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantListScheduledTransfers,
+			PrepareMockRepoSchedule: func(mock *schedule.MockRepository) {
+				mock.EXPECT().List(gomock.Any(), "origin_account_id", "").Return(nil, errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx        = gomock.WithContext(context.Background(), t)
+				mockRepoSchedule = schedule.NewMockRepository(ctrl)
+				app              = NewApp(Options{
+					Logger:       logger.New(""),
+					RepoSchedule: mockRepoSchedule,
+				})
+			)
+
+			cs.PrepareMockRepoSchedule(mockRepoSchedule)
+
+			data, err := app.List(ctx, "origin_account_id", "")
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestCancel(t *testing.T) {
+	var (
+		scheduledTransferExample = model.ScheduledTransfer{
+			ID:              "scheduled_transfer_id",
+			OriginAccountID: "origin_account_id",
+			TargetAccountID: "target_account_id",
+			Amount:          500,
+			Status:          model.ScheduledTransferStatusPending,
+		}
+		cancelledExample = model.ScheduledTransfer{
+			ID:              scheduledTransferExample.ID,
+			OriginAccountID: scheduledTransferExample.OriginAccountID,
+			TargetAccountID: scheduledTransferExample.TargetAccountID,
+			Amount:          scheduledTransferExample.Amount,
+			Status:          model.ScheduledTransferStatusCancelled,
+		}
+	)
+	cases := map[string]struct {
+		InputAccountID          string
+		ExpectedData            *model.ScheduledTransfer
+		ExpectedError           error
+		PrepareMockRepoSchedule func(mock *schedule.MockRepository)
+	}{
+		"should return success": {
+			InputAccountID: "origin_account_id",
+			ExpectedData:   &cancelledExample,
+			ExpectedError:  nil,
+			PrepareMockRepoSchedule: func(mock *schedule.MockRepository) {
+				scheduledTransfer := scheduledTransferExample
+				mock.EXPECT().GetByID(gomock.Any(), "scheduled_transfer_id").Return(&scheduledTransfer, nil)
+				mock.EXPECT().Cancel(gomock.Any(), "scheduled_transfer_id").Return(true, nil)
+			},
+		},
+		"should return error: not found": {
+			InputAccountID: "origin_account_id",
+			ExpectedData:   nil,
+			ExpectedError:  pkgerror.ErrScheduledTransferNotFound,
+			PrepareMockRepoSchedule: func(mock *schedule.MockRepository) {
+				mock.EXPECT().GetByID(gomock.Any(), "scheduled_transfer_id").Return(nil, nil)
+			},
+		},
+		"should return error: owned by another account": {
+			InputAccountID: "another_account_id",
+			ExpectedData:   nil,
+			ExpectedError:  pkgerror.ErrScheduledTransferNotFound,
+			PrepareMockRepoSchedule: func(mock *schedule.MockRepository) {
+				scheduledTransfer := scheduledTransferExample
+				mock.EXPECT().GetByID(gomock.Any(), "scheduled_transfer_id").Return(&scheduledTransfer, nil)
+			},
+		},
+		"should return error: not pending": {
+			InputAccountID: "origin_account_id",
+			ExpectedData:   nil,
+			ExpectedError:  pkgerror.ErrScheduledTransferNotPending,
+			PrepareMockRepoSchedule: func(mock *schedule.MockRepository) {
+				scheduledTransfer := scheduledTransferExample
+				mock.EXPECT().GetByID(gomock.Any(), "scheduled_transfer_id").Return(&scheduledTransfer, nil)
+				mock.EXPECT().Cancel(gomock.Any(), "scheduled_transfer_id").Return(false, nil)
+			},
+		},
+		"should return error on get": {
+			InputAccountID: "origin_account_id",
+			ExpectedData:   nil,
+			ExpectedError:  pkgerror.ErrCantCancelScheduledTransfer,
+			PrepareMockRepoSchedule: func(mock *schedule.MockRepository) {
+				mock.EXPECT().GetByID(gomock.Any(), "scheduled_transfer_id").Return(nil, errors.New("fail"))
+			},
+		},
+		"should return error on cancel": {
+			InputAccountID: "origin_account_id",
+			ExpectedData:   nil,
+			ExpectedError:  pkgerror.ErrCantCancelScheduledTransfer,
+			PrepareMockRepoSchedule: func(mock *schedule.MockRepository) {
+				scheduledTransfer := scheduledTransferExample
+				mock.EXPECT().GetByID(gomock.Any(), "scheduled_transfer_id").Return(&scheduledTransfer, nil)
+				mock.EXPECT().Cancel(gomock.Any(), "scheduled_transfer_id").Return(false, errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx        = gomock.WithContext(context.Background(), t)
+				mockRepoSchedule = schedule.NewMockRepository(ctrl)
+				app              = NewApp(Options{
+					Logger:       logger.New(""),
+					RepoSchedule: mockRepoSchedule,
+				})
+			)
+
+			cs.PrepareMockRepoSchedule(mockRepoSchedule)
+
+			data, err := app.Cancel(ctx, cs.InputAccountID, "scheduled_transfer_id")
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestExecuteDue(t *testing.T) {
+	var (
+		currentTime              = time.Now()
+		scheduledTransferExample = model.ScheduledTransfer{
+			ID:              "scheduled_transfer_id",
+			OriginAccountID: "origin_account_id",
+			TargetAccountID: "target_account_id",
+			Amount:          500,
+			Status:          model.ScheduledTransferStatusProcessing,
+			Attempts:        1,
+		}
+		transferData = model.Transfer{
+			OriginAccountID: scheduledTransferExample.OriginAccountID,
+			TargetAccountID: scheduledTransferExample.TargetAccountID,
+			Amount:          scheduledTransferExample.Amount,
+		}
+		transferID = "transfer_id"
+		txExample  = transaction.Transaction(nil)
+		// createWith makes the transfer as transfer.App.CreateWith does, failing it when record fails.
+		createWith = func(_ context.Context, _ model.Transfer, record transfer.RecordFunc) (*model.Transfer, error) {
+			if err := record(txExample, model.Transfer{ID: transferID}); err != nil {
+				return nil, pkgerror.ErrCantCreateTransfer
+			}
+			return &model.Transfer{ID: transferID}, nil
+		}
+		updated = func(attempts int, status string, transferID *string, reason string) model.ScheduledTransfer {
+			data := scheduledTransferExample
+			data.Attempts = attempts
+			data.Status = status
+			data.TransferID = transferID
+			if reason != "" {
+				data.FailureReason = &reason
+			}
+			return data
+		}
+		retried = func(attempts int, delay time.Duration) model.ScheduledTransfer {
+			data := updated(attempts, model.ScheduledTransferStatusPending, nil, "")
+			nextAttemptAt := currentTime.Add(delay)
+			data.NextAttemptAt = &nextAttemptAt
+			return data
+		}
+	)
+	cases := map[string]struct {
+		InputData               model.ScheduledTransfer
+		ExpectedError           error
+		PrepareMockRepoSchedule func(mock *schedule.MockRepository, data model.ScheduledTransfer)
+		PrepareMockTransferApp  func(mock *transfer.MockApp)
+	}{
+		"should mark as executed with the transfer": {
+			InputData:     scheduledTransferExample,
+			ExpectedError: nil,
+			PrepareMockRepoSchedule: func(mock *schedule.MockRepository, data model.ScheduledTransfer) {
+				mock.EXPECT().
+					ClaimDue(gomock.Any(), currentTime, currentTime.Add(-claimTimeout), executeDueBatchSize).
+					Return([]model.ScheduledTransfer{data}, nil)
+				mock.EXPECT().WithTransaction(txExample).Return(mock)
+				mock.EXPECT().
+					UpdateProgress(gomock.Any(), updated(2, model.ScheduledTransferStatusExecuted, &transferID, "")).
+					Return(true, nil)
+			},
+			PrepareMockTransferApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().
+					CreateWith(gomock.Any(), transferData, gomock.Any()).
+					DoAndReturn(createWith)
+			},
+		},
+		"should not make the transfer when another instance stored the outcome": {
+			InputData:     scheduledTransferExample,
+			ExpectedError: nil,
+			PrepareMockRepoSchedule: func(mock *schedule.MockRepository, data model.ScheduledTransfer) {
+				mock.EXPECT().
+					ClaimDue(gomock.Any(), currentTime, currentTime.Add(-claimTimeout), executeDueBatchSize).
+					Return([]model.ScheduledTransfer{data}, nil)
+				mock.EXPECT().WithTransaction(txExample).Return(mock)
+				mock.EXPECT().
+					UpdateProgress(gomock.Any(), updated(2, model.ScheduledTransferStatusExecuted, &transferID, "")).
+					Return(false, nil)
+				mock.EXPECT().
+					UpdateProgress(gomock.Any(), retried(2, 2*retryDelay)).
+					Return(false, nil)
+			},
+			PrepareMockTransferApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().
+					CreateWith(gomock.Any(), transferData, gomock.Any()).
+					DoAndReturn(createWith)
+			},
+		},
+		"should mark as failed on insufficient funds": {
+			InputData:     scheduledTransferExample,
+			ExpectedError: nil,
+			PrepareMockRepoSchedule: func(mock *schedule.MockRepository, data model.ScheduledTransfer) {
+				mock.EXPECT().
+					ClaimDue(gomock.Any(), currentTime, currentTime.Add(-claimTimeout), executeDueBatchSize).
+					Return([]model.ScheduledTransfer{data}, nil)
+				mock.EXPECT().
+					UpdateProgress(gomock.Any(), updated(2, model.ScheduledTransferStatusFailedInsufficientFunds, nil, "")).
+					Return(true, nil)
+			},
+			PrepareMockTransferApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().CreateWith(gomock.Any(), transferData, gomock.Any()).Return(nil, pkgerror.ErrInsufficientFunds)
+			},
+		},
+		"should mark as failed on permanent error": {
+			InputData:     scheduledTransferExample,
+			ExpectedError: nil,
+			PrepareMockRepoSchedule: func(mock *schedule.MockRepository, data model.ScheduledTransfer) {
+				mock.EXPECT().
+					ClaimDue(gomock.Any(), currentTime, currentTime.Add(-claimTimeout), executeDueBatchSize).
+					Return([]model.ScheduledTransfer{data}, nil)
+				mock.EXPECT().
+					UpdateProgress(gomock.Any(), updated(2, model.ScheduledTransferStatusFailed, nil, pkgerror.ErrTargetAccountNotActive.Error())).
+					Return(true, nil)
+			},
+			PrepareMockTransferApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().CreateWith(gomock.Any(), transferData, gomock.Any()).Return(nil, pkgerror.ErrTargetAccountNotActive)
+			},
+		},
+		"should mark as failed on transfer limit": {
+			InputData:     scheduledTransferExample,
+			ExpectedError: nil,
+			PrepareMockRepoSchedule: func(mock *schedule.MockRepository, data model.ScheduledTransfer) {
+				mock.EXPECT().
+					ClaimDue(gomock.Any(), currentTime, currentTime.Add(-claimTimeout), executeDueBatchSize).
+					Return([]model.ScheduledTransfer{data}, nil)
+				mock.EXPECT().
+					UpdateProgress(gomock.Any(), updated(2, model.ScheduledTransferStatusFailed, nil, pkgerror.ErrTransferLimitExceeded.Error())).
+					Return(true, nil)
+			},
+			PrepareMockTransferApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().
+					CreateWith(gomock.Any(), transferData, gomock.Any()).
+					Return(nil, &pkgerror.TransferLimitError{Limit: model.LimitDaily})
+			},
+		},
+		"should return to pending on unexpected error": {
+			InputData:     scheduledTransferExample,
+			ExpectedError: nil,
+			PrepareMockRepoSchedule: func(mock *schedule.MockRepository, data model.ScheduledTransfer) {
+				mock.EXPECT().
+					ClaimDue(gomock.Any(), currentTime, currentTime.Add(-claimTimeout), executeDueBatchSize).
+					Return([]model.ScheduledTransfer{data}, nil)
+				mock.EXPECT().
+					UpdateProgress(gomock.Any(), retried(2, 2*retryDelay)).
+					Return(true, nil)
+			},
+			PrepareMockTransferApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().CreateWith(gomock.Any(), transferData, gomock.Any()).Return(nil, pkgerror.ErrCantCreateTransfer)
+			},
+		},
+		"should wait longer after each unexpected error": {
+			InputData:     updated(3, model.ScheduledTransferStatusProcessing, nil, ""),
+			ExpectedError: nil,
+			PrepareMockRepoSchedule: func(mock *schedule.MockRepository, data model.ScheduledTransfer) {
+				mock.EXPECT().
+					ClaimDue(gomock.Any(), currentTime, currentTime.Add(-claimTimeout), executeDueBatchSize).
+					Return([]model.ScheduledTransfer{data}, nil)
+				mock.EXPECT().
+					UpdateProgress(gomock.Any(), retried(4, 8*retryDelay)).
+					Return(true, nil)
+			},
+			PrepareMockTransferApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().CreateWith(gomock.Any(), transferData, gomock.Any()).Return(nil, pkgerror.ErrCantCreateTransfer)
+			},
+		},
+		"should mark as failed on unexpected error after the last attempt": {
+			InputData:     updated(maxExecuteAttempts-1, model.ScheduledTransferStatusProcessing, nil, ""),
+			ExpectedError: nil,
+			PrepareMockRepoSchedule: func(mock *schedule.MockRepository, data model.ScheduledTransfer) {
+				mock.EXPECT().
+					ClaimDue(gomock.Any(), currentTime, currentTime.Add(-claimTimeout), executeDueBatchSize).
+					Return([]model.ScheduledTransfer{data}, nil)
+				mock.EXPECT().
+					UpdateProgress(gomock.Any(), updated(maxExecuteAttempts, model.ScheduledTransferStatusFailed, nil, pkgerror.ErrCantCreateTransfer.Error())).
+					Return(true, nil)
+			},
+			PrepareMockTransferApp: func(mock *transfer.MockApp) {
+				mock.EXPECT().CreateWith(gomock.Any(), transferData, gomock.Any()).Return(nil, pkgerror.ErrCantCreateTransfer)
+			},
+		},
+		"should return error on claim": {
+			InputData:     scheduledTransferExample,
+			ExpectedError: pkgerror.ErrCantExecuteScheduledTransfers,
+			PrepareMockRepoSchedule: func(mock *schedule.MockRepository, data model.ScheduledTransfer) {
+				mock.EXPECT().
+					ClaimDue(gomock.Any(), currentTime, currentTime.Add(-claimTimeout), executeDueBatchSize).
+					Return(nil, errors.New("fail"))
+			},
+			PrepareMockTransferApp: func(mock *transfer.MockApp) {},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx        = gomock.WithContext(context.Background(), t)
+				mockGenerate     = generate.NewMockGenerate(ctrl)
+				mockRepoSchedule = schedule.NewMockRepository(ctrl)
+				mockTransferApp  = transfer.NewMockApp(ctrl)
+				app              = NewApp(Options{
+					Logger:       logger.New(""),
+					Generate:     mockGenerate,
+					RepoSchedule: mockRepoSchedule,
+					TransferApp:  mockTransferApp,
+				})
+			)
+
+			mockGenerate.EXPECT().CurrentTime().Return(currentTime)
+			cs.PrepareMockRepoSchedule(mockRepoSchedule, cs.InputData)
+			cs.PrepareMockTransferApp(mockTransferApp)
+
+			err := app.ExecuteDue(ctx)
+
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
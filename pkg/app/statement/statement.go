@@ -0,0 +1,132 @@
+//go:generate mockgen -source=${GOFILE} -package=${GOPACKAGE} -destination=${GOPACKAGE}_mock.go
+
+package statement
+
+import (
+	"context"
+	pkgerror "github.com/carlosrodriguesf/bank-api/pkg/error"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/account"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/ledger"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/transfer"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/generate"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/validator"
+)
+
+type (
+	Options struct {
+		Logger       logger.Logger
+		Validator    validator.Validator
+		Generate     generate.Generate
+		RepoAccount  account.Repository
+		RepoLedger   ledger.Repository
+		RepoTransfer transfer.Repository
+		// FeeAccountID is the account credited with the transfer fees, whose statement lists the fees it collected.
+		FeeAccountID string
+	}
+	// Writer renders a statement as it is read. WriteHeader is called once before the entries and WriteFooter once after
+	// them, with the closing balance filled.
+	Writer interface {
+		WriteHeader(statement model.Statement) error
+		WriteEntry(entry model.StatementEntry) error
+		WriteFooter(statement model.Statement) error
+	}
+	App interface {
+		Export(ctx context.Context, filter model.StatementFilter, w Writer) error
+	}
+	appImpl struct {
+		logger       logger.Logger
+		validator    validator.Validator
+		generate     generate.Generate
+		repoAccount  account.Repository
+		repoLedger   ledger.Repository
+		repoTransfer transfer.Repository
+		feeAccountID string
+	}
+)
+
+func NewApp(opts Options) App {
+	return &appImpl{
+		logger:       opts.Logger.WithLocation().WithPreffix("app.statement"),
+		validator:    opts.Validator,
+		generate:     opts.Generate,
+		repoAccount:  opts.RepoAccount,
+		repoLedger:   opts.RepoLedger,
+		repoTransfer: opts.RepoTransfer,
+		feeAccountID: opts.FeeAccountID,
+	}
+}
+
+// Export streams the transfers of the period to w in chronological order, with the running balance of the account.
+// The statement of the fee account also lists the transfers it collected a fee from. Errors returned after
+// WriteHeader was called mean the statement was written partially.
+func (a *appImpl) Export(ctx context.Context, filter model.StatementFilter, w Writer) error {
+	if err := a.validator.Validate(filter); err != nil {
+		return err
+	}
+
+	acc, err := a.repoAccount.GetByIDOrDocument(ctx, filter.AccountID)
+	if err != nil {
+		a.logger.Error(err)
+		return pkgerror.ErrCantExportStatement
+	}
+	if acc == nil {
+		return pkgerror.ErrAccountNotFound
+	}
+
+	if filter.From.IsZero() {
+		filter.From = acc.CreatedAt
+	}
+	if filter.To.IsZero() {
+		filter.To = a.generate.CurrentTime()
+	}
+	if !filter.From.Before(filter.To) {
+		return pkgerror.ErrInvalidStatementPeriod
+	}
+
+	openingBalance, err := a.repoLedger.GetOpeningBalance(ctx, acc.ID, filter.From, filter.To)
+	if err != nil {
+		a.logger.Error(err)
+		return pkgerror.ErrCantExportStatement
+	}
+
+	statement := model.Statement{
+		AccountID:       acc.ID,
+		AccountName:     acc.Name,
+		AccountDocument: acc.Document,
+		Currency:        acc.Currency,
+		From:            filter.From,
+		To:              filter.To,
+		OpeningBalance:  openingBalance,
+		ClosingBalance:  openingBalance,
+	}
+	if err = w.WriteHeader(statement); err != nil {
+		a.logger.Error(err)
+		return pkgerror.ErrCantExportStatement
+	}
+
+	transferFilter := model.TransferFilter{
+		AccountID:     acc.ID,
+		From:          filter.From,
+		To:            filter.To,
+		CollectedFees: a.feeAccountID != "" && acc.ID == a.feeAccountID,
+		Sort:          model.SortAsc,
+	}
+	err = a.repoTransfer.Stream(ctx, transferFilter, func(transfer model.TransferDetailed) error {
+		entry := toStatementEntry(acc.ID, transfer)
+		statement.ClosingBalance += entry.Amount + entry.Fee
+		entry.Balance = statement.ClosingBalance
+		return w.WriteEntry(entry)
+	})
+	if err != nil {
+		a.logger.Error(err)
+		return pkgerror.ErrCantExportStatement
+	}
+
+	if err = w.WriteFooter(statement); err != nil {
+		a.logger.Error(err)
+		return pkgerror.ErrCantExportStatement
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package statement
+
+import "github.com/carlosrodriguesf/bank-api/pkg/model"
+
+// toStatementEntry sees the transfer from accountID. A transfer accountID only collected the fee of, as the fee
+// account, has no amount and the fee as a credit.
+func toStatementEntry(accountID string, transfer model.TransferDetailed) model.StatementEntry {
+	entry := model.StatementEntry{
+		TransferID:            transfer.ID,
+		ReversedTransferID:    transfer.ReversedTransferID,
+		CounterpartyAccountID: transfer.OriginAccountID,
+		CounterpartyName:      transfer.OriginAccountName,
+		Fee:                   transfer.CollectedFee,
+		CreatedAt:             transfer.CreatedAt,
+	}
+	if transfer.TargetAccountID == accountID {
+		entry.Amount = transfer.TargetAmount
+	}
+	if transfer.Sent {
+		entry.CounterpartyAccountID = transfer.TargetAccountID
+		entry.CounterpartyName = transfer.TargetAccountName
+		entry.Amount = -transfer.Amount
+		entry.Fee = -transfer.Fee
+	}
+	return entry
+}
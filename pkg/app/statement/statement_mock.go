@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: statement.go
+
+// Package statement is a generated GoMock package.
+package statement
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/carlosrodriguesf/bank-api/pkg/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockWriter is a mock of Writer interface.
+type MockWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockWriterMockRecorder
+}
+
+// MockWriterMockRecorder is the mock recorder for MockWriter.
+type MockWriterMockRecorder struct {
+	mock *MockWriter
+}
+
+// NewMockWriter creates a new mock instance.
+func NewMockWriter(ctrl *gomock.Controller) *MockWriter {
+	mock := &MockWriter{ctrl: ctrl}
+	mock.recorder = &MockWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWriter) EXPECT() *MockWriterMockRecorder {
+	return m.recorder
+}
+
+// WriteEntry mocks base method.
+func (m *MockWriter) WriteEntry(entry model.StatementEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteEntry", entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteEntry indicates an expected call of WriteEntry.
+func (mr *MockWriterMockRecorder) WriteEntry(entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteEntry", reflect.TypeOf((*MockWriter)(nil).WriteEntry), entry)
+}
+
+// WriteFooter mocks base method.
+func (m *MockWriter) WriteFooter(statement model.Statement) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteFooter", statement)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteFooter indicates an expected call of WriteFooter.
+func (mr *MockWriterMockRecorder) WriteFooter(statement interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteFooter", reflect.TypeOf((*MockWriter)(nil).WriteFooter), statement)
+}
+
+// WriteHeader mocks base method.
+func (m *MockWriter) WriteHeader(statement model.Statement) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteHeader", statement)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteHeader indicates an expected call of WriteHeader.
+func (mr *MockWriterMockRecorder) WriteHeader(statement interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteHeader", reflect.TypeOf((*MockWriter)(nil).WriteHeader), statement)
+}
+
+// MockApp is a mock of App interface.
+type MockApp struct {
+	ctrl     *gomock.Controller
+	recorder *MockAppMockRecorder
+}
+
+// MockAppMockRecorder is the mock recorder for MockApp.
+type MockAppMockRecorder struct {
+	mock *MockApp
+}
+
+// NewMockApp creates a new mock instance.
+func NewMockApp(ctrl *gomock.Controller) *MockApp {
+	mock := &MockApp{ctrl: ctrl}
+	mock.recorder = &MockAppMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApp) EXPECT() *MockAppMockRecorder {
+	return m.recorder
+}
+
+// Export mocks base method.
+func (m *MockApp) Export(ctx context.Context, filter model.StatementFilter, w Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, filter, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockAppMockRecorder) Export(ctx, filter, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockApp)(nil).Export), ctx, filter, w)
+}
@@ -0,0 +1,316 @@
+package statement
+
+import (
+	"context"
+	"errors"
+	pkgerror "github.com/carlosrodriguesf/bank-api/pkg/error"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/account"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/ledger"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/transfer"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/generate"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/validator"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestExport(t *testing.T) {
+	var (
+		currentTime    = time.Now()
+		createdAt      = currentTime.Add(-48 * time.Hour)
+		accountExample = model.Account{
+			ID:        "account_id",
+			Name:      "John Doe",
+			Document:  "12312312312",
+			Currency:  "BRL",
+			CreatedAt: createdAt,
+		}
+		filterExample  = model.StatementFilter{AccountID: "account_id"}
+		transferFilter = model.TransferFilter{
+			AccountID: "account_id",
+			From:      createdAt,
+			To:        currentTime,
+			Sort:      model.SortAsc,
+		}
+		transfersExample = []model.TransferDetailed{
+			{
+				Transfer: model.Transfer{
+					ID:              "transfer_id_1",
+					OriginAccountID: "account_id",
+					TargetAccountID: "target_account_id",
+					Amount:          300,
+					TargetAmount:    300,
+					Fee:             20,
+					CreatedAt:       currentTime.Add(-time.Hour),
+				},
+				Sent:              true,
+				OriginAccountName: "John Doe",
+				TargetAccountName: "Jane Doe",
+			},
+			{
+				Transfer: model.Transfer{
+					ID:              "transfer_id_2",
+					OriginAccountID: "origin_account_id",
+					TargetAccountID: "account_id",
+					Amount:          100,
+					TargetAmount:    100,
+					CreatedAt:       currentTime,
+				},
+				OriginAccountName: "Mary Doe",
+				TargetAccountName: "John Doe",
+			},
+		}
+		statementExample = model.Statement{
+			AccountID:       "account_id",
+			AccountName:     "John Doe",
+			AccountDocument: "12312312312",
+			Currency:        "BRL",
+			From:            createdAt,
+			To:              currentTime,
+			OpeningBalance:  1000,
+			ClosingBalance:  1000,
+		}
+		validationErrorExample = &validator.ValidationError{Message: "invalid data"}
+	)
+	cases := map[string]struct {
+		InputData               model.StatementFilter
+		ExpectedError           error
+		PrepareMockValidator    func(mock *validator.MockValidator)
+		PrepareMockGenerate     func(mock *generate.MockGenerate)
+		PrepareMockRepoAccount  func(mock *account.MockRepository)
+		PrepareMockRepoLedger   func(mock *ledger.MockRepository)
+		PrepareMockRepoTransfer func(mock *transfer.MockRepository)
+		PrepareMockWriter       func(mock *MockWriter)
+	}{
+		"should return success": {
+			InputData:     filterExample,
+			ExpectedError: nil,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(filterExample).Return(nil)
+			},
+			PrepareMockGenerate: func(mock *generate.MockGenerate) {
+				mock.EXPECT().CurrentTime().Return(currentTime)
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), "account_id").Return(&accountExample, nil)
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository) {
+				mock.EXPECT().GetOpeningBalance(gomock.Any(), "account_id", createdAt, currentTime).Return(int64(1000), nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {
+				mock.EXPECT().
+					Stream(gomock.Any(), transferFilter, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ model.TransferFilter, fn func(model.TransferDetailed) error) error {
+						for _, transfer := range transfersExample {
+							if err := fn(transfer); err != nil {
+								return err
+							}
+						}
+						return nil
+					})
+			},
+			PrepareMockWriter: func(mock *MockWriter) {
+				closingStatement := statementExample
+				closingStatement.ClosingBalance = 780
+				gomock.InOrder(
+					mock.EXPECT().WriteHeader(statementExample).Return(nil),
+					mock.EXPECT().WriteEntry(model.StatementEntry{
+						TransferID:            "transfer_id_1",
+						CounterpartyAccountID: "target_account_id",
+						CounterpartyName:      "Jane Doe",
+						Amount:                -300,
+						Fee:                   -20,
+						Balance:               680,
+						CreatedAt:             currentTime.Add(-time.Hour),
+					}).Return(nil),
+					mock.EXPECT().WriteEntry(model.StatementEntry{
+						TransferID:            "transfer_id_2",
+						CounterpartyAccountID: "origin_account_id",
+						CounterpartyName:      "Mary Doe",
+						Amount:                100,
+						Balance:               780,
+						CreatedAt:             currentTime,
+					}).Return(nil),
+					mock.EXPECT().WriteFooter(closingStatement).Return(nil),
+				)
+			},
+		},
+		"should return success for the fee account": {
+			InputData:     model.StatementFilter{AccountID: "fee_account_id"},
+			ExpectedError: nil,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(model.StatementFilter{AccountID: "fee_account_id"}).Return(nil)
+			},
+			PrepareMockGenerate: func(mock *generate.MockGenerate) {
+				mock.EXPECT().CurrentTime().Return(currentTime)
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				data := accountExample
+				data.ID = "fee_account_id"
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), "fee_account_id").Return(&data, nil)
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository) {
+				mock.EXPECT().GetOpeningBalance(gomock.Any(), "fee_account_id", createdAt, currentTime).Return(int64(1000), nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {
+				filter := transferFilter
+				filter.AccountID = "fee_account_id"
+				filter.CollectedFees = true
+				mock.EXPECT().
+					Stream(gomock.Any(), filter, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ model.TransferFilter, fn func(model.TransferDetailed) error) error {
+						return fn(model.TransferDetailed{
+							Transfer: model.Transfer{
+								ID:              "transfer_id_1",
+								OriginAccountID: "origin_account_id",
+								TargetAccountID: "target_account_id",
+								Amount:          300,
+								TargetAmount:    300,
+								Fee:             20,
+								CreatedAt:       currentTime,
+							},
+							CollectedFee:      20,
+							OriginAccountName: "Mary Doe",
+							TargetAccountName: "Jane Doe",
+						})
+					})
+			},
+			PrepareMockWriter: func(mock *MockWriter) {
+				openingStatement := statementExample
+				openingStatement.AccountID = "fee_account_id"
+				closingStatement := openingStatement
+				closingStatement.ClosingBalance = 1020
+				gomock.InOrder(
+					mock.EXPECT().WriteHeader(openingStatement).Return(nil),
+					mock.EXPECT().WriteEntry(model.StatementEntry{
+						TransferID:            "transfer_id_1",
+						CounterpartyAccountID: "origin_account_id",
+						CounterpartyName:      "Mary Doe",
+						Amount:                0,
+						Fee:                   20,
+						Balance:               1020,
+						CreatedAt:             currentTime,
+					}).Return(nil),
+					mock.EXPECT().WriteFooter(closingStatement).Return(nil),
+				)
+			},
+		},
+		"should return validation error": {
+			InputData:     filterExample,
+			ExpectedError: validationErrorExample,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(filterExample).Return(validationErrorExample)
+			},
+			PrepareMockGenerate:     func(mock *generate.MockGenerate) {},
+			PrepareMockRepoAccount:  func(mock *account.MockRepository) {},
+			PrepareMockRepoLedger:   func(mock *ledger.MockRepository) {},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {},
+			PrepareMockWriter:       func(mock *MockWriter) {},
+		},
+		"should return error: account not found": {
+			InputData:     filterExample,
+			ExpectedError: pkgerror.ErrAccountNotFound,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(filterExample).Return(nil)
+			},
+			PrepareMockGenerate: func(mock *generate.MockGenerate) {},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), "account_id").Return(nil, nil)
+			},
+			PrepareMockRepoLedger:   func(mock *ledger.MockRepository) {},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {},
+			PrepareMockWriter:       func(mock *MockWriter) {},
+		},
+		"should return error: invalid period": {
+			InputData:     model.StatementFilter{AccountID: "account_id", From: currentTime, To: createdAt},
+			ExpectedError: pkgerror.ErrInvalidStatementPeriod,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(gomock.Any()).Return(nil)
+			},
+			PrepareMockGenerate: func(mock *generate.MockGenerate) {},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), "account_id").Return(&accountExample, nil)
+			},
+			PrepareMockRepoLedger:   func(mock *ledger.MockRepository) {},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {},
+			PrepareMockWriter:       func(mock *MockWriter) {},
+		},
+		"should return error on get opening balance": {
+			InputData:     filterExample,
+			ExpectedError: pkgerror.ErrCantExportStatement,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(filterExample).Return(nil)
+			},
+			PrepareMockGenerate: func(mock *generate.MockGenerate) {
+				mock.EXPECT().CurrentTime().Return(currentTime)
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), "account_id").Return(&accountExample, nil)
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository) {
+				mock.EXPECT().GetOpeningBalance(gomock.Any(), "account_id", createdAt, currentTime).Return(int64(0), errors.New("fail"))
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {},
+			PrepareMockWriter:       func(mock *MockWriter) {},
+		},
+		"should return error on stream": {
+			InputData:     filterExample,
+			ExpectedError: pkgerror.ErrCantExportStatement,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(filterExample).Return(nil)
+			},
+			PrepareMockGenerate: func(mock *generate.MockGenerate) {
+				mock.EXPECT().CurrentTime().Return(currentTime)
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), "account_id").Return(&accountExample, nil)
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository) {
+				mock.EXPECT().GetOpeningBalance(gomock.Any(), "account_id", createdAt, currentTime).Return(int64(1000), nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {
+				mock.EXPECT().Stream(gomock.Any(), transferFilter, gomock.Any()).Return(errors.New("fail"))
+			},
+			PrepareMockWriter: func(mock *MockWriter) {
+				mock.EXPECT().WriteHeader(statementExample).Return(nil)
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx        = gomock.WithContext(context.Background(), t)
+				mockValidator    = validator.NewMockValidator(ctrl)
+				mockGenerate     = generate.NewMockGenerate(ctrl)
+				mockRepoAccount  = account.NewMockRepository(ctrl)
+				mockRepoLedger   = ledger.NewMockRepository(ctrl)
+				mockRepoTransfer = transfer.NewMockRepository(ctrl)
+				mockWriter       = NewMockWriter(ctrl)
+				app              = NewApp(Options{
+					Logger:       logger.New(""),
+					Validator:    mockValidator,
+					Generate:     mockGenerate,
+					RepoAccount:  mockRepoAccount,
+					RepoLedger:   mockRepoLedger,
+					RepoTransfer: mockRepoTransfer,
+					FeeAccountID: "fee_account_id",
+				})
+			)
+
+			cs.PrepareMockValidator(mockValidator)
+			cs.PrepareMockGenerate(mockGenerate)
+			cs.PrepareMockRepoAccount(mockRepoAccount)
+			cs.PrepareMockRepoLedger(mockRepoLedger)
+			cs.PrepareMockRepoTransfer(mockRepoTransfer)
+			cs.PrepareMockWriter(mockWriter)
+
+			err := app.Export(ctx, cs.InputData, mockWriter)
+
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
@@ -4,174 +4,1134 @@ package transfer
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"github.com/carlosrodriguesf/bank-api/pkg/app/twofactor"
 	pkgerror "github.com/carlosrodriguesf/bank-api/pkg/error"
 	"github.com/carlosrodriguesf/bank-api/pkg/model"
 	"github.com/carlosrodriguesf/bank-api/pkg/repository/account"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/hold"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/ledger"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/limit"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/review"
 	"github.com/carlosrodriguesf/bank-api/pkg/repository/transfer"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/exchange"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/fee"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/generate"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/risk"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/transaction"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/validator"
-	"golang.org/x/sync/errgroup"
+	"math/big"
+	"sort"
+	"time"
+)
+
+const (
+	defaultPageSize = 50
+	// riskHistoryPeriod is how far back the transfers sent by the origin account are handed to the risk check.
+	riskHistoryPeriod = 30 * 24 * time.Hour
+	// defaultHoldDuration is how long a hold lasts when it is created without ExpiresAt. No hold lasts longer than
+	// maxHoldDuration.
+	defaultHoldDuration  = 7 * 24 * time.Hour
+	maxHoldDuration      = 30 * 24 * time.Hour
+	expireHoldsBatchSize = 100
 )
 
 type (
 	Options struct {
 		Logger       logger.Logger
 		Validator    validator.Validator
+		Generate     generate.Generate
 		TxManager    transaction.Manager
 		RepoAccount  account.Repository
+		RepoHold     hold.Repository
+		RepoLedger   ledger.Repository
+		RepoLimit    limit.Repository
+		RepoReview   review.Repository
 		RepoTransfer transfer.Repository
+		TwoFactorApp twofactor.App
+		// Rates converts transfers between accounts of different currencies.
+		Rates exchange.Rates
+		// Fees is the fee schedule charged on transfers, credited to FeeAccountID. Without both no fee is charged.
+		Fees         fee.Engine
+		FeeAccountID string
+		// StepUpThreshold is the amount above which a transfer asks for the second factor. Zero disables it.
+		StepUpThreshold int64
+		// Risk checks the transfers made by signed in users before they move any money. Without it every transfer
+		// is allowed.
+		Risk risk.Engine
 	}
 	App interface {
 		Create(ctx context.Context, transfer model.Transfer) (*model.Transfer, error)
-		List(ctx context.Context, accountID string) ([]model.TransferDetailed, error)
+		CreateWith(ctx context.Context, transfer model.Transfer, record RecordFunc) (*model.Transfer, error)
+		Quote(ctx context.Context, transfer model.Transfer) (*model.Transfer, error)
+		Authorize(ctx context.Context, transfer model.Transfer) (*model.Transfer, error)
+		List(ctx context.Context, filter model.TransferFilter) (*model.TransferPage, error)
+		Reverse(ctx context.Context, accountID string, transferID string, amount int64) (*model.Transfer, error)
+		ListReviews(ctx context.Context) ([]model.TransferReview, error)
+		ApproveReview(ctx context.Context, reviewID string, reviewerID string) (*model.Transfer, error)
+		RejectReview(ctx context.Context, reviewID string, reviewerID string) error
+		CreateHold(ctx context.Context, hold model.Hold) (*model.Hold, error)
+		ListHolds(ctx context.Context, accountID string, status string) ([]model.Hold, error)
+		CaptureHold(ctx context.Context, accountID string, holdID string, amount int64) (*model.Transfer, error)
+		ReleaseHold(ctx context.Context, accountID string, holdID string) (*model.Hold, error)
+		ExpireHolds(ctx context.Context) error
 	}
+	// RecordFunc stores what the caller keeps about a transfer using the transaction that made it.
+	RecordFunc func(tx transaction.Transaction, transfer model.Transfer) error
+
 	appImpl struct {
-		logger       logger.Logger
-		validator    validator.Validator
-		txManager    transaction.Manager
-		repoAccount  account.Repository
-		repoTransfer transfer.Repository
+		logger          logger.Logger
+		validator       validator.Validator
+		generate        generate.Generate
+		txManager       transaction.Manager
+		repoAccount     account.Repository
+		repoHold        hold.Repository
+		repoLedger      ledger.Repository
+		repoLimit       limit.Repository
+		repoReview      review.Repository
+		repoTransfer    transfer.Repository
+		twoFactorApp    twofactor.App
+		rates           exchange.Rates
+		fees            fee.Engine
+		feeAccountID    string
+		stepUpThreshold int64
+		risk            risk.Engine
 	}
 )
 
 func NewApp(opts Options) App {
 	return &appImpl{
-		logger:       opts.Logger.WithLocation().WithPreffix("app.transfer"),
-		validator:    opts.Validator,
-		txManager:    opts.TxManager,
-		repoAccount:  opts.RepoAccount,
-		repoTransfer: opts.RepoTransfer,
+		logger:          opts.Logger.WithLocation().WithPreffix("app.transfer"),
+		validator:       opts.Validator,
+		generate:        opts.Generate,
+		txManager:       opts.TxManager,
+		repoAccount:     opts.RepoAccount,
+		repoHold:        opts.RepoHold,
+		repoLedger:      opts.RepoLedger,
+		repoLimit:       opts.RepoLimit,
+		repoReview:      opts.RepoReview,
+		repoTransfer:    opts.RepoTransfer,
+		twoFactorApp:    opts.TwoFactorApp,
+		rates:           opts.Rates,
+		fees:            opts.Fees,
+		feeAccountID:    opts.FeeAccountID,
+		stepUpThreshold: opts.StepUpThreshold,
+		risk:            opts.Risk,
 	}
 }
 
-func (a *appImpl) List(ctx context.Context, accountID string) ([]model.TransferDetailed, error) {
-	transfers, err := a.repoTransfer.List(ctx, accountID)
+// List returns a page of the transfers selected by filter, with the cursor of the next page when there is one.
+func (a *appImpl) List(ctx context.Context, filter model.TransferFilter) (*model.TransferPage, error) {
+	if err := a.validator.Validate(filter); err != nil {
+		return nil, err
+	}
+
+	limit := filter.Limit
+	if limit == 0 {
+		limit = defaultPageSize
+	}
+	filter.Limit = limit + 1
+
+	transfers, err := a.repoTransfer.List(ctx, filter)
 	if err != nil {
 		a.logger.Error(err)
 		return nil, pkgerror.ErrCantListTransfers
 	}
-	return transfers, nil
+
+	page := &model.TransferPage{Transfers: transfers}
+	if len(transfers) > limit {
+		last := transfers[limit-1]
+		page.Transfers = transfers[:limit]
+		page.NextCursor = model.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}.Encode()
+	}
+	return page, nil
 }
 
+// Create moves the money between the accounts. Amount is in the currency of the origin account and is converted to
+// the currency of the target account at the current rate. Amount must fit the transfer limits of the origin account,
+// and the fees of the transfer are charged to it on top of Amount. When a signed in user makes a transfer above the
+// step-up threshold, the second factor of the origin account is checked against TwoFactorCode. Transfers of signed in
+// users also go through the risk check, which may deny them or hold them for a review, reserving their money and
+// returning the transfer with ReviewID instead of ID. Transfers run by the workers were already authorized by
+// Authorize when scheduled and carry no session.
 func (a appImpl) Create(ctx context.Context, transfer model.Transfer) (*model.Transfer, error) {
-	if err := a.validator.Validate(transfer); err != nil {
+	return a.create(ctx, transfer, nil)
+}
+
+// CreateWith works as Create and runs record in the same transaction once the money has moved, so the workers mark
+// their transfers as made together with them and never make one twice after an instance stops. It is not run for
+// transfers held for a review.
+func (a appImpl) CreateWith(ctx context.Context, transfer model.Transfer, record RecordFunc) (*model.Transfer, error) {
+	return a.create(ctx, transfer, record)
+}
+
+func (a appImpl) create(ctx context.Context, transfer model.Transfer, record RecordFunc) (*model.Transfer, error) {
+	prepared, err := a.prepare(ctx, transfer)
+	if err != nil {
+		return nil, err
+	}
+	transfer = prepared.Transfer
+	if err = a.checkStepUp(ctx, transfer); err != nil {
 		return nil, err
 	}
 
-	originAccount, err := a.repoAccount.GetByIDOrDocument(ctx, transfer.OriginAccountID)
+	var (
+		genData *model.GeneratedData
+		held    bool
+	)
+	err = a.txManager.Execute(ctx, func(tx transaction.Transaction) error {
+		// a retried transaction starts over, so nothing decided by the attempt rolled back may carry over
+		held = false
+		a.useTransaction(tx)
+
+		wrapper, err := a.lockAccounts(ctx, transfer)
+		if err != nil {
+			return err
+		}
+		if err = a.checkTransfer(ctx, wrapper, false); err != nil {
+			return err
+		}
+		transfer = wrapper.Transfer
+
+		assessment, err := a.assessRisk(ctx, *wrapper)
+		if err != nil {
+			return err
+		}
+		switch assessment.Decision {
+		case risk.DecisionDeny:
+			return pkgerror.ErrTransferDenied
+		case risk.DecisionReview:
+			held = true
+			genData, err = a.holdForReview(ctx, transfer, assessment)
+			return err
+		}
+		genData, err = a.makeTransfer(ctx, *wrapper)
+		if err != nil || record == nil {
+			return err
+		}
+
+		made := transfer
+		made.ID = genData.ID
+		made.CreatedAt = genData.CreatedAt
+		return record(tx, made)
+	})
 	if err != nil {
+		switch {
+		case errors.Is(err, pkgerror.ErrInsufficientFunds),
+			errors.Is(err, pkgerror.ErrTransferLimitExceeded),
+			errors.Is(err, pkgerror.ErrTransferDenied),
+			errors.Is(err, pkgerror.ErrOriginAccountTransferNotFound),
+			errors.Is(err, pkgerror.ErrTargetAccountTransferNotFound),
+			errors.Is(err, pkgerror.ErrOriginAccountNotActive),
+			errors.Is(err, pkgerror.ErrTargetAccountNotActive):
+			return nil, err
+		}
 		a.logger.Error(err)
 		return nil, pkgerror.ErrCantCreateTransfer
 	}
-	if originAccount == nil {
-		return nil, pkgerror.ErrOriginAccountTransferNotFound
+
+	if held {
+		transfer.ReviewID = &genData.ID
+	} else {
+		transfer.ID = genData.ID
 	}
-	if originAccount.Balance < transfer.Amount {
-		return nil, pkgerror.ErrInsufficientFunds
+	transfer.CreatedAt = genData.CreatedAt
+
+	return &transfer, nil
+}
+
+// Quote runs the checks of Create and returns the transfer it would make, with the converted amount and the fees,
+// without moving any money.
+func (a appImpl) Quote(ctx context.Context, transfer model.Transfer) (*model.Transfer, error) {
+	prepared, err := a.prepare(ctx, transfer)
+	if err != nil {
+		return nil, err
+	}
+	transfer = prepared.Transfer
+	if err = a.applyFees(ctx, &transfer); err != nil {
+		a.logger.Error(err)
+		return nil, pkgerror.ErrCantCreateTransfer
 	}
+	return &transfer, nil
+}
 
-	targetAccount, err := a.repoAccount.GetByIDOrDocument(ctx, transfer.TargetAccountID)
+// Authorize runs the checks a signed in user goes through before a transfer that the workers will make later, when
+// nobody is around to give the second factor or wait for a review: the second factor above the step-up threshold and
+// the risk check, which denies what it would deny or hold for a review. It returns the transfer with its accounts
+// resolved and its amount converted at the current rate, without moving or reserving any money.
+func (a appImpl) Authorize(ctx context.Context, transfer model.Transfer) (*model.Transfer, error) {
+	wrapper, err := a.prepare(ctx, transfer)
+	if err != nil {
+		return nil, err
+	}
+	if err = a.checkStepUp(ctx, wrapper.Transfer); err != nil {
+		return nil, err
+	}
+
+	assessment, err := a.assessRisk(ctx, wrapper)
 	if err != nil {
 		a.logger.Error(err)
 		return nil, pkgerror.ErrCantCreateTransfer
 	}
+	if assessment.Decision != risk.DecisionAllow {
+		return nil, pkgerror.ErrTransferDenied
+	}
+	return &wrapper.Transfer, nil
+}
+
+// prepare validates the transfer, resolving both accounts by id or document, and converts its amount to the currency
+// of the target account. The accounts of the returned wrapper are not locked.
+func (a appImpl) prepare(ctx context.Context, transfer model.Transfer) (transferWrapper, error) {
+	wrapper := transferWrapper{Transfer: transfer}
+	if err := a.validator.Validate(transfer); err != nil {
+		return wrapper, err
+	}
+
+	originAccount, err := a.repoAccount.GetByIDOrDocument(ctx, transfer.OriginAccountID)
+	if err != nil {
+		a.logger.Error(err)
+		return wrapper, pkgerror.ErrCantCreateTransfer
+	}
+	if originAccount == nil {
+		return wrapper, pkgerror.ErrOriginAccountTransferNotFound
+	}
+
+	targetAccount, err := a.repoAccount.GetByIDOrDocument(ctx, transfer.TargetAccountID)
+	if err != nil {
+		a.logger.Error(err)
+		return wrapper, pkgerror.ErrCantCreateTransfer
+	}
 	if targetAccount == nil {
-		return nil, pkgerror.ErrTargetAccountTransferNotFound
+		return wrapper, pkgerror.ErrTargetAccountTransferNotFound
+	}
+
+	wrapper.AccountOrigin = originAccount
+	wrapper.AccountTarget = targetAccount
+	wrapper.Transfer.OriginAccountID = originAccount.ID
+	wrapper.Transfer.TargetAccountID = targetAccount.ID
+
+	err = a.convert(ctx, &wrapper.Transfer, originAccount.Currency, targetAccount.Currency)
+	return wrapper, err
+}
+
+// Reverse sends back amount of a received transfer to its origin, linking the compensating transfer to the original
+// one. An amount of zero reverses everything that was not reversed yet. Amounts are in the currency the transfer was
+// received in, and converted transfers are reversed at their original rate. The transfer must have been received by
+// accountID, which may be empty for operators allowed to reverse any transfer.
+func (a appImpl) Reverse(ctx context.Context, accountID string, transferID string, amount int64) (*model.Transfer, error) {
+	if amount < 0 {
+		return nil, pkgerror.ErrInvalidReversalAmount
+	}
+
+	var (
+		reversal model.Transfer
+		genData  *model.GeneratedData
+	)
+	err := a.txManager.Execute(ctx, func(tx transaction.Transaction) error {
+		a.useTransaction(tx)
+
+		original, err := a.repoTransfer.GetByIDForUpdate(ctx, transferID)
+		if err != nil {
+			return err
+		}
+		if original == nil || (accountID != "" && original.TargetAccountID != accountID) {
+			return pkgerror.ErrTransferNotFound
+		}
+		if original.ReversedTransferID != nil {
+			return pkgerror.ErrTransferIsReversal
+		}
+
+		reversedAmount, err := a.repoTransfer.GetReversedAmount(ctx, original.ID)
+		if err != nil {
+			return err
+		}
+		remaining := original.TargetAmount - reversedAmount
+		if remaining == 0 {
+			return pkgerror.ErrTransferAlreadyReversed
+		}
+		if amount > remaining {
+			return pkgerror.ErrInvalidReversalAmount
+		}
+
+		reversal = model.Transfer{
+			OriginAccountID:    original.TargetAccountID,
+			TargetAccountID:    original.OriginAccountID,
+			Amount:             remaining,
+			SourceCurrency:     original.TargetCurrency,
+			TargetCurrency:     original.SourceCurrency,
+			ReversedTransferID: &original.ID,
+		}
+		if amount > 0 {
+			reversal.Amount = amount
+		}
+		if err = reverseConversion(&reversal, *original); err != nil {
+			return err
+		}
+
+		genData, err = a.executeTransfer(ctx, &reversal, true)
+		return err
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pkgerror.ErrInsufficientFunds),
+			errors.Is(err, pkgerror.ErrTransferNotFound),
+			errors.Is(err, pkgerror.ErrTransferIsReversal),
+			errors.Is(err, pkgerror.ErrTransferAlreadyReversed),
+			errors.Is(err, pkgerror.ErrInvalidReversalAmount),
+			errors.Is(err, pkgerror.ErrOriginAccountNotActive),
+			errors.Is(err, pkgerror.ErrTargetAccountNotActive):
+			return nil, err
+		}
+		a.logger.Error(err)
+		return nil, pkgerror.ErrCantReverseTransfer
 	}
 
-	tx, err := a.startTransaction(ctx)
+	reversal.ID = genData.ID
+	reversal.CreatedAt = genData.CreatedAt
+
+	return &reversal, nil
+}
+
+// ListReviews returns the oldest transfers waiting for a review.
+func (a appImpl) ListReviews(ctx context.Context) ([]model.TransferReview, error) {
+	reviews, err := a.repoReview.ListPending(ctx, defaultPageSize)
 	if err != nil {
 		a.logger.Error(err)
-		return nil, pkgerror.ErrCantCreateTransfer
+		return nil, pkgerror.ErrCantListTransferReviews
 	}
-	defer func() {
+	return reviews, nil
+}
+
+// ApproveReview makes the transfer held by the review, recording reviewerID as the operator who approved it. Its
+// reserved money is released to pay for it. The review already counted against the limits of the origin account, so
+// only the funds are checked again, and the transfer is charged the fees due at the time of the approval. The review
+// stays pending when the transfer can't be made, so it can still be rejected.
+func (a appImpl) ApproveReview(ctx context.Context, reviewID string, reviewerID string) (*model.Transfer, error) {
+	var (
+		transfer model.Transfer
+		genData  *model.GeneratedData
+	)
+	err := a.txManager.Execute(ctx, func(tx transaction.Transaction) error {
+		a.useTransaction(tx)
+
+		review, err := a.getPendingReview(ctx, reviewID)
 		if err != nil {
-			a.rollbackTransaction(tx)
+			return err
 		}
-	}()
 
-	genData, err := a.makeTransfer(ctx, transferWrapper{
-		Transfer:      transfer,
-		AccountOrigin: originAccount,
-		AccountTarget: targetAccount,
+		wrapper, err := a.lockAccounts(ctx, review.Transfer())
+		if err != nil {
+			return err
+		}
+		if err = a.repoAccount.Release(ctx, review.OriginAccountID, review.Reserved); err != nil {
+			return err
+		}
+		wrapper.AccountOrigin.Reserved -= review.Reserved
+		if err = a.checkFunds(ctx, wrapper, false); err != nil {
+			return err
+		}
+		transfer = wrapper.Transfer
+
+		genData, err = a.makeTransfer(ctx, *wrapper)
+		if err != nil {
+			return err
+		}
+		return a.repoReview.Decide(ctx, review.ID, model.TransferReviewStatusApproved, reviewerID, &genData.ID)
 	})
 	if err != nil {
+		switch {
+		case errors.Is(err, pkgerror.ErrTransferReviewNotFound),
+			errors.Is(err, pkgerror.ErrTransferReviewAlreadyDecided),
+			errors.Is(err, pkgerror.ErrInsufficientFunds),
+			errors.Is(err, pkgerror.ErrOriginAccountNotActive),
+			errors.Is(err, pkgerror.ErrTargetAccountNotActive):
+			return nil, err
+		}
 		a.logger.Error(err)
-		return nil, pkgerror.ErrCantCreateTransfer
+		return nil, pkgerror.ErrCantDecideTransferReview
 	}
 
 	transfer.ID = genData.ID
 	transfer.CreatedAt = genData.CreatedAt
+	transfer.ReviewID = &reviewID
+
+	return &transfer, nil
+}
+
+// RejectReview drops the transfer held by the review, giving its reserved money back to the origin account and
+// recording reviewerID as the operator who rejected it.
+func (a appImpl) RejectReview(ctx context.Context, reviewID string, reviewerID string) error {
+	err := a.txManager.Execute(ctx, func(tx transaction.Transaction) error {
+		a.useTransaction(tx)
 
-	err = a.txManager.Commit(tx)
+		review, err := a.getPendingReview(ctx, reviewID)
+		if err != nil {
+			return err
+		}
+		if err = a.repoAccount.Release(ctx, review.OriginAccountID, review.Reserved); err != nil {
+			return err
+		}
+		return a.repoReview.Decide(ctx, review.ID, model.TransferReviewStatusRejected, reviewerID, nil)
+	})
 	if err != nil {
+		switch {
+		case errors.Is(err, pkgerror.ErrTransferReviewNotFound),
+			errors.Is(err, pkgerror.ErrTransferReviewAlreadyDecided):
+			return err
+		}
 		a.logger.Error(err)
-		return nil, pkgerror.ErrCantCreateTransfer
+		return pkgerror.ErrCantDecideTransferReview
 	}
+	return nil
+}
 
-	return &transfer, nil
+// getPendingReview reads the review locking it until the end of the current transaction, refusing reviews already
+// decided.
+func (a *appImpl) getPendingReview(ctx context.Context, reviewID string) (*model.TransferReview, error) {
+	review, err := a.repoReview.GetByIDForUpdate(ctx, reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if review == nil {
+		return nil, pkgerror.ErrTransferReviewNotFound
+	}
+	if review.Status != model.TransferReviewStatusPending {
+		return nil, pkgerror.ErrTransferReviewAlreadyDecided
+	}
+	return review, nil
 }
 
-func (a *appImpl) makeTransfer(ctx context.Context, wrapper transferWrapper) (*model.GeneratedData, error) {
+// CreateHold reserves the amount of the hold, with the fees a transfer of it would be charged, in the origin account
+// until the target account captures or releases it. The hold goes through the checks of Create as if it were the
+// transfer, but it can't wait for a review, so a hold the risk check would send to one is denied. It expires at
+// ExpiresAt, defaultHoldDuration from now when not set, and never after maxHoldDuration.
+func (a appImpl) CreateHold(ctx context.Context, hold model.Hold) (*model.Hold, error) {
+	now := a.generate.CurrentTime()
+	if hold.ExpiresAt.IsZero() {
+		hold.ExpiresAt = now.Add(defaultHoldDuration)
+	}
+	if !hold.ExpiresAt.After(now) || hold.ExpiresAt.After(now.Add(maxHoldDuration)) {
+		return nil, pkgerror.ErrInvalidHoldExpiry
+	}
+
+	transfer := hold.Transfer(hold.Amount)
+	transfer.TwoFactorCode = hold.TwoFactorCode
+	prepared, err := a.prepare(ctx, transfer)
+	if err != nil {
+		return nil, err
+	}
+	transfer = prepared.Transfer
+	if err = a.checkStepUp(ctx, transfer); err != nil {
+		return nil, err
+	}
+
+	var genData *model.GeneratedData
+	err = a.txManager.Execute(ctx, func(tx transaction.Transaction) error {
+		a.useTransaction(tx)
+
+		wrapper, err := a.lockAccounts(ctx, transfer)
+		if err != nil {
+			return err
+		}
+		if err = a.checkTransfer(ctx, wrapper, false); err != nil {
+			return err
+		}
+
+		assessment, err := a.assessRisk(ctx, *wrapper)
+		if err != nil {
+			return err
+		}
+		if assessment.Decision != risk.DecisionAllow {
+			return pkgerror.ErrTransferDenied
+		}
+
+		hold.OriginAccountID = wrapper.Transfer.OriginAccountID
+		hold.TargetAccountID = wrapper.Transfer.TargetAccountID
+		hold.Currency = wrapper.Transfer.SourceCurrency
+		hold.TargetAmount = wrapper.Transfer.TargetAmount
+		hold.TargetCurrency = wrapper.Transfer.TargetCurrency
+		hold.ExchangeRate = wrapper.Transfer.ExchangeRate
+		hold.Reserved = wrapper.Transfer.Amount + wrapper.Transfer.Fee
+		if err = a.reserve(ctx, hold.OriginAccountID, hold.Reserved); err != nil {
+			return err
+		}
+
+		genData, err = a.repoHold.Create(ctx, hold)
+		return err
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pkgerror.ErrInsufficientFunds),
+			errors.Is(err, pkgerror.ErrTransferLimitExceeded),
+			errors.Is(err, pkgerror.ErrTransferDenied),
+			errors.Is(err, pkgerror.ErrOriginAccountTransferNotFound),
+			errors.Is(err, pkgerror.ErrTargetAccountTransferNotFound),
+			errors.Is(err, pkgerror.ErrOriginAccountNotActive),
+			errors.Is(err, pkgerror.ErrTargetAccountNotActive):
+			return nil, err
+		}
+		a.logger.Error(err)
+		return nil, pkgerror.ErrCantCreateHold
+	}
+
+	hold.ID = genData.ID
+	hold.Status = model.HoldStatusActive
+	hold.CreatedAt = genData.CreatedAt
+
+	return &hold, nil
+}
+
+// ListHolds returns the holds made or received by the account, optionally filtered by status when it is not empty.
+func (a appImpl) ListHolds(ctx context.Context, accountID string, status string) ([]model.Hold, error) {
+	holds, err := a.repoHold.List(ctx, accountID, status)
+	if err != nil {
+		a.logger.Error(err)
+		return nil, pkgerror.ErrCantListHolds
+	}
+	return holds, nil
+}
+
+// CaptureHold makes the transfer of amount of the hold to its target account, which must be accountID, and releases
+// the rest of it. An amount of zero captures the whole hold. The money of the hold is released to pay for the
+// transfer, which is charged the fees due at the time of the capture and converted at the rate quoted when the hold
+// was made. The hold already counted against the limits of the origin account, so only the funds are checked again.
+func (a appImpl) CaptureHold(ctx context.Context, accountID string, holdID string, amount int64) (*model.Transfer, error) {
+	if amount < 0 {
+		return nil, pkgerror.ErrInvalidCaptureAmount
+	}
+
 	var (
-		transferData  = wrapper.Transfer
-		accountOrigin = wrapper.AccountOrigin
-		accountTarget = wrapper.AccountTarget
-		errGroup      *errgroup.Group
-		genData       *model.GeneratedData
+		transfer model.Transfer
+		genData  *model.GeneratedData
 	)
+	err := a.txManager.Execute(ctx, func(tx transaction.Transaction) error {
+		a.useTransaction(tx)
+
+		hold, err := a.getActiveHold(ctx, accountID, holdID)
+		if err != nil {
+			return err
+		}
+		if !hold.ExpiresAt.After(a.generate.CurrentTime()) {
+			return pkgerror.ErrHoldNotActive
+		}
+		captured := hold.Amount
+		if amount > 0 {
+			captured = amount
+		}
+		if captured > hold.Amount {
+			return pkgerror.ErrInvalidCaptureAmount
+		}
 
-	errGroup, ctx = errgroup.WithContext(ctx)
+		wrapper, err := a.lockAccounts(ctx, hold.Transfer(captured))
+		if err != nil {
+			return err
+		}
+		if err = a.repoAccount.Release(ctx, hold.OriginAccountID, hold.Reserved); err != nil {
+			return err
+		}
+		wrapper.AccountOrigin.Reserved -= hold.Reserved
+		if hold.TargetCurrency != "" {
+			err = captureConversion(&wrapper.Transfer, *hold)
+		} else {
+			// holds made before their quote was stored
+			err = a.convert(ctx, &wrapper.Transfer, wrapper.AccountOrigin.Currency, wrapper.AccountTarget.Currency)
+		}
+		if err != nil {
+			return err
+		}
+		if err = a.checkFunds(ctx, wrapper, false); err != nil {
+			return err
+		}
+		transfer = wrapper.Transfer
 
-	errGroup.Go(func() (err error) {
-		genData, err = a.repoTransfer.Create(ctx, transferData)
+		genData, err = a.makeTransfer(ctx, *wrapper)
 		if err != nil {
-			a.logger.Error(err)
+			return err
 		}
-		return
+		return a.repoHold.Close(ctx, hold.ID, model.HoldStatusCaptured, captured, &genData.ID)
 	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pkgerror.ErrHoldNotFound),
+			errors.Is(err, pkgerror.ErrHoldNotActive),
+			errors.Is(err, pkgerror.ErrInvalidCaptureAmount),
+			errors.Is(err, pkgerror.ErrInsufficientFunds),
+			errors.Is(err, pkgerror.ErrExchangeRateNotFound),
+			errors.Is(err, pkgerror.ErrTransferAmountTooSmall),
+			errors.Is(err, pkgerror.ErrOriginAccountNotActive),
+			errors.Is(err, pkgerror.ErrTargetAccountNotActive):
+			return nil, err
+		}
+		a.logger.Error(err)
+		return nil, pkgerror.ErrCantCaptureHold
+	}
+
+	transfer.ID = genData.ID
+	transfer.CreatedAt = genData.CreatedAt
 
-	errGroup.Go(func() (err error) {
-		err = a.repoAccount.UpdateBalance(ctx, accountOrigin.ID, accountOrigin.Balance-transferData.Amount)
+	return &transfer, nil
+}
+
+// ReleaseHold gives the money of the hold back to its origin account without capturing any of it. Only the target
+// account, accountID, releases a hold; the origin account waits for it to expire.
+func (a appImpl) ReleaseHold(ctx context.Context, accountID string, holdID string) (*model.Hold, error) {
+	var hold *model.Hold
+	err := a.txManager.Execute(ctx, func(tx transaction.Transaction) (err error) {
+		a.useTransaction(tx)
+
+		hold, err = a.getActiveHold(ctx, accountID, holdID)
 		if err != nil {
-			a.logger.Error(err)
+			return err
+		}
+		if err = a.repoAccount.Release(ctx, hold.OriginAccountID, hold.Reserved); err != nil {
+			return err
 		}
-		return
+		return a.repoHold.Close(ctx, hold.ID, model.HoldStatusReleased, 0, nil)
 	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pkgerror.ErrHoldNotFound),
+			errors.Is(err, pkgerror.ErrHoldNotActive):
+			return nil, err
+		}
+		a.logger.Error(err)
+		return nil, pkgerror.ErrCantReleaseHold
+	}
+
+	hold.Status = model.HoldStatusReleased
+	return hold, nil
+}
 
-	errGroup.Go(func() (err error) {
-		err = a.repoAccount.UpdateBalance(ctx, accountTarget.ID, accountTarget.Balance+transferData.Amount)
+// ExpireHolds gives the money of the active holds past their expiry back to their origin accounts, up to
+// expireHoldsBatchSize holds per call.
+func (a appImpl) ExpireHolds(ctx context.Context) error {
+	err := a.txManager.Execute(ctx, func(tx transaction.Transaction) error {
+		a.useTransaction(tx)
+
+		holds, err := a.repoHold.ListExpiredForUpdate(ctx, a.generate.CurrentTime(), expireHoldsBatchSize)
 		if err != nil {
-			a.logger.Error(err)
+			return err
 		}
-		return err
+		for _, hold := range holds {
+			if err = a.repoAccount.Release(ctx, hold.OriginAccountID, hold.Reserved); err != nil {
+				return err
+			}
+			if err = a.repoHold.Close(ctx, hold.ID, model.HoldStatusExpired, 0, nil); err != nil {
+				return err
+			}
+		}
+		return nil
 	})
+	if err != nil {
+		a.logger.Error(err)
+		return pkgerror.ErrCantExpireHolds
+	}
+	return nil
+}
+
+// getActiveHold reads the hold received by accountID locking it until the end of the current transaction, refusing
+// holds already closed.
+func (a *appImpl) getActiveHold(ctx context.Context, accountID string, holdID string) (*model.Hold, error) {
+	hold, err := a.repoHold.GetByIDForUpdate(ctx, holdID)
+	if err != nil {
+		return nil, err
+	}
+	if hold == nil || hold.TargetAccountID != accountID {
+		return nil, pkgerror.ErrHoldNotFound
+	}
+	if hold.Status != model.HoldStatusActive {
+		return nil, pkgerror.ErrHoldNotActive
+	}
+	return hold, nil
+}
+
+// convert fills the currencies of the transfer and the amount received by the target account. Transfers between
+// accounts of the same currency don't reach the rate provider.
+func (a *appImpl) convert(ctx context.Context, transfer *model.Transfer, from string, to string) error {
+	transfer.SourceCurrency = from
+	transfer.TargetCurrency = to
+	if from == to {
+		transfer.TargetAmount = transfer.Amount
+		transfer.ExchangeRate = "1"
+		return nil
+	}
 
-	if err := errGroup.Wait(); err != nil {
+	fromUnits, fromOK := model.GetCurrencyMinorUnits(from)
+	toUnits, toOK := model.GetCurrencyMinorUnits(to)
+	if !fromOK || !toOK {
+		a.logger.Error(fmt.Errorf("unsupported currency in transfer from %s to %s", from, to))
+		return pkgerror.ErrCantCreateTransfer
+	}
+
+	rate, err := a.rates.Rate(ctx, from, to)
+	if err != nil {
+		if errors.Is(err, exchange.ErrRateNotFound) {
+			return pkgerror.ErrExchangeRateNotFound
+		}
 		a.logger.Error(err)
+		return pkgerror.ErrCantCreateTransfer
+	}
+
+	targetAmount, ok := convertAmount(transfer.Amount, rate, fromUnits, toUnits)
+	if !ok {
+		return pkgerror.ErrCantCreateTransfer
+	}
+	if targetAmount <= 0 {
+		return pkgerror.ErrTransferAmountTooSmall
+	}
+	transfer.TargetAmount = targetAmount
+	transfer.ExchangeRate = formatRate(rate)
+	return nil
+}
+
+// reverseConversion fills the amount received back by the origin of original, in the proportion between the amounts
+// of original so a full reversal returns exactly what was sent.
+func reverseConversion(reversal *model.Transfer, original model.Transfer) error {
+	rate, ok := new(big.Rat).SetString(original.ExchangeRate)
+	if !ok || rate.Sign() <= 0 {
+		return fmt.Errorf("invalid exchange rate %q of transfer %s", original.ExchangeRate, original.ID)
+	}
+	targetAmount, ok := scaleAmount(reversal.Amount, original.Amount, original.TargetAmount)
+	if !ok || targetAmount <= 0 {
+		return pkgerror.ErrInvalidReversalAmount
+	}
+	reversal.TargetAmount = targetAmount
+	reversal.ExchangeRate = formatRate(rate.Inv(rate))
+	return nil
+}
+
+// captureConversion fills the conversion of the transfer capturing part of hold with the one quoted when the hold was
+// made, with the target amount in the proportion of the amount captured so a full capture receives exactly the quote.
+func captureConversion(transfer *model.Transfer, hold model.Hold) error {
+	targetAmount, ok := scaleAmount(transfer.Amount, hold.TargetAmount, hold.Amount)
+	if !ok {
+		return fmt.Errorf("invalid target amount %d of hold %s", hold.TargetAmount, hold.ID)
+	}
+	if targetAmount <= 0 {
+		return pkgerror.ErrTransferAmountTooSmall
+	}
+	transfer.SourceCurrency = hold.Currency
+	transfer.TargetAmount = targetAmount
+	transfer.TargetCurrency = hold.TargetCurrency
+	transfer.ExchangeRate = hold.ExchangeRate
+	return nil
+}
+
+func (a *appImpl) checkStepUp(ctx context.Context, transfer model.Transfer) error {
+	if a.stepUpThreshold == 0 || transfer.Amount <= a.stepUpThreshold || model.GetSessionFromContext(ctx) == nil {
+		return nil
+	}
+	err := a.twoFactorApp.Verify(ctx, transfer.OriginAccountID, transfer.TwoFactorCode)
+	switch err {
+	case nil, pkgerror.ErrTwoFactorRequired, pkgerror.ErrInvalidTwoFactorCode:
+		return err
+	}
+	return pkgerror.ErrCantCreateTransfer
+}
+
+// executeTransfer moves the money of the transfer, filling its fees. It must run inside a transaction.
+func (a *appImpl) executeTransfer(ctx context.Context, transfer *model.Transfer, reversal bool) (*model.GeneratedData, error) {
+	wrapper, err := a.lockAccounts(ctx, *transfer)
+	if err != nil {
+		return nil, err
+	}
+	if err = a.checkTransfer(ctx, wrapper, reversal); err != nil {
 		return nil, err
 	}
+	*transfer = wrapper.Transfer
+	return a.makeTransfer(ctx, *wrapper)
+}
 
-	return genData, nil
+// checkTransfer checks the transfer of the locked wrapper can be made, filling its fees. Reversals give back money
+// already received, so they are neither limited nor charged. Limits and fees are checked after the origin account is
+// locked, so concurrent transfers can't both fit the same allowance or share the same free transfer of the month.
+func (a *appImpl) checkTransfer(ctx context.Context, wrapper *transferWrapper, reversal bool) error {
+	if !reversal {
+		if err := a.checkLimits(ctx, wrapper.Transfer); err != nil {
+			return err
+		}
+	}
+	return a.checkFunds(ctx, wrapper, reversal)
+}
+
+// checkFunds fills the fees of the transfer of the locked wrapper, unless it is a reversal, and checks its origin
+// account can pay for it. Money reserved for holds and for transfers waiting for a review can't be moved.
+func (a *appImpl) checkFunds(ctx context.Context, wrapper *transferWrapper, reversal bool) error {
+	if !reversal {
+		if err := a.applyFees(ctx, &wrapper.Transfer); err != nil {
+			return err
+		}
+	}
+	if wrapper.AccountOrigin.AvailableBalance() < wrapper.Transfer.Amount+wrapper.Transfer.Fee {
+		return pkgerror.ErrInsufficientFunds
+	}
+	return nil
 }
 
-func (a *appImpl) startTransaction(ctx context.Context) (transaction.Transaction, error) {
-	tx, err := a.txManager.Create(ctx)
+// assessRisk hands the transfer of the wrapper to the risk check, with what its origin account sent recently.
+// Transfers made by the workers and transfers of an app without a risk check are allowed.
+func (a *appImpl) assessRisk(ctx context.Context, wrapper transferWrapper) (risk.Assessment, error) {
+	if a.risk == nil || model.GetSessionFromContext(ctx) == nil {
+		return risk.Assessment{Decision: risk.DecisionAllow}, nil
+	}
+
+	now := a.generate.CurrentTime()
+	history, err := a.repoTransfer.ListSent(ctx, wrapper.Transfer.OriginAccountID, now.Add(-riskHistoryPeriod))
+	if err != nil {
+		return risk.Assessment{}, err
+	}
+	return a.risk.Assess(ctx, riskCheck(wrapper, history, now))
+}
+
+// holdForReview reserves the amount and the fees of the transfer in its origin account and queues it for a review.
+func (a *appImpl) holdForReview(ctx context.Context, transfer model.Transfer, assessment risk.Assessment) (*model.GeneratedData, error) {
+	reserved := transfer.Amount + transfer.Fee
+	if err := a.reserve(ctx, transfer.OriginAccountID, reserved); err != nil {
+		return nil, err
+	}
+
+	genData, err := a.repoReview.Create(ctx, model.TransferReview{
+		OriginAccountID: transfer.OriginAccountID,
+		TargetAccountID: transfer.TargetAccountID,
+		Amount:          transfer.Amount,
+		SourceCurrency:  transfer.SourceCurrency,
+		TargetAmount:    transfer.TargetAmount,
+		TargetCurrency:  transfer.TargetCurrency,
+		ExchangeRate:    transfer.ExchangeRate,
+		Reserved:        reserved,
+		Score:           assessment.Score,
+		Reasons:         assessment.Reasons,
+	})
 	if err != nil {
 		a.logger.Error(err)
 		return nil, err
 	}
+	return genData, nil
+}
+
+// reserve sets amount of the available balance of the account apart, failing when the account doesn't have it.
+func (a *appImpl) reserve(ctx context.Context, accountID string, amount int64) error {
+	ok, err := a.repoAccount.Reserve(ctx, accountID, amount)
+	if err != nil {
+		a.logger.Error(err)
+		return err
+	}
+	if !ok {
+		return pkgerror.ErrInsufficientFunds
+	}
+	return nil
+}
 
-	a.repoAccount = a.repoAccount.WithTransaction(tx)
-	a.repoTransfer = a.repoTransfer.WithTransaction(tx)
+// checkLimits checks the transfer against the limits of its origin account and the transfers it already sent.
+func (a *appImpl) checkLimits(ctx context.Context, transfer model.Transfer) error {
+	limits, err := a.repoLimit.Get(ctx, transfer.OriginAccountID)
+	if err != nil {
+		return err
+	}
+	if limits == nil {
+		defaults := model.DefaultAccountLimits(transfer.OriginAccountID)
+		limits = &defaults
+	}
+
+	dayStart, monthStart := model.LimitPeriods(a.generate.CurrentTime())
+	usage, err := a.repoTransfer.GetUsage(ctx, transfer.OriginAccountID, dayStart, monthStart)
+	if err != nil {
+		return err
+	}
+	return exceededLimit(limits.Effective(), *usage, transfer.Amount)
+}
+
+// applyFees fills the fees of the transfer from the fee schedule. Transfers sent by the fee account or in a currency
+// other than the one of the schedule are not charged.
+func (a *appImpl) applyFees(ctx context.Context, transfer *model.Transfer) error {
+	transfer.Fee = 0
+	transfer.Fees = nil
+	if !a.mayChargeFees(*transfer) {
+		return nil
+	}
+
+	now := a.generate.CurrentTime().UTC()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	sent, err := a.repoTransfer.CountSent(ctx, transfer.OriginAccountID, monthStart)
+	if err != nil {
+		return err
+	}
+
+	for _, charge := range a.fees.Calculate(transfer.Amount, sent) {
+		transfer.Fee += charge.Amount
+		transfer.Fees = append(transfer.Fees, model.TransferFee{Rule: charge.Rule, Amount: charge.Amount})
+	}
+	return nil
+}
+
+// mayChargeFees tells whether the fee schedule applies to the transfer, before counting what its origin account sent.
+func (a *appImpl) mayChargeFees(transfer model.Transfer) bool {
+	return a.fees != nil && a.feeAccountID != "" && transfer.OriginAccountID != a.feeAccountID &&
+		transfer.SourceCurrency == a.fees.Currency()
+}
+
+// lockAccounts reads both accounts of the transfer with a row lock and checks they can move money. The fee account is
+// locked as well when the transfer may be charged, since its fees are credited to it. Locks are always taken in
+// ascending id order, so two transfers sharing accounts in opposite directions wait for each other instead of
+// deadlocking.
+func (a *appImpl) lockAccounts(ctx context.Context, transfer model.Transfer) (*transferWrapper, error) {
+	ids := []string{transfer.OriginAccountID, transfer.TargetAccountID}
+	if transfer.Fee > 0 || a.mayChargeFees(transfer) {
+		ids = append(ids, a.feeAccountID)
+	}
+	sort.Strings(ids)
 
-	return tx, nil
+	accounts := make(map[string]*model.Account, len(ids))
+	for _, id := range ids {
+		if _, locked := accounts[id]; locked {
+			continue
+		}
+		acc, err := a.repoAccount.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			a.logger.Error(err)
+			return nil, err
+		}
+		accounts[id] = acc
+	}
+
+	wrapper := &transferWrapper{
+		Transfer:      transfer,
+		AccountOrigin: accounts[transfer.OriginAccountID],
+		AccountTarget: accounts[transfer.TargetAccountID],
+	}
+	if wrapper.AccountOrigin == nil {
+		return nil, pkgerror.ErrOriginAccountTransferNotFound
+	}
+	if wrapper.AccountTarget == nil {
+		return nil, pkgerror.ErrTargetAccountTransferNotFound
+	}
+	if wrapper.AccountOrigin.Status != model.AccountStatusActive {
+		return nil, pkgerror.ErrOriginAccountNotActive
+	}
+	if wrapper.AccountTarget.Status != model.AccountStatusActive {
+		return nil, pkgerror.ErrTargetAccountNotActive
+	}
+	return wrapper, nil
 }
 
-func (a *appImpl) rollbackTransaction(tx transaction.Transaction) {
-	err := a.txManager.Rollback(tx)
+// makeTransfer moves the money and records the transfer. The statements run one after the other because they share
+// the transaction of the caller, and a database connection does not take concurrent statements.
+func (a *appImpl) makeTransfer(ctx context.Context, wrapper transferWrapper) (*model.GeneratedData, error) {
+	var (
+		transferData  = wrapper.Transfer
+		accountOrigin = wrapper.AccountOrigin
+		accountTarget = wrapper.AccountTarget
+	)
+
+	debited, err := a.repoAccount.Debit(ctx, accountOrigin.ID, transferData.Amount+transferData.Fee)
+	if err != nil {
+		a.logger.Error(err)
+		return nil, err
+	}
+	if !debited {
+		return nil, pkgerror.ErrInsufficientFunds
+	}
+
+	err = a.repoAccount.Credit(ctx, accountTarget.ID, transferData.TargetAmount)
+	if err != nil {
+		a.logger.Error(err)
+		return nil, err
+	}
+
+	genData, err := a.repoTransfer.Create(ctx, transferData)
+	if err != nil {
+		a.logger.Error(err)
+		return nil, err
+	}
+
+	entries := []model.LedgerEntry{
+		{
+			AccountID:  accountOrigin.ID,
+			TransferID: &genData.ID,
+			Type:       model.LedgerEntryTypeDebit,
+			Amount:     transferData.Amount,
+		},
+		{
+			AccountID:  accountTarget.ID,
+			TransferID: &genData.ID,
+			Type:       model.LedgerEntryTypeCredit,
+			Amount:     transferData.TargetAmount,
+		},
+	}
+	if transferData.SourceCurrency != transferData.TargetCurrency {
+		entries = append(entries,
+			model.LedgerEntry{
+				LedgerAccount: model.LedgerAccountFXClearing,
+				Currency:      transferData.SourceCurrency,
+				TransferID:    &genData.ID,
+				Type:          model.LedgerEntryTypeCredit,
+				Amount:        transferData.Amount,
+			},
+			model.LedgerEntry{
+				LedgerAccount: model.LedgerAccountFXClearing,
+				Currency:      transferData.TargetCurrency,
+				TransferID:    &genData.ID,
+				Type:          model.LedgerEntryTypeDebit,
+				Amount:        transferData.TargetAmount,
+			},
+		)
+	}
+	if transferData.Fee > 0 {
+		if err := a.collectFees(ctx, genData.ID, transferData); err != nil {
+			return nil, err
+		}
+		entries = append(entries,
+			model.LedgerEntry{
+				AccountID:  accountOrigin.ID,
+				TransferID: &genData.ID,
+				Type:       model.LedgerEntryTypeDebit,
+				Amount:     transferData.Fee,
+			},
+			model.LedgerEntry{
+				AccountID:  a.feeAccountID,
+				TransferID: &genData.ID,
+				Type:       model.LedgerEntryTypeCredit,
+				Amount:     transferData.Fee,
+			},
+		)
+	}
+
+	err = a.repoLedger.Create(ctx, entries)
 	if err != nil {
 		a.logger.Error(err)
+		return nil, err
 	}
+
+	return genData, nil
+}
+
+// collectFees credits the fee of the transfer to the fee account and stores its breakdown. The fee was already
+// debited from the origin account along with the amount.
+func (a *appImpl) collectFees(ctx context.Context, transferID string, transfer model.Transfer) error {
+	if err := a.repoAccount.Credit(ctx, a.feeAccountID, transfer.Fee); err != nil {
+		a.logger.Error(err)
+		return err
+	}
+
+	fees := make([]model.TransferFee, len(transfer.Fees))
+	for i, item := range transfer.Fees {
+		item.TransferID = transferID
+		fees[i] = item
+	}
+	if err := a.repoTransfer.CreateFees(ctx, fees); err != nil {
+		a.logger.Error(err)
+		return err
+	}
+	return nil
+}
+
+func (a *appImpl) useTransaction(tx transaction.Transaction) {
+	a.repoAccount = a.repoAccount.WithTransaction(tx)
+	a.repoHold = a.repoHold.WithTransaction(tx)
+	a.repoLedger = a.repoLedger.WithTransaction(tx)
+	a.repoLimit = a.repoLimit.WithTransaction(tx)
+	a.repoReview = a.repoReview.WithTransaction(tx)
+	a.repoTransfer = a.repoTransfer.WithTransaction(tx)
 }
@@ -0,0 +1,101 @@
+package transfer
+
+import (
+	pkgerror "github.com/carlosrodriguesf/bank-api/pkg/error"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/risk"
+	"math/big"
+	"strings"
+	"time"
+)
+
+const exchangeRateDigits = 12
+
+// convertAmount converts amount, in minor units of a currency with fromUnits minor digits, to minor units of a
+// currency with toUnits minor digits, rounding half up. It returns false when the result does not fit an int64.
+func convertAmount(amount int64, rate *big.Rat, fromUnits int, toUnits int) (int64, bool) {
+	value := new(big.Rat).Mul(big.NewRat(amount, 1), rate)
+	value.Mul(value, new(big.Rat).SetFrac(pow10(toUnits), pow10(fromUnits)))
+	return roundHalfUp(value)
+}
+
+// scaleAmount returns amount * numerator / denominator rounded half up, used to reverse a part of a converted
+// transfer in the proportion of its amounts.
+func scaleAmount(amount int64, numerator int64, denominator int64) (int64, bool) {
+	return roundHalfUp(new(big.Rat).Mul(big.NewRat(amount, 1), big.NewRat(numerator, denominator)))
+}
+
+func roundHalfUp(value *big.Rat) (int64, bool) {
+	quotient, remainder := new(big.Int).QuoRem(value.Num(), value.Denom(), new(big.Int))
+	if remainder.Mul(remainder, big.NewInt(2)).CmpAbs(value.Denom()) >= 0 {
+		quotient.Add(quotient, big.NewInt(int64(value.Sign())))
+	}
+	return quotient.Int64(), quotient.IsInt64()
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// formatRate writes rate as a decimal with up to exchangeRateDigits digits, the precision kept by the database.
+func formatRate(rate *big.Rat) string {
+	s := rate.FloatString(exchangeRateDigits)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// exceededLimit returns a *pkgerror.TransferLimitError for the first limit a new transfer of amount would go over,
+// with what is left of it, or nil when it fits all of them.
+func exceededLimit(limits model.TransferLimits, usage model.TransferUsage, amount int64) error {
+	checks := []struct {
+		name  string
+		limit int64
+		used  int64
+		next  int64
+	}{
+		{model.LimitPerTransfer, limits.PerTransfer, 0, amount},
+		{model.LimitDaily, limits.Daily, usage.Daily, amount},
+		{model.LimitMonthly, limits.Monthly, usage.Monthly, amount},
+		{model.LimitDailyCount, limits.DailyCount, usage.DailyCount, 1},
+	}
+	for _, check := range checks {
+		if check.limit == 0 || check.used+check.next <= check.limit {
+			continue
+		}
+		remaining := check.limit - check.used
+		if remaining < 0 {
+			remaining = 0
+		}
+		return &pkgerror.TransferLimitError{Limit: check.name, Remaining: remaining}
+	}
+	return nil
+}
+
+// riskCheck describes the transfer of the wrapper, made at now, to the risk check. Balances are what the
+// accounts can move, without their reserved money.
+func riskCheck(wrapper transferWrapper, history []model.Transfer, now time.Time) risk.Check {
+	check := risk.Check{
+		Transfer: risk.Transfer{
+			OriginAccountID: wrapper.Transfer.OriginAccountID,
+			TargetAccountID: wrapper.Transfer.TargetAccountID,
+			Amount:          wrapper.Transfer.Amount,
+			CreatedAt:       now,
+		},
+		Origin:  riskAccount(*wrapper.AccountOrigin),
+		Target:  riskAccount(*wrapper.AccountTarget),
+		History: make([]risk.Transfer, len(history)),
+	}
+	for i, sent := range history {
+		check.History[i] = risk.Transfer{
+			OriginAccountID: sent.OriginAccountID,
+			TargetAccountID: sent.TargetAccountID,
+			Amount:          sent.Amount,
+			CreatedAt:       sent.CreatedAt,
+		}
+	}
+	return check
+}
+
+func riskAccount(acc model.Account) risk.Account {
+	return risk.Account{ID: acc.ID, Balance: acc.AvailableBalance(), CreatedAt: acc.CreatedAt}
+}
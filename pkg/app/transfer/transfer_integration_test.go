@@ -0,0 +1,148 @@
+//go:build integration
+
+package transfer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	accountapp "github.com/carlosrodriguesf/bank-api/pkg/app/account"
+	pkgerror "github.com/carlosrodriguesf/bank-api/pkg/error"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/db"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/generate"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/secret"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/transaction"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/validator"
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestCreateConcurrently fires transfers in parallel between a small set of accounts and checks that money is
+// neither created nor destroyed. It needs a real postgres, configured through DATABASE_URL.
+func TestCreateConcurrently(t *testing.T) {
+	const (
+		accountsCount  = 10
+		openingBalance = 1000
+		transfersCount = 500
+	)
+
+	databaseURL := os.Getenv("DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("DATABASE_URL not set")
+	}
+
+	m, err := migrate.New("file://../../../migrations", databaseURL)
+	if !assert.NoError(t, err) {
+		return
+	}
+	if err = m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		t.Fatal(err)
+	}
+
+	conn, err := sqlx.Connect("postgres", databaseURL)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer conn.Close()
+
+	secretInstance, err := secret.New(secret.Options{})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var (
+		ctx           = context.Background()
+		log           = logger.New("")
+		extendedDB    = db.NewExtendedDB(conn)
+		txManager     = transaction.NewManager(extendedDB)
+		repoContainer = repository.NewContainer(repository.Options{Logger: log, DB: extendedDB})
+		accountApp    = accountapp.NewApp(accountapp.Options{
+			Logger:       log,
+			Secret:       secretInstance,
+			Validator:    validator.New(),
+			TxManager:    txManager,
+			RepoAccount:  repoContainer.Account(),
+			RepoLedger:   repoContainer.Ledger(),
+			RepoTransfer: repoContainer.Transfer(),
+		})
+		app = NewApp(Options{
+			Logger:       log,
+			Validator:    validator.New(),
+			Generate:     generate.New(),
+			TxManager:    txManager,
+			RepoAccount:  repoContainer.Account(),
+			RepoHold:     repoContainer.Hold(),
+			RepoLedger:   repoContainer.Ledger(),
+			RepoLimit:    repoContainer.Limit(),
+			RepoReview:   repoContainer.Review(),
+			RepoTransfer: repoContainer.Transfer(),
+		})
+		random   = rand.New(rand.NewSource(time.Now().UnixNano()))
+		accounts = make([]string, accountsCount)
+	)
+
+	for i := range accounts {
+		acc, err := accountApp.Create(ctx, model.Account{
+			Name:     fmt.Sprintf("Concurrency %d", i),
+			Document: fmt.Sprintf("%011d", random.Int63n(1e11)),
+			Secret:   "secret",
+			Balance:  openingBalance,
+		})
+		if !assert.NoError(t, err) {
+			return
+		}
+		accounts[i] = acc.ID
+	}
+
+	transfers := make([]model.Transfer, transfersCount)
+	for i := range transfers {
+		origin := random.Intn(accountsCount)
+		target := (origin + 1 + random.Intn(accountsCount-1)) % accountsCount
+		transfers[i] = model.Transfer{
+			OriginAccountID: accounts[origin],
+			TargetAccountID: accounts[target],
+			Amount:          1 + random.Int63n(300),
+		}
+	}
+
+	var wg sync.WaitGroup
+	for _, transfer := range transfers {
+		wg.Add(1)
+		go func(transfer model.Transfer) {
+			defer wg.Done()
+			_, err := app.Create(ctx, transfer)
+			// accounts running out of money or of daily transfers are expected
+			if err != nil && err != pkgerror.ErrInsufficientFunds && !errors.Is(err, pkgerror.ErrTransferLimitExceeded) {
+				t.Error(err)
+			}
+		}(transfer)
+	}
+	wg.Wait()
+
+	var total int64
+	for _, id := range accounts {
+		acc, err := repoContainer.Account().GetByIDOrDocument(ctx, id)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.GreaterOrEqual(t, acc.Balance, int64(0))
+		total += acc.Balance
+	}
+	assert.Equal(t, int64(accountsCount*openingBalance), total)
+
+	divergences, err := repoContainer.Ledger().ListDivergences(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, divergences)
+}
@@ -35,6 +35,51 @@ func (m *MockApp) EXPECT() *MockAppMockRecorder {
 	return m.recorder
 }
 
+// ApproveReview mocks base method.
+func (m *MockApp) ApproveReview(ctx context.Context, reviewID, reviewerID string) (*model.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveReview", ctx, reviewID, reviewerID)
+	ret0, _ := ret[0].(*model.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveReview indicates an expected call of ApproveReview.
+func (mr *MockAppMockRecorder) ApproveReview(ctx, reviewID, reviewerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveReview", reflect.TypeOf((*MockApp)(nil).ApproveReview), ctx, reviewID, reviewerID)
+}
+
+// Authorize mocks base method.
+func (m *MockApp) Authorize(ctx context.Context, transfer model.Transfer) (*model.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authorize", ctx, transfer)
+	ret0, _ := ret[0].(*model.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authorize indicates an expected call of Authorize.
+func (mr *MockAppMockRecorder) Authorize(ctx, transfer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorize", reflect.TypeOf((*MockApp)(nil).Authorize), ctx, transfer)
+}
+
+// CaptureHold mocks base method.
+func (m *MockApp) CaptureHold(ctx context.Context, accountID, holdID string, amount int64) (*model.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CaptureHold", ctx, accountID, holdID, amount)
+	ret0, _ := ret[0].(*model.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CaptureHold indicates an expected call of CaptureHold.
+func (mr *MockAppMockRecorder) CaptureHold(ctx, accountID, holdID, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CaptureHold", reflect.TypeOf((*MockApp)(nil).CaptureHold), ctx, accountID, holdID, amount)
+}
+
 // Create mocks base method.
 func (m *MockApp) Create(ctx context.Context, transfer model.Transfer) (*model.Transfer, error) {
 	m.ctrl.T.Helper()
@@ -50,17 +95,150 @@ func (mr *MockAppMockRecorder) Create(ctx, transfer interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockApp)(nil).Create), ctx, transfer)
 }
 
+// CreateHold mocks base method.
+func (m *MockApp) CreateHold(ctx context.Context, hold model.Hold) (*model.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHold", ctx, hold)
+	ret0, _ := ret[0].(*model.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateHold indicates an expected call of CreateHold.
+func (mr *MockAppMockRecorder) CreateHold(ctx, hold interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHold", reflect.TypeOf((*MockApp)(nil).CreateHold), ctx, hold)
+}
+
+// CreateWith mocks base method.
+func (m *MockApp) CreateWith(ctx context.Context, transfer model.Transfer, record RecordFunc) (*model.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWith", ctx, transfer, record)
+	ret0, _ := ret[0].(*model.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWith indicates an expected call of CreateWith.
+func (mr *MockAppMockRecorder) CreateWith(ctx, transfer, record interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWith", reflect.TypeOf((*MockApp)(nil).CreateWith), ctx, transfer, record)
+}
+
+// ExpireHolds mocks base method.
+func (m *MockApp) ExpireHolds(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireHolds", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExpireHolds indicates an expected call of ExpireHolds.
+func (mr *MockAppMockRecorder) ExpireHolds(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireHolds", reflect.TypeOf((*MockApp)(nil).ExpireHolds), ctx)
+}
+
 // List mocks base method.
-func (m *MockApp) List(ctx context.Context, accountID string) ([]model.TransferDetailed, error) {
+func (m *MockApp) List(ctx context.Context, filter model.TransferFilter) (*model.TransferPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", ctx, accountID)
-	ret0, _ := ret[0].([]model.TransferDetailed)
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].(*model.TransferPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // List indicates an expected call of List.
-func (mr *MockAppMockRecorder) List(ctx, accountID interface{}) *gomock.Call {
+func (mr *MockAppMockRecorder) List(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockApp)(nil).List), ctx, filter)
+}
+
+// ListHolds mocks base method.
+func (m *MockApp) ListHolds(ctx context.Context, accountID, status string) ([]model.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHolds", ctx, accountID, status)
+	ret0, _ := ret[0].([]model.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHolds indicates an expected call of ListHolds.
+func (mr *MockAppMockRecorder) ListHolds(ctx, accountID, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHolds", reflect.TypeOf((*MockApp)(nil).ListHolds), ctx, accountID, status)
+}
+
+// ListReviews mocks base method.
+func (m *MockApp) ListReviews(ctx context.Context) ([]model.TransferReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReviews", ctx)
+	ret0, _ := ret[0].([]model.TransferReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReviews indicates an expected call of ListReviews.
+func (mr *MockAppMockRecorder) ListReviews(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReviews", reflect.TypeOf((*MockApp)(nil).ListReviews), ctx)
+}
+
+// Quote mocks base method.
+func (m *MockApp) Quote(ctx context.Context, transfer model.Transfer) (*model.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Quote", ctx, transfer)
+	ret0, _ := ret[0].(*model.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Quote indicates an expected call of Quote.
+func (mr *MockAppMockRecorder) Quote(ctx, transfer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Quote", reflect.TypeOf((*MockApp)(nil).Quote), ctx, transfer)
+}
+
+// RejectReview mocks base method.
+func (m *MockApp) RejectReview(ctx context.Context, reviewID, reviewerID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectReview", ctx, reviewID, reviewerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RejectReview indicates an expected call of RejectReview.
+func (mr *MockAppMockRecorder) RejectReview(ctx, reviewID, reviewerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectReview", reflect.TypeOf((*MockApp)(nil).RejectReview), ctx, reviewID, reviewerID)
+}
+
+// ReleaseHold mocks base method.
+func (m *MockApp) ReleaseHold(ctx context.Context, accountID, holdID string) (*model.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseHold", ctx, accountID, holdID)
+	ret0, _ := ret[0].(*model.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseHold indicates an expected call of ReleaseHold.
+func (mr *MockAppMockRecorder) ReleaseHold(ctx, accountID, holdID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseHold", reflect.TypeOf((*MockApp)(nil).ReleaseHold), ctx, accountID, holdID)
+}
+
+// Reverse mocks base method.
+func (m *MockApp) Reverse(ctx context.Context, accountID, transferID string, amount int64) (*model.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reverse", ctx, accountID, transferID, amount)
+	ret0, _ := ret[0].(*model.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reverse indicates an expected call of Reverse.
+func (mr *MockAppMockRecorder) Reverse(ctx, accountID, transferID, amount interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockApp)(nil).List), ctx, accountID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reverse", reflect.TypeOf((*MockApp)(nil).Reverse), ctx, accountID, transferID, amount)
 }
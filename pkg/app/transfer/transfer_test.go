@@ -3,51 +3,118 @@ package transfer
 import (
 	"context"
 	"errors"
+	"github.com/carlosrodriguesf/bank-api/pkg/app/twofactor"
 	pkgerror "github.com/carlosrodriguesf/bank-api/pkg/error"
 	"github.com/carlosrodriguesf/bank-api/pkg/model"
 	"github.com/carlosrodriguesf/bank-api/pkg/repository/account"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/hold"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/ledger"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/limit"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/review"
 	"github.com/carlosrodriguesf/bank-api/pkg/repository/transfer"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/exchange"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/fee"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/generate"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/risk"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/transaction"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/validator"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"math/big"
+	"reflect"
 	"testing"
 	"time"
 )
 
 func TestList(t *testing.T) {
-	transfersExample := []model.TransferDetailed{{
-		Transfer: model.Transfer{
-			ID:              "transfer_id",
-			OriginAccountID: "origin_account_id",
-			TargetAccountID: "target_account_id",
-			Amount:          500,
-			CreatedAt:       time.Now(),
-		},
-		OriginAccountName: "Origin Account",
-		TargetAccountName: "Target account",
-	}}
+	var (
+		currentTime      = time.Now()
+		transfersExample = []model.TransferDetailed{
+			{
+				Transfer: model.Transfer{
+					ID:              "transfer_id_1",
+					OriginAccountID: "origin_account_id",
+					TargetAccountID: "target_account_id",
+					Amount:          500,
+					CreatedAt:       currentTime,
+				},
+				OriginAccountName: "Origin Account",
+				TargetAccountName: "Target account",
+			},
+			{
+				Transfer: model.Transfer{
+					ID:              "transfer_id_2",
+					OriginAccountID: "origin_account_id",
+					TargetAccountID: "target_account_id",
+					Amount:          300,
+					CreatedAt:       currentTime.Add(-time.Hour),
+				},
+				OriginAccountName: "Origin Account",
+				TargetAccountName: "Target account",
+			},
+		}
+		filterExample = model.TransferFilter{
+			AccountID: "origin_account_id",
+			Limit:     1,
+		}
+		validationErrorExample = &validator.ValidationError{Message: "invalid data"}
+	)
 	cases := map[string]struct {
-		InputData               string
-		ExpectedData            []model.TransferDetailed
+		InputData               model.TransferFilter
+		ExpectedData            *model.TransferPage
 		ExpectedError           error
+		PrepareMockValidator    func(mock *validator.MockValidator)
 		PrepareMockRepoTransfer func(mock *transfer.MockRepository)
 	}{
-		"should return success": {
-			InputData:     "origin_account_id",
-			ExpectedData:  transfersExample,
+		"should return page with next cursor": {
+			InputData: filterExample,
+			ExpectedData: &model.TransferPage{
+				Transfers:  transfersExample[:1],
+				NextCursor: model.Cursor{CreatedAt: currentTime, ID: "transfer_id_1"}.Encode(),
+			},
+			ExpectedError: nil,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(filterExample).Return(nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {
+				filter := filterExample
+				filter.Limit = 2
+				mock.EXPECT().List(gomock.Any(), filter).Return(transfersExample, nil)
+			},
+		},
+		"should return last page with default limit": {
+			InputData: model.TransferFilter{AccountID: "origin_account_id"},
+			ExpectedData: &model.TransferPage{
+				Transfers: transfersExample,
+			},
 			ExpectedError: nil,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(model.TransferFilter{AccountID: "origin_account_id"}).Return(nil)
+			},
 			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {
-				mock.EXPECT().List(gomock.Any(), "origin_account_id").Return(transfersExample, nil)
+				filter := model.TransferFilter{AccountID: "origin_account_id", Limit: defaultPageSize + 1}
+				mock.EXPECT().List(gomock.Any(), filter).Return(transfersExample, nil)
+			},
+		},
+		"should return validation error": {
+			InputData:     filterExample,
+			ExpectedData:  nil,
+			ExpectedError: validationErrorExample,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(filterExample).Return(validationErrorExample)
 			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {},
 		},
 		"should return error": {
-			InputData:     "origin_account_id",
+			InputData:     filterExample,
 			ExpectedData:  nil,
 			ExpectedError: pkgerror.ErrCantListTransfers,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(filterExample).Return(nil)
+			},
 			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {
-				mock.EXPECT().List(gomock.Any(), "origin_account_id").Return(nil, errors.New("fail"))
+				mock.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, errors.New("fail"))
 			},
 		},
 	}
@@ -56,13 +123,16 @@ func TestList(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			var (
 				ctrl, ctx        = gomock.WithContext(context.Background(), t)
+				mockValidator    = validator.NewMockValidator(ctrl)
 				mockRepoTransfer = transfer.NewMockRepository(ctrl)
 				app              = NewApp(Options{
 					Logger:       logger.New(""),
+					Validator:    mockValidator,
 					RepoTransfer: mockRepoTransfer,
 				})
 			)
 
+			cs.PrepareMockValidator(mockValidator)
 			cs.PrepareMockRepoTransfer(mockRepoTransfer)
 
 			data, err := app.List(ctx, cs.InputData)
@@ -81,6 +151,15 @@ func TestCreate(t *testing.T) {
 			TargetAccountID: "target_account_id",
 			Amount:          500,
 		}
+		transferData = model.Transfer{
+			OriginAccountID: createData.OriginAccountID,
+			TargetAccountID: createData.TargetAccountID,
+			Amount:          createData.Amount,
+			SourceCurrency:  "BRL",
+			TargetAmount:    createData.Amount,
+			TargetCurrency:  "BRL",
+			ExchangeRate:    "1",
+		}
 		genTransferData = model.GeneratedData{
 			ID:        "transfer_id",
 			CreatedAt: currentTime,
@@ -90,30 +169,66 @@ func TestCreate(t *testing.T) {
 			Name:     "Test Account",
 			Document: "12312312312",
 			Balance:  1000,
+			Currency: "BRL",
+			Status:   model.AccountStatusActive,
 		}
 		accountTarget = model.Account{
 			ID:       createData.TargetAccountID,
 			Name:     "Test Account",
 			Document: "12312312312",
 			Balance:  1000,
+			Currency: "BRL",
+			Status:   model.AccountStatusActive,
 		}
 		createdTransfer = model.Transfer{
 			ID:              genTransferData.ID,
 			OriginAccountID: createData.OriginAccountID,
 			TargetAccountID: createData.TargetAccountID,
 			Amount:          createData.Amount,
+			SourceCurrency:  transferData.SourceCurrency,
+			TargetAmount:    transferData.TargetAmount,
+			TargetCurrency:  transferData.TargetCurrency,
+			ExchangeRate:    transferData.ExchangeRate,
 			CreatedAt:       genTransferData.CreatedAt,
 		}
-		validationError = validator.ValidationError{}
+		ledgerEntries = []model.LedgerEntry{
+			{
+				AccountID:  accountOrigin.ID,
+				TransferID: &genTransferData.ID,
+				Type:       model.LedgerEntryTypeDebit,
+				Amount:     createData.Amount,
+			},
+			{
+				AccountID:  accountTarget.ID,
+				TransferID: &genTransferData.ID,
+				Type:       model.LedgerEntryTypeCredit,
+				Amount:     createData.Amount,
+			},
+		}
+		feesExample, _ = fee.NewEngine(fee.Config{
+			Currency: "BRL",
+			Rules:    []fee.Rule{{Name: "pix", Type: fee.RuleTypeFlat, Amount: 50}},
+		})
+		dayStart, monthStart = model.LimitPeriods(currentTime)
+		validationError      = validator.ValidationError{}
+		executeWith          = func(tx transaction.Transaction) func(context.Context, func(transaction.Transaction) error) error {
+			return func(_ context.Context, fn func(transaction.Transaction) error) error {
+				return fn(tx)
+			}
+		}
 	)
 	cases := map[string]struct {
 		InputData               model.Transfer
+		InputFees               fee.Engine
+		InputRecord             RecordFunc
 		ExpectedData            *model.Transfer
 		ExpectedError           error
 		PrepareMockValidator    func(mock *validator.MockValidator)
 		PrepareMockTxManager    func(mock *transaction.MockManager, tx transaction.Transaction)
 		PrepareMockRepoAccount  func(mock *account.MockRepository, tx transaction.Transaction)
+		PrepareMockRepoLimit    func(mock *limit.MockRepository, tx transaction.Transaction)
 		PrepareMockRepoTransfer func(mock *transfer.MockRepository, tx transaction.Transaction)
+		PrepareMockRepoLedger   func(mock *ledger.MockRepository, tx transaction.Transaction)
 	}{
 		"should return success": {
 			InputData:     createData,
@@ -123,29 +238,307 @@ func TestCreate(t *testing.T) {
 				mock.EXPECT().Validate(createData).Return(nil)
 			},
 			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {
-				mock.EXPECT().Create(gomock.Any()).Return(tx, nil)
-				mock.EXPECT().Commit(tx)
+				mock.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(executeWith(tx))
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.OriginAccountID).Return(&accountOrigin, nil)
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.TargetAccountID).Return(&accountTarget, nil)
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				gomock.InOrder(
+					mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountOrigin.ID).Return(&accountOrigin, nil),
+					mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountTarget.ID).Return(&accountTarget, nil),
+					mock.EXPECT().Debit(gomock.Any(), accountOrigin.ID, createData.Amount).Return(true, nil),
+					mock.EXPECT().Credit(gomock.Any(), accountTarget.ID, createData.Amount).Return(nil),
+				)
+			},
+			PrepareMockRepoLimit: func(mock *limit.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().Get(gomock.Any(), accountOrigin.ID).Return(nil, nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetUsage(gomock.Any(), accountOrigin.ID, dayStart, monthStart).Return(&model.TransferUsage{}, nil)
+				mock.EXPECT().Create(gomock.Any(), transferData).Return(&genTransferData, nil)
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().Create(gomock.Any(), ledgerEntries).Return(nil)
+			},
+		},
+		"should return success: recording the transfer": {
+			InputData: createData,
+			InputRecord: func(tx transaction.Transaction, transfer model.Transfer) error {
+				if !reflect.DeepEqual(transfer, createdTransfer) {
+					return errors.New("unexpected transfer")
+				}
+				return nil
+			},
+			ExpectedData:  &createdTransfer,
+			ExpectedError: nil,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(createData).Return(nil)
+			},
+			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {
+				mock.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(executeWith(tx))
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.OriginAccountID).Return(&accountOrigin, nil)
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.TargetAccountID).Return(&accountTarget, nil)
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				gomock.InOrder(
+					mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountOrigin.ID).Return(&accountOrigin, nil),
+					mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountTarget.ID).Return(&accountTarget, nil),
+					mock.EXPECT().Debit(gomock.Any(), accountOrigin.ID, createData.Amount).Return(true, nil),
+					mock.EXPECT().Credit(gomock.Any(), accountTarget.ID, createData.Amount).Return(nil),
+				)
+			},
+			PrepareMockRepoLimit: func(mock *limit.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().Get(gomock.Any(), accountOrigin.ID).Return(nil, nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetUsage(gomock.Any(), accountOrigin.ID, dayStart, monthStart).Return(&model.TransferUsage{}, nil)
+				mock.EXPECT().Create(gomock.Any(), transferData).Return(&genTransferData, nil)
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().Create(gomock.Any(), ledgerEntries).Return(nil)
+			},
+		},
+		"should return error: can't record the transfer": {
+			InputData: createData,
+			InputRecord: func(tx transaction.Transaction, transfer model.Transfer) error {
+				return errors.New("fail")
+			},
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantCreateTransfer,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(createData).Return(nil)
+			},
+			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {
+				mock.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(executeWith(tx))
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.OriginAccountID).Return(&accountOrigin, nil)
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.TargetAccountID).Return(&accountTarget, nil)
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				gomock.InOrder(
+					mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountOrigin.ID).Return(&accountOrigin, nil),
+					mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountTarget.ID).Return(&accountTarget, nil),
+					mock.EXPECT().Debit(gomock.Any(), accountOrigin.ID, createData.Amount).Return(true, nil),
+					mock.EXPECT().Credit(gomock.Any(), accountTarget.ID, createData.Amount).Return(nil),
+				)
+			},
+			PrepareMockRepoLimit: func(mock *limit.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().Get(gomock.Any(), accountOrigin.ID).Return(nil, nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetUsage(gomock.Any(), accountOrigin.ID, dayStart, monthStart).Return(&model.TransferUsage{}, nil)
+				mock.EXPECT().Create(gomock.Any(), transferData).Return(&genTransferData, nil)
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().Create(gomock.Any(), ledgerEntries).Return(nil)
+			},
+		},
+		"should return success: charging fees": {
+			InputData: createData,
+			InputFees: feesExample,
+			ExpectedData: func() *model.Transfer {
+				data := createdTransfer
+				data.Fee = 50
+				data.Fees = []model.TransferFee{{Rule: "pix", Amount: 50}}
+				return &data
+			}(),
+			ExpectedError: nil,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(createData).Return(nil)
+			},
+			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {
+				mock.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(executeWith(tx))
 			},
 			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
 				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.OriginAccountID).Return(&accountOrigin, nil)
 				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.TargetAccountID).Return(&accountTarget, nil)
 				mock.EXPECT().WithTransaction(tx).Return(mock)
+				gomock.InOrder(
+					mock.EXPECT().GetByIDForUpdate(gomock.Any(), "fee_account_id").Return(&model.Account{ID: "fee_account_id"}, nil),
+					mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountOrigin.ID).Return(&accountOrigin, nil),
+					mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountTarget.ID).Return(&accountTarget, nil),
+				)
+				mock.EXPECT().Debit(gomock.Any(), accountOrigin.ID, int64(550)).Return(true, nil)
+				mock.EXPECT().Credit(gomock.Any(), accountTarget.ID, createData.Amount).Return(nil)
+				mock.EXPECT().Credit(gomock.Any(), "fee_account_id", int64(50)).Return(nil)
+			},
+			PrepareMockRepoLimit: func(mock *limit.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().Get(gomock.Any(), accountOrigin.ID).Return(nil, nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository, tx transaction.Transaction) {
+				data := transferData
+				data.Fee = 50
+				data.Fees = []model.TransferFee{{Rule: "pix", Amount: 50}}
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetUsage(gomock.Any(), accountOrigin.ID, dayStart, monthStart).Return(&model.TransferUsage{}, nil)
+				mock.EXPECT().CountSent(gomock.Any(), accountOrigin.ID, monthStart).Return(3, nil)
+				mock.EXPECT().Create(gomock.Any(), data).Return(&genTransferData, nil)
 				mock.EXPECT().
-					UpdateBalance(
-						gomock.Any(),
-						accountOrigin.ID,
-						accountOrigin.Balance-createData.Amount).
+					CreateFees(gomock.Any(), []model.TransferFee{{TransferID: genTransferData.ID, Rule: "pix", Amount: 50}}).
 					Return(nil)
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+				entries := append(ledgerEntries,
+					model.LedgerEntry{
+						AccountID:  accountOrigin.ID,
+						TransferID: &genTransferData.ID,
+						Type:       model.LedgerEntryTypeDebit,
+						Amount:     50,
+					},
+					model.LedgerEntry{
+						AccountID:  "fee_account_id",
+						TransferID: &genTransferData.ID,
+						Type:       model.LedgerEntryTypeCredit,
+						Amount:     50,
+					},
+				)
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().Create(gomock.Any(), entries).Return(nil)
+			},
+		},
+		"should return error: insufficient funds for the fees": {
+			InputData:     model.Transfer{OriginAccountID: createData.OriginAccountID, TargetAccountID: createData.TargetAccountID, Amount: 1000},
+			InputFees:     feesExample,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrInsufficientFunds,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(gomock.Any()).Return(nil)
+			},
+			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {
+				mock.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(executeWith(tx))
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.OriginAccountID).Return(&accountOrigin, nil)
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.TargetAccountID).Return(&accountTarget, nil)
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				gomock.InOrder(
+					mock.EXPECT().GetByIDForUpdate(gomock.Any(), "fee_account_id").Return(&model.Account{ID: "fee_account_id"}, nil),
+					mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountOrigin.ID).Return(&accountOrigin, nil),
+					mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountTarget.ID).Return(&accountTarget, nil),
+				)
+			},
+			PrepareMockRepoLimit: func(mock *limit.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().Get(gomock.Any(), accountOrigin.ID).Return(nil, nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetUsage(gomock.Any(), accountOrigin.ID, dayStart, monthStart).Return(&model.TransferUsage{}, nil)
+				mock.EXPECT().CountSent(gomock.Any(), accountOrigin.ID, monthStart).Return(0, nil)
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+			},
+		},
+		"should return error: transfer limit exceeded": {
+			InputData:     createData,
+			ExpectedData:  nil,
+			ExpectedError: &pkgerror.TransferLimitError{Limit: model.LimitDaily, Remaining: 300},
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(createData).Return(nil)
+			},
+			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {
+				mock.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(executeWith(tx))
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.OriginAccountID).Return(&accountOrigin, nil)
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.TargetAccountID).Return(&accountTarget, nil)
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountOrigin.ID).Return(&accountOrigin, nil)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountTarget.ID).Return(&accountTarget, nil)
+			},
+			PrepareMockRepoLimit: func(mock *limit.MockRepository, tx transaction.Transaction) {
+				daily := int64(1000)
+				mock.EXPECT().WithTransaction(tx).Return(mock)
 				mock.EXPECT().
-					UpdateBalance(
-						gomock.Any(),
-						accountTarget.ID,
-						accountOrigin.Balance+createData.Amount).
-					Return(nil)
+					Get(gomock.Any(), accountOrigin.ID).
+					Return(&model.AccountLimits{AccountID: accountOrigin.ID, Tier: model.AccountTierStandard, Daily: &daily}, nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().
+					GetUsage(gomock.Any(), accountOrigin.ID, dayStart, monthStart).
+					Return(&model.TransferUsage{Daily: 700, Monthly: 700, DailyCount: 1}, nil)
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+			},
+		},
+		"should return error: can't get limits": {
+			InputData:     createData,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantCreateTransfer,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(createData).Return(nil)
+			},
+			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {
+				mock.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(executeWith(tx))
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.OriginAccountID).Return(&accountOrigin, nil)
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.TargetAccountID).Return(&accountTarget, nil)
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountOrigin.ID).Return(&accountOrigin, nil)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountTarget.ID).Return(&accountTarget, nil)
+			},
+			PrepareMockRepoLimit: func(mock *limit.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().Get(gomock.Any(), accountOrigin.ID).Return(nil, errors.New("fail"))
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+			},
+		},
+		"should return success: target informed by document": {
+			InputData: model.Transfer{
+				OriginAccountID: createData.OriginAccountID,
+				TargetAccountID: accountTarget.Document,
+				Amount:          createData.Amount,
+			},
+			ExpectedData:  &createdTransfer,
+			ExpectedError: nil,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(gomock.Any()).Return(nil)
+			},
+			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {
+				mock.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(executeWith(tx))
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.OriginAccountID).Return(&accountOrigin, nil)
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), accountTarget.Document).Return(&accountTarget, nil)
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountOrigin.ID).Return(&accountOrigin, nil)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountTarget.ID).Return(&accountTarget, nil)
+				mock.EXPECT().Debit(gomock.Any(), accountOrigin.ID, createData.Amount).Return(true, nil)
+				mock.EXPECT().Credit(gomock.Any(), accountTarget.ID, createData.Amount).Return(nil)
+			},
+			PrepareMockRepoLimit: func(mock *limit.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().Get(gomock.Any(), accountOrigin.ID).Return(nil, nil)
 			},
 			PrepareMockRepoTransfer: func(mock *transfer.MockRepository, tx transaction.Transaction) {
 				mock.EXPECT().WithTransaction(tx).Return(mock)
-				mock.EXPECT().Create(gomock.Any(), createData).Return(&genTransferData, nil)
+				mock.EXPECT().GetUsage(gomock.Any(), accountOrigin.ID, dayStart, monthStart).Return(&model.TransferUsage{}, nil)
+				mock.EXPECT().Create(gomock.Any(), transferData).Return(&genTransferData, nil)
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().Create(gomock.Any(), ledgerEntries).Return(nil)
 			},
 		},
 		"should return error: validation": {
@@ -159,8 +552,12 @@ func TestCreate(t *testing.T) {
 			},
 			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
 			},
+			PrepareMockRepoLimit: func(mock *limit.MockRepository, tx transaction.Transaction) {
+			},
 			PrepareMockRepoTransfer: func(mock *transfer.MockRepository, tx transaction.Transaction) {
 			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+			},
 		},
 		"should return error: can't get origin account": {
 			InputData:     createData,
@@ -174,8 +571,12 @@ func TestCreate(t *testing.T) {
 			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
 				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.OriginAccountID).Return(nil, errors.New("fail"))
 			},
+			PrepareMockRepoLimit: func(mock *limit.MockRepository, tx transaction.Transaction) {
+			},
 			PrepareMockRepoTransfer: func(mock *transfer.MockRepository, tx transaction.Transaction) {
 			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+			},
 		},
 		"should return error: origin account not exists": {
 			InputData:     createData,
@@ -189,8 +590,12 @@ func TestCreate(t *testing.T) {
 			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
 				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.OriginAccountID).Return(nil, nil)
 			},
+			PrepareMockRepoLimit: func(mock *limit.MockRepository, tx transaction.Transaction) {
+			},
 			PrepareMockRepoTransfer: func(mock *transfer.MockRepository, tx transaction.Transaction) {
 			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+			},
 		},
 		"should return error: can't get target account": {
 			InputData:     createData,
@@ -205,8 +610,12 @@ func TestCreate(t *testing.T) {
 				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.OriginAccountID).Return(&accountOrigin, nil)
 				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.TargetAccountID).Return(nil, errors.New("fail"))
 			},
+			PrepareMockRepoLimit: func(mock *limit.MockRepository, tx transaction.Transaction) {
+			},
 			PrepareMockRepoTransfer: func(mock *transfer.MockRepository, tx transaction.Transaction) {
 			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+			},
 		},
 		"should return error: origin target not exists": {
 			InputData:     createData,
@@ -221,27 +630,35 @@ func TestCreate(t *testing.T) {
 				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.OriginAccountID).Return(&accountOrigin, nil)
 				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.TargetAccountID).Return(nil, nil)
 			},
+			PrepareMockRepoLimit: func(mock *limit.MockRepository, tx transaction.Transaction) {
+			},
 			PrepareMockRepoTransfer: func(mock *transfer.MockRepository, tx transaction.Transaction) {
 			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+			},
 		},
-		"should return error: insufficient funds": {
+		"should return error: execute transaction": {
 			InputData:     createData,
 			ExpectedData:  nil,
-			ExpectedError: pkgerror.ErrInsufficientFunds,
+			ExpectedError: pkgerror.ErrCantCreateTransfer,
 			PrepareMockValidator: func(mock *validator.MockValidator) {
 				mock.EXPECT().Validate(createData).Return(nil)
 			},
 			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {
+				mock.EXPECT().Execute(gomock.Any(), gomock.Any()).Return(errors.New("fail"))
 			},
 			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
-				accountOrigin := accountOrigin
-				accountOrigin.Balance = 0
 				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.OriginAccountID).Return(&accountOrigin, nil)
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.TargetAccountID).Return(&accountTarget, nil)
+			},
+			PrepareMockRepoLimit: func(mock *limit.MockRepository, tx transaction.Transaction) {
 			},
 			PrepareMockRepoTransfer: func(mock *transfer.MockRepository, tx transaction.Transaction) {
 			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+			},
 		},
-		"should return error: create transaction": {
+		"should return error: can't lock account": {
 			InputData:     createData,
 			ExpectedData:  nil,
 			ExpectedError: pkgerror.ErrCantCreateTransfer,
@@ -249,177 +666,2556 @@ func TestCreate(t *testing.T) {
 				mock.EXPECT().Validate(createData).Return(nil)
 			},
 			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {
-				mock.EXPECT().Create(gomock.Any()).Return(nil, errors.New("fail"))
+				mock.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(executeWith(tx))
 			},
 			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
 				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.OriginAccountID).Return(&accountOrigin, nil)
 				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.TargetAccountID).Return(&accountTarget, nil)
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountOrigin.ID).Return(nil, errors.New("fail"))
+			},
+			PrepareMockRepoLimit: func(mock *limit.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
 			},
 			PrepareMockRepoTransfer: func(mock *transfer.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
 			},
 		},
-		"should return error: can't create transfer": {
+		"should return error: insufficient funds": {
 			InputData:     createData,
 			ExpectedData:  nil,
-			ExpectedError: pkgerror.ErrCantCreateTransfer,
+			ExpectedError: pkgerror.ErrInsufficientFunds,
 			PrepareMockValidator: func(mock *validator.MockValidator) {
 				mock.EXPECT().Validate(createData).Return(nil)
 			},
 			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {
-				mock.EXPECT().Create(gomock.Any()).Return(tx, nil)
-				mock.EXPECT().Rollback(tx)
+				mock.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(executeWith(tx))
 			},
 			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
+				lockedOrigin := accountOrigin
+				lockedOrigin.Balance = 0
 				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.OriginAccountID).Return(&accountOrigin, nil)
 				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.TargetAccountID).Return(&accountTarget, nil)
 				mock.EXPECT().WithTransaction(tx).Return(mock)
-				mock.EXPECT().
-					UpdateBalance(
-						gomock.Any(),
-						accountOrigin.ID,
-						accountOrigin.Balance-createData.Amount).
-					Return(nil)
-				mock.EXPECT().
-					UpdateBalance(
-						gomock.Any(),
-						accountTarget.ID,
-						accountOrigin.Balance+createData.Amount).
-					Return(nil)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountOrigin.ID).Return(&lockedOrigin, nil)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountTarget.ID).Return(&accountTarget, nil)
+			},
+			PrepareMockRepoLimit: func(mock *limit.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().Get(gomock.Any(), accountOrigin.ID).Return(nil, nil)
 			},
 			PrepareMockRepoTransfer: func(mock *transfer.MockRepository, tx transaction.Transaction) {
 				mock.EXPECT().WithTransaction(tx).Return(mock)
-				mock.EXPECT().Create(gomock.Any(), createData).Return(nil, errors.New("fail"))
+				mock.EXPECT().GetUsage(gomock.Any(), accountOrigin.ID, dayStart, monthStart).Return(&model.TransferUsage{}, nil)
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
 			},
 		},
-		"should return error: can't update origin account balance": {
+		"should return error: funds reserved for reviews": {
 			InputData:     createData,
 			ExpectedData:  nil,
-			ExpectedError: pkgerror.ErrCantCreateTransfer,
+			ExpectedError: pkgerror.ErrInsufficientFunds,
 			PrepareMockValidator: func(mock *validator.MockValidator) {
 				mock.EXPECT().Validate(createData).Return(nil)
 			},
 			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {
-				mock.EXPECT().Create(gomock.Any()).Return(tx, nil)
-				mock.EXPECT().Rollback(tx)
+				mock.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(executeWith(tx))
 			},
 			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
+				lockedOrigin := accountOrigin
+				lockedOrigin.Reserved = 600
 				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.OriginAccountID).Return(&accountOrigin, nil)
 				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.TargetAccountID).Return(&accountTarget, nil)
 				mock.EXPECT().WithTransaction(tx).Return(mock)
-				mock.EXPECT().
-					UpdateBalance(
-						gomock.Any(),
-						accountOrigin.ID,
-						accountOrigin.Balance-createData.Amount).
-					Return(errors.New("fail"))
-				mock.EXPECT().
-					UpdateBalance(
-						gomock.Any(),
-						accountTarget.ID,
-						accountOrigin.Balance+createData.Amount).
-					Return(nil)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountOrigin.ID).Return(&lockedOrigin, nil)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountTarget.ID).Return(&accountTarget, nil)
+			},
+			PrepareMockRepoLimit: func(mock *limit.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().Get(gomock.Any(), accountOrigin.ID).Return(nil, nil)
 			},
 			PrepareMockRepoTransfer: func(mock *transfer.MockRepository, tx transaction.Transaction) {
 				mock.EXPECT().WithTransaction(tx).Return(mock)
-				mock.EXPECT().Create(gomock.Any(), createData).Return(&genTransferData, nil)
+				mock.EXPECT().GetUsage(gomock.Any(), accountOrigin.ID, dayStart, monthStart).Return(&model.TransferUsage{}, nil)
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
 			},
 		},
-		"should return error: can't update target account balance": {
+		"should return error: origin account frozen": {
 			InputData:     createData,
 			ExpectedData:  nil,
-			ExpectedError: pkgerror.ErrCantCreateTransfer,
+			ExpectedError: pkgerror.ErrOriginAccountNotActive,
 			PrepareMockValidator: func(mock *validator.MockValidator) {
 				mock.EXPECT().Validate(createData).Return(nil)
 			},
 			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {
-				mock.EXPECT().Create(gomock.Any()).Return(tx, nil)
-				mock.EXPECT().Rollback(tx)
+				mock.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(executeWith(tx))
 			},
 			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
+				lockedOrigin := accountOrigin
+				lockedOrigin.Status = model.AccountStatusFrozen
 				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.OriginAccountID).Return(&accountOrigin, nil)
 				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.TargetAccountID).Return(&accountTarget, nil)
 				mock.EXPECT().WithTransaction(tx).Return(mock)
-				mock.EXPECT().
-					UpdateBalance(
-						gomock.Any(),
-						accountOrigin.ID,
-						accountOrigin.Balance-createData.Amount).
-					Return(nil)
-				mock.EXPECT().
-					UpdateBalance(
-						gomock.Any(),
-						accountTarget.ID,
-						accountOrigin.Balance+createData.Amount).
-					Return(errors.New("fail"))
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountOrigin.ID).Return(&lockedOrigin, nil)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountTarget.ID).Return(&accountTarget, nil)
+			},
+			PrepareMockRepoLimit: func(mock *limit.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
 			},
 			PrepareMockRepoTransfer: func(mock *transfer.MockRepository, tx transaction.Transaction) {
 				mock.EXPECT().WithTransaction(tx).Return(mock)
-				mock.EXPECT().Create(gomock.Any(), createData).Return(&genTransferData, nil)
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
 			},
 		},
-		"should return error: can't commit transaction": {
+		"should return error: target account closed": {
 			InputData:     createData,
 			ExpectedData:  nil,
-			ExpectedError: pkgerror.ErrCantCreateTransfer,
+			ExpectedError: pkgerror.ErrTargetAccountNotActive,
 			PrepareMockValidator: func(mock *validator.MockValidator) {
 				mock.EXPECT().Validate(createData).Return(nil)
 			},
 			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {
-				mock.EXPECT().Create(gomock.Any()).Return(tx, nil)
-				mock.EXPECT().Commit(tx).Return(errors.New("fail"))
-				mock.EXPECT().Rollback(tx).Return(errors.New("fail"))
+				mock.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(executeWith(tx))
 			},
 			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
+				lockedTarget := accountTarget
+				lockedTarget.Status = model.AccountStatusClosed
 				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.OriginAccountID).Return(&accountOrigin, nil)
 				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.TargetAccountID).Return(&accountTarget, nil)
 				mock.EXPECT().WithTransaction(tx).Return(mock)
-				mock.EXPECT().
-					UpdateBalance(
-						gomock.Any(),
-						accountOrigin.ID,
-						accountOrigin.Balance-createData.Amount).
-					Return(nil)
-				mock.EXPECT().
-					UpdateBalance(
-						gomock.Any(),
-						accountTarget.ID,
-						accountOrigin.Balance+createData.Amount).
-					Return(nil)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountOrigin.ID).Return(&accountOrigin, nil)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountTarget.ID).Return(&lockedTarget, nil)
+			},
+			PrepareMockRepoLimit: func(mock *limit.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
 			},
 			PrepareMockRepoTransfer: func(mock *transfer.MockRepository, tx transaction.Transaction) {
 				mock.EXPECT().WithTransaction(tx).Return(mock)
-				mock.EXPECT().Create(gomock.Any(), createData).Return(&genTransferData, nil)
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
 			},
 		},
-	}
-
-	for name, cs := range cases {
-		t.Run(name, func(t *testing.T) {
-			var (
-				ctrl, ctx        = gomock.WithContext(context.Background(), t)
-				txExample        = transaction.Transaction(nil)
-				mockValidator    = validator.NewMockValidator(ctrl)
-				mockTxManager    = transaction.NewMockManager(ctrl)
+		"should return error: can't create transfer": {
+			InputData:     createData,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantCreateTransfer,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(createData).Return(nil)
+			},
+			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {
+				mock.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(executeWith(tx))
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.OriginAccountID).Return(&accountOrigin, nil)
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.TargetAccountID).Return(&accountTarget, nil)
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountOrigin.ID).Return(&accountOrigin, nil)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountTarget.ID).Return(&accountTarget, nil)
+				mock.EXPECT().Debit(gomock.Any(), accountOrigin.ID, createData.Amount).Return(true, nil)
+				mock.EXPECT().Credit(gomock.Any(), accountTarget.ID, createData.Amount).Return(nil)
+			},
+			PrepareMockRepoLimit: func(mock *limit.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().Get(gomock.Any(), accountOrigin.ID).Return(nil, nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetUsage(gomock.Any(), accountOrigin.ID, dayStart, monthStart).Return(&model.TransferUsage{}, nil)
+				mock.EXPECT().Create(gomock.Any(), transferData).Return(nil, errors.New("fail"))
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+			},
+		},
+		"should return error: can't debit origin account": {
+			InputData:     createData,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantCreateTransfer,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(createData).Return(nil)
+			},
+			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {
+				mock.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(executeWith(tx))
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.OriginAccountID).Return(&accountOrigin, nil)
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.TargetAccountID).Return(&accountTarget, nil)
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountOrigin.ID).Return(&accountOrigin, nil)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountTarget.ID).Return(&accountTarget, nil)
+				mock.EXPECT().Debit(gomock.Any(), accountOrigin.ID, createData.Amount).Return(false, errors.New("fail"))
+			},
+			PrepareMockRepoLimit: func(mock *limit.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().Get(gomock.Any(), accountOrigin.ID).Return(nil, nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetUsage(gomock.Any(), accountOrigin.ID, dayStart, monthStart).Return(&model.TransferUsage{}, nil)
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+			},
+		},
+		"should return error: insufficient funds on debit": {
+			InputData:     createData,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrInsufficientFunds,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(createData).Return(nil)
+			},
+			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {
+				mock.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(executeWith(tx))
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.OriginAccountID).Return(&accountOrigin, nil)
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.TargetAccountID).Return(&accountTarget, nil)
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountOrigin.ID).Return(&accountOrigin, nil)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountTarget.ID).Return(&accountTarget, nil)
+				mock.EXPECT().Debit(gomock.Any(), accountOrigin.ID, createData.Amount).Return(false, nil)
+			},
+			PrepareMockRepoLimit: func(mock *limit.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().Get(gomock.Any(), accountOrigin.ID).Return(nil, nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetUsage(gomock.Any(), accountOrigin.ID, dayStart, monthStart).Return(&model.TransferUsage{}, nil)
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+			},
+		},
+		"should return error: can't credit target account": {
+			InputData:     createData,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantCreateTransfer,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(createData).Return(nil)
+			},
+			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {
+				mock.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(executeWith(tx))
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.OriginAccountID).Return(&accountOrigin, nil)
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.TargetAccountID).Return(&accountTarget, nil)
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountOrigin.ID).Return(&accountOrigin, nil)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountTarget.ID).Return(&accountTarget, nil)
+				mock.EXPECT().Debit(gomock.Any(), accountOrigin.ID, createData.Amount).Return(true, nil)
+				mock.EXPECT().Credit(gomock.Any(), accountTarget.ID, createData.Amount).Return(errors.New("fail"))
+			},
+			PrepareMockRepoLimit: func(mock *limit.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().Get(gomock.Any(), accountOrigin.ID).Return(nil, nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetUsage(gomock.Any(), accountOrigin.ID, dayStart, monthStart).Return(&model.TransferUsage{}, nil)
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+			},
+		},
+		"should return error: can't create ledger entries": {
+			InputData:     createData,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantCreateTransfer,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(createData).Return(nil)
+			},
+			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {
+				mock.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(executeWith(tx))
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.OriginAccountID).Return(&accountOrigin, nil)
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), createData.TargetAccountID).Return(&accountTarget, nil)
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountOrigin.ID).Return(&accountOrigin, nil)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountTarget.ID).Return(&accountTarget, nil)
+				mock.EXPECT().Debit(gomock.Any(), accountOrigin.ID, createData.Amount).Return(true, nil)
+				mock.EXPECT().Credit(gomock.Any(), accountTarget.ID, createData.Amount).Return(nil)
+			},
+			PrepareMockRepoLimit: func(mock *limit.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().Get(gomock.Any(), accountOrigin.ID).Return(nil, nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetUsage(gomock.Any(), accountOrigin.ID, dayStart, monthStart).Return(&model.TransferUsage{}, nil)
+				mock.EXPECT().Create(gomock.Any(), transferData).Return(&genTransferData, nil)
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().Create(gomock.Any(), ledgerEntries).Return(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx        = gomock.WithContext(context.Background(), t)
+				txExample        = transaction.Transaction(nil)
+				mockValidator    = validator.NewMockValidator(ctrl)
+				mockTxManager    = transaction.NewMockManager(ctrl)
 				mockRepoAccount  = account.NewMockRepository(ctrl)
 				mockRepoTransfer = transfer.NewMockRepository(ctrl)
+				mockRepoLedger   = ledger.NewMockRepository(ctrl)
+				mockRepoLimit    = limit.NewMockRepository(ctrl)
+				mockRepoReview   = review.NewMockRepository(ctrl)
+				mockRepoHold     = hold.NewMockRepository(ctrl)
+				mockGenerate     = generate.NewMockGenerate(ctrl)
 				app              = NewApp(Options{
 					Logger:       logger.New(""),
 					Validator:    mockValidator,
+					Generate:     mockGenerate,
 					TxManager:    mockTxManager,
 					RepoAccount:  mockRepoAccount,
+					RepoHold:     mockRepoHold,
+					RepoLedger:   mockRepoLedger,
+					RepoLimit:    mockRepoLimit,
+					RepoReview:   mockRepoReview,
 					RepoTransfer: mockRepoTransfer,
+					Fees:         cs.InputFees,
+					FeeAccountID: "fee_account_id",
 				})
 			)
 
+			mockGenerate.EXPECT().CurrentTime().Return(currentTime).AnyTimes()
+			mockRepoReview.EXPECT().WithTransaction(txExample).Return(mockRepoReview).AnyTimes()
+			mockRepoHold.EXPECT().WithTransaction(txExample).Return(mockRepoHold).AnyTimes()
 			cs.PrepareMockValidator(mockValidator)
 			cs.PrepareMockTxManager(mockTxManager, txExample)
 			cs.PrepareMockRepoAccount(mockRepoAccount, txExample)
+			cs.PrepareMockRepoLimit(mockRepoLimit, txExample)
 			cs.PrepareMockRepoTransfer(mockRepoTransfer, txExample)
+			cs.PrepareMockRepoLedger(mockRepoLedger, txExample)
+
+			var (
+				data *model.Transfer
+				err  error
+			)
+			if cs.InputRecord != nil {
+				data, err = app.CreateWith(ctx, cs.InputData, cs.InputRecord)
+			} else {
+				data, err = app.Create(ctx, cs.InputData)
+			}
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestQuote(t *testing.T) {
+	var (
+		currentTime = time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC)
+		monthStart  = time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+		quoteData   = model.Transfer{
+			OriginAccountID: "origin_account_id",
+			TargetAccountID: "12312312312",
+			Amount:          500,
+		}
+		accountOrigin  = model.Account{ID: "origin_account_id", Currency: "BRL"}
+		accountTarget  = model.Account{ID: "target_account_id", Currency: "BRL"}
+		feesExample, _ = fee.NewEngine(fee.Config{
+			Currency: "BRL",
+			Rules: []fee.Rule{
+				{Name: "pix", Type: fee.RuleTypeFlat, Amount: 50, FreePerMonth: 2},
+				{Name: "service", Type: fee.RuleTypePercentage, BasisPoints: 100},
+			},
+		})
+		validationError = validator.ValidationError{}
+	)
+	cases := map[string]struct {
+		ExpectedData            *model.Transfer
+		ExpectedError           error
+		PrepareMockValidator    func(mock *validator.MockValidator)
+		PrepareMockRepoAccount  func(mock *account.MockRepository)
+		PrepareMockRepoTransfer func(mock *transfer.MockRepository)
+	}{
+		"should return success": {
+			ExpectedData: &model.Transfer{
+				OriginAccountID: accountOrigin.ID,
+				TargetAccountID: accountTarget.ID,
+				Amount:          500,
+				SourceCurrency:  "BRL",
+				TargetAmount:    500,
+				TargetCurrency:  "BRL",
+				ExchangeRate:    "1",
+				Fee:             55,
+				Fees:            []model.TransferFee{{Rule: "pix", Amount: 50}, {Rule: "service", Amount: 5}},
+			},
+			ExpectedError: nil,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(quoteData).Return(nil)
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), quoteData.OriginAccountID).Return(&accountOrigin, nil)
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), quoteData.TargetAccountID).Return(&accountTarget, nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {
+				mock.EXPECT().CountSent(gomock.Any(), accountOrigin.ID, monthStart).Return(2, nil)
+			},
+		},
+		"should return error: validation": {
+			ExpectedData:  nil,
+			ExpectedError: &validationError,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(quoteData).Return(&validationError)
+			},
+			PrepareMockRepoAccount:  func(mock *account.MockRepository) {},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {},
+		},
+		"should return error on count sent": {
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantCreateTransfer,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(quoteData).Return(nil)
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), quoteData.OriginAccountID).Return(&accountOrigin, nil)
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), quoteData.TargetAccountID).Return(&accountTarget, nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {
+				mock.EXPECT().CountSent(gomock.Any(), accountOrigin.ID, monthStart).Return(0, errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx        = gomock.WithContext(context.Background(), t)
+				mockValidator    = validator.NewMockValidator(ctrl)
+				mockGenerate     = generate.NewMockGenerate(ctrl)
+				mockRepoAccount  = account.NewMockRepository(ctrl)
+				mockRepoTransfer = transfer.NewMockRepository(ctrl)
+				app              = NewApp(Options{
+					Logger:       logger.New(""),
+					Validator:    mockValidator,
+					Generate:     mockGenerate,
+					RepoAccount:  mockRepoAccount,
+					RepoTransfer: mockRepoTransfer,
+					Fees:         feesExample,
+					FeeAccountID: "fee_account_id",
+				})
+			)
+
+			mockGenerate.EXPECT().CurrentTime().Return(currentTime).AnyTimes()
+			cs.PrepareMockValidator(mockValidator)
+			cs.PrepareMockRepoAccount(mockRepoAccount)
+			cs.PrepareMockRepoTransfer(mockRepoTransfer)
 
-			data, err := app.Create(ctx, createData)
+			data, err := app.Quote(ctx, quoteData)
 
 			assert.Equal(t, cs.ExpectedData, data)
 			assert.Equal(t, cs.ExpectedError, err)
 		})
 	}
 }
+
+func TestAuthorize(t *testing.T) {
+	var (
+		currentTime = time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC)
+		sessionCtx  = model.SetSessionOnContext(context.Background(), &model.Session{
+			Account: model.Account{ID: "origin_account_id"},
+		})
+		authorizeData = model.Transfer{
+			OriginAccountID: "origin_account_id",
+			TargetAccountID: "12312312312",
+			Amount:          500,
+			TwoFactorCode:   "123456",
+		}
+		accountOrigin   = model.Account{ID: "origin_account_id", Currency: "BRL", Balance: 1000}
+		accountTarget   = model.Account{ID: "target_account_id", Currency: "BRL"}
+		validationError = validator.ValidationError{}
+	)
+	cases := map[string]struct {
+		ExpectedData            *model.Transfer
+		ExpectedError           error
+		PrepareMockValidator    func(mock *validator.MockValidator)
+		PrepareMockRepoAccount  func(mock *account.MockRepository)
+		PrepareMockTwoFactor    func(mock *twofactor.MockApp)
+		PrepareMockRepoTransfer func(mock *transfer.MockRepository)
+		PrepareMockRisk         func(mock *risk.MockEngine)
+	}{
+		"should return success": {
+			ExpectedData: &model.Transfer{
+				OriginAccountID: accountOrigin.ID,
+				TargetAccountID: accountTarget.ID,
+				Amount:          500,
+				SourceCurrency:  "BRL",
+				TargetAmount:    500,
+				TargetCurrency:  "BRL",
+				ExchangeRate:    "1",
+				TwoFactorCode:   "123456",
+			},
+			ExpectedError: nil,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(authorizeData).Return(nil)
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), authorizeData.OriginAccountID).Return(&accountOrigin, nil)
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), authorizeData.TargetAccountID).Return(&accountTarget, nil)
+			},
+			PrepareMockTwoFactor: func(mock *twofactor.MockApp) {
+				mock.EXPECT().Verify(gomock.Any(), accountOrigin.ID, "123456").Return(nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {
+				mock.EXPECT().ListSent(gomock.Any(), accountOrigin.ID, currentTime.Add(-riskHistoryPeriod)).Return(nil, nil)
+			},
+			PrepareMockRisk: func(mock *risk.MockEngine) {
+				mock.EXPECT().Assess(gomock.Any(), gomock.Any()).Return(risk.Assessment{Decision: risk.DecisionAllow}, nil)
+			},
+		},
+		"should return error: validation": {
+			ExpectedData:  nil,
+			ExpectedError: &validationError,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(authorizeData).Return(&validationError)
+			},
+			PrepareMockRepoAccount:  func(mock *account.MockRepository) {},
+			PrepareMockTwoFactor:    func(mock *twofactor.MockApp) {},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {},
+			PrepareMockRisk:         func(mock *risk.MockEngine) {},
+		},
+		"should return error: invalid two factor code": {
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrInvalidTwoFactorCode,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(authorizeData).Return(nil)
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), authorizeData.OriginAccountID).Return(&accountOrigin, nil)
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), authorizeData.TargetAccountID).Return(&accountTarget, nil)
+			},
+			PrepareMockTwoFactor: func(mock *twofactor.MockApp) {
+				mock.EXPECT().Verify(gomock.Any(), accountOrigin.ID, "123456").Return(pkgerror.ErrInvalidTwoFactorCode)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {},
+			PrepareMockRisk:         func(mock *risk.MockEngine) {},
+		},
+		"should return error: held by the risk check": {
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrTransferDenied,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(authorizeData).Return(nil)
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), authorizeData.OriginAccountID).Return(&accountOrigin, nil)
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), authorizeData.TargetAccountID).Return(&accountTarget, nil)
+			},
+			PrepareMockTwoFactor: func(mock *twofactor.MockApp) {
+				mock.EXPECT().Verify(gomock.Any(), accountOrigin.ID, "123456").Return(nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {
+				mock.EXPECT().ListSent(gomock.Any(), accountOrigin.ID, currentTime.Add(-riskHistoryPeriod)).Return(nil, nil)
+			},
+			PrepareMockRisk: func(mock *risk.MockEngine) {
+				mock.EXPECT().Assess(gomock.Any(), gomock.Any()).Return(risk.Assessment{Decision: risk.DecisionReview}, nil)
+			},
+		},
+		"should return error on list sent": {
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantCreateTransfer,
+			PrepareMockValidator: func(mock *validator.MockValidator) {
+				mock.EXPECT().Validate(authorizeData).Return(nil)
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), authorizeData.OriginAccountID).Return(&accountOrigin, nil)
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), authorizeData.TargetAccountID).Return(&accountTarget, nil)
+			},
+			PrepareMockTwoFactor: func(mock *twofactor.MockApp) {
+				mock.EXPECT().Verify(gomock.Any(), accountOrigin.ID, "123456").Return(nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {
+				mock.EXPECT().
+					ListSent(gomock.Any(), accountOrigin.ID, currentTime.Add(-riskHistoryPeriod)).
+					Return(nil, errors.New("fail"))
+			},
+			PrepareMockRisk: func(mock *risk.MockEngine) {},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl             = gomock.NewController(t)
+				mockValidator    = validator.NewMockValidator(ctrl)
+				mockGenerate     = generate.NewMockGenerate(ctrl)
+				mockRepoAccount  = account.NewMockRepository(ctrl)
+				mockRepoTransfer = transfer.NewMockRepository(ctrl)
+				mockTwoFactor    = twofactor.NewMockApp(ctrl)
+				mockRisk         = risk.NewMockEngine(ctrl)
+				app              = NewApp(Options{
+					Logger:          logger.New(""),
+					Validator:       mockValidator,
+					Generate:        mockGenerate,
+					RepoAccount:     mockRepoAccount,
+					RepoTransfer:    mockRepoTransfer,
+					TwoFactorApp:    mockTwoFactor,
+					StepUpThreshold: 100,
+					Risk:            mockRisk,
+				})
+			)
+
+			mockGenerate.EXPECT().CurrentTime().Return(currentTime).AnyTimes()
+			cs.PrepareMockValidator(mockValidator)
+			cs.PrepareMockRepoAccount(mockRepoAccount)
+			cs.PrepareMockTwoFactor(mockTwoFactor)
+			cs.PrepareMockRepoTransfer(mockRepoTransfer)
+			cs.PrepareMockRisk(mockRisk)
+
+			data, err := app.Authorize(sessionCtx, authorizeData)
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestReverse(t *testing.T) {
+	var (
+		currentTime      = time.Now()
+		originalTransfer = model.Transfer{
+			ID:              "original_transfer_id",
+			OriginAccountID: "origin_account_id",
+			TargetAccountID: "target_account_id",
+			Amount:          500,
+			SourceCurrency:  "BRL",
+			TargetAmount:    500,
+			TargetCurrency:  "BRL",
+			ExchangeRate:    "1",
+		}
+		genTransferData = model.GeneratedData{
+			ID:        "reversal_transfer_id",
+			CreatedAt: currentTime,
+		}
+		accountOrigin = model.Account{ID: originalTransfer.OriginAccountID, Balance: 1000, Status: model.AccountStatusActive}
+		accountTarget = model.Account{ID: originalTransfer.TargetAccountID, Balance: 1000, Status: model.AccountStatusActive}
+		reversal      = func(amount int64) model.Transfer {
+			return model.Transfer{
+				OriginAccountID:    originalTransfer.TargetAccountID,
+				TargetAccountID:    originalTransfer.OriginAccountID,
+				Amount:             amount,
+				SourceCurrency:     "BRL",
+				TargetAmount:       amount,
+				TargetCurrency:     "BRL",
+				ExchangeRate:       "1",
+				ReversedTransferID: &originalTransfer.ID,
+			}
+		}
+		reversed = func(amount int64) *model.Transfer {
+			data := reversal(amount)
+			data.ID = genTransferData.ID
+			data.CreatedAt = genTransferData.CreatedAt
+			return &data
+		}
+		executeWith = func(tx transaction.Transaction) func(context.Context, func(transaction.Transaction) error) error {
+			return func(_ context.Context, fn func(transaction.Transaction) error) error {
+				return fn(tx)
+			}
+		}
+	)
+	cases := map[string]struct {
+		InputAccountID          string
+		InputAmount             int64
+		ExpectedData            *model.Transfer
+		ExpectedError           error
+		PrepareMockRepoAccount  func(mock *account.MockRepository, tx transaction.Transaction)
+		PrepareMockRepoTransfer func(mock *transfer.MockRepository, tx transaction.Transaction)
+		PrepareMockRepoLedger   func(mock *ledger.MockRepository, tx transaction.Transaction)
+	}{
+		"should reverse remaining amount": {
+			InputAccountID: originalTransfer.TargetAccountID,
+			InputAmount:    0,
+			ExpectedData:   reversed(300),
+			ExpectedError:  nil,
+			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				gomock.InOrder(
+					mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountOrigin.ID).Return(&accountOrigin, nil),
+					mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountTarget.ID).Return(&accountTarget, nil),
+				)
+				mock.EXPECT().Debit(gomock.Any(), accountTarget.ID, int64(300)).Return(true, nil)
+				mock.EXPECT().Credit(gomock.Any(), accountOrigin.ID, int64(300)).Return(nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository, tx transaction.Transaction) {
+				original := originalTransfer
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), originalTransfer.ID).Return(&original, nil)
+				mock.EXPECT().GetReversedAmount(gomock.Any(), originalTransfer.ID).Return(int64(200), nil)
+				mock.EXPECT().Create(gomock.Any(), reversal(300)).Return(&genTransferData, nil)
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		"should reverse partial amount": {
+			InputAccountID: originalTransfer.TargetAccountID,
+			InputAmount:    100,
+			ExpectedData:   reversed(100),
+			ExpectedError:  nil,
+			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountOrigin.ID).Return(&accountOrigin, nil)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountTarget.ID).Return(&accountTarget, nil)
+				mock.EXPECT().Debit(gomock.Any(), accountTarget.ID, int64(100)).Return(true, nil)
+				mock.EXPECT().Credit(gomock.Any(), accountOrigin.ID, int64(100)).Return(nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository, tx transaction.Transaction) {
+				original := originalTransfer
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), originalTransfer.ID).Return(&original, nil)
+				mock.EXPECT().GetReversedAmount(gomock.Any(), originalTransfer.ID).Return(int64(0), nil)
+				mock.EXPECT().Create(gomock.Any(), reversal(100)).Return(&genTransferData, nil)
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		"should reverse converted transfer at its original rate": {
+			InputAccountID: originalTransfer.TargetAccountID,
+			InputAmount:    250,
+			ExpectedData: &model.Transfer{
+				ID:                 genTransferData.ID,
+				OriginAccountID:    originalTransfer.TargetAccountID,
+				TargetAccountID:    originalTransfer.OriginAccountID,
+				Amount:             250,
+				SourceCurrency:     "BRL",
+				TargetAmount:       50,
+				TargetCurrency:     "USD",
+				ExchangeRate:       "0.2",
+				ReversedTransferID: &originalTransfer.ID,
+				CreatedAt:          genTransferData.CreatedAt,
+			},
+			ExpectedError: nil,
+			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountOrigin.ID).Return(&accountOrigin, nil)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountTarget.ID).Return(&accountTarget, nil)
+				mock.EXPECT().Debit(gomock.Any(), accountTarget.ID, int64(250)).Return(true, nil)
+				mock.EXPECT().Credit(gomock.Any(), accountOrigin.ID, int64(50)).Return(nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository, tx transaction.Transaction) {
+				original := originalTransfer
+				original.Amount = 100
+				original.SourceCurrency = "USD"
+				original.TargetAmount = 500
+				original.ExchangeRate = "5"
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), originalTransfer.ID).Return(&original, nil)
+				mock.EXPECT().GetReversedAmount(gomock.Any(), originalTransfer.ID).Return(int64(0), nil)
+				mock.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&genTransferData, nil)
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		"should reverse any transfer for operators": {
+			InputAccountID: "",
+			InputAmount:    100,
+			ExpectedData:   reversed(100),
+			ExpectedError:  nil,
+			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountOrigin.ID).Return(&accountOrigin, nil)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountTarget.ID).Return(&accountTarget, nil)
+				mock.EXPECT().Debit(gomock.Any(), accountTarget.ID, int64(100)).Return(true, nil)
+				mock.EXPECT().Credit(gomock.Any(), accountOrigin.ID, int64(100)).Return(nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository, tx transaction.Transaction) {
+				original := originalTransfer
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), originalTransfer.ID).Return(&original, nil)
+				mock.EXPECT().GetReversedAmount(gomock.Any(), originalTransfer.ID).Return(int64(0), nil)
+				mock.EXPECT().Create(gomock.Any(), reversal(100)).Return(&genTransferData, nil)
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		"should return error: not the target account": {
+			InputAccountID: originalTransfer.OriginAccountID,
+			ExpectedData:   nil,
+			ExpectedError:  pkgerror.ErrTransferNotFound,
+			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository, tx transaction.Transaction) {
+				original := originalTransfer
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), originalTransfer.ID).Return(&original, nil)
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+			},
+		},
+		"should return error: transfer is a reversal": {
+			InputAccountID: originalTransfer.TargetAccountID,
+			ExpectedData:   nil,
+			ExpectedError:  pkgerror.ErrTransferIsReversal,
+			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository, tx transaction.Transaction) {
+				original := originalTransfer
+				original.ReversedTransferID = &genTransferData.ID
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), originalTransfer.ID).Return(&original, nil)
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+			},
+		},
+		"should return error: already reversed": {
+			InputAccountID: originalTransfer.TargetAccountID,
+			ExpectedData:   nil,
+			ExpectedError:  pkgerror.ErrTransferAlreadyReversed,
+			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository, tx transaction.Transaction) {
+				original := originalTransfer
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), originalTransfer.ID).Return(&original, nil)
+				mock.EXPECT().GetReversedAmount(gomock.Any(), originalTransfer.ID).Return(originalTransfer.Amount, nil)
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+			},
+		},
+		"should return error: amount above remaining": {
+			InputAccountID: originalTransfer.TargetAccountID,
+			InputAmount:    400,
+			ExpectedData:   nil,
+			ExpectedError:  pkgerror.ErrInvalidReversalAmount,
+			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository, tx transaction.Transaction) {
+				original := originalTransfer
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), originalTransfer.ID).Return(&original, nil)
+				mock.EXPECT().GetReversedAmount(gomock.Any(), originalTransfer.ID).Return(int64(200), nil)
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+			},
+		},
+		"should return error on get transfer": {
+			InputAccountID: originalTransfer.TargetAccountID,
+			ExpectedData:   nil,
+			ExpectedError:  pkgerror.ErrCantReverseTransfer,
+			PrepareMockRepoAccount: func(mock *account.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), originalTransfer.ID).Return(nil, errors.New("fail"))
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx        = gomock.WithContext(context.Background(), t)
+				txExample        = transaction.Transaction(nil)
+				mockTxManager    = transaction.NewMockManager(ctrl)
+				mockRepoAccount  = account.NewMockRepository(ctrl)
+				mockRepoTransfer = transfer.NewMockRepository(ctrl)
+				mockRepoLedger   = ledger.NewMockRepository(ctrl)
+				mockRepoLimit    = limit.NewMockRepository(ctrl)
+				mockRepoReview   = review.NewMockRepository(ctrl)
+				mockRepoHold     = hold.NewMockRepository(ctrl)
+				app              = NewApp(Options{
+					Logger:       logger.New(""),
+					TxManager:    mockTxManager,
+					RepoAccount:  mockRepoAccount,
+					RepoHold:     mockRepoHold,
+					RepoLedger:   mockRepoLedger,
+					RepoLimit:    mockRepoLimit,
+					RepoReview:   mockRepoReview,
+					RepoTransfer: mockRepoTransfer,
+				})
+			)
+
+			mockTxManager.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(executeWith(txExample))
+			mockRepoLimit.EXPECT().WithTransaction(txExample).Return(mockRepoLimit)
+			mockRepoReview.EXPECT().WithTransaction(txExample).Return(mockRepoReview)
+			mockRepoHold.EXPECT().WithTransaction(txExample).Return(mockRepoHold)
+			cs.PrepareMockRepoAccount(mockRepoAccount, txExample)
+			cs.PrepareMockRepoTransfer(mockRepoTransfer, txExample)
+			cs.PrepareMockRepoLedger(mockRepoLedger, txExample)
+
+			data, err := app.Reverse(ctx, cs.InputAccountID, originalTransfer.ID, cs.InputAmount)
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestListReviews(t *testing.T) {
+	reviewsExample := []model.TransferReview{{ID: "review_id", Status: model.TransferReviewStatusPending}}
+
+	cases := map[string]struct {
+		ExpectedData          []model.TransferReview
+		ExpectedError         error
+		PrepareMockRepoReview func(mock *review.MockRepository)
+	}{
+		"should return success": {
+			ExpectedData:  reviewsExample,
+			ExpectedError: nil,
+			PrepareMockRepoReview: func(mock *review.MockRepository) {
+				mock.EXPECT().ListPending(gomock.Any(), defaultPageSize).Return(reviewsExample, nil)
+			},
+		},
+		"should return error": {
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantListTransferReviews,
+			PrepareMockRepoReview: func(mock *review.MockRepository) {
+				mock.EXPECT().ListPending(gomock.Any(), defaultPageSize).Return(nil, errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx      = gomock.WithContext(context.Background(), t)
+				mockRepoReview = review.NewMockRepository(ctrl)
+				app            = NewApp(Options{
+					Logger:     logger.New(""),
+					RepoReview: mockRepoReview,
+				})
+			)
+
+			cs.PrepareMockRepoReview(mockRepoReview)
+
+			data, err := app.ListReviews(ctx)
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestApproveReview(t *testing.T) {
+	var (
+		currentTime   = time.Now()
+		reviewExample = model.TransferReview{
+			ID:              "review_id",
+			OriginAccountID: "origin_account_id",
+			TargetAccountID: "target_account_id",
+			Amount:          500,
+			SourceCurrency:  "BRL",
+			TargetAmount:    500,
+			TargetCurrency:  "BRL",
+			ExchangeRate:    "1",
+			Reserved:        500,
+			Status:          model.TransferReviewStatusPending,
+		}
+		accountOrigin = model.Account{
+			ID:       "origin_account_id",
+			Balance:  600,
+			Reserved: 500,
+			Currency: "BRL",
+			Status:   model.AccountStatusActive,
+		}
+		accountTarget = model.Account{
+			ID:       "target_account_id",
+			Currency: "BRL",
+			Status:   model.AccountStatusActive,
+		}
+		genTransferData = model.GeneratedData{ID: "transfer_id", CreatedAt: currentTime}
+		executeWith     = func(tx transaction.Transaction) func(context.Context, func(transaction.Transaction) error) error {
+			return func(_ context.Context, fn func(transaction.Transaction) error) error {
+				return fn(tx)
+			}
+		}
+	)
+
+	cases := map[string]struct {
+		ExpectedData            *model.Transfer
+		ExpectedError           error
+		PrepareMockRepoReview   func(mock *review.MockRepository)
+		PrepareMockRepoAccount  func(mock *account.MockRepository)
+		PrepareMockRepoTransfer func(mock *transfer.MockRepository)
+		PrepareMockRepoLedger   func(mock *ledger.MockRepository)
+	}{
+		"should return success": {
+			ExpectedData: func() *model.Transfer {
+				data := reviewExample.Transfer()
+				data.ID = genTransferData.ID
+				data.CreatedAt = genTransferData.CreatedAt
+				data.ReviewID = &reviewExample.ID
+				return &data
+			}(),
+			ExpectedError: nil,
+			PrepareMockRepoReview: func(mock *review.MockRepository) {
+				data := reviewExample
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), "review_id").Return(&data, nil)
+				mock.EXPECT().
+					Decide(gomock.Any(), "review_id", model.TransferReviewStatusApproved, "admin_id", &genTransferData.ID).
+					Return(nil)
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				origin, target := accountOrigin, accountTarget
+				gomock.InOrder(
+					mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountOrigin.ID).Return(&origin, nil),
+					mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountTarget.ID).Return(&target, nil),
+					mock.EXPECT().Release(gomock.Any(), accountOrigin.ID, int64(500)).Return(nil),
+				)
+				mock.EXPECT().Debit(gomock.Any(), accountOrigin.ID, int64(500)).Return(true, nil)
+				mock.EXPECT().Credit(gomock.Any(), accountTarget.ID, int64(500)).Return(nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {
+				mock.EXPECT().Create(gomock.Any(), reviewExample.Transfer()).Return(&genTransferData, nil)
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository) {
+				mock.EXPECT().Create(gomock.Any(), gomock.Len(2)).Return(nil)
+			},
+		},
+		"should return error: review not found": {
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrTransferReviewNotFound,
+			PrepareMockRepoReview: func(mock *review.MockRepository) {
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), "review_id").Return(nil, nil)
+			},
+			PrepareMockRepoAccount:  func(mock *account.MockRepository) {},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {},
+			PrepareMockRepoLedger:   func(mock *ledger.MockRepository) {},
+		},
+		"should return error: review already decided": {
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrTransferReviewAlreadyDecided,
+			PrepareMockRepoReview: func(mock *review.MockRepository) {
+				data := reviewExample
+				data.Status = model.TransferReviewStatusRejected
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), "review_id").Return(&data, nil)
+			},
+			PrepareMockRepoAccount:  func(mock *account.MockRepository) {},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {},
+			PrepareMockRepoLedger:   func(mock *ledger.MockRepository) {},
+		},
+		"should return error: origin account frozen": {
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrOriginAccountNotActive,
+			PrepareMockRepoReview: func(mock *review.MockRepository) {
+				data := reviewExample
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), "review_id").Return(&data, nil)
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				origin, target := accountOrigin, accountTarget
+				origin.Status = model.AccountStatusFrozen
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountOrigin.ID).Return(&origin, nil)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountTarget.ID).Return(&target, nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {},
+			PrepareMockRepoLedger:   func(mock *ledger.MockRepository) {},
+		},
+		"should return error": {
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantDecideTransferReview,
+			PrepareMockRepoReview: func(mock *review.MockRepository) {
+				data := reviewExample
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), "review_id").Return(&data, nil)
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				origin, target := accountOrigin, accountTarget
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountOrigin.ID).Return(&origin, nil)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountTarget.ID).Return(&target, nil)
+				mock.EXPECT().Release(gomock.Any(), accountOrigin.ID, int64(500)).Return(errors.New("fail"))
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {},
+			PrepareMockRepoLedger:   func(mock *ledger.MockRepository) {},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx        = gomock.WithContext(context.Background(), t)
+				txExample        = transaction.Transaction(nil)
+				mockTxManager    = transaction.NewMockManager(ctrl)
+				mockRepoAccount  = account.NewMockRepository(ctrl)
+				mockRepoTransfer = transfer.NewMockRepository(ctrl)
+				mockRepoLedger   = ledger.NewMockRepository(ctrl)
+				mockRepoLimit    = limit.NewMockRepository(ctrl)
+				mockRepoReview   = review.NewMockRepository(ctrl)
+				mockRepoHold     = hold.NewMockRepository(ctrl)
+				mockGenerate     = generate.NewMockGenerate(ctrl)
+				app              = NewApp(Options{
+					Logger:       logger.New(""),
+					Generate:     mockGenerate,
+					TxManager:    mockTxManager,
+					RepoAccount:  mockRepoAccount,
+					RepoHold:     mockRepoHold,
+					RepoLedger:   mockRepoLedger,
+					RepoLimit:    mockRepoLimit,
+					RepoReview:   mockRepoReview,
+					RepoTransfer: mockRepoTransfer,
+				})
+			)
+
+			mockGenerate.EXPECT().CurrentTime().Return(currentTime).AnyTimes()
+			mockTxManager.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(executeWith(txExample))
+			mockRepoAccount.EXPECT().WithTransaction(txExample).Return(mockRepoAccount)
+			mockRepoLedger.EXPECT().WithTransaction(txExample).Return(mockRepoLedger)
+			mockRepoLimit.EXPECT().WithTransaction(txExample).Return(mockRepoLimit)
+			mockRepoReview.EXPECT().WithTransaction(txExample).Return(mockRepoReview)
+			mockRepoHold.EXPECT().WithTransaction(txExample).Return(mockRepoHold)
+			mockRepoTransfer.EXPECT().WithTransaction(txExample).Return(mockRepoTransfer)
+			cs.PrepareMockRepoReview(mockRepoReview)
+			cs.PrepareMockRepoAccount(mockRepoAccount)
+			cs.PrepareMockRepoTransfer(mockRepoTransfer)
+			cs.PrepareMockRepoLedger(mockRepoLedger)
+
+			data, err := app.ApproveReview(ctx, "review_id", "admin_id")
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestRejectReview(t *testing.T) {
+	var (
+		reviewExample = model.TransferReview{
+			ID:              "review_id",
+			OriginAccountID: "origin_account_id",
+			Reserved:        500,
+			Status:          model.TransferReviewStatusPending,
+		}
+		executeWith = func(tx transaction.Transaction) func(context.Context, func(transaction.Transaction) error) error {
+			return func(_ context.Context, fn func(transaction.Transaction) error) error {
+				return fn(tx)
+			}
+		}
+	)
+
+	cases := map[string]struct {
+		ExpectedError          error
+		PrepareMockRepoReview  func(mock *review.MockRepository)
+		PrepareMockRepoAccount func(mock *account.MockRepository)
+	}{
+		"should return success": {
+			ExpectedError: nil,
+			PrepareMockRepoReview: func(mock *review.MockRepository) {
+				data := reviewExample
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), "review_id").Return(&data, nil)
+				mock.EXPECT().
+					Decide(gomock.Any(), "review_id", model.TransferReviewStatusRejected, "admin_id", nil).
+					Return(nil)
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().Release(gomock.Any(), "origin_account_id", int64(500)).Return(nil)
+			},
+		},
+		"should return error: review not found": {
+			ExpectedError: pkgerror.ErrTransferReviewNotFound,
+			PrepareMockRepoReview: func(mock *review.MockRepository) {
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), "review_id").Return(nil, nil)
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {},
+		},
+		"should return error": {
+			ExpectedError: pkgerror.ErrCantDecideTransferReview,
+			PrepareMockRepoReview: func(mock *review.MockRepository) {
+				data := reviewExample
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), "review_id").Return(&data, nil)
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().Release(gomock.Any(), "origin_account_id", int64(500)).Return(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx        = gomock.WithContext(context.Background(), t)
+				txExample        = transaction.Transaction(nil)
+				mockTxManager    = transaction.NewMockManager(ctrl)
+				mockRepoAccount  = account.NewMockRepository(ctrl)
+				mockRepoTransfer = transfer.NewMockRepository(ctrl)
+				mockRepoLedger   = ledger.NewMockRepository(ctrl)
+				mockRepoLimit    = limit.NewMockRepository(ctrl)
+				mockRepoReview   = review.NewMockRepository(ctrl)
+				mockRepoHold     = hold.NewMockRepository(ctrl)
+				app              = NewApp(Options{
+					Logger:       logger.New(""),
+					TxManager:    mockTxManager,
+					RepoAccount:  mockRepoAccount,
+					RepoHold:     mockRepoHold,
+					RepoLedger:   mockRepoLedger,
+					RepoLimit:    mockRepoLimit,
+					RepoReview:   mockRepoReview,
+					RepoTransfer: mockRepoTransfer,
+				})
+			)
+
+			mockTxManager.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(executeWith(txExample))
+			mockRepoAccount.EXPECT().WithTransaction(txExample).Return(mockRepoAccount)
+			mockRepoLedger.EXPECT().WithTransaction(txExample).Return(mockRepoLedger)
+			mockRepoLimit.EXPECT().WithTransaction(txExample).Return(mockRepoLimit)
+			mockRepoReview.EXPECT().WithTransaction(txExample).Return(mockRepoReview)
+			mockRepoHold.EXPECT().WithTransaction(txExample).Return(mockRepoHold)
+			mockRepoTransfer.EXPECT().WithTransaction(txExample).Return(mockRepoTransfer)
+			cs.PrepareMockRepoReview(mockRepoReview)
+			cs.PrepareMockRepoAccount(mockRepoAccount)
+
+			err := app.RejectReview(ctx, "review_id", "admin_id")
+
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestCreateHold(t *testing.T) {
+	var (
+		currentTime = time.Now()
+		sessionCtx  = model.SetSessionOnContext(context.Background(), &model.Session{
+			Account: model.Account{ID: "origin_account_id"},
+		})
+		holdExample = model.Hold{
+			OriginAccountID: "origin_account_id",
+			TargetAccountID: "target_account_id",
+			Amount:          500,
+			ExpiresAt:       currentTime.Add(24 * time.Hour),
+		}
+		transferExample = model.Transfer{
+			OriginAccountID: holdExample.OriginAccountID,
+			TargetAccountID: holdExample.TargetAccountID,
+			Amount:          holdExample.Amount,
+		}
+		storedHold = model.Hold{
+			OriginAccountID: holdExample.OriginAccountID,
+			TargetAccountID: holdExample.TargetAccountID,
+			Amount:          holdExample.Amount,
+			Currency:        "BRL",
+			TargetAmount:    holdExample.Amount,
+			TargetCurrency:  "BRL",
+			ExchangeRate:    "1",
+			Reserved:        500,
+			ExpiresAt:       holdExample.ExpiresAt,
+		}
+		accountOrigin = model.Account{
+			ID:       holdExample.OriginAccountID,
+			Balance:  1000,
+			Reserved: 300,
+			Currency: "BRL",
+			Status:   model.AccountStatusActive,
+		}
+		accountTarget = model.Account{
+			ID:       holdExample.TargetAccountID,
+			Currency: "BRL",
+			Status:   model.AccountStatusActive,
+		}
+		genHoldData          = model.GeneratedData{ID: "hold_id", CreatedAt: currentTime}
+		dayStart, monthStart = model.LimitPeriods(currentTime)
+		executeWith          = func(tx transaction.Transaction) func(context.Context, func(transaction.Transaction) error) error {
+			return func(_ context.Context, fn func(transaction.Transaction) error) error {
+				return fn(tx)
+			}
+		}
+	)
+
+	cases := map[string]struct {
+		InputCtx                context.Context
+		InputData               model.Hold
+		ExpectedData            *model.Hold
+		ExpectedError           error
+		PrepareMockRepoAccount  func(mock *account.MockRepository)
+		PrepareMockRepoTransfer func(mock *transfer.MockRepository)
+		PrepareMockRepoHold     func(mock *hold.MockRepository)
+		PrepareMockRisk         func(mock *risk.MockEngine)
+	}{
+		"should return success": {
+			InputCtx:  context.Background(),
+			InputData: holdExample,
+			ExpectedData: func() *model.Hold {
+				data := storedHold
+				data.ID = genHoldData.ID
+				data.Status = model.HoldStatusActive
+				data.CreatedAt = genHoldData.CreatedAt
+				return &data
+			}(),
+			ExpectedError: nil,
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				origin, target := accountOrigin, accountTarget
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), accountOrigin.ID).Return(&origin, nil)
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), accountTarget.ID).Return(&target, nil)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountOrigin.ID).Return(&origin, nil)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountTarget.ID).Return(&target, nil)
+				mock.EXPECT().Reserve(gomock.Any(), accountOrigin.ID, int64(500)).Return(true, nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {
+				mock.EXPECT().GetUsage(gomock.Any(), accountOrigin.ID, dayStart, monthStart).Return(&model.TransferUsage{}, nil)
+			},
+			PrepareMockRepoHold: func(mock *hold.MockRepository) {
+				mock.EXPECT().Create(gomock.Any(), storedHold).Return(&genHoldData, nil)
+			},
+			PrepareMockRisk: func(mock *risk.MockEngine) {},
+		},
+		"should return error: expiry in the past": {
+			InputCtx: context.Background(),
+			InputData: func() model.Hold {
+				data := holdExample
+				data.ExpiresAt = currentTime.Add(-time.Minute)
+				return data
+			}(),
+			ExpectedData:            nil,
+			ExpectedError:           pkgerror.ErrInvalidHoldExpiry,
+			PrepareMockRepoAccount:  func(mock *account.MockRepository) {},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {},
+			PrepareMockRepoHold:     func(mock *hold.MockRepository) {},
+			PrepareMockRisk:         func(mock *risk.MockEngine) {},
+		},
+		"should return error: insufficient available balance": {
+			InputCtx:      context.Background(),
+			InputData:     holdExample,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrInsufficientFunds,
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				origin, target := accountOrigin, accountTarget
+				origin.Reserved = 600
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), accountOrigin.ID).Return(&origin, nil)
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), accountTarget.ID).Return(&target, nil)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountOrigin.ID).Return(&origin, nil)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountTarget.ID).Return(&target, nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {
+				mock.EXPECT().GetUsage(gomock.Any(), accountOrigin.ID, dayStart, monthStart).Return(&model.TransferUsage{}, nil)
+			},
+			PrepareMockRepoHold: func(mock *hold.MockRepository) {},
+			PrepareMockRisk:     func(mock *risk.MockEngine) {},
+		},
+		"should return error: held by the risk check": {
+			InputCtx:      sessionCtx,
+			InputData:     holdExample,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrTransferDenied,
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				origin, target := accountOrigin, accountTarget
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), accountOrigin.ID).Return(&origin, nil)
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), accountTarget.ID).Return(&target, nil)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountOrigin.ID).Return(&origin, nil)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountTarget.ID).Return(&target, nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {
+				mock.EXPECT().GetUsage(gomock.Any(), accountOrigin.ID, dayStart, monthStart).Return(&model.TransferUsage{}, nil)
+				mock.EXPECT().
+					ListSent(gomock.Any(), accountOrigin.ID, currentTime.Add(-riskHistoryPeriod)).
+					Return([]model.Transfer{}, nil)
+			},
+			PrepareMockRepoHold: func(mock *hold.MockRepository) {},
+			PrepareMockRisk: func(mock *risk.MockEngine) {
+				mock.EXPECT().
+					Assess(gomock.Any(), gomock.Any()).
+					Return(risk.Assessment{Decision: risk.DecisionReview, Score: 50}, nil)
+			},
+		},
+		"should return error": {
+			InputCtx:      context.Background(),
+			InputData:     holdExample,
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantCreateHold,
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				origin, target := accountOrigin, accountTarget
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), accountOrigin.ID).Return(&origin, nil)
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), accountTarget.ID).Return(&target, nil)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountOrigin.ID).Return(&origin, nil)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountTarget.ID).Return(&target, nil)
+				mock.EXPECT().Reserve(gomock.Any(), accountOrigin.ID, int64(500)).Return(true, nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {
+				mock.EXPECT().GetUsage(gomock.Any(), accountOrigin.ID, dayStart, monthStart).Return(&model.TransferUsage{}, nil)
+			},
+			PrepareMockRepoHold: func(mock *hold.MockRepository) {
+				mock.EXPECT().Create(gomock.Any(), storedHold).Return(nil, errors.New("fail"))
+			},
+			PrepareMockRisk: func(mock *risk.MockEngine) {},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl             = gomock.NewController(t)
+				txExample        = transaction.Transaction(nil)
+				mockValidator    = validator.NewMockValidator(ctrl)
+				mockTxManager    = transaction.NewMockManager(ctrl)
+				mockRepoAccount  = account.NewMockRepository(ctrl)
+				mockRepoTransfer = transfer.NewMockRepository(ctrl)
+				mockRepoLedger   = ledger.NewMockRepository(ctrl)
+				mockRepoLimit    = limit.NewMockRepository(ctrl)
+				mockRepoReview   = review.NewMockRepository(ctrl)
+				mockRepoHold     = hold.NewMockRepository(ctrl)
+				mockGenerate     = generate.NewMockGenerate(ctrl)
+				mockRisk         = risk.NewMockEngine(ctrl)
+				app              = NewApp(Options{
+					Logger:       logger.New(""),
+					Validator:    mockValidator,
+					Generate:     mockGenerate,
+					TxManager:    mockTxManager,
+					RepoAccount:  mockRepoAccount,
+					RepoHold:     mockRepoHold,
+					RepoLedger:   mockRepoLedger,
+					RepoLimit:    mockRepoLimit,
+					RepoReview:   mockRepoReview,
+					RepoTransfer: mockRepoTransfer,
+					Risk:         mockRisk,
+				})
+			)
+
+			mockGenerate.EXPECT().CurrentTime().Return(currentTime).AnyTimes()
+			mockValidator.EXPECT().Validate(transferExample).Return(nil).AnyTimes()
+			mockTxManager.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(executeWith(txExample)).AnyTimes()
+			mockRepoAccount.EXPECT().WithTransaction(txExample).Return(mockRepoAccount).AnyTimes()
+			mockRepoHold.EXPECT().WithTransaction(txExample).Return(mockRepoHold).AnyTimes()
+			mockRepoLedger.EXPECT().WithTransaction(txExample).Return(mockRepoLedger).AnyTimes()
+			mockRepoLimit.EXPECT().WithTransaction(txExample).Return(mockRepoLimit).AnyTimes()
+			mockRepoLimit.EXPECT().Get(gomock.Any(), accountOrigin.ID).Return(nil, nil).AnyTimes()
+			mockRepoReview.EXPECT().WithTransaction(txExample).Return(mockRepoReview).AnyTimes()
+			mockRepoTransfer.EXPECT().WithTransaction(txExample).Return(mockRepoTransfer).AnyTimes()
+			cs.PrepareMockRepoAccount(mockRepoAccount)
+			cs.PrepareMockRepoTransfer(mockRepoTransfer)
+			cs.PrepareMockRepoHold(mockRepoHold)
+			cs.PrepareMockRisk(mockRisk)
+
+			data, err := app.CreateHold(cs.InputCtx, cs.InputData)
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestListHolds(t *testing.T) {
+	holdsExample := []model.Hold{{ID: "hold_id", Status: model.HoldStatusActive}}
+
+	cases := map[string]struct {
+		ExpectedData        []model.Hold
+		ExpectedError       error
+		PrepareMockRepoHold func(mock *hold.MockRepository)
+	}{
+		"should return success": {
+			ExpectedData:  holdsExample,
+			ExpectedError: nil,
+			PrepareMockRepoHold: func(mock *hold.MockRepository) {
+				mock.EXPECT().List(gomock.Any(), "account_id", model.HoldStatusActive).Return(holdsExample, nil)
+			},
+		},
+		"should return error": {
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantListHolds,
+			PrepareMockRepoHold: func(mock *hold.MockRepository) {
+				mock.EXPECT().List(gomock.Any(), "account_id", model.HoldStatusActive).Return(nil, errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx    = gomock.WithContext(context.Background(), t)
+				mockRepoHold = hold.NewMockRepository(ctrl)
+				app          = NewApp(Options{
+					Logger:   logger.New(""),
+					RepoHold: mockRepoHold,
+				})
+			)
+
+			cs.PrepareMockRepoHold(mockRepoHold)
+
+			data, err := app.ListHolds(ctx, "account_id", model.HoldStatusActive)
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestCaptureHold(t *testing.T) {
+	var (
+		currentTime = time.Now()
+		holdExample = model.Hold{
+			ID:              "hold_id",
+			OriginAccountID: "origin_account_id",
+			TargetAccountID: "target_account_id",
+			Amount:          500,
+			Currency:        "BRL",
+			TargetAmount:    500,
+			TargetCurrency:  "BRL",
+			ExchangeRate:    "1",
+			Reserved:        500,
+			Status:          model.HoldStatusActive,
+			ExpiresAt:       currentTime.Add(time.Hour),
+		}
+		accountOrigin = model.Account{
+			ID:       "origin_account_id",
+			Balance:  600,
+			Reserved: 500,
+			Currency: "BRL",
+			Status:   model.AccountStatusActive,
+		}
+		accountTarget = model.Account{
+			ID:       "target_account_id",
+			Currency: "BRL",
+			Status:   model.AccountStatusActive,
+		}
+		transferExample = model.Transfer{
+			OriginAccountID: "origin_account_id",
+			TargetAccountID: "target_account_id",
+			Amount:          300,
+			SourceCurrency:  "BRL",
+			TargetAmount:    300,
+			TargetCurrency:  "BRL",
+			ExchangeRate:    "1",
+		}
+		genTransferData = model.GeneratedData{ID: "transfer_id", CreatedAt: currentTime}
+		executeWith     = func(tx transaction.Transaction) func(context.Context, func(transaction.Transaction) error) error {
+			return func(_ context.Context, fn func(transaction.Transaction) error) error {
+				return fn(tx)
+			}
+		}
+	)
+
+	cases := map[string]struct {
+		InputAccountID          string
+		InputAmount             int64
+		ExpectedData            *model.Transfer
+		ExpectedError           error
+		PrepareMockRepoHold     func(mock *hold.MockRepository)
+		PrepareMockRepoAccount  func(mock *account.MockRepository)
+		PrepareMockRepoTransfer func(mock *transfer.MockRepository)
+		PrepareMockRepoLedger   func(mock *ledger.MockRepository)
+	}{
+		"should return success": {
+			InputAccountID: "target_account_id",
+			InputAmount:    300,
+			ExpectedData: func() *model.Transfer {
+				data := transferExample
+				data.ID = genTransferData.ID
+				data.CreatedAt = genTransferData.CreatedAt
+				return &data
+			}(),
+			ExpectedError: nil,
+			PrepareMockRepoHold: func(mock *hold.MockRepository) {
+				data := holdExample
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), "hold_id").Return(&data, nil)
+				mock.EXPECT().
+					Close(gomock.Any(), "hold_id", model.HoldStatusCaptured, int64(300), &genTransferData.ID).
+					Return(nil)
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				origin, target := accountOrigin, accountTarget
+				gomock.InOrder(
+					mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountOrigin.ID).Return(&origin, nil),
+					mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountTarget.ID).Return(&target, nil),
+					mock.EXPECT().Release(gomock.Any(), accountOrigin.ID, int64(500)).Return(nil),
+				)
+				mock.EXPECT().Debit(gomock.Any(), accountOrigin.ID, int64(300)).Return(true, nil)
+				mock.EXPECT().Credit(gomock.Any(), accountTarget.ID, int64(300)).Return(nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {
+				mock.EXPECT().Create(gomock.Any(), transferExample).Return(&genTransferData, nil)
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository) {
+				mock.EXPECT().Create(gomock.Any(), gomock.Len(2)).Return(nil)
+			},
+		},
+		"should return success: rate quoted by the hold": {
+			InputAccountID: "target_account_id",
+			InputAmount:    300,
+			ExpectedData: func() *model.Transfer {
+				data := transferExample
+				data.TargetAmount = 60
+				data.TargetCurrency = "USD"
+				data.ExchangeRate = "0.2"
+				data.ID = genTransferData.ID
+				data.CreatedAt = genTransferData.CreatedAt
+				return &data
+			}(),
+			ExpectedError: nil,
+			PrepareMockRepoHold: func(mock *hold.MockRepository) {
+				data := holdExample
+				data.TargetAmount = 100
+				data.TargetCurrency = "USD"
+				data.ExchangeRate = "0.2"
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), "hold_id").Return(&data, nil)
+				mock.EXPECT().
+					Close(gomock.Any(), "hold_id", model.HoldStatusCaptured, int64(300), &genTransferData.ID).
+					Return(nil)
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				origin, target := accountOrigin, accountTarget
+				target.Currency = "USD"
+				gomock.InOrder(
+					mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountOrigin.ID).Return(&origin, nil),
+					mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountTarget.ID).Return(&target, nil),
+					mock.EXPECT().Release(gomock.Any(), accountOrigin.ID, int64(500)).Return(nil),
+				)
+				mock.EXPECT().Debit(gomock.Any(), accountOrigin.ID, int64(300)).Return(true, nil)
+				mock.EXPECT().Credit(gomock.Any(), accountTarget.ID, int64(60)).Return(nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {
+				data := transferExample
+				data.TargetAmount = 60
+				data.TargetCurrency = "USD"
+				data.ExchangeRate = "0.2"
+				mock.EXPECT().Create(gomock.Any(), data).Return(&genTransferData, nil)
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository) {
+				mock.EXPECT().Create(gomock.Any(), gomock.Len(4)).Return(nil)
+			},
+		},
+		"should return success: hold without quote": {
+			InputAccountID: "target_account_id",
+			InputAmount:    300,
+			ExpectedData: func() *model.Transfer {
+				data := transferExample
+				data.ID = genTransferData.ID
+				data.CreatedAt = genTransferData.CreatedAt
+				return &data
+			}(),
+			ExpectedError: nil,
+			PrepareMockRepoHold: func(mock *hold.MockRepository) {
+				data := holdExample
+				data.TargetAmount = 0
+				data.TargetCurrency = ""
+				data.ExchangeRate = "0"
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), "hold_id").Return(&data, nil)
+				mock.EXPECT().
+					Close(gomock.Any(), "hold_id", model.HoldStatusCaptured, int64(300), &genTransferData.ID).
+					Return(nil)
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				origin, target := accountOrigin, accountTarget
+				gomock.InOrder(
+					mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountOrigin.ID).Return(&origin, nil),
+					mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountTarget.ID).Return(&target, nil),
+					mock.EXPECT().Release(gomock.Any(), accountOrigin.ID, int64(500)).Return(nil),
+				)
+				mock.EXPECT().Debit(gomock.Any(), accountOrigin.ID, int64(300)).Return(true, nil)
+				mock.EXPECT().Credit(gomock.Any(), accountTarget.ID, int64(300)).Return(nil)
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {
+				mock.EXPECT().Create(gomock.Any(), transferExample).Return(&genTransferData, nil)
+			},
+			PrepareMockRepoLedger: func(mock *ledger.MockRepository) {
+				mock.EXPECT().Create(gomock.Any(), gomock.Len(2)).Return(nil)
+			},
+		},
+		"should return error: hold not found": {
+			InputAccountID: "origin_account_id",
+			InputAmount:    0,
+			ExpectedData:   nil,
+			ExpectedError:  pkgerror.ErrHoldNotFound,
+			PrepareMockRepoHold: func(mock *hold.MockRepository) {
+				data := holdExample
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), "hold_id").Return(&data, nil)
+			},
+			PrepareMockRepoAccount:  func(mock *account.MockRepository) {},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {},
+			PrepareMockRepoLedger:   func(mock *ledger.MockRepository) {},
+		},
+		"should return error: hold expired": {
+			InputAccountID: "target_account_id",
+			InputAmount:    0,
+			ExpectedData:   nil,
+			ExpectedError:  pkgerror.ErrHoldNotActive,
+			PrepareMockRepoHold: func(mock *hold.MockRepository) {
+				data := holdExample
+				data.ExpiresAt = currentTime.Add(-time.Minute)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), "hold_id").Return(&data, nil)
+			},
+			PrepareMockRepoAccount:  func(mock *account.MockRepository) {},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {},
+			PrepareMockRepoLedger:   func(mock *ledger.MockRepository) {},
+		},
+		"should return error: amount above the hold": {
+			InputAccountID: "target_account_id",
+			InputAmount:    501,
+			ExpectedData:   nil,
+			ExpectedError:  pkgerror.ErrInvalidCaptureAmount,
+			PrepareMockRepoHold: func(mock *hold.MockRepository) {
+				data := holdExample
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), "hold_id").Return(&data, nil)
+			},
+			PrepareMockRepoAccount:  func(mock *account.MockRepository) {},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {},
+			PrepareMockRepoLedger:   func(mock *ledger.MockRepository) {},
+		},
+		"should return error": {
+			InputAccountID: "target_account_id",
+			InputAmount:    0,
+			ExpectedData:   nil,
+			ExpectedError:  pkgerror.ErrCantCaptureHold,
+			PrepareMockRepoHold: func(mock *hold.MockRepository) {
+				data := holdExample
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), "hold_id").Return(&data, nil)
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				origin, target := accountOrigin, accountTarget
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountOrigin.ID).Return(&origin, nil)
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), accountTarget.ID).Return(&target, nil)
+				mock.EXPECT().Release(gomock.Any(), accountOrigin.ID, int64(500)).Return(errors.New("fail"))
+			},
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {},
+			PrepareMockRepoLedger:   func(mock *ledger.MockRepository) {},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx        = gomock.WithContext(context.Background(), t)
+				txExample        = transaction.Transaction(nil)
+				mockTxManager    = transaction.NewMockManager(ctrl)
+				mockRepoAccount  = account.NewMockRepository(ctrl)
+				mockRepoTransfer = transfer.NewMockRepository(ctrl)
+				mockRepoLedger   = ledger.NewMockRepository(ctrl)
+				mockRepoLimit    = limit.NewMockRepository(ctrl)
+				mockRepoReview   = review.NewMockRepository(ctrl)
+				mockRepoHold     = hold.NewMockRepository(ctrl)
+				mockGenerate     = generate.NewMockGenerate(ctrl)
+				app              = NewApp(Options{
+					Logger:       logger.New(""),
+					Generate:     mockGenerate,
+					TxManager:    mockTxManager,
+					RepoAccount:  mockRepoAccount,
+					RepoHold:     mockRepoHold,
+					RepoLedger:   mockRepoLedger,
+					RepoLimit:    mockRepoLimit,
+					RepoReview:   mockRepoReview,
+					RepoTransfer: mockRepoTransfer,
+				})
+			)
+
+			mockGenerate.EXPECT().CurrentTime().Return(currentTime).AnyTimes()
+			mockTxManager.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(executeWith(txExample))
+			mockRepoAccount.EXPECT().WithTransaction(txExample).Return(mockRepoAccount)
+			mockRepoHold.EXPECT().WithTransaction(txExample).Return(mockRepoHold)
+			mockRepoLedger.EXPECT().WithTransaction(txExample).Return(mockRepoLedger)
+			mockRepoLimit.EXPECT().WithTransaction(txExample).Return(mockRepoLimit)
+			mockRepoReview.EXPECT().WithTransaction(txExample).Return(mockRepoReview)
+			mockRepoTransfer.EXPECT().WithTransaction(txExample).Return(mockRepoTransfer)
+			cs.PrepareMockRepoHold(mockRepoHold)
+			cs.PrepareMockRepoAccount(mockRepoAccount)
+			cs.PrepareMockRepoTransfer(mockRepoTransfer)
+			cs.PrepareMockRepoLedger(mockRepoLedger)
+
+			data, err := app.CaptureHold(ctx, cs.InputAccountID, "hold_id", cs.InputAmount)
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestReleaseHold(t *testing.T) {
+	var (
+		holdExample = model.Hold{
+			ID:              "hold_id",
+			OriginAccountID: "origin_account_id",
+			TargetAccountID: "target_account_id",
+			Amount:          500,
+			Reserved:        500,
+			Status:          model.HoldStatusActive,
+		}
+		executeWith = func(tx transaction.Transaction) func(context.Context, func(transaction.Transaction) error) error {
+			return func(_ context.Context, fn func(transaction.Transaction) error) error {
+				return fn(tx)
+			}
+		}
+	)
+
+	cases := map[string]struct {
+		ExpectedData           *model.Hold
+		ExpectedError          error
+		PrepareMockRepoHold    func(mock *hold.MockRepository)
+		PrepareMockRepoAccount func(mock *account.MockRepository)
+	}{
+		"should return success": {
+			ExpectedData: func() *model.Hold {
+				data := holdExample
+				data.Status = model.HoldStatusReleased
+				return &data
+			}(),
+			ExpectedError: nil,
+			PrepareMockRepoHold: func(mock *hold.MockRepository) {
+				data := holdExample
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), "hold_id").Return(&data, nil)
+				mock.EXPECT().Close(gomock.Any(), "hold_id", model.HoldStatusReleased, int64(0), nil).Return(nil)
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().Release(gomock.Any(), "origin_account_id", int64(500)).Return(nil)
+			},
+		},
+		"should return error: hold not active": {
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrHoldNotActive,
+			PrepareMockRepoHold: func(mock *hold.MockRepository) {
+				data := holdExample
+				data.Status = model.HoldStatusCaptured
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), "hold_id").Return(&data, nil)
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {},
+		},
+		"should return error": {
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantReleaseHold,
+			PrepareMockRepoHold: func(mock *hold.MockRepository) {
+				mock.EXPECT().GetByIDForUpdate(gomock.Any(), "hold_id").Return(nil, errors.New("fail"))
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx        = gomock.WithContext(context.Background(), t)
+				txExample        = transaction.Transaction(nil)
+				mockTxManager    = transaction.NewMockManager(ctrl)
+				mockRepoAccount  = account.NewMockRepository(ctrl)
+				mockRepoTransfer = transfer.NewMockRepository(ctrl)
+				mockRepoLedger   = ledger.NewMockRepository(ctrl)
+				mockRepoLimit    = limit.NewMockRepository(ctrl)
+				mockRepoReview   = review.NewMockRepository(ctrl)
+				mockRepoHold     = hold.NewMockRepository(ctrl)
+				app              = NewApp(Options{
+					Logger:       logger.New(""),
+					TxManager:    mockTxManager,
+					RepoAccount:  mockRepoAccount,
+					RepoHold:     mockRepoHold,
+					RepoLedger:   mockRepoLedger,
+					RepoLimit:    mockRepoLimit,
+					RepoReview:   mockRepoReview,
+					RepoTransfer: mockRepoTransfer,
+				})
+			)
+
+			mockTxManager.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(executeWith(txExample))
+			mockRepoAccount.EXPECT().WithTransaction(txExample).Return(mockRepoAccount)
+			mockRepoHold.EXPECT().WithTransaction(txExample).Return(mockRepoHold)
+			mockRepoLedger.EXPECT().WithTransaction(txExample).Return(mockRepoLedger)
+			mockRepoLimit.EXPECT().WithTransaction(txExample).Return(mockRepoLimit)
+			mockRepoReview.EXPECT().WithTransaction(txExample).Return(mockRepoReview)
+			mockRepoTransfer.EXPECT().WithTransaction(txExample).Return(mockRepoTransfer)
+			cs.PrepareMockRepoHold(mockRepoHold)
+			cs.PrepareMockRepoAccount(mockRepoAccount)
+
+			data, err := app.ReleaseHold(ctx, "target_account_id", "hold_id")
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestExpireHolds(t *testing.T) {
+	var (
+		currentTime  = time.Now()
+		holdsExample = []model.Hold{
+			{ID: "hold_1", OriginAccountID: "account_1", Reserved: 500, Status: model.HoldStatusActive},
+			{ID: "hold_2", OriginAccountID: "account_2", Reserved: 300, Status: model.HoldStatusActive},
+		}
+		executeWith = func(tx transaction.Transaction) func(context.Context, func(transaction.Transaction) error) error {
+			return func(_ context.Context, fn func(transaction.Transaction) error) error {
+				return fn(tx)
+			}
+		}
+	)
+
+	cases := map[string]struct {
+		ExpectedError          error
+		PrepareMockRepoHold    func(mock *hold.MockRepository)
+		PrepareMockRepoAccount func(mock *account.MockRepository)
+	}{
+		"should return success": {
+			ExpectedError: nil,
+			PrepareMockRepoHold: func(mock *hold.MockRepository) {
+				mock.EXPECT().
+					ListExpiredForUpdate(gomock.Any(), currentTime, expireHoldsBatchSize).
+					Return(holdsExample, nil)
+				mock.EXPECT().Close(gomock.Any(), "hold_1", model.HoldStatusExpired, int64(0), nil).Return(nil)
+				mock.EXPECT().Close(gomock.Any(), "hold_2", model.HoldStatusExpired, int64(0), nil).Return(nil)
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().Release(gomock.Any(), "account_1", int64(500)).Return(nil)
+				mock.EXPECT().Release(gomock.Any(), "account_2", int64(300)).Return(nil)
+			},
+		},
+		"should return error": {
+			ExpectedError: pkgerror.ErrCantExpireHolds,
+			PrepareMockRepoHold: func(mock *hold.MockRepository) {
+				mock.EXPECT().
+					ListExpiredForUpdate(gomock.Any(), currentTime, expireHoldsBatchSize).
+					Return(holdsExample, nil)
+			},
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().Release(gomock.Any(), "account_1", int64(500)).Return(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx        = gomock.WithContext(context.Background(), t)
+				txExample        = transaction.Transaction(nil)
+				mockTxManager    = transaction.NewMockManager(ctrl)
+				mockRepoAccount  = account.NewMockRepository(ctrl)
+				mockRepoTransfer = transfer.NewMockRepository(ctrl)
+				mockRepoLedger   = ledger.NewMockRepository(ctrl)
+				mockRepoLimit    = limit.NewMockRepository(ctrl)
+				mockRepoReview   = review.NewMockRepository(ctrl)
+				mockRepoHold     = hold.NewMockRepository(ctrl)
+				mockGenerate     = generate.NewMockGenerate(ctrl)
+				app              = NewApp(Options{
+					Logger:       logger.New(""),
+					Generate:     mockGenerate,
+					TxManager:    mockTxManager,
+					RepoAccount:  mockRepoAccount,
+					RepoHold:     mockRepoHold,
+					RepoLedger:   mockRepoLedger,
+					RepoLimit:    mockRepoLimit,
+					RepoReview:   mockRepoReview,
+					RepoTransfer: mockRepoTransfer,
+				})
+			)
+
+			mockGenerate.EXPECT().CurrentTime().Return(currentTime)
+			mockTxManager.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(executeWith(txExample))
+			mockRepoAccount.EXPECT().WithTransaction(txExample).Return(mockRepoAccount)
+			mockRepoHold.EXPECT().WithTransaction(txExample).Return(mockRepoHold)
+			mockRepoLedger.EXPECT().WithTransaction(txExample).Return(mockRepoLedger)
+			mockRepoLimit.EXPECT().WithTransaction(txExample).Return(mockRepoLimit)
+			mockRepoReview.EXPECT().WithTransaction(txExample).Return(mockRepoReview)
+			mockRepoTransfer.EXPECT().WithTransaction(txExample).Return(mockRepoTransfer)
+			cs.PrepareMockRepoHold(mockRepoHold)
+			cs.PrepareMockRepoAccount(mockRepoAccount)
+
+			err := app.ExpireHolds(ctx)
+
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestCheckStepUp(t *testing.T) {
+	var (
+		sessionCtx = model.SetSessionOnContext(context.Background(), &model.Session{
+			Account: model.Account{ID: "origin_account_id"},
+		})
+		transferExample = model.Transfer{
+			OriginAccountID: "origin_account_id",
+			TargetAccountID: "target_account_id",
+			Amount:          1001,
+			TwoFactorCode:   "123456",
+		}
+	)
+
+	cases := map[string]struct {
+		InputCtx             context.Context
+		InputAmount          int64
+		ExpectedError        error
+		PrepareMockTwoFactor func(mock *twofactor.MockApp)
+	}{
+		"should return success": {
+			InputCtx:      sessionCtx,
+			InputAmount:   1001,
+			ExpectedError: nil,
+			PrepareMockTwoFactor: func(mock *twofactor.MockApp) {
+				mock.EXPECT().Verify(gomock.Any(), "origin_account_id", "123456").Return(nil)
+			},
+		},
+		"should return success: up to the threshold": {
+			InputCtx:             sessionCtx,
+			InputAmount:          1000,
+			ExpectedError:        nil,
+			PrepareMockTwoFactor: func(mock *twofactor.MockApp) {},
+		},
+		"should return success: made by a worker": {
+			InputCtx:             context.Background(),
+			InputAmount:          1001,
+			ExpectedError:        nil,
+			PrepareMockTwoFactor: func(mock *twofactor.MockApp) {},
+		},
+		"should return error: invalid code": {
+			InputCtx:      sessionCtx,
+			InputAmount:   1001,
+			ExpectedError: pkgerror.ErrInvalidTwoFactorCode,
+			PrepareMockTwoFactor: func(mock *twofactor.MockApp) {
+				mock.EXPECT().
+					Verify(gomock.Any(), "origin_account_id", "123456").
+					Return(pkgerror.ErrInvalidTwoFactorCode)
+			},
+		},
+		"should return error on verify": {
+			InputCtx:      sessionCtx,
+			InputAmount:   1001,
+			ExpectedError: pkgerror.ErrCantCreateTransfer,
+			PrepareMockTwoFactor: func(mock *twofactor.MockApp) {
+				mock.EXPECT().
+					Verify(gomock.Any(), "origin_account_id", "123456").
+					Return(pkgerror.ErrCantVerifyTwoFactor)
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl          = gomock.NewController(t)
+				mockTwoFactor = twofactor.NewMockApp(ctrl)
+			)
+
+			cs.PrepareMockTwoFactor(mockTwoFactor)
+
+			app := &appImpl{
+				twoFactorApp:    mockTwoFactor,
+				stepUpThreshold: 1000,
+			}
+
+			data := transferExample
+			data.Amount = cs.InputAmount
+			err := app.checkStepUp(cs.InputCtx, data)
+
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestAssessRisk(t *testing.T) {
+	var (
+		currentTime = time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC)
+		sessionCtx  = model.SetSessionOnContext(context.Background(), &model.Session{
+			Account: model.Account{ID: "origin_account_id"},
+		})
+		wrapperExample = transferWrapper{
+			AccountOrigin: &model.Account{ID: "origin_account_id", Balance: 1000, Reserved: 300},
+			AccountTarget: &model.Account{ID: "target_account_id", Balance: 50},
+			Transfer: model.Transfer{
+				OriginAccountID: "origin_account_id",
+				TargetAccountID: "target_account_id",
+				Amount:          500,
+			},
+		}
+		history = []model.Transfer{{
+			OriginAccountID: "origin_account_id",
+			TargetAccountID: "other_account_id",
+			Amount:          100,
+			CreatedAt:       currentTime.Add(-time.Hour),
+		}}
+		checkExample = risk.Check{
+			Transfer: risk.Transfer{
+				OriginAccountID: "origin_account_id",
+				TargetAccountID: "target_account_id",
+				Amount:          500,
+				CreatedAt:       currentTime,
+			},
+			Origin: risk.Account{ID: "origin_account_id", Balance: 700},
+			Target: risk.Account{ID: "target_account_id", Balance: 50},
+			History: []risk.Transfer{{
+				OriginAccountID: "origin_account_id",
+				TargetAccountID: "other_account_id",
+				Amount:          100,
+				CreatedAt:       currentTime.Add(-time.Hour),
+			}},
+		}
+		reviewAssessment = risk.Assessment{Decision: risk.DecisionReview, Score: 50, Reasons: []string{risk.ReasonBurst}}
+	)
+
+	cases := map[string]struct {
+		InputCtx                context.Context
+		ExpectedData            risk.Assessment
+		ExpectedError           error
+		PrepareMockRepoTransfer func(mock *transfer.MockRepository)
+		PrepareMockRisk         func(mock *risk.MockEngine)
+	}{
+		"should return success": {
+			InputCtx:      sessionCtx,
+			ExpectedData:  reviewAssessment,
+			ExpectedError: nil,
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {
+				mock.EXPECT().
+					ListSent(gomock.Any(), "origin_account_id", currentTime.Add(-riskHistoryPeriod)).
+					Return(history, nil)
+			},
+			PrepareMockRisk: func(mock *risk.MockEngine) {
+				mock.EXPECT().Assess(gomock.Any(), checkExample).Return(reviewAssessment, nil)
+			},
+		},
+		"should return success: made by a worker": {
+			InputCtx:                context.Background(),
+			ExpectedData:            risk.Assessment{Decision: risk.DecisionAllow},
+			ExpectedError:           nil,
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {},
+			PrepareMockRisk:         func(mock *risk.MockEngine) {},
+		},
+		"should return error": {
+			InputCtx:      sessionCtx,
+			ExpectedData:  risk.Assessment{},
+			ExpectedError: errors.New("fail"),
+			PrepareMockRepoTransfer: func(mock *transfer.MockRepository) {
+				mock.EXPECT().
+					ListSent(gomock.Any(), "origin_account_id", currentTime.Add(-riskHistoryPeriod)).
+					Return(nil, errors.New("fail"))
+			},
+			PrepareMockRisk: func(mock *risk.MockEngine) {},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl             = gomock.NewController(t)
+				mockGenerate     = generate.NewMockGenerate(ctrl)
+				mockRepoTransfer = transfer.NewMockRepository(ctrl)
+				mockRisk         = risk.NewMockEngine(ctrl)
+			)
+
+			mockGenerate.EXPECT().CurrentTime().Return(currentTime).AnyTimes()
+			cs.PrepareMockRepoTransfer(mockRepoTransfer)
+			cs.PrepareMockRisk(mockRisk)
+
+			app := &appImpl{
+				generate:     mockGenerate,
+				repoTransfer: mockRepoTransfer,
+				risk:         mockRisk,
+			}
+
+			data, err := app.assessRisk(cs.InputCtx, wrapperExample)
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestHoldForReview(t *testing.T) {
+	var (
+		transferExample = model.Transfer{
+			OriginAccountID: "origin_account_id",
+			TargetAccountID: "target_account_id",
+			Amount:          500,
+			SourceCurrency:  "BRL",
+			TargetAmount:    500,
+			TargetCurrency:  "BRL",
+			ExchangeRate:    "1",
+			Fee:             50,
+		}
+		assessmentExample = risk.Assessment{Decision: risk.DecisionReview, Score: 50, Reasons: []string{risk.ReasonBurst}}
+		reviewExample     = model.TransferReview{
+			OriginAccountID: "origin_account_id",
+			TargetAccountID: "target_account_id",
+			Amount:          500,
+			SourceCurrency:  "BRL",
+			TargetAmount:    500,
+			TargetCurrency:  "BRL",
+			ExchangeRate:    "1",
+			Reserved:        550,
+			Score:           50,
+			Reasons:         []string{risk.ReasonBurst},
+		}
+		genDataExample = model.GeneratedData{ID: "review_id"}
+	)
+
+	cases := map[string]struct {
+		ExpectedData           *model.GeneratedData
+		ExpectedError          error
+		PrepareMockRepoAccount func(mock *account.MockRepository)
+		PrepareMockRepoReview  func(mock *review.MockRepository)
+	}{
+		"should return success": {
+			ExpectedData:  &genDataExample,
+			ExpectedError: nil,
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().Reserve(gomock.Any(), "origin_account_id", int64(550)).Return(true, nil)
+			},
+			PrepareMockRepoReview: func(mock *review.MockRepository) {
+				mock.EXPECT().Create(gomock.Any(), reviewExample).Return(&genDataExample, nil)
+			},
+		},
+		"should return error: insufficient funds": {
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrInsufficientFunds,
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().Reserve(gomock.Any(), "origin_account_id", int64(550)).Return(false, nil)
+			},
+			PrepareMockRepoReview: func(mock *review.MockRepository) {},
+		},
+		"should return error": {
+			ExpectedData:  nil,
+			ExpectedError: errors.New("fail"),
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().Reserve(gomock.Any(), "origin_account_id", int64(550)).Return(true, nil)
+			},
+			PrepareMockRepoReview: func(mock *review.MockRepository) {
+				mock.EXPECT().Create(gomock.Any(), reviewExample).Return(nil, errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl            = gomock.NewController(t)
+				mockRepoAccount = account.NewMockRepository(ctrl)
+				mockRepoReview  = review.NewMockRepository(ctrl)
+			)
+
+			cs.PrepareMockRepoAccount(mockRepoAccount)
+			cs.PrepareMockRepoReview(mockRepoReview)
+
+			app := &appImpl{
+				logger:      logger.New(""),
+				repoAccount: mockRepoAccount,
+				repoReview:  mockRepoReview,
+			}
+
+			data, err := app.holdForReview(context.Background(), transferExample, assessmentExample)
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	cases := map[string]struct {
+		InputAmount      int64
+		InputFrom        string
+		InputTo          string
+		ExpectedData     model.Transfer
+		ExpectedError    error
+		PrepareMockRates func(mock *exchange.MockRates)
+	}{
+		"should return success: same currency": {
+			InputAmount: 1000,
+			InputFrom:   "BRL",
+			InputTo:     "BRL",
+			ExpectedData: model.Transfer{
+				Amount:         1000,
+				SourceCurrency: "BRL",
+				TargetAmount:   1000,
+				TargetCurrency: "BRL",
+				ExchangeRate:   "1",
+			},
+			ExpectedError:    nil,
+			PrepareMockRates: func(mock *exchange.MockRates) {},
+		},
+		"should return success: rounding half up": {
+			InputAmount: 1001,
+			InputFrom:   "USD",
+			InputTo:     "BRL",
+			ExpectedData: model.Transfer{
+				Amount:         1001,
+				SourceCurrency: "USD",
+				TargetAmount:   5010,
+				TargetCurrency: "BRL",
+				ExchangeRate:   "5.005",
+			},
+			ExpectedError: nil,
+			PrepareMockRates: func(mock *exchange.MockRates) {
+				mock.EXPECT().Rate(gomock.Any(), "USD", "BRL").Return(big.NewRat(1001, 200), nil)
+			},
+		},
+		"should return success: currencies with different minor units": {
+			InputAmount: 1000,
+			InputFrom:   "JPY",
+			InputTo:     "BRL",
+			ExpectedData: model.Transfer{
+				Amount:         1000,
+				SourceCurrency: "JPY",
+				TargetAmount:   3500,
+				TargetCurrency: "BRL",
+				ExchangeRate:   "0.035",
+			},
+			ExpectedError: nil,
+			PrepareMockRates: func(mock *exchange.MockRates) {
+				mock.EXPECT().Rate(gomock.Any(), "JPY", "BRL").Return(big.NewRat(35, 1000), nil)
+			},
+		},
+		"should return error: rate not found": {
+			InputAmount:   1000,
+			InputFrom:     "USD",
+			InputTo:       "BRL",
+			ExpectedData:  model.Transfer{Amount: 1000, SourceCurrency: "USD", TargetCurrency: "BRL"},
+			ExpectedError: pkgerror.ErrExchangeRateNotFound,
+			PrepareMockRates: func(mock *exchange.MockRates) {
+				mock.EXPECT().Rate(gomock.Any(), "USD", "BRL").Return(nil, exchange.ErrRateNotFound)
+			},
+		},
+		"should return error: amount too small": {
+			InputAmount:   1,
+			InputFrom:     "BRL",
+			InputTo:       "JPY",
+			ExpectedData:  model.Transfer{Amount: 1, SourceCurrency: "BRL", TargetCurrency: "JPY"},
+			ExpectedError: pkgerror.ErrTransferAmountTooSmall,
+			PrepareMockRates: func(mock *exchange.MockRates) {
+				mock.EXPECT().Rate(gomock.Any(), "BRL", "JPY").Return(big.NewRat(28, 1), nil)
+			},
+		},
+		"should return error on rate": {
+			InputAmount:   1000,
+			InputFrom:     "USD",
+			InputTo:       "BRL",
+			ExpectedData:  model.Transfer{Amount: 1000, SourceCurrency: "USD", TargetCurrency: "BRL"},
+			ExpectedError: pkgerror.ErrCantCreateTransfer,
+			PrepareMockRates: func(mock *exchange.MockRates) {
+				mock.EXPECT().Rate(gomock.Any(), "USD", "BRL").Return(nil, errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx = gomock.WithContext(context.Background(), t)
+				mockRates = exchange.NewMockRates(ctrl)
+			)
+
+			cs.PrepareMockRates(mockRates)
+
+			app := &appImpl{
+				logger: logger.New(""),
+				rates:  mockRates,
+			}
+
+			data := model.Transfer{Amount: cs.InputAmount}
+			err := app.convert(ctx, &data, cs.InputFrom, cs.InputTo)
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestMakeTransfer(t *testing.T) {
+	var (
+		accountOrigin = model.Account{ID: "origin_account_id", Currency: "BRL"}
+		accountTarget = model.Account{ID: "target_account_id", Currency: "USD"}
+		accountFee    = model.Account{ID: "fee_account_id", Currency: "BRL"}
+		genData       = model.GeneratedData{ID: "transfer_id"}
+	)
+	cases := map[string]struct {
+		InputData       model.Transfer
+		InputTarget     model.Account
+		ExpectedEntries int
+	}{
+		"should balance each currency: same currency": {
+			InputData: model.Transfer{
+				OriginAccountID: accountOrigin.ID,
+				TargetAccountID: "brl_target_account_id",
+				Amount:          500,
+				SourceCurrency:  "BRL",
+				TargetAmount:    500,
+				TargetCurrency:  "BRL",
+			},
+			InputTarget:     model.Account{ID: "brl_target_account_id", Currency: "BRL"},
+			ExpectedEntries: 2,
+		},
+		"should balance each currency: converting currencies": {
+			InputData: model.Transfer{
+				OriginAccountID: accountOrigin.ID,
+				TargetAccountID: accountTarget.ID,
+				Amount:          500,
+				SourceCurrency:  "BRL",
+				TargetAmount:    100,
+				TargetCurrency:  "USD",
+			},
+			InputTarget:     accountTarget,
+			ExpectedEntries: 4,
+		},
+		"should balance each currency: converting currencies with fees": {
+			InputData: model.Transfer{
+				OriginAccountID: accountOrigin.ID,
+				TargetAccountID: accountTarget.ID,
+				Amount:          500,
+				SourceCurrency:  "BRL",
+				TargetAmount:    100,
+				TargetCurrency:  "USD",
+				Fee:             50,
+				Fees:            []model.TransferFee{{Rule: "pix", Amount: 50}},
+			},
+			InputTarget:     accountTarget,
+			ExpectedEntries: 6,
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx        = gomock.WithContext(context.Background(), t)
+				mockRepoAccount  = account.NewMockRepository(ctrl)
+				mockRepoTransfer = transfer.NewMockRepository(ctrl)
+				mockRepoLedger   = ledger.NewMockRepository(ctrl)
+				app              = &appImpl{
+					logger:       logger.New(""),
+					repoAccount:  mockRepoAccount,
+					repoTransfer: mockRepoTransfer,
+					repoLedger:   mockRepoLedger,
+					feeAccountID: accountFee.ID,
+				}
+				currencies = map[string]string{
+					accountOrigin.ID:  accountOrigin.Currency,
+					cs.InputTarget.ID: cs.InputTarget.Currency,
+					accountFee.ID:     accountFee.Currency,
+				}
+				entries []model.LedgerEntry
+			)
+
+			mockRepoAccount.EXPECT().Debit(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
+			mockRepoAccount.EXPECT().Credit(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			mockRepoTransfer.EXPECT().Create(gomock.Any(), cs.InputData).Return(&genData, nil)
+			mockRepoTransfer.EXPECT().CreateFees(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			mockRepoLedger.EXPECT().
+				Create(gomock.Any(), gomock.Any()).
+				Do(func(_ context.Context, created []model.LedgerEntry) {
+					entries = created
+				})
+
+			_, err := app.makeTransfer(ctx, transferWrapper{
+				Transfer:      cs.InputData,
+				AccountOrigin: &accountOrigin,
+				AccountTarget: &cs.InputTarget,
+			})
+
+			assert.NoError(t, err)
+			assert.Len(t, entries, cs.ExpectedEntries)
+			sums := make(map[string]int64)
+			for _, entry := range entries {
+				currency := entry.Currency
+				if entry.AccountID != "" {
+					currency = currencies[entry.AccountID]
+				}
+				if entry.Type == model.LedgerEntryTypeDebit {
+					sums[currency] -= entry.Amount
+				} else {
+					sums[currency] += entry.Amount
+				}
+			}
+			for currency, sum := range sums {
+				assert.Zero(t, sum, currency)
+			}
+		})
+	}
+}
+
+func TestApplyFees(t *testing.T) {
+	feesExample, _ := fee.NewEngine(fee.Config{
+		Currency: "BRL",
+		Rules:    []fee.Rule{{Name: "pix", Type: fee.RuleTypeFlat, Amount: 50}},
+	})
+
+	cases := map[string]struct {
+		InputData   model.Transfer
+		InputFees   fee.Engine
+		ExpectedFee int64
+	}{
+		"should charge nothing: no fee schedule": {
+			InputData:   model.Transfer{OriginAccountID: "origin_account_id", Amount: 500, SourceCurrency: "BRL"},
+			InputFees:   nil,
+			ExpectedFee: 0,
+		},
+		"should charge nothing: sent by the fee account": {
+			InputData:   model.Transfer{OriginAccountID: "fee_account_id", Amount: 500, SourceCurrency: "BRL"},
+			InputFees:   feesExample,
+			ExpectedFee: 0,
+		},
+		"should charge nothing: other currency": {
+			InputData:   model.Transfer{OriginAccountID: "origin_account_id", Amount: 500, SourceCurrency: "USD"},
+			InputFees:   feesExample,
+			ExpectedFee: 0,
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			app := &appImpl{
+				fees:         cs.InputFees,
+				feeAccountID: "fee_account_id",
+			}
+
+			data := cs.InputData
+			err := app.applyFees(context.Background(), &data)
+
+			assert.NoError(t, err)
+			assert.Equal(t, cs.ExpectedFee, data.Fee)
+			assert.Empty(t, data.Fees)
+		})
+	}
+}
+
+func TestExceededLimit(t *testing.T) {
+	limits := model.TransferLimits{PerTransfer: 500, Daily: 1000, Monthly: 3000, DailyCount: 3}
+
+	cases := map[string]struct {
+		InputUsage    model.TransferUsage
+		InputAmount   int64
+		ExpectedError error
+	}{
+		"should return success": {
+			InputUsage:    model.TransferUsage{Daily: 500, Monthly: 2500, DailyCount: 2},
+			InputAmount:   500,
+			ExpectedError: nil,
+		},
+		"should return error: per transfer": {
+			InputUsage:    model.TransferUsage{},
+			InputAmount:   501,
+			ExpectedError: &pkgerror.TransferLimitError{Limit: model.LimitPerTransfer, Remaining: 500},
+		},
+		"should return error: daily": {
+			InputUsage:    model.TransferUsage{Daily: 800, Monthly: 800, DailyCount: 1},
+			InputAmount:   300,
+			ExpectedError: &pkgerror.TransferLimitError{Limit: model.LimitDaily, Remaining: 200},
+		},
+		"should return error: monthly": {
+			InputUsage:    model.TransferUsage{Monthly: 2900},
+			InputAmount:   200,
+			ExpectedError: &pkgerror.TransferLimitError{Limit: model.LimitMonthly, Remaining: 100},
+		},
+		"should return error: daily count": {
+			InputUsage:    model.TransferUsage{Daily: 30, Monthly: 30, DailyCount: 3},
+			InputAmount:   10,
+			ExpectedError: &pkgerror.TransferLimitError{Limit: model.LimitDailyCount, Remaining: 0},
+		},
+		"should return error: limit lowered below usage": {
+			InputUsage:    model.TransferUsage{Daily: 1200, Monthly: 1200, DailyCount: 1},
+			InputAmount:   10,
+			ExpectedError: &pkgerror.TransferLimitError{Limit: model.LimitDaily, Remaining: 0},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, cs.ExpectedError, exceededLimit(limits, cs.InputUsage, cs.InputAmount))
+		})
+	}
+}
@@ -0,0 +1,198 @@
+//go:generate mockgen -source=${GOFILE} -package=${GOPACKAGE} -destination=${GOPACKAGE}_mock.go
+
+package twofactor
+
+import (
+	"context"
+	pkgerror "github.com/carlosrodriguesf/bank-api/pkg/error"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/account"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/twofactor"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/cache"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/generate"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/totp"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/transaction"
+)
+
+const (
+	cacheKeyUsedStep   = "two-factor:used:%s:%d"
+	recoveryCodesCount = 10
+)
+
+type (
+	Options struct {
+		Logger        logger.Logger
+		Cache         cache.Cache
+		Generate      generate.Generate
+		TxManager     transaction.Manager
+		TOTP          totp.TOTP
+		RepoAccount   account.Repository
+		RepoTwoFactor twofactor.Repository
+	}
+	App interface {
+		Enroll(ctx context.Context, accountID string) (*model.TwoFactorEnrollment, error)
+		Confirm(ctx context.Context, accountID string, code string) (*model.TwoFactorConfirmation, error)
+		Verify(ctx context.Context, accountID string, code string) error
+	}
+	appImpl struct {
+		logger        logger.Logger
+		cache         cache.Cache
+		generate      generate.Generate
+		txManager     transaction.Manager
+		totp          totp.TOTP
+		repoAccount   account.Repository
+		repoTwoFactor twofactor.Repository
+	}
+)
+
+func NewApp(opts Options) App {
+	return &appImpl{
+		logger:        opts.Logger.WithLocation().WithPreffix("app.twofactor"),
+		cache:         opts.Cache,
+		generate:      opts.Generate,
+		txManager:     opts.TxManager,
+		totp:          opts.TOTP,
+		repoAccount:   opts.RepoAccount,
+		repoTwoFactor: opts.RepoTwoFactor,
+	}
+}
+
+// Enroll creates a new TOTP secret for the account, replacing one that was never confirmed. The secret is only
+// enforced after Confirm.
+func (a *appImpl) Enroll(ctx context.Context, accountID string) (*model.TwoFactorEnrollment, error) {
+	acc, err := a.repoAccount.GetByIDOrDocument(ctx, accountID)
+	if err != nil {
+		a.logger.Error(err)
+		return nil, pkgerror.ErrCantEnrollTwoFactor
+	}
+	if acc == nil {
+		return nil, pkgerror.ErrAccountNotFound
+	}
+
+	secret, err := a.totp.GenerateSecret()
+	if err != nil {
+		a.logger.Error(err)
+		return nil, pkgerror.ErrCantEnrollTwoFactor
+	}
+
+	saved, err := a.repoTwoFactor.Save(ctx, model.TwoFactor{AccountID: acc.ID, Secret: secret})
+	if err != nil {
+		a.logger.Error(err)
+		return nil, pkgerror.ErrCantEnrollTwoFactor
+	}
+	if !saved {
+		return nil, pkgerror.ErrTwoFactorAlreadyEnabled
+	}
+
+	return &model.TwoFactorEnrollment{
+		Secret: secret,
+		URI:    a.totp.URI(secret, acc.Document),
+	}, nil
+}
+
+// Confirm enables the pending secret of the account once it is proven with a code, returning the recovery codes.
+// They are only shown here, the service keeps just their hashes.
+func (a *appImpl) Confirm(ctx context.Context, accountID string, code string) (*model.TwoFactorConfirmation, error) {
+	twoFactor, err := a.repoTwoFactor.Get(ctx, accountID)
+	if err != nil {
+		a.logger.Error(err)
+		return nil, pkgerror.ErrCantConfirmTwoFactor
+	}
+	if twoFactor == nil {
+		return nil, pkgerror.ErrTwoFactorNotEnrolled
+	}
+	if twoFactor.ConfirmedAt != nil {
+		return nil, pkgerror.ErrTwoFactorAlreadyEnabled
+	}
+	if err = a.checkCode(ctx, *twoFactor, code); err != nil {
+		if err == pkgerror.ErrInvalidTwoFactorCode {
+			return nil, err
+		}
+		return nil, pkgerror.ErrCantConfirmTwoFactor
+	}
+
+	codes, err := generateRecoveryCodes(recoveryCodesCount)
+	if err != nil {
+		a.logger.Error(err)
+		return nil, pkgerror.ErrCantConfirmTwoFactor
+	}
+	recoveryCodes := make([]model.TwoFactorRecoveryCode, len(codes))
+	for i, c := range codes {
+		recoveryCodes[i] = model.TwoFactorRecoveryCode{AccountID: accountID, CodeHash: hashRecoveryCode(c)}
+	}
+
+	err = a.txManager.Execute(ctx, func(tx transaction.Transaction) error {
+		repo := a.repoTwoFactor.WithTransaction(tx)
+		confirmed, err := repo.Confirm(ctx, accountID)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return pkgerror.ErrTwoFactorAlreadyEnabled
+		}
+		return repo.CreateRecoveryCodes(ctx, recoveryCodes)
+	})
+	if err != nil {
+		if err == pkgerror.ErrTwoFactorAlreadyEnabled {
+			return nil, err
+		}
+		a.logger.Error(err)
+		return nil, pkgerror.ErrCantConfirmTwoFactor
+	}
+
+	return &model.TwoFactorConfirmation{RecoveryCodes: codes}, nil
+}
+
+// Verify checks the second factor of the account, accepting either a TOTP code or an unused recovery code. Accounts
+// without a confirmed secret pass without a code.
+func (a *appImpl) Verify(ctx context.Context, accountID string, code string) error {
+	twoFactor, err := a.repoTwoFactor.Get(ctx, accountID)
+	if err != nil {
+		a.logger.Error(err)
+		return pkgerror.ErrCantVerifyTwoFactor
+	}
+	if twoFactor == nil || twoFactor.ConfirmedAt == nil {
+		return nil
+	}
+	if code == "" {
+		return pkgerror.ErrTwoFactorRequired
+	}
+
+	err = a.checkCode(ctx, *twoFactor, code)
+	if err == nil {
+		return nil
+	}
+	if err != pkgerror.ErrInvalidTwoFactorCode {
+		return pkgerror.ErrCantVerifyTwoFactor
+	}
+
+	used, err := a.repoTwoFactor.UseRecoveryCode(ctx, accountID, hashRecoveryCode(code))
+	if err != nil {
+		a.logger.Error(err)
+		return pkgerror.ErrCantVerifyTwoFactor
+	}
+	if !used {
+		return pkgerror.ErrInvalidTwoFactorCode
+	}
+	return nil
+}
+
+// checkCode validates a TOTP code and burns its time step, so the same code is refused if presented again while
+// still inside the accepted window.
+func (a *appImpl) checkCode(ctx context.Context, twoFactor model.TwoFactor, code string) error {
+	step, valid := a.totp.Validate(twoFactor.Secret, code, a.generate.CurrentTime())
+	if !valid {
+		return pkgerror.ErrInvalidTwoFactorCode
+	}
+
+	firstUse, err := a.cache.SetIfNotExists(ctx, getUsedStepCacheKey(twoFactor.AccountID, step), true, a.totp.Window())
+	if err != nil {
+		a.logger.Error(err)
+		return err
+	}
+	if !firstUse {
+		return pkgerror.ErrInvalidTwoFactorCode
+	}
+	return nil
+}
@@ -0,0 +1,410 @@
+package twofactor
+
+import (
+	"context"
+	"errors"
+	pkgerror "github.com/carlosrodriguesf/bank-api/pkg/error"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/account"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/twofactor"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/cache"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/generate"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/totp"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/transaction"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestEnroll(t *testing.T) {
+	accountExample := model.Account{ID: "account_id", Document: "12312312312"}
+
+	cases := map[string]struct {
+		ExpectedData           *model.TwoFactorEnrollment
+		ExpectedError          error
+		PrepareMockRepoAccount func(mock *account.MockRepository)
+		PrepareMockRepository  func(mock *twofactor.MockRepository)
+		PrepareMockTOTP        func(mock *totp.MockTOTP)
+	}{
+		"should return success": {
+			ExpectedData:  &model.TwoFactorEnrollment{Secret: "secret", URI: "otpauth://totp/uri"},
+			ExpectedError: nil,
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), "account_id").Return(&accountExample, nil)
+			},
+			PrepareMockRepository: func(mock *twofactor.MockRepository) {
+				mock.EXPECT().
+					Save(gomock.Any(), model.TwoFactor{AccountID: "account_id", Secret: "secret"}).
+					Return(true, nil)
+			},
+			PrepareMockTOTP: func(mock *totp.MockTOTP) {
+				mock.EXPECT().GenerateSecret().Return("secret", nil)
+				mock.EXPECT().URI("secret", accountExample.Document).Return("otpauth://totp/uri")
+			},
+		},
+		"should return error: already enabled": {
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrTwoFactorAlreadyEnabled,
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), "account_id").Return(&accountExample, nil)
+			},
+			PrepareMockRepository: func(mock *twofactor.MockRepository) {
+				mock.EXPECT().
+					Save(gomock.Any(), model.TwoFactor{AccountID: "account_id", Secret: "secret"}).
+					Return(false, nil)
+			},
+			PrepareMockTOTP: func(mock *totp.MockTOTP) {
+				mock.EXPECT().GenerateSecret().Return("secret", nil)
+			},
+		},
+		"should return error: account not found": {
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrAccountNotFound,
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), "account_id").Return(nil, nil)
+			},
+			PrepareMockRepository: func(mock *twofactor.MockRepository) {},
+			PrepareMockTOTP:       func(mock *totp.MockTOTP) {},
+		},
+		"should return error on save": {
+			ExpectedData:  nil,
+			ExpectedError: pkgerror.ErrCantEnrollTwoFactor,
+			PrepareMockRepoAccount: func(mock *account.MockRepository) {
+				mock.EXPECT().GetByIDOrDocument(gomock.Any(), "account_id").Return(&accountExample, nil)
+			},
+			PrepareMockRepository: func(mock *twofactor.MockRepository) {
+				mock.EXPECT().
+					Save(gomock.Any(), model.TwoFactor{AccountID: "account_id", Secret: "secret"}).
+					Return(false, errors.New("fail"))
+			},
+			PrepareMockTOTP: func(mock *totp.MockTOTP) {
+				mock.EXPECT().GenerateSecret().Return("secret", nil)
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx       = gomock.WithContext(context.Background(), t)
+				mockRepoAccount = account.NewMockRepository(ctrl)
+				mockRepository  = twofactor.NewMockRepository(ctrl)
+				mockTOTP        = totp.NewMockTOTP(ctrl)
+			)
+
+			cs.PrepareMockRepoAccount(mockRepoAccount)
+			cs.PrepareMockRepository(mockRepository)
+			cs.PrepareMockTOTP(mockTOTP)
+
+			app := NewApp(Options{
+				Logger:        logger.New(""),
+				TOTP:          mockTOTP,
+				RepoAccount:   mockRepoAccount,
+				RepoTwoFactor: mockRepository,
+			})
+
+			data, err := app.Enroll(ctx, "account_id")
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	var (
+		currentTime      = time.Now()
+		pendingExample   = model.TwoFactor{AccountID: "account_id", Secret: "secret"}
+		confirmedExample = model.TwoFactor{AccountID: "account_id", Secret: "secret", ConfirmedAt: &currentTime}
+		executeWith      = func(tx transaction.Transaction) func(context.Context, func(transaction.Transaction) error) error {
+			return func(_ context.Context, fn func(transaction.Transaction) error) error {
+				return fn(tx)
+			}
+		}
+		expectValidCode = func(mock *totp.MockTOTP, cacheMock *cache.MockCache) {
+			mock.EXPECT().Validate("secret", "123456", currentTime).Return(int64(42), true)
+			mock.EXPECT().Window().Return(90 * time.Second)
+			cacheMock.EXPECT().
+				SetIfNotExists(gomock.Any(), getUsedStepCacheKey("account_id", 42), true, 90*time.Second).
+				Return(true, nil)
+		}
+	)
+
+	cases := map[string]struct {
+		ExpectedCodes         bool
+		ExpectedError         error
+		PrepareMockRepository func(mock *twofactor.MockRepository, tx transaction.Transaction)
+		PrepareMockTxManager  func(mock *transaction.MockManager, tx transaction.Transaction)
+		PrepareMockTOTP       func(mock *totp.MockTOTP, cacheMock *cache.MockCache)
+	}{
+		"should return success": {
+			ExpectedCodes: true,
+			ExpectedError: nil,
+			PrepareMockRepository: func(mock *twofactor.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().Get(gomock.Any(), "account_id").Return(&pendingExample, nil)
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().Confirm(gomock.Any(), "account_id").Return(true, nil)
+				mock.EXPECT().
+					CreateRecoveryCodes(gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, codes []model.TwoFactorRecoveryCode) {
+						assert.Len(t, codes, recoveryCodesCount)
+					})
+			},
+			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {
+				mock.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(executeWith(tx))
+			},
+			PrepareMockTOTP: expectValidCode,
+		},
+		"should return error: not enrolled": {
+			ExpectedError: pkgerror.ErrTwoFactorNotEnrolled,
+			PrepareMockRepository: func(mock *twofactor.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().Get(gomock.Any(), "account_id").Return(nil, nil)
+			},
+			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {},
+			PrepareMockTOTP:      func(mock *totp.MockTOTP, cacheMock *cache.MockCache) {},
+		},
+		"should return error: already enabled": {
+			ExpectedError: pkgerror.ErrTwoFactorAlreadyEnabled,
+			PrepareMockRepository: func(mock *twofactor.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().Get(gomock.Any(), "account_id").Return(&confirmedExample, nil)
+			},
+			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {},
+			PrepareMockTOTP:      func(mock *totp.MockTOTP, cacheMock *cache.MockCache) {},
+		},
+		"should return error: invalid code": {
+			ExpectedError: pkgerror.ErrInvalidTwoFactorCode,
+			PrepareMockRepository: func(mock *twofactor.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().Get(gomock.Any(), "account_id").Return(&pendingExample, nil)
+			},
+			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {},
+			PrepareMockTOTP: func(mock *totp.MockTOTP, cacheMock *cache.MockCache) {
+				mock.EXPECT().Validate("secret", "123456", currentTime).Return(int64(0), false)
+			},
+		},
+		"should return error: confirmed meanwhile": {
+			ExpectedError: pkgerror.ErrTwoFactorAlreadyEnabled,
+			PrepareMockRepository: func(mock *twofactor.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().Get(gomock.Any(), "account_id").Return(&pendingExample, nil)
+				mock.EXPECT().WithTransaction(tx).Return(mock)
+				mock.EXPECT().Confirm(gomock.Any(), "account_id").Return(false, nil)
+			},
+			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {
+				mock.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(executeWith(tx))
+			},
+			PrepareMockTOTP: expectValidCode,
+		},
+		"should return error on transaction": {
+			ExpectedError: pkgerror.ErrCantConfirmTwoFactor,
+			PrepareMockRepository: func(mock *twofactor.MockRepository, tx transaction.Transaction) {
+				mock.EXPECT().Get(gomock.Any(), "account_id").Return(&pendingExample, nil)
+			},
+			PrepareMockTxManager: func(mock *transaction.MockManager, tx transaction.Transaction) {
+				mock.EXPECT().Execute(gomock.Any(), gomock.Any()).Return(errors.New("fail"))
+			},
+			PrepareMockTOTP: expectValidCode,
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx      = gomock.WithContext(context.Background(), t)
+				mockCache      = cache.NewMockCache(ctrl)
+				mockGenerate   = generate.NewMockGenerate(ctrl)
+				mockTxManager  = transaction.NewMockManager(ctrl)
+				mockRepository = twofactor.NewMockRepository(ctrl)
+				mockTOTP       = totp.NewMockTOTP(ctrl)
+				txExample      = transaction.Transaction(nil)
+			)
+
+			cs.PrepareMockRepository(mockRepository, txExample)
+			cs.PrepareMockTxManager(mockTxManager, txExample)
+			cs.PrepareMockTOTP(mockTOTP, mockCache)
+
+			mockGenerate.EXPECT().CurrentTime().AnyTimes().Return(currentTime)
+
+			app := NewApp(Options{
+				Logger:        logger.New(""),
+				Cache:         mockCache,
+				Generate:      mockGenerate,
+				TxManager:     mockTxManager,
+				TOTP:          mockTOTP,
+				RepoTwoFactor: mockRepository,
+			})
+
+			data, err := app.Confirm(ctx, "account_id", "123456")
+
+			assert.Equal(t, cs.ExpectedError, err)
+			if !cs.ExpectedCodes {
+				assert.Nil(t, data)
+				return
+			}
+			assert.Len(t, data.RecoveryCodes, recoveryCodesCount)
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	var (
+		currentTime      = time.Now()
+		pendingExample   = model.TwoFactor{AccountID: "account_id", Secret: "secret"}
+		confirmedExample = model.TwoFactor{AccountID: "account_id", Secret: "secret", ConfirmedAt: &currentTime}
+	)
+
+	cases := map[string]struct {
+		InputCode             string
+		ExpectedError         error
+		PrepareMockRepository func(mock *twofactor.MockRepository)
+		PrepareMockTOTP       func(mock *totp.MockTOTP)
+		PrepareMockCache      func(mock *cache.MockCache)
+	}{
+		"should return success with code": {
+			InputCode:     "123456",
+			ExpectedError: nil,
+			PrepareMockRepository: func(mock *twofactor.MockRepository) {
+				mock.EXPECT().Get(gomock.Any(), "account_id").Return(&confirmedExample, nil)
+			},
+			PrepareMockTOTP: func(mock *totp.MockTOTP) {
+				mock.EXPECT().Validate("secret", "123456", currentTime).Return(int64(42), true)
+				mock.EXPECT().Window().Return(90 * time.Second)
+			},
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().
+					SetIfNotExists(gomock.Any(), getUsedStepCacheKey("account_id", 42), true, 90*time.Second).
+					Return(true, nil)
+			},
+		},
+		"should return success with recovery code": {
+			InputCode:     "ABCDE-FGHJK",
+			ExpectedError: nil,
+			PrepareMockRepository: func(mock *twofactor.MockRepository) {
+				mock.EXPECT().Get(gomock.Any(), "account_id").Return(&confirmedExample, nil)
+				mock.EXPECT().UseRecoveryCode(gomock.Any(), "account_id", hashRecoveryCode("abcdefghjk")).Return(true, nil)
+			},
+			PrepareMockTOTP: func(mock *totp.MockTOTP) {
+				mock.EXPECT().Validate("secret", "ABCDE-FGHJK", currentTime).Return(int64(0), false)
+			},
+			PrepareMockCache: func(mock *cache.MockCache) {},
+		},
+		"should return success: not enrolled": {
+			InputCode:     "",
+			ExpectedError: nil,
+			PrepareMockRepository: func(mock *twofactor.MockRepository) {
+				mock.EXPECT().Get(gomock.Any(), "account_id").Return(nil, nil)
+			},
+			PrepareMockTOTP:  func(mock *totp.MockTOTP) {},
+			PrepareMockCache: func(mock *cache.MockCache) {},
+		},
+		"should return success: not confirmed": {
+			InputCode:     "",
+			ExpectedError: nil,
+			PrepareMockRepository: func(mock *twofactor.MockRepository) {
+				mock.EXPECT().Get(gomock.Any(), "account_id").Return(&pendingExample, nil)
+			},
+			PrepareMockTOTP:  func(mock *totp.MockTOTP) {},
+			PrepareMockCache: func(mock *cache.MockCache) {},
+		},
+		"should return error: code required": {
+			InputCode:     "",
+			ExpectedError: pkgerror.ErrTwoFactorRequired,
+			PrepareMockRepository: func(mock *twofactor.MockRepository) {
+				mock.EXPECT().Get(gomock.Any(), "account_id").Return(&confirmedExample, nil)
+			},
+			PrepareMockTOTP:  func(mock *totp.MockTOTP) {},
+			PrepareMockCache: func(mock *cache.MockCache) {},
+		},
+		"should return error: code replayed": {
+			InputCode:     "123456",
+			ExpectedError: pkgerror.ErrInvalidTwoFactorCode,
+			PrepareMockRepository: func(mock *twofactor.MockRepository) {
+				mock.EXPECT().Get(gomock.Any(), "account_id").Return(&confirmedExample, nil)
+				mock.EXPECT().UseRecoveryCode(gomock.Any(), "account_id", hashRecoveryCode("123456")).Return(false, nil)
+			},
+			PrepareMockTOTP: func(mock *totp.MockTOTP) {
+				mock.EXPECT().Validate("secret", "123456", currentTime).Return(int64(42), true)
+				mock.EXPECT().Window().Return(90 * time.Second)
+			},
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().
+					SetIfNotExists(gomock.Any(), getUsedStepCacheKey("account_id", 42), true, 90*time.Second).
+					Return(false, nil)
+			},
+		},
+		"should return error on burn step": {
+			InputCode:     "123456",
+			ExpectedError: pkgerror.ErrCantVerifyTwoFactor,
+			PrepareMockRepository: func(mock *twofactor.MockRepository) {
+				mock.EXPECT().Get(gomock.Any(), "account_id").Return(&confirmedExample, nil)
+			},
+			PrepareMockTOTP: func(mock *totp.MockTOTP) {
+				mock.EXPECT().Validate("secret", "123456", currentTime).Return(int64(42), true)
+				mock.EXPECT().Window().Return(90 * time.Second)
+			},
+			PrepareMockCache: func(mock *cache.MockCache) {
+				mock.EXPECT().
+					SetIfNotExists(gomock.Any(), getUsedStepCacheKey("account_id", 42), true, 90*time.Second).
+					Return(false, errors.New("fail"))
+			},
+		},
+		"should return error on get": {
+			InputCode:     "123456",
+			ExpectedError: pkgerror.ErrCantVerifyTwoFactor,
+			PrepareMockRepository: func(mock *twofactor.MockRepository) {
+				mock.EXPECT().Get(gomock.Any(), "account_id").Return(nil, errors.New("fail"))
+			},
+			PrepareMockTOTP:  func(mock *totp.MockTOTP) {},
+			PrepareMockCache: func(mock *cache.MockCache) {},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctrl, ctx      = gomock.WithContext(context.Background(), t)
+				mockCache      = cache.NewMockCache(ctrl)
+				mockGenerate   = generate.NewMockGenerate(ctrl)
+				mockRepository = twofactor.NewMockRepository(ctrl)
+				mockTOTP       = totp.NewMockTOTP(ctrl)
+			)
+
+			cs.PrepareMockRepository(mockRepository)
+			cs.PrepareMockTOTP(mockTOTP)
+			cs.PrepareMockCache(mockCache)
+
+			mockGenerate.EXPECT().CurrentTime().AnyTimes().Return(currentTime)
+
+			app := NewApp(Options{
+				Logger:        logger.New(""),
+				Cache:         mockCache,
+				Generate:      mockGenerate,
+				TOTP:          mockTOTP,
+				RepoTwoFactor: mockRepository,
+			})
+
+			err := app.Verify(ctx, "account_id", cs.InputCode)
+
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, err := generateRecoveryCodes(recoveryCodesCount)
+	assert.NoError(t, err)
+	assert.Len(t, codes, recoveryCodesCount)
+
+	format := regexp.MustCompile("^[" + recoveryCodeAlphabet + "]{5}-[" + recoveryCodeAlphabet + "]{5}$")
+	for _, code := range codes {
+		assert.Regexp(t, format, code)
+	}
+	assert.NotEqual(t, codes[0], codes[1])
+}
+
+func TestHashRecoveryCode(t *testing.T) {
+	assert.Equal(t, hashRecoveryCode("abcde-fghjk"), hashRecoveryCode("ABCDE FGHJK"))
+	assert.Equal(t, hashRecoveryCode("abcde-fghjk"), hashRecoveryCode("abcdefghjk"))
+	assert.NotEqual(t, hashRecoveryCode("abcde-fghjk"), hashRecoveryCode("abcde-fghjm"))
+}
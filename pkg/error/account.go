@@ -8,5 +8,16 @@ var (
 	ErrCantListAccounts      = errors.New("account.cant-list-accounts")
 	ErrAccountNotFound       = errors.New("account.account-not-found")
 	ErrCantGetAccountBalance = errors.New("account.cant-get-balance")
+	ErrCantGetAccount        = errors.New("account.cant-get-account")
+	ErrCantLookupAccount     = errors.New("account.cant-lookup-account")
 	ErrInsufficientFunds     = errors.New("account.insufficient-funds")
+	ErrCantChangeStatus      = errors.New("account.cant-change-status")
+	ErrInvalidStatusChange   = errors.New("account.invalid-status-change")
+	ErrAccountHasBalance     = errors.New("account.has-balance")
+	ErrAccountHasReserved    = errors.New("account.has-reserved-funds")
+	ErrInvalidSweepTarget    = errors.New("account.invalid-sweep-target")
+	ErrCurrencyNotSupported  = errors.New("account.currency-not-supported")
+	ErrCantGetLimits         = errors.New("account.cant-get-limits")
+	ErrCantUpdateLimits      = errors.New("account.cant-update-limits")
+	ErrCantListDivergences   = errors.New("account.cant-list-ledger-divergences")
 )
@@ -0,0 +1,14 @@
+package errors
+
+import "errors"
+
+var (
+	ErrCantCreateAPIKey        = errors.New("api-key.cant-create")
+	ErrCantListAPIKeys         = errors.New("api-key.cant-list")
+	ErrCantRevokeAPIKey        = errors.New("api-key.cant-revoke")
+	ErrCantAuthenticateAPIKey  = errors.New("api-key.cant-authenticate")
+	ErrAPIKeyNotFound          = errors.New("api-key.not-found")
+	ErrInvalidAPIKey           = errors.New("api-key.invalid")
+	ErrInvalidAPIKeyScopes     = errors.New("api-key.invalid-scopes")
+	ErrInvalidAPIKeyExpiration = errors.New("api-key.invalid-expiration")
+)
@@ -5,6 +5,18 @@ import "errors"
 var (
 	ErrCantAuth           = errors.New("auth.cant-auth")
 	ErrInvalidCredentials = errors.New("auth.invalid-credentials")
+	ErrAccountClosed      = errors.New("auth.account-closed")
+	ErrAccountLocked      = errors.New("auth.account-locked")
+	ErrTooManyAttempts    = errors.New("auth.too-many-attempts")
 	ErrCantGetSession     = errors.New("auth.cant-get-session")
 	ErrSessionNotFound    = errors.New("auth.session-not-found")
+	ErrCantListSessions   = errors.New("auth.cant-list-sessions")
+	ErrCantRevokeSession  = errors.New("auth.cant-revoke-session")
+	ErrCantRefresh        = errors.New("auth.cant-refresh")
+	ErrInvalidRefresh     = errors.New("auth.invalid-refresh-token")
+	ErrRefreshReused      = errors.New("auth.refresh-token-reused")
+	ErrCantChangeSecret   = errors.New("auth.cant-change-secret")
+	ErrInvalidSecret      = errors.New("auth.invalid-current-secret")
+	ErrCantResetSecret    = errors.New("auth.cant-reset-secret")
+	ErrInvalidResetToken  = errors.New("auth.invalid-reset-token")
 )
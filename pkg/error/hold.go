@@ -0,0 +1,15 @@
+package errors
+
+import "errors"
+
+var (
+	ErrCantCreateHold       = errors.New("hold.cant-create-hold")
+	ErrCantListHolds        = errors.New("hold.cant-list-holds")
+	ErrCantCaptureHold      = errors.New("hold.cant-capture-hold")
+	ErrCantReleaseHold      = errors.New("hold.cant-release-hold")
+	ErrCantExpireHolds      = errors.New("hold.cant-expire-holds")
+	ErrHoldNotFound         = errors.New("hold.not-found")
+	ErrHoldNotActive        = errors.New("hold.not-active")
+	ErrInvalidHoldExpiry    = errors.New("hold.invalid-expiry")
+	ErrInvalidCaptureAmount = errors.New("hold.invalid-capture-amount")
+)
@@ -0,0 +1,16 @@
+package errors
+
+import "errors"
+
+var (
+	ErrCantCreateRecurringTransfer    = errors.New("recurring-transfer.cant-create-recurring-transfer")
+	ErrCantListRecurringTransfers     = errors.New("recurring-transfer.cant-list-recurring-transfers")
+	ErrCantListRecurringTransferRuns  = errors.New("recurring-transfer.cant-list-recurring-transfer-runs")
+	ErrCantCancelRecurringTransfer    = errors.New("recurring-transfer.cant-cancel-recurring-transfer")
+	ErrCantExecuteRecurringTransfers  = errors.New("recurring-transfer.cant-execute-recurring-transfers")
+	ErrRecurringTransferNotFound      = errors.New("recurring-transfer.not-found")
+	ErrRecurringTransferNotActive     = errors.New("recurring-transfer.not-active")
+	ErrRecurringTransferNotProcessing = errors.New("recurring-transfer.not-processing")
+	ErrRecurrenceStartAtInPast        = errors.New("recurring-transfer.start-at-in-past")
+	ErrRecurrenceEndAtBeforeStart     = errors.New("recurring-transfer.end-at-before-start-at")
+)
@@ -0,0 +1,14 @@
+package errors
+
+import "errors"
+
+var (
+	ErrCantCreateScheduledTransfer    = errors.New("scheduled-transfer.cant-create-scheduled-transfer")
+	ErrCantListScheduledTransfers     = errors.New("scheduled-transfer.cant-list-scheduled-transfers")
+	ErrCantCancelScheduledTransfer    = errors.New("scheduled-transfer.cant-cancel-scheduled-transfer")
+	ErrCantExecuteScheduledTransfers  = errors.New("scheduled-transfer.cant-execute-scheduled-transfers")
+	ErrScheduledTransferNotFound      = errors.New("scheduled-transfer.not-found")
+	ErrScheduledTransferNotPending    = errors.New("scheduled-transfer.not-pending")
+	ErrScheduledTransferNotProcessing = errors.New("scheduled-transfer.not-processing")
+	ErrScheduledForInPast             = errors.New("scheduled-transfer.scheduled-for-in-past")
+)
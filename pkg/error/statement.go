@@ -0,0 +1,8 @@
+package errors
+
+import "errors"
+
+var (
+	ErrCantExportStatement    = errors.New("statement.cant-export-statement")
+	ErrInvalidStatementPeriod = errors.New("statement.invalid-period")
+)
@@ -7,4 +7,37 @@ var (
 	ErrCantListTransfers             = errors.New("transfer.cant-list-transfer")
 	ErrOriginAccountTransferNotFound = errors.New("transfer.origin-not-found")
 	ErrTargetAccountTransferNotFound = errors.New("transfer.target-not-found")
+	ErrOriginAccountNotActive        = errors.New("transfer.origin-not-active")
+	ErrTargetAccountNotActive        = errors.New("transfer.target-not-active")
+	ErrCantReverseTransfer           = errors.New("transfer.cant-reverse-transfer")
+	ErrTransferNotFound              = errors.New("transfer.not-found")
+	ErrTransferIsReversal            = errors.New("transfer.transfer-is-reversal")
+	ErrTransferAlreadyReversed       = errors.New("transfer.already-reversed")
+	ErrInvalidReversalAmount         = errors.New("transfer.invalid-reversal-amount")
+	ErrExchangeRateNotFound          = errors.New("transfer.exchange-rate-not-found")
+	ErrTransferAmountTooSmall        = errors.New("transfer.amount-too-small")
+	ErrTransferLimitExceeded         = errors.New("transfer.limit-exceeded")
+	ErrTransferDenied                = errors.New("transfer.denied")
+	ErrCantListTransferReviews       = errors.New("transfer.cant-list-reviews")
+	ErrCantDecideTransferReview      = errors.New("transfer.cant-decide-review")
+	ErrTransferReviewNotFound        = errors.New("transfer.review-not-found")
+	ErrTransferReviewAlreadyDecided  = errors.New("transfer.review-already-decided")
 )
+
+// TransferLimitError is ErrTransferLimitExceeded with the limit the transfer went over and what is left of it.
+type TransferLimitError struct {
+	Limit     string `json:"limit"`
+	Remaining int64  `json:"remaining"`
+}
+
+func (e *TransferLimitError) Error() string {
+	return ErrTransferLimitExceeded.Error()
+}
+
+func (e *TransferLimitError) Unwrap() error {
+	return ErrTransferLimitExceeded
+}
+
+func (e *TransferLimitError) Detail() interface{} {
+	return e
+}
@@ -0,0 +1,13 @@
+package errors
+
+import "errors"
+
+var (
+	ErrCantEnrollTwoFactor     = errors.New("two-factor.cant-enroll")
+	ErrCantConfirmTwoFactor    = errors.New("two-factor.cant-confirm")
+	ErrCantVerifyTwoFactor     = errors.New("two-factor.cant-verify")
+	ErrTwoFactorAlreadyEnabled = errors.New("two-factor.already-enabled")
+	ErrTwoFactorNotEnrolled    = errors.New("two-factor.not-enrolled")
+	ErrTwoFactorRequired       = errors.New("two-factor.required")
+	ErrInvalidTwoFactorCode    = errors.New("two-factor.invalid-code")
+)
@@ -1,19 +1,30 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/carlosrodriguesf/bank-api/pkg/api"
 	apierror "github.com/carlosrodriguesf/bank-api/pkg/api/error"
 	"github.com/carlosrodriguesf/bank-api/pkg/api/middleware"
+	"github.com/carlosrodriguesf/bank-api/pkg/api/middleware/signature"
 	apimodel "github.com/carlosrodriguesf/bank-api/pkg/api/model"
 	"github.com/carlosrodriguesf/bank-api/pkg/api/swagger"
 	"github.com/carlosrodriguesf/bank-api/pkg/app"
+	"github.com/carlosrodriguesf/bank-api/pkg/app/auth"
 	"github.com/carlosrodriguesf/bank-api/pkg/repository"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/cache"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/closer"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/db"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/exchange"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/fee"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/jwt"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/notifier"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/risk"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/secret"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/totp"
+	"github.com/carlosrodriguesf/bank-api/pkg/worker"
 	"github.com/go-redis/redis/v8"
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
@@ -24,7 +35,9 @@ import (
 	emiddleware "github.com/labstack/echo/v4/middleware"
 	"os"
 	"runtime"
+	"strconv"
 	"strings"
+	"time"
 )
 
 func runMigrations(log logger.Logger) {
@@ -76,6 +89,184 @@ func startCache(log logger.Logger) (cache.Cache, error) {
 	return cache.NewRedisCache(redis.NewClient(opts)), nil
 }
 
+func startSecret(log logger.Logger) secret.Secret {
+	var (
+		opts = secret.Options{Algorithm: os.Getenv("SECRET_ALGORITHM")}
+		err  error
+	)
+	if opts.BcryptCost, err = getEnvInt("SECRET_BCRYPT_COST", 0); err != nil {
+		log.Fatal(err)
+	}
+	argon2Time, err := getEnvInt("SECRET_ARGON2_TIME", 32)
+	if err != nil {
+		log.Fatal(err)
+	}
+	argon2Memory, err := getEnvInt("SECRET_ARGON2_MEMORY", 32)
+	if err != nil {
+		log.Fatal(err)
+	}
+	argon2Threads, err := getEnvInt("SECRET_ARGON2_THREADS", 8)
+	if err != nil {
+		log.Fatal(err)
+	}
+	opts.Argon2Time = uint32(argon2Time)
+	opts.Argon2Memory = uint32(argon2Memory)
+	opts.Argon2Threads = uint8(argon2Threads)
+
+	s, err := secret.New(opts)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return s
+}
+
+// getEnvInt reads an optional unsigned integer from the environment, returning zero when it is not set. A bitSize
+// of zero means the size of int.
+func getEnvInt(name string, bitSize int) (int, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseUint(value, 10, bitSize)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", name, err)
+	}
+	return int(n), nil
+}
+
+func getAuthLockout(log logger.Logger) auth.LockoutConfig {
+	maxAttempts, err := getEnvInt("AUTH_MAX_ATTEMPTS", 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	maxAttemptsPerIP, err := getEnvInt("AUTH_MAX_ATTEMPTS_PER_IP", 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	config := auth.LockoutConfig{
+		MaxAttempts:      int64(maxAttempts),
+		MaxAttemptsPerIP: int64(maxAttemptsPerIP),
+	}
+	if config.Duration, err = getEnvDuration("AUTH_LOCKOUT_DURATION"); err != nil {
+		log.Fatal(err)
+	}
+	if config.MaxDuration, err = getEnvDuration("AUTH_MAX_LOCKOUT_DURATION"); err != nil {
+		log.Fatal(err)
+	}
+	if config.Window, err = getEnvDuration("AUTH_ATTEMPTS_WINDOW"); err != nil {
+		log.Fatal(err)
+	}
+	return config
+}
+
+func getAuthTokens(log logger.Logger) auth.TokenConfig {
+	config := auth.TokenConfig{Mode: os.Getenv("AUTH_TOKEN_MODE")}
+	switch config.Mode {
+	case "", auth.TokenModeSession:
+		return config
+	case auth.TokenModeJWT:
+	default:
+		log.Fatal(fmt.Errorf("AUTH_TOKEN_MODE: unknown mode %q", config.Mode))
+	}
+
+	keys, err := jwt.ParseKeys(os.Getenv("AUTH_JWT_KEYS"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if config.JWT, err = jwt.New(jwt.Options{Algorithm: os.Getenv("AUTH_JWT_ALGORITHM"), Keys: keys}); err != nil {
+		log.Fatal(err)
+	}
+	if config.AccessTTL, err = getEnvDuration("AUTH_JWT_ACCESS_TTL"); err != nil {
+		log.Fatal(err)
+	}
+	if config.RefreshTTL, err = getEnvDuration("AUTH_JWT_REFRESH_TTL"); err != nil {
+		log.Fatal(err)
+	}
+	return config
+}
+
+func startNotifier(log logger.Logger) notifier.Notifier {
+	switch kind := os.Getenv("NOTIFIER"); kind {
+	case "", "log":
+		return notifier.NewLogNotifier(log)
+	case "file":
+		return notifier.NewFileNotifier(os.Getenv("NOTIFIER_FILE"))
+	default:
+		log.Fatal(fmt.Errorf("NOTIFIER: unknown notifier %q", kind))
+		return nil
+	}
+}
+
+// startRates loads the exchange rates from EXCHANGE_RATES_FILE. Without it only transfers between accounts of the same
+// currency are possible.
+func startRates(log logger.Logger) exchange.Rates {
+	path := os.Getenv("EXCHANGE_RATES_FILE")
+	if path == "" {
+		rates, _ := exchange.NewStaticRates(nil)
+		return rates
+	}
+	rates, err := exchange.NewFileRates(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return rates
+}
+
+// startFees loads the fee schedule from FEE_RULES_FILE. Without it, or without FEE_ACCOUNT_ID, transfers are free.
+func startFees(log logger.Logger) fee.Engine {
+	path := os.Getenv("FEE_RULES_FILE")
+	if path == "" {
+		return nil
+	}
+	engine, err := fee.NewFileEngine(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return engine
+}
+
+// startRisk loads the rules of the risk check from RISK_RULES_FILE. Without it every transfer is allowed.
+func startRisk(log logger.Logger) risk.Engine {
+	path := os.Getenv("RISK_RULES_FILE")
+	if path == "" {
+		return nil
+	}
+	engine, err := risk.NewFileEngine(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return engine
+}
+
+func getSignature(log logger.Logger) signature.Config {
+	clients, err := signature.ParseClients(os.Getenv("SIGNATURE_CLIENTS"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	config := signature.Config{
+		Clients:  clients,
+		Required: os.Getenv("SIGNATURE_REQUIRED") == "true",
+	}
+	if config.MaxSkew, err = getEnvDuration("SIGNATURE_MAX_SKEW"); err != nil {
+		log.Fatal(err)
+	}
+	return config
+}
+
+// getEnvDuration reads an optional duration from the environment, returning zero when it is not set.
+func getEnvDuration(name string) (time.Duration, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", name, err)
+	}
+	return d, nil
+}
+
 func startEcho(log logger.Logger) *echo.Echo {
 	e := echo.New()
 	e.Use(emiddleware.CORS())
@@ -118,6 +309,18 @@ func startSwagger(e *echo.Echo, log logger.Logger) {
 	})
 }
 
+func startWorker(ctx context.Context, log logger.Logger, appContainer app.Container) {
+	interval, err := time.ParseDuration(os.Getenv("WORKER_INTERVAL"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	worker.Start(ctx, worker.Options{
+		Logger:   log,
+		App:      appContainer,
+		Interval: interval,
+	})
+}
+
 func main() {
 	log := logger.New(getProjectDir())
 
@@ -142,19 +345,43 @@ func main() {
 
 	e := startEcho(log)
 
+	stepUpThreshold, err := getEnvInt("TRANSFER_STEP_UP_THRESHOLD", 64)
+	if err != nil {
+		log.Fatal(err)
+	}
+	secretResetTTL, err := getEnvDuration("AUTH_SECRET_RESET_TTL")
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	repositoryContainer := repository.NewContainer(repository.Options{
 		Logger: log,
 		DB:     connDB,
 	})
 	appContainer := app.NewContainer(app.Options{
-		DB:         connDB,
-		Logger:     log,
-		Cache:      connCache,
-		Repository: repositoryContainer,
+		DB:          connDB,
+		Logger:      log,
+		Cache:       connCache,
+		Secret:      startSecret(log),
+		AuthLockout: getAuthLockout(log),
+		AuthTokens:  getAuthTokens(log),
+		TOTP:        totp.New(totp.Options{Issuer: os.Getenv("TOTP_ISSUER")}),
+		Notifier:    startNotifier(log),
+		Rates:       startRates(log),
+		Fees:        startFees(log),
+		Risk:        startRisk(log),
+		Repository:  repositoryContainer,
+
+		SecretResetTTL:          secretResetTTL,
+		TransferStepUpThreshold: int64(stepUpThreshold),
+		FeeAccountID:            os.Getenv("FEE_ACCOUNT_ID"),
 	})
 	middlewareContainer := middleware.NewContainer(middleware.Options{
 		Logger: log,
 		App:    appContainer,
+		Cache:  connCache,
+
+		Signature: getSignature(log),
 	})
 	api.Register(e, apimodel.Options{
 		Logger:     log,
@@ -162,6 +389,10 @@ func main() {
 		Middleware: middlewareContainer,
 	})
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	startWorker(ctx, log, appContainer)
+
 	if os.Getenv("ENABLE_DOCS") == "true" {
 		startSwagger(e, log)
 	}
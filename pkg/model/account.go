@@ -2,17 +2,57 @@ package model
 
 import "time"
 
+const (
+	AccountSortByCreatedAt = "created_at"
+	AccountSortByName      = "name"
+
+	AccountStatusActive = "active"
+	AccountStatusFrozen = "frozen"
+	AccountStatusClosed = "closed"
+)
+
 type (
+	// AccountBalance is the balance of the account, its ledger balance, and AvailableBalance, what is left of it to be
+	// moved once the money reserved for holds and reviews is set apart.
 	AccountBalance struct {
-		Balance int64 `json:"balance"`
+		Balance          int64  `json:"balance"`
+		AvailableBalance int64  `json:"available_balance"`
+		Currency         string `json:"currency"`
 	}
 	Account struct {
 		ID         string    `json:"id" db:"id"`
 		Name       string    `json:"name" db:"name" validate:"required"`
 		Document   string    `json:"document" db:"document" validate:"required"`
 		Balance    int64     `json:"balance" db:"balance" validate:"required,min=1"`
+		Reserved   int64     `json:"-" db:"reserved"`
+		Currency   string    `json:"currency" db:"currency"`
 		Secret     string    `json:"-" db:"secret" validate:"required" label:"secret"`
 		SecretSalt string    `json:"-" db:"secret_salt"`
+		Role       string    `json:"role" db:"role"`
+		Status     string    `json:"status" db:"status"`
 		CreatedAt  time.Time `json:"created_at" db:"created_at"`
 	}
+	// AccountDirectoryEntry is the public view of an account, enough to confirm the target of a transfer.
+	AccountDirectoryEntry struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+	// AccountFilter selects accounts for the operators listing. Name matches as a case-insensitive prefix.
+	AccountFilter struct {
+		Name     string
+		Document string
+		SortBy   string `validate:"omitempty,oneof=created_at name" label:"sort_by"`
+		Sort     string `validate:"omitempty,oneof=asc desc"`
+		Cursor   *Cursor
+		Limit    int `validate:"min=0,max=100"`
+	}
+	AccountPage struct {
+		Accounts   []Account
+		NextCursor string
+	}
 )
+
+// AvailableBalance is the balance the account can move, without the money reserved for holds and reviews.
+func (a Account) AvailableBalance() int64 {
+	return a.Balance - a.Reserved
+}
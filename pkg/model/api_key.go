@@ -0,0 +1,37 @@
+package model
+
+import (
+	"github.com/lib/pq"
+	"time"
+)
+
+// APIKeyPrefix starts every API key, telling them apart from session tokens.
+const APIKeyPrefix = "bk_"
+
+type (
+	// APIKey lets a server act on an account without a login, limited to the permissions in Scopes. Only the hash of
+	// the key is stored, Prefix identifies it.
+	APIKey struct {
+		ID         string         `json:"id" db:"id"`
+		AccountID  string         `json:"-" db:"account_id"`
+		Name       string         `json:"name" db:"name"`
+		Prefix     string         `json:"prefix" db:"prefix"`
+		KeyHash    string         `json:"-" db:"key_hash"`
+		Scopes     pq.StringArray `json:"scopes" db:"scopes"`
+		ExpiresAt  *time.Time     `json:"expires_at" db:"expires_at"`
+		LastUsedAt *time.Time     `json:"last_used_at" db:"last_used_at"`
+		RevokedAt  *time.Time     `json:"-" db:"revoked_at"`
+		CreatedAt  time.Time      `json:"created_at" db:"created_at"`
+	}
+	APIKeyCreation struct {
+		AccountID string     `json:"-"`
+		Name      string     `json:"name" validate:"required,max=100"`
+		Scopes    []string   `json:"scopes" validate:"required,min=1"`
+		ExpiresAt *time.Time `json:"expires_at"`
+	}
+	// APIKeyCreated carries the key itself, which is shown only when it is created.
+	APIKeyCreated struct {
+		APIKey
+		Key string `json:"key"`
+	}
+)
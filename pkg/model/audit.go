@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+const AuditActionLoginFailed = "login-failed"
+
+type AuditEntry struct {
+	ID        string    `json:"id" db:"id"`
+	Action    string    `json:"action" db:"action"`
+	AccountID *string   `json:"account_id" db:"account_id"`
+	Document  string    `json:"document" db:"document"`
+	IP        string    `json:"ip" db:"ip"`
+	Reason    string    `json:"reason" db:"reason"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
@@ -9,11 +9,46 @@ type (
 	Credentials struct {
 		Document string `json:"document" validate:"required"`
 		Secret   string `json:"secret" validate:"required"`
+		// Code is the TOTP or recovery code, required once the account enables two-factor authentication.
+		Code   string `json:"code"`
+		IP     string `json:"-"`
+		Device string `json:"-"`
 	}
+	// Session is handed to the client on login. RefreshToken and ExpiresAt are only set when Token is a JWT access
+	// token, which must be renewed with RefreshToken before ExpiresAt. APIKeyID and Scopes are only set when the
+	// request is authenticated with an API key.
 	Session struct {
-		Token     string    `json:"token"`
-		Account   Account   `json:"account"`
-		CreatedAt time.Time `json:"createdAt"`
+		ID           string     `json:"id"`
+		Token        string     `json:"token"`
+		RefreshToken string     `json:"refreshToken,omitempty"`
+		ExpiresAt    *time.Time `json:"expiresAt,omitempty"`
+		Account      Account    `json:"account"`
+		Device       string     `json:"device"`
+		IP           string     `json:"ip"`
+		CreatedAt    time.Time  `json:"createdAt"`
+		LastSeenAt   time.Time  `json:"lastSeenAt"`
+		APIKeyID     string     `json:"-"`
+		Scopes       []string   `json:"-"`
+	}
+	// SecretChange replaces the secret of an authenticated account, which must prove it knows the current one.
+	SecretChange struct {
+		AccountID     string `json:"-"`
+		CurrentSecret string `json:"current_secret" validate:"required" label:"current_secret"`
+		NewSecret     string `json:"new_secret" validate:"required" label:"new_secret"`
+	}
+	// SecretReset replaces the secret of an account with a token delivered by a secret reset request.
+	SecretReset struct {
+		Token     string `json:"token" validate:"required" label:"token"`
+		NewSecret string `json:"new_secret" validate:"required" label:"new_secret"`
+	}
+	// SessionInfo describes a session without its token, so it can be listed to its owner.
+	SessionInfo struct {
+		ID         string    `json:"id"`
+		Device     string    `json:"device"`
+		IP         string    `json:"ip"`
+		Current    bool      `json:"current"`
+		CreatedAt  time.Time `json:"created_at"`
+		LastSeenAt time.Time `json:"last_seen_at"`
 	}
 )
 
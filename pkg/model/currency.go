@@ -0,0 +1,26 @@
+package model
+
+// DefaultCurrency is the currency of accounts created without one.
+const DefaultCurrency = "BRL"
+
+// currencyMinorUnits holds the ISO 4217 currencies accepted by the service and how many digits of their amounts are
+// minor units, e.g. amounts in BRL are cents and amounts in JPY have no minor unit.
+var currencyMinorUnits = map[string]int{
+	"ARS": 2,
+	"BRL": 2,
+	"CAD": 2,
+	"CHF": 2,
+	"CLP": 0,
+	"EUR": 2,
+	"GBP": 2,
+	"JPY": 0,
+	"KWD": 3,
+	"MXN": 2,
+	"USD": 2,
+}
+
+// GetCurrencyMinorUnits returns the number of minor unit digits of currency and whether the currency is supported.
+func GetCurrencyMinorUnits(currency string) (int, bool) {
+	units, ok := currencyMinorUnits[currency]
+	return units, ok
+}
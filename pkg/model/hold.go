@@ -0,0 +1,44 @@
+package model
+
+import "time"
+
+const (
+	HoldStatusActive   = "active"
+	HoldStatusCaptured = "captured"
+	HoldStatusReleased = "released"
+	HoldStatusExpired  = "expired"
+)
+
+// Hold is money of the origin account authorized for the target account, reserved until the target captures it into
+// a transfer, releases it, or it expires at ExpiresAt. Amount is the most that can be captured, in Currency, the one of
+// the origin account. TargetAmount is what the whole Amount is worth in TargetCurrency at the ExchangeRate quoted when
+// the hold was made, which the capture keeps. Reserved is the money set apart for it, the amount with the fees it was
+// quoted with. CapturedAmount and TransferID are filled by the capture.
+type Hold struct {
+	ID              string     `json:"id" db:"id"`
+	OriginAccountID string     `json:"origin_account_id" db:"origin_account_id"`
+	TargetAccountID string     `json:"target_account_id" db:"target_account_id"`
+	Amount          int64      `json:"amount" db:"amount"`
+	Currency        string     `json:"currency" db:"currency"`
+	TargetAmount    int64      `json:"target_amount" db:"target_amount"`
+	TargetCurrency  string     `json:"target_currency" db:"target_currency"`
+	ExchangeRate    string     `json:"exchange_rate" db:"exchange_rate"`
+	Reserved        int64      `json:"reserved" db:"reserved"`
+	CapturedAmount  int64      `json:"captured_amount" db:"captured_amount"`
+	Status          string     `json:"status" db:"status"`
+	TransferID      *string    `json:"transfer_id,omitempty" db:"transfer_id"`
+	ExpiresAt       time.Time  `json:"expires_at" db:"expires_at"`
+	ClosedAt        *time.Time `json:"closed_at,omitempty" db:"closed_at"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	// TwoFactorCode proves the hold was made by the owner of the origin account, see transfer.App.CreateHold.
+	TwoFactorCode string `json:"-" db:"-"`
+}
+
+// Transfer returns the transfer capturing amount of the hold.
+func (h Hold) Transfer(amount int64) Transfer {
+	return Transfer{
+		OriginAccountID: h.OriginAccountID,
+		TargetAccountID: h.TargetAccountID,
+		Amount:          amount,
+	}
+}
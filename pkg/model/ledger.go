@@ -0,0 +1,32 @@
+package model
+
+import "time"
+
+const (
+	LedgerEntryTypeDebit  = "debit"
+	LedgerEntryTypeCredit = "credit"
+
+	// LedgerAccountOpeningBalances is the internal account that funds the initial balance of new accounts.
+	LedgerAccountOpeningBalances = "opening-balances"
+	// LedgerAccountFXClearing is the internal account that converts transfers between currencies, receiving the
+	// amount in the currency of the origin and paying the converted amount in the currency of the target.
+	LedgerAccountFXClearing = "fx-clearing"
+)
+
+type (
+	LedgerEntry struct {
+		ID            string    `json:"id" db:"id"`
+		AccountID     string    `json:"account_id,omitempty" db:"account_id"`
+		LedgerAccount string    `json:"ledger_account,omitempty" db:"ledger_account"`
+		Currency      string    `json:"currency,omitempty" db:"currency"`
+		TransferID    *string   `json:"transfer_id" db:"transfer_id"`
+		Type          string    `json:"type" db:"type"`
+		Amount        int64     `json:"amount" db:"amount"`
+		CreatedAt     time.Time `json:"created_at" db:"created_at"`
+	}
+	LedgerDivergence struct {
+		AccountID     string `json:"account_id" db:"account_id"`
+		Balance       int64  `json:"balance" db:"balance"`
+		LedgerBalance int64  `json:"ledger_balance" db:"ledger_balance"`
+	}
+)
@@ -0,0 +1,87 @@
+package model
+
+import "time"
+
+const (
+	AccountTierStandard = "standard"
+	AccountTierPremium  = "premium"
+	AccountTierBusiness = "business"
+
+	LimitPerTransfer = "per_transfer"
+	LimitDaily       = "daily"
+	LimitMonthly     = "monthly"
+	LimitDailyCount  = "daily_count"
+)
+
+type (
+	// TransferLimits caps what an account sends. Amounts are in minor units of the currency of the account, and a
+	// zero limit is no limit.
+	TransferLimits struct {
+		PerTransfer int64 `json:"per_transfer"`
+		Daily       int64 `json:"daily"`
+		Monthly     int64 `json:"monthly"`
+		DailyCount  int64 `json:"daily_count"`
+	}
+	// AccountLimits is the tier of an account and the limits an admin set for it. Nil limits follow the tier.
+	AccountLimits struct {
+		AccountID   string    `json:"-" db:"account_id"`
+		Tier        string    `json:"tier" db:"tier" validate:"required,oneof=standard premium business"`
+		PerTransfer *int64    `json:"per_transfer" db:"per_transfer" validate:"omitempty,min=0" label:"per_transfer"`
+		Daily       *int64    `json:"daily" db:"daily" validate:"omitempty,min=0"`
+		Monthly     *int64    `json:"monthly" db:"monthly" validate:"omitempty,min=0"`
+		DailyCount  *int64    `json:"daily_count" db:"daily_count" validate:"omitempty,min=0" label:"daily_count"`
+		UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+	}
+	// TransferUsage is what an account sent in the current UTC day and month, reversals excluded.
+	TransferUsage struct {
+		Daily      int64 `json:"daily" db:"daily"`
+		Monthly    int64 `json:"monthly" db:"monthly"`
+		DailyCount int64 `json:"daily_count" db:"daily_count"`
+	}
+	// AccountLimitsStatus shows the limits in force for an account and how much of them was used.
+	AccountLimitsStatus struct {
+		Settings AccountLimits  `json:"settings"`
+		Limits   TransferLimits `json:"limits"`
+		Usage    TransferUsage  `json:"usage"`
+	}
+)
+
+// tierLimits are the limits of each tier when an admin didn't set others.
+var tierLimits = map[string]TransferLimits{
+	AccountTierStandard: {PerTransfer: 500000, Daily: 1000000, Monthly: 5000000, DailyCount: 50},
+	AccountTierPremium:  {PerTransfer: 5000000, Daily: 10000000, Monthly: 100000000, DailyCount: 200},
+	AccountTierBusiness: {PerTransfer: 50000000, Daily: 100000000, Monthly: 1000000000},
+}
+
+// DefaultAccountLimits are the settings of an account no admin has changed.
+func DefaultAccountLimits(accountID string) AccountLimits {
+	return AccountLimits{AccountID: accountID, Tier: AccountTierStandard}
+}
+
+// Effective returns the limits of the tier with the ones set by an admin in place.
+func (l AccountLimits) Effective() TransferLimits {
+	limits, ok := tierLimits[l.Tier]
+	if !ok {
+		limits = tierLimits[AccountTierStandard]
+	}
+	if l.PerTransfer != nil {
+		limits.PerTransfer = *l.PerTransfer
+	}
+	if l.Daily != nil {
+		limits.Daily = *l.Daily
+	}
+	if l.Monthly != nil {
+		limits.Monthly = *l.Monthly
+	}
+	if l.DailyCount != nil {
+		limits.DailyCount = *l.DailyCount
+	}
+	return limits
+}
+
+// LimitPeriods returns the start of the UTC day and month of now, the periods the daily and monthly limits count.
+func LimitPeriods(now time.Time) (time.Time, time.Time) {
+	now = now.UTC()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC),
+		time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestAccountLimits_Effective(t *testing.T) {
+	var (
+		zero  = int64(0)
+		daily = int64(2000)
+	)
+
+	cases := map[string]struct {
+		InputLimits    AccountLimits
+		ExpectedLimits TransferLimits
+	}{
+		"should return the limits of the tier": {
+			InputLimits:    AccountLimits{Tier: AccountTierPremium},
+			ExpectedLimits: tierLimits[AccountTierPremium],
+		},
+		"should return the limits set by an admin": {
+			InputLimits: AccountLimits{Tier: AccountTierStandard, PerTransfer: &zero, Daily: &daily},
+			ExpectedLimits: TransferLimits{
+				PerTransfer: 0,
+				Daily:       2000,
+				Monthly:     tierLimits[AccountTierStandard].Monthly,
+				DailyCount:  tierLimits[AccountTierStandard].DailyCount,
+			},
+		},
+		"should return the limits of the standard tier: unknown tier": {
+			InputLimits:    AccountLimits{Tier: "unknown"},
+			ExpectedLimits: tierLimits[AccountTierStandard],
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, cs.ExpectedLimits, cs.InputLimits.Effective())
+		})
+	}
+}
+
+func TestLimitPeriods(t *testing.T) {
+	now := time.Date(2026, time.October, 18, 23, 30, 0, 0, time.FixedZone("BRT", -3*60*60))
+
+	dayStart, monthStart := LimitPeriods(now)
+
+	assert.Equal(t, time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC), dayStart)
+	assert.Equal(t, time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC), monthStart)
+}
@@ -0,0 +1,54 @@
+package model
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	SortAsc  = "asc"
+	SortDesc = "desc"
+)
+
+var errMalformedCursor = errors.New("malformed cursor")
+
+// Cursor points to the last item of a page ordered by created_at and id, or by name and id when Name is set.
+// It travels to clients as an opaque string.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        string
+	Name      string
+}
+
+func (c Cursor) Encode() string {
+	value := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "," + c.ID
+	if c.Name != "" {
+		value += "," + c.Name
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(value))
+}
+
+func DecodeCursor(value string) (*Cursor, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	parts := strings.SplitN(string(decoded), ",", 3)
+	if len(parts) < 2 || parts[1] == "" {
+		return nil, errMalformedCursor
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return nil, err
+	}
+	cursor := &Cursor{
+		CreatedAt: createdAt,
+		ID:        parts[1],
+	}
+	if len(parts) == 3 {
+		cursor.Name = parts[2]
+	}
+	return cursor, nil
+}
@@ -0,0 +1,63 @@
+package model
+
+import "time"
+
+const (
+	RecurrenceFrequencyDaily   = "daily"
+	RecurrenceFrequencyWeekly  = "weekly"
+	RecurrenceFrequencyMonthly = "monthly"
+
+	RecurrencePolicySkip  = "skip"
+	RecurrencePolicyRetry = "retry"
+
+	RecurringTransferStatusActive     = "active"
+	RecurringTransferStatusProcessing = "processing"
+	RecurringTransferStatusFinished   = "finished"
+	RecurringTransferStatusFailed     = "failed"
+	RecurringTransferStatusCancelled  = "cancelled"
+
+	RecurringTransferRunStatusExecuted                = "executed"
+	RecurringTransferRunStatusFailedInsufficientFunds = "failed-insufficient-funds"
+	RecurringTransferRunStatusSkipped                 = "skipped"
+	RecurringTransferRunStatusFailed                  = "failed"
+)
+
+type (
+	RecurrenceRule struct {
+		Frequency      string     `json:"frequency" db:"frequency" validate:"required,oneof=daily weekly monthly"`
+		DayOfMonth     *int       `json:"day_of_month,omitempty" db:"day_of_month" validate:"required_if=Frequency monthly,omitempty,min=1,max=31"`
+		StartAt        time.Time  `json:"start_at" db:"start_at" validate:"required"`
+		EndAt          *time.Time `json:"end_at,omitempty" db:"end_at"`
+		MaxOccurrences *int       `json:"max_occurrences,omitempty" db:"max_occurrences" validate:"omitempty,min=1"`
+	}
+	RecurringTransfer struct {
+		ID              string `json:"id" db:"id"`
+		OriginAccountID string `json:"origin_account_id" db:"origin_account_id" validate:"required"`
+		TargetAccountID string `json:"target_account_id" db:"target_account_id" validate:"required" label:"account_destination_id"`
+		Amount          int64  `json:"amount" db:"amount" validate:"required,min=1"`
+		RecurrenceRule
+		InsufficientFundsPolicy string    `json:"insufficient_funds_policy" db:"insufficient_funds_policy" validate:"required,oneof=skip retry"`
+		MaxRetries              int       `json:"max_retries" db:"max_retries" validate:"min=0"`
+		Status                  string    `json:"status" db:"status"`
+		Occurrences             int       `json:"occurrences" db:"occurrences"`
+		Retries                 int       `json:"retries" db:"retries"`
+		Attempts                int       `json:"attempts" db:"attempts"`
+		NextOccurrenceAt        time.Time `json:"next_occurrence_at" db:"next_occurrence_at"`
+		NextAttemptAt           time.Time `json:"next_attempt_at" db:"next_attempt_at"`
+		// FailureReason is the code of the error that failed the recurring transfer for good.
+		FailureReason *string   `json:"failure_reason,omitempty" db:"failure_reason"`
+		CreatedAt     time.Time `json:"created_at" db:"created_at"`
+		// TwoFactorCode proves the recurring transfer was set up by the owner of the origin account, see
+		// recurrence.App.Create.
+		TwoFactorCode string `json:"-" db:"-"`
+	}
+	RecurringTransferRun struct {
+		ID                  string    `json:"id" db:"id"`
+		RecurringTransferID string    `json:"recurring_transfer_id" db:"recurring_transfer_id"`
+		OccurrenceAt        time.Time `json:"occurrence_at" db:"occurrence_at"`
+		Status              string    `json:"status" db:"status"`
+		TransferID          *string   `json:"transfer_id,omitempty" db:"transfer_id"`
+		FailureReason       *string   `json:"failure_reason,omitempty" db:"failure_reason"`
+		CreatedAt           time.Time `json:"created_at" db:"created_at"`
+	}
+)
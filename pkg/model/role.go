@@ -0,0 +1,88 @@
+package model
+
+const (
+	RoleCustomer = "customer"
+	RoleSupport  = "support"
+	RoleAdmin    = "admin"
+
+	PermissionReadAccounts        = "accounts.read"
+	PermissionReadTransfers       = "transfers.read"
+	PermissionCreateTransfers     = "transfers.create"
+	PermissionManageCredentials   = "credentials.manage"
+	PermissionListAccounts        = "accounts.list"
+	PermissionReadAnyAccount      = "accounts.read-any"
+	PermissionChangeAccountStatus = "accounts.change-status"
+	PermissionCloseAccounts       = "accounts.close"
+	PermissionReadAnyStatement    = "statements.read-any"
+	PermissionReverseAnyTransfer  = "transfers.reverse-any"
+	PermissionManageLimits        = "accounts.manage-limits"
+	PermissionReviewTransfers     = "transfers.review"
+	PermissionAuditLedger         = "ledger.audit"
+)
+
+// ownPermissions are granted to every role and cover what an account does on itself. They exist so API keys can be
+// limited to a part of it.
+var ownPermissions = []string{
+	PermissionReadAccounts,
+	PermissionReadTransfers,
+	PermissionCreateTransfers,
+	PermissionManageCredentials,
+}
+
+// rolePermissions lists what each operator role may do on accounts other than its own.
+var rolePermissions = map[string][]string{
+	RoleCustomer: ownPermissions,
+	RoleSupport: {
+		PermissionListAccounts,
+		PermissionReadAnyAccount,
+		PermissionChangeAccountStatus,
+		PermissionReadAnyStatement,
+	},
+	RoleAdmin: {
+		PermissionListAccounts,
+		PermissionReadAnyAccount,
+		PermissionChangeAccountStatus,
+		PermissionCloseAccounts,
+		PermissionReadAnyStatement,
+		PermissionReverseAnyTransfer,
+		PermissionManageLimits,
+		PermissionReviewTransfers,
+		PermissionAuditLedger,
+	},
+}
+
+// HasRole tells whether the account has one of roles.
+func (a Account) HasRole(roles ...string) bool {
+	for _, role := range roles {
+		if a.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPermission tells whether the role of the account grants permission.
+func (a Account) HasPermission(permission string) bool {
+	if _, ok := rolePermissions[a.Role]; !ok {
+		return false
+	}
+	return contains(ownPermissions, permission) || contains(rolePermissions[a.Role], permission)
+}
+
+// HasPermission tells whether the session may use permission. Sessions opened with an API key are further limited to
+// the scopes of the key.
+func (s Session) HasPermission(permission string) bool {
+	if s.APIKeyID != "" && !contains(s.Scopes, permission) {
+		return false
+	}
+	return s.Account.HasPermission(permission)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,143 @@
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAccount_HasRole(t *testing.T) {
+	cases := map[string]struct {
+		InputAccount  Account
+		InputRoles    []string
+		ExpectedValue bool
+	}{
+		"should return true: one of the roles": {
+			InputAccount:  Account{Role: RoleSupport},
+			InputRoles:    []string{RoleAdmin, RoleSupport},
+			ExpectedValue: true,
+		},
+		"should return false: other role": {
+			InputAccount:  Account{Role: RoleCustomer},
+			InputRoles:    []string{RoleAdmin, RoleSupport},
+			ExpectedValue: false,
+		},
+		"should return false: without roles": {
+			InputAccount:  Account{Role: RoleAdmin},
+			InputRoles:    nil,
+			ExpectedValue: false,
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, cs.ExpectedValue, cs.InputAccount.HasRole(cs.InputRoles...))
+		})
+	}
+}
+
+func TestAccount_HasPermission(t *testing.T) {
+	cases := map[string]struct {
+		InputRole       string
+		InputPermission string
+		ExpectedValue   bool
+	}{
+		"should return true: admin closes accounts": {
+			InputRole:       RoleAdmin,
+			InputPermission: PermissionCloseAccounts,
+			ExpectedValue:   true,
+		},
+		"should return true: admin audits the ledger": {
+			InputRole:       RoleAdmin,
+			InputPermission: PermissionAuditLedger,
+			ExpectedValue:   true,
+		},
+		"should return true: support freezes accounts": {
+			InputRole:       RoleSupport,
+			InputPermission: PermissionChangeAccountStatus,
+			ExpectedValue:   true,
+		},
+		"should return false: support closes accounts": {
+			InputRole:       RoleSupport,
+			InputPermission: PermissionCloseAccounts,
+			ExpectedValue:   false,
+		},
+		"should return false: support reverses transfers": {
+			InputRole:       RoleSupport,
+			InputPermission: PermissionReverseAnyTransfer,
+			ExpectedValue:   false,
+		},
+		"should return true: customer creates transfers": {
+			InputRole:       RoleCustomer,
+			InputPermission: PermissionCreateTransfers,
+			ExpectedValue:   true,
+		},
+		"should return true: support reads its accounts": {
+			InputRole:       RoleSupport,
+			InputPermission: PermissionReadAccounts,
+			ExpectedValue:   true,
+		},
+		"should return false: customer lists accounts": {
+			InputRole:       RoleCustomer,
+			InputPermission: PermissionListAccounts,
+			ExpectedValue:   false,
+		},
+		"should return false: unknown role": {
+			InputRole:       "",
+			InputPermission: PermissionReadAccounts,
+			ExpectedValue:   false,
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, cs.ExpectedValue, Account{Role: cs.InputRole}.HasPermission(cs.InputPermission))
+		})
+	}
+}
+
+func TestSession_HasPermission(t *testing.T) {
+	cases := map[string]struct {
+		InputSession    Session
+		InputPermission string
+		ExpectedValue   bool
+	}{
+		"should return true: session without api key": {
+			InputSession:    Session{Account: Account{Role: RoleCustomer}},
+			InputPermission: PermissionManageCredentials,
+			ExpectedValue:   true,
+		},
+		"should return true: api key with scope": {
+			InputSession: Session{
+				Account:  Account{Role: RoleCustomer},
+				APIKeyID: "api_key_id",
+				Scopes:   []string{PermissionReadAccounts, PermissionReadTransfers},
+			},
+			InputPermission: PermissionReadTransfers,
+			ExpectedValue:   true,
+		},
+		"should return false: api key without scope": {
+			InputSession: Session{
+				Account:  Account{Role: RoleCustomer},
+				APIKeyID: "api_key_id",
+				Scopes:   []string{PermissionReadAccounts},
+			},
+			InputPermission: PermissionCreateTransfers,
+			ExpectedValue:   false,
+		},
+		"should return false: api key scope not granted by role": {
+			InputSession: Session{
+				Account:  Account{Role: RoleCustomer},
+				APIKeyID: "api_key_id",
+				Scopes:   []string{PermissionListAccounts},
+			},
+			InputPermission: PermissionListAccounts,
+			ExpectedValue:   false,
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, cs.ExpectedValue, cs.InputSession.HasPermission(cs.InputPermission))
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+const (
+	ScheduledTransferStatusPending                 = "pending"
+	ScheduledTransferStatusProcessing              = "processing"
+	ScheduledTransferStatusExecuted                = "executed"
+	ScheduledTransferStatusFailedInsufficientFunds = "failed-insufficient-funds"
+	ScheduledTransferStatusFailed                  = "failed"
+	ScheduledTransferStatusCancelled               = "cancelled"
+)
+
+type ScheduledTransfer struct {
+	ID              string    `json:"id" db:"id"`
+	OriginAccountID string    `json:"origin_account_id" db:"origin_account_id" validate:"required"`
+	TargetAccountID string    `json:"target_account_id" db:"target_account_id" validate:"required" label:"account_destination_id"`
+	Amount          int64     `json:"amount" db:"amount" validate:"required,min=1"`
+	ScheduledFor    time.Time `json:"scheduled_for" db:"scheduled_for" validate:"required"`
+	Status          string    `json:"status" db:"status"`
+	TransferID      *string   `json:"transfer_id,omitempty" db:"transfer_id"`
+	Attempts        int       `json:"attempts" db:"attempts"`
+	// NextAttemptAt is when a transfer that failed because of the infrastructure is attempted again.
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty" db:"next_attempt_at"`
+	// FailureReason is the code of the error that failed the transfer for good.
+	FailureReason *string   `json:"failure_reason,omitempty" db:"failure_reason"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+	// TwoFactorCode proves the transfer was scheduled by the owner of the origin account, see schedule.App.Create.
+	TwoFactorCode string `json:"-" db:"-"`
+}
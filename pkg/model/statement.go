@@ -0,0 +1,42 @@
+package model
+
+import "time"
+
+const (
+	StatementFormatCSV  = "csv"
+	StatementFormatOFX  = "ofx"
+	StatementFormatJSON = "json"
+)
+
+type (
+	// StatementFilter selects the period of the statement of AccountID. A zero From starts at the account creation and a
+	// zero To ends now.
+	StatementFilter struct {
+		AccountID string `validate:"required"`
+		From      time.Time
+		To        time.Time
+	}
+	Statement struct {
+		AccountID       string    `json:"account_id"`
+		AccountName     string    `json:"account_name"`
+		AccountDocument string    `json:"account_document"`
+		Currency        string    `json:"currency"`
+		From            time.Time `json:"from"`
+		To              time.Time `json:"to"`
+		OpeningBalance  int64     `json:"opening_balance"`
+		ClosingBalance  int64     `json:"closing_balance"`
+	}
+	// StatementEntry is a transfer seen from the statement account, in the currency of the account. Amount and Fee are
+	// negative for sent transfers, Fee is positive for the fees collected by the fee account and Balance is the running
+	// balance after both.
+	StatementEntry struct {
+		TransferID            string    `json:"transfer_id"`
+		ReversedTransferID    *string   `json:"reversed_transfer_id,omitempty"`
+		CounterpartyAccountID string    `json:"counterparty_account_id"`
+		CounterpartyName      string    `json:"counterparty_name"`
+		Amount                int64     `json:"amount"`
+		Fee                   int64     `json:"fee"`
+		Balance               int64     `json:"balance"`
+		CreatedAt             time.Time `json:"created_at"`
+	}
+)
@@ -3,17 +3,60 @@ package model
 import "time"
 
 type (
+	// Transfer moves Amount, in the currency of the origin account, to the target account, which receives
+	// TargetAmount in its own currency. ExchangeRate is how many units of TargetCurrency were paid for one unit of
+	// SourceCurrency. Fee is charged to the origin account on top of Amount, itemized in Fees.
 	Transfer struct {
-		ID              string    `json:"id" db:"id"`
-		OriginAccountID string    `json:"origin_account_id" db:"origin_account_id" validate:"required"`
-		TargetAccountID string    `json:"target_account_id" db:"target_account_id" validate:"required" label:"account_destination_id"`
-		Amount          int64     `json:"amount" db:"amount" validate:"required,min=1"`
-		CreatedAt       time.Time `json:"created_at" db:"created_at"`
+		ID                 string        `json:"id" db:"id"`
+		OriginAccountID    string        `json:"origin_account_id" db:"origin_account_id" validate:"required"`
+		TargetAccountID    string        `json:"target_account_id" db:"target_account_id" validate:"required" label:"account_destination_id"`
+		Amount             int64         `json:"amount" db:"amount" validate:"required,min=1"`
+		SourceCurrency     string        `json:"source_currency" db:"source_currency"`
+		TargetAmount       int64         `json:"target_amount" db:"target_amount"`
+		TargetCurrency     string        `json:"target_currency" db:"target_currency"`
+		ExchangeRate       string        `json:"exchange_rate" db:"exchange_rate"`
+		Fee                int64         `json:"fee" db:"fee"`
+		Fees               []TransferFee `json:"fees,omitempty" db:"-"`
+		ReversedTransferID *string       `json:"reversed_transfer_id,omitempty" db:"reversed_transfer_id"`
+		CreatedAt          time.Time     `json:"created_at" db:"created_at"`
+		// ReviewID is set, instead of ID, when the transfer was held for a review by the risk check.
+		ReviewID *string `json:"review_id,omitempty" db:"-"`
+		// TwoFactorCode proves the transfer was made by the owner of the origin account, see transfer.App.Create.
+		TwoFactorCode string `json:"-" db:"-"`
 	}
+	// TransferFee is the part of the fee of a transfer charged by one rule of the fee schedule.
+	TransferFee struct {
+		TransferID string `json:"-" db:"transfer_id"`
+		Rule       string `json:"rule" db:"rule"`
+		Amount     int64  `json:"amount" db:"amount"`
+	}
+	// TransferDetailed is a transfer as seen by one of its accounts, with ReversedAmount in the currency of that account.
 	TransferDetailed struct {
 		Transfer
 		Sent              bool   `json:"sent" db:"sent"`
+		ReversedAmount    int64  `json:"reversed_amount" db:"reversed_amount"`
+		CollectedFee      int64  `json:"collected_fee,omitempty" db:"collected_fee"`
 		OriginAccountName string `json:"origin_account_name" db:"origin_account_name"`
 		TargetAccountName string `json:"target_account_name" db:"target_account_name"`
 	}
+	// TransferFilter selects the transfers of AccountID. Zero values of the optional fields disable their filter.
+	// MinAmount and MaxAmount are in the currency of AccountID, compared to what it sent or received. CollectedFees is set
+	// for the fee account, also selecting the transfers charged a fee, with the fee in CollectedFee.
+	TransferFilter struct {
+		AccountID             string
+		Sent                  *bool
+		CounterpartyAccountID string
+		From                  time.Time
+		To                    time.Time
+		MinAmount             int64
+		MaxAmount             int64
+		CollectedFees         bool
+		Sort                  string `validate:"omitempty,oneof=asc desc"`
+		Cursor                *Cursor
+		Limit                 int `validate:"min=0,max=100"`
+	}
+	TransferPage struct {
+		Transfers  []TransferDetailed
+		NextCursor string
+	}
 )
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"github.com/lib/pq"
+	"time"
+)
+
+const (
+	TransferReviewStatusPending  = "pending"
+	TransferReviewStatusApproved = "approved"
+	TransferReviewStatusRejected = "rejected"
+)
+
+// TransferReview is a transfer held by the risk check until an operator approves or rejects it. Reserved is the money
+// of the origin account set apart for it in the meantime, the amount with the fees it was quoted with. TransferID is
+// the transfer made once it is approved.
+type TransferReview struct {
+	ID              string         `json:"id" db:"id"`
+	OriginAccountID string         `json:"origin_account_id" db:"origin_account_id"`
+	TargetAccountID string         `json:"target_account_id" db:"target_account_id"`
+	Amount          int64          `json:"amount" db:"amount"`
+	SourceCurrency  string         `json:"source_currency" db:"source_currency"`
+	TargetAmount    int64          `json:"target_amount" db:"target_amount"`
+	TargetCurrency  string         `json:"target_currency" db:"target_currency"`
+	ExchangeRate    string         `json:"exchange_rate" db:"exchange_rate"`
+	Reserved        int64          `json:"reserved" db:"reserved"`
+	Score           int            `json:"score" db:"score"`
+	Reasons         pq.StringArray `json:"reasons" db:"reasons"`
+	Status          string         `json:"status" db:"status"`
+	TransferID      *string        `json:"transfer_id,omitempty" db:"transfer_id"`
+	DecidedBy       *string        `json:"decided_by,omitempty" db:"decided_by"`
+	DecidedAt       *time.Time     `json:"decided_at,omitempty" db:"decided_at"`
+	CreatedAt       time.Time      `json:"created_at" db:"created_at"`
+}
+
+// Transfer returns the transfer held by the review.
+func (r TransferReview) Transfer() Transfer {
+	return Transfer{
+		OriginAccountID: r.OriginAccountID,
+		TargetAccountID: r.TargetAccountID,
+		Amount:          r.Amount,
+		SourceCurrency:  r.SourceCurrency,
+		TargetAmount:    r.TargetAmount,
+		TargetCurrency:  r.TargetCurrency,
+		ExchangeRate:    r.ExchangeRate,
+	}
+}
@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+type (
+	// TwoFactor is the TOTP secret of an account. It is only enforced once ConfirmedAt is set.
+	TwoFactor struct {
+		AccountID   string     `json:"-" db:"account_id"`
+		Secret      string     `json:"-" db:"secret"`
+		ConfirmedAt *time.Time `json:"confirmed_at" db:"confirmed_at"`
+		CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	}
+	// TwoFactorRecoveryCode replaces a TOTP code once, for when the authenticator app is lost. Only its hash is kept.
+	TwoFactorRecoveryCode struct {
+		AccountID string     `db:"account_id"`
+		CodeHash  string     `db:"code_hash"`
+		UsedAt    *time.Time `db:"used_at"`
+	}
+	// TwoFactorEnrollment is shown once to the account owner, to be added to an authenticator app.
+	TwoFactorEnrollment struct {
+		Secret string `json:"secret"`
+		URI    string `json:"uri"`
+	}
+	TwoFactorConfirmation struct {
+		RecoveryCodes []string `json:"recovery_codes"`
+	}
+)
@@ -5,12 +5,16 @@ package account
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"github.com/carlosrodriguesf/bank-api/pkg/model"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/db"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/transaction"
+	"strings"
 )
 
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
 type (
 	Options struct {
 		Logger logger.Logger
@@ -20,9 +24,15 @@ type (
 	Repository interface {
 		Create(ctx context.Context, account model.Account) (*model.GeneratedData, error)
 		HasDocument(ctx context.Context, document string) (bool, error)
-		List(ctx context.Context) ([]model.Account, error)
+		List(ctx context.Context, filter model.AccountFilter) ([]model.Account, error)
 		GetByIDOrDocument(ctx context.Context, v string) (*model.Account, error)
-		UpdateBalance(ctx context.Context, accountID string, balance int64) error
+		GetByIDForUpdate(ctx context.Context, accountID string) (*model.Account, error)
+		Debit(ctx context.Context, accountID string, amount int64) (bool, error)
+		Credit(ctx context.Context, accountID string, amount int64) error
+		Reserve(ctx context.Context, accountID string, amount int64) (bool, error)
+		Release(ctx context.Context, accountID string, amount int64) error
+		UpdateStatus(ctx context.Context, accountID string, status string) error
+		UpdateSecret(ctx context.Context, accountID string, secret string) error
 		WithTransaction(conn transaction.Transaction) Repository
 	}
 
@@ -42,8 +52,8 @@ func NewRepository(opts Options) Repository {
 func (r *repositoryImpl) Create(ctx context.Context, account model.Account) (*model.GeneratedData, error) {
 	generatedData := new(model.GeneratedData)
 	query := `
-		INSERT INTO accounts(name, document, balance, secret, secret_salt) 
-		VALUES (:name, :document, :balance, :secret, :secret_salt)
+		INSERT INTO accounts(name, document, balance, currency, secret, secret_salt) 
+		VALUES (:name, :document, :balance, :currency, :secret, :secret_salt)
 		RETURNING id, created_at`
 	err := r.db.NamedGetContext(ctx, query, generatedData, account)
 	if err != nil {
@@ -63,10 +73,58 @@ func (r *repositoryImpl) HasDocument(ctx context.Context, document string) (bool
 	return exists, err
 }
 
-func (r *repositoryImpl) List(ctx context.Context) ([]model.Account, error) {
-	query := `SELECT id, name, document, balance, created_at FROM accounts`
+func (r *repositoryImpl) List(ctx context.Context, filter model.AccountFilter) ([]model.Account, error) {
+	var (
+		args       = make([]interface{}, 0)
+		conditions = make([]string, 0)
+		where      = ""
+		sortColumn = "created_at"
+		order      = "DESC"
+		comparator = "<"
+		param      = func(value interface{}) string {
+			args = append(args, value)
+			return fmt.Sprintf("$%d", len(args))
+		}
+	)
+
+	// names are listed alphabetically and creation dates newest first, unless sorted otherwise
+	if filter.SortBy == model.AccountSortByName {
+		sortColumn = "name"
+	}
+	if filter.Sort == model.SortAsc || (filter.Sort == "" && sortColumn == "name") {
+		order = "ASC"
+		comparator = ">"
+	}
+	if filter.Name != "" {
+		conditions = append(conditions, "lower(name) LIKE lower("+param(likeEscaper.Replace(filter.Name))+") || '%'")
+	}
+	if filter.Document != "" {
+		conditions = append(conditions, "document = "+param(filter.Document))
+	}
+	if filter.Cursor != nil && sortColumn == "name" {
+		conditions = append(conditions, fmt.Sprintf(
+			"(name, id) %s (%s, %s)", comparator, param(filter.Cursor.Name), param(filter.Cursor.ID),
+		))
+	}
+	if filter.Cursor != nil && sortColumn == "created_at" {
+		conditions = append(conditions, fmt.Sprintf(
+			"(created_at, id) %s (%s, %s)", comparator, param(filter.Cursor.CreatedAt), param(filter.Cursor.ID),
+		))
+	}
+
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query := `
+		SELECT id, name, document, balance, currency, role, status, created_at 
+		FROM accounts
+		` + where + `
+		ORDER BY ` + sortColumn + ` ` + order + `, id ` + order + `
+		LIMIT ` + param(filter.Limit)
+
 	accounts := make([]model.Account, 0)
-	err := r.db.SelectContext(ctx, &accounts, query)
+	err := r.db.SelectContext(ctx, &accounts, query, args...)
 	if err != nil {
 		r.logger.Error(err)
 		return nil, err
@@ -75,7 +133,7 @@ func (r *repositoryImpl) List(ctx context.Context) ([]model.Account, error) {
 }
 
 func (r *repositoryImpl) GetByIDOrDocument(ctx context.Context, v string) (*model.Account, error) {
-	query := "SELECT id, name, document, balance, secret, secret_salt, created_at FROM accounts WHERE id = $1 OR document = $1"
+	query := "SELECT id, name, document, balance, reserved, currency, secret, secret_salt, role, status, created_at FROM accounts WHERE id = $1 OR document = $1"
 	acc := new(model.Account)
 	err := r.db.GetContext(ctx, acc, query, v)
 	if err != nil {
@@ -88,9 +146,88 @@ func (r *repositoryImpl) GetByIDOrDocument(ctx context.Context, v string) (*mode
 	return acc, nil
 }
 
-func (r *repositoryImpl) UpdateBalance(ctx context.Context, accountID string, balance int64) error {
-	query := "UPDATE accounts SET balance = $1 WHERE id = $2"
-	_, err := r.db.ExecContext(ctx, query, balance, accountID)
+// GetByIDForUpdate reads the account locking its row until the end of the current transaction.
+func (r *repositoryImpl) GetByIDForUpdate(ctx context.Context, accountID string) (*model.Account, error) {
+	query := "SELECT id, name, document, balance, reserved, currency, status, created_at FROM accounts WHERE id = $1 FOR UPDATE"
+	acc := new(model.Account)
+	err := r.db.GetContext(ctx, acc, query, accountID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		r.logger.Error(err)
+		return nil, err
+	}
+	return acc, nil
+}
+
+// Debit subtracts amount from the account balance in a single statement, refusing to take reserved money or to make
+// it negative. It returns false when the account does not have enough funds.
+func (r *repositoryImpl) Debit(ctx context.Context, accountID string, amount int64) (bool, error) {
+	query := "UPDATE accounts SET balance = balance - $1 WHERE id = $2 AND balance - reserved >= $1"
+	result, err := r.db.ExecContext(ctx, query, amount, accountID)
+	if err != nil {
+		r.logger.Error(err)
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.Error(err)
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+func (r *repositoryImpl) Credit(ctx context.Context, accountID string, amount int64) error {
+	query := "UPDATE accounts SET balance = balance + $1 WHERE id = $2"
+	_, err := r.db.ExecContext(ctx, query, amount, accountID)
+	if err != nil {
+		r.logger.Error(err)
+	}
+	return err
+}
+
+// Reserve sets amount of the account balance apart, so it can't be debited until released. It returns false when the
+// account does not have enough funds that aren't reserved yet.
+func (r *repositoryImpl) Reserve(ctx context.Context, accountID string, amount int64) (bool, error) {
+	query := "UPDATE accounts SET reserved = reserved + $1 WHERE id = $2 AND balance - reserved >= $1"
+	result, err := r.db.ExecContext(ctx, query, amount, accountID)
+	if err != nil {
+		r.logger.Error(err)
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.Error(err)
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// Release gives back amount reserved by Reserve to the funds the account can move.
+func (r *repositoryImpl) Release(ctx context.Context, accountID string, amount int64) error {
+	query := "UPDATE accounts SET reserved = reserved - $1 WHERE id = $2"
+	_, err := r.db.ExecContext(ctx, query, amount, accountID)
+	if err != nil {
+		r.logger.Error(err)
+	}
+	return err
+}
+
+func (r *repositoryImpl) UpdateStatus(ctx context.Context, accountID string, status string) error {
+	query := "UPDATE accounts SET status = $1 WHERE id = $2"
+	_, err := r.db.ExecContext(ctx, query, status, accountID)
+	if err != nil {
+		r.logger.Error(err)
+	}
+	return err
+}
+
+// UpdateSecret replaces the hashed secret of the account. The salt column is cleared since current hashes carry
+// their own salt.
+func (r *repositoryImpl) UpdateSecret(ctx context.Context, accountID string, secret string) error {
+	query := "UPDATE accounts SET secret = $1, secret_salt = '' WHERE id = $2"
+	_, err := r.db.ExecContext(ctx, query, secret, accountID)
 	if err != nil {
 		r.logger.Error(err)
 	}
@@ -51,6 +51,50 @@ func (mr *MockRepositoryMockRecorder) Create(ctx, account interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, account)
 }
 
+// Credit mocks base method.
+func (m *MockRepository) Credit(ctx context.Context, accountID string, amount int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Credit", ctx, accountID, amount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Credit indicates an expected call of Credit.
+func (mr *MockRepositoryMockRecorder) Credit(ctx, accountID, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Credit", reflect.TypeOf((*MockRepository)(nil).Credit), ctx, accountID, amount)
+}
+
+// Debit mocks base method.
+func (m *MockRepository) Debit(ctx context.Context, accountID string, amount int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Debit", ctx, accountID, amount)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Debit indicates an expected call of Debit.
+func (mr *MockRepositoryMockRecorder) Debit(ctx, accountID, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Debit", reflect.TypeOf((*MockRepository)(nil).Debit), ctx, accountID, amount)
+}
+
+// GetByIDForUpdate mocks base method.
+func (m *MockRepository) GetByIDForUpdate(ctx context.Context, accountID string) (*model.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDForUpdate", ctx, accountID)
+	ret0, _ := ret[0].(*model.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDForUpdate indicates an expected call of GetByIDForUpdate.
+func (mr *MockRepositoryMockRecorder) GetByIDForUpdate(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDForUpdate", reflect.TypeOf((*MockRepository)(nil).GetByIDForUpdate), ctx, accountID)
+}
+
 // GetByIDOrDocument mocks base method.
 func (m *MockRepository) GetByIDOrDocument(ctx context.Context, v string) (*model.Account, error) {
 	m.ctrl.T.Helper()
@@ -82,32 +126,75 @@ func (mr *MockRepositoryMockRecorder) HasDocument(ctx, document interface{}) *go
 }
 
 // List mocks base method.
-func (m *MockRepository) List(ctx context.Context) ([]model.Account, error) {
+func (m *MockRepository) List(ctx context.Context, filter model.AccountFilter) ([]model.Account, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", ctx)
+	ret := m.ctrl.Call(m, "List", ctx, filter)
 	ret0, _ := ret[0].([]model.Account)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // List indicates an expected call of List.
-func (mr *MockRepositoryMockRecorder) List(ctx interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) List(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepository)(nil).List), ctx, filter)
+}
+
+// Release mocks base method.
+func (m *MockRepository) Release(ctx context.Context, accountID string, amount int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, accountID, amount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockRepositoryMockRecorder) Release(ctx, accountID, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockRepository)(nil).Release), ctx, accountID, amount)
+}
+
+// Reserve mocks base method.
+func (m *MockRepository) Reserve(ctx context.Context, accountID string, amount int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", ctx, accountID, amount)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockRepositoryMockRecorder) Reserve(ctx, accountID, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockRepository)(nil).Reserve), ctx, accountID, amount)
+}
+
+// UpdateSecret mocks base method.
+func (m *MockRepository) UpdateSecret(ctx context.Context, accountID, secret string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSecret", ctx, accountID, secret)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSecret indicates an expected call of UpdateSecret.
+func (mr *MockRepositoryMockRecorder) UpdateSecret(ctx, accountID, secret interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepository)(nil).List), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecret", reflect.TypeOf((*MockRepository)(nil).UpdateSecret), ctx, accountID, secret)
 }
 
-// UpdateBalance mocks base method.
-func (m *MockRepository) UpdateBalance(ctx context.Context, accountID string, balance int64) error {
+// UpdateStatus mocks base method.
+func (m *MockRepository) UpdateStatus(ctx context.Context, accountID, status string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateBalance", ctx, accountID, balance)
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, accountID, status)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateBalance indicates an expected call of UpdateBalance.
-func (mr *MockRepositoryMockRecorder) UpdateBalance(ctx, accountID, balance interface{}) *gomock.Call {
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockRepositoryMockRecorder) UpdateStatus(ctx, accountID, status interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBalance", reflect.TypeOf((*MockRepository)(nil).UpdateBalance), ctx, accountID, balance)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockRepository)(nil).UpdateStatus), ctx, accountID, status)
 }
 
 // WithTransaction mocks base method.
@@ -16,8 +16,8 @@ import (
 
 func TestCreate(t *testing.T) {
 	query := regexp.QuoteMeta(`
-		INSERT INTO accounts(name, document, balance, secret, secret_salt) 
-		VALUES (?, ?, ?, ?, ?)
+		INSERT INTO accounts(name, document, balance, currency, secret, secret_salt) 
+		VALUES (?, ?, ?, ?, ?, ?)
 		RETURNING id, created_at`)
 
 	currentTime := time.Now()
@@ -33,6 +33,7 @@ func TestCreate(t *testing.T) {
 				Name:       "John Doe",
 				Document:   "123.123.123-12",
 				Balance:    100,
+				Currency:   "BRL",
 				Secret:     "secret",
 				SecretSalt: "secret salt",
 			},
@@ -47,7 +48,7 @@ func TestCreate(t *testing.T) {
 					AddRow("generated_id", currentTime)
 				mock.ExpectPrepare(query).
 					ExpectQuery().
-					WithArgs("John Doe", "123.123.123-12", 100, "secret", "secret salt").
+					WithArgs("John Doe", "123.123.123-12", 100, "BRL", "secret", "secret salt").
 					WillReturnRows(rows)
 			},
 		},
@@ -56,6 +57,7 @@ func TestCreate(t *testing.T) {
 				Name:       "John Doe",
 				Document:   "123.123.123-12",
 				Balance:    100,
+				Currency:   "BRL",
 				Secret:     "secret",
 				SecretSalt: "secret salt",
 			},
@@ -64,7 +66,7 @@ func TestCreate(t *testing.T) {
 			PrepareMockDB: func(mock sqlmock.Sqlmock) {
 				mock.ExpectPrepare(query).
 					ExpectQuery().
-					WithArgs("John Doe", "123.123.123-12", 100, "secret", "secret salt").
+					WithArgs("John Doe", "123.123.123-12", 100, "BRL", "secret", "secret salt").
 					WillReturnError(errors.New("fail"))
 			},
 		},
@@ -146,58 +148,133 @@ func TestHasDocument(t *testing.T) {
 
 func TestListAccounts(t *testing.T) {
 	var (
-		query           = regexp.QuoteMeta(`SELECT id, name, document, balance, created_at FROM accounts`)
+		currentTime     = time.Now()
 		accountsExample = []model.Account{
 			{
 				ID:       "account_id_1",
 				Name:     "Account Test 1",
 				Document: "12312312312",
 				Balance:  453,
+				Currency: "BRL",
 			},
 			{
 				ID:       "account_id",
 				Name:     "Account Test",
 				Document: "12312312312",
 				Balance:  819,
+				Currency: "USD",
+				Role:     model.RoleAdmin,
 			},
 		}
+		selectQuery = `
+			SELECT id, name, document, balance, currency, role, status, created_at 
+			FROM accounts
+		`
+		newRows = func() *sqlmock.Rows {
+			rows := sqlmock.NewRows([]string{"id", "name", "document", "balance", "currency", "role", "status", "created_at"})
+			for _, accountExample := range accountsExample {
+				rows.AddRow(
+					accountExample.ID,
+					accountExample.Name,
+					accountExample.Document,
+					accountExample.Balance,
+					accountExample.Currency,
+					accountExample.Role,
+					accountExample.Status,
+					accountExample.CreatedAt,
+				)
+			}
+			return rows
+		}
 	)
 
 	cases := map[string]struct {
+		InputData     model.AccountFilter
 		ExpectedData  []model.Account
 		ExpectedError error
 		PrepareMockDB func(mock sqlmock.Sqlmock)
 	}{
 		"should return success": {
+			InputData:     model.AccountFilter{Limit: 10},
 			ExpectedData:  accountsExample,
 			ExpectedError: nil,
 			PrepareMockDB: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "name", "document", "balance", "created_at"})
-				for _, accountExample := range accountsExample {
-					rows.AddRow(
-						accountExample.ID,
-						accountExample.Name,
-						accountExample.Document,
-						accountExample.Balance,
-						accountExample.CreatedAt,
-					)
-				}
-				mock.ExpectQuery(query).WillReturnRows(rows)
+				query := regexp.QuoteMeta(selectQuery + `
+					ORDER BY created_at DESC, id DESC
+					LIMIT $1
+				`)
+				mock.ExpectQuery(query).WithArgs(10).WillReturnRows(newRows())
+			},
+		},
+		"should return success with all filters": {
+			InputData: model.AccountFilter{
+				Name:     "Acc_",
+				Document: "12312312312",
+				Sort:     model.SortAsc,
+				Cursor:   &model.Cursor{CreatedAt: currentTime, ID: "account_id_0"},
+				Limit:    10,
+			},
+			ExpectedData:  accountsExample,
+			ExpectedError: nil,
+			PrepareMockDB: func(mock sqlmock.Sqlmock) {
+				query := regexp.QuoteMeta(selectQuery + `
+					WHERE lower(name) LIKE lower($1) || '%' 
+						AND document = $2 
+						AND (created_at, id) > ($3, $4)
+					ORDER BY created_at ASC, id ASC
+					LIMIT $5
+				`)
+				mock.ExpectQuery(query).
+					WithArgs(`Acc\_`, "12312312312", currentTime, "account_id_0", 10).
+					WillReturnRows(newRows())
+			},
+		},
+		"should return success sorted by name": {
+			InputData: model.AccountFilter{
+				SortBy: model.AccountSortByName,
+				Cursor: &model.Cursor{CreatedAt: currentTime, ID: "account_id_0", Name: "Account"},
+				Limit:  10,
+			},
+			ExpectedData:  accountsExample,
+			ExpectedError: nil,
+			PrepareMockDB: func(mock sqlmock.Sqlmock) {
+				query := regexp.QuoteMeta(selectQuery + `
+					WHERE (name, id) > ($1, $2)
+					ORDER BY name ASC, id ASC
+					LIMIT $3
+				`)
+				mock.ExpectQuery(query).
+					WithArgs("Account", "account_id_0", 10).
+					WillReturnRows(newRows())
+			},
+		},
+		"should return success sorted by name descending": {
+			InputData:     model.AccountFilter{SortBy: model.AccountSortByName, Sort: model.SortDesc, Limit: 10},
+			ExpectedData:  accountsExample,
+			ExpectedError: nil,
+			PrepareMockDB: func(mock sqlmock.Sqlmock) {
+				query := regexp.QuoteMeta(selectQuery + `
+					ORDER BY name DESC, id DESC
+					LIMIT $1
+				`)
+				mock.ExpectQuery(query).WithArgs(10).WillReturnRows(newRows())
 			},
 		},
 		"should return success without accounts": {
+			InputData:     model.AccountFilter{Limit: 10},
 			ExpectedData:  make([]model.Account, 0),
 			ExpectedError: nil,
 			PrepareMockDB: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "name", "document", "balance", "created_at"})
-				mock.ExpectQuery(query).WillReturnRows(rows)
+				rows := sqlmock.NewRows([]string{"id", "name", "document", "balance", "currency", "role", "status", "created_at"})
+				mock.ExpectQuery(regexp.QuoteMeta(selectQuery)).WillReturnRows(rows)
 			},
 		},
 		"should return error": {
+			InputData:     model.AccountFilter{Limit: 10},
 			ExpectedData:  nil,
 			ExpectedError: errors.New("fail"),
 			PrepareMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(query).WillReturnError(errors.New("fail"))
+				mock.ExpectQuery(regexp.QuoteMeta(selectQuery)).WillReturnError(errors.New("fail"))
 			},
 		},
 	}
@@ -213,7 +290,7 @@ func TestListAccounts(t *testing.T) {
 				DB:     db.NewExtendedDB(conn),
 			})
 
-			data, err := repo.List(context.Background())
+			data, err := repo.List(context.Background(), cs.InputData)
 
 			assert.Equal(t, cs.ExpectedData, data)
 			assert.Equal(t, cs.ExpectedError, err)
@@ -223,12 +300,14 @@ func TestListAccounts(t *testing.T) {
 
 func TestGetByIDOrDocument(t *testing.T) {
 	var (
-		query          = regexp.QuoteMeta(`SELECT id, name, document, balance, secret, secret_salt, created_at FROM accounts WHERE id = $1 OR document = $1`)
+		query          = regexp.QuoteMeta(`SELECT id, name, document, balance, reserved, currency, secret, secret_salt, role, status, created_at FROM accounts WHERE id = $1 OR document = $1`)
 		accountExample = model.Account{
 			ID:         "account_id",
 			Name:       "Account Test",
 			Document:   "12312312312",
 			Balance:    100,
+			Reserved:   40,
+			Currency:   "BRL",
 			Secret:     "secret",
 			SecretSalt: "secret_salt",
 		}
@@ -246,14 +325,18 @@ func TestGetByIDOrDocument(t *testing.T) {
 			ExpectedError: nil,
 			PrepareMockDB: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.
-					NewRows([]string{"id", "name", "document", "balance", "secret", "secret_salt", "created_at"}).
+					NewRows([]string{"id", "name", "document", "balance", "reserved", "currency", "secret", "secret_salt", "role", "status", "created_at"}).
 					AddRow(
 						accountExample.ID,
 						accountExample.Name,
 						accountExample.Document,
 						accountExample.Balance,
+						accountExample.Reserved,
+						accountExample.Currency,
 						accountExample.Secret,
 						accountExample.SecretSalt,
+						accountExample.Role,
+						accountExample.Status,
 						accountExample.CreatedAt,
 					)
 				mock.
@@ -268,7 +351,7 @@ func TestGetByIDOrDocument(t *testing.T) {
 			ExpectedError: nil,
 			PrepareMockDB: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.
-					NewRows([]string{"id", "name", "document", "balance", "secret", "secret_salt", "created_at"})
+					NewRows([]string{"id", "name", "document", "balance", "currency", "secret", "secret_salt", "role", "status", "created_at"})
 				mock.
 					ExpectQuery(query).
 					WithArgs("id_or_document").
@@ -307,17 +390,297 @@ func TestGetByIDOrDocument(t *testing.T) {
 	}
 }
 
-func TestUpdateBalance(t *testing.T) {
-	query := regexp.QuoteMeta("UPDATE accounts SET balance = $1 WHERE id = $2")
+func TestGetByIDForUpdate(t *testing.T) {
+	var (
+		query          = regexp.QuoteMeta(`SELECT id, name, document, balance, reserved, currency, status, created_at FROM accounts WHERE id = $1 FOR UPDATE`)
+		accountExample = model.Account{
+			ID:       "account_id",
+			Name:     "Account Test",
+			Document: "12312312312",
+			Balance:  100,
+			Reserved: 30,
+			Currency: "BRL",
+			Status:   model.AccountStatusFrozen,
+		}
+	)
+
+	cases := map[string]struct {
+		InputData     string
+		ExpectedData  *model.Account
+		ExpectedError error
+		PrepareMockDB func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			InputData:     "account_id",
+			ExpectedData:  &accountExample,
+			ExpectedError: nil,
+			PrepareMockDB: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.
+					NewRows([]string{"id", "name", "document", "balance", "reserved", "currency", "status", "created_at"}).
+					AddRow(
+						accountExample.ID,
+						accountExample.Name,
+						accountExample.Document,
+						accountExample.Balance,
+						accountExample.Reserved,
+						accountExample.Currency,
+						accountExample.Status,
+						accountExample.CreatedAt,
+					)
+				mock.
+					ExpectQuery(query).
+					WithArgs("account_id").
+					WillReturnRows(rows)
+			},
+		},
+		"should return success: account not found": {
+			InputData:     "account_id",
+			ExpectedData:  nil,
+			ExpectedError: nil,
+			PrepareMockDB: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "name", "document", "balance", "reserved", "currency", "status", "created_at"})
+				mock.
+					ExpectQuery(query).
+					WithArgs("account_id").
+					WillReturnRows(rows)
+			},
+		},
+		"should return error": {
+			InputData:     "account_id",
+			ExpectedData:  nil,
+			ExpectedError: errors.New("fail"),
+			PrepareMockDB: func(mock sqlmock.Sqlmock) {
+				mock.
+					ExpectQuery(query).
+					WithArgs("account_id").
+					WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			conn, mock := test.GetSQLMock()
+
+			cs.PrepareMockDB(mock)
+
+			repo := NewRepository(Options{
+				Logger: logger.New(""),
+				DB:     db.NewExtendedDB(conn),
+			})
+
+			data, err := repo.GetByIDForUpdate(context.Background(), cs.InputData)
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestDebit(t *testing.T) {
+	query := regexp.QuoteMeta("UPDATE accounts SET balance = balance - $1 WHERE id = $2 AND balance - reserved >= $1")
+	cases := map[string]struct {
+		InputAccountID string
+		InputAmount    int64
+		ExpectedData   bool
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			InputAccountID: "account_id",
+			InputAmount:    1000,
+			ExpectedData:   true,
+			ExpectedError:  nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs(1000, "account_id").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		"should return success: insufficient funds": {
+			InputAccountID: "account_id",
+			InputAmount:    1000,
+			ExpectedData:   false,
+			ExpectedError:  nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs(1000, "account_id").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+		"should return error": {
+			InputAccountID: "account_id",
+			InputAmount:    1000,
+			ExpectedData:   false,
+			ExpectedError:  errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs(1000, "account_id").
+					WillReturnError(errors.New("fail"))
+			},
+		},
+		"should return error on rows affected": {
+			InputAccountID: "account_id",
+			InputAmount:    1000,
+			ExpectedData:   false,
+			ExpectedError:  errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs(1000, "account_id").
+					WillReturnResult(sqlmock.NewErrorResult(errors.New("fail")))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			dbMock, sqlMock := test.GetSQLMock()
+			repo := NewRepository(Options{
+				Logger: logger.New(""),
+				DB:     db.NewExtendedDB(dbMock),
+			})
+
+			cs.PrepareMockSQL(sqlMock)
+
+			data, err := repo.Debit(context.Background(), cs.InputAccountID, cs.InputAmount)
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestCredit(t *testing.T) {
+	query := regexp.QuoteMeta("UPDATE accounts SET balance = balance + $1 WHERE id = $2")
+	cases := map[string]struct {
+		InputAccountID string
+		InputAmount    int64
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			InputAccountID: "account_id",
+			InputAmount:    1000,
+			ExpectedError:  nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs(1000, "account_id").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		"should return error": {
+			InputAccountID: "account_id",
+			InputAmount:    1000,
+			ExpectedError:  errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs(1000, "account_id").
+					WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			dbMock, sqlMock := test.GetSQLMock()
+			repo := NewRepository(Options{
+				Logger: logger.New(""),
+				DB:     db.NewExtendedDB(dbMock),
+			})
+
+			cs.PrepareMockSQL(sqlMock)
+
+			err := repo.Credit(context.Background(), cs.InputAccountID, cs.InputAmount)
+
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestReserve(t *testing.T) {
+	query := regexp.QuoteMeta("UPDATE accounts SET reserved = reserved + $1 WHERE id = $2 AND balance - reserved >= $1")
+	cases := map[string]struct {
+		InputAccountID string
+		InputAmount    int64
+		ExpectedData   bool
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			InputAccountID: "account_id",
+			InputAmount:    1000,
+			ExpectedData:   true,
+			ExpectedError:  nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs(1000, "account_id").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		"should return success: insufficient funds": {
+			InputAccountID: "account_id",
+			InputAmount:    1000,
+			ExpectedData:   false,
+			ExpectedError:  nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs(1000, "account_id").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+		"should return error": {
+			InputAccountID: "account_id",
+			InputAmount:    1000,
+			ExpectedData:   false,
+			ExpectedError:  errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs(1000, "account_id").
+					WillReturnError(errors.New("fail"))
+			},
+		},
+		"should return error on rows affected": {
+			InputAccountID: "account_id",
+			InputAmount:    1000,
+			ExpectedData:   false,
+			ExpectedError:  errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs(1000, "account_id").
+					WillReturnResult(sqlmock.NewErrorResult(errors.New("fail")))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			dbMock, sqlMock := test.GetSQLMock()
+			repo := NewRepository(Options{
+				Logger: logger.New(""),
+				DB:     db.NewExtendedDB(dbMock),
+			})
+
+			cs.PrepareMockSQL(sqlMock)
+
+			data, err := repo.Reserve(context.Background(), cs.InputAccountID, cs.InputAmount)
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestRelease(t *testing.T) {
+	query := regexp.QuoteMeta("UPDATE accounts SET reserved = reserved - $1 WHERE id = $2")
 	cases := map[string]struct {
 		InputAccountID string
-		InputBalance   int64
+		InputAmount    int64
 		ExpectedError  error
 		PrepareMockSQL func(mock sqlmock.Sqlmock)
 	}{
 		"should return success": {
 			InputAccountID: "account_id",
-			InputBalance:   1000,
+			InputAmount:    1000,
 			ExpectedError:  nil,
 			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
 				mock.ExpectExec(query).
@@ -327,7 +690,7 @@ func TestUpdateBalance(t *testing.T) {
 		},
 		"should return error": {
 			InputAccountID: "account_id",
-			InputBalance:   1000,
+			InputAmount:    1000,
 			ExpectedError:  errors.New("fail"),
 			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
 				mock.ExpectExec(query).
@@ -347,7 +710,89 @@ func TestUpdateBalance(t *testing.T) {
 
 			cs.PrepareMockSQL(sqlMock)
 
-			err := repo.UpdateBalance(context.Background(), cs.InputAccountID, cs.InputBalance)
+			err := repo.Release(context.Background(), cs.InputAccountID, cs.InputAmount)
+
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestUpdateStatus(t *testing.T) {
+	query := regexp.QuoteMeta("UPDATE accounts SET status = $1 WHERE id = $2")
+	cases := map[string]struct {
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs(model.AccountStatusFrozen, "account_id").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		"should return error": {
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs(model.AccountStatusFrozen, "account_id").
+					WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			dbMock, sqlMock := test.GetSQLMock()
+			repo := NewRepository(Options{
+				Logger: logger.New(""),
+				DB:     db.NewExtendedDB(dbMock),
+			})
+
+			cs.PrepareMockSQL(sqlMock)
+
+			err := repo.UpdateStatus(context.Background(), "account_id", model.AccountStatusFrozen)
+
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestUpdateSecret(t *testing.T) {
+	query := regexp.QuoteMeta("UPDATE accounts SET secret = $1, secret_salt = '' WHERE id = $2")
+	cases := map[string]struct {
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs("secret", "account_id").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		"should return error": {
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs("secret", "account_id").
+					WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			dbMock, sqlMock := test.GetSQLMock()
+			repo := NewRepository(Options{
+				Logger: logger.New(""),
+				DB:     db.NewExtendedDB(dbMock),
+			})
+
+			cs.PrepareMockSQL(sqlMock)
+
+			err := repo.UpdateSecret(context.Background(), "account_id", "secret")
 
 			assert.Equal(t, cs.ExpectedError, err)
 		})
@@ -0,0 +1,128 @@
+//go:generate mockgen -source=${GOFILE} -package=${GOPACKAGE} -destination=${GOPACKAGE}_mock.go
+
+package apikey
+
+import (
+	"context"
+	"database/sql"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/db"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/transaction"
+	"time"
+)
+
+type (
+	Options struct {
+		Logger logger.Logger
+		DB     db.Connection
+	}
+	Repository interface {
+		Create(ctx context.Context, apiKey model.APIKey) (*model.GeneratedData, error)
+		GetByPrefix(ctx context.Context, prefix string) (*model.APIKey, error)
+		ListByAccount(ctx context.Context, accountID string) ([]model.APIKey, error)
+		Revoke(ctx context.Context, accountID string, id string) (bool, error)
+		UpdateLastUsed(ctx context.Context, id string, at time.Time) error
+		WithTransaction(conn transaction.Transaction) Repository
+	}
+	repositoryImpl struct {
+		logger logger.Logger
+		db     db.Connection
+	}
+)
+
+func NewRepository(opts Options) Repository {
+	return &repositoryImpl{
+		logger: opts.Logger.WithLocation().WithPreffix("repository.apikey"),
+		db:     opts.DB,
+	}
+}
+
+func (r *repositoryImpl) Create(ctx context.Context, apiKey model.APIKey) (*model.GeneratedData, error) {
+	query := `
+		INSERT INTO api_keys(account_id, name, prefix, key_hash, scopes, expires_at) 
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at`
+	generatedData := new(model.GeneratedData)
+	err := r.db.GetContext(
+		ctx,
+		generatedData,
+		query,
+		apiKey.AccountID,
+		apiKey.Name,
+		apiKey.Prefix,
+		apiKey.KeyHash,
+		apiKey.Scopes,
+		apiKey.ExpiresAt,
+	)
+	if err != nil {
+		r.logger.Error(err)
+		return nil, err
+	}
+	return generatedData, nil
+}
+
+// GetByPrefix returns the key identified by prefix, even when it is revoked or expired, or nil when there is none.
+func (r *repositoryImpl) GetByPrefix(ctx context.Context, prefix string) (*model.APIKey, error) {
+	query := `
+		SELECT id, account_id, name, prefix, key_hash, scopes, expires_at, last_used_at, revoked_at, created_at 
+		FROM api_keys WHERE prefix = $1`
+	apiKey := new(model.APIKey)
+	err := r.db.GetContext(ctx, apiKey, query, prefix)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		r.logger.Error(err)
+		return nil, err
+	}
+	return apiKey, nil
+}
+
+// ListByAccount returns the keys of the account that were not revoked, newest first.
+func (r *repositoryImpl) ListByAccount(ctx context.Context, accountID string) ([]model.APIKey, error) {
+	query := `
+		SELECT id, account_id, name, prefix, key_hash, scopes, expires_at, last_used_at, revoked_at, created_at 
+		FROM api_keys WHERE account_id = $1 AND revoked_at IS NULL
+		ORDER BY created_at DESC, id DESC`
+	apiKeys := make([]model.APIKey, 0)
+	err := r.db.SelectContext(ctx, &apiKeys, query, accountID)
+	if err != nil {
+		r.logger.Error(err)
+		return nil, err
+	}
+	return apiKeys, nil
+}
+
+// Revoke disables the key of the account for good. It returns false when the account has no such key or it was
+// already revoked.
+func (r *repositoryImpl) Revoke(ctx context.Context, accountID string, id string) (bool, error) {
+	query := "UPDATE api_keys SET revoked_at = CURRENT_TIMESTAMP WHERE id = $1 AND account_id = $2 AND revoked_at IS NULL"
+	result, err := r.db.ExecContext(ctx, query, id, accountID)
+	if err != nil {
+		r.logger.Error(err)
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.Error(err)
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+func (r *repositoryImpl) UpdateLastUsed(ctx context.Context, id string, at time.Time) error {
+	query := "UPDATE api_keys SET last_used_at = $1 WHERE id = $2"
+	_, err := r.db.ExecContext(ctx, query, at, id)
+	if err != nil {
+		r.logger.Error(err)
+	}
+	return err
+}
+
+func (r *repositoryImpl) WithTransaction(conn transaction.Transaction) Repository {
+	return &repositoryImpl{
+		logger: r.logger,
+		db:     conn,
+	}
+}
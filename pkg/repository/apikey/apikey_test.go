@@ -0,0 +1,295 @@
+package apikey
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/db"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/test"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+	"time"
+)
+
+var (
+	currentTime   = time.Now()
+	apiKeyExample = model.APIKey{
+		ID:        "api_key_id",
+		AccountID: "account_id",
+		Name:      "merchant backend",
+		Prefix:    "0a1b2c3d4e5f",
+		KeyHash:   "key_hash",
+		Scopes:    pq.StringArray{model.PermissionReadAccounts},
+		ExpiresAt: &currentTime,
+		CreatedAt: currentTime,
+	}
+	apiKeyColumns = []string{
+		"id", "account_id", "name", "prefix", "key_hash", "scopes", "expires_at", "last_used_at", "revoked_at", "created_at",
+	}
+)
+
+func addAPIKeyRow(rows *sqlmock.Rows, apiKey model.APIKey) *sqlmock.Rows {
+	return rows.AddRow(
+		apiKey.ID,
+		apiKey.AccountID,
+		apiKey.Name,
+		apiKey.Prefix,
+		apiKey.KeyHash,
+		"{accounts.read}",
+		apiKey.ExpiresAt,
+		apiKey.LastUsedAt,
+		apiKey.RevokedAt,
+		apiKey.CreatedAt,
+	)
+}
+
+func TestCreate(t *testing.T) {
+	var (
+		query = regexp.QuoteMeta(`
+		INSERT INTO api_keys(account_id, name, prefix, key_hash, scopes, expires_at) 
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at`)
+		generatedDataExample = model.GeneratedData{ID: "api_key_id", CreatedAt: currentTime}
+	)
+
+	cases := map[string]struct {
+		ExpectedData   *model.GeneratedData
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedData:  &generatedDataExample,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "created_at"}).AddRow("api_key_id", currentTime)
+				mock.ExpectQuery(query).
+					WithArgs("account_id", "merchant backend", "0a1b2c3d4e5f", "key_hash", "{\"accounts.read\"}", currentTime).
+					WillReturnRows(rows)
+			},
+		},
+		"should return error": {
+			ExpectedData:  nil,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				dbConn, sqlMock = test.GetSQLMock()
+				repository      = NewRepository(Options{
+					Logger: logger.New(""),
+					DB:     db.NewExtendedDB(dbConn),
+				})
+			)
+
+			cs.PrepareMockSQL(sqlMock)
+
+			data, err := repository.Create(context.Background(), apiKeyExample)
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestGetByPrefix(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		SELECT id, account_id, name, prefix, key_hash, scopes, expires_at, last_used_at, revoked_at, created_at 
+		FROM api_keys WHERE prefix = $1`)
+
+	cases := map[string]struct {
+		ExpectedData   *model.APIKey
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedData:  &apiKeyExample,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				rows := addAPIKeyRow(sqlmock.NewRows(apiKeyColumns), apiKeyExample)
+				mock.ExpectQuery(query).WithArgs("0a1b2c3d4e5f").WillReturnRows(rows)
+			},
+		},
+		"should return success: not found": {
+			ExpectedData:  nil,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs("0a1b2c3d4e5f").WillReturnError(sql.ErrNoRows)
+			},
+		},
+		"should return error": {
+			ExpectedData:  nil,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs("0a1b2c3d4e5f").WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				dbConn, sqlMock = test.GetSQLMock()
+				repository      = NewRepository(Options{
+					Logger: logger.New(""),
+					DB:     db.NewExtendedDB(dbConn),
+				})
+			)
+
+			cs.PrepareMockSQL(sqlMock)
+
+			data, err := repository.GetByPrefix(context.Background(), "0a1b2c3d4e5f")
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestListByAccount(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		SELECT id, account_id, name, prefix, key_hash, scopes, expires_at, last_used_at, revoked_at, created_at 
+		FROM api_keys WHERE account_id = $1 AND revoked_at IS NULL
+		ORDER BY created_at DESC, id DESC`)
+
+	cases := map[string]struct {
+		ExpectedData   []model.APIKey
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedData:  []model.APIKey{apiKeyExample},
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				rows := addAPIKeyRow(sqlmock.NewRows(apiKeyColumns), apiKeyExample)
+				mock.ExpectQuery(query).WithArgs("account_id").WillReturnRows(rows)
+			},
+		},
+		"should return error": {
+			ExpectedData:  nil,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs("account_id").WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				dbConn, sqlMock = test.GetSQLMock()
+				repository      = NewRepository(Options{
+					Logger: logger.New(""),
+					DB:     db.NewExtendedDB(dbConn),
+				})
+			)
+
+			cs.PrepareMockSQL(sqlMock)
+
+			data, err := repository.ListByAccount(context.Background(), "account_id")
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestRevoke(t *testing.T) {
+	query := regexp.QuoteMeta("UPDATE api_keys SET revoked_at = CURRENT_TIMESTAMP WHERE id = $1 AND account_id = $2 AND revoked_at IS NULL")
+
+	cases := map[string]struct {
+		ExpectedData   bool
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedData:  true,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).WithArgs("api_key_id", "account_id").WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		"should return success: not found": {
+			ExpectedData:  false,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).WithArgs("api_key_id", "account_id").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+		"should return error": {
+			ExpectedData:  false,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).WithArgs("api_key_id", "account_id").WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				dbConn, sqlMock = test.GetSQLMock()
+				repository      = NewRepository(Options{
+					Logger: logger.New(""),
+					DB:     db.NewExtendedDB(dbConn),
+				})
+			)
+
+			cs.PrepareMockSQL(sqlMock)
+
+			data, err := repository.Revoke(context.Background(), "account_id", "api_key_id")
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestUpdateLastUsed(t *testing.T) {
+	query := regexp.QuoteMeta("UPDATE api_keys SET last_used_at = $1 WHERE id = $2")
+
+	cases := map[string]struct {
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).WithArgs(currentTime, "api_key_id").WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		"should return error": {
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).WithArgs(currentTime, "api_key_id").WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				dbConn, sqlMock = test.GetSQLMock()
+				repository      = NewRepository(Options{
+					Logger: logger.New(""),
+					DB:     db.NewExtendedDB(dbConn),
+				})
+			)
+
+			cs.PrepareMockSQL(sqlMock)
+
+			err := repository.UpdateLastUsed(context.Background(), "api_key_id", currentTime)
+
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
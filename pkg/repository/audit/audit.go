@@ -0,0 +1,51 @@
+//go:generate mockgen -source=${GOFILE} -package=${GOPACKAGE} -destination=${GOPACKAGE}_mock.go
+
+package audit
+
+import (
+	"context"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/db"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/transaction"
+)
+
+type (
+	Options struct {
+		Logger logger.Logger
+		DB     db.Connection
+	}
+	Repository interface {
+		Create(ctx context.Context, entry model.AuditEntry) error
+		WithTransaction(conn transaction.Transaction) Repository
+	}
+	repositoryImpl struct {
+		logger logger.Logger
+		db     db.Connection
+	}
+)
+
+func NewRepository(opts Options) Repository {
+	return &repositoryImpl{
+		logger: opts.Logger.WithLocation().WithPreffix("repository.audit"),
+		db:     opts.DB,
+	}
+}
+
+func (r *repositoryImpl) Create(ctx context.Context, entry model.AuditEntry) error {
+	query := `
+		INSERT INTO audit_logs(action, account_id, document, ip, reason) 
+		VALUES (:action, :account_id, :document, :ip, :reason)`
+	_, err := r.db.NamedExecContext(ctx, query, entry)
+	if err != nil {
+		r.logger.Error(err)
+	}
+	return err
+}
+
+func (r *repositoryImpl) WithTransaction(conn transaction.Transaction) Repository {
+	return &repositoryImpl{
+		logger: r.logger,
+		db:     conn,
+	}
+}
@@ -0,0 +1,83 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/db"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/test"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+)
+
+func TestCreate(t *testing.T) {
+	var (
+		accountID    = "account_id"
+		entryExample = model.AuditEntry{
+			Action:    model.AuditActionLoginFailed,
+			AccountID: &accountID,
+			Document:  "12312312312",
+			IP:        "192.0.2.1",
+			Reason:    "auth.invalid-credentials",
+		}
+		query = regexp.QuoteMeta(`
+			INSERT INTO audit_logs(action, account_id, document, ip, reason) 
+			VALUES (?, ?, ?, ?, ?)
+		`)
+	)
+	cases := map[string]struct {
+		InputData      model.AuditEntry
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			InputData:     entryExample,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs(model.AuditActionLoginFailed, accountID, "12312312312", "192.0.2.1", "auth.invalid-credentials").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		"should return error": {
+			InputData:     entryExample,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs(model.AuditActionLoginFailed, accountID, "12312312312", "192.0.2.1", "auth.invalid-credentials").
+					WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				dbConn, sqlMock = test.GetSQLMock()
+				repository      = NewRepository(Options{
+					Logger: logger.New(""),
+					DB:     db.NewExtendedDB(dbConn),
+				})
+			)
+
+			cs.PrepareMockSQL(sqlMock)
+
+			err := repository.Create(context.Background(), cs.InputData)
+
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestWithTransaction(t *testing.T) {
+	repoWithDB := &repositoryImpl{
+		db: db.ExtendedDB(nil),
+	}
+	repoWithTx := &repositoryImpl{
+		db: db.ExtendedTx(nil),
+	}
+	assert.Equal(t, repoWithTx, repoWithDB.WithTransaction(db.ExtendedTx(nil)))
+}
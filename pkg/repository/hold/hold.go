@@ -0,0 +1,141 @@
+//go:generate mockgen -source=${GOFILE} -package=${GOPACKAGE} -destination=${GOPACKAGE}_mock.go
+
+package hold
+
+import (
+	"context"
+	"database/sql"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/db"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/transaction"
+	"time"
+)
+
+type (
+	Options struct {
+		Logger logger.Logger
+		DB     db.Connection
+	}
+	Repository interface {
+		Create(ctx context.Context, hold model.Hold) (*model.GeneratedData, error)
+		List(ctx context.Context, accountID string, status string) ([]model.Hold, error)
+		GetByIDForUpdate(ctx context.Context, id string) (*model.Hold, error)
+		ListExpiredForUpdate(ctx context.Context, now time.Time, limit int) ([]model.Hold, error)
+		Close(ctx context.Context, id string, status string, capturedAmount int64, transferID *string) error
+		WithTransaction(conn transaction.Transaction) Repository
+	}
+	repositoryImpl struct {
+		logger logger.Logger
+		db     db.Connection
+	}
+)
+
+func NewRepository(opts Options) Repository {
+	return &repositoryImpl{
+		logger: opts.Logger.WithLocation().WithPreffix("repository.hold"),
+		db:     opts.DB,
+	}
+}
+
+func (r *repositoryImpl) Create(ctx context.Context, hold model.Hold) (*model.GeneratedData, error) {
+	query := `
+		INSERT INTO holds(
+			origin_account_id, target_account_id, amount, currency, target_amount, target_currency, exchange_rate, 
+			reserved, expires_at
+		) 
+		VALUES (
+			:origin_account_id, :target_account_id, :amount, :currency, :target_amount, :target_currency, :exchange_rate, 
+			:reserved, :expires_at
+		)
+		RETURNING id, created_at`
+	generatedData := new(model.GeneratedData)
+	err := r.db.NamedGetContext(ctx, query, generatedData, hold)
+	if err != nil {
+		r.logger.Error(err)
+		return nil, err
+	}
+	return generatedData, nil
+}
+
+// List returns the holds made or received by the account, newest first, optionally filtered by status when it is not
+// empty.
+func (r *repositoryImpl) List(ctx context.Context, accountID string, status string) ([]model.Hold, error) {
+	query := `
+		SELECT id, origin_account_id, target_account_id, amount, currency, COALESCE(target_amount, 0) AS target_amount, 
+			COALESCE(target_currency, '') AS target_currency, COALESCE(exchange_rate, 0) AS exchange_rate, reserved, 
+			captured_amount, status, transfer_id, expires_at, closed_at, created_at 
+		FROM holds 
+		WHERE (origin_account_id = $1 OR target_account_id = $1) AND ($2 = '' OR status = $2) 
+		ORDER BY created_at DESC, id DESC`
+	holds := make([]model.Hold, 0)
+	err := r.db.SelectContext(ctx, &holds, query, accountID, status)
+	if err != nil {
+		r.logger.Error(err)
+		return nil, err
+	}
+	return holds, nil
+}
+
+// GetByIDForUpdate reads the hold locking its row until the end of the current transaction, or returns nil when there
+// is none.
+func (r *repositoryImpl) GetByIDForUpdate(ctx context.Context, id string) (*model.Hold, error) {
+	query := `
+		SELECT id, origin_account_id, target_account_id, amount, currency, COALESCE(target_amount, 0) AS target_amount, 
+			COALESCE(target_currency, '') AS target_currency, COALESCE(exchange_rate, 0) AS exchange_rate, reserved, 
+			captured_amount, status, transfer_id, expires_at, closed_at, created_at 
+		FROM holds 
+		WHERE id = $1 
+		FOR UPDATE`
+	hold := new(model.Hold)
+	err := r.db.GetContext(ctx, hold, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		r.logger.Error(err)
+		return nil, err
+	}
+	return hold, nil
+}
+
+// ListExpiredForUpdate returns up to limit active holds that expired until now, locking their rows until the end of
+// the current transaction. Rows locked by another instance are skipped, so each hold is expired only once.
+func (r *repositoryImpl) ListExpiredForUpdate(ctx context.Context, now time.Time, limit int) ([]model.Hold, error) {
+	query := `
+		SELECT id, origin_account_id, target_account_id, amount, currency, COALESCE(target_amount, 0) AS target_amount, 
+			COALESCE(target_currency, '') AS target_currency, COALESCE(exchange_rate, 0) AS exchange_rate, reserved, 
+			captured_amount, status, transfer_id, expires_at, closed_at, created_at 
+		FROM holds 
+		WHERE status = 'active' AND expires_at <= $1 
+		ORDER BY expires_at 
+		LIMIT $2 
+		FOR UPDATE SKIP LOCKED`
+	holds := make([]model.Hold, 0)
+	err := r.db.SelectContext(ctx, &holds, query, now, limit)
+	if err != nil {
+		r.logger.Error(err)
+		return nil, err
+	}
+	return holds, nil
+}
+
+// Close moves the hold out of active, with the amount captured and the transfer made when it was captured.
+func (r *repositoryImpl) Close(ctx context.Context, id string, status string, capturedAmount int64, transferID *string) error {
+	query := `
+		UPDATE holds 
+		SET status = $1, captured_amount = $2, transfer_id = $3, closed_at = CURRENT_TIMESTAMP 
+		WHERE id = $4`
+	_, err := r.db.ExecContext(ctx, query, status, capturedAmount, transferID, id)
+	if err != nil {
+		r.logger.Error(err)
+	}
+	return err
+}
+
+func (r *repositoryImpl) WithTransaction(conn transaction.Transaction) Repository {
+	return &repositoryImpl{
+		logger: r.logger,
+		db:     conn,
+	}
+}
@@ -0,0 +1,323 @@
+package hold
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/db"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/test"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+	"time"
+)
+
+var (
+	currentTime = time.Now()
+	holdExample = model.Hold{
+		ID:              "hold_id",
+		OriginAccountID: "origin_account_id",
+		TargetAccountID: "target_account_id",
+		Amount:          10000,
+		Currency:        "BRL",
+		TargetAmount:    10000,
+		TargetCurrency:  "BRL",
+		ExchangeRate:    "1",
+		Reserved:        10050,
+		Status:          model.HoldStatusActive,
+		ExpiresAt:       currentTime.Add(24 * time.Hour),
+		CreatedAt:       currentTime,
+	}
+	holdColumns = []string{
+		"id", "origin_account_id", "target_account_id", "amount", "currency", "target_amount", "target_currency",
+		"exchange_rate", "reserved", "captured_amount", "status", "transfer_id", "expires_at", "closed_at", "created_at",
+	}
+	holdRow = []driver.Value{
+		"hold_id", "origin_account_id", "target_account_id", 10000, "BRL", 10000, "BRL", "1", 10050, 0,
+		model.HoldStatusActive, nil, currentTime.Add(24 * time.Hour), nil, currentTime,
+	}
+)
+
+func TestCreate(t *testing.T) {
+	var (
+		query = regexp.QuoteMeta(`
+		INSERT INTO holds(
+			origin_account_id, target_account_id, amount, currency, target_amount, target_currency, exchange_rate, 
+			reserved, expires_at
+		) 
+		VALUES (
+			?, ?, ?, ?, ?, ?, ?, 
+			?, ?
+		)
+		RETURNING id, created_at`)
+		args = []driver.Value{
+			"origin_account_id", "target_account_id", 10000, "BRL", 10000, "BRL", "1", 10050, holdExample.ExpiresAt,
+		}
+		generatedDataExample = model.GeneratedData{ID: "hold_id", CreatedAt: currentTime}
+	)
+
+	cases := map[string]struct {
+		ExpectedData   *model.GeneratedData
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedData:  &generatedDataExample,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "created_at"}).AddRow("hold_id", currentTime)
+				mock.ExpectPrepare(query).
+					ExpectQuery().
+					WithArgs(args...).
+					WillReturnRows(rows)
+			},
+		},
+		"should return error": {
+			ExpectedData:  nil,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(query).
+					ExpectQuery().
+					WithArgs(args...).
+					WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				dbConn, sqlMock = test.GetSQLMock()
+				repository      = NewRepository(Options{
+					Logger: logger.New(""),
+					DB:     db.NewExtendedDB(dbConn),
+				})
+			)
+
+			cs.PrepareMockSQL(sqlMock)
+
+			data, err := repository.Create(context.Background(), holdExample)
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		SELECT id, origin_account_id, target_account_id, amount, currency, COALESCE(target_amount, 0) AS target_amount, 
+			COALESCE(target_currency, '') AS target_currency, COALESCE(exchange_rate, 0) AS exchange_rate, reserved, 
+			captured_amount, status, transfer_id, expires_at, closed_at, created_at 
+		FROM holds 
+		WHERE (origin_account_id = $1 OR target_account_id = $1) AND ($2 = '' OR status = $2) 
+		ORDER BY created_at DESC, id DESC`)
+
+	cases := map[string]struct {
+		ExpectedData   []model.Hold
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedData:  []model.Hold{holdExample},
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(holdColumns).AddRow(holdRow...)
+				mock.ExpectQuery(query).WithArgs("origin_account_id", model.HoldStatusActive).WillReturnRows(rows)
+			},
+		},
+		"should return error": {
+			ExpectedData:  nil,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs("origin_account_id", model.HoldStatusActive).
+					WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				dbConn, sqlMock = test.GetSQLMock()
+				repository      = NewRepository(Options{
+					Logger: logger.New(""),
+					DB:     db.NewExtendedDB(dbConn),
+				})
+			)
+
+			cs.PrepareMockSQL(sqlMock)
+
+			data, err := repository.List(context.Background(), "origin_account_id", model.HoldStatusActive)
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestGetByIDForUpdate(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		SELECT id, origin_account_id, target_account_id, amount, currency, COALESCE(target_amount, 0) AS target_amount, 
+			COALESCE(target_currency, '') AS target_currency, COALESCE(exchange_rate, 0) AS exchange_rate, reserved, 
+			captured_amount, status, transfer_id, expires_at, closed_at, created_at 
+		FROM holds 
+		WHERE id = $1 
+		FOR UPDATE`)
+
+	cases := map[string]struct {
+		ExpectedData   *model.Hold
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedData:  &holdExample,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(holdColumns).AddRow(holdRow...)
+				mock.ExpectQuery(query).WithArgs("hold_id").WillReturnRows(rows)
+			},
+		},
+		"should return success: not found": {
+			ExpectedData:  nil,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs("hold_id").WillReturnError(sql.ErrNoRows)
+			},
+		},
+		"should return error": {
+			ExpectedData:  nil,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs("hold_id").WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				dbConn, sqlMock = test.GetSQLMock()
+				repository      = NewRepository(Options{
+					Logger: logger.New(""),
+					DB:     db.NewExtendedDB(dbConn),
+				})
+			)
+
+			cs.PrepareMockSQL(sqlMock)
+
+			data, err := repository.GetByIDForUpdate(context.Background(), "hold_id")
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestListExpiredForUpdate(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		SELECT id, origin_account_id, target_account_id, amount, currency, COALESCE(target_amount, 0) AS target_amount, 
+			COALESCE(target_currency, '') AS target_currency, COALESCE(exchange_rate, 0) AS exchange_rate, reserved, 
+			captured_amount, status, transfer_id, expires_at, closed_at, created_at 
+		FROM holds 
+		WHERE status = 'active' AND expires_at <= $1 
+		ORDER BY expires_at 
+		LIMIT $2 
+		FOR UPDATE SKIP LOCKED`)
+
+	cases := map[string]struct {
+		ExpectedData   []model.Hold
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedData:  []model.Hold{holdExample},
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(holdColumns).AddRow(holdRow...)
+				mock.ExpectQuery(query).WithArgs(currentTime, 100).WillReturnRows(rows)
+			},
+		},
+		"should return error": {
+			ExpectedData:  nil,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs(currentTime, 100).WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				dbConn, sqlMock = test.GetSQLMock()
+				repository      = NewRepository(Options{
+					Logger: logger.New(""),
+					DB:     db.NewExtendedDB(dbConn),
+				})
+			)
+
+			cs.PrepareMockSQL(sqlMock)
+
+			data, err := repository.ListExpiredForUpdate(context.Background(), currentTime, 100)
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestClose(t *testing.T) {
+	var (
+		transferID = "transfer_id"
+		query      = regexp.QuoteMeta(`
+		UPDATE holds 
+		SET status = $1, captured_amount = $2, transfer_id = $3, closed_at = CURRENT_TIMESTAMP 
+		WHERE id = $4`)
+	)
+
+	cases := map[string]struct {
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs(model.HoldStatusCaptured, 6000, transferID, "hold_id").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		"should return error": {
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs(model.HoldStatusCaptured, 6000, transferID, "hold_id").
+					WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				dbConn, sqlMock = test.GetSQLMock()
+				repository      = NewRepository(Options{
+					Logger: logger.New(""),
+					DB:     db.NewExtendedDB(dbConn),
+				})
+			)
+
+			cs.PrepareMockSQL(sqlMock)
+
+			err := repository.Close(context.Background(), "hold_id", model.HoldStatusCaptured, 6000, &transferID)
+
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+//go:generate mockgen -source=${GOFILE} -package=${GOPACKAGE} -destination=${GOPACKAGE}_mock.go
+
+package ledger
+
+import (
+	"context"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/db"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/transaction"
+	"time"
+)
+
+type (
+	Options struct {
+		Logger logger.Logger
+		DB     db.Connection
+	}
+	Repository interface {
+		Create(ctx context.Context, entries []model.LedgerEntry) error
+		ListDivergences(ctx context.Context) ([]model.LedgerDivergence, error)
+		GetOpeningBalance(ctx context.Context, accountID string, from time.Time, to time.Time) (int64, error)
+		WithTransaction(conn transaction.Transaction) Repository
+	}
+	repositoryImpl struct {
+		logger logger.Logger
+		db     db.Connection
+	}
+)
+
+func NewRepository(opts Options) Repository {
+	return &repositoryImpl{
+		logger: opts.Logger.WithLocation().WithPreffix("repository.ledger"),
+		db:     opts.DB,
+	}
+}
+
+// Create inserts the entries. Entries of internal ledger accounts, like the opening balances funding, have no
+// account_id and carry the currency of the amount instead.
+func (r *repositoryImpl) Create(ctx context.Context, entries []model.LedgerEntry) error {
+	query := `
+		INSERT INTO ledger_entries(account_id, ledger_account, currency, transfer_id, type, amount) 
+		VALUES (NULLIF(:account_id, ''), NULLIF(:ledger_account, ''), NULLIF(:currency, ''), :transfer_id, :type, :amount)`
+	_, err := r.db.NamedExecContext(ctx, query, entries)
+	if err != nil {
+		r.logger.Error(err)
+	}
+	return err
+}
+
+// ListDivergences returns every account whose stored balance differs from the sum of its ledger entries.
+func (r *repositoryImpl) ListDivergences(ctx context.Context) ([]model.LedgerDivergence, error) {
+	query := `
+		SELECT 
+			a.id AS account_id, 
+			a.balance, 
+			COALESCE(SUM(CASE WHEN l.type = 'credit' THEN l.amount ELSE -l.amount END), 0) AS ledger_balance
+		FROM accounts a
+			LEFT JOIN ledger_entries l ON l.account_id = a.id
+		GROUP BY a.id, a.balance
+		HAVING a.balance <> COALESCE(SUM(CASE WHEN l.type = 'credit' THEN l.amount ELSE -l.amount END), 0)`
+	divergences := make([]model.LedgerDivergence, 0)
+	err := r.db.SelectContext(ctx, &divergences, query)
+	if err != nil {
+		r.logger.Error(err)
+		return nil, err
+	}
+	return divergences, nil
+}
+
+// GetOpeningBalance returns the balance of the account right before the first transfer of the period from-to. Entries
+// not tied to a transfer, like the opening deposit, always precede the transfers and are counted up to the end.
+func (r *repositoryImpl) GetOpeningBalance(ctx context.Context, accountID string, from time.Time, to time.Time) (int64, error) {
+	var balance int64
+	query := `
+		SELECT COALESCE(SUM(CASE WHEN type = 'credit' THEN amount ELSE -amount END), 0)
+		FROM ledger_entries
+		WHERE account_id = $1 AND (created_at < $2 OR (transfer_id IS NULL AND created_at < $3))`
+	err := r.db.GetContext(ctx, &balance, query, accountID, from, to)
+	if err != nil {
+		r.logger.Error(err)
+		return 0, err
+	}
+	return balance, nil
+}
+
+func (r *repositoryImpl) WithTransaction(conn transaction.Transaction) Repository {
+	return &repositoryImpl{
+		logger: r.logger,
+		db:     conn,
+	}
+}
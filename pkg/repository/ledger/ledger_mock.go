@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ledger.go
+
+// Package ledger is a generated GoMock package.
+package ledger
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	model "github.com/carlosrodriguesf/bank-api/pkg/model"
+	transaction "github.com/carlosrodriguesf/bank-api/pkg/tool/transaction"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRepository) Create(ctx context.Context, entries []model.LedgerEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entries)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepositoryMockRecorder) Create(ctx, entries interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, entries)
+}
+
+// GetOpeningBalance mocks base method.
+func (m *MockRepository) GetOpeningBalance(ctx context.Context, accountID string, from, to time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpeningBalance", ctx, accountID, from, to)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpeningBalance indicates an expected call of GetOpeningBalance.
+func (mr *MockRepositoryMockRecorder) GetOpeningBalance(ctx, accountID, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpeningBalance", reflect.TypeOf((*MockRepository)(nil).GetOpeningBalance), ctx, accountID, from, to)
+}
+
+// ListDivergences mocks base method.
+func (m *MockRepository) ListDivergences(ctx context.Context) ([]model.LedgerDivergence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDivergences", ctx)
+	ret0, _ := ret[0].([]model.LedgerDivergence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDivergences indicates an expected call of ListDivergences.
+func (mr *MockRepositoryMockRecorder) ListDivergences(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDivergences", reflect.TypeOf((*MockRepository)(nil).ListDivergences), ctx)
+}
+
+// WithTransaction mocks base method.
+func (m *MockRepository) WithTransaction(conn transaction.Transaction) Repository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTransaction", conn)
+	ret0, _ := ret[0].(Repository)
+	return ret0
+}
+
+// WithTransaction indicates an expected call of WithTransaction.
+func (mr *MockRepositoryMockRecorder) WithTransaction(conn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTransaction", reflect.TypeOf((*MockRepository)(nil).WithTransaction), conn)
+}
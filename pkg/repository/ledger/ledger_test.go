@@ -0,0 +1,228 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/db"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/test"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestCreate(t *testing.T) {
+	var (
+		transferID     = "transfer_id"
+		entriesExample = []model.LedgerEntry{
+			{
+				AccountID:  "origin_account_id",
+				TransferID: &transferID,
+				Type:       model.LedgerEntryTypeDebit,
+				Amount:     500,
+			},
+			{
+				AccountID:  "target_account_id",
+				TransferID: &transferID,
+				Type:       model.LedgerEntryTypeCredit,
+				Amount:     500,
+			},
+			{
+				LedgerAccount: model.LedgerAccountOpeningBalances,
+				Currency:      "BRL",
+				Type:          model.LedgerEntryTypeDebit,
+				Amount:        500,
+			},
+		}
+		query = regexp.QuoteMeta(`
+			INSERT INTO ledger_entries(account_id, ledger_account, currency, transfer_id, type, amount) 
+			VALUES (NULLIF(?, ''), NULLIF(?, ''), NULLIF(?, ''), ?, ?, ?),(NULLIF(?, ''), NULLIF(?, ''), NULLIF(?, ''), ?, ?, ?),(NULLIF(?, ''), NULLIF(?, ''), NULLIF(?, ''), ?, ?, ?)
+		`)
+	)
+	cases := map[string]struct {
+		InputData      []model.LedgerEntry
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			InputData:     entriesExample,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs(
+						"origin_account_id", "", "", transferID, model.LedgerEntryTypeDebit, 500,
+						"target_account_id", "", "", transferID, model.LedgerEntryTypeCredit, 500,
+						"", model.LedgerAccountOpeningBalances, "BRL", nil, model.LedgerEntryTypeDebit, 500,
+					).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+		},
+		"should return error": {
+			InputData:     entriesExample,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs(
+						"origin_account_id", "", "", transferID, model.LedgerEntryTypeDebit, 500,
+						"target_account_id", "", "", transferID, model.LedgerEntryTypeCredit, 500,
+						"", model.LedgerAccountOpeningBalances, "BRL", nil, model.LedgerEntryTypeDebit, 500,
+					).
+					WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				dbConn, sqlMock = test.GetSQLMock()
+				repository      = NewRepository(Options{
+					Logger: logger.New(""),
+					DB:     db.NewExtendedDB(dbConn),
+				})
+			)
+
+			cs.PrepareMockSQL(sqlMock)
+
+			err := repository.Create(context.Background(), cs.InputData)
+
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestListDivergences(t *testing.T) {
+	var (
+		divergencesExample = []model.LedgerDivergence{{
+			AccountID:     "account_id",
+			Balance:       1000,
+			LedgerBalance: 500,
+		}}
+		query = regexp.QuoteMeta(`
+			SELECT 
+				a.id AS account_id, 
+				a.balance, 
+				COALESCE(SUM(CASE WHEN l.type = 'credit' THEN l.amount ELSE -l.amount END), 0) AS ledger_balance
+			FROM accounts a
+				LEFT JOIN ledger_entries l ON l.account_id = a.id
+			GROUP BY a.id, a.balance
+			HAVING a.balance <> COALESCE(SUM(CASE WHEN l.type = 'credit' THEN l.amount ELSE -l.amount END), 0)
+		`)
+	)
+	cases := map[string]struct {
+		ExpectedData   []model.LedgerDivergence
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedData:  divergencesExample,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"account_id", "balance", "ledger_balance"})
+				for _, d := range divergencesExample {
+					rows.AddRow(d.AccountID, d.Balance, d.LedgerBalance)
+				}
+				mock.ExpectQuery(query).WillReturnRows(rows)
+			},
+		},
+		"should return success without divergences": {
+			ExpectedData:  make([]model.LedgerDivergence, 0),
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"account_id", "balance", "ledger_balance"})
+				mock.ExpectQuery(query).WillReturnRows(rows)
+			},
+		},
+		"should return error": {
+			ExpectedData:  nil,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				dbConn, sqlMock = test.GetSQLMock()
+				repository      = NewRepository(Options{
+					Logger: logger.New(""),
+					DB:     db.NewExtendedDB(dbConn),
+				})
+			)
+
+			cs.PrepareMockSQL(sqlMock)
+
+			data, err := repository.ListDivergences(context.Background())
+
+			assert.Equal(t, cs.ExpectedError, err)
+			assert.Equal(t, cs.ExpectedData, data)
+		})
+	}
+}
+
+func TestGetOpeningBalance(t *testing.T) {
+	var (
+		from  = time.Now().Add(-time.Hour)
+		to    = time.Now()
+		query = regexp.QuoteMeta(`
+			SELECT COALESCE(SUM(CASE WHEN type = 'credit' THEN amount ELSE -amount END), 0)
+			FROM ledger_entries
+			WHERE account_id = $1 AND (created_at < $2 OR (transfer_id IS NULL AND created_at < $3))
+		`)
+	)
+	cases := map[string]struct {
+		ExpectedData   int64
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedData:  1500,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"coalesce"}).AddRow(1500)
+				mock.ExpectQuery(query).WithArgs("account_id", from, to).WillReturnRows(rows)
+			},
+		},
+		"should return error": {
+			ExpectedData:  0,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs("account_id", from, to).WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				dbConn, sqlMock = test.GetSQLMock()
+				repository      = NewRepository(Options{
+					Logger: logger.New(""),
+					DB:     db.NewExtendedDB(dbConn),
+				})
+			)
+
+			cs.PrepareMockSQL(sqlMock)
+
+			data, err := repository.GetOpeningBalance(context.Background(), "account_id", from, to)
+
+			assert.Equal(t, cs.ExpectedError, err)
+			assert.Equal(t, cs.ExpectedData, data)
+		})
+	}
+}
+
+func TestWithTransaction(t *testing.T) {
+	repoWithDB := &repositoryImpl{
+		db: db.ExtendedDB(nil),
+	}
+	repoWithTx := &repositoryImpl{
+		db: db.ExtendedTx(nil),
+	}
+	assert.Equal(t, repoWithTx, repoWithDB.WithTransaction(db.ExtendedTx(nil)))
+}
@@ -0,0 +1,75 @@
+//go:generate mockgen -source=${GOFILE} -package=${GOPACKAGE} -destination=${GOPACKAGE}_mock.go
+
+package limit
+
+import (
+	"context"
+	"database/sql"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/db"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/transaction"
+)
+
+type (
+	Options struct {
+		Logger logger.Logger
+		DB     db.Connection
+	}
+	Repository interface {
+		Get(ctx context.Context, accountID string) (*model.AccountLimits, error)
+		Save(ctx context.Context, limits model.AccountLimits) (*model.AccountLimits, error)
+		WithTransaction(conn transaction.Transaction) Repository
+	}
+	repositoryImpl struct {
+		logger logger.Logger
+		db     db.Connection
+	}
+)
+
+func NewRepository(opts Options) Repository {
+	return &repositoryImpl{
+		logger: opts.Logger.WithLocation().WithPreffix("repository.limit"),
+		db:     opts.DB,
+	}
+}
+
+// Get returns the limits an admin set for the account, or nil when it keeps the defaults.
+func (r *repositoryImpl) Get(ctx context.Context, accountID string) (*model.AccountLimits, error) {
+	query := "SELECT account_id, tier, per_transfer, daily, monthly, daily_count, updated_at FROM account_limits WHERE account_id = $1"
+	limits := new(model.AccountLimits)
+	err := r.db.GetContext(ctx, limits, query, accountID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		r.logger.Error(err)
+		return nil, err
+	}
+	return limits, nil
+}
+
+// Save replaces the tier and the limits of the account.
+func (r *repositoryImpl) Save(ctx context.Context, limits model.AccountLimits) (*model.AccountLimits, error) {
+	query := `
+		INSERT INTO account_limits(account_id, tier, per_transfer, daily, monthly, daily_count) 
+		VALUES (:account_id, :tier, :per_transfer, :daily, :monthly, :daily_count)
+		ON CONFLICT (account_id) DO UPDATE SET tier = EXCLUDED.tier, per_transfer = EXCLUDED.per_transfer,
+			daily = EXCLUDED.daily, monthly = EXCLUDED.monthly, daily_count = EXCLUDED.daily_count,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING account_id, tier, per_transfer, daily, monthly, daily_count, updated_at`
+	saved := new(model.AccountLimits)
+	err := r.db.NamedGetContext(ctx, query, saved, limits)
+	if err != nil {
+		r.logger.Error(err)
+		return nil, err
+	}
+	return saved, nil
+}
+
+func (r *repositoryImpl) WithTransaction(conn transaction.Transaction) Repository {
+	return &repositoryImpl{
+		logger: r.logger,
+		db:     conn,
+	}
+}
@@ -0,0 +1,152 @@
+package limit
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/db"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/test"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestGet(t *testing.T) {
+	var (
+		updatedAt     = time.Now()
+		daily         = int64(2000)
+		query         = regexp.QuoteMeta("SELECT account_id, tier, per_transfer, daily, monthly, daily_count, updated_at FROM account_limits WHERE account_id = $1")
+		limitsExample = model.AccountLimits{
+			AccountID: "account_id",
+			Tier:      model.AccountTierPremium,
+			Daily:     &daily,
+			UpdatedAt: updatedAt,
+		}
+	)
+
+	cases := map[string]struct {
+		ExpectedData   *model.AccountLimits
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedData:  &limitsExample,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.
+					NewRows([]string{"account_id", "tier", "per_transfer", "daily", "monthly", "daily_count", "updated_at"}).
+					AddRow("account_id", model.AccountTierPremium, nil, 2000, nil, nil, updatedAt)
+				mock.ExpectQuery(query).WithArgs("account_id").WillReturnRows(rows)
+			},
+		},
+		"should return success: default limits": {
+			ExpectedData:  nil,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs("account_id").WillReturnError(sql.ErrNoRows)
+			},
+		},
+		"should return error": {
+			ExpectedData:  nil,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs("account_id").WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				dbConn, sqlMock = test.GetSQLMock()
+				repository      = NewRepository(Options{
+					Logger: logger.New(""),
+					DB:     db.NewExtendedDB(dbConn),
+				})
+			)
+
+			cs.PrepareMockSQL(sqlMock)
+
+			data, err := repository.Get(context.Background(), "account_id")
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestSave(t *testing.T) {
+	var (
+		updatedAt = time.Now()
+		daily     = int64(2000)
+		query     = regexp.QuoteMeta(`
+		INSERT INTO account_limits(account_id, tier, per_transfer, daily, monthly, daily_count) 
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (account_id) DO UPDATE SET tier = EXCLUDED.tier, per_transfer = EXCLUDED.per_transfer,
+			daily = EXCLUDED.daily, monthly = EXCLUDED.monthly, daily_count = EXCLUDED.daily_count,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING account_id, tier, per_transfer, daily, monthly, daily_count, updated_at`)
+		limitsExample = model.AccountLimits{
+			AccountID: "account_id",
+			Tier:      model.AccountTierPremium,
+			Daily:     &daily,
+		}
+	)
+
+	cases := map[string]struct {
+		ExpectedData   *model.AccountLimits
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedData: func() *model.AccountLimits {
+				data := limitsExample
+				data.UpdatedAt = updatedAt
+				return &data
+			}(),
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.
+					NewRows([]string{"account_id", "tier", "per_transfer", "daily", "monthly", "daily_count", "updated_at"}).
+					AddRow("account_id", model.AccountTierPremium, nil, 2000, nil, nil, updatedAt)
+				mock.ExpectPrepare(query).
+					ExpectQuery().
+					WithArgs("account_id", model.AccountTierPremium, nil, 2000, nil, nil).
+					WillReturnRows(rows)
+			},
+		},
+		"should return error": {
+			ExpectedData:  nil,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(query).
+					ExpectQuery().
+					WithArgs("account_id", model.AccountTierPremium, nil, 2000, nil, nil).
+					WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				dbConn, sqlMock = test.GetSQLMock()
+				repository      = NewRepository(Options{
+					Logger: logger.New(""),
+					DB:     db.NewExtendedDB(dbConn),
+				})
+			)
+
+			cs.PrepareMockSQL(sqlMock)
+
+			data, err := repository.Save(context.Background(), limitsExample)
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
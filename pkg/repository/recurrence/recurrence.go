@@ -0,0 +1,198 @@
+//go:generate mockgen -source=${GOFILE} -package=${GOPACKAGE} -destination=${GOPACKAGE}_mock.go
+
+package recurrence
+
+import (
+	"context"
+	"database/sql"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/db"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/transaction"
+	"time"
+)
+
+type (
+	Options struct {
+		Logger logger.Logger
+		DB     db.Connection
+	}
+	Repository interface {
+		Create(ctx context.Context, recurringTransfer model.RecurringTransfer) (*model.GeneratedData, error)
+		List(ctx context.Context, accountID string) ([]model.RecurringTransfer, error)
+		GetByID(ctx context.Context, id string) (*model.RecurringTransfer, error)
+		Cancel(ctx context.Context, id string) (bool, error)
+		ClaimDue(ctx context.Context, now time.Time, staleBefore time.Time, limit int) ([]model.RecurringTransfer, error)
+		UpdateProgress(ctx context.Context, recurringTransfer model.RecurringTransfer) (bool, error)
+		CreateRun(ctx context.Context, run model.RecurringTransferRun) error
+		ListRuns(ctx context.Context, recurringTransferID string) ([]model.RecurringTransferRun, error)
+		WithTransaction(conn transaction.Transaction) Repository
+	}
+	repositoryImpl struct {
+		logger logger.Logger
+		db     db.Connection
+	}
+)
+
+func NewRepository(opts Options) Repository {
+	return &repositoryImpl{
+		logger: opts.Logger.WithLocation().WithPreffix("repository.recurrence"),
+		db:     opts.DB,
+	}
+}
+
+func (r *repositoryImpl) Create(ctx context.Context, recurringTransfer model.RecurringTransfer) (*model.GeneratedData, error) {
+	query := `
+		INSERT INTO recurring_transfers(
+			origin_account_id, target_account_id, amount, frequency, day_of_month, start_at, end_at, max_occurrences, 
+			insufficient_funds_policy, max_retries, status, next_occurrence_at, next_attempt_at
+		) 
+		VALUES (
+			:origin_account_id, :target_account_id, :amount, :frequency, :day_of_month, :start_at, :end_at, :max_occurrences, 
+			:insufficient_funds_policy, :max_retries, :status, :next_occurrence_at, :next_attempt_at
+		)
+		RETURNING id, created_at`
+	generatedData := new(model.GeneratedData)
+	err := r.db.NamedGetContext(ctx, query, generatedData, recurringTransfer)
+	if err != nil {
+		r.logger.Error(err)
+		return nil, err
+	}
+	return generatedData, nil
+}
+
+func (r *repositoryImpl) List(ctx context.Context, accountID string) ([]model.RecurringTransfer, error) {
+	query := `
+		SELECT 
+			id, origin_account_id, target_account_id, amount, frequency, day_of_month, start_at, end_at, max_occurrences, 
+			insufficient_funds_policy, max_retries, status, occurrences, retries, attempts, next_occurrence_at, next_attempt_at, 
+			failure_reason, created_at
+		FROM recurring_transfers
+		WHERE origin_account_id = $1
+		ORDER BY created_at`
+	recurringTransfers := make([]model.RecurringTransfer, 0)
+	err := r.db.SelectContext(ctx, &recurringTransfers, query, accountID)
+	if err != nil {
+		r.logger.Error(err)
+		return nil, err
+	}
+	return recurringTransfers, nil
+}
+
+func (r *repositoryImpl) GetByID(ctx context.Context, id string) (*model.RecurringTransfer, error) {
+	query := `
+		SELECT 
+			id, origin_account_id, target_account_id, amount, frequency, day_of_month, start_at, end_at, max_occurrences, 
+			insufficient_funds_policy, max_retries, status, occurrences, retries, attempts, next_occurrence_at, next_attempt_at, 
+			failure_reason, created_at
+		FROM recurring_transfers
+		WHERE id = $1`
+	recurringTransfer := new(model.RecurringTransfer)
+	err := r.db.GetContext(ctx, recurringTransfer, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		r.logger.Error(err)
+		return nil, err
+	}
+	return recurringTransfer, nil
+}
+
+// Cancel moves an active recurring transfer to cancelled. It returns false when the transfer is no longer active.
+func (r *repositoryImpl) Cancel(ctx context.Context, id string) (bool, error) {
+	query := "UPDATE recurring_transfers SET status = 'cancelled' WHERE id = $1 AND status = 'active'"
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		r.logger.Error(err)
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.Error(err)
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// ClaimDue moves up to limit active recurring transfers whose next attempt is due to processing, recording when they
+// were claimed, and returns them. Recurring transfers claimed before staleBefore and still processing were left behind
+// by an instance that stopped, so they are claimed again. Rows locked by another instance are skipped, so each attempt
+// is claimed only once at a time.
+func (r *repositoryImpl) ClaimDue(ctx context.Context, now time.Time, staleBefore time.Time, limit int) ([]model.RecurringTransfer, error) {
+	query := `
+		UPDATE recurring_transfers SET status = 'processing', claimed_at = $1
+		WHERE id IN (
+			SELECT id FROM recurring_transfers
+			WHERE (status = 'active' AND next_attempt_at <= $1) OR (status = 'processing' AND claimed_at <= $2)
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING 
+			id, origin_account_id, target_account_id, amount, frequency, day_of_month, start_at, end_at, max_occurrences, 
+			insufficient_funds_policy, max_retries, status, occurrences, retries, attempts, next_occurrence_at, next_attempt_at, 
+			failure_reason, created_at`
+	recurringTransfers := make([]model.RecurringTransfer, 0)
+	err := r.db.SelectContext(ctx, &recurringTransfers, query, now, staleBefore, limit)
+	if err != nil {
+		r.logger.Error(err)
+		return nil, err
+	}
+	return recurringTransfers, nil
+}
+
+// UpdateProgress stores the status, counters, next dates and failure reason of a processing recurring transfer after
+// an attempt. It returns false when the recurring transfer is no longer processing, as when another instance that
+// claimed it again stored its outcome first.
+func (r *repositoryImpl) UpdateProgress(ctx context.Context, recurringTransfer model.RecurringTransfer) (bool, error) {
+	query := `
+		UPDATE recurring_transfers 
+		SET status = :status, occurrences = :occurrences, retries = :retries, attempts = :attempts, 
+			next_occurrence_at = :next_occurrence_at, next_attempt_at = :next_attempt_at, failure_reason = :failure_reason
+		WHERE id = :id AND status = 'processing'`
+	result, err := r.db.NamedExecContext(ctx, query, recurringTransfer)
+	if err != nil {
+		r.logger.Error(err)
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.Error(err)
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+func (r *repositoryImpl) CreateRun(ctx context.Context, run model.RecurringTransferRun) error {
+	query := `
+		INSERT INTO recurring_transfer_runs(recurring_transfer_id, occurrence_at, status, transfer_id, failure_reason) 
+		VALUES (:recurring_transfer_id, :occurrence_at, :status, :transfer_id, :failure_reason)`
+	_, err := r.db.NamedExecContext(ctx, query, run)
+	if err != nil {
+		r.logger.Error(err)
+	}
+	return err
+}
+
+func (r *repositoryImpl) ListRuns(ctx context.Context, recurringTransferID string) ([]model.RecurringTransferRun, error) {
+	query := `
+		SELECT id, recurring_transfer_id, occurrence_at, status, transfer_id, failure_reason, created_at
+		FROM recurring_transfer_runs
+		WHERE recurring_transfer_id = $1
+		ORDER BY created_at DESC`
+	runs := make([]model.RecurringTransferRun, 0)
+	err := r.db.SelectContext(ctx, &runs, query, recurringTransferID)
+	if err != nil {
+		r.logger.Error(err)
+		return nil, err
+	}
+	return runs, nil
+}
+
+func (r *repositoryImpl) WithTransaction(conn transaction.Transaction) Repository {
+	return &repositoryImpl{
+		logger: r.logger,
+		db:     conn,
+	}
+}
@@ -0,0 +1,504 @@
+package recurrence
+
+import (
+	"context"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/db"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/test"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+	"time"
+)
+
+var (
+	recurringTransferColumns = []string{
+		"id", "origin_account_id", "target_account_id", "amount", "frequency", "day_of_month", "start_at", "end_at",
+		"max_occurrences", "insufficient_funds_policy", "max_retries", "status", "occurrences", "retries", "attempts",
+		"next_occurrence_at", "next_attempt_at", "failure_reason", "created_at",
+	}
+	recurringTransferSelect = `
+		SELECT 
+			id, origin_account_id, target_account_id, amount, frequency, day_of_month, start_at, end_at, max_occurrences, 
+			insufficient_funds_policy, max_retries, status, occurrences, retries, attempts, next_occurrence_at, next_attempt_at, 
+			failure_reason, created_at
+		FROM recurring_transfers
+	`
+)
+
+func getRecurringTransferExample() model.RecurringTransfer {
+	now := time.Now()
+	return model.RecurringTransfer{
+		ID:              "recurring_transfer_id",
+		OriginAccountID: "origin_account_id",
+		TargetAccountID: "target_account_id",
+		Amount:          500,
+		RecurrenceRule: model.RecurrenceRule{
+			Frequency: model.RecurrenceFrequencyDaily,
+			StartAt:   now,
+		},
+		InsufficientFundsPolicy: model.RecurrencePolicySkip,
+		Status:                  model.RecurringTransferStatusActive,
+		NextOccurrenceAt:        now,
+		NextAttemptAt:           now,
+		CreatedAt:               now,
+	}
+}
+
+func addRecurringTransferRows(rows *sqlmock.Rows, recurringTransfers ...model.RecurringTransfer) *sqlmock.Rows {
+	for _, r := range recurringTransfers {
+		rows.AddRow(
+			r.ID, r.OriginAccountID, r.TargetAccountID, r.Amount, r.Frequency, r.DayOfMonth, r.StartAt, r.EndAt,
+			r.MaxOccurrences, r.InsufficientFundsPolicy, r.MaxRetries, r.Status, r.Occurrences, r.Retries, r.Attempts,
+			r.NextOccurrenceAt, r.NextAttemptAt, r.FailureReason, r.CreatedAt,
+		)
+	}
+	return rows
+}
+
+func newRepository() (Repository, sqlmock.Sqlmock) {
+	dbConn, sqlMock := test.GetSQLMock()
+	return NewRepository(Options{
+		Logger: logger.New(""),
+		DB:     db.NewExtendedDB(dbConn),
+	}), sqlMock
+}
+
+func TestCreate(t *testing.T) {
+	var (
+		recurringTransferExample = getRecurringTransferExample()
+		generatedDataExample     = model.GeneratedData{
+			ID:        "generated_id",
+			CreatedAt: time.Now(),
+		}
+		query = regexp.QuoteMeta(`
+			INSERT INTO recurring_transfers(
+				origin_account_id, target_account_id, amount, frequency, day_of_month, start_at, end_at, max_occurrences, 
+				insufficient_funds_policy, max_retries, status, next_occurrence_at, next_attempt_at
+			) 
+			VALUES (
+				?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+			)
+			RETURNING id, created_at
+		`)
+	)
+	cases := map[string]struct {
+		ExpectedData   *model.GeneratedData
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedData:  &generatedDataExample,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.
+					NewRows([]string{"id", "created_at"}).
+					AddRow(generatedDataExample.ID, generatedDataExample.CreatedAt)
+				mock.ExpectPrepare(query).ExpectQuery().WillReturnRows(rows)
+			},
+		},
+		"should return error": {
+			ExpectedData:  nil,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(query).ExpectQuery().WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			repository, sqlMock := newRepository()
+
+			cs.PrepareMockSQL(sqlMock)
+
+			data, err := repository.Create(context.Background(), recurringTransferExample)
+
+			assert.Equal(t, cs.ExpectedError, err)
+			assert.Equal(t, cs.ExpectedData, data)
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	var (
+		recurringTransfersExample = []model.RecurringTransfer{getRecurringTransferExample()}
+		query                     = regexp.QuoteMeta(recurringTransferSelect + `
+			WHERE origin_account_id = $1
+			ORDER BY created_at
+		`)
+	)
+	cases := map[string]struct {
+		ExpectedData   []model.RecurringTransfer
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedData:  recurringTransfersExample,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				rows := addRecurringTransferRows(sqlmock.NewRows(recurringTransferColumns), recurringTransfersExample...)
+				mock.ExpectQuery(query).WithArgs("origin_account_id").WillReturnRows(rows)
+			},
+		},
+		"should return error": {
+			ExpectedData:  nil,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs("origin_account_id").WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			repository, sqlMock := newRepository()
+
+			cs.PrepareMockSQL(sqlMock)
+
+			data, err := repository.List(context.Background(), "origin_account_id")
+
+			assert.Equal(t, cs.ExpectedError, err)
+			assert.Equal(t, cs.ExpectedData, data)
+		})
+	}
+}
+
+func TestGetByID(t *testing.T) {
+	var (
+		recurringTransferExample = getRecurringTransferExample()
+		query                    = regexp.QuoteMeta(recurringTransferSelect + "WHERE id = $1")
+	)
+	cases := map[string]struct {
+		ExpectedData   *model.RecurringTransfer
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedData:  &recurringTransferExample,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				rows := addRecurringTransferRows(sqlmock.NewRows(recurringTransferColumns), recurringTransferExample)
+				mock.ExpectQuery(query).WithArgs(recurringTransferExample.ID).WillReturnRows(rows)
+			},
+		},
+		"should return nil when not found": {
+			ExpectedData:  nil,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs(recurringTransferExample.ID).WillReturnRows(sqlmock.NewRows(recurringTransferColumns))
+			},
+		},
+		"should return error": {
+			ExpectedData:  nil,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs(recurringTransferExample.ID).WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			repository, sqlMock := newRepository()
+
+			cs.PrepareMockSQL(sqlMock)
+
+			data, err := repository.GetByID(context.Background(), recurringTransferExample.ID)
+
+			assert.Equal(t, cs.ExpectedError, err)
+			assert.Equal(t, cs.ExpectedData, data)
+		})
+	}
+}
+
+func TestCancel(t *testing.T) {
+	query := regexp.QuoteMeta("UPDATE recurring_transfers SET status = 'cancelled' WHERE id = $1 AND status = 'active'")
+	cases := map[string]struct {
+		ExpectedData   bool
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedData:  true,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).WithArgs("recurring_transfer_id").WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		"should return false when not active": {
+			ExpectedData:  false,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).WithArgs("recurring_transfer_id").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+		"should return error": {
+			ExpectedData:  false,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).WithArgs("recurring_transfer_id").WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			repository, sqlMock := newRepository()
+
+			cs.PrepareMockSQL(sqlMock)
+
+			data, err := repository.Cancel(context.Background(), "recurring_transfer_id")
+
+			assert.Equal(t, cs.ExpectedError, err)
+			assert.Equal(t, cs.ExpectedData, data)
+		})
+	}
+}
+
+func TestClaimDue(t *testing.T) {
+	var (
+		now                       = time.Now()
+		staleBefore               = now.Add(-time.Minute)
+		recurringTransfersExample = []model.RecurringTransfer{getRecurringTransferExample()}
+		query                     = regexp.QuoteMeta(`
+			UPDATE recurring_transfers SET status = 'processing', claimed_at = $1
+			WHERE id IN (
+				SELECT id FROM recurring_transfers
+				WHERE (status = 'active' AND next_attempt_at <= $1) OR (status = 'processing' AND claimed_at <= $2)
+				ORDER BY next_attempt_at
+				LIMIT $3
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING 
+				id, origin_account_id, target_account_id, amount, frequency, day_of_month, start_at, end_at, max_occurrences, 
+				insufficient_funds_policy, max_retries, status, occurrences, retries, attempts, next_occurrence_at, next_attempt_at, 
+				failure_reason, created_at
+		`)
+	)
+	cases := map[string]struct {
+		ExpectedData   []model.RecurringTransfer
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedData:  recurringTransfersExample,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				rows := addRecurringTransferRows(sqlmock.NewRows(recurringTransferColumns), recurringTransfersExample...)
+				mock.ExpectQuery(query).WithArgs(now, staleBefore, 10).WillReturnRows(rows)
+			},
+		},
+		"should return error": {
+			ExpectedData:  nil,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs(now, staleBefore, 10).WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			repository, sqlMock := newRepository()
+
+			cs.PrepareMockSQL(sqlMock)
+
+			data, err := repository.ClaimDue(context.Background(), now, staleBefore, 10)
+
+			assert.Equal(t, cs.ExpectedError, err)
+			assert.Equal(t, cs.ExpectedData, data)
+		})
+	}
+}
+
+func TestUpdateProgress(t *testing.T) {
+	var (
+		recurringTransferExample = getRecurringTransferExample()
+		query                    = regexp.QuoteMeta(`
+			UPDATE recurring_transfers 
+			SET status = ?, occurrences = ?, retries = ?, attempts = ?, 
+				next_occurrence_at = ?, next_attempt_at = ?, failure_reason = ?
+			WHERE id = ? AND status = 'processing'
+		`)
+	)
+	cases := map[string]struct {
+		ExpectedData   bool
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedData:  true,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs(
+						recurringTransferExample.Status,
+						recurringTransferExample.Occurrences,
+						recurringTransferExample.Retries,
+						recurringTransferExample.Attempts,
+						recurringTransferExample.NextOccurrenceAt,
+						recurringTransferExample.NextAttemptAt,
+						recurringTransferExample.FailureReason,
+						recurringTransferExample.ID,
+					).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		"should return false when not processing": {
+			ExpectedData:  false,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs(
+						recurringTransferExample.Status,
+						recurringTransferExample.Occurrences,
+						recurringTransferExample.Retries,
+						recurringTransferExample.Attempts,
+						recurringTransferExample.NextOccurrenceAt,
+						recurringTransferExample.NextAttemptAt,
+						recurringTransferExample.FailureReason,
+						recurringTransferExample.ID,
+					).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+		"should return error": {
+			ExpectedData:  false,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			repository, sqlMock := newRepository()
+
+			cs.PrepareMockSQL(sqlMock)
+
+			data, err := repository.UpdateProgress(context.Background(), recurringTransferExample)
+
+			assert.Equal(t, cs.ExpectedError, err)
+			assert.Equal(t, cs.ExpectedData, data)
+		})
+	}
+}
+
+func TestCreateRun(t *testing.T) {
+	var (
+		transferID = "transfer_id"
+		runExample = model.RecurringTransferRun{
+			RecurringTransferID: "recurring_transfer_id",
+			OccurrenceAt:        time.Now(),
+			Status:              model.RecurringTransferRunStatusExecuted,
+			TransferID:          &transferID,
+		}
+		query = regexp.QuoteMeta(`
+			INSERT INTO recurring_transfer_runs(recurring_transfer_id, occurrence_at, status, transfer_id, failure_reason) 
+			VALUES (?, ?, ?, ?, ?)
+		`)
+	)
+	cases := map[string]struct {
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs(runExample.RecurringTransferID, runExample.OccurrenceAt, runExample.Status, &transferID, nil).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		"should return error": {
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			repository, sqlMock := newRepository()
+
+			cs.PrepareMockSQL(sqlMock)
+
+			err := repository.CreateRun(context.Background(), runExample)
+
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestListRuns(t *testing.T) {
+	var (
+		runsExample = []model.RecurringTransferRun{{
+			ID:                  "run_id",
+			RecurringTransferID: "recurring_transfer_id",
+			OccurrenceAt:        time.Now(),
+			Status:              model.RecurringTransferRunStatusSkipped,
+			CreatedAt:           time.Now(),
+		}}
+		query = regexp.QuoteMeta(`
+			SELECT id, recurring_transfer_id, occurrence_at, status, transfer_id, failure_reason, created_at
+			FROM recurring_transfer_runs
+			WHERE recurring_transfer_id = $1
+			ORDER BY created_at DESC
+		`)
+	)
+	cases := map[string]struct {
+		ExpectedData   []model.RecurringTransferRun
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedData:  runsExample,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{
+					"id", "recurring_transfer_id", "occurrence_at", "status", "transfer_id", "failure_reason", "created_at",
+				})
+				for _, r := range runsExample {
+					rows.AddRow(r.ID, r.RecurringTransferID, r.OccurrenceAt, r.Status, r.TransferID, r.FailureReason, r.CreatedAt)
+				}
+				mock.ExpectQuery(query).WithArgs("recurring_transfer_id").WillReturnRows(rows)
+			},
+		},
+		"should return error": {
+			ExpectedData:  nil,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs("recurring_transfer_id").WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			repository, sqlMock := newRepository()
+
+			cs.PrepareMockSQL(sqlMock)
+
+			data, err := repository.ListRuns(context.Background(), "recurring_transfer_id")
+
+			assert.Equal(t, cs.ExpectedError, err)
+			assert.Equal(t, cs.ExpectedData, data)
+		})
+	}
+}
+
+func TestWithTransaction(t *testing.T) {
+	repoWithDB := &repositoryImpl{
+		db: db.ExtendedDB(nil),
+	}
+	repoWithTx := &repositoryImpl{
+		db: db.ExtendedTx(nil),
+	}
+	assert.Equal(t, repoWithTx, repoWithDB.WithTransaction(db.ExtendedTx(nil)))
+}
@@ -2,7 +2,16 @@ package repository
 
 import (
 	"github.com/carlosrodriguesf/bank-api/pkg/repository/account"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/apikey"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/audit"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/hold"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/ledger"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/limit"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/recurrence"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/review"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/schedule"
 	"github.com/carlosrodriguesf/bank-api/pkg/repository/transfer"
+	"github.com/carlosrodriguesf/bank-api/pkg/repository/twofactor"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/db"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
 )
@@ -14,11 +23,29 @@ type (
 	}
 	Container interface {
 		Account() account.Repository
+		APIKey() apikey.Repository
+		Audit() audit.Repository
+		Hold() hold.Repository
+		Ledger() ledger.Repository
+		Limit() limit.Repository
+		Recurrence() recurrence.Repository
+		Review() review.Repository
+		Schedule() schedule.Repository
 		Transfer() transfer.Repository
+		TwoFactor() twofactor.Repository
 	}
 	container struct {
-		account  account.Repository
-		transfer transfer.Repository
+		account    account.Repository
+		apiKey     apikey.Repository
+		audit      audit.Repository
+		hold       hold.Repository
+		ledger     ledger.Repository
+		limit      limit.Repository
+		recurrence recurrence.Repository
+		review     review.Repository
+		schedule   schedule.Repository
+		transfer   transfer.Repository
+		twoFactor  twofactor.Repository
 	}
 )
 
@@ -28,10 +55,46 @@ func NewContainer(opts Options) Container {
 			Logger: opts.Logger,
 			DB:     opts.DB,
 		}),
+		apiKey: apikey.NewRepository(apikey.Options{
+			Logger: opts.Logger,
+			DB:     opts.DB,
+		}),
+		audit: audit.NewRepository(audit.Options{
+			Logger: opts.Logger,
+			DB:     opts.DB,
+		}),
+		hold: hold.NewRepository(hold.Options{
+			Logger: opts.Logger,
+			DB:     opts.DB,
+		}),
+		ledger: ledger.NewRepository(ledger.Options{
+			Logger: opts.Logger,
+			DB:     opts.DB,
+		}),
+		limit: limit.NewRepository(limit.Options{
+			Logger: opts.Logger,
+			DB:     opts.DB,
+		}),
+		recurrence: recurrence.NewRepository(recurrence.Options{
+			Logger: opts.Logger,
+			DB:     opts.DB,
+		}),
+		review: review.NewRepository(review.Options{
+			Logger: opts.Logger,
+			DB:     opts.DB,
+		}),
+		schedule: schedule.NewRepository(schedule.Options{
+			Logger: opts.Logger,
+			DB:     opts.DB,
+		}),
 		transfer: transfer.NewRepository(transfer.Options{
 			Logger: opts.Logger,
 			DB:     opts.DB,
 		}),
+		twoFactor: twofactor.NewRepository(twofactor.Options{
+			Logger: opts.Logger,
+			DB:     opts.DB,
+		}),
 	}
 }
 
@@ -39,6 +102,42 @@ func (c *container) Account() account.Repository {
 	return c.account
 }
 
+func (c *container) APIKey() apikey.Repository {
+	return c.apiKey
+}
+
+func (c *container) Audit() audit.Repository {
+	return c.audit
+}
+
+func (c *container) Hold() hold.Repository {
+	return c.hold
+}
+
+func (c *container) Ledger() ledger.Repository {
+	return c.ledger
+}
+
+func (c *container) Limit() limit.Repository {
+	return c.limit
+}
+
+func (c *container) Recurrence() recurrence.Repository {
+	return c.recurrence
+}
+
+func (c *container) Review() review.Repository {
+	return c.review
+}
+
+func (c *container) Schedule() schedule.Repository {
+	return c.schedule
+}
+
 func (c *container) Transfer() transfer.Repository {
 	return c.transfer
 }
+
+func (c *container) TwoFactor() twofactor.Repository {
+	return c.twoFactor
+}
@@ -0,0 +1,116 @@
+//go:generate mockgen -source=${GOFILE} -package=${GOPACKAGE} -destination=${GOPACKAGE}_mock.go
+
+package review
+
+import (
+	"context"
+	"database/sql"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/db"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/transaction"
+)
+
+type (
+	Options struct {
+		Logger logger.Logger
+		DB     db.Connection
+	}
+	Repository interface {
+		Create(ctx context.Context, review model.TransferReview) (*model.GeneratedData, error)
+		ListPending(ctx context.Context, limit int) ([]model.TransferReview, error)
+		GetByIDForUpdate(ctx context.Context, id string) (*model.TransferReview, error)
+		Decide(ctx context.Context, id string, status string, decidedBy string, transferID *string) error
+		WithTransaction(conn transaction.Transaction) Repository
+	}
+	repositoryImpl struct {
+		logger logger.Logger
+		db     db.Connection
+	}
+)
+
+func NewRepository(opts Options) Repository {
+	return &repositoryImpl{
+		logger: opts.Logger.WithLocation().WithPreffix("repository.review"),
+		db:     opts.DB,
+	}
+}
+
+func (r *repositoryImpl) Create(ctx context.Context, review model.TransferReview) (*model.GeneratedData, error) {
+	query := `
+		INSERT INTO transfer_reviews(
+			origin_account_id, target_account_id, amount, source_currency, target_amount, target_currency, exchange_rate, 
+			reserved, score, reasons
+		) 
+		VALUES (
+			:origin_account_id, :target_account_id, :amount, :source_currency, :target_amount, :target_currency, 
+			:exchange_rate, :reserved, :score, :reasons
+		)
+		RETURNING id, created_at`
+	generatedData := new(model.GeneratedData)
+	err := r.db.NamedGetContext(ctx, query, generatedData, review)
+	if err != nil {
+		r.logger.Error(err)
+		return nil, err
+	}
+	return generatedData, nil
+}
+
+// ListPending returns up to limit reviews waiting for a decision, oldest first.
+func (r *repositoryImpl) ListPending(ctx context.Context, limit int) ([]model.TransferReview, error) {
+	query := `
+		SELECT id, origin_account_id, target_account_id, amount, source_currency, target_amount, target_currency, 
+			exchange_rate, reserved, score, reasons, status, created_at 
+		FROM transfer_reviews 
+		WHERE status = 'pending' 
+		ORDER BY created_at, id 
+		LIMIT $1`
+	reviews := make([]model.TransferReview, 0)
+	err := r.db.SelectContext(ctx, &reviews, query, limit)
+	if err != nil {
+		r.logger.Error(err)
+		return nil, err
+	}
+	return reviews, nil
+}
+
+// GetByIDForUpdate reads the review locking its row until the end of the current transaction, or returns nil when
+// there is none.
+func (r *repositoryImpl) GetByIDForUpdate(ctx context.Context, id string) (*model.TransferReview, error) {
+	query := `
+		SELECT id, origin_account_id, target_account_id, amount, source_currency, target_amount, target_currency, 
+			exchange_rate, reserved, score, reasons, status, transfer_id, decided_by, decided_at, created_at 
+		FROM transfer_reviews 
+		WHERE id = $1 
+		FOR UPDATE`
+	review := new(model.TransferReview)
+	err := r.db.GetContext(ctx, review, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		r.logger.Error(err)
+		return nil, err
+	}
+	return review, nil
+}
+
+// Decide records the decision of the operator decidedBy on the review, with the transfer made when it was approved.
+func (r *repositoryImpl) Decide(ctx context.Context, id string, status string, decidedBy string, transferID *string) error {
+	query := `
+		UPDATE transfer_reviews 
+		SET status = $1, decided_by = $2, transfer_id = $3, decided_at = CURRENT_TIMESTAMP 
+		WHERE id = $4`
+	_, err := r.db.ExecContext(ctx, query, status, decidedBy, transferID, id)
+	if err != nil {
+		r.logger.Error(err)
+	}
+	return err
+}
+
+func (r *repositoryImpl) WithTransaction(conn transaction.Transaction) Repository {
+	return &repositoryImpl{
+		logger: r.logger,
+		db:     conn,
+	}
+}
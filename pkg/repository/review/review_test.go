@@ -0,0 +1,282 @@
+package review
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/db"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/test"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+	"time"
+)
+
+var (
+	currentTime   = time.Now()
+	reviewExample = model.TransferReview{
+		ID:              "review_id",
+		OriginAccountID: "origin_account_id",
+		TargetAccountID: "target_account_id",
+		Amount:          150000,
+		SourceCurrency:  "BRL",
+		TargetAmount:    150000,
+		TargetCurrency:  "BRL",
+		ExchangeRate:    "1",
+		Reserved:        150500,
+		Score:           50,
+		Reasons:         pq.StringArray{"new-counterparty-high-amount"},
+		Status:          model.TransferReviewStatusPending,
+		CreatedAt:       currentTime,
+	}
+)
+
+func TestCreate(t *testing.T) {
+	var (
+		query = regexp.QuoteMeta(`
+		INSERT INTO transfer_reviews(
+			origin_account_id, target_account_id, amount, source_currency, target_amount, target_currency, exchange_rate, 
+			reserved, score, reasons
+		) 
+		VALUES (
+			?, ?, ?, ?, ?, ?, 
+			?, ?, ?, ?
+		)
+		RETURNING id, created_at`)
+		args = []driver.Value{
+			"origin_account_id", "target_account_id", 150000, "BRL", 150000, "BRL", "1", 150500, 50,
+			"{\"new-counterparty-high-amount\"}",
+		}
+		generatedDataExample = model.GeneratedData{ID: "review_id", CreatedAt: currentTime}
+	)
+
+	cases := map[string]struct {
+		ExpectedData   *model.GeneratedData
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedData:  &generatedDataExample,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "created_at"}).AddRow("review_id", currentTime)
+				mock.ExpectPrepare(query).
+					ExpectQuery().
+					WithArgs(args...).
+					WillReturnRows(rows)
+			},
+		},
+		"should return error": {
+			ExpectedData:  nil,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(query).
+					ExpectQuery().
+					WithArgs(args...).
+					WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				dbConn, sqlMock = test.GetSQLMock()
+				repository      = NewRepository(Options{
+					Logger: logger.New(""),
+					DB:     db.NewExtendedDB(dbConn),
+				})
+			)
+
+			cs.PrepareMockSQL(sqlMock)
+
+			data, err := repository.Create(context.Background(), reviewExample)
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestListPending(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		SELECT id, origin_account_id, target_account_id, amount, source_currency, target_amount, target_currency, 
+			exchange_rate, reserved, score, reasons, status, created_at 
+		FROM transfer_reviews 
+		WHERE status = 'pending' 
+		ORDER BY created_at, id 
+		LIMIT $1`)
+
+	cases := map[string]struct {
+		ExpectedData   []model.TransferReview
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedData:  []model.TransferReview{reviewExample},
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.
+					NewRows([]string{
+						"id", "origin_account_id", "target_account_id", "amount", "source_currency", "target_amount",
+						"target_currency", "exchange_rate", "reserved", "score", "reasons", "status", "created_at",
+					}).
+					AddRow(
+						"review_id", "origin_account_id", "target_account_id", 150000, "BRL", 150000, "BRL", "1", 150500,
+						50, "{new-counterparty-high-amount}", model.TransferReviewStatusPending, currentTime,
+					)
+				mock.ExpectQuery(query).WithArgs(50).WillReturnRows(rows)
+			},
+		},
+		"should return error": {
+			ExpectedData:  nil,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs(50).WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				dbConn, sqlMock = test.GetSQLMock()
+				repository      = NewRepository(Options{
+					Logger: logger.New(""),
+					DB:     db.NewExtendedDB(dbConn),
+				})
+			)
+
+			cs.PrepareMockSQL(sqlMock)
+
+			data, err := repository.ListPending(context.Background(), 50)
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestGetByIDForUpdate(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		SELECT id, origin_account_id, target_account_id, amount, source_currency, target_amount, target_currency, 
+			exchange_rate, reserved, score, reasons, status, transfer_id, decided_by, decided_at, created_at 
+		FROM transfer_reviews 
+		WHERE id = $1 
+		FOR UPDATE`)
+
+	cases := map[string]struct {
+		ExpectedData   *model.TransferReview
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedData:  &reviewExample,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.
+					NewRows([]string{
+						"id", "origin_account_id", "target_account_id", "amount", "source_currency", "target_amount",
+						"target_currency", "exchange_rate", "reserved", "score", "reasons", "status", "transfer_id",
+						"decided_by", "decided_at", "created_at",
+					}).
+					AddRow(
+						"review_id", "origin_account_id", "target_account_id", 150000, "BRL", 150000, "BRL", "1", 150500,
+						50, "{new-counterparty-high-amount}", model.TransferReviewStatusPending, nil, nil, nil,
+						currentTime,
+					)
+				mock.ExpectQuery(query).WithArgs("review_id").WillReturnRows(rows)
+			},
+		},
+		"should return success: not found": {
+			ExpectedData:  nil,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs("review_id").WillReturnError(sql.ErrNoRows)
+			},
+		},
+		"should return error": {
+			ExpectedData:  nil,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs("review_id").WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				dbConn, sqlMock = test.GetSQLMock()
+				repository      = NewRepository(Options{
+					Logger: logger.New(""),
+					DB:     db.NewExtendedDB(dbConn),
+				})
+			)
+
+			cs.PrepareMockSQL(sqlMock)
+
+			data, err := repository.GetByIDForUpdate(context.Background(), "review_id")
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestDecide(t *testing.T) {
+	var (
+		transferID = "transfer_id"
+		query      = regexp.QuoteMeta(`
+		UPDATE transfer_reviews 
+		SET status = $1, decided_by = $2, transfer_id = $3, decided_at = CURRENT_TIMESTAMP 
+		WHERE id = $4`)
+	)
+
+	cases := map[string]struct {
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs(model.TransferReviewStatusApproved, "admin_id", transferID, "review_id").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		"should return error": {
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs(model.TransferReviewStatusApproved, "admin_id", transferID, "review_id").
+					WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				dbConn, sqlMock = test.GetSQLMock()
+				repository      = NewRepository(Options{
+					Logger: logger.New(""),
+					DB:     db.NewExtendedDB(dbConn),
+				})
+			)
+
+			cs.PrepareMockSQL(sqlMock)
+
+			err := repository.Decide(
+				context.Background(), "review_id", model.TransferReviewStatusApproved, "admin_id", &transferID,
+			)
+
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+//go:generate mockgen -source=${GOFILE} -package=${GOPACKAGE} -destination=${GOPACKAGE}_mock.go
+
+package schedule
+
+import (
+	"context"
+	"database/sql"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/db"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/transaction"
+	"time"
+)
+
+type (
+	Options struct {
+		Logger logger.Logger
+		DB     db.Connection
+	}
+	Repository interface {
+		Create(ctx context.Context, scheduledTransfer model.ScheduledTransfer) (*model.GeneratedData, error)
+		List(ctx context.Context, accountID string, status string) ([]model.ScheduledTransfer, error)
+		GetByID(ctx context.Context, id string) (*model.ScheduledTransfer, error)
+		Cancel(ctx context.Context, id string) (bool, error)
+		ClaimDue(ctx context.Context, now time.Time, staleBefore time.Time, limit int) ([]model.ScheduledTransfer, error)
+		UpdateProgress(ctx context.Context, scheduledTransfer model.ScheduledTransfer) (bool, error)
+		WithTransaction(conn transaction.Transaction) Repository
+	}
+	repositoryImpl struct {
+		logger logger.Logger
+		db     db.Connection
+	}
+)
+
+func NewRepository(opts Options) Repository {
+	return &repositoryImpl{
+		logger: opts.Logger.WithLocation().WithPreffix("repository.schedule"),
+		db:     opts.DB,
+	}
+}
+
+func (r *repositoryImpl) Create(ctx context.Context, scheduledTransfer model.ScheduledTransfer) (*model.GeneratedData, error) {
+	query := `
+		INSERT INTO scheduled_transfers(origin_account_id, target_account_id, amount, scheduled_for, status) 
+		VALUES (:origin_account_id, :target_account_id, :amount, :scheduled_for, :status)
+		RETURNING id, created_at`
+	generatedData := new(model.GeneratedData)
+	err := r.db.NamedGetContext(ctx, query, generatedData, scheduledTransfer)
+	if err != nil {
+		r.logger.Error(err)
+		return nil, err
+	}
+	return generatedData, nil
+}
+
+// List returns the transfers scheduled by the account, optionally filtered by status when it is not empty.
+func (r *repositoryImpl) List(ctx context.Context, accountID string, status string) ([]model.ScheduledTransfer, error) {
+	query := `
+		SELECT 
+			id, origin_account_id, target_account_id, amount, scheduled_for, status, transfer_id, attempts, next_attempt_at, 
+			failure_reason, created_at
+		FROM scheduled_transfers
+		WHERE origin_account_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY scheduled_for`
+	scheduledTransfers := make([]model.ScheduledTransfer, 0)
+	err := r.db.SelectContext(ctx, &scheduledTransfers, query, accountID, status)
+	if err != nil {
+		r.logger.Error(err)
+		return nil, err
+	}
+	return scheduledTransfers, nil
+}
+
+func (r *repositoryImpl) GetByID(ctx context.Context, id string) (*model.ScheduledTransfer, error) {
+	query := `
+		SELECT 
+			id, origin_account_id, target_account_id, amount, scheduled_for, status, transfer_id, attempts, next_attempt_at, 
+			failure_reason, created_at
+		FROM scheduled_transfers
+		WHERE id = $1`
+	scheduledTransfer := new(model.ScheduledTransfer)
+	err := r.db.GetContext(ctx, scheduledTransfer, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		r.logger.Error(err)
+		return nil, err
+	}
+	return scheduledTransfer, nil
+}
+
+// Cancel moves a pending scheduled transfer to cancelled. It returns false when the transfer is no longer pending.
+func (r *repositoryImpl) Cancel(ctx context.Context, id string) (bool, error) {
+	query := "UPDATE scheduled_transfers SET status = 'cancelled' WHERE id = $1 AND status = 'pending'"
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		r.logger.Error(err)
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.Error(err)
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// ClaimDue moves up to limit pending transfers scheduled, or to be attempted again, until now to processing, recording when they were claimed,
+// and returns them. Transfers claimed before staleBefore and still processing were left behind by an instance that
+// stopped, so they are claimed again. Rows locked by another instance are skipped, so each scheduled transfer is
+// claimed only once at a time.
+func (r *repositoryImpl) ClaimDue(ctx context.Context, now time.Time, staleBefore time.Time, limit int) ([]model.ScheduledTransfer, error) {
+	query := `
+		UPDATE scheduled_transfers SET status = 'processing', claimed_at = $1
+		WHERE id IN (
+			SELECT id FROM scheduled_transfers
+			WHERE (status = 'pending' AND COALESCE(next_attempt_at, scheduled_for) <= $1) OR (status = 'processing' AND claimed_at <= $2)
+			ORDER BY scheduled_for
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING 
+			id, origin_account_id, target_account_id, amount, scheduled_for, status, transfer_id, attempts, next_attempt_at, 
+			failure_reason, created_at`
+	scheduledTransfers := make([]model.ScheduledTransfer, 0)
+	err := r.db.SelectContext(ctx, &scheduledTransfers, query, now, staleBefore, limit)
+	if err != nil {
+		r.logger.Error(err)
+		return nil, err
+	}
+	return scheduledTransfers, nil
+}
+
+// UpdateProgress stores the status, transfer, attempts, next attempt and failure reason of a processing scheduled
+// transfer after an attempt. It returns false when the transfer is no longer processing, as when another instance that claimed it again
+// stored its outcome first.
+func (r *repositoryImpl) UpdateProgress(ctx context.Context, scheduledTransfer model.ScheduledTransfer) (bool, error) {
+	query := `
+		UPDATE scheduled_transfers 
+		SET status = :status, transfer_id = :transfer_id, attempts = :attempts, next_attempt_at = :next_attempt_at, 
+			failure_reason = :failure_reason
+		WHERE id = :id AND status = 'processing'`
+	result, err := r.db.NamedExecContext(ctx, query, scheduledTransfer)
+	if err != nil {
+		r.logger.Error(err)
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.Error(err)
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+func (r *repositoryImpl) WithTransaction(conn transaction.Transaction) Repository {
+	return &repositoryImpl{
+		logger: r.logger,
+		db:     conn,
+	}
+}
@@ -0,0 +1,437 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/db"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/test"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+	"time"
+)
+
+var scheduledTransferColumns = []string{
+	"id", "origin_account_id", "target_account_id", "amount", "scheduled_for", "status", "transfer_id", "attempts",
+	"next_attempt_at", "failure_reason", "created_at",
+}
+
+func addScheduledTransferRows(rows *sqlmock.Rows, scheduledTransfers ...model.ScheduledTransfer) *sqlmock.Rows {
+	for _, s := range scheduledTransfers {
+		rows.AddRow(
+			s.ID, s.OriginAccountID, s.TargetAccountID, s.Amount, s.ScheduledFor, s.Status, s.TransferID, s.Attempts,
+			s.NextAttemptAt, s.FailureReason, s.CreatedAt,
+		)
+	}
+	return rows
+}
+
+func TestCreate(t *testing.T) {
+	var (
+		currentTime              = time.Now()
+		scheduledTransferExample = model.ScheduledTransfer{
+			OriginAccountID: "origin_account_id",
+			TargetAccountID: "target_account_id",
+			Amount:          500,
+			ScheduledFor:    currentTime.Add(time.Hour),
+			Status:          model.ScheduledTransferStatusPending,
+		}
+		generatedDataExample = model.GeneratedData{
+			ID:        "generated_id",
+			CreatedAt: currentTime,
+		}
+		query = regexp.QuoteMeta(`
+			INSERT INTO scheduled_transfers(origin_account_id, target_account_id, amount, scheduled_for, status) 
+			VALUES (?, ?, ?, ?, ?)
+			RETURNING id, created_at
+		`)
+	)
+	cases := map[string]struct {
+		ExpectedData   *model.GeneratedData
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedData:  &generatedDataExample,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.
+					NewRows([]string{"id", "created_at"}).
+					AddRow(generatedDataExample.ID, generatedDataExample.CreatedAt)
+				mock.ExpectPrepare(query).
+					ExpectQuery().
+					WithArgs(
+						scheduledTransferExample.OriginAccountID,
+						scheduledTransferExample.TargetAccountID,
+						scheduledTransferExample.Amount,
+						scheduledTransferExample.ScheduledFor,
+						scheduledTransferExample.Status,
+					).
+					WillReturnRows(rows)
+			},
+		},
+		"should return error": {
+			ExpectedData:  nil,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(query).
+					ExpectQuery().
+					WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				dbConn, sqlMock = test.GetSQLMock()
+				repository      = NewRepository(Options{
+					Logger: logger.New(""),
+					DB:     db.NewExtendedDB(dbConn),
+				})
+			)
+
+			cs.PrepareMockSQL(sqlMock)
+
+			data, err := repository.Create(context.Background(), scheduledTransferExample)
+
+			assert.Equal(t, cs.ExpectedError, err)
+			assert.Equal(t, cs.ExpectedData, data)
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	var (
+		scheduledTransfersExample = []model.ScheduledTransfer{{
+			ID:              "scheduled_transfer_id",
+			OriginAccountID: "origin_account_id",
+			TargetAccountID: "target_account_id",
+			Amount:          500,
+			ScheduledFor:    time.Now(),
+			Status:          model.ScheduledTransferStatusPending,
+			CreatedAt:       time.Now(),
+		}}
+		query = regexp.QuoteMeta(`
+			SELECT 
+				id, origin_account_id, target_account_id, amount, scheduled_for, status, transfer_id, attempts, next_attempt_at, 
+				failure_reason, created_at
+			FROM scheduled_transfers
+			WHERE origin_account_id = $1 AND ($2 = '' OR status = $2)
+			ORDER BY scheduled_for
+		`)
+	)
+	cases := map[string]struct {
+		ExpectedData   []model.ScheduledTransfer
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedData:  scheduledTransfersExample,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				rows := addScheduledTransferRows(sqlmock.NewRows(scheduledTransferColumns), scheduledTransfersExample...)
+				mock.ExpectQuery(query).
+					WithArgs("origin_account_id", model.ScheduledTransferStatusPending).
+					WillReturnRows(rows)
+			},
+		},
+		"should return error": {
+			ExpectedData:  nil,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs("origin_account_id", model.ScheduledTransferStatusPending).
+					WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				dbConn, sqlMock = test.GetSQLMock()
+				repository      = NewRepository(Options{
+					Logger: logger.New(""),
+					DB:     db.NewExtendedDB(dbConn),
+				})
+			)
+
+			cs.PrepareMockSQL(sqlMock)
+
+			data, err := repository.List(context.Background(), "origin_account_id", model.ScheduledTransferStatusPending)
+
+			assert.Equal(t, cs.ExpectedError, err)
+			assert.Equal(t, cs.ExpectedData, data)
+		})
+	}
+}
+
+func TestGetByID(t *testing.T) {
+	var (
+		scheduledTransferExample = model.ScheduledTransfer{
+			ID:              "scheduled_transfer_id",
+			OriginAccountID: "origin_account_id",
+			TargetAccountID: "target_account_id",
+			Amount:          500,
+			ScheduledFor:    time.Now(),
+			Status:          model.ScheduledTransferStatusPending,
+			CreatedAt:       time.Now(),
+		}
+		query = regexp.QuoteMeta(`
+			SELECT 
+				id, origin_account_id, target_account_id, amount, scheduled_for, status, transfer_id, attempts, next_attempt_at, 
+				failure_reason, created_at
+			FROM scheduled_transfers
+			WHERE id = $1
+		`)
+	)
+	cases := map[string]struct {
+		ExpectedData   *model.ScheduledTransfer
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedData:  &scheduledTransferExample,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				rows := addScheduledTransferRows(sqlmock.NewRows(scheduledTransferColumns), scheduledTransferExample)
+				mock.ExpectQuery(query).WithArgs(scheduledTransferExample.ID).WillReturnRows(rows)
+			},
+		},
+		"should return nil when not found": {
+			ExpectedData:  nil,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(scheduledTransferColumns)
+				mock.ExpectQuery(query).WithArgs(scheduledTransferExample.ID).WillReturnRows(rows)
+			},
+		},
+		"should return error": {
+			ExpectedData:  nil,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs(scheduledTransferExample.ID).WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				dbConn, sqlMock = test.GetSQLMock()
+				repository      = NewRepository(Options{
+					Logger: logger.New(""),
+					DB:     db.NewExtendedDB(dbConn),
+				})
+			)
+
+			cs.PrepareMockSQL(sqlMock)
+
+			data, err := repository.GetByID(context.Background(), scheduledTransferExample.ID)
+
+			assert.Equal(t, cs.ExpectedError, err)
+			assert.Equal(t, cs.ExpectedData, data)
+		})
+	}
+}
+
+func TestCancel(t *testing.T) {
+	query := regexp.QuoteMeta("UPDATE scheduled_transfers SET status = 'cancelled' WHERE id = $1 AND status = 'pending'")
+	cases := map[string]struct {
+		ExpectedData   bool
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedData:  true,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).WithArgs("scheduled_transfer_id").WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		"should return false when not pending": {
+			ExpectedData:  false,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).WithArgs("scheduled_transfer_id").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+		"should return error": {
+			ExpectedData:  false,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).WithArgs("scheduled_transfer_id").WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				dbConn, sqlMock = test.GetSQLMock()
+				repository      = NewRepository(Options{
+					Logger: logger.New(""),
+					DB:     db.NewExtendedDB(dbConn),
+				})
+			)
+
+			cs.PrepareMockSQL(sqlMock)
+
+			data, err := repository.Cancel(context.Background(), "scheduled_transfer_id")
+
+			assert.Equal(t, cs.ExpectedError, err)
+			assert.Equal(t, cs.ExpectedData, data)
+		})
+	}
+}
+
+func TestClaimDue(t *testing.T) {
+	var (
+		now                       = time.Now()
+		staleBefore               = now.Add(-time.Minute)
+		scheduledTransfersExample = []model.ScheduledTransfer{{
+			ID:              "scheduled_transfer_id",
+			OriginAccountID: "origin_account_id",
+			TargetAccountID: "target_account_id",
+			Amount:          500,
+			ScheduledFor:    now,
+			Status:          model.ScheduledTransferStatusProcessing,
+			CreatedAt:       now,
+		}}
+		query = regexp.QuoteMeta(`
+			UPDATE scheduled_transfers SET status = 'processing', claimed_at = $1
+			WHERE id IN (
+				SELECT id FROM scheduled_transfers
+				WHERE (status = 'pending' AND COALESCE(next_attempt_at, scheduled_for) <= $1) OR (status = 'processing' AND claimed_at <= $2)
+				ORDER BY scheduled_for
+				LIMIT $3
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING 
+				id, origin_account_id, target_account_id, amount, scheduled_for, status, transfer_id, attempts, next_attempt_at, 
+				failure_reason, created_at
+		`)
+	)
+	cases := map[string]struct {
+		ExpectedData   []model.ScheduledTransfer
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedData:  scheduledTransfersExample,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				rows := addScheduledTransferRows(sqlmock.NewRows(scheduledTransferColumns), scheduledTransfersExample...)
+				mock.ExpectQuery(query).WithArgs(now, staleBefore, 10).WillReturnRows(rows)
+			},
+		},
+		"should return error": {
+			ExpectedData:  nil,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs(now, staleBefore, 10).WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				dbConn, sqlMock = test.GetSQLMock()
+				repository      = NewRepository(Options{
+					Logger: logger.New(""),
+					DB:     db.NewExtendedDB(dbConn),
+				})
+			)
+
+			cs.PrepareMockSQL(sqlMock)
+
+			data, err := repository.ClaimDue(context.Background(), now, staleBefore, 10)
+
+			assert.Equal(t, cs.ExpectedError, err)
+			assert.Equal(t, cs.ExpectedData, data)
+		})
+	}
+}
+
+func TestUpdateProgress(t *testing.T) {
+	var (
+		failureReason            = "transfer.target-not-active"
+		scheduledTransferExample = model.ScheduledTransfer{
+			ID:            "scheduled_transfer_id",
+			Status:        model.ScheduledTransferStatusFailed,
+			Attempts:      1,
+			FailureReason: &failureReason,
+		}
+		query = regexp.QuoteMeta(`
+			UPDATE scheduled_transfers 
+			SET status = ?, transfer_id = ?, attempts = ?, next_attempt_at = ?, 
+				failure_reason = ?
+			WHERE id = ? AND status = 'processing'
+		`)
+	)
+	cases := map[string]struct {
+		ExpectedData   bool
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedData:  true,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs(model.ScheduledTransferStatusFailed, nil, 1, nil, &failureReason, "scheduled_transfer_id").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		"should return false when not processing": {
+			ExpectedData:  false,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs(model.ScheduledTransferStatusFailed, nil, 1, nil, &failureReason, "scheduled_transfer_id").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+		"should return error": {
+			ExpectedData:  false,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				dbConn, sqlMock = test.GetSQLMock()
+				repository      = NewRepository(Options{
+					Logger: logger.New(""),
+					DB:     db.NewExtendedDB(dbConn),
+				})
+			)
+
+			cs.PrepareMockSQL(sqlMock)
+
+			data, err := repository.UpdateProgress(context.Background(), scheduledTransferExample)
+
+			assert.Equal(t, cs.ExpectedError, err)
+			assert.Equal(t, cs.ExpectedData, data)
+		})
+	}
+}
+
+func TestWithTransaction(t *testing.T) {
+	repoWithDB := &repositoryImpl{
+		db: db.ExtendedDB(nil),
+	}
+	repoWithTx := &repositoryImpl{
+		db: db.ExtendedTx(nil),
+	}
+	assert.Equal(t, repoWithTx, repoWithDB.WithTransaction(db.ExtendedTx(nil)))
+}
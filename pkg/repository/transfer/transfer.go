@@ -4,10 +4,14 @@ package transfer
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
 	"github.com/carlosrodriguesf/bank-api/pkg/model"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/db"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/transaction"
+	"strings"
+	"time"
 )
 
 type (
@@ -17,7 +21,14 @@ type (
 	}
 	Repository interface {
 		Create(ctx context.Context, movement model.Transfer) (*model.GeneratedData, error)
-		List(ctx context.Context, accountID string) ([]model.TransferDetailed, error)
+		List(ctx context.Context, filter model.TransferFilter) ([]model.TransferDetailed, error)
+		Stream(ctx context.Context, filter model.TransferFilter, fn func(transfer model.TransferDetailed) error) error
+		GetByIDForUpdate(ctx context.Context, id string) (*model.Transfer, error)
+		GetReversedAmount(ctx context.Context, id string) (int64, error)
+		CountSent(ctx context.Context, accountID string, since time.Time) (int, error)
+		ListSent(ctx context.Context, accountID string, since time.Time) ([]model.Transfer, error)
+		GetUsage(ctx context.Context, accountID string, dayStart time.Time, monthStart time.Time) (*model.TransferUsage, error)
+		CreateFees(ctx context.Context, fees []model.TransferFee) error
 		WithTransaction(conn transaction.Transaction) Repository
 	}
 	repositoryImpl struct {
@@ -35,8 +46,14 @@ func NewRepository(opts Options) Repository {
 
 func (r *repositoryImpl) Create(ctx context.Context, movement model.Transfer) (*model.GeneratedData, error) {
 	query := `
-		INSERT INTO transfers(origin_account_id, target_account_id, amount) 
-		VALUES (:origin_account_id, :target_account_id, :amount)
+		INSERT INTO transfers(
+			origin_account_id, target_account_id, amount, source_currency, target_amount, target_currency, exchange_rate, 
+			fee, reversed_transfer_id
+		) 
+		VALUES (
+			:origin_account_id, :target_account_id, :amount, :source_currency, :target_amount, :target_currency, 
+			:exchange_rate, :fee, :reversed_transfer_id
+		)
 		RETURNING id, created_at`
 	generatedData := new(model.GeneratedData)
 	err := r.db.NamedGetContext(ctx, query, generatedData, movement)
@@ -47,23 +64,192 @@ func (r *repositoryImpl) Create(ctx context.Context, movement model.Transfer) (*
 	return generatedData, nil
 }
 
-func (r *repositoryImpl) List(ctx context.Context, accountID string) ([]model.TransferDetailed, error) {
+// List returns a page of the transfers of filter.AccountID, ordered by created_at and id. Only the filters with
+// non-zero values are applied.
+func (r *repositoryImpl) List(ctx context.Context, filter model.TransferFilter) ([]model.TransferDetailed, error) {
+	query, args := getListQuery(filter)
+	transfers := make([]model.TransferDetailed, 0)
+	err := r.db.SelectContext(ctx, &transfers, query, args...)
+	if err != nil {
+		r.logger.Error(err)
+		return nil, err
+	}
+	return transfers, nil
+}
+
+// Stream calls fn for each transfer selected by filter as rows arrive from the database, without loading them all
+// into memory. A zero filter.Limit streams every transfer. It stops at the first error returned by fn.
+func (r *repositoryImpl) Stream(ctx context.Context, filter model.TransferFilter, fn func(transfer model.TransferDetailed) error) error {
+	query, args := getListQuery(filter)
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		r.logger.Error(err)
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var transfer model.TransferDetailed
+		if err = rows.StructScan(&transfer); err != nil {
+			r.logger.Error(err)
+			return err
+		}
+		if err = fn(transfer); err != nil {
+			return err
+		}
+	}
+	if err = rows.Err(); err != nil {
+		r.logger.Error(err)
+		return err
+	}
+	return nil
+}
+
+// getListQuery builds the query of List and Stream. Amounts are compared and summed in the currency of
+// filter.AccountID: what it sent for transfers it made and what it received for the others. Reversals of a transfer
+// move its target amount back, so the reversed amount of a sent transfer is what the reversals delivered to the origin.
+func getListQuery(filter model.TransferFilter) (string, []interface{}) {
+	var (
+		args       = []interface{}{filter.AccountID}
+		conditions = []string{"(t.origin_account_id = $1 OR t.target_account_id = $1)"}
+		order      = "DESC"
+		comparator = "<"
+		amount     = "CASE WHEN t.origin_account_id = $1 THEN t.amount ELSE t.target_amount END"
+		fee        = "0"
+		param      = func(value interface{}) string {
+			args = append(args, value)
+			return fmt.Sprintf("$%d", len(args))
+		}
+	)
+
+	if filter.Sort == model.SortAsc {
+		order = "ASC"
+		comparator = ">"
+	}
+	if filter.CollectedFees {
+		conditions[0] = "(t.origin_account_id = $1 OR t.target_account_id = $1 OR t.fee > 0)"
+		fee = "t.fee"
+	}
+	if filter.Sent != nil && *filter.Sent {
+		conditions = append(conditions, "t.origin_account_id = $1")
+	}
+	if filter.Sent != nil && !*filter.Sent {
+		conditions = append(conditions, "t.target_account_id = $1")
+	}
+	if filter.CounterpartyAccountID != "" {
+		counterparty := param(filter.CounterpartyAccountID)
+		conditions = append(conditions, fmt.Sprintf("(t.origin_account_id = %s OR t.target_account_id = %s)", counterparty, counterparty))
+	}
+	if !filter.From.IsZero() {
+		conditions = append(conditions, "t.created_at >= "+param(filter.From))
+	}
+	if !filter.To.IsZero() {
+		conditions = append(conditions, "t.created_at < "+param(filter.To))
+	}
+	if filter.MinAmount > 0 {
+		conditions = append(conditions, amount+" >= "+param(filter.MinAmount))
+	}
+	if filter.MaxAmount > 0 {
+		conditions = append(conditions, amount+" <= "+param(filter.MaxAmount))
+	}
+	if filter.Cursor != nil {
+		conditions = append(conditions, fmt.Sprintf(
+			"(t.created_at, t.id) %s (%s, %s)", comparator, param(filter.Cursor.CreatedAt), param(filter.Cursor.ID),
+		))
+	}
+
 	query := `
 		SELECT 
 			t.id, 
 			t.origin_account_id, 
 			t.target_account_id, 
 			t.amount, 
+			t.source_currency, 
+			t.target_amount, 
+			t.target_currency, 
+			t.exchange_rate, 
+			t.fee, 
+			t.reversed_transfer_id,
 			t.created_at, 
 			t.origin_account_id = $1 AS sent,
+			(
+				SELECT COALESCE(SUM(CASE WHEN t.origin_account_id = $1 THEN r.target_amount ELSE r.amount END), 0) 
+				FROM transfers r 
+				WHERE r.reversed_transfer_id = t.id
+			) AS reversed_amount,
+			` + fee + ` AS collected_fee,
 			oa.name AS origin_account_name,
 			ta.name AS target_account_name
 		FROM transfers t
 			INNER JOIN accounts oa ON oa.id = t.origin_account_id
 			INNER JOIN accounts ta ON ta.id = t.target_account_id
-		WHERE origin_account_id = $1 OR target_account_id = $1`
-	transfers := make([]model.TransferDetailed, 0)
-	err := r.db.SelectContext(ctx, &transfers, query, accountID)
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY t.created_at ` + order + `, t.id ` + order
+
+	if filter.Limit > 0 {
+		query += `
+		LIMIT ` + param(filter.Limit)
+	}
+	return query, args
+}
+
+// GetByIDForUpdate reads a transfer locking its row until the end of the current transaction.
+func (r *repositoryImpl) GetByIDForUpdate(ctx context.Context, id string) (*model.Transfer, error) {
+	query := `
+		SELECT 
+			id, origin_account_id, target_account_id, amount, source_currency, target_amount, target_currency, 
+			exchange_rate, fee, reversed_transfer_id, created_at 
+		FROM transfers 
+		WHERE id = $1 
+		FOR UPDATE`
+	transfer := new(model.Transfer)
+	err := r.db.GetContext(ctx, transfer, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		r.logger.Error(err)
+		return nil, err
+	}
+	return transfer, nil
+}
+
+// GetReversedAmount sums the amount of all reversals already made for the transfer, in its target currency.
+func (r *repositoryImpl) GetReversedAmount(ctx context.Context, id string) (int64, error) {
+	query := "SELECT COALESCE(SUM(amount), 0) FROM transfers WHERE reversed_transfer_id = $1"
+	var amount int64
+	err := r.db.GetContext(ctx, &amount, query, id)
+	if err != nil {
+		r.logger.Error(err)
+		return 0, err
+	}
+	return amount, nil
+}
+
+// CountSent counts the transfers sent by the account since the given time, reversals excluded.
+func (r *repositoryImpl) CountSent(ctx context.Context, accountID string, since time.Time) (int, error) {
+	query := `
+		SELECT COUNT(*) 
+		FROM transfers 
+		WHERE origin_account_id = $1 AND created_at >= $2 AND reversed_transfer_id IS NULL`
+	var count int
+	err := r.db.GetContext(ctx, &count, query, accountID, since)
+	if err != nil {
+		r.logger.Error(err)
+		return 0, err
+	}
+	return count, nil
+}
+
+// ListSent returns the transfers sent by the account since the given time, newest first, reversals excluded.
+func (r *repositoryImpl) ListSent(ctx context.Context, accountID string, since time.Time) ([]model.Transfer, error) {
+	query := `
+		SELECT id, origin_account_id, target_account_id, amount, created_at 
+		FROM transfers 
+		WHERE origin_account_id = $1 AND created_at >= $2 AND reversed_transfer_id IS NULL
+		ORDER BY created_at DESC`
+	transfers := make([]model.Transfer, 0)
+	err := r.db.SelectContext(ctx, &transfers, query, accountID, since)
 	if err != nil {
 		r.logger.Error(err)
 		return nil, err
@@ -71,6 +257,46 @@ func (r *repositoryImpl) List(ctx context.Context, accountID string) ([]model.Tr
 	return transfers, nil
 }
 
+// GetUsage sums the transfers sent by the account since dayStart and since monthStart, reversals excluded. Active holds
+// and transfers waiting for a review are counted too, as they become transfers without being limited again.
+func (r *repositoryImpl) GetUsage(ctx context.Context, accountID string, dayStart time.Time, monthStart time.Time) (*model.TransferUsage, error) {
+	query := `
+		SELECT COALESCE(SUM(amount) FILTER (WHERE created_at >= $2), 0) AS daily,
+			COALESCE(SUM(amount), 0) AS monthly,
+			COUNT(*) FILTER (WHERE created_at >= $2) AS daily_count
+		FROM (
+			SELECT amount, created_at
+			FROM transfers
+			WHERE origin_account_id = $1 AND created_at >= $3 AND reversed_transfer_id IS NULL
+			UNION ALL
+			SELECT amount, created_at
+			FROM holds
+			WHERE origin_account_id = $1 AND created_at >= $3 AND status = 'active'
+			UNION ALL
+			SELECT amount, created_at
+			FROM transfer_reviews
+			WHERE origin_account_id = $1 AND created_at >= $3 AND status = 'pending'
+		) sent`
+	usage := new(model.TransferUsage)
+	err := r.db.GetContext(ctx, usage, query, accountID, dayStart, monthStart)
+	if err != nil {
+		r.logger.Error(err)
+		return nil, err
+	}
+	return usage, nil
+}
+
+func (r *repositoryImpl) CreateFees(ctx context.Context, fees []model.TransferFee) error {
+	query := `
+		INSERT INTO transfer_fees(transfer_id, rule, amount) 
+		VALUES (:transfer_id, :rule, :amount)`
+	_, err := r.db.NamedExecContext(ctx, query, fees)
+	if err != nil {
+		r.logger.Error(err)
+	}
+	return err
+}
+
 func (r *repositoryImpl) WithTransaction(conn transaction.Transaction) Repository {
 	return &repositoryImpl{
 		logger: r.logger,
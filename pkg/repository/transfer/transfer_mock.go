@@ -7,6 +7,7 @@ package transfer
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	model "github.com/carlosrodriguesf/bank-api/pkg/model"
 	transaction "github.com/carlosrodriguesf/bank-api/pkg/tool/transaction"
@@ -36,6 +37,21 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountSent mocks base method.
+func (m *MockRepository) CountSent(ctx context.Context, accountID string, since time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountSent", ctx, accountID, since)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountSent indicates an expected call of CountSent.
+func (mr *MockRepositoryMockRecorder) CountSent(ctx, accountID, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSent", reflect.TypeOf((*MockRepository)(nil).CountSent), ctx, accountID, since)
+}
+
 // Create mocks base method.
 func (m *MockRepository) Create(ctx context.Context, movement model.Transfer) (*model.GeneratedData, error) {
 	m.ctrl.T.Helper()
@@ -51,19 +67,107 @@ func (mr *MockRepositoryMockRecorder) Create(ctx, movement interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, movement)
 }
 
+// CreateFees mocks base method.
+func (m *MockRepository) CreateFees(ctx context.Context, fees []model.TransferFee) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFees", ctx, fees)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateFees indicates an expected call of CreateFees.
+func (mr *MockRepositoryMockRecorder) CreateFees(ctx, fees interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFees", reflect.TypeOf((*MockRepository)(nil).CreateFees), ctx, fees)
+}
+
+// GetByIDForUpdate mocks base method.
+func (m *MockRepository) GetByIDForUpdate(ctx context.Context, id string) (*model.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDForUpdate", ctx, id)
+	ret0, _ := ret[0].(*model.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDForUpdate indicates an expected call of GetByIDForUpdate.
+func (mr *MockRepositoryMockRecorder) GetByIDForUpdate(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDForUpdate", reflect.TypeOf((*MockRepository)(nil).GetByIDForUpdate), ctx, id)
+}
+
+// GetReversedAmount mocks base method.
+func (m *MockRepository) GetReversedAmount(ctx context.Context, id string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReversedAmount", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReversedAmount indicates an expected call of GetReversedAmount.
+func (mr *MockRepositoryMockRecorder) GetReversedAmount(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReversedAmount", reflect.TypeOf((*MockRepository)(nil).GetReversedAmount), ctx, id)
+}
+
+// GetUsage mocks base method.
+func (m *MockRepository) GetUsage(ctx context.Context, accountID string, dayStart, monthStart time.Time) (*model.TransferUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsage", ctx, accountID, dayStart, monthStart)
+	ret0, _ := ret[0].(*model.TransferUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsage indicates an expected call of GetUsage.
+func (mr *MockRepositoryMockRecorder) GetUsage(ctx, accountID, dayStart, monthStart interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsage", reflect.TypeOf((*MockRepository)(nil).GetUsage), ctx, accountID, dayStart, monthStart)
+}
+
 // List mocks base method.
-func (m *MockRepository) List(ctx context.Context, accountID string) ([]model.TransferDetailed, error) {
+func (m *MockRepository) List(ctx context.Context, filter model.TransferFilter) ([]model.TransferDetailed, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", ctx, accountID)
+	ret := m.ctrl.Call(m, "List", ctx, filter)
 	ret0, _ := ret[0].([]model.TransferDetailed)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // List indicates an expected call of List.
-func (mr *MockRepositoryMockRecorder) List(ctx, accountID interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) List(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepository)(nil).List), ctx, filter)
+}
+
+// ListSent mocks base method.
+func (m *MockRepository) ListSent(ctx context.Context, accountID string, since time.Time) ([]model.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSent", ctx, accountID, since)
+	ret0, _ := ret[0].([]model.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSent indicates an expected call of ListSent.
+func (mr *MockRepositoryMockRecorder) ListSent(ctx, accountID, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSent", reflect.TypeOf((*MockRepository)(nil).ListSent), ctx, accountID, since)
+}
+
+// Stream mocks base method.
+func (m *MockRepository) Stream(ctx context.Context, filter model.TransferFilter, fn func(model.TransferDetailed) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stream", ctx, filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stream indicates an expected call of Stream.
+func (mr *MockRepositoryMockRecorder) Stream(ctx, filter, fn interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepository)(nil).List), ctx, accountID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stream", reflect.TypeOf((*MockRepository)(nil).Stream), ctx, filter, fn)
 }
 
 // WithTransaction mocks base method.
@@ -11,6 +11,7 @@ import (
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/test"
 	"github.com/stretchr/testify/assert"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 )
@@ -22,14 +23,24 @@ func TestCreate(t *testing.T) {
 			OriginAccountID: "origin_account_id",
 			TargetAccountID: "target_account_id",
 			Amount:          500,
+			SourceCurrency:  "USD",
+			TargetAmount:    2625,
+			TargetCurrency:  "BRL",
+			ExchangeRate:    "5.25",
+			Fee:             50,
 		}
 		genreatedDataExample = model.GeneratedData{
 			ID:        "generated_id",
 			CreatedAt: currentTime,
 		}
 		query = regexp.QuoteMeta(`
-			INSERT INTO transfers(origin_account_id, target_account_id, amount) 
-			VALUES (?, ?, ?)
+			INSERT INTO transfers(
+				origin_account_id, target_account_id, amount, source_currency, target_amount, target_currency, exchange_rate, 
+				fee, reversed_transfer_id
+			) 
+			VALUES (
+				?, ?, ?, ?, ?, ?, ?, ?, ?
+			)
 			RETURNING id, created_at
 		`)
 	)
@@ -52,7 +63,17 @@ func TestCreate(t *testing.T) {
 					)
 				mock.ExpectPrepare(query).
 					ExpectQuery().
-					WithArgs(transferExample.OriginAccountID, transferExample.TargetAccountID, transferExample.Amount).
+					WithArgs(
+						transferExample.OriginAccountID,
+						transferExample.TargetAccountID,
+						transferExample.Amount,
+						transferExample.SourceCurrency,
+						transferExample.TargetAmount,
+						transferExample.TargetCurrency,
+						transferExample.ExchangeRate,
+						transferExample.Fee,
+						nil,
+					).
 					WillReturnRows(rows)
 			},
 		},
@@ -66,7 +87,17 @@ func TestCreate(t *testing.T) {
 
 				mock.ExpectPrepare(query).
 					ExpectQuery().
-					WithArgs(transferExample.OriginAccountID, transferExample.TargetAccountID, transferExample.Amount).
+					WithArgs(
+						transferExample.OriginAccountID,
+						transferExample.TargetAccountID,
+						transferExample.Amount,
+						transferExample.SourceCurrency,
+						transferExample.TargetAmount,
+						transferExample.TargetCurrency,
+						transferExample.ExchangeRate,
+						transferExample.Fee,
+						nil,
+					).
 					WillReturnRows(rows)
 			},
 		},
@@ -77,7 +108,17 @@ func TestCreate(t *testing.T) {
 			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
 				mock.ExpectPrepare(query).
 					ExpectQuery().
-					WithArgs(transferExample.OriginAccountID, transferExample.TargetAccountID, transferExample.Amount).
+					WithArgs(
+						transferExample.OriginAccountID,
+						transferExample.TargetAccountID,
+						transferExample.Amount,
+						transferExample.SourceCurrency,
+						transferExample.TargetAmount,
+						transferExample.TargetCurrency,
+						transferExample.ExchangeRate,
+						transferExample.Fee,
+						nil,
+					).
 					WillReturnError(errors.New("fail"))
 			},
 		},
@@ -106,43 +147,419 @@ func TestCreate(t *testing.T) {
 func TestList(t *testing.T) {
 	var (
 		currentTime      = time.Now()
+		sent             = true
 		transfersExample = []model.TransferDetailed{{
 			Transfer: model.Transfer{
 				ID:              "transfer_id",
 				OriginAccountID: "origin_account_id",
 				TargetAccountID: "target_account_id",
 				Amount:          500,
+				SourceCurrency:  "BRL",
+				TargetAmount:    500,
+				TargetCurrency:  "BRL",
+				ExchangeRate:    "1",
+				Fee:             25,
 				CreatedAt:       currentTime,
 			},
+			ReversedAmount:    100,
 			OriginAccountName: "Origin Account",
 			TargetAccountName: "Target account",
 		}}
+		selectQuery = `
+			SELECT 
+				t.id, 
+				t.origin_account_id, 
+				t.target_account_id, 
+				t.amount, 
+				t.source_currency, 
+				t.target_amount, 
+				t.target_currency, 
+				t.exchange_rate, 
+				t.fee, 
+				t.reversed_transfer_id,
+				t.created_at, 
+				t.origin_account_id = $1 AS sent,
+				(
+					SELECT COALESCE(SUM(CASE WHEN t.origin_account_id = $1 THEN r.target_amount ELSE r.amount END), 0) 
+					FROM transfers r 
+					WHERE r.reversed_transfer_id = t.id
+				) AS reversed_amount,
+				0 AS collected_fee,
+				oa.name AS origin_account_name,
+				ta.name AS target_account_name
+			FROM transfers t
+				INNER JOIN accounts oa ON oa.id = t.origin_account_id
+				INNER JOIN accounts ta ON ta.id = t.target_account_id
+		`
+		newRows = func() *sqlmock.Rows {
+			rows := sqlmock.
+				NewRows([]string{
+					"id",
+					"origin_account_id",
+					"target_account_id",
+					"amount",
+					"source_currency",
+					"target_amount",
+					"target_currency",
+					"exchange_rate",
+					"fee",
+					"reversed_transfer_id",
+					"created_at",
+					"sent",
+					"reversed_amount",
+					"collected_fee",
+					"origin_account_name",
+					"target_account_name",
+				})
+			for _, t := range transfersExample {
+				rows.AddRow(
+					t.ID,
+					t.OriginAccountID,
+					t.TargetAccountID,
+					t.Amount,
+					t.SourceCurrency,
+					t.TargetAmount,
+					t.TargetCurrency,
+					t.ExchangeRate,
+					t.Fee,
+					t.ReversedTransferID,
+					t.CreatedAt,
+					t.Sent,
+					t.ReversedAmount,
+					t.CollectedFee,
+					t.OriginAccountName,
+					t.TargetAccountName,
+				)
+			}
+			return rows
+		}
+	)
+	cases := map[string]struct {
+		InputData      model.TransferFilter
+		ExpectedData   []model.TransferDetailed
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			InputData:     model.TransferFilter{AccountID: "origin_account_id", Limit: 10},
+			ExpectedData:  transfersExample,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				query := regexp.QuoteMeta(selectQuery + `
+					WHERE (t.origin_account_id = $1 OR t.target_account_id = $1)
+					ORDER BY t.created_at DESC, t.id DESC
+					LIMIT $2
+				`)
+				mock.ExpectQuery(query).
+					WithArgs("origin_account_id", 10).
+					WillReturnRows(newRows())
+			},
+		},
+		"should return success for the fee account": {
+			InputData:     model.TransferFilter{AccountID: "fee_account_id", CollectedFees: true, Limit: 10},
+			ExpectedData:  transfersExample,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				query := regexp.QuoteMeta(strings.Replace(selectQuery, "0 AS collected_fee", "t.fee AS collected_fee", 1) + `
+					WHERE (t.origin_account_id = $1 OR t.target_account_id = $1 OR t.fee > 0)
+					ORDER BY t.created_at DESC, t.id DESC
+					LIMIT $2
+				`)
+				mock.ExpectQuery(query).
+					WithArgs("fee_account_id", 10).
+					WillReturnRows(newRows())
+			},
+		},
+		"should return success with all filters": {
+			InputData: model.TransferFilter{
+				AccountID:             "origin_account_id",
+				Sent:                  &sent,
+				CounterpartyAccountID: "target_account_id",
+				From:                  currentTime.Add(-time.Hour),
+				To:                    currentTime,
+				MinAmount:             100,
+				MaxAmount:             1000,
+				Sort:                  model.SortAsc,
+				Cursor:                &model.Cursor{CreatedAt: currentTime.Add(-time.Minute), ID: "last_id"},
+				Limit:                 10,
+			},
+			ExpectedData:  transfersExample,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				query := regexp.QuoteMeta(selectQuery + `
+					WHERE (t.origin_account_id = $1 OR t.target_account_id = $1) 
+						AND t.origin_account_id = $1 
+						AND (t.origin_account_id = $2 OR t.target_account_id = $2) 
+						AND t.created_at >= $3 
+						AND t.created_at < $4 
+						AND CASE WHEN t.origin_account_id = $1 THEN t.amount ELSE t.target_amount END >= $5 
+						AND CASE WHEN t.origin_account_id = $1 THEN t.amount ELSE t.target_amount END <= $6 
+						AND (t.created_at, t.id) > ($7, $8)
+					ORDER BY t.created_at ASC, t.id ASC
+					LIMIT $9
+				`)
+				mock.ExpectQuery(query).
+					WithArgs(
+						"origin_account_id",
+						"target_account_id",
+						currentTime.Add(-time.Hour),
+						currentTime,
+						100,
+						1000,
+						currentTime.Add(-time.Minute),
+						"last_id",
+						10,
+					).
+					WillReturnRows(newRows())
+			},
+		},
+		"should return error": {
+			InputData:     model.TransferFilter{AccountID: "origin_account_id", Limit: 10},
+			ExpectedData:  nil,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(selectQuery)).
+					WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				dbConn, sqlMock = test.GetSQLMock()
+				repository      = NewRepository(Options{
+					Logger: logger.New(""),
+					DB:     db.NewExtendedDB(dbConn),
+				})
+			)
+
+			cs.PrepareMockSQL(sqlMock)
+
+			data, err := repository.List(context.Background(), cs.InputData)
+
+			assert.Equal(t, cs.ExpectedError, err)
+			assert.Equal(t, cs.ExpectedData, data)
+		})
+	}
+}
+
+func TestStream(t *testing.T) {
+	var (
+		currentTime      = time.Now()
+		transfersExample = []model.TransferDetailed{
+			{
+				Transfer: model.Transfer{
+					ID:              "transfer_id_1",
+					OriginAccountID: "origin_account_id",
+					TargetAccountID: "target_account_id",
+					Amount:          500,
+					SourceCurrency:  "BRL",
+					TargetAmount:    500,
+					TargetCurrency:  "BRL",
+					ExchangeRate:    "1",
+					CreatedAt:       currentTime.Add(-time.Minute),
+				},
+				Sent:              true,
+				OriginAccountName: "Origin Account",
+				TargetAccountName: "Target account",
+			},
+			{
+				Transfer: model.Transfer{
+					ID:              "transfer_id_2",
+					OriginAccountID: "target_account_id",
+					TargetAccountID: "origin_account_id",
+					Amount:          300,
+					SourceCurrency:  "BRL",
+					TargetAmount:    300,
+					TargetCurrency:  "BRL",
+					ExchangeRate:    "1",
+					CreatedAt:       currentTime,
+				},
+				OriginAccountName: "Target Account",
+				TargetAccountName: "Origin account",
+			},
+		}
+		filterExample = model.TransferFilter{
+			AccountID: "origin_account_id",
+			From:      currentTime.Add(-time.Hour),
+			Sort:      model.SortAsc,
+		}
 		query = regexp.QuoteMeta(`
 			SELECT 
 				t.id, 
 				t.origin_account_id, 
 				t.target_account_id, 
 				t.amount, 
+				t.source_currency, 
+				t.target_amount, 
+				t.target_currency, 
+				t.exchange_rate, 
+				t.fee, 
+				t.reversed_transfer_id,
 				t.created_at, 
 				t.origin_account_id = $1 AS sent,
+				(
+					SELECT COALESCE(SUM(CASE WHEN t.origin_account_id = $1 THEN r.target_amount ELSE r.amount END), 0) 
+					FROM transfers r 
+					WHERE r.reversed_transfer_id = t.id
+				) AS reversed_amount,
+				0 AS collected_fee,
 				oa.name AS origin_account_name,
 				ta.name AS target_account_name
 			FROM transfers t
 				INNER JOIN accounts oa ON oa.id = t.origin_account_id
 				INNER JOIN accounts ta ON ta.id = t.target_account_id
-			WHERE origin_account_id = $1 OR target_account_id = $1
+			WHERE (t.origin_account_id = $1 OR t.target_account_id = $1) 
+				AND t.created_at >= $2
+			ORDER BY t.created_at ASC, t.id ASC
 		`)
+		newRows = func() *sqlmock.Rows {
+			rows := sqlmock.
+				NewRows([]string{
+					"id",
+					"origin_account_id",
+					"target_account_id",
+					"amount",
+					"source_currency",
+					"target_amount",
+					"target_currency",
+					"exchange_rate",
+					"fee",
+					"reversed_transfer_id",
+					"created_at",
+					"sent",
+					"reversed_amount",
+					"collected_fee",
+					"origin_account_name",
+					"target_account_name",
+				})
+			for _, t := range transfersExample {
+				rows.AddRow(
+					t.ID,
+					t.OriginAccountID,
+					t.TargetAccountID,
+					t.Amount,
+					t.SourceCurrency,
+					t.TargetAmount,
+					t.TargetCurrency,
+					t.ExchangeRate,
+					t.Fee,
+					t.ReversedTransferID,
+					t.CreatedAt,
+					t.Sent,
+					t.ReversedAmount,
+					t.CollectedFee,
+					t.OriginAccountName,
+					t.TargetAccountName,
+				)
+			}
+			return rows
+		}
 	)
 	cases := map[string]struct {
-		InputData      string
+		InputFn        func(received *[]model.TransferDetailed) func(transfer model.TransferDetailed) error
 		ExpectedData   []model.TransferDetailed
 		ExpectedError  error
 		PrepareMockSQL func(mock sqlmock.Sqlmock)
 	}{
 		"should return success": {
-			InputData:     "origin_account_id",
+			InputFn: func(received *[]model.TransferDetailed) func(transfer model.TransferDetailed) error {
+				return func(transfer model.TransferDetailed) error {
+					*received = append(*received, transfer)
+					return nil
+				}
+			},
 			ExpectedData:  transfersExample,
 			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs("origin_account_id", currentTime.Add(-time.Hour)).
+					WillReturnRows(newRows())
+			},
+		},
+		"should stop on callback error": {
+			InputFn: func(received *[]model.TransferDetailed) func(transfer model.TransferDetailed) error {
+				return func(transfer model.TransferDetailed) error {
+					*received = append(*received, transfer)
+					return errors.New("fail")
+				}
+			},
+			ExpectedData:  transfersExample[:1],
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs("origin_account_id", currentTime.Add(-time.Hour)).
+					WillReturnRows(newRows())
+			},
+		},
+		"should return error": {
+			InputFn: func(received *[]model.TransferDetailed) func(transfer model.TransferDetailed) error {
+				return func(transfer model.TransferDetailed) error {
+					*received = append(*received, transfer)
+					return nil
+				}
+			},
+			ExpectedData:  nil,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				dbConn, sqlMock = test.GetSQLMock()
+				repository      = NewRepository(Options{
+					Logger: logger.New(""),
+					DB:     db.NewExtendedDB(dbConn),
+				})
+				received []model.TransferDetailed
+			)
+
+			cs.PrepareMockSQL(sqlMock)
+
+			err := repository.Stream(context.Background(), filterExample, cs.InputFn(&received))
+
+			assert.Equal(t, cs.ExpectedError, err)
+			assert.Equal(t, cs.ExpectedData, received)
+		})
+	}
+}
+
+func TestGetByIDForUpdate(t *testing.T) {
+	var (
+		transferExample = model.Transfer{
+			ID:              "transfer_id",
+			OriginAccountID: "origin_account_id",
+			TargetAccountID: "target_account_id",
+			Amount:          500,
+			SourceCurrency:  "USD",
+			TargetAmount:    2625,
+			TargetCurrency:  "BRL",
+			ExchangeRate:    "5.25",
+			Fee:             50,
+			CreatedAt:       time.Now(),
+		}
+		query = regexp.QuoteMeta(`
+			SELECT 
+				id, origin_account_id, target_account_id, amount, source_currency, target_amount, target_currency, 
+				exchange_rate, fee, reversed_transfer_id, created_at 
+			FROM transfers 
+			WHERE id = $1 
+			FOR UPDATE
+		`)
+	)
+	cases := map[string]struct {
+		ExpectedData   *model.Transfer
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedData:  &transferExample,
+			ExpectedError: nil,
 			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.
 					NewRows([]string{
@@ -150,36 +567,42 @@ func TestList(t *testing.T) {
 						"origin_account_id",
 						"target_account_id",
 						"amount",
+						"source_currency",
+						"target_amount",
+						"target_currency",
+						"exchange_rate",
+						"fee",
+						"reversed_transfer_id",
 						"created_at",
-						"sent",
-						"origin_account_name",
-						"target_account_name",
-					})
-				for _, t := range transfersExample {
-					rows.AddRow(
-						t.ID,
-						t.OriginAccountID,
-						t.TargetAccountID,
-						t.Amount,
-						t.CreatedAt,
-						t.Sent,
-						t.OriginAccountName,
-						t.TargetAccountName,
+					}).
+					AddRow(
+						transferExample.ID,
+						transferExample.OriginAccountID,
+						transferExample.TargetAccountID,
+						transferExample.Amount,
+						transferExample.SourceCurrency,
+						transferExample.TargetAmount,
+						transferExample.TargetCurrency,
+						transferExample.ExchangeRate,
+						transferExample.Fee,
+						nil,
+						transferExample.CreatedAt,
 					)
-				}
-				mock.ExpectQuery(query).
-					WithArgs("origin_account_id").
-					WillReturnRows(rows)
+				mock.ExpectQuery(query).WithArgs(transferExample.ID).WillReturnRows(rows)
+			},
+		},
+		"should return nil when not found": {
+			ExpectedData:  nil,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs(transferExample.ID).WillReturnError(sql.ErrNoRows)
 			},
 		},
 		"should return error": {
-			InputData:     "origin_account_id",
 			ExpectedData:  nil,
 			ExpectedError: errors.New("fail"),
 			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(query).
-					WithArgs("origin_account_id").
-					WillReturnError(errors.New("fail"))
+				mock.ExpectQuery(query).WithArgs(transferExample.ID).WillReturnError(errors.New("fail"))
 			},
 		},
 	}
@@ -196,7 +619,169 @@ func TestList(t *testing.T) {
 
 			cs.PrepareMockSQL(sqlMock)
 
-			data, err := repository.List(context.Background(), cs.InputData)
+			data, err := repository.GetByIDForUpdate(context.Background(), transferExample.ID)
+
+			assert.Equal(t, cs.ExpectedError, err)
+			assert.Equal(t, cs.ExpectedData, data)
+		})
+	}
+}
+
+func TestGetReversedAmount(t *testing.T) {
+	query := regexp.QuoteMeta("SELECT COALESCE(SUM(amount), 0) FROM transfers WHERE reversed_transfer_id = $1")
+	cases := map[string]struct {
+		ExpectedData   int64
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedData:  300,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"coalesce"}).AddRow(300)
+				mock.ExpectQuery(query).WithArgs("transfer_id").WillReturnRows(rows)
+			},
+		},
+		"should return error": {
+			ExpectedData:  0,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs("transfer_id").WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				dbConn, sqlMock = test.GetSQLMock()
+				repository      = NewRepository(Options{
+					Logger: logger.New(""),
+					DB:     db.NewExtendedDB(dbConn),
+				})
+			)
+
+			cs.PrepareMockSQL(sqlMock)
+
+			data, err := repository.GetReversedAmount(context.Background(), "transfer_id")
+
+			assert.Equal(t, cs.ExpectedError, err)
+			assert.Equal(t, cs.ExpectedData, data)
+		})
+	}
+}
+
+func TestCountSent(t *testing.T) {
+	var (
+		since = time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+		query = regexp.QuoteMeta(`
+			SELECT COUNT(*) 
+			FROM transfers 
+			WHERE origin_account_id = $1 AND created_at >= $2 AND reversed_transfer_id IS NULL
+		`)
+	)
+	cases := map[string]struct {
+		ExpectedData   int
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedData:  3,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"count"}).AddRow(3)
+				mock.ExpectQuery(query).WithArgs("account_id", since).WillReturnRows(rows)
+			},
+		},
+		"should return error": {
+			ExpectedData:  0,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs("account_id", since).WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				dbConn, sqlMock = test.GetSQLMock()
+				repository      = NewRepository(Options{
+					Logger: logger.New(""),
+					DB:     db.NewExtendedDB(dbConn),
+				})
+			)
+
+			cs.PrepareMockSQL(sqlMock)
+
+			data, err := repository.CountSent(context.Background(), "account_id", since)
+
+			assert.Equal(t, cs.ExpectedError, err)
+			assert.Equal(t, cs.ExpectedData, data)
+		})
+	}
+}
+
+func TestListSent(t *testing.T) {
+	var (
+		since = time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+		query = regexp.QuoteMeta(`
+			SELECT id, origin_account_id, target_account_id, amount, created_at 
+			FROM transfers 
+			WHERE origin_account_id = $1 AND created_at >= $2 AND reversed_transfer_id IS NULL
+			ORDER BY created_at DESC
+		`)
+		transferExample = model.Transfer{
+			ID:              "transfer_id",
+			OriginAccountID: "account_id",
+			TargetAccountID: "target_account_id",
+			Amount:          1000,
+			CreatedAt:       since,
+		}
+	)
+	cases := map[string]struct {
+		ExpectedData   []model.Transfer
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedData:  []model.Transfer{transferExample},
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.
+					NewRows([]string{"id", "origin_account_id", "target_account_id", "amount", "created_at"}).
+					AddRow(
+						transferExample.ID,
+						transferExample.OriginAccountID,
+						transferExample.TargetAccountID,
+						transferExample.Amount,
+						transferExample.CreatedAt,
+					)
+				mock.ExpectQuery(query).WithArgs("account_id", since).WillReturnRows(rows)
+			},
+		},
+		"should return error": {
+			ExpectedData:  nil,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs("account_id", since).WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				dbConn, sqlMock = test.GetSQLMock()
+				repository      = NewRepository(Options{
+					Logger: logger.New(""),
+					DB:     db.NewExtendedDB(dbConn),
+				})
+			)
+
+			cs.PrepareMockSQL(sqlMock)
+
+			data, err := repository.ListSent(context.Background(), "account_id", since)
 
 			assert.Equal(t, cs.ExpectedError, err)
 			assert.Equal(t, cs.ExpectedData, data)
@@ -204,6 +789,123 @@ func TestList(t *testing.T) {
 	}
 }
 
+func TestGetUsage(t *testing.T) {
+	var (
+		dayStart   = time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+		monthStart = time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+		query      = regexp.QuoteMeta(`
+			SELECT COALESCE(SUM(amount) FILTER (WHERE created_at >= $2), 0) AS daily,
+				COALESCE(SUM(amount), 0) AS monthly,
+				COUNT(*) FILTER (WHERE created_at >= $2) AS daily_count
+			FROM (
+				SELECT amount, created_at
+				FROM transfers
+				WHERE origin_account_id = $1 AND created_at >= $3 AND reversed_transfer_id IS NULL
+				UNION ALL
+				SELECT amount, created_at
+				FROM holds
+				WHERE origin_account_id = $1 AND created_at >= $3 AND status = 'active'
+				UNION ALL
+				SELECT amount, created_at
+				FROM transfer_reviews
+				WHERE origin_account_id = $1 AND created_at >= $3 AND status = 'pending'
+			) sent
+		`)
+	)
+	cases := map[string]struct {
+		ExpectedData   *model.TransferUsage
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedData:  &model.TransferUsage{Daily: 300, Monthly: 1200, DailyCount: 2},
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"daily", "monthly", "daily_count"}).AddRow(300, 1200, 2)
+				mock.ExpectQuery(query).WithArgs("account_id", dayStart, monthStart).WillReturnRows(rows)
+			},
+		},
+		"should return error": {
+			ExpectedData:  nil,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs("account_id", dayStart, monthStart).WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				dbConn, sqlMock = test.GetSQLMock()
+				repository      = NewRepository(Options{
+					Logger: logger.New(""),
+					DB:     db.NewExtendedDB(dbConn),
+				})
+			)
+
+			cs.PrepareMockSQL(sqlMock)
+
+			data, err := repository.GetUsage(context.Background(), "account_id", dayStart, monthStart)
+
+			assert.Equal(t, cs.ExpectedError, err)
+			assert.Equal(t, cs.ExpectedData, data)
+		})
+	}
+}
+
+func TestCreateFees(t *testing.T) {
+	var (
+		feesExample = []model.TransferFee{
+			{TransferID: "transfer_id", Rule: "pix", Amount: 50},
+			{TransferID: "transfer_id", Rule: "service", Amount: 15},
+		}
+		query = regexp.QuoteMeta(`
+			INSERT INTO transfer_fees(transfer_id, rule, amount) 
+			VALUES (?, ?, ?),(?, ?, ?)
+		`)
+	)
+	cases := map[string]struct {
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs("transfer_id", "pix", 50, "transfer_id", "service", 15).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+		},
+		"should return error": {
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs("transfer_id", "pix", 50, "transfer_id", "service", 15).
+					WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				dbConn, sqlMock = test.GetSQLMock()
+				repository      = NewRepository(Options{
+					Logger: logger.New(""),
+					DB:     db.NewExtendedDB(dbConn),
+				})
+			)
+
+			cs.PrepareMockSQL(sqlMock)
+
+			err := repository.CreateFees(context.Background(), feesExample)
+
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
 func TestWithTransaction(t *testing.T) {
 	repoWithDB := &repositoryImpl{
 		db: db.ExtendedDB(nil),
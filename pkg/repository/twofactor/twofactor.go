@@ -0,0 +1,108 @@
+//go:generate mockgen -source=${GOFILE} -package=${GOPACKAGE} -destination=${GOPACKAGE}_mock.go
+
+package twofactor
+
+import (
+	"context"
+	"database/sql"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/db"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/transaction"
+)
+
+type (
+	Options struct {
+		Logger logger.Logger
+		DB     db.Connection
+	}
+	Repository interface {
+		Get(ctx context.Context, accountID string) (*model.TwoFactor, error)
+		Save(ctx context.Context, twoFactor model.TwoFactor) (bool, error)
+		Confirm(ctx context.Context, accountID string) (bool, error)
+		CreateRecoveryCodes(ctx context.Context, codes []model.TwoFactorRecoveryCode) error
+		UseRecoveryCode(ctx context.Context, accountID string, codeHash string) (bool, error)
+		WithTransaction(conn transaction.Transaction) Repository
+	}
+	repositoryImpl struct {
+		logger logger.Logger
+		db     db.Connection
+	}
+)
+
+func NewRepository(opts Options) Repository {
+	return &repositoryImpl{
+		logger: opts.Logger.WithLocation().WithPreffix("repository.twofactor"),
+		db:     opts.DB,
+	}
+}
+
+func (r *repositoryImpl) Get(ctx context.Context, accountID string) (*model.TwoFactor, error) {
+	query := "SELECT account_id, secret, confirmed_at, created_at FROM two_factors WHERE account_id = $1"
+	twoFactor := new(model.TwoFactor)
+	err := r.db.GetContext(ctx, twoFactor, query, accountID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		r.logger.Error(err)
+		return nil, err
+	}
+	return twoFactor, nil
+}
+
+// Save stores a new pending secret for the account, replacing a previous one that was never confirmed. It returns
+// false when the account already has a confirmed secret, which is left untouched.
+func (r *repositoryImpl) Save(ctx context.Context, twoFactor model.TwoFactor) (bool, error) {
+	query := `
+		INSERT INTO two_factors(account_id, secret) VALUES ($1, $2)
+		ON CONFLICT (account_id) DO UPDATE SET secret = EXCLUDED.secret, created_at = CURRENT_TIMESTAMP
+		WHERE two_factors.confirmed_at IS NULL`
+	return r.execAffectingOne(ctx, query, twoFactor.AccountID, twoFactor.Secret)
+}
+
+// Confirm enables the pending secret of the account. It returns false when there is none.
+func (r *repositoryImpl) Confirm(ctx context.Context, accountID string) (bool, error) {
+	query := "UPDATE two_factors SET confirmed_at = CURRENT_TIMESTAMP WHERE account_id = $1 AND confirmed_at IS NULL"
+	return r.execAffectingOne(ctx, query, accountID)
+}
+
+func (r *repositoryImpl) CreateRecoveryCodes(ctx context.Context, codes []model.TwoFactorRecoveryCode) error {
+	query := `
+		INSERT INTO two_factor_recovery_codes(account_id, code_hash) 
+		VALUES (:account_id, :code_hash)`
+	_, err := r.db.NamedExecContext(ctx, query, codes)
+	if err != nil {
+		r.logger.Error(err)
+	}
+	return err
+}
+
+// UseRecoveryCode spends the recovery code. It returns false when the code does not exist or was already used.
+func (r *repositoryImpl) UseRecoveryCode(ctx context.Context, accountID string, codeHash string) (bool, error) {
+	query := `
+		UPDATE two_factor_recovery_codes SET used_at = CURRENT_TIMESTAMP
+		WHERE account_id = $1 AND code_hash = $2 AND used_at IS NULL`
+	return r.execAffectingOne(ctx, query, accountID, codeHash)
+}
+
+func (r *repositoryImpl) WithTransaction(conn transaction.Transaction) Repository {
+	return &repositoryImpl{
+		logger: r.logger,
+		db:     conn,
+	}
+}
+
+func (r *repositoryImpl) execAffectingOne(ctx context.Context, query string, args ...interface{}) (bool, error) {
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		r.logger.Error(err)
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.Error(err)
+		return false, err
+	}
+	return affected == 1, nil
+}
@@ -0,0 +1,309 @@
+package twofactor
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/carlosrodriguesf/bank-api/pkg/model"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/db"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/test"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestGet(t *testing.T) {
+	var (
+		confirmedAt      = time.Now()
+		query            = regexp.QuoteMeta("SELECT account_id, secret, confirmed_at, created_at FROM two_factors WHERE account_id = $1")
+		twoFactorExample = model.TwoFactor{
+			AccountID:   "account_id",
+			Secret:      "secret",
+			ConfirmedAt: &confirmedAt,
+			CreatedAt:   confirmedAt,
+		}
+	)
+
+	cases := map[string]struct {
+		ExpectedData   *model.TwoFactor
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedData:  &twoFactorExample,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.
+					NewRows([]string{"account_id", "secret", "confirmed_at", "created_at"}).
+					AddRow("account_id", "secret", confirmedAt, confirmedAt)
+				mock.ExpectQuery(query).WithArgs("account_id").WillReturnRows(rows)
+			},
+		},
+		"should return success: not enrolled": {
+			ExpectedData:  nil,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs("account_id").WillReturnError(sql.ErrNoRows)
+			},
+		},
+		"should return error": {
+			ExpectedData:  nil,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs("account_id").WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				dbConn, sqlMock = test.GetSQLMock()
+				repository      = NewRepository(Options{
+					Logger: logger.New(""),
+					DB:     db.NewExtendedDB(dbConn),
+				})
+			)
+
+			cs.PrepareMockSQL(sqlMock)
+
+			data, err := repository.Get(context.Background(), "account_id")
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestSave(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		INSERT INTO two_factors(account_id, secret) VALUES ($1, $2)
+		ON CONFLICT (account_id) DO UPDATE SET secret = EXCLUDED.secret, created_at = CURRENT_TIMESTAMP
+		WHERE two_factors.confirmed_at IS NULL`)
+
+	cases := map[string]struct {
+		ExpectedData   bool
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedData:  true,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).WithArgs("account_id", "secret").WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		"should return success: already confirmed": {
+			ExpectedData:  false,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).WithArgs("account_id", "secret").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+		"should return error": {
+			ExpectedData:  false,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).WithArgs("account_id", "secret").WillReturnError(errors.New("fail"))
+			},
+		},
+		"should return error on rows affected": {
+			ExpectedData:  false,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs("account_id", "secret").
+					WillReturnResult(sqlmock.NewErrorResult(errors.New("fail")))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				dbConn, sqlMock = test.GetSQLMock()
+				repository      = NewRepository(Options{
+					Logger: logger.New(""),
+					DB:     db.NewExtendedDB(dbConn),
+				})
+			)
+
+			cs.PrepareMockSQL(sqlMock)
+
+			data, err := repository.Save(context.Background(), model.TwoFactor{AccountID: "account_id", Secret: "secret"})
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	query := regexp.QuoteMeta("UPDATE two_factors SET confirmed_at = CURRENT_TIMESTAMP WHERE account_id = $1 AND confirmed_at IS NULL")
+
+	cases := map[string]struct {
+		ExpectedData   bool
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedData:  true,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).WithArgs("account_id").WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		"should return success: nothing pending": {
+			ExpectedData:  false,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).WithArgs("account_id").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+		"should return error": {
+			ExpectedData:  false,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).WithArgs("account_id").WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				dbConn, sqlMock = test.GetSQLMock()
+				repository      = NewRepository(Options{
+					Logger: logger.New(""),
+					DB:     db.NewExtendedDB(dbConn),
+				})
+			)
+
+			cs.PrepareMockSQL(sqlMock)
+
+			data, err := repository.Confirm(context.Background(), "account_id")
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestCreateRecoveryCodes(t *testing.T) {
+	var (
+		codesExample = []model.TwoFactorRecoveryCode{
+			{AccountID: "account_id", CodeHash: "hash_1"},
+			{AccountID: "account_id", CodeHash: "hash_2"},
+		}
+		query = regexp.QuoteMeta(`
+			INSERT INTO two_factor_recovery_codes(account_id, code_hash) 
+			VALUES (?, ?),(?, ?)
+		`)
+	)
+
+	cases := map[string]struct {
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs("account_id", "hash_1", "account_id", "hash_2").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+		},
+		"should return error": {
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs("account_id", "hash_1", "account_id", "hash_2").
+					WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				dbConn, sqlMock = test.GetSQLMock()
+				repository      = NewRepository(Options{
+					Logger: logger.New(""),
+					DB:     db.NewExtendedDB(dbConn),
+				})
+			)
+
+			cs.PrepareMockSQL(sqlMock)
+
+			err := repository.CreateRecoveryCodes(context.Background(), codesExample)
+
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestUseRecoveryCode(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		UPDATE two_factor_recovery_codes SET used_at = CURRENT_TIMESTAMP
+		WHERE account_id = $1 AND code_hash = $2 AND used_at IS NULL`)
+
+	cases := map[string]struct {
+		ExpectedData   bool
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should return success": {
+			ExpectedData:  true,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).WithArgs("account_id", "hash").WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		"should return success: code already used": {
+			ExpectedData:  false,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).WithArgs("account_id", "hash").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+		"should return error": {
+			ExpectedData:  false,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).WithArgs("account_id", "hash").WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				dbConn, sqlMock = test.GetSQLMock()
+				repository      = NewRepository(Options{
+					Logger: logger.New(""),
+					DB:     db.NewExtendedDB(dbConn),
+				})
+			)
+
+			cs.PrepareMockSQL(sqlMock)
+
+			data, err := repository.UseRecoveryCode(context.Background(), "account_id", "hash")
+
+			assert.Equal(t, cs.ExpectedData, data)
+			assert.Equal(t, cs.ExpectedError, err)
+		})
+	}
+}
+
+func TestWithTransaction(t *testing.T) {
+	repoWithDB := &repositoryImpl{
+		db: db.ExtendedDB(nil),
+	}
+	repoWithTx := &repositoryImpl{
+		db: db.ExtendedTx(nil),
+	}
+	assert.Equal(t, repoWithTx, repoWithDB.WithTransaction(db.ExtendedTx(nil)))
+}
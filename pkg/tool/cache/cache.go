@@ -14,8 +14,15 @@ type Options struct {
 
 type Cache interface {
 	Set(ctx context.Context, key string, value interface{}, d time.Duration) error
+	SetIfNotExists(ctx context.Context, key string, value interface{}, d time.Duration) (bool, error)
+	SetIfExists(ctx context.Context, key string, value interface{}, d time.Duration) (bool, error)
 	Get(ctx context.Context, key string, value interface{}) error
 	GetUpdating(ctx context.Context, key string, value interface{}, d time.Duration) error
+	Increment(ctx context.Context, key string, d time.Duration) (int64, error)
+	Delete(ctx context.Context, key string) error
+	SetField(ctx context.Context, key string, field string, value interface{}, d time.Duration) error
+	GetFields(ctx context.Context, key string, value interface{}) error
+	DeleteFields(ctx context.Context, key string, fields ...string) error
 	Close() error
 	IsErrCacheMissing(err error) bool
 }
@@ -49,6 +49,39 @@ func (mr *MockCacheMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockCache)(nil).Close))
 }
 
+// Delete mocks base method.
+func (m *MockCache) Delete(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCacheMockRecorder) Delete(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCache)(nil).Delete), ctx, key)
+}
+
+// DeleteFields mocks base method.
+func (m *MockCache) DeleteFields(ctx context.Context, key string, fields ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, key}
+	for _, a := range fields {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteFields", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFields indicates an expected call of DeleteFields.
+func (mr *MockCacheMockRecorder) DeleteFields(ctx, key interface{}, fields ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, key}, fields...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFields", reflect.TypeOf((*MockCache)(nil).DeleteFields), varargs...)
+}
+
 // Get mocks base method.
 func (m *MockCache) Get(ctx context.Context, key string, value interface{}) error {
 	m.ctrl.T.Helper()
@@ -63,6 +96,20 @@ func (mr *MockCacheMockRecorder) Get(ctx, key, value interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCache)(nil).Get), ctx, key, value)
 }
 
+// GetFields mocks base method.
+func (m *MockCache) GetFields(ctx context.Context, key string, value interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFields", ctx, key, value)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetFields indicates an expected call of GetFields.
+func (mr *MockCacheMockRecorder) GetFields(ctx, key, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFields", reflect.TypeOf((*MockCache)(nil).GetFields), ctx, key, value)
+}
+
 // GetUpdating mocks base method.
 func (m *MockCache) GetUpdating(ctx context.Context, key string, value interface{}, d time.Duration) error {
 	m.ctrl.T.Helper()
@@ -77,6 +124,21 @@ func (mr *MockCacheMockRecorder) GetUpdating(ctx, key, value, d interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpdating", reflect.TypeOf((*MockCache)(nil).GetUpdating), ctx, key, value, d)
 }
 
+// Increment mocks base method.
+func (m *MockCache) Increment(ctx context.Context, key string, d time.Duration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Increment", ctx, key, d)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Increment indicates an expected call of Increment.
+func (mr *MockCacheMockRecorder) Increment(ctx, key, d interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Increment", reflect.TypeOf((*MockCache)(nil).Increment), ctx, key, d)
+}
+
 // IsErrCacheMissing mocks base method.
 func (m *MockCache) IsErrCacheMissing(err error) bool {
 	m.ctrl.T.Helper()
@@ -104,3 +166,47 @@ func (mr *MockCacheMockRecorder) Set(ctx, key, value, d interface{}) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockCache)(nil).Set), ctx, key, value, d)
 }
+
+// SetField mocks base method.
+func (m *MockCache) SetField(ctx context.Context, key, field string, value interface{}, d time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetField", ctx, key, field, value, d)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetField indicates an expected call of SetField.
+func (mr *MockCacheMockRecorder) SetField(ctx, key, field, value, d interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetField", reflect.TypeOf((*MockCache)(nil).SetField), ctx, key, field, value, d)
+}
+
+// SetIfExists mocks base method.
+func (m *MockCache) SetIfExists(ctx context.Context, key string, value interface{}, d time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetIfExists", ctx, key, value, d)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetIfExists indicates an expected call of SetIfExists.
+func (mr *MockCacheMockRecorder) SetIfExists(ctx, key, value, d interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetIfExists", reflect.TypeOf((*MockCache)(nil).SetIfExists), ctx, key, value, d)
+}
+
+// SetIfNotExists mocks base method.
+func (m *MockCache) SetIfNotExists(ctx context.Context, key string, value interface{}, d time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetIfNotExists", ctx, key, value, d)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetIfNotExists indicates an expected call of SetIfNotExists.
+func (mr *MockCacheMockRecorder) SetIfNotExists(ctx, key, value, d interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetIfNotExists", reflect.TypeOf((*MockCache)(nil).SetIfNotExists), ctx, key, value, d)
+}
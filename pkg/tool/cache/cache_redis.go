@@ -25,6 +25,22 @@ func (r redisCache) Set(ctx context.Context, key string, value interface{}, d ti
 	return r.client.Set(ctx, key, data, d).Err()
 }
 
+func (r redisCache) SetIfNotExists(ctx context.Context, key string, value interface{}, d time.Duration) (bool, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return false, err
+	}
+	return r.client.SetNX(ctx, key, data, d).Result()
+}
+
+func (r redisCache) SetIfExists(ctx context.Context, key string, value interface{}, d time.Duration) (bool, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return false, err
+	}
+	return r.client.SetXX(ctx, key, data, d).Result()
+}
+
 func (r redisCache) Get(ctx context.Context, key string, value interface{}) error {
 	result := r.client.Get(ctx, key)
 	if err := result.Err(); err != nil {
@@ -40,6 +56,56 @@ func (r redisCache) GetUpdating(ctx context.Context, key string, value interface
 	return r.Set(ctx, key, value, d)
 }
 
+// Increment adds one to the counter stored in key, creating it when missing, and pushes its expiration to d from now.
+func (r redisCache) Increment(ctx context.Context, key string, d time.Duration) (int64, error) {
+	pipe := r.client.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, d)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
+
+func (r redisCache) Delete(ctx context.Context, key string) error {
+	return r.client.Del(ctx, key).Err()
+}
+
+// SetField stores value under field of the hash in key and pushes the expiration of the whole hash to d from now.
+func (r redisCache) SetField(ctx context.Context, key string, field string, value interface{}, d time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	pipe := r.client.TxPipeline()
+	pipe.HSet(ctx, key, field, data)
+	pipe.Expire(ctx, key, d)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// GetFields reads every field of the hash in key into value, which must point to a map keyed by field. A missing
+// hash leaves the map empty.
+func (r redisCache) GetFields(ctx context.Context, key string, value interface{}) error {
+	fields, err := r.client.HGetAll(ctx, key).Result()
+	if err != nil {
+		return err
+	}
+	raw := make(map[string]json.RawMessage, len(fields))
+	for field, data := range fields {
+		raw[field] = json.RawMessage(data)
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, value)
+}
+
+func (r redisCache) DeleteFields(ctx context.Context, key string, fields ...string) error {
+	return r.client.HDel(ctx, key, fields...).Err()
+}
+
 func (r redisCache) Close() error {
 	return r.client.Close()
 }
@@ -0,0 +1,20 @@
+//go:generate mockgen -source=${GOFILE} -package=${GOPACKAGE} -destination=${GOPACKAGE}_mock.go
+
+package exchange
+
+import (
+	"context"
+	"errors"
+	"math/big"
+)
+
+var (
+	ErrRateNotFound = errors.New("exchange: rate not found")
+	ErrInvalidRate  = errors.New("exchange: invalid rate")
+)
+
+// Rates provides exchange rates between ISO 4217 currencies. Rate returns how many units of to one unit of from is
+// worth, both in major units, e.g. 5.25 for USD to BRL when one dollar costs R$ 5,25.
+type Rates interface {
+	Rate(ctx context.Context, from string, to string) (*big.Rat, error)
+}
@@ -0,0 +1,19 @@
+package exchange
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// NewFileRates reads the rates once from a JSON file holding an object in the format of NewStaticRates.
+func NewFileRates(path string) (Rates, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var rates map[string]string
+	if err = json.Unmarshal(data, &rates); err != nil {
+		return nil, err
+	}
+	return NewStaticRates(rates)
+}
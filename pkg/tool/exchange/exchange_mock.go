@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: exchange.go
+
+// Package exchange is a generated GoMock package.
+package exchange
+
+import (
+	context "context"
+	big "math/big"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRates is a mock of Rates interface.
+type MockRates struct {
+	ctrl     *gomock.Controller
+	recorder *MockRatesMockRecorder
+}
+
+// MockRatesMockRecorder is the mock recorder for MockRates.
+type MockRatesMockRecorder struct {
+	mock *MockRates
+}
+
+// NewMockRates creates a new mock instance.
+func NewMockRates(ctrl *gomock.Controller) *MockRates {
+	mock := &MockRates{ctrl: ctrl}
+	mock.recorder = &MockRatesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRates) EXPECT() *MockRatesMockRecorder {
+	return m.recorder
+}
+
+// Rate mocks base method.
+func (m *MockRates) Rate(ctx context.Context, from, to string) (*big.Rat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rate", ctx, from, to)
+	ret0, _ := ret[0].(*big.Rat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rate indicates an expected call of Rate.
+func (mr *MockRatesMockRecorder) Rate(ctx, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rate", reflect.TypeOf((*MockRates)(nil).Rate), ctx, from, to)
+}
@@ -0,0 +1,43 @@
+package exchange
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+type staticRates struct {
+	rates map[string]*big.Rat
+}
+
+// NewStaticRates serves fixed rates written as decimals and keyed by FROM/TO, e.g. "USD/BRL": "5.25". The inverse of
+// a pair is used when only the opposite direction is given.
+func NewStaticRates(rates map[string]string) (Rates, error) {
+	parsed := make(map[string]*big.Rat, len(rates))
+	for pair, value := range rates {
+		parts := strings.Split(pair, "/")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidRate, pair)
+		}
+		rate, ok := new(big.Rat).SetString(value)
+		if !ok || rate.Sign() <= 0 {
+			return nil, fmt.Errorf("%w: %q: %q", ErrInvalidRate, pair, value)
+		}
+		parsed[strings.ToUpper(pair)] = rate
+	}
+	return &staticRates{rates: parsed}, nil
+}
+
+func (r *staticRates) Rate(_ context.Context, from string, to string) (*big.Rat, error) {
+	if from == to {
+		return big.NewRat(1, 1), nil
+	}
+	if rate, ok := r.rates[from+"/"+to]; ok {
+		return new(big.Rat).Set(rate), nil
+	}
+	if rate, ok := r.rates[to+"/"+from]; ok {
+		return new(big.Rat).Inv(rate), nil
+	}
+	return nil, fmt.Errorf("%w: %s/%s", ErrRateNotFound, from, to)
+}
@@ -0,0 +1,83 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStaticRates_Rate(t *testing.T) {
+	rates, err := NewStaticRates(map[string]string{"USD/BRL": "5.25", "eur/brl": "5.5"})
+	assert.NoError(t, err)
+
+	cases := map[string]struct {
+		InputFrom     string
+		InputTo       string
+		ExpectedData  *big.Rat
+		ExpectedError error
+	}{
+		"should return success": {
+			InputFrom:    "USD",
+			InputTo:      "BRL",
+			ExpectedData: big.NewRat(21, 4),
+		},
+		"should return success: inverse": {
+			InputFrom:    "BRL",
+			InputTo:      "EUR",
+			ExpectedData: big.NewRat(2, 11),
+		},
+		"should return success: same currency": {
+			InputFrom:    "JPY",
+			InputTo:      "JPY",
+			ExpectedData: big.NewRat(1, 1),
+		},
+		"should return error: not found": {
+			InputFrom:     "USD",
+			InputTo:       "EUR",
+			ExpectedError: ErrRateNotFound,
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			rate, err := rates.Rate(context.Background(), cs.InputFrom, cs.InputTo)
+
+			assert.True(t, errors.Is(err, cs.ExpectedError))
+			assert.Equal(t, cs.ExpectedData, rate)
+		})
+	}
+}
+
+func TestNewStaticRates(t *testing.T) {
+	cases := map[string]map[string]string{
+		"should return error: invalid pair":   {"USDBRL": "5.25"},
+		"should return error: invalid rate":   {"USD/BRL": "five"},
+		"should return error: negative rate":  {"USD/BRL": "-5.25"},
+		"should return error: rate of zero":   {"USD/BRL": "0"},
+		"should return error: empty currency": {"USD/": "5.25"},
+	}
+
+	for name, rates := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewStaticRates(rates)
+
+			assert.True(t, errors.Is(err, ErrInvalidRate))
+		})
+	}
+}
+
+func TestNewFileRates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"USD/BRL": "5.25"}`), 0600))
+
+	rates, err := NewFileRates(path)
+	assert.NoError(t, err)
+
+	rate, err := rates.Rate(context.Background(), "BRL", "USD")
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewRat(4, 21), rate)
+}
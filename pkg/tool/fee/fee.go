@@ -0,0 +1,54 @@
+//go:generate mockgen -source=${GOFILE} -package=${GOPACKAGE} -destination=${GOPACKAGE}_mock.go
+
+package fee
+
+import "errors"
+
+const (
+	RuleTypeFlat       = "flat"
+	RuleTypePercentage = "percentage"
+	RuleTypeTiered     = "tiered"
+)
+
+var ErrInvalidRule = errors.New("fee: invalid rule")
+
+type (
+	// Config is the fee schedule of the bank. Amounts are in minor units of Currency and only transfers sent in
+	// Currency are charged.
+	Config struct {
+		Currency string `json:"currency"`
+		Rules    []Rule `json:"rules"`
+	}
+	// Rule charges every transfer according to Type: a flat Amount, BasisPoints of the transfer amount limited to Min
+	// and Max (zero Max is unlimited), or the fee of the first of Tiers that covers the transfer amount. The first
+	// FreePerMonth transfers sent by an account in a calendar month are not charged by the rule.
+	Rule struct {
+		Name         string `json:"name"`
+		Type         string `json:"type"`
+		Amount       int64  `json:"amount"`
+		BasisPoints  int64  `json:"basis_points"`
+		Min          int64  `json:"min"`
+		Max          int64  `json:"max"`
+		Tiers        []Tier `json:"tiers"`
+		FreePerMonth int    `json:"free_per_month"`
+	}
+	// Tier covers transfers up to UpTo, inclusive, or of any amount when UpTo is zero. Its fee is Amount plus
+	// BasisPoints of the transfer amount.
+	Tier struct {
+		UpTo        int64 `json:"up_to"`
+		Amount      int64 `json:"amount"`
+		BasisPoints int64 `json:"basis_points"`
+	}
+	// Charge is the part of the fee of a transfer due to one rule.
+	Charge struct {
+		Rule   string
+		Amount int64
+	}
+	Engine interface {
+		// Currency is the currency of the fee amounts.
+		Currency() string
+		// Calculate returns the charges of a transfer of amount from an account that already sent sentThisMonth
+		// transfers in the current month. Rules that charge nothing are left out.
+		Calculate(amount int64, sentThisMonth int) []Charge
+	}
+)
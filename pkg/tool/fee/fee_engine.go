@@ -0,0 +1,115 @@
+package fee
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const basisPointsScale = 10000
+
+type engine struct {
+	config Config
+}
+
+// NewEngine checks every rule of config and returns an engine evaluating them in order.
+func NewEngine(config Config) (Engine, error) {
+	config.Currency = strings.ToUpper(config.Currency)
+	if len(config.Currency) != 3 {
+		return nil, fmt.Errorf("%w: currency %q", ErrInvalidRule, config.Currency)
+	}
+	names := make(map[string]bool, len(config.Rules))
+	for _, rule := range config.Rules {
+		if rule.Name == "" || names[rule.Name] {
+			return nil, fmt.Errorf("%w: missing or repeated name %q", ErrInvalidRule, rule.Name)
+		}
+		names[rule.Name] = true
+		if err := validateRule(rule); err != nil {
+			return nil, fmt.Errorf("%w: %s: %s", ErrInvalidRule, rule.Name, err)
+		}
+	}
+	return &engine{config: config}, nil
+}
+
+func validateRule(rule Rule) error {
+	if rule.FreePerMonth < 0 {
+		return errors.New("negative free_per_month")
+	}
+	switch rule.Type {
+	case RuleTypeFlat:
+		if rule.Amount <= 0 {
+			return errors.New("amount must be positive")
+		}
+	case RuleTypePercentage:
+		if rule.BasisPoints <= 0 || rule.Min < 0 || (rule.Max != 0 && rule.Max < rule.Min) {
+			return errors.New("basis_points must be positive and min must not exceed max")
+		}
+	case RuleTypeTiered:
+		if len(rule.Tiers) == 0 {
+			return errors.New("no tiers")
+		}
+		for i, tier := range rule.Tiers {
+			if tier.Amount < 0 || tier.BasisPoints < 0 {
+				return fmt.Errorf("negative fee in tier %d", i)
+			}
+			last := i == len(rule.Tiers)-1
+			if (tier.UpTo == 0 && !last) || tier.UpTo < 0 || (i > 0 && tier.UpTo != 0 && tier.UpTo <= rule.Tiers[i-1].UpTo) {
+				return errors.New("tiers must be in ascending order of up_to, with only the last one unlimited")
+			}
+		}
+	default:
+		return fmt.Errorf("unknown type %q", rule.Type)
+	}
+	return nil
+}
+
+func (e *engine) Currency() string {
+	return e.config.Currency
+}
+
+func (e *engine) Calculate(amount int64, sentThisMonth int) []Charge {
+	charges := make([]Charge, 0, len(e.config.Rules))
+	for _, rule := range e.config.Rules {
+		if sentThisMonth < rule.FreePerMonth {
+			continue
+		}
+		if fee := calculateRule(rule, amount); fee > 0 {
+			charges = append(charges, Charge{Rule: rule.Name, Amount: fee})
+		}
+	}
+	return charges
+}
+
+func calculateRule(rule Rule, amount int64) int64 {
+	switch rule.Type {
+	case RuleTypeFlat:
+		return rule.Amount
+	case RuleTypePercentage:
+		fee := applyBasisPoints(amount, rule.BasisPoints)
+		if fee < rule.Min {
+			fee = rule.Min
+		}
+		if rule.Max != 0 && fee > rule.Max {
+			fee = rule.Max
+		}
+		return fee
+	case RuleTypeTiered:
+		for _, tier := range rule.Tiers {
+			if tier.UpTo == 0 || amount <= tier.UpTo {
+				return tier.Amount + applyBasisPoints(amount, tier.BasisPoints)
+			}
+		}
+	}
+	return 0
+}
+
+// applyBasisPoints returns basisPoints hundredths of a percent of amount, rounded half up.
+func applyBasisPoints(amount int64, basisPoints int64) int64 {
+	if basisPoints == 0 {
+		return 0
+	}
+	value := new(big.Int).Mul(big.NewInt(amount), big.NewInt(basisPoints))
+	value.Add(value, big.NewInt(basisPointsScale/2))
+	return value.Quo(value, big.NewInt(basisPointsScale)).Int64()
+}
@@ -0,0 +1,19 @@
+package fee
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// NewFileEngine reads the fee schedule once from a JSON file holding a Config.
+func NewFileEngine(path string) (Engine, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config Config
+	if err = json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+	return NewEngine(config)
+}
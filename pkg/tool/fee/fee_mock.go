@@ -0,0 +1,62 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: fee.go
+
+// Package fee is a generated GoMock package.
+package fee
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockEngine is a mock of Engine interface.
+type MockEngine struct {
+	ctrl     *gomock.Controller
+	recorder *MockEngineMockRecorder
+}
+
+// MockEngineMockRecorder is the mock recorder for MockEngine.
+type MockEngineMockRecorder struct {
+	mock *MockEngine
+}
+
+// NewMockEngine creates a new mock instance.
+func NewMockEngine(ctrl *gomock.Controller) *MockEngine {
+	mock := &MockEngine{ctrl: ctrl}
+	mock.recorder = &MockEngineMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEngine) EXPECT() *MockEngineMockRecorder {
+	return m.recorder
+}
+
+// Calculate mocks base method.
+func (m *MockEngine) Calculate(amount int64, sentThisMonth int) []Charge {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Calculate", amount, sentThisMonth)
+	ret0, _ := ret[0].([]Charge)
+	return ret0
+}
+
+// Calculate indicates an expected call of Calculate.
+func (mr *MockEngineMockRecorder) Calculate(amount, sentThisMonth interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Calculate", reflect.TypeOf((*MockEngine)(nil).Calculate), amount, sentThisMonth)
+}
+
+// Currency mocks base method.
+func (m *MockEngine) Currency() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Currency")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Currency indicates an expected call of Currency.
+func (mr *MockEngineMockRecorder) Currency() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Currency", reflect.TypeOf((*MockEngine)(nil).Currency))
+}
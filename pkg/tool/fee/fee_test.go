@@ -0,0 +1,105 @@
+package fee
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEngine_Calculate(t *testing.T) {
+	engine, err := NewEngine(Config{
+		Currency: "brl",
+		Rules: []Rule{
+			{Name: "pix", Type: RuleTypeFlat, Amount: 50, FreePerMonth: 2},
+			{Name: "service", Type: RuleTypePercentage, BasisPoints: 150, Min: 10, Max: 1000},
+			{Name: "size", Type: RuleTypeTiered, Tiers: []Tier{
+				{UpTo: 10000},
+				{UpTo: 100000, Amount: 100},
+				{Amount: 200, BasisPoints: 10},
+			}},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "BRL", engine.Currency())
+
+	cases := map[string]struct {
+		InputAmount        int64
+		InputSentThisMonth int
+		ExpectedData       []Charge
+	}{
+		"should return success: inside the free tier": {
+			InputAmount:        1000,
+			InputSentThisMonth: 1,
+			ExpectedData:       []Charge{{Rule: "service", Amount: 15}},
+		},
+		"should return success: after the free tier": {
+			InputAmount:        1000,
+			InputSentThisMonth: 2,
+			ExpectedData:       []Charge{{Rule: "pix", Amount: 50}, {Rule: "service", Amount: 15}},
+		},
+		"should return success: percentage rounded half up": {
+			InputAmount:        1033,
+			InputSentThisMonth: 0,
+			ExpectedData:       []Charge{{Rule: "service", Amount: 15}},
+		},
+		"should return success: percentage minimum": {
+			InputAmount:        100,
+			InputSentThisMonth: 0,
+			ExpectedData:       []Charge{{Rule: "service", Amount: 10}},
+		},
+		"should return success: percentage maximum and middle tier": {
+			InputAmount:        100000,
+			InputSentThisMonth: 0,
+			ExpectedData:       []Charge{{Rule: "service", Amount: 1000}, {Rule: "size", Amount: 100}},
+		},
+		"should return success: unlimited tier": {
+			InputAmount:        1000000,
+			InputSentThisMonth: 0,
+			ExpectedData:       []Charge{{Rule: "service", Amount: 1000}, {Rule: "size", Amount: 1200}},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			charges := engine.Calculate(cs.InputAmount, cs.InputSentThisMonth)
+
+			assert.Equal(t, cs.ExpectedData, charges)
+		})
+	}
+}
+
+func TestNewEngine(t *testing.T) {
+	cases := map[string]Config{
+		"should return error: invalid currency":  {Currency: "real"},
+		"should return error: missing name":      {Currency: "BRL", Rules: []Rule{{Type: RuleTypeFlat, Amount: 1}}},
+		"should return error: repeated name":     {Currency: "BRL", Rules: []Rule{{Name: "a", Type: RuleTypeFlat, Amount: 1}, {Name: "a", Type: RuleTypeFlat, Amount: 1}}},
+		"should return error: unknown type":      {Currency: "BRL", Rules: []Rule{{Name: "a", Type: "free"}}},
+		"should return error: flat of zero":      {Currency: "BRL", Rules: []Rule{{Name: "a", Type: RuleTypeFlat}}},
+		"should return error: min above max":     {Currency: "BRL", Rules: []Rule{{Name: "a", Type: RuleTypePercentage, BasisPoints: 1, Min: 10, Max: 5}}},
+		"should return error: no tiers":          {Currency: "BRL", Rules: []Rule{{Name: "a", Type: RuleTypeTiered}}},
+		"should return error: unordered tiers":   {Currency: "BRL", Rules: []Rule{{Name: "a", Type: RuleTypeTiered, Tiers: []Tier{{UpTo: 10}, {UpTo: 5}}}}},
+		"should return error: unlimited tier":    {Currency: "BRL", Rules: []Rule{{Name: "a", Type: RuleTypeTiered, Tiers: []Tier{{}, {UpTo: 5}}}}},
+		"should return error: negative free use": {Currency: "BRL", Rules: []Rule{{Name: "a", Type: RuleTypeFlat, Amount: 1, FreePerMonth: -1}}},
+	}
+
+	for name, config := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewEngine(config)
+
+			assert.True(t, errors.Is(err, ErrInvalidRule))
+		})
+	}
+}
+
+func TestNewFileEngine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fees.json")
+	data := `{"currency": "BRL", "rules": [{"name": "pix", "type": "flat", "amount": 50}]}`
+	assert.NoError(t, os.WriteFile(path, []byte(data), 0600))
+
+	engine, err := NewFileEngine(path)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []Charge{{Rule: "pix", Amount: 50}}, engine.Calculate(1000, 0))
+}
@@ -0,0 +1,170 @@
+//go:generate mockgen -source=${GOFILE} -package=${GOPACKAGE} -destination=${GOPACKAGE}_mock.go
+
+package jwt
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	jwtgo "github.com/golang-jwt/jwt"
+	"strings"
+	"time"
+)
+
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmEdDSA = "EdDSA"
+
+	headerKeyID = "kid"
+)
+
+var (
+	ErrUnknownAlgorithm = errors.New("jwt: unknown algorithm")
+	ErrNoKeys           = errors.New("jwt: no keys")
+	ErrInvalidKey       = errors.New("jwt: invalid key")
+	ErrInvalidToken     = errors.New("jwt: invalid token")
+)
+
+type (
+	// Key is a signing key identified by the kid header of the tokens it signs. For HS256 Secret is the shared
+	// secret, for EdDSA it is the 32 bytes seed of the private key.
+	Key struct {
+		ID     string
+		Secret []byte
+	}
+	// Options selects the algorithm and the keys. The first key signs new tokens, the others are only accepted when
+	// verifying, so a key can be rotated without invalidating the tokens it already signed.
+	Options struct {
+		Algorithm string
+		Keys      []Key
+	}
+	// Claims are the facts carried by an access token. Times have a precision of one second.
+	Claims struct {
+		ID        string
+		AccountID string
+		SessionID string
+		Name      string
+		Role      string
+		IssuedAt  time.Time
+		ExpiresAt time.Time
+	}
+	claims struct {
+		jwtgo.StandardClaims
+		SessionID string `json:"sid"`
+		Name      string `json:"name"`
+		Role      string `json:"role"`
+	}
+	JWT interface {
+		Sign(claims Claims) (string, error)
+		Parse(token string) (*Claims, error)
+	}
+	jwtImpl struct {
+		method       jwtgo.SigningMethod
+		currentKeyID string
+		signingKeys  map[string]interface{}
+		verifyKeys   map[string]interface{}
+	}
+)
+
+func New(opts Options) (JWT, error) {
+	if opts.Algorithm == "" {
+		opts.Algorithm = AlgorithmHS256
+	}
+	if len(opts.Keys) == 0 {
+		return nil, ErrNoKeys
+	}
+
+	j := &jwtImpl{
+		currentKeyID: opts.Keys[0].ID,
+		signingKeys:  make(map[string]interface{}, len(opts.Keys)),
+		verifyKeys:   make(map[string]interface{}, len(opts.Keys)),
+	}
+	for _, key := range opts.Keys {
+		if key.ID == "" || len(key.Secret) == 0 {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidKey, key.ID)
+		}
+		switch opts.Algorithm {
+		case AlgorithmHS256:
+			j.method = jwtgo.SigningMethodHS256
+			j.signingKeys[key.ID] = key.Secret
+			j.verifyKeys[key.ID] = key.Secret
+		case AlgorithmEdDSA:
+			if len(key.Secret) != ed25519.SeedSize {
+				return nil, fmt.Errorf("%w: %q", ErrInvalidKey, key.ID)
+			}
+			privateKey := ed25519.NewKeyFromSeed(key.Secret)
+			j.method = jwtgo.SigningMethodEdDSA
+			j.signingKeys[key.ID] = privateKey
+			j.verifyKeys[key.ID] = privateKey.Public()
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrUnknownAlgorithm, opts.Algorithm)
+		}
+	}
+	return j, nil
+}
+
+// ParseKeys reads keys written as a comma separated list of kid:base64 pairs, the current key first.
+func ParseKeys(value string) ([]Key, error) {
+	var keys []Key
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		parts := strings.SplitN(pair, ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidKey, pair)
+		}
+		secret, err := base64.StdEncoding.DecodeString(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %s", ErrInvalidKey, parts[0], err)
+		}
+		keys = append(keys, Key{ID: parts[0], Secret: secret})
+	}
+	return keys, nil
+}
+
+// Sign returns the claims signed with the current key.
+func (j *jwtImpl) Sign(c Claims) (string, error) {
+	token := jwtgo.NewWithClaims(j.method, claims{
+		StandardClaims: jwtgo.StandardClaims{
+			Id:        c.ID,
+			Subject:   c.AccountID,
+			IssuedAt:  c.IssuedAt.Unix(),
+			ExpiresAt: c.ExpiresAt.Unix(),
+		},
+		SessionID: c.SessionID,
+		Name:      c.Name,
+		Role:      c.Role,
+	})
+	token.Header[headerKeyID] = j.currentKeyID
+	return token.SignedString(j.signingKeys[j.currentKeyID])
+}
+
+// Parse verifies the signature and the expiration of token and returns its claims. Tokens signed with another
+// algorithm or with an unknown key are refused.
+func (j *jwtImpl) Parse(token string) (*Claims, error) {
+	c := new(claims)
+	parser := jwtgo.Parser{ValidMethods: []string{j.method.Alg()}}
+	_, err := parser.ParseWithClaims(token, c, func(token *jwtgo.Token) (interface{}, error) {
+		keyID, _ := token.Header[headerKeyID].(string)
+		key, ok := j.verifyKeys[keyID]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown key %q", ErrInvalidToken, keyID)
+		}
+		return key, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidToken, err)
+	}
+	return &Claims{
+		ID:        c.Id,
+		AccountID: c.Subject,
+		SessionID: c.SessionID,
+		Name:      c.Name,
+		Role:      c.Role,
+		IssuedAt:  time.Unix(c.IssuedAt, 0),
+		ExpiresAt: time.Unix(c.ExpiresAt, 0),
+	}, nil
+}
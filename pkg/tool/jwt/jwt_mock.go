@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: jwt.go
+
+// Package jwt is a generated GoMock package.
+package jwt
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockJWT is a mock of JWT interface.
+type MockJWT struct {
+	ctrl     *gomock.Controller
+	recorder *MockJWTMockRecorder
+}
+
+// MockJWTMockRecorder is the mock recorder for MockJWT.
+type MockJWTMockRecorder struct {
+	mock *MockJWT
+}
+
+// NewMockJWT creates a new mock instance.
+func NewMockJWT(ctrl *gomock.Controller) *MockJWT {
+	mock := &MockJWT{ctrl: ctrl}
+	mock.recorder = &MockJWTMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJWT) EXPECT() *MockJWTMockRecorder {
+	return m.recorder
+}
+
+// Parse mocks base method.
+func (m *MockJWT) Parse(token string) (*Claims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Parse", token)
+	ret0, _ := ret[0].(*Claims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Parse indicates an expected call of Parse.
+func (mr *MockJWTMockRecorder) Parse(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Parse", reflect.TypeOf((*MockJWT)(nil).Parse), token)
+}
+
+// Sign mocks base method.
+func (m *MockJWT) Sign(claims Claims) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sign", claims)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sign indicates an expected call of Sign.
+func (mr *MockJWTMockRecorder) Sign(claims interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sign", reflect.TypeOf((*MockJWT)(nil).Sign), claims)
+}
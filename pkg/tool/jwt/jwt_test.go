@@ -0,0 +1,208 @@
+package jwt
+
+import (
+	"bytes"
+	"errors"
+	jwtgo "github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+var (
+	hs256Options = Options{Algorithm: AlgorithmHS256, Keys: []Key{{ID: "k1", Secret: []byte("secret")}}}
+	eddsaOptions = Options{Algorithm: AlgorithmEdDSA, Keys: []Key{{ID: "k1", Secret: bytes.Repeat([]byte{1}, 32)}}}
+)
+
+func getClaims(expiresAt time.Time) Claims {
+	return Claims{
+		ID:        "token_id",
+		AccountID: "account_id",
+		IssuedAt:  time.Unix(time.Now().Unix(), 0),
+		ExpiresAt: time.Unix(expiresAt.Unix(), 0),
+		SessionID: "session_id",
+		Name:      "Carlos",
+		Role:      "admin",
+	}
+}
+
+func TestNew(t *testing.T) {
+	cases := map[string]struct {
+		InputOptions  Options
+		ExpectedError error
+	}{
+		"should return success with hs256": {
+			InputOptions:  hs256Options,
+			ExpectedError: nil,
+		},
+		"should return success with eddsa": {
+			InputOptions:  eddsaOptions,
+			ExpectedError: nil,
+		},
+		"should return error: no keys": {
+			InputOptions:  Options{Algorithm: AlgorithmHS256},
+			ExpectedError: ErrNoKeys,
+		},
+		"should return error: unknown algorithm": {
+			InputOptions:  Options{Algorithm: "none", Keys: hs256Options.Keys},
+			ExpectedError: ErrUnknownAlgorithm,
+		},
+		"should return error: invalid eddsa seed": {
+			InputOptions:  Options{Algorithm: AlgorithmEdDSA, Keys: hs256Options.Keys},
+			ExpectedError: ErrInvalidKey,
+		},
+		"should return error: key without id": {
+			InputOptions:  Options{Algorithm: AlgorithmHS256, Keys: []Key{{Secret: []byte("secret")}}},
+			ExpectedError: ErrInvalidKey,
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			j, err := New(cs.InputOptions)
+			if cs.ExpectedError != nil {
+				assert.True(t, errors.Is(err, cs.ExpectedError), err)
+				assert.Nil(t, j)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, j)
+		})
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	cases := map[string]struct {
+		Input         string
+		ExpectedKeys  []Key
+		ExpectedError error
+	}{
+		"should return success": {
+			Input:        "k2:c2Vjb25k, k1:Zmlyc3Q=",
+			ExpectedKeys: []Key{{ID: "k2", Secret: []byte("second")}, {ID: "k1", Secret: []byte("first")}},
+		},
+		"should return success when empty": {
+			Input:        "",
+			ExpectedKeys: nil,
+		},
+		"should return error: missing kid": {
+			Input:         "c2Vjb25k",
+			ExpectedError: ErrInvalidKey,
+		},
+		"should return error: invalid base64": {
+			Input:         "k1:???",
+			ExpectedError: ErrInvalidKey,
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			keys, err := ParseKeys(cs.Input)
+			if cs.ExpectedError != nil {
+				assert.True(t, errors.Is(err, cs.ExpectedError), err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, cs.ExpectedKeys, keys)
+		})
+	}
+}
+
+func TestSignAndParse(t *testing.T) {
+	for name, opts := range map[string]Options{"hs256": hs256Options, "eddsa": eddsaOptions} {
+		t.Run(name, func(t *testing.T) {
+			j, err := New(opts)
+			assert.NoError(t, err)
+
+			claims := getClaims(time.Now().Add(time.Minute))
+			token, err := j.Sign(claims)
+			assert.NoError(t, err)
+
+			parsed, err := j.Parse(token)
+			assert.NoError(t, err)
+			assert.Equal(t, &claims, parsed)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	signer, err := New(hs256Options)
+	assert.NoError(t, err)
+
+	rotated, err := New(Options{
+		Algorithm: AlgorithmHS256,
+		Keys:      []Key{{ID: "k2", Secret: []byte("new secret")}, hs256Options.Keys[0]},
+	})
+	assert.NoError(t, err)
+
+	otherKey, err := New(Options{Algorithm: AlgorithmHS256, Keys: []Key{{ID: "k1", Secret: []byte("other")}}})
+	assert.NoError(t, err)
+
+	otherAlgorithm, err := New(eddsaOptions)
+	assert.NoError(t, err)
+
+	valid, err := signer.Sign(getClaims(time.Now().Add(time.Minute)))
+	assert.NoError(t, err)
+
+	expired, err := signer.Sign(getClaims(time.Now().Add(-time.Minute)))
+	assert.NoError(t, err)
+
+	unsigned, err := jwtgo.NewWithClaims(jwtgo.SigningMethodNone, jwtgo.StandardClaims{Subject: "account_id"}).
+		SignedString(jwtgo.UnsafeAllowNoneSignatureType)
+	assert.NoError(t, err)
+
+	cases := map[string]struct {
+		InputJWT      JWT
+		InputToken    string
+		ExpectedError error
+	}{
+		"should return success": {
+			InputJWT:      signer,
+			InputToken:    valid,
+			ExpectedError: nil,
+		},
+		"should return success with a rotated key": {
+			InputJWT:      rotated,
+			InputToken:    valid,
+			ExpectedError: nil,
+		},
+		"should return error: expired": {
+			InputJWT:      signer,
+			InputToken:    expired,
+			ExpectedError: ErrInvalidToken,
+		},
+		"should return error: wrong key": {
+			InputJWT:      otherKey,
+			InputToken:    valid,
+			ExpectedError: ErrInvalidToken,
+		},
+		"should return error: wrong algorithm": {
+			InputJWT:      otherAlgorithm,
+			InputToken:    valid,
+			ExpectedError: ErrInvalidToken,
+		},
+		"should return error: unsigned": {
+			InputJWT:      signer,
+			InputToken:    unsigned,
+			ExpectedError: ErrInvalidToken,
+		},
+		"should return error: malformed": {
+			InputJWT:      signer,
+			InputToken:    "token",
+			ExpectedError: ErrInvalidToken,
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			claims, err := cs.InputJWT.Parse(cs.InputToken)
+			if cs.ExpectedError != nil {
+				assert.True(t, errors.Is(err, cs.ExpectedError), err)
+				assert.Nil(t, claims)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "session_id", claims.SessionID)
+		})
+	}
+}
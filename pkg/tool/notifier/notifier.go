@@ -0,0 +1,18 @@
+//go:generate mockgen -source=${GOFILE} -package=${GOPACKAGE} -destination=${GOPACKAGE}_mock.go
+
+package notifier
+
+import "context"
+
+type (
+	// Message is something the account holder must be told out of band, such as a secret reset token.
+	Message struct {
+		AccountID string `json:"accountId"`
+		Recipient string `json:"recipient"`
+		Subject   string `json:"subject"`
+		Body      string `json:"body"`
+	}
+	Notifier interface {
+		Notify(ctx context.Context, message Message) error
+	}
+)
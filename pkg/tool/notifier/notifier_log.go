@@ -0,0 +1,24 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+)
+
+type logNotifier struct {
+	logger logger.Logger
+}
+
+// NewLogNotifier writes the messages to the log. It is meant for local development only, since the log then holds
+// whatever the messages carry.
+func NewLogNotifier(log logger.Logger) Notifier {
+	return &logNotifier{
+		logger: log.WithPreffix("notifier"),
+	}
+}
+
+func (n *logNotifier) Notify(ctx context.Context, message Message) error {
+	n.logger.WithContext(ctx).Info(fmt.Sprintf("to %s: %s: %s", message.Recipient, message.Subject, message.Body))
+	return nil
+}
@@ -0,0 +1,44 @@
+package notifier
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileNotifier_Notify(t *testing.T) {
+	var (
+		path     = filepath.Join(t.TempDir(), "notifications.log")
+		messages = []Message{
+			{AccountID: "account_id", Recipient: "12312312312", Subject: "first", Body: "body"},
+			{AccountID: "account_id", Recipient: "12312312312", Subject: "second", Body: "body"},
+		}
+	)
+
+	n := NewFileNotifier(path)
+	for _, message := range messages {
+		assert.NoError(t, n.Notify(context.Background(), message))
+	}
+
+	f, err := os.Open(path)
+	assert.NoError(t, err)
+	defer f.Close()
+
+	var written []Message
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var message Message
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &message))
+		written = append(written, message)
+	}
+	assert.Equal(t, messages, written)
+}
+
+func TestFileNotifier_NotifyError(t *testing.T) {
+	n := NewFileNotifier(filepath.Join(t.TempDir(), "missing", "notifications.log"))
+	assert.Error(t, n.Notify(context.Background(), Message{}))
+}
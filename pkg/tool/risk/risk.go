@@ -0,0 +1,50 @@
+//go:generate mockgen -source=${GOFILE} -package=${GOPACKAGE} -destination=${GOPACKAGE}_mock.go
+
+package risk
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const (
+	DecisionAllow  = "allow"
+	DecisionDeny   = "deny"
+	DecisionReview = "review"
+)
+
+var ErrInvalidRules = errors.New("risk: invalid rules")
+
+type (
+	// Account is one side of a transfer. Balance is the money it can move.
+	Account struct {
+		ID        string
+		Balance   int64
+		CreatedAt time.Time
+	}
+	Transfer struct {
+		OriginAccountID string
+		TargetAccountID string
+		Amount          int64
+		CreatedAt       time.Time
+	}
+	// Check is a transfer about to be made, with both of its accounts and the transfers the origin account sent
+	// recently, newest first.
+	Check struct {
+		Transfer Transfer
+		Origin   Account
+		Target   Account
+		History  []Transfer
+	}
+	// Assessment is the decision about a transfer, with the Score that led to it and the Reasons that added to it.
+	Assessment struct {
+		Decision string
+		Score    int
+		Reasons  []string
+	}
+	Engine interface {
+		// Assess decides whether the transfer of check may go on, must be denied or must wait for a review.
+		Assess(ctx context.Context, check Check) (Assessment, error)
+	}
+)
@@ -0,0 +1,19 @@
+package risk
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// NewFileEngine reads the rules once from a JSON file holding a RulesConfig.
+func NewFileEngine(path string) (Engine, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config RulesConfig
+	if err = json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+	return NewRulesEngine(config)
+}
@@ -0,0 +1,145 @@
+package risk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	ReasonNewCounterparty = "new-counterparty-high-amount"
+	ReasonBurst           = "burst-of-transfers"
+	ReasonRoundAtNight    = "round-amount-at-night"
+)
+
+type (
+	// RulesConfig scores every transfer with the sum of the scores of the rules it matches. A transfer scoring
+	// ReviewScore or more waits for a review, and one scoring DenyScore or more is denied. Zero DenyScore never
+	// denies. A rule with zero Score is disabled.
+	RulesConfig struct {
+		ReviewScore     int                 `json:"review_score"`
+		DenyScore       int                 `json:"deny_score"`
+		NewCounterparty NewCounterpartyRule `json:"new_counterparty"`
+		Burst           BurstRule           `json:"burst"`
+		RoundAtNight    RoundAtNightRule    `json:"round_at_night"`
+	}
+	// NewCounterpartyRule matches transfers of MinAmount or more to an account the origin didn't send money to in
+	// its recent history.
+	NewCounterpartyRule struct {
+		MinAmount int64 `json:"min_amount"`
+		Score     int   `json:"score"`
+	}
+	// BurstRule matches the transfer that makes more than Count transfers sent in the last WindowMinutes.
+	BurstRule struct {
+		Count         int `json:"count"`
+		WindowMinutes int `json:"window_minutes"`
+		Score         int `json:"score"`
+	}
+	// RoundAtNightRule matches transfers of MinAmount or more, multiple of Multiple, made from the hour FromHour to
+	// the hour ToHour, exclusive, in Timezone. The period may cross midnight.
+	RoundAtNightRule struct {
+		Multiple  int64  `json:"multiple"`
+		MinAmount int64  `json:"min_amount"`
+		FromHour  int    `json:"from_hour"`
+		ToHour    int    `json:"to_hour"`
+		Timezone  string `json:"timezone"`
+		Score     int    `json:"score"`
+	}
+)
+
+type rulesEngine struct {
+	config   RulesConfig
+	location *time.Location
+}
+
+// NewRulesEngine checks config and returns an engine scoring transfers with its rules.
+func NewRulesEngine(config RulesConfig) (Engine, error) {
+	if config.ReviewScore <= 0 || (config.DenyScore != 0 && config.DenyScore < config.ReviewScore) {
+		return nil, fmt.Errorf("%w: review_score must be positive and deny_score, when set, not below it", ErrInvalidRules)
+	}
+	if err := validateRules(config); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidRules, err)
+	}
+	location, err := time.LoadLocation(config.RoundAtNight.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("%w: round_at_night: %s", ErrInvalidRules, err)
+	}
+	return &rulesEngine{config: config, location: location}, nil
+}
+
+func validateRules(config RulesConfig) error {
+	if rule := config.NewCounterparty; rule.Score < 0 || rule.MinAmount < 0 {
+		return errors.New("new_counterparty: negative score or min_amount")
+	}
+	if rule := config.Burst; rule.Score < 0 || (rule.Score > 0 && (rule.Count <= 0 || rule.WindowMinutes <= 0)) {
+		return errors.New("burst: count and window_minutes must be positive")
+	}
+	rule := config.RoundAtNight
+	if rule.Score < 0 || (rule.Score > 0 && rule.Multiple <= 0) || rule.MinAmount < 0 {
+		return errors.New("round_at_night: multiple must be positive")
+	}
+	if rule.FromHour < 0 || rule.FromHour > 23 || rule.ToHour < 0 || rule.ToHour > 23 {
+		return errors.New("round_at_night: hours must be from 0 to 23")
+	}
+	return nil
+}
+
+func (e *rulesEngine) Assess(_ context.Context, check Check) (Assessment, error) {
+	assessment := Assessment{Decision: DecisionAllow}
+	add := func(reason string, score int) {
+		assessment.Score += score
+		assessment.Reasons = append(assessment.Reasons, reason)
+	}
+
+	if rule := e.config.NewCounterparty; rule.Score > 0 && check.Transfer.Amount >= rule.MinAmount &&
+		!sentTo(check.History, check.Transfer.TargetAccountID) {
+		add(ReasonNewCounterparty, rule.Score)
+	}
+	if rule := e.config.Burst; rule.Score > 0 {
+		since := check.Transfer.CreatedAt.Add(-time.Duration(rule.WindowMinutes) * time.Minute)
+		if countSince(check.History, since)+1 > rule.Count {
+			add(ReasonBurst, rule.Score)
+		}
+	}
+	if rule := e.config.RoundAtNight; rule.Score > 0 && check.Transfer.Amount >= rule.MinAmount &&
+		check.Transfer.Amount%rule.Multiple == 0 && e.atNight(check.Transfer.CreatedAt) {
+		add(ReasonRoundAtNight, rule.Score)
+	}
+
+	switch {
+	case e.config.DenyScore > 0 && assessment.Score >= e.config.DenyScore:
+		assessment.Decision = DecisionDeny
+	case assessment.Score >= e.config.ReviewScore:
+		assessment.Decision = DecisionReview
+	}
+	return assessment, nil
+}
+
+func (e *rulesEngine) atNight(at time.Time) bool {
+	hour := at.In(e.location).Hour()
+	from, to := e.config.RoundAtNight.FromHour, e.config.RoundAtNight.ToHour
+	if from <= to {
+		return hour >= from && hour < to
+	}
+	return hour >= from || hour < to
+}
+
+func sentTo(history []Transfer, accountID string) bool {
+	for _, transfer := range history {
+		if transfer.TargetAccountID == accountID {
+			return true
+		}
+	}
+	return false
+}
+
+func countSince(history []Transfer, since time.Time) int {
+	count := 0
+	for _, transfer := range history {
+		if !transfer.CreatedAt.Before(since) {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,133 @@
+package risk
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRulesEngine_Assess(t *testing.T) {
+	engine, err := NewRulesEngine(RulesConfig{
+		ReviewScore:     50,
+		DenyScore:       100,
+		NewCounterparty: NewCounterpartyRule{MinAmount: 100000, Score: 50},
+		Burst:           BurstRule{Count: 3, WindowMinutes: 10, Score: 30},
+		RoundAtNight:    RoundAtNightRule{Multiple: 10000, MinAmount: 50000, FromHour: 23, ToHour: 5, Timezone: "America/Sao_Paulo", Score: 30},
+	})
+	assert.NoError(t, err)
+
+	var (
+		noon    = time.Date(2026, time.October, 18, 15, 0, 0, 0, time.UTC)
+		night   = time.Date(2026, time.October, 18, 5, 0, 0, 0, time.UTC)
+		history = []Transfer{
+			{TargetAccountID: "known_account_id", Amount: 1000, CreatedAt: noon.Add(-time.Minute)},
+			{TargetAccountID: "known_account_id", Amount: 1000, CreatedAt: noon.Add(-5 * time.Minute)},
+			{TargetAccountID: "other_account_id", Amount: 1000, CreatedAt: noon.Add(-time.Hour)},
+		}
+		nightHistory = []Transfer{{TargetAccountID: "known_account_id", Amount: 1000, CreatedAt: night.Add(-48 * time.Hour)}}
+	)
+
+	cases := map[string]struct {
+		InputTransfer Transfer
+		InputHistory  []Transfer
+		ExpectedData  Assessment
+	}{
+		"should allow: known counterparty": {
+			InputTransfer: Transfer{TargetAccountID: "known_account_id", Amount: 200000, CreatedAt: noon},
+			InputHistory:  history,
+			ExpectedData:  Assessment{Decision: DecisionAllow},
+		},
+		"should allow: new counterparty and low amount": {
+			InputTransfer: Transfer{TargetAccountID: "new_account_id", Amount: 99999, CreatedAt: noon},
+			InputHistory:  history,
+			ExpectedData:  Assessment{Decision: DecisionAllow},
+		},
+		"should review: new counterparty and high amount": {
+			InputTransfer: Transfer{TargetAccountID: "new_account_id", Amount: 100000, CreatedAt: noon},
+			InputHistory:  history,
+			ExpectedData:  Assessment{Decision: DecisionReview, Score: 50, Reasons: []string{ReasonNewCounterparty}},
+		},
+		"should allow: burst alone": {
+			InputTransfer: Transfer{TargetAccountID: "known_account_id", Amount: 1000, CreatedAt: noon.Add(2 * time.Minute)},
+			InputHistory:  append([]Transfer{{TargetAccountID: "known_account_id", CreatedAt: noon.Add(time.Minute)}}, history...),
+			ExpectedData:  Assessment{Decision: DecisionAllow, Score: 30, Reasons: []string{ReasonBurst}},
+		},
+		"should allow: round amount at night alone": {
+			InputTransfer: Transfer{TargetAccountID: "known_account_id", Amount: 50000, CreatedAt: night.Add(-time.Hour)},
+			InputHistory:  nightHistory,
+			ExpectedData:  Assessment{Decision: DecisionAllow, Score: 30, Reasons: []string{ReasonRoundAtNight}},
+		},
+		"should allow: round amount after the night": {
+			InputTransfer: Transfer{TargetAccountID: "known_account_id", Amount: 50000, CreatedAt: night.Add(3 * time.Hour)},
+			InputHistory:  nightHistory,
+			ExpectedData:  Assessment{Decision: DecisionAllow},
+		},
+		"should deny: every rule": {
+			InputTransfer: Transfer{TargetAccountID: "new_account_id", Amount: 100000, CreatedAt: night},
+			InputHistory: []Transfer{
+				{TargetAccountID: "known_account_id", CreatedAt: night.Add(-time.Minute)},
+				{TargetAccountID: "known_account_id", CreatedAt: night.Add(-2 * time.Minute)},
+				{TargetAccountID: "known_account_id", CreatedAt: night.Add(-3 * time.Minute)},
+			},
+			ExpectedData: Assessment{
+				Decision: DecisionDeny,
+				Score:    110,
+				Reasons:  []string{ReasonNewCounterparty, ReasonBurst, ReasonRoundAtNight},
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			assessment, err := engine.Assess(context.Background(), Check{Transfer: cs.InputTransfer, History: cs.InputHistory})
+
+			assert.NoError(t, err)
+			assert.Equal(t, cs.ExpectedData, assessment)
+		})
+	}
+}
+
+func TestNewRulesEngine(t *testing.T) {
+	cases := map[string]RulesConfig{
+		"should return error: no review score":     {},
+		"should return error: deny below review":   {ReviewScore: 50, DenyScore: 10},
+		"should return error: burst without count": {ReviewScore: 50, Burst: BurstRule{WindowMinutes: 10, Score: 10}},
+		"should return error: night without multiple": {
+			ReviewScore:  50,
+			RoundAtNight: RoundAtNightRule{FromHour: 23, ToHour: 5, Score: 10},
+		},
+		"should return error: invalid hour": {
+			ReviewScore:  50,
+			RoundAtNight: RoundAtNightRule{Multiple: 100, FromHour: 24, Score: 10},
+		},
+		"should return error: unknown timezone": {
+			ReviewScore:  50,
+			RoundAtNight: RoundAtNightRule{Multiple: 100, Timezone: "Nowhere/City", Score: 10},
+		},
+	}
+
+	for name, config := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewRulesEngine(config)
+
+			assert.True(t, errors.Is(err, ErrInvalidRules))
+		})
+	}
+}
+
+func TestNewFileEngine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "risk.json")
+	data := `{"review_score": 50, "new_counterparty": {"min_amount": 1000, "score": 50}}`
+	assert.NoError(t, os.WriteFile(path, []byte(data), 0600))
+
+	engine, err := NewFileEngine(path)
+	assert.NoError(t, err)
+
+	assessment, err := engine.Assess(context.Background(), Check{Transfer: Transfer{TargetAccountID: "account_id", Amount: 1000}})
+	assert.NoError(t, err)
+	assert.Equal(t, DecisionReview, assessment.Decision)
+}
@@ -4,44 +4,93 @@ package secret
 
 import (
 	"crypto/sha512"
+	"crypto/subtle"
+	"errors"
 	"fmt"
-	"math/rand"
-	"time"
 )
 
+const (
+	AlgorithmArgon2id = "argon2id"
+	AlgorithmBcrypt   = "bcrypt"
+)
+
+var ErrUnknownAlgorithm = errors.New("secret: unknown algorithm")
+
 type (
+	// Options selects the algorithm used for new hashes and its cost. Zero values fall back to the defaults of the
+	// algorithm.
+	Options struct {
+		Algorithm     string
+		BcryptCost    int
+		Argon2Time    uint32
+		Argon2Memory  uint32
+		Argon2Threads uint8
+	}
 	Secret interface {
-		GenSalt() (hash string)
-		Encode(password, salt string) (hash string)
+		Encode(password string) (hash string, err error)
 		Verify(decoded, encoded, salt string) (isValid bool)
+		NeedsRehash(encoded string) bool
+	}
+	// hasher is one password hashing algorithm. Hashes are self describing: they carry the algorithm and the
+	// parameters used, so any known hasher can verify them regardless of the current options.
+	hasher interface {
+		encode(password string) (string, error)
+		verify(password, encoded string) bool
+		recognizes(encoded string) bool
+		upToDate(encoded string) bool
 	}
 	secretImpl struct {
+		current hasher
+		hashers []hasher
 	}
 )
 
-func New() Secret {
-	return &secretImpl{}
+func New(opts Options) (Secret, error) {
+	if opts.Algorithm == "" {
+		opts.Algorithm = AlgorithmArgon2id
+	}
+
+	argon2idHasher := newArgon2idHasher(opts.Argon2Time, opts.Argon2Memory, opts.Argon2Threads)
+	bcryptHasher, err := newBcryptHasher(opts.BcryptCost)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &secretImpl{
+		hashers: []hasher{argon2idHasher, bcryptHasher},
+	}
+	switch opts.Algorithm {
+	case AlgorithmArgon2id:
+		s.current = argon2idHasher
+	case AlgorithmBcrypt:
+		s.current = bcryptHasher
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAlgorithm, opts.Algorithm)
+	}
+	return s, nil
 }
 
-func (s *secretImpl) Encode(password, salt string) string {
-	return hash(password + salt)
+func (s *secretImpl) Encode(password string) (string, error) {
+	return s.current.encode(password)
 }
 
+// Verify checks decoded against encoded. The salt is only used by the legacy salted SHA-512 hashes, which kept it
+// in its own column.
 func (s *secretImpl) Verify(decoded, encoded, salt string) bool {
-	return encoded == s.Encode(decoded, salt)
+	for _, h := range s.hashers {
+		if h.recognizes(encoded) {
+			return h.verify(decoded, encoded)
+		}
+	}
+	return verifyLegacy(decoded, encoded, salt)
 }
 
-func (s *secretImpl) GenSalt() string {
-	b := make([]byte, 16)
-	rand.Seed(time.Now().UnixNano())
-	rand.Read(b)
-	r := fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
-	return hash(r)
+// NeedsRehash tells whether encoded was not produced by the current algorithm with the current parameters.
+func (s *secretImpl) NeedsRehash(encoded string) bool {
+	return !s.current.recognizes(encoded) || !s.current.upToDate(encoded)
 }
 
-func hash(s string) string {
-	h := sha512.New()
-	h.Write([]byte(s))
-	bs := h.Sum(nil)
-	return fmt.Sprintf("%x", bs)
+func verifyLegacy(password, encoded, salt string) bool {
+	sum := sha512.Sum512([]byte(password + salt))
+	return subtle.ConstantTimeCompare([]byte(fmt.Sprintf("%x", sum)), []byte(encoded)) == 1
 }
@@ -0,0 +1,45 @@
+package secret
+
+import (
+	"golang.org/x/crypto/bcrypt"
+	"strings"
+)
+
+const defaultBcryptCost = 12
+
+type bcryptHasher struct {
+	cost int
+}
+
+func newBcryptHasher(cost int) (*bcryptHasher, error) {
+	if cost == 0 {
+		cost = defaultBcryptCost
+	}
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		return nil, bcrypt.InvalidCostError(cost)
+	}
+	return &bcryptHasher{cost: cost}, nil
+}
+
+// encode returns the hash in the modular crypt format, $2a$<cost>$<salt and key>. bcrypt draws its salt from
+// crypto/rand.
+func (h *bcryptHasher) encode(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+func (h *bcryptHasher) verify(password, encoded string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password)) == nil
+}
+
+func (h *bcryptHasher) recognizes(encoded string) bool {
+	return strings.HasPrefix(encoded, "$2a$") || strings.HasPrefix(encoded, "$2b$") || strings.HasPrefix(encoded, "$2y$")
+}
+
+func (h *bcryptHasher) upToDate(encoded string) bool {
+	cost, err := bcrypt.Cost([]byte(encoded))
+	return err == nil && cost == h.cost
+}
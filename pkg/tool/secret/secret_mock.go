@@ -34,31 +34,32 @@ func (m *MockSecret) EXPECT() *MockSecretMockRecorder {
 }
 
 // Encode mocks base method.
-func (m *MockSecret) Encode(password, salt string) string {
+func (m *MockSecret) Encode(password string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Encode", password, salt)
+	ret := m.ctrl.Call(m, "Encode", password)
 	ret0, _ := ret[0].(string)
-	return ret0
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Encode indicates an expected call of Encode.
-func (mr *MockSecretMockRecorder) Encode(password, salt interface{}) *gomock.Call {
+func (mr *MockSecretMockRecorder) Encode(password interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Encode", reflect.TypeOf((*MockSecret)(nil).Encode), password, salt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Encode", reflect.TypeOf((*MockSecret)(nil).Encode), password)
 }
 
-// GenSalt mocks base method.
-func (m *MockSecret) GenSalt() string {
+// NeedsRehash mocks base method.
+func (m *MockSecret) NeedsRehash(encoded string) bool {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GenSalt")
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "NeedsRehash", encoded)
+	ret0, _ := ret[0].(bool)
 	return ret0
 }
 
-// GenSalt indicates an expected call of GenSalt.
-func (mr *MockSecretMockRecorder) GenSalt() *gomock.Call {
+// NeedsRehash indicates an expected call of NeedsRehash.
+func (mr *MockSecretMockRecorder) NeedsRehash(encoded interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenSalt", reflect.TypeOf((*MockSecret)(nil).GenSalt))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NeedsRehash", reflect.TypeOf((*MockSecret)(nil).NeedsRehash), encoded)
 }
 
 // Verify mocks base method.
@@ -74,3 +75,83 @@ func (mr *MockSecretMockRecorder) Verify(decoded, encoded, salt interface{}) *go
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockSecret)(nil).Verify), decoded, encoded, salt)
 }
+
+// Mockhasher is a mock of hasher interface.
+type Mockhasher struct {
+	ctrl     *gomock.Controller
+	recorder *MockhasherMockRecorder
+}
+
+// MockhasherMockRecorder is the mock recorder for Mockhasher.
+type MockhasherMockRecorder struct {
+	mock *Mockhasher
+}
+
+// NewMockhasher creates a new mock instance.
+func NewMockhasher(ctrl *gomock.Controller) *Mockhasher {
+	mock := &Mockhasher{ctrl: ctrl}
+	mock.recorder = &MockhasherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockhasher) EXPECT() *MockhasherMockRecorder {
+	return m.recorder
+}
+
+// encode mocks base method.
+func (m *Mockhasher) encode(password string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "encode", password)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// encode indicates an expected call of encode.
+func (mr *MockhasherMockRecorder) encode(password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "encode", reflect.TypeOf((*Mockhasher)(nil).encode), password)
+}
+
+// recognizes mocks base method.
+func (m *Mockhasher) recognizes(encoded string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "recognizes", encoded)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// recognizes indicates an expected call of recognizes.
+func (mr *MockhasherMockRecorder) recognizes(encoded interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "recognizes", reflect.TypeOf((*Mockhasher)(nil).recognizes), encoded)
+}
+
+// upToDate mocks base method.
+func (m *Mockhasher) upToDate(encoded string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "upToDate", encoded)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// upToDate indicates an expected call of upToDate.
+func (mr *MockhasherMockRecorder) upToDate(encoded interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "upToDate", reflect.TypeOf((*Mockhasher)(nil).upToDate), encoded)
+}
+
+// verify mocks base method.
+func (m *Mockhasher) verify(password, encoded string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "verify", password, encoded)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// verify indicates an expected call of verify.
+func (mr *MockhasherMockRecorder) verify(password, encoded interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "verify", reflect.TypeOf((*Mockhasher)(nil).verify), password, encoded)
+}
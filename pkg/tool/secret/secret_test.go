@@ -0,0 +1,192 @@
+package secret
+
+import (
+	"crypto/sha512"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+	"strings"
+	"testing"
+)
+
+var (
+	argon2idOptions = Options{Algorithm: AlgorithmArgon2id, Argon2Time: 1, Argon2Memory: 1024, Argon2Threads: 1}
+	bcryptOptions   = Options{Algorithm: AlgorithmBcrypt, BcryptCost: bcrypt.MinCost}
+)
+
+func TestNew(t *testing.T) {
+	cases := map[string]struct {
+		InputOptions  Options
+		ExpectedError string
+	}{
+		"should return success with default options": {
+			InputOptions:  Options{},
+			ExpectedError: "",
+		},
+		"should return success with bcrypt": {
+			InputOptions:  bcryptOptions,
+			ExpectedError: "",
+		},
+		"should return error: unknown algorithm": {
+			InputOptions:  Options{Algorithm: "md5"},
+			ExpectedError: "secret: unknown algorithm: md5",
+		},
+		"should return error: invalid bcrypt cost": {
+			InputOptions:  Options{Algorithm: AlgorithmBcrypt, BcryptCost: bcrypt.MaxCost + 1},
+			ExpectedError: bcrypt.InvalidCostError(bcrypt.MaxCost + 1).Error(),
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			s, err := New(cs.InputOptions)
+			if cs.ExpectedError != "" {
+				assert.EqualError(t, err, cs.ExpectedError)
+				assert.Nil(t, s)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, s)
+		})
+	}
+}
+
+func TestEncodeAndVerify(t *testing.T) {
+	cases := map[string]struct {
+		InputOptions   Options
+		ExpectedPrefix string
+	}{
+		"should encode with argon2id": {
+			InputOptions:   argon2idOptions,
+			ExpectedPrefix: "$argon2id$v=19$m=1024,t=1,p=1$",
+		},
+		"should encode with bcrypt": {
+			InputOptions:   bcryptOptions,
+			ExpectedPrefix: "$2a$04$",
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			s, err := New(cs.InputOptions)
+			assert.NoError(t, err)
+
+			encoded, err := s.Encode("secret")
+			assert.NoError(t, err)
+			assert.True(t, strings.HasPrefix(encoded, cs.ExpectedPrefix), encoded)
+
+			again, err := s.Encode("secret")
+			assert.NoError(t, err)
+			assert.NotEqual(t, encoded, again, "salts must differ between hashes")
+
+			assert.True(t, s.Verify("secret", encoded, ""))
+			assert.False(t, s.Verify("wrong", encoded, ""))
+			assert.False(t, s.NeedsRehash(encoded))
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	argon2idSecret, err := New(argon2idOptions)
+	assert.NoError(t, err)
+	bcryptSecret, err := New(bcryptOptions)
+	assert.NoError(t, err)
+
+	argon2idHash, err := argon2idSecret.Encode("secret")
+	assert.NoError(t, err)
+	bcryptHash, err := bcryptSecret.Encode("secret")
+	assert.NoError(t, err)
+
+	cases := map[string]struct {
+		InputDecoded  string
+		InputEncoded  string
+		InputSalt     string
+		ExpectedValid bool
+	}{
+		"should verify argon2id hash": {
+			InputDecoded:  "secret",
+			InputEncoded:  argon2idHash,
+			ExpectedValid: true,
+		},
+		"should verify bcrypt hash": {
+			InputDecoded:  "secret",
+			InputEncoded:  bcryptHash,
+			ExpectedValid: true,
+		},
+		"should verify legacy hash": {
+			InputDecoded:  "secret",
+			InputEncoded:  fmt.Sprintf("%x", sha512.Sum512([]byte("secret"+"salt"))),
+			InputSalt:     "salt",
+			ExpectedValid: true,
+		},
+		"should refuse legacy hash with another salt": {
+			InputDecoded:  "secret",
+			InputEncoded:  fmt.Sprintf("%x", sha512.Sum512([]byte("secret"+"salt"))),
+			InputSalt:     "another_salt",
+			ExpectedValid: false,
+		},
+		"should refuse malformed argon2id hash": {
+			InputDecoded:  "secret",
+			InputEncoded:  "$argon2id$v=19$m=1024",
+			ExpectedValid: false,
+		},
+		"should refuse argon2id hash of another version": {
+			InputDecoded:  "secret",
+			InputEncoded:  strings.Replace(argon2idHash, "v=19", "v=16", 1),
+			ExpectedValid: false,
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, cs.ExpectedValid, argon2idSecret.Verify(cs.InputDecoded, cs.InputEncoded, cs.InputSalt))
+		})
+	}
+}
+
+func TestNeedsRehash(t *testing.T) {
+	s, err := New(argon2idOptions)
+	assert.NoError(t, err)
+	stronger, err := New(Options{Algorithm: AlgorithmArgon2id, Argon2Time: 2, Argon2Memory: 1024, Argon2Threads: 1})
+	assert.NoError(t, err)
+	bcryptSecret, err := New(bcryptOptions)
+	assert.NoError(t, err)
+
+	current, err := s.Encode("secret")
+	assert.NoError(t, err)
+	bcryptHash, err := bcryptSecret.Encode("secret")
+	assert.NoError(t, err)
+
+	cases := map[string]struct {
+		InputSecret   Secret
+		InputEncoded  string
+		ExpectedValue bool
+	}{
+		"should not rehash current hash": {
+			InputSecret:   s,
+			InputEncoded:  current,
+			ExpectedValue: false,
+		},
+		"should rehash hash with weaker parameters": {
+			InputSecret:   stronger,
+			InputEncoded:  current,
+			ExpectedValue: true,
+		},
+		"should rehash hash of another algorithm": {
+			InputSecret:   s,
+			InputEncoded:  bcryptHash,
+			ExpectedValue: true,
+		},
+		"should rehash legacy hash": {
+			InputSecret:   s,
+			InputEncoded:  fmt.Sprintf("%x", sha512.Sum512([]byte("secret"+"salt"))),
+			ExpectedValue: true,
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, cs.ExpectedValue, cs.InputSecret.NeedsRehash(cs.InputEncoded))
+		})
+	}
+}
@@ -0,0 +1,145 @@
+//go:generate mockgen -source=${GOFILE} -package=${GOPACKAGE} -destination=${GOPACKAGE}_mock.go
+
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	secretSize     = 20
+	defaultIssuer  = "Bank API"
+	defaultPeriod  = 30 * time.Second
+	defaultDigits  = 6
+	defaultSkew    = 1
+	maxCodeDigits  = 8
+	uriPathPrefix  = "otpauth://totp/"
+	hashAlgorithm  = "SHA1"
+	codeSeparators = " -"
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+type (
+	// Options configures the codes as described by RFC 6238. Skew is how many periods before and after the current
+	// one are still accepted, to tolerate clock drift. Zero values fall back to the defaults, which are the ones
+	// authenticator apps expect.
+	Options struct {
+		Issuer string
+		Period time.Duration
+		Digits int
+		Skew   int64
+	}
+	TOTP interface {
+		GenerateSecret() (string, error)
+		URI(secret string, accountName string) string
+		Validate(secret string, code string, at time.Time) (step int64, valid bool)
+		Window() time.Duration
+	}
+	totpImpl struct {
+		issuer string
+		period time.Duration
+		digits int
+		skew   int64
+	}
+)
+
+func New(opts Options) TOTP {
+	if opts.Issuer == "" {
+		opts.Issuer = defaultIssuer
+	}
+	if opts.Period == 0 {
+		opts.Period = defaultPeriod
+	}
+	if opts.Digits == 0 || opts.Digits > maxCodeDigits {
+		opts.Digits = defaultDigits
+	}
+	if opts.Skew == 0 {
+		opts.Skew = defaultSkew
+	}
+	return &totpImpl{
+		issuer: opts.Issuer,
+		period: opts.Period,
+		digits: opts.Digits,
+		skew:   opts.Skew,
+	}
+}
+
+// GenerateSecret returns a new random secret, base32 encoded as authenticator apps expect it.
+func (t *totpImpl) GenerateSecret() (string, error) {
+	b := make([]byte, secretSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return encoding.EncodeToString(b), nil
+}
+
+// URI returns the otpauth:// provisioning uri of the secret, usually shown as a qr code.
+func (t *totpImpl) URI(secret string, accountName string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", t.issuer)
+	query.Set("algorithm", hashAlgorithm)
+	query.Set("digits", fmt.Sprint(t.digits))
+	query.Set("period", fmt.Sprint(int64(t.period/time.Second)))
+	label := url.PathEscape(t.issuer) + ":" + url.PathEscape(accountName)
+	return uriPathPrefix + label + "?" + query.Encode()
+}
+
+// Validate tells whether code is the one of secret at a time step within the skew around at, returning the step it
+// matched. Callers must refuse a step already used, so a code can not be replayed.
+func (t *totpImpl) Validate(secret string, code string, at time.Time) (int64, bool) {
+	code = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(codeSeparators, r) {
+			return -1
+		}
+		return r
+	}, code)
+	if len(code) != t.digits {
+		return 0, false
+	}
+	key, err := encoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil {
+		return 0, false
+	}
+
+	current := at.Unix() / int64(t.period/time.Second)
+	for step := current - t.skew; step <= current+t.skew; step++ {
+		if subtle.ConstantTimeCompare([]byte(t.generate(key, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// Window returns for how long a code may be accepted, counting the skew on both sides.
+func (t *totpImpl) Window() time.Duration {
+	return time.Duration(2*t.skew+1) * t.period
+}
+
+func (t *totpImpl) generate(key []byte, step int64) string {
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < t.digits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", t.digits, value%mod)
+}
@@ -0,0 +1,137 @@
+package totp
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// rfcSecret is the secret of the test vectors of RFC 6238, "12345678901234567890" encoded in base32.
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestValidate(t *testing.T) {
+	var (
+		rfcTOTP     = New(Options{Digits: 8})
+		defaultTOTP = New(Options{})
+	)
+
+	cases := map[string]struct {
+		InputTOTP     TOTP
+		InputSecret   string
+		InputCode     string
+		InputTime     time.Time
+		ExpectedStep  int64
+		ExpectedValid bool
+	}{
+		"should return success: rfc vector 59": {
+			InputTOTP:     rfcTOTP,
+			InputSecret:   rfcSecret,
+			InputCode:     "94287082",
+			InputTime:     time.Unix(59, 0),
+			ExpectedStep:  1,
+			ExpectedValid: true,
+		},
+		"should return success: rfc vector 1111111109": {
+			InputTOTP:     rfcTOTP,
+			InputSecret:   rfcSecret,
+			InputCode:     "07081804",
+			InputTime:     time.Unix(1111111109, 0),
+			ExpectedStep:  37037036,
+			ExpectedValid: true,
+		},
+		"should return success: rfc vector 1234567890": {
+			InputTOTP:     rfcTOTP,
+			InputSecret:   rfcSecret,
+			InputCode:     "8900 5924",
+			InputTime:     time.Unix(1234567890, 0),
+			ExpectedStep:  41152263,
+			ExpectedValid: true,
+		},
+		"should return success: previous period within skew": {
+			InputTOTP:     rfcTOTP,
+			InputSecret:   rfcSecret,
+			InputCode:     "94287082",
+			InputTime:     time.Unix(89, 0),
+			ExpectedStep:  1,
+			ExpectedValid: true,
+		},
+		"should return success: six digits": {
+			InputTOTP:     defaultTOTP,
+			InputSecret:   rfcSecret,
+			InputCode:     "287082",
+			InputTime:     time.Unix(59, 0),
+			ExpectedStep:  1,
+			ExpectedValid: true,
+		},
+		"should return error: outside skew": {
+			InputTOTP:     rfcTOTP,
+			InputSecret:   rfcSecret,
+			InputCode:     "94287082",
+			InputTime:     time.Unix(120, 0),
+			ExpectedValid: false,
+		},
+		"should return error: wrong code": {
+			InputTOTP:     defaultTOTP,
+			InputSecret:   rfcSecret,
+			InputCode:     "123456",
+			InputTime:     time.Unix(59, 0),
+			ExpectedValid: false,
+		},
+		"should return error: wrong length": {
+			InputTOTP:     defaultTOTP,
+			InputSecret:   rfcSecret,
+			InputCode:     "28708",
+			InputTime:     time.Unix(59, 0),
+			ExpectedValid: false,
+		},
+		"should return error: invalid secret": {
+			InputTOTP:     defaultTOTP,
+			InputSecret:   "not base32!",
+			InputCode:     "287082",
+			InputTime:     time.Unix(59, 0),
+			ExpectedValid: false,
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			step, valid := cs.InputTOTP.Validate(cs.InputSecret, cs.InputCode, cs.InputTime)
+
+			assert.Equal(t, cs.ExpectedValid, valid)
+			assert.Equal(t, cs.ExpectedStep, step)
+		})
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	totp := New(Options{})
+
+	secret, err := totp.GenerateSecret()
+	assert.NoError(t, err)
+	assert.Len(t, secret, 32)
+
+	another, err := totp.GenerateSecret()
+	assert.NoError(t, err)
+	assert.NotEqual(t, secret, another)
+}
+
+func TestURI(t *testing.T) {
+	uri, err := url.Parse(New(Options{Issuer: "Bank API"}).URI(rfcSecret, "12312312312"))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "otpauth", uri.Scheme)
+	assert.Equal(t, "totp", uri.Host)
+	assert.Equal(t, "/Bank API:12312312312", uri.Path)
+	assert.Equal(t, url.Values{
+		"secret":    {rfcSecret},
+		"issuer":    {"Bank API"},
+		"algorithm": {"SHA1"},
+		"digits":    {"6"},
+		"period":    {"30"},
+	}, uri.Query())
+}
+
+func TestWindow(t *testing.T) {
+	assert.Equal(t, 90*time.Second, New(Options{}).Window())
+}
@@ -4,7 +4,18 @@ package transaction
 
 import (
 	"context"
+	"errors"
 	"github.com/carlosrodriguesf/bank-api/pkg/tool/db"
+	"github.com/lib/pq"
+	"time"
+)
+
+const (
+	maxAttempts      = 5
+	retryBackoffUnit = 10 * time.Millisecond
+
+	pqCodeSerializationFailure = "40001"
+	pqCodeDeadlockDetected     = "40P01"
 )
 
 type (
@@ -13,6 +24,7 @@ type (
 		Create(ctx context.Context) (Transaction, error)
 		Commit(tx Transaction) error
 		Rollback(tx Transaction) error
+		Execute(ctx context.Context, fn func(tx Transaction) error) error
 	}
 	manager struct {
 		db db.ExtendedDB
@@ -36,3 +48,43 @@ func (r *manager) Commit(tx Transaction) error {
 func (r *manager) Rollback(tx Transaction) error {
 	return tx.Rollback()
 }
+
+// Execute runs fn inside a transaction, committing when it succeeds and rolling back otherwise.
+// The whole transaction is retried when postgres aborts it due to a serialization failure or a deadlock,
+// so fn must not keep side effects outside the transaction between attempts.
+func (r *manager) Execute(ctx context.Context, fn func(tx Transaction) error) error {
+	for attempt := 1; ; attempt++ {
+		err := r.execute(ctx, fn)
+		if err == nil || attempt == maxAttempts || !isRetryable(err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(attempt) * retryBackoffUnit):
+		}
+	}
+}
+
+func (r *manager) execute(ctx context.Context, fn func(tx Transaction) error) error {
+	tx, err := r.Create(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err = fn(tx); err != nil {
+		_ = r.Rollback(tx)
+		return err
+	}
+
+	return r.Commit(tx)
+}
+
+func isRetryable(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	return pqErr.Code == pqCodeSerializationFailure || pqErr.Code == pqCodeDeadlockDetected
+}
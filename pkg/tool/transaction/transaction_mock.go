@@ -63,6 +63,20 @@ func (mr *MockManagerMockRecorder) Create(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockManager)(nil).Create), ctx)
 }
 
+// Execute mocks base method.
+func (m *MockManager) Execute(ctx context.Context, fn func(Transaction) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockManagerMockRecorder) Execute(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockManager)(nil).Execute), ctx, fn)
+}
+
 // Rollback mocks base method.
 func (m *MockManager) Rollback(tx Transaction) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,110 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/db"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/test"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestExecute(t *testing.T) {
+	var (
+		errSerialization = &pq.Error{Code: pqCodeSerializationFailure}
+		errDeadlock      = &pq.Error{Code: pqCodeDeadlockDetected}
+	)
+
+	cases := map[string]struct {
+		FnErrors       []error
+		ExpectedCalls  int
+		ExpectedError  error
+		PrepareMockSQL func(mock sqlmock.Sqlmock)
+	}{
+		"should commit": {
+			FnErrors:      []error{nil},
+			ExpectedCalls: 1,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectCommit()
+			},
+		},
+		"should rollback and not retry": {
+			FnErrors:      []error{errors.New("fail")},
+			ExpectedCalls: 1,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+		},
+		"should retry on serialization failure and deadlock": {
+			FnErrors:      []error{errSerialization, errDeadlock, nil},
+			ExpectedCalls: 3,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+				mock.ExpectBegin()
+				mock.ExpectCommit()
+			},
+		},
+		"should retry when commit fails with serialization failure": {
+			FnErrors:      []error{nil, nil},
+			ExpectedCalls: 2,
+			ExpectedError: nil,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectCommit().WillReturnError(errSerialization)
+				mock.ExpectBegin()
+				mock.ExpectCommit()
+			},
+		},
+		"should give up after max attempts": {
+			FnErrors:      []error{errDeadlock, errDeadlock, errDeadlock, errDeadlock, errDeadlock},
+			ExpectedCalls: maxAttempts,
+			ExpectedError: errDeadlock,
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				for i := 0; i < maxAttempts; i++ {
+					mock.ExpectBegin()
+					mock.ExpectRollback()
+				}
+			},
+		},
+		"should return error on begin": {
+			FnErrors:      nil,
+			ExpectedCalls: 0,
+			ExpectedError: errors.New("fail"),
+			PrepareMockSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin().WillReturnError(errors.New("fail"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				conn, sqlMock = test.GetSQLMock()
+				manager       = NewManager(db.NewExtendedDB(conn))
+				calls         = 0
+			)
+
+			cs.PrepareMockSQL(sqlMock)
+
+			err := manager.Execute(context.Background(), func(tx Transaction) error {
+				err := cs.FnErrors[calls]
+				calls++
+				return err
+			})
+
+			assert.Equal(t, cs.ExpectedError, err)
+			assert.Equal(t, cs.ExpectedCalls, calls)
+			assert.NoError(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package worker
+
+import (
+	"context"
+	"github.com/carlosrodriguesf/bank-api/pkg/app"
+	"github.com/carlosrodriguesf/bank-api/pkg/tool/logger"
+	"time"
+)
+
+type (
+	Options struct {
+		Logger   logger.Logger
+		App      app.Container
+		Interval time.Duration
+	}
+	job func(ctx context.Context) error
+)
+
+// Start runs the background jobs of the service, each one every opts.Interval, until ctx is done.
+func Start(ctx context.Context, opts Options) {
+	log := opts.Logger.WithPreffix("worker")
+
+	go run(ctx, log.WithPreffix("worker.schedule"), opts.Interval, opts.App.Schedule().ExecuteDue)
+	go run(ctx, log.WithPreffix("worker.recurrence"), opts.Interval, opts.App.Recurrence().ExecuteDue)
+	go run(ctx, log.WithPreffix("worker.hold"), opts.Interval, opts.App.Transfer().ExpireHolds)
+
+	log.Info("started")
+}
+
+func run(ctx context.Context, log logger.Logger, interval time.Duration, fn job) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := fn(ctx); err != nil {
+				log.Error(err)
+			}
+		}
+	}
+}